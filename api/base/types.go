@@ -164,4 +164,8 @@ type StoredCredential struct {
 
 	// Valid is a flag that indicates whether the credential is valid.
 	Valid bool
+
+	// Missing is a flag that indicates that the credential is referenced
+	// by the model, but is no longer stored on the controller.
+	Missing bool
 }
@@ -61,6 +61,22 @@ func (c *Client) GetChangesMapArgs(bundleURL, bundleDataYAML string) (params.Bun
 	return result, nil
 }
 
+// Validate returns any problems found verifying the given bundle data,
+// without computing the changes required to deploy the bundle.
+func (c *Client) Validate(bundleURL, bundleDataYAML string) (params.BundleValidationResults, error) {
+	var result params.BundleValidationResults
+	if bestVer := c.BestAPIVersion(); bestVer < 5 {
+		return result, errors.Errorf("this controller version does not support bundle validation.")
+	}
+	if err := c.facade.FacadeCall("Validate", params.BundleChangesParams{
+		BundleURL:      bundleURL,
+		BundleDataYAML: bundleDataYAML,
+	}, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // ExportBundle exports the current model configuration.
 func (c *Client) ExportBundle() (string, error) {
 	var result params.StringResult
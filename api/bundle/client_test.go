@@ -268,6 +268,56 @@ func (s *bundleMockSuite) TestGetChangesMapArgsV3(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this controller version does not support bundle get changes as map args feature.")
 }
 
+func (s *bundleMockSuite) TestValidate(c *gc.C) {
+	bundleURL := "cs:bundle-url"
+	bundleYAML := `applications:
+	ubuntu:
+		charm: cs:trusty/ubuntu
+		num_units: -1`
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Validate")
+			c.Assert(args, gc.Equals, params.BundleChangesParams{
+				BundleDataYAML: bundleYAML,
+				BundleURL:      bundleURL,
+			})
+			result := response.(*params.BundleValidationResults)
+			result.Errors = []string{
+				`negative number of units specified on application "ubuntu"`,
+			}
+			return nil
+		}, 5,
+	)
+	result, err := client.Validate(bundleURL, bundleYAML)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Errors, gc.DeepEquals, []string{`negative number of units specified on application "ubuntu"`})
+}
+
+func (s *bundleMockSuite) TestValidateV4(c *gc.C) {
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		}, 4,
+	)
+	_, err := client.Validate("", "")
+	c.Assert(err, gc.ErrorMatches, "this controller version does not support bundle validation.")
+}
+
 func (s *bundleMockSuite) TestFailExportBundlev1(c *gc.C) {
 	client := newClient(
 		func(objType string,
@@ -43,6 +43,53 @@ func (c *Client) WatchApplications() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchApplicationsLife returns a StringsWatcher that notifies of
+// changes to the lifecycles of CAAS applications in the current model,
+// along with the life of each application in the watcher's initial event.
+// Applications removed before their life could be read are reported as dead.
+func (c *Client) WatchApplicationsLife() (watcher.StringsWatcher, map[string]life.Value, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, nil, errors.NotSupportedf("watching applications with life on this version of Juju")
+	}
+	var result params.StringsLifeWatchResult
+	if err := c.facade.FacadeCall("WatchApplicationsLife", nil, &result); err != nil {
+		return nil, nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), params.StringsWatchResult{
+		StringsWatcherId: result.StringsWatcherId,
+		Changes:          result.Changes,
+	})
+	lives, err := appLives(result.Changes, result.Life)
+	if err != nil {
+		w.Kill()
+		_ = w.Wait()
+		return nil, nil, errors.Trace(err)
+	}
+	return w, lives, nil
+}
+
+// appLives correlates the input application names with their life results.
+func appLives(appNames []string, results []params.LifeResult) (map[string]life.Value, error) {
+	if len(results) != len(appNames) {
+		return nil, errors.Errorf("expected %d life result(s), got %d", len(appNames), len(results))
+	}
+	lives := make(map[string]life.Value, len(appNames))
+	for i, appName := range appNames {
+		if results[i].Error == nil {
+			lives[appName] = results[i].Life
+			continue
+		}
+		if err := maybeNotFound(results[i].Error); !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "getting life of application %q", appName)
+		}
+		lives[appName] = life.Dead
+	}
+	return lives, nil
+}
+
 // ApplicationPassword holds parameters for setting
 // an application password.
 type ApplicationPassword struct {
@@ -181,3 +228,23 @@ func (c *Client) IssueOperatorCertificate(applicationName string) (OperatorCerti
 		PrivateKey: certInfo.PrivateKey,
 	}, nil
 }
+
+// CheckOperatorImage returns an error if the operator image with the
+// input path can not be pulled from its registry. An error satisfying
+// errors.IsNotFound is returned if the image does not exist, and one
+// satisfying errors.IsNotSupported if the controller can not perform
+// the check.
+func (c *Client) CheckOperatorImage(path string) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("checking operator images on this version of Juju")
+	}
+	args := params.CheckOperatorImageArgs{ImagePath: path}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("CheckOperatorImage", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return maybeNotFound(result.Error)
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/storage"
 )
 
@@ -47,6 +48,61 @@ func (s *provisionerSuite) TestWatchApplications(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 }
 
+func (s *provisionerSuite) TestWatchApplicationsLife(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		if objType == "StringsWatcher" {
+			if request != "Next" && request != "Stop" {
+				c.Fatalf("unexpected watcher request %q", request)
+			}
+			return nil
+		}
+		c.Check(objType, gc.Equals, "CAASOperatorProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "WatchApplicationsLife")
+		c.Assert(a, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.StringsLifeWatchResult{})
+		*(result.(*params.StringsLifeWatchResult)) = params.StringsLifeWatchResult{
+			StringsWatcherId: "1",
+			Changes:          []string{"gitlab", "mysql", "gone"},
+			Life: []params.LifeResult{
+				{Life: life.Alive},
+				{Life: life.Dying},
+				{Error: &params.Error{Code: params.CodeNotFound, Message: "application gone not found"}},
+			},
+		}
+		return nil
+	})
+	w, lives, err := client.WatchApplicationsLife()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lives, jc.DeepEquals, map[string]life.Value{
+		"gitlab": life.Alive,
+		"mysql":  life.Dying,
+		"gone":   life.Dead,
+	})
+
+	wc := watchertest.NewStringsWatcherC(c, w, nil)
+	defer wc.AssertStops()
+	wc.AssertChange("gitlab", "mysql", "gone")
+}
+
+func (s *provisionerSuite) TestWatchApplicationsLifeError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		if objType == "StringsWatcher" {
+			return nil
+		}
+		*(result.(*params.StringsLifeWatchResult)) = params.StringsLifeWatchResult{
+			StringsWatcherId: "1",
+			Changes:          []string{"gitlab"},
+			Life: []params.LifeResult{
+				{Error: &params.Error{Message: "FAIL"}},
+			},
+		}
+		return nil
+	})
+	_, _, err := client.WatchApplicationsLife()
+	c.Assert(err, gc.ErrorMatches, `getting life of application "gitlab": FAIL`)
+}
+
 func (s *provisionerSuite) TestSetPasswords(c *gc.C) {
 	passwords := []caasoperatorprovisioner.ApplicationPassword{
 		{Name: "app", Password: "secret"},
@@ -249,3 +305,48 @@ func (s *provisionerSuite) TestIssueOperatorCertificateArity(c *gc.C) {
 	_, err := client.IssueOperatorCertificate("appymcappface")
 	c.Assert(err, gc.ErrorMatches, "expected one result, got 0")
 }
+
+func (s *provisionerSuite) TestCheckOperatorImage(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "CAASOperatorProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "CheckOperatorImage")
+		c.Assert(a, jc.DeepEquals, params.CheckOperatorImageArgs{
+			ImagePath: "jujusolutions/jujud-operator:2.9.0",
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResult{})
+		return nil
+	})
+	err := client.CheckOperatorImage("jujusolutions/jujud-operator:2.9.0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *provisionerSuite) TestCheckOperatorImageNotFound(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		*(result.(*params.ErrorResult)) = params.ErrorResult{
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `checking operator image: image "jujusolutions/jujud-operator:6.6.6" not found`,
+			},
+		}
+		return nil
+	})
+	err := client.CheckOperatorImage("jujusolutions/jujud-operator:6.6.6")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `checking operator image: image "jujusolutions/jujud-operator:6.6.6" not found`)
+}
+
+func (s *provisionerSuite) TestCheckOperatorImageNotSupported(c *gc.C) {
+	client := caasoperatorprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		},
+		BestVersion: 1,
+	})
+	err := client.CheckOperatorImage("jujusolutions/jujud-operator:2.9.0")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/storage"
@@ -80,6 +81,29 @@ func (c *Client) ApplicationConfig(applicationName string) (application.ConfigAt
 	return application.ConfigAttributes(results.Results[0].Config), nil
 }
 
+// ApplicationServiceHash returns the hash of the service definition
+// last applied to the cloud for the specified application.
+func (c *Client) ApplicationServiceHash(applicationName string) (string, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return "", errors.NotSupportedf("service hashes on this version of Juju")
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(applicationName).String()}},
+	}
+	err := c.facade.FacadeCall("ApplicationsServiceHash", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != len(args.Entities) {
+		return "", errors.Errorf("expected %d result(s), got %d", len(args.Entities), len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
 // WatchApplicationScale returns a NotifyWatcher that notifies of
 // changes to the lifecycles of units of the specified
 // CAAS application in the current model.
@@ -341,6 +365,76 @@ func (c *Client) UpdateApplicationService(arg params.UpdateApplicationServiceArg
 	return maybeNotFound(result.Results[0].Error)
 }
 
+// SetApplicationServiceAddresses replaces the recorded addresses of the
+// specified application's service. Passing no addresses clears them.
+func (c *Client) SetApplicationServiceAddresses(appName string, addresses network.ProviderAddresses) error {
+	if c.facade.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("setting service addresses on this version of Juju")
+	}
+	var result params.ErrorResults
+	args := params.SetApplicationServiceAddressesArgs{Args: []params.ApplicationServiceAddresses{{
+		ApplicationTag: names.NewApplicationTag(appName).String(),
+		Addresses:      params.FromProviderAddresses(addresses...),
+	}}}
+	if err := c.facade.FacadeCall("SetApplicationsServiceAddresses", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Args) {
+		return errors.Errorf("expected %d result(s), got %d", len(args.Args), len(result.Results))
+	}
+	if result.Results[0].Error == nil {
+		return nil
+	}
+	return maybeNotFound(result.Results[0].Error)
+}
+
+// StopUnits marks the units of the specified application that are
+// surplus to the scale it is about to be reduced to as dying, so that
+// they stop before their pods are removed. It returns the names of
+// the units marked.
+func (c *Client) StopUnits(appName string, scale int) ([]string, error) {
+	if c.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("stopping units on this version of Juju")
+	}
+	var result params.StringsResults
+	args := params.StopApplicationUnitsArgs{Args: []params.StopApplicationUnitsArg{{
+		ApplicationTag: names.NewApplicationTag(appName).String(),
+		Scale:          scale,
+	}}}
+	if err := c.facade.FacadeCall("StopApplicationsUnits", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Args) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args.Args), len(result.Results))
+	}
+	if err := result.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return result.Results[0].Result, nil
+}
+
+// SetApplicationServiceHash records the hash of the service definition
+// last applied to the cloud for the specified application.
+func (c *Client) SetApplicationServiceHash(appName, hash string) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("service hashes on this version of Juju")
+	}
+	var result params.ErrorResults
+	args := params.SetApplicationServiceHashArgs{Args: []params.EntityString{
+		{Tag: names.NewApplicationTag(appName).String(), Value: hash},
+	}}
+	if err := c.facade.FacadeCall("SetApplicationsServiceHash", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Args) {
+		return errors.Errorf("expected %d result(s), got %d", len(args.Args), len(result.Results))
+	}
+	if result.Results[0].Error == nil {
+		return nil
+	}
+	return maybeNotFound(result.Results[0].Error)
+}
+
 // SetOperatorStatus updates the provisioning status of an operator.
 func (c *Client) SetOperatorStatus(appName string, status status.Status, message string, data map[string]interface{}) error {
 	var result params.ErrorResults
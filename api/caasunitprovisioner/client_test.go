@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/storage"
 )
@@ -512,3 +513,147 @@ func (s *unitprovisionerSuite) TestClearApplicationResources(c *gc.C) {
 	err := client.ClearApplicationResources("gitlab")
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
+
+func (s *unitprovisionerSuite) TestApplicationServiceHash(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ApplicationsServiceHash")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringResults{})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{
+				Result: "deadbeef",
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 2})
+	hash, err := client.ApplicationServiceHash("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hash, gc.Equals, "deadbeef")
+}
+
+func (s *unitprovisionerSuite) TestServiceHashNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 1})
+	_, err := client.ApplicationServiceHash("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.SetApplicationServiceHash("gitlab", "deadbeef")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *unitprovisionerSuite) TestSetApplicationServiceHash(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetApplicationsServiceHash")
+		c.Assert(arg, jc.DeepEquals, params.SetApplicationServiceHashArgs{
+			Args: []params.EntityString{{
+				Tag:   "application-gitlab",
+				Value: "deadbeef",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 2})
+	err := client.SetApplicationServiceHash("gitlab", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *unitprovisionerSuite) TestSetApplicationServiceAddresses(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(version, gc.Equals, 3)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "SetApplicationsServiceAddresses")
+		c.Assert(arg, jc.DeepEquals, params.SetApplicationServiceAddressesArgs{
+			Args: []params.ApplicationServiceAddresses{{
+				ApplicationTag: "application-gitlab",
+				Addresses: []params.Address{{
+					Value: "203.0.113.1",
+					Type:  "ipv4",
+					Scope: "public",
+				}},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 3})
+	err := client.SetApplicationServiceAddresses("gitlab", network.NewProviderAddresses("203.0.113.1"))
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *unitprovisionerSuite) TestSetApplicationServiceAddressesNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 2})
+	err := client.SetApplicationServiceAddresses("gitlab", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *unitprovisionerSuite) TestStopUnits(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(version, gc.Equals, 4)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "StopApplicationsUnits")
+		c.Assert(arg, jc.DeepEquals, params.StopApplicationUnitsArgs{
+			Args: []params.StopApplicationUnitsArg{{
+				ApplicationTag: "application-gitlab",
+				Scale:          1,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Result: []string{"gitlab/2", "gitlab/1"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 4})
+	units, err := client.StopUnits("gitlab", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []string{"gitlab/2", "gitlab/1"})
+}
+
+func (s *unitprovisionerSuite) TestStopUnitsNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 3})
+	_, err := client.StopUnits("gitlab", 1)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/errors"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/names/v4"
+	"github.com/juju/os/v2/series"
 	"github.com/juju/version"
 	"gopkg.in/macaroon.v2"
 
@@ -552,6 +553,16 @@ func NewCharmDownloader(apiCaller base.APICaller) *downloader.Downloader {
 			if err != nil {
 				return nil, errors.Annotate(err, "did not receive a valid charm URL")
 			}
+			if charm.CharmHub.Matches(curl.Schema) {
+				reader, err := openCharmHubCharm(apiCaller, curl)
+				if err == nil {
+					return reader, nil
+				}
+				// The controller may be unable to reach CharmHub itself,
+				// in which case the copy it stored when the charm was
+				// added is used.
+				logger.Warningf("cannot download %q through the controller's CharmHub proxy: %v", curl, err)
+			}
 			reader, err := OpenCharm(apiCaller, curl)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -562,6 +573,27 @@ func NewCharmDownloader(apiCaller base.APICaller) *downloader.Downloader {
 	return dlr
 }
 
+// openCharmHubCharm streams out the identified CharmHub charm, which the
+// controller downloads from CharmHub on the agent's behalf.
+func openCharmHubCharm(apiCaller base.APICaller, curl *charm.URL) (io.ReadCloser, error) {
+	if curl.Revision < 0 {
+		return nil, errors.NotValidf("charm URL %q without revision", curl)
+	}
+	query := make(url.Values)
+	query.Add("arch", curl.Architecture)
+	query.Add("series", curl.Series)
+	if curl.Series != "" {
+		sys, err := series.GetOSFromSeries(curl.Series)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// CharmHub platforms are case sensitive.
+		query.Add("os", strings.ToLower(sys.String()))
+	}
+	uri := fmt.Sprintf("/charmhub/charms/%s/%d", url.PathEscape(curl.Name), curl.Revision)
+	return openURI(apiCaller, uri, query)
+}
+
 // UploadTools uploads tools at the specified location to the API server over HTTPS.
 func (c *Client) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
 	endpoint := fmt.Sprintf("/tools?binaryVersion=%s&series=%s", vers, strings.Join(additionalSeries, ","))
@@ -473,6 +473,31 @@ func (s *clientSuite) TestOpenCharmMissing(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `.*cannot get charm from state: charm "cs:quantal/spam-3" not found`)
 }
 
+func (s *clientSuite) TestCharmDownloaderUsesCharmHubProxy(c *gc.C) {
+	client := s.APIState.Client()
+	var query url.Values
+	defer fakeAPIEndpoint(c, client, modelEndpoint(c, s.APIState, "charmhub/charms/mysql/1"), "GET",
+		func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			_, _ = w.Write([]byte("charm-content"))
+		},
+	).Close()
+
+	dlr := api.NewCharmDownloader(s.APIState)
+	curl, err := url.Parse("ch:amd64/focal/mysql-1")
+	c.Assert(err, jc.ErrorIsNil)
+	reader, err := dlr.OpenBlob(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "charm-content")
+	c.Check(query.Get("arch"), gc.Equals, "amd64")
+	c.Check(query.Get("os"), gc.Equals, "ubuntu")
+	c.Check(query.Get("series"), gc.Equals, "focal")
+}
+
 func addLocalCharm(c *gc.C, client *api.Client, name string, force bool) (*charm.URL, *charm.CharmArchive, string) {
 	charmArchive := testcharms.Repo.CharmArchive(c.MkDir(), name)
 	curl := charm.MustParseURL(fmt.Sprintf("local:quantal/%s-%d", charmArchive.Meta().Name, charmArchive.Revision()))
@@ -62,13 +62,58 @@ func (a *LeadershipPinningAPI) PinnedLeadership() (map[string][]names.Tag, error
 	return pinned, nil
 }
 
+// PinnedEntity identifies an entity requiring pinned leadership
+// for an application, along with the reason it gave for the pin.
+type PinnedEntity struct {
+	Tag    names.Tag
+	Reason string
+}
+
+// PinnedLeadershipReasons returns a collection of application names for
+// which leadership is currently pinned, with the entities requiring each
+// application's pinned behaviour and the reasons they recorded.
+func (a *LeadershipPinningAPI) PinnedLeadershipReasons() (map[string][]PinnedEntity, error) {
+	var callResult params.PinnedLeadershipReasonsResult
+	err := a.facade.FacadeCall("PinnedLeadershipReasons", nil, &callResult)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	pinned := make(map[string][]PinnedEntity, len(callResult.Result))
+	for app, entities := range callResult.Result {
+		pinnedEntities := make([]PinnedEntity, len(entities))
+		for i, e := range entities {
+			tag, err := names.ParseTag(e.Entity)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			pinnedEntities[i] = PinnedEntity{Tag: tag, Reason: e.Reason}
+		}
+
+		pinned[app] = pinnedEntities
+	}
+	return pinned, nil
+}
+
 // PinMachineApplications pins leadership for applications represented by units
 // running on the local machine.
 // If the caller is not a machine agent, an error will be returned.
 // The return is a collection of applications determined to be running on the
 // machine, with the result of each individual pin operation.
 func (a *LeadershipPinningAPI) PinMachineApplications() (map[string]error, error) {
-	res, err := a.pinMachineAppsOps("PinMachineApplications")
+	res, err := a.pinMachineAppsOps("PinMachineApplications", nil)
+	return res, errors.Trace(err)
+}
+
+// PinMachineApplicationsWithReason is as for PinMachineApplications,
+// but records the input reason against the pins, so that it can be
+// retrieved later via PinnedLeadershipReasons.
+func (a *LeadershipPinningAPI) PinMachineApplicationsWithReason(reason string) (map[string]error, error) {
+	var args interface{}
+	if reason != "" {
+		args = params.PinApplicationsArgs{Reason: reason}
+	}
+	res, err := a.pinMachineAppsOps("PinMachineApplications", args)
 	return res, errors.Trace(err)
 }
 
@@ -78,15 +123,15 @@ func (a *LeadershipPinningAPI) PinMachineApplications() (map[string]error, error
 // The return is a collection of applications determined to be running on the
 // machine, with the result of each individual unpin operation.
 func (a *LeadershipPinningAPI) UnpinMachineApplications() (map[string]error, error) {
-	res, err := a.pinMachineAppsOps("UnpinMachineApplications")
+	res, err := a.pinMachineAppsOps("UnpinMachineApplications", nil)
 	return res, errors.Trace(err)
 }
 
-// pinMachineAppsOps makes a facade call to the input method name and
-// transforms the response into map.
-func (a *LeadershipPinningAPI) pinMachineAppsOps(callName string) (map[string]error, error) {
+// pinMachineAppsOps makes a facade call to the input method name with
+// the input arguments and transforms the response into map.
+func (a *LeadershipPinningAPI) pinMachineAppsOps(callName string, args interface{}) (map[string]error, error) {
 	var callResult params.PinApplicationsResults
-	err := a.facade.FacadeCall(callName, nil, &callResult)
+	err := a.facade.FacadeCall(callName, args, &callResult)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
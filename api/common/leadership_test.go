@@ -56,6 +56,40 @@ func (s *LeadershipSuite) TestPinMachineApplicationsSuccess(c *gc.C) {
 	c.Check(res, gc.DeepEquals, s.pinApplicationsClientSuccessResults())
 }
 
+func (s *LeadershipSuite) TestPinMachineApplicationsWithReason(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	args := params.PinApplicationsArgs{Reason: "upgrade-series"}
+	resultSource := params.PinApplicationsResults{Results: s.pinApplicationsServerSuccessResults()}
+	s.facade.EXPECT().FacadeCall("PinMachineApplications", args, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.PinMachineApplicationsWithReason("upgrade-series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, s.pinApplicationsClientSuccessResults())
+}
+
+func (s *LeadershipSuite) TestPinnedLeadershipReasons(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	pinned := map[string][]params.PinnedLeadershipReason{
+		"redis": {
+			{Entity: "machine-0", Reason: "upgrade-series"},
+			{Entity: "machine-1"},
+		},
+	}
+	resultSource := params.PinnedLeadershipReasonsResult{Result: pinned}
+	s.facade.EXPECT().FacadeCall("PinnedLeadershipReasons", nil, gomock.Any()).SetArg(2, resultSource)
+
+	res, err := s.client.PinnedLeadershipReasons()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, map[string][]common.PinnedEntity{
+		"redis": {
+			{Tag: names.NewMachineTag("0"), Reason: "upgrade-series"},
+			{Tag: names.NewMachineTag("1")},
+		},
+	})
+}
+
 func (s *LeadershipSuite) TestPinMachineApplicationsPartialError(c *gc.C) {
 	defer s.setup(c).Finish()
 
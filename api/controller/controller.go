@@ -382,3 +382,28 @@ func (c *Client) ControllerVersion() (ControllerVersion, error) {
 	}
 	return out, err
 }
+
+// CacheHealth describes the health of the controller's model cache.
+type CacheHealth struct {
+	// Status is one of "initializing", "synced" or "degraded".
+	Status string
+
+	// LastError is the error that degraded the cache.
+	// It is only reported to controller superusers.
+	LastError string
+}
+
+// CacheHealth fetches the health of the controller's model cache.
+func (c *Client) CacheHealth() (CacheHealth, error) {
+	if c.BestAPIVersion() < 10 {
+		return CacheHealth{}, errors.NotSupportedf("CacheHealth not supported by this version of Juju")
+	}
+	var result params.ControllerCacheHealth
+	if err := c.facade.FacadeCall("CacheHealth", nil, &result); err != nil {
+		return CacheHealth{}, errors.Trace(err)
+	}
+	return CacheHealth{
+		Status:    result.Status,
+		LastError: result.LastError,
+	}, nil
+}
@@ -419,3 +419,38 @@ func (s *Suite) TestWatchAllModelSummaries(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "some error")
 	c.Assert(watcher, gc.IsNil)
 }
+
+func (s *Suite) TestCacheHealthPriorV10(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.CacheHealth()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestCacheHealth(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(version, gc.Equals, 10)
+			c.Check(request, gc.Equals, "CacheHealth")
+			c.Check(args, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ControllerCacheHealth{})
+			*(result.(*params.ControllerCacheHealth)) = params.ControllerCacheHealth{
+				Status:    "degraded",
+				LastError: "boom",
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	health, err := client.CacheHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health, gc.Equals, controller.CacheHealth{Status: "degraded", LastError: "boom"})
+}
@@ -53,6 +53,7 @@ func (c *Facade) ModelCredential() (base.StoredCredential, bool, error) {
 	return base.StoredCredential{
 		CloudCredential: credentialTag.Id(),
 		Valid:           out.Valid,
+		Missing:         out.Missing,
 	}, true, nil
 }
 
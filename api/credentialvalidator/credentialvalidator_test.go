@@ -45,6 +45,24 @@ func (s *CredentialValidatorSuite) TestModelCredential(c *gc.C) {
 	c.Assert(found, gc.DeepEquals, base.StoredCredential{CloudCredential: "cloud/user/credential", Valid: true})
 }
 
+func (s *CredentialValidatorSuite) TestModelCredentialMissing(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ModelCredential)) = params.ModelCredential{
+			Model:           modelTag.String(),
+			CloudCredential: credentialTag.String(),
+			Exists:          true,
+			Missing:         true,
+		}
+		return nil
+	})
+
+	client := credentialvalidator.NewFacade(apiCaller)
+	found, exists, err := client.ModelCredential()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.IsTrue)
+	c.Assert(found, gc.DeepEquals, base.StoredCredential{CloudCredential: "cloud/user/credential", Missing: true})
+}
+
 func (s *CredentialValidatorSuite) TestModelCredentialIsNotNeeded(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		*(result.(*params.ModelCredential)) = params.ModelCredential{
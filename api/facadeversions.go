@@ -23,7 +23,7 @@ var facadeVersions = map[string]int{
 	"ApplicationScaler":            1,
 	"Backups":                      3,
 	"Block":                        2,
-	"Bundle":                       4,
+	"Bundle":                       5,
 	"CAASAgent":                    1,
 	"CAASAdmission":                1,
 	"CAASApplication":              1,
@@ -32,16 +32,16 @@ var facadeVersions = map[string]int{
 	"CAASFirewallerEmbedded":       1,
 	"CAASModelOperator":            1,
 	"CAASOperator":                 1,
-	"CAASOperatorProvisioner":      1,
+	"CAASOperatorProvisioner":      2,
 	"CAASOperatorUpgrader":         1,
-	"CAASUnitProvisioner":          1,
+	"CAASUnitProvisioner":          4,
 	"CharmHub":                     1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       4,
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        7,
-	"Controller":                   9,
+	"Controller":                   10,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
@@ -69,7 +69,7 @@ var facadeVersions = map[string]int{
 	"Logger":                       1,
 	"MachineActions":               1,
 	"MachineManager":               6,
-	"MachineUndertaker":            1,
+	"MachineUndertaker":            2,
 	"Machiner":                     4,
 	"MeterStatus":                  2,
 	"MetricsAdder":                 2,
@@ -81,7 +81,7 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  2,
-	"ModelGeneration":              4,
+	"ModelGeneration":              7,
 	"ModelManager":                 9,
 	"ModelSummaryWatcher":          1,
 	"ModelUpgrader":                1,
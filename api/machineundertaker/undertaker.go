@@ -96,6 +96,23 @@ func (api *API) CompleteRemoval(machine names.MachineTag) error {
 	return api.facade.FacadeCall("CompleteMachineRemovals", &args, nil)
 }
 
+// SetRemovalProgress records the progress made in removing the machines
+// of the model: the number still pending removal, the number whose
+// addresses have been released and the number that could not be removed.
+// Controllers that do not support recording progress ignore it.
+func (api *API) SetRemovalProgress(pending, addressesReleased, failures int) error {
+	if api.facade.BestAPIVersion() < 2 {
+		return nil
+	}
+	args := params.MachineRemovalProgress{
+		ModelTag:          api.modelTag.String(),
+		Pending:           pending,
+		AddressesReleased: addressesReleased,
+		Failures:          failures,
+	}
+	return api.facade.FacadeCall("SetMachineRemovalProgress", &args, nil)
+}
+
 // WatchMachineRemovals registers to be notified when a machine
 // removal is requested.
 func (api *API) WatchMachineRemovals() (watcher.NotifyWatcher, error) {
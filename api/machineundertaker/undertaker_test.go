@@ -234,6 +234,40 @@ func (*undertakerSuite) TestCompleteRemoval(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "gooey kablooey")
 }
 
+func (*undertakerSuite) TestSetRemovalProgress(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "MachineUndertaker")
+		c.Check(request, gc.Equals, "SetMachineRemovalProgress")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(arg, gc.DeepEquals, &params.MachineRemovalProgress{
+			ModelTag:          coretesting.ModelTag.String(),
+			Pending:           12,
+			AddressesReleased: 3,
+			Failures:          1,
+		})
+		c.Check(result, gc.IsNil)
+		return nil
+	}
+	api, err := machineundertaker.NewAPI(testing.BestVersionCaller{
+		APICallerFunc: caller,
+		BestVersion:   2,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.SetRemovalProgress(12, 3, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*undertakerSuite) TestSetRemovalProgressNotSupported(c *gc.C) {
+	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	}
+	api := makeAPI(c, caller)
+	err := api.SetRemovalProgress(12, 3, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (*undertakerSuite) TestWatchMachineRemovals_CallFailed(c *gc.C) {
 	caller := func(facade string, version int, id, request string, arg, result interface{}) error {
 		c.Check(facade, gc.Equals, "MachineUndertaker")
@@ -57,16 +57,35 @@ func (c *Client) AbortBranch(branchName string) error {
 // CommitBranch commits the branch with the input name to the model,
 // effectively completing it and applying all branch changes across the model.
 // The new generation ID of the model is returned.
-func (c *Client) CommitBranch(branchName string) (int, error) {
-	var result params.IntResult
-	err := c.facade.FacadeCall("CommitBranch", argForBranch(branchName), &result)
+// If config changed under the branch has since been changed on master,
+// an error identifying the conflicting settings is returned, unless force
+// is true. Controllers before facade version 6 do not check for conflicts.
+func (c *Client) CommitBranch(branchName string, force bool) (int, error) {
+	if c.facade.BestAPIVersion() < 6 {
+		var result params.IntResult
+		err := c.facade.FacadeCall("CommitBranch", argForBranch(branchName), &result)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if result.Error != nil {
+			return 0, errors.Trace(result.Error)
+		}
+		return result.Result, nil
+	}
+
+	var result params.BranchCommitResult
+	arg := params.BranchCommitArg{
+		BranchName: branchName,
+		Force:      force,
+	}
+	err := c.facade.FacadeCall("CommitBranch", arg, &result)
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
 	if result.Error != nil {
 		return 0, errors.Trace(result.Error)
 	}
-	return result.Result, nil
+	return result.GenerationId, nil
 }
 
 // ListCommits returns the details of all committed model branches.
@@ -98,36 +117,78 @@ func (c *Client) ShowCommit(generationId int) (model.GenerationCommit, error) {
 
 // TrackBranch sets the input units and/or applications
 // to track changes made under the input branch name.
-func (c *Client) TrackBranch(branchName string, entities []string, numUnits int) error {
+// If a tracking policy is supplied, it determines whether units
+// subsequently added to the input applications track the branch.
+// Otherwise the controller chooses the policy.
+func (c *Client) TrackBranch(
+	branchName string, entities []string, numUnits int, policy model.BranchTrackingPolicy,
+) error {
+	if policy != "" && c.facade.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("branch tracking policies on this controller")
+	}
+	tags, err := branchEntities(entities)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var result params.ErrorResults
+	arg := params.BranchTrackArg{
+		BranchName:     branchName,
+		Entities:       tags,
+		NumUnits:       numUnits,
+		TrackingPolicy: string(policy),
+	}
+	err = c.facade.FacadeCall("TrackBranch", arg, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := result.Combine(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UntrackBranch sets the input units and/or applications to no longer
+// track changes made under the input branch name. Untracking an
+// application untracks all of its units.
+func (c *Client) UntrackBranch(branchName string, entities []string) error {
+	if c.facade.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("untracking branches on this controller")
+	}
+	tags, err := branchEntities(entities)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	var result params.ErrorResults
 	arg := params.BranchTrackArg{
 		BranchName: branchName,
-		NumUnits:   numUnits,
+		Entities:   tags,
 	}
+	err = c.facade.FacadeCall("UntrackBranch", arg, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(result.Combine())
+}
+
+// branchEntities returns the tags of the input
+// application and unit names, for tracking branches.
+func branchEntities(entities []string) ([]params.Entity, error) {
 	if len(entities) == 0 {
-		return errors.New("no units or applications specified")
+		return nil, errors.New("no units or applications specified")
 	}
-	for _, entity := range entities {
+	tags := make([]params.Entity, len(entities))
+	for i, entity := range entities {
 		switch {
 		case names.IsValidApplication(entity):
-			arg.Entities = append(arg.Entities,
-				params.Entity{Tag: names.NewApplicationTag(entity).String()})
+			tags[i] = params.Entity{Tag: names.NewApplicationTag(entity).String()}
 		case names.IsValidUnit(entity):
-			arg.Entities = append(arg.Entities,
-				params.Entity{Tag: names.NewUnitTag(entity).String()})
+			tags[i] = params.Entity{Tag: names.NewUnitTag(entity).String()}
 		default:
-			return errors.Errorf("%q is not an application or a unit", entity)
+			return nil, errors.Errorf("%q is not an application or a unit", entity)
 		}
 	}
-	err := c.facade.FacadeCall("TrackBranch", arg, &result)
-	if err != nil {
-		return errors.Trace(err)
-	}
-
-	if err := result.Combine(); err != nil {
-		return errors.Trace(err)
-	}
-	return nil
+	return tags, nil
 }
 
 // HasActiveBranch returns true if the model has an
@@ -167,6 +228,30 @@ func (c *Client) BranchInfo(
 	return generationInfoFromResult(result, detailed, formatTime), nil
 }
 
+// BranchSummaries returns a compact summary of each "in-flight" branch.
+// Unlike BranchInfo, no per-application detail is retrieved.
+func (c *Client) BranchSummaries() ([]model.BranchSummary, error) {
+	var result params.BranchSummaryResults
+	err := c.facade.FacadeCall("BranchSummaries", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	summaries := make([]model.BranchSummary, len(result.Summaries))
+	for i, s := range result.Summaries {
+		summaries[i] = model.BranchSummary{
+			BranchName:       s.BranchName,
+			Created:          time.Unix(s.Created, 0),
+			CreatedBy:        s.CreatedBy,
+			TrackedUnitCount: s.TrackedUnitCount,
+			ApplicationCount: s.ApplicationCount,
+		}
+	}
+	return summaries, nil
+}
+
 func argForBranch(branchName string) params.BranchArg {
 	return params.BranchArg{
 		BranchName: branchName,
@@ -183,8 +268,17 @@ func generationInfoFromResult(
 			bApp := model.GenerationApplication{
 				ApplicationName: a.ApplicationName,
 				UnitProgress:    a.UnitProgress,
+				TrackingPolicy:  a.TrackingPolicy,
 				ConfigChanges:   a.ConfigChanges,
 			}
+			for _, conflict := range a.ConfigConflicts {
+				bApp.ConfigConflicts = append(bApp.ConfigConflicts, model.ConfigConflict{
+					Key:      conflict.Key,
+					Baseline: conflict.Baseline,
+					Master:   conflict.Master,
+					Branch:   conflict.Branch,
+				})
+			}
 			if detailed {
 				bApp.UnitDetail = &model.GenerationUnits{
 					UnitsTracking: a.UnitsTracking,
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -86,27 +87,122 @@ func (s *modelGenerationSuite) TestTrackBranchSuccess(c *gc.C) {
 	s.fCaller.EXPECT().FacadeCall("TrackBranch", arg, gomock.Any()).SetArg(2, resultsSource).Return(nil)
 
 	api := modelgeneration.NewStateFromCaller(s.fCaller)
-	err := api.TrackBranch(s.branchName, []string{"mysql/0", "mysql"}, 0)
+	err := api.TrackBranch(s.branchName, []string{"mysql/0", "mysql"}, 0, "")
 	c.Assert(err, gc.IsNil)
 }
 
+func (s *modelGenerationSuite) TestTrackBranchWithPolicy(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultsSource := params.ErrorResults{Results: []params.ErrorResult{
+		{Error: nil},
+	}}
+	arg := params.BranchTrackArg{
+		BranchName: s.branchName,
+		Entities: []params.Entity{
+			{Tag: "application-mysql"},
+		},
+		TrackingPolicy: "explicit-units",
+	}
+	s.fCaller.EXPECT().BestAPIVersion().Return(7)
+	s.fCaller.EXPECT().FacadeCall("TrackBranch", arg, gomock.Any()).SetArg(2, resultsSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.TrackBranch(s.branchName, []string{"mysql"}, 0, model.BranchTrackExplicitUnits)
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *modelGenerationSuite) TestTrackBranchWithPolicyNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.TrackBranch(s.branchName, []string{"mysql"}, 0, model.BranchTrackAll)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelGenerationSuite) TestTrackBranchError(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
 	api := modelgeneration.NewStateFromCaller(s.fCaller)
-	err := api.TrackBranch(s.branchName, []string{"mysql/0", "mysql", "machine-3"}, 0)
+	err := api.TrackBranch(s.branchName, []string{"mysql/0", "mysql", "machine-3"}, 0, "")
 	c.Assert(err, gc.ErrorMatches, `"machine-3" is not an application or a unit`)
 }
 
+func (s *modelGenerationSuite) TestUntrackBranch(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultsSource := params.ErrorResults{Results: []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Message: "branch was already committed"}},
+	}}
+	arg := params.BranchTrackArg{
+		BranchName: s.branchName,
+		Entities: []params.Entity{
+			{Tag: "unit-mysql-0"},
+			{Tag: "application-redis"},
+		},
+	}
+	s.fCaller.EXPECT().BestAPIVersion().Return(7)
+	s.fCaller.EXPECT().FacadeCall("UntrackBranch", arg, gomock.Any()).SetArg(2, resultsSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.UntrackBranch(s.branchName, []string{"mysql/0", "redis"})
+	c.Assert(err, gc.ErrorMatches, "branch was already committed")
+}
+
+func (s *modelGenerationSuite) TestUntrackBranchNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.UntrackBranch(s.branchName, []string{"mysql/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
+	resultSource := params.BranchCommitResult{GenerationId: 2}
+	arg := params.BranchCommitArg{BranchName: s.branchName, Force: true}
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+	s.fCaller.EXPECT().FacadeCall("CommitBranch", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	newGenID, err := api.CommitBranch("new-branch", true)
+	c.Assert(err, gc.IsNil)
+	c.Check(newGenID, gc.Equals, 2)
+}
+
+func (s *modelGenerationSuite) TestCommitBranchConflict(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.BranchCommitResult{
+		Conflicts: []params.ApplicationConfigConflicts{{
+			ApplicationName: "redis",
+			Conflicts:       []params.ConfigConflict{{Key: "port", Baseline: 7000, Master: 7500, Branch: 8000}},
+		}},
+		Error: &params.Error{Message: `branch "new-branch" config changed on master since it was changed under the branch: redis (port)`},
+	}
+	arg := params.BranchCommitArg{BranchName: s.branchName}
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+	s.fCaller.EXPECT().FacadeCall("CommitBranch", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	_, err := api.CommitBranch("new-branch", false)
+	c.Assert(err, gc.ErrorMatches, `branch "new-branch" config changed on master .*: redis \(port\)`)
+}
+
+func (s *modelGenerationSuite) TestCommitBranchV5(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
 	resultSource := params.IntResult{Result: 2}
 	arg := params.BranchArg{BranchName: s.branchName}
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
 	s.fCaller.EXPECT().FacadeCall("CommitBranch", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
 
 	api := modelgeneration.NewStateFromCaller(s.fCaller)
-	newGenID, err := api.CommitBranch("new-branch")
+	newGenID, err := api.CommitBranch("new-branch", false)
 	c.Assert(err, gc.IsNil)
 	c.Check(newGenID, gc.Equals, 2)
 }
@@ -135,9 +231,13 @@ func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 			{
 				ApplicationName: "redis",
 				UnitProgress:    "1/2",
+				TrackingPolicy:  "track-all",
 				UnitsTracking:   []string{"redis/0"},
 				UnitsPending:    []string{"redis/1"},
 				ConfigChanges:   map[string]interface{}{"databases": 8},
+				ConfigConflicts: []params.ConfigConflict{
+					{Key: "databases", Baseline: 4, Master: 6, Branch: 8},
+				},
 			},
 		},
 	}}}
@@ -163,12 +263,40 @@ func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 			Applications: []model.GenerationApplication{{
 				ApplicationName: "redis",
 				UnitProgress:    "1/2",
+				TrackingPolicy:  "track-all",
 				UnitDetail: &model.GenerationUnits{
 					UnitsTracking: []string{"redis/0"},
 					UnitsPending:  []string{"redis/1"},
 				},
 				ConfigChanges: map[string]interface{}{"databases": 8},
+				ConfigConflicts: []model.ConfigConflict{
+					{Key: "databases", Baseline: 4, Master: 6, Branch: 8},
+				},
 			}},
 		},
 	})
 }
+
+func (s *modelGenerationSuite) TestBranchSummaries(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.BranchSummaryResults{Summaries: []params.BranchSummary{{
+		BranchName:       "new-branch",
+		Created:          time.Time{}.Unix(),
+		CreatedBy:        "test-user",
+		TrackedUnitCount: 3,
+		ApplicationCount: 2,
+	}}}
+	s.fCaller.EXPECT().FacadeCall("BranchSummaries", nil, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	summaries, err := api.BranchSummaries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summaries, jc.DeepEquals, []model.BranchSummary{{
+		BranchName:       "new-branch",
+		Created:          time.Unix(time.Time{}.Unix(), 0),
+		CreatedBy:        "test-user",
+		TrackedUnitCount: 3,
+		ApplicationCount: 2,
+	}})
+}
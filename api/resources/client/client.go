@@ -110,12 +110,22 @@ func newListResourcesArgs(applications []string) (params.ListResourcesArgs, erro
 	return args, nil
 }
 
+// UploadOptions holds the optional settings for a resource upload.
+type UploadOptions struct {
+	// SkipRegistryCheck indicates that the controller should not
+	// verify that a container image resource can be retrieved
+	// from its registry, such as when the registry is not
+	// reachable from the controller.
+	SkipRegistryCheck bool
+}
+
 // Upload sends the provided resource blob up to Juju.
-func (c Client) Upload(application, name, filename string, reader io.ReadSeeker) error {
+func (c Client) Upload(application, name, filename string, reader io.ReadSeeker, opts UploadOptions) error {
 	uReq, err := api.NewUploadRequest(application, name, filename, reader)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	uReq.SkipRegistryCheck = opts.SkipRegistryCheck
 	req, err := uReq.HTTPRequest()
 	if err != nil {
 		return errors.Trace(err)
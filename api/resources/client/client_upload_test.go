@@ -36,7 +36,7 @@ func (s *UploadSuite) TestOkay(c *gc.C) {
 
 	_, s.response.Resource = newResource(c, "spam", "a-user", data)
 
-	err := cl.Upload("a-application", "spam", "foo.zip", reader)
+	err := cl.Upload("a-application", "spam", "foo.zip", reader, client.UploadOptions{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	fp, err := charmresource.GenerateFingerprint(strings.NewReader(data))
@@ -53,10 +53,28 @@ func (s *UploadSuite) TestOkay(c *gc.C) {
 	s.stub.CheckCall(c, 3, "Do", req, s.response)
 }
 
+func (s *UploadSuite) TestSkipRegistryCheck(c *gc.C) {
+	data := "<data>"
+	reader := &stubFile{stub: s.stub}
+	reader.returnRead = strings.NewReader(data)
+	cl := client.NewClient(context.Background(), s.facade, s, s.facade)
+
+	_, s.response.Resource = newResource(c, "spam", "a-user", data)
+
+	err := cl.Upload("a-application", "spam", "foo.zip", reader, client.UploadOptions{
+		SkipRegistryCheck: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do")
+	req := s.stub.Calls()[3].Args[0].(*http.Request)
+	c.Check(req.URL.Query().Get("skip-registry-check"), gc.Equals, "true")
+}
+
 func (s *UploadSuite) TestBadService(c *gc.C) {
 	cl := client.NewClient(context.Background(), s.facade, s, s.facade)
 
-	err := cl.Upload("???", "spam", "file.zip", nil)
+	err := cl.Upload("???", "spam", "file.zip", nil, client.UploadOptions{})
 
 	c.Check(err, gc.ErrorMatches, `.*invalid application.*`)
 	s.stub.CheckNoCalls(c)
@@ -68,7 +86,7 @@ func (s *UploadSuite) TestBadRequest(c *gc.C) {
 	failure := errors.New("<failure>")
 	s.stub.SetErrors(failure)
 
-	err := cl.Upload("a-application", "spam", "file.zip", reader)
+	err := cl.Upload("a-application", "spam", "file.zip", reader, client.UploadOptions{})
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c, "Read")
@@ -81,7 +99,7 @@ func (s *UploadSuite) TestRequestFailed(c *gc.C) {
 	failure := errors.New("<failure>")
 	s.stub.SetErrors(nil, nil, nil, failure)
 
-	err := cl.Upload("a-application", "spam", "file.zip", reader)
+	err := cl.Upload("a-application", "spam", "file.zip", reader, client.UploadOptions{})
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c, "Read", "Read", "Seek", "Do")
@@ -53,6 +53,10 @@ const (
 	MediaTypeFormData = "form-data"
 	// QueryParamPendingID is the query parameter we use to send up the pending id.
 	QueryParamPendingID = "pendingid"
+	// QueryParamSkipRegistryCheck is the query parameter used to request
+	// that the controller does not verify access to a container image
+	// resource's registry, for registries unreachable from the controller.
+	QueryParamSkipRegistryCheck = "skip-registry-check"
 )
 
 // NewEndpointPath returns the API URL path for the identified resource.
@@ -36,6 +36,10 @@ type UploadRequest struct {
 	// PendingID is the pending ID to associate with this upload, if any.
 	PendingID string
 
+	// SkipRegistryCheck indicates that the controller should not verify
+	// that a container image resource can be pulled from its registry.
+	SkipRegistryCheck bool
+
 	// Content is the content to upload.
 	Content io.ReadSeeker
 }
@@ -100,9 +104,14 @@ func (ur UploadRequest) HTTPRequest() (*http.Request, error) {
 
 	req.ContentLength = ur.Size
 
-	if ur.PendingID != "" {
+	if ur.PendingID != "" || ur.SkipRegistryCheck {
 		query := req.URL.Query()
-		query.Set(QueryParamPendingID, ur.PendingID)
+		if ur.PendingID != "" {
+			query.Set(QueryParamPendingID, ur.PendingID)
+		}
+		if ur.SkipRegistryCheck {
+			query.Set(QueryParamSkipRegistryCheck, "true")
+		}
 		req.URL.RawQuery = query.Encode()
 	}
 
@@ -166,6 +166,7 @@ func AllFacades() *facade.Registry {
 	reg("Bundle", 2, bundle.NewFacadeV2)
 	reg("Bundle", 3, bundle.NewFacadeV3)
 	reg("Bundle", 4, bundle.NewFacadeV4)
+	reg("Bundle", 5, bundle.NewFacadeV5)
 	reg("CharmHub", 1, charmhub.NewFacade)
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
 	reg("Charms", 2, charms.NewFacadeV2)
@@ -190,9 +191,13 @@ func AllFacades() *facade.Registry {
 	reg("CAASAdmission", 1, caasadmission.NewStateFacade)
 	reg("CAASAgent", 1, caasagent.NewStateFacade)
 	reg("CAASModelOperator", 1, caasmodeloperator.NewAPIFromContext)
-	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI)
+	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPIV1)
+	reg("CAASOperatorProvisioner", 2, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI)
 	reg("CAASOperatorUpgrader", 1, caasoperatorupgrader.NewStateCAASOperatorUpgraderAPI)
-	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacade)
+	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacadeV1)
+	reg("CAASUnitProvisioner", 2, caasunitprovisioner.NewStateFacadeV2) // Adds service hash methods.
+	reg("CAASUnitProvisioner", 3, caasunitprovisioner.NewStateFacadeV3) // Adds SetApplicationsServiceAddresses.
+	reg("CAASUnitProvisioner", 4, caasunitprovisioner.NewStateFacade)   // Adds StopApplicationsUnits.
 	reg("CAASApplication", 1, caasapplication.NewStateFacade)
 	reg("CAASApplicationProvisioner", 1, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPI)
 
@@ -203,6 +208,7 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
 	reg("Controller", 9, controller.NewControllerAPIv9)
+	reg("Controller", 10, controller.NewControllerAPIv10)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPIV1)
 	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds WatchRelationChanges, removes WatchRelationUnits
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
@@ -247,7 +253,8 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
 	reg("MachineManager", 6, machinemanager.NewFacadeV6) // DestroyMachinesWithParams gains maxWait.
 
-	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
+	reg("MachineUndertaker", 1, machineundertaker.NewFacadeV1)
+	reg("MachineUndertaker", 2, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPIV1)
 	reg("Machiner", 2, machine.NewMachinerAPIV2) // Adds RecordAgentStartTime.
 	reg("Machiner", 3, machine.NewMachinerAPIV3) // Relies on agent-set origin in SetObservedNetworkConfig.
@@ -271,6 +278,9 @@ func AllFacades() *facade.Registry {
 	reg("ModelGeneration", 2, modelgeneration.NewModelGenerationFacadeV2)
 	reg("ModelGeneration", 3, modelgeneration.NewModelGenerationFacadeV3)
 	reg("ModelGeneration", 4, modelgeneration.NewModelGenerationFacadeV4)
+	reg("ModelGeneration", 5, modelgeneration.NewModelGenerationFacadeV5)
+	reg("ModelGeneration", 6, modelgeneration.NewModelGenerationFacadeV6)
+	reg("ModelGeneration", 7, modelgeneration.NewModelGenerationFacadeV7)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
@@ -28,6 +28,7 @@ import (
 	"gopkg.in/tomb.v2"
 
 	"github.com/juju/juju/apiserver/apiserverhttp"
+	"github.com/juju/juju/apiserver/charmhubproxy"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/apihttp"
 	"github.com/juju/juju/apiserver/common/crossmodel"
@@ -38,12 +39,14 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/stateauthenticator"
 	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/presence"
+	coreresources "github.com/juju/juju/core/resources"
 	"github.com/juju/juju/pubsub/apiserver"
 	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/resource"
@@ -51,12 +54,17 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
 )
 
 var logger = loggo.GetLogger("juju.apiserver")
 
 var defaultHTTPMethods = []string{"GET", "POST", "HEAD", "PUT", "DELETE", "OPTIONS"}
 
+// registryCheckTimeout bounds the time spent verifying that a container
+// image resource can be pulled from its registry when it is attached.
+const registryCheckTimeout = 30 * time.Second
+
 // Server holds the server side of the API.
 type Server struct {
 	tomb      tomb.Tomb
@@ -79,12 +87,14 @@ type Server struct {
 	allowModelAccess       bool
 	logSinkWriter          io.WriteCloser
 	logsinkRateLimitConfig logsink.RateLimitConfig
+	logsinkLimiter         *logsink.IngestionLimiter
 	dbloggers              dbloggers
 	getAuditConfig         func() auditlog.Config
 	upgradeComplete        func() bool
 	mux                    *apiserverhttp.Mux
 	metricsCollector       *Collector
 	execEmbeddedCommand    ExecEmbeddedCommandFunc
+	charmhubProxy          *charmhubproxy.Proxy
 
 	// mu guards the fields below it.
 	mu sync.Mutex
@@ -107,6 +117,11 @@ type Server struct {
 	agentRateLimitRate time.Duration
 	agentRateLimit     *ratelimit.Bucket
 
+	// uploadLimits holds the maximum sizes of the request bodies sent
+	// to upload endpoints. They come from controller config, and can
+	// be updated on the fly.
+	uploadLimits uploadLimits
+
 	// registerIntrospectionHandlers is a function that will
 	// call a function with (path, http.Handler) tuples. This
 	// is to support registering the handlers underneath the
@@ -261,12 +276,32 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 
 const readyTimeout = time.Second * 30
 
+const (
+	// charmhubInfoCacheTTL is how long charmhub info responses
+	// are shared between the models on the controller.
+	charmhubInfoCacheTTL = time.Minute
+
+	// charmhubInfoCacheMaxEntries is the maximum number
+	// of charmhub info responses cached by the controller.
+	charmhubInfoCacheMaxEntries = 1000
+)
+
 func newServer(cfg ServerConfig) (_ *Server, err error) {
 	controllerConfig, err := cfg.StatePool.SystemState().ControllerConfig()
 	if err != nil {
 		return nil, errors.Annotate(err, "unable to get controller config")
 	}
 
+	charmhubInfoCache, err := charmhub.NewInfoCache(charmhub.InfoCacheConfig{
+		Clock:      cfg.Clock,
+		TTL:        charmhubInfoCacheTTL,
+		MaxEntries: charmhubInfoCacheMaxEntries,
+		Metrics:    charmhubInfoCacheMetrics{collector: cfg.MetricsCollector},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	shared, err := newSharedServerContext(sharedServerConfig{
 		statePool:           cfg.StatePool,
 		controller:          cfg.Controller,
@@ -275,6 +310,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		presence:            cfg.Presence,
 		leaseManager:        cfg.LeaseManager,
 		controllerConfig:    controllerConfig,
+		charmhubInfoCache:   charmhubInfoCache,
 		logger:              loggo.GetLogger("juju.apiserver"),
 	})
 	if err != nil {
@@ -306,12 +342,15 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 			dbLoggerBufferSize:    cfg.LogSinkConfig.DBLoggerBufferSize,
 			dbLoggerFlushInterval: cfg.LogSinkConfig.DBLoggerFlushInterval,
 		},
+		logsinkLimiter:      logsink.NewIngestionLimiter(cfg.Clock, logsinkIngestionLimits(controllerConfig)),
 		metricsCollector:    cfg.MetricsCollector,
 		execEmbeddedCommand: cfg.ExecEmbeddedCommand,
 
 		healthStatus: "starting",
 	}
 	srv.updateAgentRateLimiter(controllerConfig)
+	srv.setUploadLimits(uploadLimitsFromConfig(controllerConfig))
+	srv.updateAgentChurnThreshold(controllerConfig)
 
 	// We are able to get the current controller config before subscribing to changes
 	// because the changes are only ever published in response to an API call,
@@ -324,6 +363,9 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 				return
 			}
 			srv.updateAgentRateLimiter(data.Config)
+			srv.logsinkLimiter.SetLimits(logsinkIngestionLimits(data.Config))
+			srv.setUploadLimits(uploadLimitsFromConfig(data.Config))
+			srv.updateAgentChurnThreshold(data.Config)
 		})
 	if err != nil {
 		logger.Criticalf("programming error in subscribe function: %v", err)
@@ -332,6 +374,18 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 
 	srv.shared.cancel = srv.tomb.Dying()
 
+	// Charm downloads proxied for agents are shared between requests,
+	// so they are bound by the lifetime of the server.
+	systemState := srv.shared.statePool.SystemState()
+	srv.charmhubProxy, err = charmhubproxy.NewProxy(charmhubproxy.Config{
+		Context:      srv.tomb.Context(context.Background()),
+		Store:        storage.NewStorage(systemState.ModelUUID(), systemState.MongoSession()),
+		MaxCacheSize: charmhubproxy.DefaultMaxCacheSize,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating charmhub proxy")
+	}
+
 	// The auth context for authenticating access to application offers.
 	srv.offerAuthCtxt, err = newOfferAuthcontext(cfg.StatePool)
 	if err != nil {
@@ -429,6 +483,24 @@ func (srv *Server) updateAgentRateLimiter(cfg controller.Config) {
 	}
 }
 
+// updateAgentChurnThreshold sets the threshold at which the presence
+// recorder considers agents that repeatedly disconnect to be flapping.
+func (srv *Server) updateAgentChurnThreshold(cfg controller.Config) {
+	if err := srv.shared.presence.SetChurnThreshold(cfg.AgentChurnThreshold()); err != nil {
+		logger.Warningf("unable to set agent churn threshold: %v", err)
+	}
+}
+
+// logsinkIngestionLimits returns the limits to apply to
+// log messages received by the logsink endpoint.
+func logsinkIngestionLimits(cfg controller.Config) logsink.IngestionLimits {
+	return logsink.IngestionLimits{
+		Rate:       cfg.LogSinkIngestionRate(),
+		Burst:      cfg.LogSinkIngestionBurst(),
+		DailyQuota: cfg.ModelLogsDailyQuota(),
+	}
+}
+
 type rateClock struct {
 	clock.Clock
 }
@@ -467,6 +539,17 @@ func (w *loggoWrapper) Write(content []byte) (int, error) {
 	return len(content), nil
 }
 
+// charmhubInfoCacheMetrics records the lookups
+// in the charmhub info cache with the metrics collector.
+type charmhubInfoCacheMetrics struct {
+	collector *Collector
+}
+
+// RecordLookup is part of the charmhub.InfoCacheMetrics interface.
+func (m charmhubInfoCacheMetrics) RecordLookup(result string) {
+	m.collector.CharmhubInfoCacheLookups.WithLabelValues(result).Inc()
+}
+
 // logsinkMetricsCollectorWrapper defines a wrapper for exposing the essentials
 // for the logsink api handler to interact with the metrics collector.
 type logsinkMetricsCollectorWrapper struct {
@@ -493,6 +576,10 @@ func (w logsinkMetricsCollectorWrapper) LogReadCount(modelUUID, state string) pr
 	return w.collector.LogReadCount.WithLabelValues(modelUUID, state)
 }
 
+func (w logsinkMetricsCollectorWrapper) LogDropCount(modelUUID, reason string) prometheus.Counter {
+	return w.collector.LogDropCount.WithLabelValues(modelUUID, reason)
+}
+
 // loop is the main loop for the server.
 func (srv *Server) loop(ready chan struct{}) error {
 	// for pat based handlers, they are matched in-order of being
@@ -533,6 +620,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		authorizer      httpcontext.Authorizer
 		tracked         bool
 		noModelUUID     bool
+		// uploads, if set, identifies the limit
+		// applied to the size of request bodies.
+		uploads uploadCategory
 	}
 	var endpoints []apihttp.Endpoint
 	controllerModelUUID := srv.shared.statePool.SystemState().ModelUUID()
@@ -542,6 +632,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			methods = defaultHTTPMethods
 		}
 		h := handler.handler
+		if handler.uploads != noUploadLimit {
+			h = srv.limitUploads(handler.uploads, h)
+		}
 		if handler.tracked {
 			h = srv.trackRequests(h)
 		}
@@ -587,6 +680,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
 		httpCtxt.stop(),
 		&srv.logsinkRateLimitConfig,
+		srv.logsinkLimiter,
 		logsinkMetricsCollectorWrapper{collector: srv.metricsCollector},
 		controllerModelUUID,
 	)
@@ -597,6 +691,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		newMigrationLogWriteCloserFunc(httpCtxt, &srv.dbloggers),
 		httpCtxt.stop(),
 		nil, // no rate-limiting
+		nil, // no ingestion limits
 		logsinkMetricsCollectorWrapper{collector: srv.metricsCollector},
 		controllerModelUUID,
 	)
@@ -626,6 +721,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	modelToolsDownloadHandler := &toolsDownloadHandler{
 		ctxt: httpCtxt,
 	}
+	charmhubDownloadHandler := &charmhubDownloadHandler{
+		ctxt:  httpCtxt,
+		proxy: srv.charmhubProxy,
+	}
+	charmhubDownloadAuthorizer := tagKindAuthorizer(stateauthenticator.AgentTags)
 	resourcesHandler := &ResourcesHandler{
 		StateAuthFunc: func(req *http.Request, tagKinds ...string) (ResourcesBackend, state.PoolHelper, names.Tag, error) {
 			st, entity, err := httpCtxt.stateForRequestAuthenticatedTag(req, tagKinds...)
@@ -649,6 +749,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			}
 			return nil
 		},
+		RegistryChecker: coreresources.NewRegistryChecker(&http.Client{
+			Timeout: registryCheckTimeout,
+		}),
 	}
 	unitResourcesHandler := &UnitResourcesHandler{
 		NewOpener: func(req *http.Request, tagKinds ...string) (resource.Opener, state.PoolHelper, error) {
@@ -743,35 +846,47 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		methods:    []string{"POST"},
 		handler:    modelCharmsHTTPHandler,
 		authorizer: modelCharmsUploadAuthorizer,
+		uploads:    charmUploads,
 	}, {
 		pattern:    modelRoutePrefix + "/tools",
 		handler:    modelToolsUploadHandler,
 		authorizer: modelToolsUploadAuthorizer,
+		uploads:    agentBinaryUploads,
 	}, {
 		pattern:         modelRoutePrefix + "/tools/:version",
 		handler:         modelToolsDownloadHandler,
 		unauthenticated: true,
+	}, {
+		pattern:    modelRoutePrefix + "/charmhub/charms/:name/:revision",
+		methods:    []string{"GET"},
+		handler:    charmhubDownloadHandler,
+		authorizer: charmhubDownloadAuthorizer,
 	}, {
 		pattern: modelRoutePrefix + "/applications/:application/resources/:resource",
 		handler: resourcesHandler,
+		uploads: resourceUploads,
 	}, {
 		pattern: modelRoutePrefix + "/units/:unit/resources/:resource",
 		handler: unitResourcesHandler,
 	}, {
 		pattern: modelRoutePrefix + "/backups",
 		handler: backupHandler,
+		uploads: backupUploads,
 	}, {
 		pattern:    "/migrate/charms",
 		handler:    migrateCharmsHTTPHandler,
 		authorizer: controllerAdminAuthorizer,
+		uploads:    charmUploads,
 	}, {
 		pattern:    "/migrate/tools",
 		handler:    migrateToolsUploadHandler,
 		authorizer: controllerAdminAuthorizer,
+		uploads:    agentBinaryUploads,
 	}, {
 		pattern:    "/migrate/resources",
 		handler:    resourcesMigrationUploadHandler,
 		authorizer: controllerAdminAuthorizer,
+		uploads:    resourceUploads,
 	}, {
 		pattern:    "/migrate/logtransfer",
 		handler:    logTransferHandler,
@@ -811,6 +926,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		pattern:    "/tools",
 		handler:    modelToolsUploadHandler,
 		authorizer: modelToolsUploadAuthorizer,
+		uploads:    agentBinaryUploads,
 	}, {
 		pattern:         "/tools/:version",
 		handler:         modelToolsDownloadHandler,
@@ -832,6 +948,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		methods:    []string{"POST"},
 		handler:    modelCharmsHTTPHandler,
 		authorizer: modelCharmsUploadAuthorizer,
+		uploads:    charmUploads,
 	}, {
 		pattern: "/dashboard-archive",
 		methods: []string{"POST"},
@@ -26,6 +26,12 @@ const MetricLabelModelUUID = "model_uuid"
 // MetricLabelState defines a constant for the LogWriteCount Label
 const MetricLabelState = "state"
 
+// MetricLabelReason defines a constant for the LogDropCount Label
+const MetricLabelReason = "reason"
+
+// MetricLabelResult defines a constant for the CharmhubInfoCacheLookups Label
+const MetricLabelResult = "result"
+
 // MetricAPIConnectionsLabelNames defines a series of labels for the
 // APIConnections metric.
 var MetricAPIConnectionsLabelNames = []string{
@@ -46,6 +52,18 @@ var MetricLogLabelNames = []string{
 	MetricLabelState,
 }
 
+// MetricLogDropLabelNames defines a series of labels for the LogDrop metric
+var MetricLogDropLabelNames = []string{
+	MetricLabelModelUUID,
+	MetricLabelReason,
+}
+
+// MetricCharmhubInfoCacheLabelNames defines a series of labels for the
+// CharmhubInfoCacheLookups metric
+var MetricCharmhubInfoCacheLabelNames = []string{
+	MetricLabelResult,
+}
+
 // Collector is a prometheus.Collector that collects metrics based
 // on apiserver status.
 type Collector struct {
@@ -56,6 +74,9 @@ type Collector struct {
 	PingFailureCount   *prometheus.CounterVec
 	LogWriteCount      *prometheus.CounterVec
 	LogReadCount       *prometheus.CounterVec
+	LogDropCount       *prometheus.CounterVec
+
+	CharmhubInfoCacheLookups *prometheus.CounterVec
 }
 
 // NewMetricsCollector returns a new Collector.
@@ -109,6 +130,18 @@ func NewMetricsCollector() *Collector {
 			Name:      "log_read_count",
 			Help:      "Current number of log reads",
 		}, MetricLogLabelNames),
+		LogDropCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "log_drop_count",
+			Help:      "Current number of log messages dropped for exceeding ingestion limits",
+		}, MetricLogDropLabelNames),
+		CharmhubInfoCacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: apiserverMetricsNamespace,
+			Subsystem: apiserverSubsystemNamespace,
+			Name:      "charmhub_info_cache_lookups",
+			Help:      "Number of charmhub info lookups, by whether they were served from the cache",
+		}, MetricCharmhubInfoCacheLabelNames),
 	}
 }
 
@@ -121,6 +154,8 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.PingFailureCount.Describe(ch)
 	c.LogWriteCount.Describe(ch)
 	c.LogReadCount.Describe(ch)
+	c.LogDropCount.Describe(ch)
+	c.CharmhubInfoCacheLookups.Describe(ch)
 }
 
 // Collect is part of the prometheus.Collector interface.
@@ -132,4 +167,6 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.PingFailureCount.Collect(ch)
 	c.LogWriteCount.Collect(ch)
 	c.LogReadCount.Collect(ch)
+	c.LogDropCount.Collect(ch)
+	c.CharmhubInfoCacheLookups.Collect(ch)
 }
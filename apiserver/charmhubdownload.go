@@ -0,0 +1,103 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/charmhubproxy"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/state"
+)
+
+// charmhubDownloadHandler downloads charms from CharmHub on behalf of
+// agents that are unable to reach CharmHub directly, streaming the
+// verified archive back to the agent.
+type charmhubDownloadHandler struct {
+	ctxt  httpContext
+	proxy *charmhubproxy.Proxy
+}
+
+func (h *charmhubDownloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		if err := sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method)); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+
+	st, _, err := h.ctxt.stateForRequestAuthenticatedAgent(r)
+	if err != nil {
+		if err := sendError(w, err); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	defer st.Release()
+
+	req, err := charmhubRequestFromHTTP(r)
+	if err != nil {
+		if err := sendError(w, errors.NewBadRequest(err, "")); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+
+	client, err := common.CharmhubClient(charmhubModelGetter{st.State}, logger, nil)
+	if err != nil {
+		if err := sendError(w, err); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+
+	blob, err := h.proxy.Fetch(r.Context(), req, charmhubproxy.NewCharmHubSource(client))
+	if err != nil {
+		logger.Errorf("GET(%s) failed: %v", r.URL, err)
+		if err := sendError(w, err); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	defer func() { _ = blob.Close() }()
+	charmhubproxy.Serve(w, r, fmt.Sprintf("%s_%d.charm", req.Name, req.Revision), blob)
+}
+
+// charmhubRequestFromHTTP extracts the charm revision and platform
+// being requested from the input HTTP request.
+func charmhubRequestFromHTTP(r *http.Request) (charmhubproxy.Request, error) {
+	query := r.URL.Query()
+	revision, err := strconv.Atoi(query.Get(":revision"))
+	if err != nil {
+		return charmhubproxy.Request{}, errors.NotValidf("charm revision %q", query.Get(":revision"))
+	}
+	req := charmhubproxy.Request{
+		Name:     query.Get(":name"),
+		Revision: revision,
+		Platform: charmhub.RefreshPlatform{
+			Architecture: query.Get("arch"),
+			OS:           query.Get("os"),
+			Series:       query.Get("series"),
+		},
+	}
+	if err := req.Validate(); err != nil {
+		return charmhubproxy.Request{}, errors.Trace(err)
+	}
+	return req, nil
+}
+
+// charmhubModelGetter adapts a *state.State to common.ModelGetter.
+type charmhubModelGetter struct {
+	st *state.State
+}
+
+// Model is part of the common.ModelGetter interface.
+func (g charmhubModelGetter) Model() (common.ConfigModel, error) {
+	return g.st.Model()
+}
@@ -0,0 +1,506 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmhubproxy allows the controller to download CharmHub charm
+// blobs on behalf of agents that are unable to reach CharmHub themselves.
+// Downloaded blobs are verified against the hash reported by CharmHub and
+// can be cached in the controller's object store.
+package charmhubproxy
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/charmhub"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.charmhubproxy")
+
+// DefaultMaxCacheSize is the default upper bound, in bytes, for the total
+// size of charm blobs cached in the object store.
+const DefaultMaxCacheSize = 1 << 30
+
+// Resolver locates the download for a charm revision.
+type Resolver interface {
+	// ResolveDownload returns the URL from which the charm revision can be
+	// downloaded, along with the expected SHA256 hash of its content.
+	ResolveDownload(ctx context.Context, req Request) (*url.URL, string, error)
+}
+
+// Downloader retrieves the content at a resolved download URL.
+type Downloader interface {
+	DownloadResource(ctx context.Context, resourceURL *url.URL) (io.ReadCloser, error)
+}
+
+// Source combines the means of resolving and downloading charm blobs
+// for a model.
+type Source struct {
+	Resolver   Resolver
+	Downloader Downloader
+}
+
+// BlobStore describes the object store used to cache charm blobs.
+// It is satisfied by state/storage.Storage.
+type BlobStore interface {
+	Get(path string) (io.ReadCloser, int64, error)
+	Put(path string, r io.Reader, length int64) error
+	Remove(path string) error
+}
+
+// Request identifies a charm revision to be downloaded.
+type Request struct {
+	Name     string
+	Revision int
+	Platform charmhub.RefreshPlatform
+}
+
+// Validate returns an error if the request does not identify
+// a single charm revision.
+func (r Request) Validate() error {
+	if r.Name == "" {
+		return errors.NotValidf("empty charm name")
+	}
+	if r.Revision < 0 {
+		return errors.NotValidf("charm revision %d", r.Revision)
+	}
+	return nil
+}
+
+// key uniquely identifies the requested blob.
+func (r Request) key() string {
+	return fmt.Sprintf("%s-%d-%s", r.Name, r.Revision, strings.Replace(r.Platform.String(), "/", "-", -1))
+}
+
+// Blob is a verified charm archive. Its content is spooled to a
+// temporary file, which is removed once every Blob sharing it has
+// been closed.
+type Blob struct {
+	io.ReadSeeker
+
+	// Size is the length of the archive in bytes.
+	Size int64
+
+	// SHA256 is the hex-encoded SHA256 hash of the archive.
+	SHA256 string
+
+	release func()
+	once    sync.Once
+}
+
+// Close releases the blob's content.
+func (b *Blob) Close() error {
+	if b.release != nil {
+		b.once.Do(b.release)
+	}
+	return nil
+}
+
+// Config holds the dependencies and configuration for a Proxy.
+type Config struct {
+	// Context bounds downloads shared between concurrent requests, which
+	// must outlive any single request. It is typically done when the API
+	// server stops.
+	Context context.Context
+
+	// Store, if not nil, is used to cache downloaded blobs.
+	Store BlobStore
+
+	// MaxCacheSize is the upper bound, in bytes, for the total size of
+	// blobs retained in Store. The least recently used blobs are evicted
+	// in order to stay within it.
+	MaxCacheSize int64
+}
+
+// Validate returns an error if the configuration is not valid.
+func (config Config) Validate() error {
+	if config.Context == nil {
+		return errors.NotValidf("nil Context")
+	}
+	if config.Store != nil && config.MaxCacheSize <= 0 {
+		return errors.NotValidf("non-positive MaxCacheSize")
+	}
+	return nil
+}
+
+// cacheEntry records a blob held in the store. The
+// entries are persisted so that the cache outlives the
+// proxy, hence the exported fields.
+type cacheEntry struct {
+	Key    string `json:"key"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// fetchCall is a fetch of a charm revision that is in progress or
+// whose content is still being served. Its result is available once
+// done is closed.
+type fetchCall struct {
+	done   chan struct{}
+	file   *os.File
+	size   int64
+	sha256 string
+	err    error
+
+	// refs counts the callers waiting on or reading the result.
+	// finished is true once the fetch is complete. Both are
+	// guarded by the proxy's mutex; the spooled file is removed
+	// when the fetch is finished and there are no more refs.
+	refs     int
+	finished bool
+}
+
+// Proxy downloads charm blobs, ensuring that concurrent requests for the
+// same charm revision result in a single download. Blobs are spooled to
+// temporary files rather than held in memory. The index of blobs cached
+// in the store is itself saved in the store, so that cached blobs are
+// still used, and evicted, after a restart.
+type Proxy struct {
+	config Config
+
+	// indexMu serialises writes of the index to the store.
+	indexMu sync.Mutex
+
+	// mu guards the fields below it.
+	mu        sync.Mutex
+	inFlight  map[string]*fetchCall
+	entries   map[string]*list.Element
+	lru       *list.List
+	cacheSize int64
+}
+
+// NewProxy returns a new Proxy using the input configuration. If the
+// configuration includes a store, the index of blobs cached by earlier
+// proxies is read from it.
+func NewProxy(config Config) (*Proxy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	p := &Proxy{
+		config:   config,
+		inFlight: make(map[string]*fetchCall),
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+	if config.Store != nil {
+		p.loadIndex()
+	}
+	return p, nil
+}
+
+// Fetch returns the blob for the requested charm revision, from the cache
+// if present, or otherwise from the input source. The download itself is
+// shared with concurrent requests for the same revision, so it is bound by
+// the proxy's context; the input context only bounds the wait for it. The
+// returned blob must be closed once it has been served.
+func (p *Proxy) Fetch(ctx context.Context, req Request, source Source) (*Blob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	key := req.key()
+
+	p.mu.Lock()
+	call, ok := p.inFlight[key]
+	if !ok {
+		call = &fetchCall{done: make(chan struct{})}
+		p.inFlight[key] = call
+		go p.fetch(key, req, source, call)
+	}
+	call.refs++
+	p.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		p.release(call)
+		return nil, errors.Annotatef(ctx.Err(), "fetching charm %q revision %d", req.Name, req.Revision)
+	case <-call.done:
+	}
+	if call.err != nil {
+		p.release(call)
+		return nil, errors.Annotatef(call.err, "fetching charm %q revision %d", req.Name, req.Revision)
+	}
+	return &Blob{
+		ReadSeeker: io.NewSectionReader(call.file, 0, call.size),
+		Size:       call.size,
+		SHA256:     call.sha256,
+		release:    func() { p.release(call) },
+	}, nil
+}
+
+// fetch spools the blob for the input request to a temporary file,
+// from the cache if present or otherwise from the source, and records
+// the result in the input call.
+func (p *Proxy) fetch(key string, req Request, source Source, call *fetchCall) {
+	var ok bool
+	call.file, call.size, call.sha256, ok = p.fromCache(key)
+	if !ok {
+		call.file, call.size, call.sha256, call.err = p.download(p.config.Context, req, source)
+		if call.err == nil {
+			p.addToCache(key, call.file, call.size, call.sha256)
+		}
+	}
+
+	p.mu.Lock()
+	delete(p.inFlight, key)
+	call.finished = true
+	if call.refs == 0 {
+		removeSpool(call.file)
+	}
+	p.mu.Unlock()
+	close(call.done)
+}
+
+// release drops a reference to the result of the input call,
+// removing its spooled file if it is no longer needed.
+func (p *Proxy) release(call *fetchCall) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	call.refs--
+	if call.refs == 0 && call.finished {
+		removeSpool(call.file)
+	}
+}
+
+func (p *Proxy) download(ctx context.Context, req Request, source Source) (*os.File, int64, string, error) {
+	durl, hash, err := source.Resolver.ResolveDownload(ctx, req)
+	if err != nil {
+		return nil, 0, "", errors.Annotate(err, "resolving download")
+	}
+
+	logger.Debugf("downloading charm %q revision %d from %s", req.Name, req.Revision, durl)
+	r, err := source.Downloader.DownloadResource(ctx, durl)
+	if err != nil {
+		return nil, 0, "", errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	f, size, actual, err := spool(r)
+	if err != nil {
+		return nil, 0, "", errors.Annotate(err, "reading download")
+	}
+	if actual != hash {
+		removeSpool(f)
+		return nil, 0, "", errors.Errorf("hash mismatch for %s: expected %q, got %q", durl, hash, actual)
+	}
+	return f, size, hash, nil
+}
+
+func (p *Proxy) fromCache(key string) (*os.File, int64, string, bool) {
+	if p.config.Store == nil {
+		return nil, 0, "", false
+	}
+
+	p.mu.Lock()
+	elem, ok := p.entries[key]
+	if ok {
+		p.lru.MoveToFront(elem)
+	}
+	p.mu.Unlock()
+	if !ok {
+		return nil, 0, "", false
+	}
+	entry := elem.Value.(*cacheEntry)
+
+	r, _, err := p.config.Store.Get(storePath(key))
+	if err != nil {
+		logger.Warningf("reading cached charm blob %q: %v", key, err)
+		p.evict(key)
+		return nil, 0, "", false
+	}
+	defer func() { _ = r.Close() }()
+
+	f, size, sum, err := spool(r)
+	if err != nil {
+		logger.Warningf("reading cached charm blob %q: %v", key, err)
+		p.evict(key)
+		return nil, 0, "", false
+	}
+	if sum != entry.SHA256 {
+		logger.Warningf("cached charm blob %q is corrupt", key)
+		removeSpool(f)
+		p.evict(key)
+		return nil, 0, "", false
+	}
+	return f, size, sum, true
+}
+
+func (p *Proxy) addToCache(key string, f *os.File, size int64, sha256 string) {
+	if p.config.Store == nil || size > p.config.MaxCacheSize {
+		return
+	}
+	if err := p.config.Store.Put(storePath(key), io.NewSectionReader(f, 0, size), size); err != nil {
+		// Failing to cache does not prevent the blob from being served.
+		logger.Warningf("caching charm blob %q: %v", key, err)
+		return
+	}
+
+	p.mu.Lock()
+	if elem, ok := p.entries[key]; ok {
+		p.cacheSize -= elem.Value.(*cacheEntry).Size
+		p.lru.Remove(elem)
+	}
+	p.entries[key] = p.lru.PushFront(&cacheEntry{Key: key, Size: size, SHA256: sha256})
+	p.cacheSize += size
+	evicted := p.shrink()
+	p.mu.Unlock()
+
+	p.saveIndex()
+	for _, k := range evicted {
+		p.removeFromStore(k)
+	}
+}
+
+// shrink evicts the least recently used entries until the cache is
+// within its maximum size, returning the keys of the evicted entries.
+// It must be called with the mutex held.
+func (p *Proxy) shrink() []string {
+	var evicted []string
+	for p.cacheSize > p.config.MaxCacheSize {
+		entry := p.lru.Remove(p.lru.Back()).(*cacheEntry)
+		delete(p.entries, entry.Key)
+		p.cacheSize -= entry.Size
+		evicted = append(evicted, entry.Key)
+	}
+	return evicted
+}
+
+func (p *Proxy) evict(key string) {
+	p.mu.Lock()
+	if elem, ok := p.entries[key]; ok {
+		p.cacheSize -= elem.Value.(*cacheEntry).Size
+		p.lru.Remove(elem)
+		delete(p.entries, key)
+	}
+	p.mu.Unlock()
+
+	p.saveIndex()
+	p.removeFromStore(key)
+}
+
+func (p *Proxy) removeFromStore(key string) {
+	if err := p.config.Store.Remove(storePath(key)); err != nil && !errors.IsNotFound(err) {
+		logger.Warningf("removing cached charm blob %q: %v", key, err)
+	}
+}
+
+// loadIndex reads the index of cached blobs from the store. Blobs
+// that no longer fit within the maximum cache size are evicted.
+func (p *Proxy) loadIndex() {
+	r, _, err := p.config.Store.Get(indexPath)
+	if errors.IsNotFound(err) {
+		return
+	} else if err != nil {
+		logger.Warningf("reading charm blob cache index: %v", err)
+		return
+	}
+	defer func() { _ = r.Close() }()
+
+	var entries []cacheEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		logger.Warningf("reading charm blob cache index: %v", err)
+		return
+	}
+
+	p.mu.Lock()
+	for i := range entries {
+		entry := entries[i]
+		if _, ok := p.entries[entry.Key]; ok {
+			continue
+		}
+		p.entries[entry.Key] = p.lru.PushBack(&entry)
+		p.cacheSize += entry.Size
+	}
+	evicted := p.shrink()
+	p.mu.Unlock()
+
+	if len(evicted) == 0 {
+		return
+	}
+	p.saveIndex()
+	for _, k := range evicted {
+		p.removeFromStore(k)
+	}
+}
+
+// saveIndex writes the index of cached blobs to the store, most
+// recently used first. The order of later cache hits is not saved,
+// as doing so would mean writing the index on every request.
+func (p *Proxy) saveIndex() {
+	p.indexMu.Lock()
+	defer p.indexMu.Unlock()
+
+	p.mu.Lock()
+	entries := make([]cacheEntry, 0, p.lru.Len())
+	for elem := p.lru.Front(); elem != nil; elem = elem.Next() {
+		entries = append(entries, *elem.Value.(*cacheEntry))
+	}
+	p.mu.Unlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		logger.Warningf("saving charm blob cache index: %v", err)
+		return
+	}
+	if err := p.config.Store.Put(indexPath, bytes.NewReader(data), int64(len(data))); err != nil {
+		logger.Warningf("saving charm blob cache index: %v", err)
+	}
+}
+
+// indexPath is the object store path of the index of cached blobs.
+const indexPath = "charmhub-proxy-index"
+
+// storePath returns the object store path for the blob with the input key.
+func storePath(key string) string {
+	return "charmhub-proxy/" + key
+}
+
+// spool copies the content of the input reader to a temporary file,
+// returning the file, the length of the content and its hex-encoded
+// SHA256 hash.
+func spool(r io.Reader) (*os.File, int64, string, error) {
+	f, err := ioutil.TempFile("", "charmhub-proxy-")
+	if err != nil {
+		return nil, 0, "", errors.Trace(err)
+	}
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hasher), r)
+	if err != nil {
+		removeSpool(f)
+		return nil, 0, "", errors.Trace(err)
+	}
+	return f, size, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// removeSpool closes and removes a file created by spool.
+func removeSpool(f *os.File) {
+	if f == nil {
+		return
+	}
+	_ = f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		logger.Warningf("removing spooled charm blob: %v", err)
+	}
+}
+
+// Serve writes the blob to the input response writer.
+// Range requests are honoured, allowing interrupted downloads to resume.
+func Serve(w http.ResponseWriter, r *http.Request, name string, blob *Blob) {
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("ETag", fmt.Sprintf("%q", blob.SHA256))
+	http.ServeContent(w, r, name, time.Time{}, blob)
+}
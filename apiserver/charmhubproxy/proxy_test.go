@@ -0,0 +1,494 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhubproxy_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/charmhubproxy"
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/transport"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type proxySuite struct {
+	testing.IsolationSuite
+
+	source *fakeSource
+	store  *fakeStore
+}
+
+var _ = gc.Suite(&proxySuite{})
+
+func (s *proxySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.source = newFakeSource()
+	s.store = newFakeStore()
+}
+
+func (s *proxySuite) newProxy(c *gc.C, maxSize int64) *charmhubproxy.Proxy {
+	p, err := charmhubproxy.NewProxy(charmhubproxy.Config{
+		Context:      context.Background(),
+		Store:        s.store,
+		MaxCacheSize: maxSize,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return p
+}
+
+func (s *proxySuite) charmSource() charmhubproxy.Source {
+	return charmhubproxy.Source{Resolver: s.source, Downloader: s.source}
+}
+
+func (s *proxySuite) TestValidateConfig(c *gc.C) {
+	_, err := charmhubproxy.NewProxy(charmhubproxy.Config{})
+	c.Assert(err, gc.ErrorMatches, "nil Context not valid")
+
+	_, err = charmhubproxy.NewProxy(charmhubproxy.Config{Context: context.Background(), Store: s.store})
+	c.Assert(err, gc.ErrorMatches, "non-positive MaxCacheSize not valid")
+
+	_, err = charmhubproxy.NewProxy(charmhubproxy.Config{Context: context.Background()})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *proxySuite) TestFetchInvalidRequest(c *gc.C) {
+	p := s.newProxy(c, 1024)
+	err := fetch(p, context.Background(), charmhubproxy.Request{Revision: 1}, s.charmSource())
+	c.Assert(err, gc.ErrorMatches, "empty charm name not valid")
+}
+
+func (s *proxySuite) TestFetchVerifiesHash(c *gc.C) {
+	s.source.add("mysql", "mysql-content")
+	s.source.hashes["mysql"] = "deadbeef"
+
+	p := s.newProxy(c, 1024)
+	err := fetch(p, context.Background(), request("mysql", 1), s.charmSource())
+	c.Assert(err, gc.ErrorMatches, `fetching charm "mysql" revision 1: hash mismatch for .*`)
+	c.Check(s.store.blobs, gc.HasLen, 0)
+}
+
+func (s *proxySuite) TestFetchResolveError(c *gc.C) {
+	s.source.resolveErr = errors.New("boom")
+
+	p := s.newProxy(c, 1024)
+	err := fetch(p, context.Background(), request("mysql", 1), s.charmSource())
+	c.Assert(err, gc.ErrorMatches, `fetching charm "mysql" revision 1: resolving download: boom`)
+}
+
+func (s *proxySuite) TestFetchCachesInStore(c *gc.C) {
+	s.source.add("mysql", "mysql-content")
+
+	p := s.newProxy(c, 1024)
+	blob, err := p.Fetch(context.Background(), request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readBlob(c, blob), gc.Equals, "mysql-content")
+	c.Check(blob.Size, gc.Equals, int64(len("mysql-content")))
+	c.Check(blob.SHA256, gc.Equals, hash("mysql-content"))
+	c.Check(s.store.blobs, gc.HasLen, 2)
+	c.Check(s.store.blobs["charmhub-proxy-index"], gc.NotNil)
+
+	blob, err = p.Fetch(context.Background(), request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readBlob(c, blob), gc.Equals, "mysql-content")
+	c.Check(s.source.downloads(), gc.Equals, 1)
+}
+
+func (s *proxySuite) TestFetchWithoutStore(c *gc.C) {
+	s.source.add("mysql", "mysql-content")
+
+	p, err := charmhubproxy.NewProxy(charmhubproxy.Config{Context: context.Background()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i := 0; i < 2; i++ {
+		blob, err := p.Fetch(context.Background(), request("mysql", 1), s.charmSource())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(readBlob(c, blob), gc.Equals, "mysql-content")
+	}
+	c.Check(s.source.downloads(), gc.Equals, 2)
+}
+
+func (s *proxySuite) TestFetchCorruptCacheRedownloads(c *gc.C) {
+	s.source.add("mysql", "mysql-content")
+
+	p := s.newProxy(c, 1024)
+	err := fetch(p, context.Background(), request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.store.blobs["charmhub-proxy/mysql-1-amd64-ubuntu-focal"] = []byte("garbage")
+
+	blob, err := p.Fetch(context.Background(), request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readBlob(c, blob), gc.Equals, "mysql-content")
+	c.Check(s.source.downloads(), gc.Equals, 2)
+}
+
+func (s *proxySuite) TestCacheEvictsLeastRecentlyUsed(c *gc.C) {
+	s.source.add("mysql", "0123456789")
+	s.source.add("redis", "0123456789")
+	s.source.add("nginx", "0123456789")
+
+	p := s.newProxy(c, 25)
+	ctx := context.Background()
+
+	err := fetch(p, ctx, request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	err = fetch(p, ctx, request("redis", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Use mysql again so that redis is the least recently used.
+	err = fetch(p, ctx, request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.source.downloads(), gc.Equals, 2)
+
+	err = fetch(p, ctx, request("nginx", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.store.blobs, gc.HasLen, 3)
+
+	// Redis was evicted, so is downloaded again. Mysql was not.
+	err = fetch(p, ctx, request("redis", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.source.downloads(), gc.Equals, 4)
+}
+
+func (s *proxySuite) TestCacheSkipsOversizedBlobs(c *gc.C) {
+	s.source.add("mysql", "0123456789")
+
+	p := s.newProxy(c, 5)
+	blob, err := p.Fetch(context.Background(), request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readBlob(c, blob), gc.Equals, "0123456789")
+	c.Check(s.store.blobs, gc.HasLen, 0)
+}
+
+func (s *proxySuite) TestConcurrentFetchesDeduplicated(c *gc.C) {
+	s.source.add("mysql", "mysql-content")
+	s.source.block = make(chan struct{})
+
+	p := s.newProxy(c, 1024)
+
+	const count = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := fetch(p, context.Background(), request("mysql", 1), s.charmSource())
+			errs <- err
+		}()
+	}
+
+	// Wait for the first download to start, then give the other
+	// requests a chance to join it before letting it complete.
+	select {
+	case <-s.source.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for download")
+	}
+	time.Sleep(coretesting.ShortWait)
+	close(s.source.block)
+	wg.Wait()
+
+	close(errs)
+	for err := range errs {
+		c.Check(err, jc.ErrorIsNil)
+	}
+	c.Check(s.source.downloads(), gc.Equals, 1)
+}
+
+func (s *proxySuite) TestCancelledFetchDoesNotAbortSharedDownload(c *gc.C) {
+	s.source.add("mysql", "mysql-content")
+	s.source.block = make(chan struct{})
+
+	p := s.newProxy(c, 1024)
+
+	// The first request starts the download, then goes away.
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		err := fetch(p, ctx, request("mysql", 1), s.charmSource())
+		firstErr <- err
+	}()
+	select {
+	case <-s.source.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for download")
+	}
+
+	secondErr := make(chan error, 1)
+	go func() {
+		err := fetch(p, context.Background(), request("mysql", 1), s.charmSource())
+		secondErr <- err
+	}()
+
+	cancel()
+	select {
+	case err := <-firstErr:
+		c.Assert(err, gc.ErrorMatches, `fetching charm "mysql" revision 1: context canceled`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for cancelled fetch")
+	}
+
+	// The shared download carries on for the remaining request.
+	close(s.source.block)
+	select {
+	case err := <-secondErr:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for fetch")
+	}
+	c.Check(s.source.downloads(), gc.Equals, 1)
+}
+
+func (s *proxySuite) TestCacheIndexSurvivesRestart(c *gc.C) {
+	s.source.add("mysql", "0123456789")
+	s.source.add("redis", "0123456789")
+	ctx := context.Background()
+
+	p := s.newProxy(c, 25)
+	err := fetch(p, ctx, request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	err = fetch(p, ctx, request("redis", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.source.downloads(), gc.Equals, 2)
+
+	// A new proxy using the same store serves the cached blob.
+	p = s.newProxy(c, 25)
+	blob, err := p.Fetch(ctx, request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readBlob(c, blob), gc.Equals, "0123456789")
+	c.Check(s.source.downloads(), gc.Equals, 2)
+}
+
+func (s *proxySuite) TestRestartEvictsBlobsBeyondMaxSize(c *gc.C) {
+	s.source.add("mysql", "0123456789")
+	s.source.add("redis", "0123456789")
+	ctx := context.Background()
+
+	p := s.newProxy(c, 25)
+	err := fetch(p, ctx, request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	err = fetch(p, ctx, request("redis", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the most recently used blob fits within the new limit;
+	// the other is removed from the store rather than orphaned.
+	p = s.newProxy(c, 15)
+	c.Check(s.store.blobs, gc.HasLen, 2)
+	c.Check(s.store.blobs["charmhub-proxy/mysql-1-amd64-ubuntu-focal"], gc.IsNil)
+
+	err = fetch(p, ctx, request("redis", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.source.downloads(), gc.Equals, 2)
+}
+
+func (s *proxySuite) TestSpooledContentRemovedOnClose(c *gc.C) {
+	dir := c.MkDir()
+	s.PatchEnvironment("TMPDIR", dir)
+	s.source.add("mysql", "mysql-content")
+
+	p := s.newProxy(c, 1024)
+	blob, err := p.Fetch(context.Background(), request("mysql", 1), s.charmSource())
+	c.Assert(err, jc.ErrorIsNil)
+	files, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(files, gc.HasLen, 1)
+
+	c.Check(readBlob(c, blob), gc.Equals, "mysql-content")
+	files, err = ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(files, gc.HasLen, 0)
+}
+
+func (s *proxySuite) TestServeRange(c *gc.C) {
+	blob := &charmhubproxy.Blob{
+		ReadSeeker: strings.NewReader("0123456789"),
+		Size:       10,
+		SHA256:     hash("0123456789"),
+	}
+
+	req := httptest.NewRequest("GET", "/charmhub/mysql/1", nil)
+	req.Header.Set("Range", "bytes=4-")
+	rec := httptest.NewRecorder()
+	charmhubproxy.Serve(rec, req, "mysql.charm", blob)
+
+	c.Check(rec.Code, gc.Equals, http.StatusPartialContent)
+	c.Check(rec.Body.String(), gc.Equals, "456789")
+	c.Check(rec.Header().Get("Content-Range"), gc.Equals, "bytes 4-9/10")
+
+	req = httptest.NewRequest("GET", "/charmhub/mysql/1", nil)
+	rec = httptest.NewRecorder()
+	charmhubproxy.Serve(rec, req, "mysql.charm", blob)
+
+	c.Check(rec.Code, gc.Equals, http.StatusOK)
+	c.Check(rec.Body.String(), gc.Equals, "0123456789")
+}
+
+func (s *proxySuite) TestCharmHubSource(c *gc.C) {
+	client := &fakeCharmHubClient{
+		responses: []transport.RefreshResponse{{
+			Entity: transport.RefreshEntity{
+				Download: transport.Download{
+					URL:        "https://api.example.com/mysql_1.charm",
+					HashSHA256: hash("mysql-content"),
+				},
+			},
+		}},
+	}
+	source := charmhubproxy.NewCharmHubSource(client)
+
+	durl, sum, err := source.Resolver.ResolveDownload(context.Background(), request("mysql", 1))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(durl.String(), gc.Equals, "https://api.example.com/mysql_1.charm")
+	c.Check(sum, gc.Equals, hash("mysql-content"))
+
+	client.responses[0].Error = &transport.APIError{Code: "revision-not-found", Message: "no such revision"}
+	_, _, err = source.Resolver.ResolveDownload(context.Background(), request("mysql", 1))
+	c.Assert(err, gc.ErrorMatches, "revision-not-found: no such revision")
+}
+
+func request(name string, revision int) charmhubproxy.Request {
+	return charmhubproxy.Request{
+		Name:     name,
+		Revision: revision,
+		Platform: charmhub.RefreshPlatform{Architecture: "amd64", OS: "ubuntu", Series: "focal"},
+	}
+}
+
+// fetch fetches the requested blob, closing it straight away.
+func fetch(p *charmhubproxy.Proxy, ctx context.Context, req charmhubproxy.Request, source charmhubproxy.Source) error {
+	blob, err := p.Fetch(ctx, req, source)
+	if err != nil {
+		return err
+	}
+	return blob.Close()
+}
+
+// readBlob returns the content of the input blob, closing it.
+func readBlob(c *gc.C, blob *charmhubproxy.Blob) string {
+	defer func() { _ = blob.Close() }()
+	data, err := ioutil.ReadAll(blob)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
+func hash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+type fakeSource struct {
+	mu         sync.Mutex
+	content    map[string]string
+	hashes     map[string]string
+	resolveErr error
+	count      int
+
+	block   chan struct{}
+	started chan struct{}
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		content: make(map[string]string),
+		hashes:  make(map[string]string),
+		started: make(chan struct{}, 10),
+	}
+}
+
+func (s *fakeSource) add(name, content string) {
+	s.content[name] = content
+	s.hashes[name] = hash(content)
+}
+
+func (s *fakeSource) downloads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+func (s *fakeSource) ResolveDownload(_ context.Context, req charmhubproxy.Request) (*url.URL, string, error) {
+	if s.resolveErr != nil {
+		return nil, "", s.resolveErr
+	}
+	durl, err := url.Parse("https://api.example.com/" + req.Name)
+	return durl, s.hashes[req.Name], err
+}
+
+func (s *fakeSource) DownloadResource(ctx context.Context, durl *url.URL) (io.ReadCloser, error) {
+	s.mu.Lock()
+	s.count++
+	s.mu.Unlock()
+
+	s.started <- struct{}{}
+	if s.block != nil {
+		select {
+		case <-s.block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return ioutil.NopCloser(bytes.NewReader([]byte(s.content[durl.Path[1:]]))), nil
+}
+
+type fakeStore struct {
+	blobs map[string][]byte
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{blobs: make(map[string][]byte)}
+}
+
+func (s *fakeStore) Get(path string) (io.ReadCloser, int64, error) {
+	data, ok := s.blobs[path]
+	if !ok {
+		return nil, -1, errors.NotFoundf("blob %q", path)
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+func (s *fakeStore) Put(path string, r io.Reader, _ int64) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.blobs[path] = data
+	return nil
+}
+
+func (s *fakeStore) Remove(path string) error {
+	if _, ok := s.blobs[path]; !ok {
+		return errors.NotFoundf("blob %q", path)
+	}
+	delete(s.blobs, path)
+	return nil
+}
+
+type fakeCharmHubClient struct {
+	responses []transport.RefreshResponse
+}
+
+func (c *fakeCharmHubClient) Refresh(context.Context, charmhub.RefreshConfig) ([]transport.RefreshResponse, error) {
+	return c.responses, nil
+}
+
+func (c *fakeCharmHubClient) DownloadResource(context.Context, *url.URL) (io.ReadCloser, error) {
+	return nil, errors.NotImplementedf("DownloadResource")
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhubproxy
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/transport"
+)
+
+// CharmHubClient describes the CharmHub client methods used to locate
+// and download charm blobs.
+type CharmHubClient interface {
+	Refresh(ctx context.Context, config charmhub.RefreshConfig) ([]transport.RefreshResponse, error)
+	DownloadResource(ctx context.Context, resourceURL *url.URL) (io.ReadCloser, error)
+}
+
+// NewCharmHubSource returns a Source that resolves and downloads
+// charm blobs using the input CharmHub client.
+func NewCharmHubSource(client CharmHubClient) Source {
+	return Source{
+		Resolver:   charmHubResolver{client: client},
+		Downloader: client,
+	}
+}
+
+type charmHubResolver struct {
+	client CharmHubClient
+}
+
+// ResolveDownload is part of the Resolver interface.
+func (r charmHubResolver) ResolveDownload(ctx context.Context, req Request) (*url.URL, string, error) {
+	cfg, err := charmhub.InstallOneFromRevision(req.Name, req.Revision, req.Platform)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	results, err := r.client.Refresh(ctx, cfg)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return nil, "", errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	result := results[0]
+	if result.Error != nil {
+		return nil, "", errors.Errorf("%s: %s", result.Error.Code, result.Error.Message)
+	}
+
+	durl, err := url.Parse(result.Entity.Download.URL)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return durl, result.Entity.Download.HashSHA256, nil
+}
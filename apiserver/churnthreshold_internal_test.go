@@ -0,0 +1,43 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/testing"
+)
+
+type churnThresholdSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&churnThresholdSuite{})
+
+func (s *churnThresholdSuite) TestUpdateAgentChurnThreshold(c *gc.C) {
+	recorder := presence.New(testclock.NewClock(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)))
+	recorder.Enable()
+	srv := &Server{shared: &sharedServerContext{presence: recorder}}
+
+	recorder.Connect("machine-0", "model-uuid", "unit-mysql-0", 1, false, "")
+	recorder.Disconnect("machine-0", 1)
+	connections := recorder.Connections().ForModel("model-uuid")
+	flapping, err := connections.Flapping("unit-mysql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsFalse)
+
+	srv.updateAgentChurnThreshold(controller.Config{
+		controller.AgentChurnDisconnects: 1,
+		controller.AgentChurnWindow:      time.Hour,
+	})
+	flapping, err = connections.Flapping("unit-mysql-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsTrue)
+}
@@ -506,6 +506,10 @@ type offerGetter interface {
 // model from the model cache.
 type CachedModel interface {
 	Application(string) (CachedApplication, error)
+
+	// Degraded returns true if the cache may be stale,
+	// in which case the model should be read from state.
+	Degraded() bool
 }
 
 // CachedApplication represents the methods that the StatusAPI needs on
@@ -545,11 +549,15 @@ func GetOfferStatusChange(model CachedModel, st offerGetter, offerUUID, offerNam
 
 	// We use the status from the cached application as that is where
 	// the derived status from the units are handled if this is necessary.
+	// While the cache is degraded, the status is derived from state.
 	sts := status.StatusInfo{
 		Status: status.Unknown,
 	}
-	cachedApp, err := model.Application(app.Name())
-	if err == nil {
+	if model.Degraded() {
+		if info, err := app.DerivedStatus(); err == nil {
+			sts = info
+		}
+	} else if cachedApp, err := model.Application(app.Name()); err == nil {
 		sts = cachedApp.Status()
 	}
 	return &params.OfferStatusChange{
@@ -577,3 +585,8 @@ func (c CacheShim) Application(name string) (CachedApplication, error) {
 	}
 	return &app, nil
 }
+
+// Degraded returns true if the cache may be stale.
+func (c CacheShim) Degraded() bool {
+	return c.Model.Degraded()
+}
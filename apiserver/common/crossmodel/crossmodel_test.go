@@ -67,6 +67,20 @@ func (s *crossmodelSuite) TestGetOfferStatusChangeApplicationGone(c *gc.C) {
 	})
 }
 
+func (s *crossmodelSuite) TestGetOfferStatusChangeCacheDegraded(c *gc.C) {
+	st := &mockBackend{appName: "mysql"}
+	cache := &fakeCachedModel{
+		info:     status.StatusInfo{Status: status.Active},
+		degraded: true,
+	}
+	ch, err := crossmodel.GetOfferStatusChange(cache, st, "deadbeef", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch, gc.DeepEquals, &params.OfferStatusChange{
+		OfferName: "mysql",
+		Status:    params.EntityStatus{Status: status.Blocked, Info: "from state"},
+	})
+}
+
 func (s *crossmodelSuite) TestGetOfferStatusChange(c *gc.C) {
 	st := &mockBackend{appName: "mysql"}
 	cache := &fakeCachedModel{
@@ -127,9 +141,14 @@ func (a *mockApplication) Name() string {
 	return a.name
 }
 
+func (a *mockApplication) DerivedStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: status.Blocked, Message: "from state"}, nil
+}
+
 type fakeCachedModel struct {
-	err  error
-	info status.StatusInfo
+	err      error
+	info     status.StatusInfo
+	degraded bool
 }
 
 func (f *fakeCachedModel) Application(name string) (crossmodel.CachedApplication, error) {
@@ -142,3 +161,7 @@ func (f *fakeCachedModel) Application(name string) (crossmodel.CachedApplication
 func (f *fakeCachedModel) Status() status.StatusInfo {
 	return f.info
 }
+
+func (f *fakeCachedModel) Degraded() bool {
+	return f.degraded
+}
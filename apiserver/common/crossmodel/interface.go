@@ -213,6 +213,10 @@ type Application interface {
 
 	// Status returns the status of the application.
 	Status() (status.StatusInfo, error)
+
+	// DerivedStatus returns the status of the application, which
+	// is derived from the status of its units if unset.
+	DerivedStatus() (status.StatusInfo, error)
 }
 
 // Bindings defines a subset of the functionality provided by the
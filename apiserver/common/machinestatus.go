@@ -4,6 +4,8 @@
 package common
 
 import (
+	"github.com/juju/names/v4"
+
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
@@ -39,6 +41,8 @@ func (c *ModelPresenceContext) MachineStatus(machine MachineStatusGetter) (statu
 	if machine.Life() != state.Dead && !agentAlive {
 		machineStatus.Status = status.Down
 		machineStatus.Message = "agent is not communicating with the server"
+	} else if agentAlive && c.flapping(names.NewMachineTag(machine.Id()).String()) {
+		annotateUnstableConnection(&machineStatus)
 	}
 	return machineStatus, nil
 }
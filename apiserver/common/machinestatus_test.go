@@ -61,6 +61,16 @@ func (s *MachineStatusSuite) TestDown(c *gc.C) {
 	})
 }
 
+func (s *MachineStatusSuite) TestFlapping(c *gc.C) {
+	s.ctx.Presence = agentFlapping(names.NewMachineTag(s.machine.Id()).String())
+	agent, err := s.ctx.MachineStatus(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agent, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Started,
+		Message: "unstable connection",
+	})
+}
+
 func (s *MachineStatusSuite) TestDownAndDead(c *gc.C) {
 	s.ctx.Presence = agentDown(names.NewMachineTag(s.machine.Id()).String())
 	s.machine.life = state.Dead
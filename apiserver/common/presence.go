@@ -4,16 +4,27 @@
 package common
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/status"
 )
 
+// unstableConnectionMessage is added to the status message of agents
+// that are alive, but have been repeatedly disconnecting.
+const unstableConnectionMessage = "unstable connection"
+
 // ModelPresence represents the API server connections for a model.
 type ModelPresence interface {
 	// For a given non controller agent, return the Status for that agent.
 	AgentStatus(agent string) (presence.Status, error)
+
+	// Flapping returns whether the given non controller agent
+	// has been repeatedly disconnecting from the API servers.
+	Flapping(agent string) (bool, error)
 }
 
 // ModelPresenceContext represents the known agent presence state for the
@@ -30,6 +41,17 @@ func (c *ModelPresenceContext) machinePresence(machine MachineStatusGetter) (boo
 }
 
 func (c *ModelPresenceContext) unitPresence(unit UnitStatusGetter) (bool, error) {
+	agent, err := unitAgent(unit)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	status, err := c.Presence.AgentStatus(agent)
+	return status == presence.Alive, err
+}
+
+// unitAgent returns the tag of the agent whose presence
+// determines whether the input unit's agent is alive.
+func unitAgent(unit UnitStatusGetter) (string, error) {
 	agent := names.NewUnitTag(unit.Name()).String()
 	if !unit.ShouldBeAssigned() {
 		embedded, err := unit.IsEmbedded()
@@ -46,6 +68,77 @@ func (c *ModelPresenceContext) unitPresence(unit UnitStatusGetter) (bool, error)
 			agent = names.NewApplicationTag(appName).String()
 		}
 	}
-	status, err := c.Presence.AgentStatus(agent)
-	return status == presence.Alive, err
+	return agent, nil
+}
+
+// flapping returns whether the input agent has been repeatedly
+// disconnecting. As with presence, errors are logged rather
+// than being allowed to affect status.
+func (c *ModelPresenceContext) flapping(agent string) bool {
+	flapping, err := c.Presence.Flapping(agent)
+	if err != nil {
+		logger.Debugf("error determining connection stability for %s: %v", agent, err)
+		return false
+	}
+	return flapping
+}
+
+// annotateUnstableConnection adds a note to the input
+// status message that the agent's connection is unstable.
+func annotateUnstableConnection(info *status.StatusInfo) {
+	if info.Message == "" {
+		info.Message = unstableConnectionMessage
+		return
+	}
+	info.Message = fmt.Sprintf("%s (%s)", info.Message, unstableConnectionMessage)
+}
+
+// PresenceCounts holds the number of entities of a given kind whose agents
+// are present or absent.
+type PresenceCounts struct {
+	Present int
+	Absent  int
+
+	// Errors holds any errors encountered when determining presence,
+	// keyed by entity tag. Entities in this map are counted as absent.
+	Errors map[string]error
+}
+
+// PresenceSummary holds aggregate agent presence counts for a model,
+// broken down by machines and units.
+type PresenceSummary struct {
+	Machines PresenceCounts
+	Units    PresenceCounts
+}
+
+// PresenceSummary returns the aggregate presence of the agents for the
+// input machines and units. An error determining the presence of an
+// agent is not fatal; the entity is counted as absent and the error
+// recorded in the summary.
+func (c *ModelPresenceContext) PresenceSummary(machines []MachineStatusGetter, units []UnitStatusGetter) PresenceSummary {
+	summary := PresenceSummary{
+		Machines: PresenceCounts{Errors: make(map[string]error)},
+		Units:    PresenceCounts{Errors: make(map[string]error)},
+	}
+	for _, m := range machines {
+		present, err := c.machinePresence(m)
+		summary.Machines.add(names.NewMachineTag(m.Id()).String(), present, err)
+	}
+	for _, u := range units {
+		present, err := c.unitPresence(u)
+		summary.Units.add(names.NewUnitTag(u.Name()).String(), present, err)
+	}
+	return summary
+}
+
+func (p *PresenceCounts) add(tag string, present bool, err error) {
+	if err != nil {
+		p.Errors[tag] = err
+		present = false
+	}
+	if present {
+		p.Present++
+	} else {
+		p.Absent++
+	}
 }
@@ -7,6 +7,8 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/core/presence"
@@ -24,10 +26,15 @@ func presenceError(agent string) common.ModelPresence {
 	return &fakeModelPresence{err: errors.New("boom"), agent: agent}
 }
 
+func agentFlapping(agent string) common.ModelPresence {
+	return &fakeModelPresence{status: presence.Alive, agent: agent, flapping: true}
+}
+
 type fakeModelPresence struct {
-	agent  string
-	status presence.Status
-	err    error
+	agent    string
+	status   presence.Status
+	flapping bool
+	err      error
 }
 
 func (f *fakeModelPresence) AgentStatus(agent string) (presence.Status, error) {
@@ -36,3 +43,87 @@ func (f *fakeModelPresence) AgentStatus(agent string) (presence.Status, error) {
 	}
 	return f.status, f.err
 }
+
+func (f *fakeModelPresence) Flapping(agent string) (bool, error) {
+	if agent != f.agent {
+		return false, fmt.Errorf("unexpected agent %v, expected %v", agent, f.agent)
+	}
+	return f.flapping, f.err
+}
+
+type fakeMultiModelPresence struct {
+	status map[string]presence.Status
+	errs   map[string]error
+}
+
+func (f *fakeMultiModelPresence) AgentStatus(agent string) (presence.Status, error) {
+	if err, ok := f.errs[agent]; ok {
+		return presence.Unknown, err
+	}
+	if status, ok := f.status[agent]; ok {
+		return status, nil
+	}
+	return presence.Missing, nil
+}
+
+func (f *fakeMultiModelPresence) Flapping(agent string) (bool, error) {
+	return false, nil
+}
+
+type PresenceSummarySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PresenceSummarySuite{})
+
+func (s *PresenceSummarySuite) TestPresenceSummary(c *gc.C) {
+	ctx := common.ModelPresenceContext{
+		Presence: &fakeMultiModelPresence{
+			status: map[string]presence.Status{
+				"machine-0":        presence.Alive,
+				"machine-1":        presence.Alive,
+				"machine-2":        presence.Missing,
+				"unit-mysql-2":     presence.Alive,
+				"unit-wordpress-2": presence.Missing,
+				// CAAS units rely on the application operator's presence.
+				"application-gitlab": presence.Alive,
+			},
+			errs: map[string]error{
+				"machine-3":    errors.New("boom"),
+				"unit-redis-2": errors.New("bang"),
+			},
+		},
+	}
+	machines := []common.MachineStatusGetter{
+		&mockMachine{id: "0"},
+		&mockMachine{id: "1"},
+		&mockMachine{id: "2"},
+		&mockMachine{id: "3"},
+	}
+	units := []common.UnitStatusGetter{
+		&fakeStatusUnit{app: "mysql", shouldBeAssigned: true},
+		&fakeStatusUnit{app: "wordpress", shouldBeAssigned: true},
+		&fakeStatusUnit{app: "redis", shouldBeAssigned: true},
+		&fakeStatusUnit{app: "gitlab"},
+	}
+
+	summary := ctx.PresenceSummary(machines, units)
+	c.Check(summary.Machines.Present, gc.Equals, 2)
+	c.Check(summary.Machines.Absent, gc.Equals, 2)
+	c.Check(summary.Machines.Errors, gc.HasLen, 1)
+	c.Check(summary.Machines.Errors["machine-3"], gc.ErrorMatches, "boom")
+
+	c.Check(summary.Units.Present, gc.Equals, 2)
+	c.Check(summary.Units.Absent, gc.Equals, 2)
+	c.Check(summary.Units.Errors, gc.HasLen, 1)
+	c.Check(summary.Units.Errors["unit-redis-2"], gc.ErrorMatches, "bang")
+}
+
+func (s *PresenceSummarySuite) TestPresenceSummaryEmpty(c *gc.C) {
+	ctx := common.ModelPresenceContext{Presence: &fakeMultiModelPresence{}}
+	summary := ctx.PresenceSummary(nil, nil)
+	c.Check(summary.Machines.Present+summary.Machines.Absent, gc.Equals, 0)
+	c.Check(summary.Units.Present+summary.Units.Absent, gc.Equals, 0)
+	c.Check(summary.Machines.Errors, gc.HasLen, 0)
+	c.Check(summary.Units.Errors, gc.HasLen, 0)
+}
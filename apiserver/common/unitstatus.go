@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/charm/v9/hooks"
 
+	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -42,10 +43,15 @@ func (c *ModelPresenceContext) UnitStatus(unit UnitStatusGetter) (agent StatusAn
 		return
 	}
 
-	agentAlive, err := c.unitPresence(unit)
+	agentTag, err := unitAgent(unit)
 	if err != nil {
 		return
 	}
+	agentStatus, err := c.Presence.AgentStatus(agentTag)
+	if err != nil {
+		return
+	}
+	agentAlive := agentStatus == presence.Alive
 	if unit.Life() != state.Dead && !agentAlive {
 		// If the unit is in error, it would be bad to throw away
 		// the error information as when the agent reconnects, that
@@ -56,6 +62,8 @@ func (c *ModelPresenceContext) UnitStatus(unit UnitStatusGetter) (agent StatusAn
 		}
 		agent.Status.Status = status.Lost
 		agent.Status.Message = "agent is not communicating with the server"
+	} else if agentAlive && c.flapping(agentTag) {
+		annotateUnstableConnection(&agent.Status)
 	}
 	return
 }
@@ -95,6 +95,18 @@ func (s *UnitStatusSuite) TestCAASLost(c *gc.C) {
 	s.checkLost(c)
 }
 
+func (s *UnitStatusSuite) TestFlapping(c *gc.C) {
+	s.ctx.Presence = agentFlapping(s.unit.Tag().String())
+	agent, workload := s.ctx.UnitStatus(s.unit)
+	c.Check(agent.Status, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Started,
+		Message: "agent ok (unstable connection)",
+	})
+	c.Check(agent.Err, jc.ErrorIsNil)
+	c.Check(workload.Status, jc.DeepEquals, s.unit.status)
+	c.Check(workload.Err, jc.ErrorIsNil)
+}
+
 func (s *UnitStatusSuite) TestLostAndDead(c *gc.C) {
 	s.ctx.Presence = agentDown(s.unit.Tag().String())
 	s.unit.life = state.Dead
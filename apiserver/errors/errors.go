@@ -111,6 +111,25 @@ func IsUpgradeSeriesValidationError(err error) bool {
 	return ok
 }
 
+// RequestTooLargeError is the error returned when the body of an HTTP
+// request exceeds the maximum size accepted by the endpoint.
+type RequestTooLargeError struct {
+	// MaxSize is the maximum size in bytes of the request body.
+	MaxSize int64
+}
+
+// Error implements the error interface.
+func (e *RequestTooLargeError) Error() string {
+	return fmt.Sprintf("request body exceeds the maximum size of %d bytes", e.MaxSize)
+}
+
+// IsRequestTooLargeError reports whether the cause
+// of the error is a *RequestTooLargeError.
+func IsRequestTooLargeError(err error) bool {
+	_, ok := errors.Cause(err).(*RequestTooLargeError)
+	return ok
+}
+
 // RedirectError is the error returned when a model (previously accessible by
 // the user) has been migrated to a different controller.
 type RedirectError struct {
@@ -242,6 +261,8 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 		status = http.StatusServiceUnavailable
 	case params.CodeRedirect:
 		status = http.StatusMovedPermanently
+	case params.CodeRequestTooLarge:
+		status = http.StatusRequestEntityTooLarge
 	}
 	return err1, status
 }
@@ -341,6 +362,11 @@ func ServerError(err error) *params.Error {
 		}.AsMap()
 	case errors.IsQuotaLimitExceeded(err):
 		code = params.CodeQuotaLimitExceeded
+	case IsRequestTooLargeError(err):
+		code = params.CodeRequestTooLarge
+		info = params.RequestTooLargeErrorInfo{
+			MaxSize: errors.Cause(err).(*RequestTooLargeError).MaxSize,
+		}.AsMap()
 	case params.IsIncompatibleClientError(err):
 		code = params.CodeIncompatibleClient
 		rawErr := errors.Cause(err).(*params.IncompatibleClientError)
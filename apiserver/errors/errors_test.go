@@ -215,6 +215,15 @@ var errorTransformTests = []struct {
 	code:       params.CodeQuotaLimitExceeded,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeQuotaLimitExceeded,
+}, {
+	err:    errors.Annotate(&apiservererrors.RequestTooLargeError{MaxSize: 1024}, "processing upload"),
+	code:   params.CodeRequestTooLarge,
+	status: http.StatusRequestEntityTooLarge,
+	helperFunc: func(err error) bool {
+		err1, ok := err.(*params.Error)
+		exp := asMap(params.RequestTooLargeErrorInfo{MaxSize: 1024})
+		return ok && params.IsCodeRequestTooLarge(err) && reflect.DeepEqual(err1.Info, exp)
+	},
 }, {
 	err: &params.IncompatibleClientError{
 		ServerVersion: jujuversion.Current,
@@ -298,7 +307,8 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeModelNotFound,
 			params.CodeRetry,
 			params.CodeRedirect,
-			params.CodeIncompatibleClient:
+			params.CodeIncompatibleClient,
+			params.CodeRequestTooLarge:
 			continue
 		case params.CodeOperationBlocked:
 			// ServerError doesn't actually have a case for this code.
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/clock"
 
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -26,6 +27,7 @@ type Context struct {
 	MultiwatcherFactory_ multiwatcher.Factory
 	ID_                  string
 	Cancel_              <-chan struct{}
+	CharmhubInfoCache_   *charmhub.InfoCache
 
 	LeadershipClaimer_ leadership.Claimer
 	LeadershipRevoker_ leadership.Revoker
@@ -58,6 +60,11 @@ func (context Context) Hub() facade.Hub {
 	return context.Hub_
 }
 
+// CharmhubInfoCache is part of the facade.Context interface.
+func (context Context) CharmhubInfoCache() *charmhub.InfoCache {
+	return context.CharmhubInfoCache_
+}
+
 // Controller is part of the facade.Context interface.
 func (context Context) Controller() *cache.Controller {
 	return context.Controller_
@@ -6,6 +6,7 @@ package facade
 import (
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -95,6 +96,10 @@ type Context interface {
 	// At least at this stage, facades only need to publish events.
 	Hub() Hub
 
+	// CharmhubInfoCache returns the cache of charmhub info responses
+	// that is shared by all the models on the controller.
+	CharmhubInfoCache() *charmhub.InfoCache
+
 	// ID returns a string that should almost always be "", unless
 	// this is a watcher facade, in which case it exists in lieu of
 	// actual arguments in the Next() call, and is used as a key
@@ -206,6 +211,10 @@ type Presence interface {
 type ModelPresence interface {
 	// For a given non controller agent, return the Status for that agent.
 	AgentStatus(agent string) (presence.Status, error)
+
+	// Flapping returns whether the given non controller agent
+	// has been repeatedly disconnecting from the API servers.
+	Flapping(agent string) (bool, error)
 }
 
 // Hub represents the central hub that the API server has.
@@ -83,6 +83,7 @@ func (b *backend) ModelCredential() (*ModelCredential, error) {
 		// TODO (anastasiamac 2018-11-12) Figure out how to notify the users here - maybe set a model status?...
 		logger.Warningf("cloud credential reference is set for the model but the credential content is no longer on the controller")
 		result.Valid = false
+		result.Missing = true
 		return result, nil
 	}
 	result.Valid = credential.IsValid()
@@ -137,4 +138,8 @@ type ModelCredential struct {
 	// If a model is on the cloud that does require credential and
 	// the model's credential is not set, this property will be set to 'false'.
 	Valid bool
+
+	// Missing indicates that the model refers to a cloud credential
+	// whose content is no longer on the controller.
+	Missing bool
 }
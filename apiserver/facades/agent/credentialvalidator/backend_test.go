@@ -89,6 +89,7 @@ func (s *BackendSuite) TestModelCredentialSetButCloudCredentialNotFound(c *gc.C)
 			Exists:     true,
 			Credential: s.state.aModel.credentialTag,
 			Valid:      expected,
+			Missing:    !expected,
 		})
 		s.state.CheckCallNames(c, "Model", "mockModel.CloudCredentialTag", "ModelTag", "mockState.CloudCredentialTag")
 		s.state.ResetCalls()
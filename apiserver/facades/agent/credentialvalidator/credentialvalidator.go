@@ -121,6 +121,7 @@ func (api *CredentialValidatorAPI) ModelCredential() (params.ModelCredential, er
 		CloudCredential: c.Credential.String(),
 		Exists:          c.Exists,
 		Valid:           c.Valid,
+		Missing:         c.Missing,
 	}, nil
 }
 
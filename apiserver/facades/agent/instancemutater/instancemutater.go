@@ -163,7 +163,15 @@ func (api *InstanceMutaterAPI) ContainerType(arg params.Entity) (params.Containe
 		result.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
 		return result, nil
 	}
-	m, err := api.getCacheMachine(canAccess, tag)
+	// While the cache may be stale, the machine is read from state.
+	var m interface {
+		ContainerType() instance.ContainerType
+	}
+	if api.model.Degraded() {
+		m, err = api.getMachine(canAccess, tag)
+	} else {
+		m, err = api.getCacheMachine(canAccess, tag)
+	}
 	if err != nil {
 		result.Error = apiservererrors.ServerError(err)
 		return result, nil
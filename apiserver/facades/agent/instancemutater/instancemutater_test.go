@@ -641,6 +641,58 @@ func (s *InstanceMutaterAPISetModificationStatusSuite) expectSetModificationStat
 	}).Return(err)
 }
 
+type InstanceMutaterAPIContainerTypeSuite struct {
+	instanceMutaterAPISuite
+
+	cacheMachine *mocks.MockModelCacheMachine
+	machine      *mocks.MockMachine
+}
+
+var _ = gc.Suite(&InstanceMutaterAPIContainerTypeSuite{})
+
+func (s *InstanceMutaterAPIContainerTypeSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := s.instanceMutaterAPISuite.setup(c)
+
+	s.cacheMachine = mocks.NewMockModelCacheMachine(ctrl)
+	s.machine = mocks.NewMockMachine(ctrl)
+
+	return ctrl
+}
+
+func (s *InstanceMutaterAPIContainerTypeSuite) TestContainerType(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthMachineAgent()
+	s.expectLife(s.machineTag)
+	s.model.EXPECT().Degraded().Return(false)
+	s.model.EXPECT().Machine("0").Return(s.cacheMachine, nil)
+	s.cacheMachine.EXPECT().ContainerType().Return(instance.LXD)
+	facade := s.facadeAPIForScenario(c)
+
+	result, err := facade.ContainerType(params.Entity{Tag: "machine-0"})
+	c.Assert(err, gc.IsNil)
+	c.Assert(result, gc.DeepEquals, params.ContainerTypeResult{Type: instance.LXD})
+}
+
+func (s *InstanceMutaterAPIContainerTypeSuite) TestContainerTypeCacheDegraded(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	s.expectAuthMachineAgent()
+	s.expectLife(s.machineTag)
+	s.model.EXPECT().Degraded().Return(true)
+	s.expectFindEntity(s.machineTag, machineEntityShim{
+		Machine: s.machine,
+		Entity:  s.entity,
+		Lifer:   s.lifer,
+	})
+	s.machine.EXPECT().ContainerType().Return(instance.LXD)
+	facade := s.facadeAPIForScenario(c)
+
+	result, err := facade.ContainerType(params.Entity{Tag: "machine-0"})
+	c.Assert(err, gc.IsNil)
+	c.Assert(result, gc.DeepEquals, params.ContainerTypeResult{Type: instance.LXD})
+}
+
 type InstanceMutaterAPIWatchMachinesSuite struct {
 	instanceMutaterAPISuite
 
@@ -26,6 +26,7 @@ type InstanceMutaterState interface {
 
 // Machine represents point of use methods from the state Machine object.
 type Machine interface {
+	ContainerType() instance.ContainerType
 	InstanceId() (instance.Id, error)
 	CharmProfiles() ([]string, error)
 	SetCharmProfiles([]string) error
@@ -51,6 +52,7 @@ type Application interface {
 // ModelCache represents point of use methods from the cache
 // model
 type ModelCache interface {
+	Degraded() bool
 	Name() string
 	Machine(machineId string) (ModelCacheMachine, error)
 	WatchMachines() (cache.StringsWatcher, error)
@@ -138,6 +138,20 @@ func (mr *MockMachineMockRecorder) CharmProfiles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmProfiles", reflect.TypeOf((*MockMachine)(nil).CharmProfiles))
 }
 
+// ContainerType mocks base method
+func (m *MockMachine) ContainerType() instance.ContainerType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContainerType")
+	ret0, _ := ret[0].(instance.ContainerType)
+	return ret0
+}
+
+// ContainerType indicates an expected call of ContainerType
+func (mr *MockMachineMockRecorder) ContainerType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContainerType", reflect.TypeOf((*MockMachine)(nil).ContainerType))
+}
+
 // InstanceId mocks base method
 func (m *MockMachine) InstanceId() (instance.Id, error) {
 	m.ctrl.T.Helper()
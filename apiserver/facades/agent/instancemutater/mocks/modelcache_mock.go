@@ -35,6 +35,20 @@ func (m *MockModelCache) EXPECT() *MockModelCacheMockRecorder {
 	return m.recorder
 }
 
+// Degraded mocks base method
+func (m *MockModelCache) Degraded() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Degraded")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Degraded indicates an expected call of Degraded
+func (mr *MockModelCacheMockRecorder) Degraded() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Degraded", reflect.TypeOf((*MockModelCache)(nil).Degraded))
+}
+
 // Machine mocks base method
 func (m *MockModelCache) Machine(arg0 string) (instancemutater.ModelCacheMachine, error) {
 	m.ctrl.T.Helper()
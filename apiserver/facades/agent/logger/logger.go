@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
 )
 
 // Logger defines the methods on the logger API end point.  Unfortunately, the
@@ -28,6 +29,7 @@ type Logger interface {
 type LoggerAPI struct {
 	controller *cache.Controller
 	model      *cache.Model
+	st         *state.State
 	resources  facade.Resources
 	authorizer facade.Authorizer
 }
@@ -54,6 +56,7 @@ func NewLoggerAPI(ctx facade.Context) (*LoggerAPI, error) {
 	return &LoggerAPI{
 		controller: ctx.Controller(),
 		model:      m,
+		st:         st,
 		resources:  resources,
 		authorizer: authorizer,
 	}, nil
@@ -73,7 +76,12 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 		}
 		err = apiservererrors.ErrPerm
 		if api.authorizer.AuthOwner(tag) {
-			watch := api.model.WatchConfig("logging-config")
+			var watch configWatcher
+			watch, err = api.watchLoggingConfig()
+			if err != nil {
+				result[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
 			// Consume the initial event. Technically, API calls to Watch
 			// 'transmit' the initial event in the Watch response. But
 			// NotifyWatchers have no state to transmit.
@@ -89,14 +97,34 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 	return params.NotifyWatchResults{Results: result}
 }
 
+// configWatcher is satisfied by both the cache
+// and state watchers of the model config.
+type configWatcher interface {
+	facade.Resource
+	Changes() <-chan struct{}
+}
+
+// watchLoggingConfig watches the logging config in the cache, unless
+// the cache is degraded and may not see changes, in which case the
+// model config is watched in state.
+func (api *LoggerAPI) watchLoggingConfig() (configWatcher, error) {
+	if api.controller.Health().Status != cache.HealthDegraded {
+		return api.model.WatchConfig("logging-config"), nil
+	}
+	m, err := api.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.WatchForModelConfigChanges(), nil
+}
+
 // LoggingConfig reports the logging configuration for the agents specified.
 func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	if len(arg.Entities) == 0 {
 		return params.StringResults{}
 	}
 	results := make([]params.StringResult, len(arg.Entities))
-	// TODO: ensure that the cache model can return a proper config object.
-	config, configErr := config.New(config.NoDefaults, api.model.Config())
+	config, configErr := api.modelConfig()
 	for i, entity := range arg.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
@@ -116,3 +144,19 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	}
 	return params.StringResults{Results: results}
 }
+
+// modelConfig returns the model config from the cache,
+// unless the cache is degraded and may be stale, in which
+// case it is read from state.
+func (api *LoggerAPI) modelConfig() (*config.Config, error) {
+	if api.controller.Health().Status != cache.HealthDegraded {
+		// TODO: ensure that the cache model can return a proper config object.
+		return config.New(config.NoDefaults, api.model.Config())
+	}
+	m, err := api.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	return cfg, errors.Trace(err)
+}
@@ -4,6 +4,7 @@
 package logger_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v2/workertest"
@@ -132,6 +133,29 @@ func (s *loggerSuite) TestWatchLoggingConfig(c *gc.C) {
 	// The watcher implementation is tested in the cache package.
 }
 
+func (s *loggerSuite) TestWatchLoggingConfigCacheDegraded(c *gc.C) {
+	s.ctrl.Controller.SetDegraded(errors.New("boom"))
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results := s.logger.WatchLoggingConfig(args)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	resource := s.resources.Get(results.Results[0].NotifyWatcherId)
+	c.Assert(resource, gc.NotNil)
+
+	// While the cache is degraded, the model config is watched in state.
+	w, ok := resource.(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"logging-config": "<root>=DEBUG"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *loggerSuite) TestWatchLoggingConfigRefusesWrongAgent(c *gc.C) {
 	// We are a machine agent, but not the one we are trying to track
 	args := params.Entities{
@@ -160,6 +184,25 @@ func (s *loggerSuite) TestLoggingConfigRefusesWrongAgent(c *gc.C) {
 	c.Assert(result.Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
 }
 
+func (s *loggerSuite) TestLoggingConfigCacheDegraded(c *gc.C) {
+	s.setLoggingConfig(c, "<root>=WARN")
+
+	// While the cache is degraded, the config is read from state.
+	newLoggingConfig := "<root>=WARN;juju.log.test=DEBUG"
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"logging-config": newLoggingConfig}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.ctrl.Controller.SetDegraded(errors.New("boom"))
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results := s.logger.LoggingConfig(args)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.Equals, newLoggingConfig)
+}
+
 func (s *loggerSuite) TestLoggingConfigForAgent(c *gc.C) {
 	newLoggingConfig := "<root>=WARN;juju.log.test=DEBUG;unit=INFO"
 	s.setLoggingConfig(c, newLoggingConfig)
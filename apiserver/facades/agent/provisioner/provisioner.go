@@ -4,6 +4,7 @@
 package provisioner
 
 import (
+	"strconv"
 	"sync"
 
 	"github.com/juju/collections/set"
@@ -451,6 +452,7 @@ func (api *ProvisionerAPI) ContainerManagerConfig(args params.ContainerManagerCo
 	switch args.Type {
 	case instance.LXD:
 		cfg[config.LXDSnapChannel] = mConfig.LXDSnapChannel()
+		cfg[config.ContainerImageRequireSignedMetadataKey] = strconv.FormatBool(mConfig.ContainerImageRequireSignedMetadata())
 		// TODO(jam): DefaultMTU needs to be handled here
 	}
 
@@ -1885,10 +1885,11 @@ func (s *withImageMetadataSuite) SetUpTest(c *gc.C) {
 func (s *withImageMetadataSuite) TestContainerManagerConfigImageMetadata(c *gc.C) {
 	cfg := s.getManagerConfig(c, instance.LXD)
 	c.Assert(cfg, jc.DeepEquals, map[string]string{
-		container.ConfigModelUUID:           coretesting.ModelTag.Id(),
-		config.ContainerImageStreamKey:      "daily",
-		config.ContainerImageMetadataURLKey: "https://images.linuxcontainers.org/",
-		config.LXDSnapChannel:               "latest/stable",
+		container.ConfigModelUUID:                     coretesting.ModelTag.Id(),
+		config.ContainerImageStreamKey:                "daily",
+		config.ContainerImageMetadataURLKey:           "https://images.linuxcontainers.org/",
+		config.LXDSnapChannel:                         "latest/stable",
+		config.ContainerImageRequireSignedMetadataKey: "false",
 	})
 }
 
@@ -34,6 +34,10 @@ type StatusAPI struct {
 // model from the model cache.
 type CachedModel interface {
 	Application(string) (CachedApplication, error)
+
+	// Degraded returns true if the cache may be stale,
+	// in which case the model should be read from state.
+	Degraded() bool
 }
 
 // CachedApplication represents the methods that the StatusAPI needs on
@@ -170,19 +174,10 @@ func (s *StatusAPI) toStatusResult(i status.StatusInfo) params.StatusResult {
 }
 
 func (s *StatusAPI) getAppAndUnitStatus(application *state.Application) params.ApplicationStatusResult {
-	// If for some reason the application isn't yet in the cache, then
-	// it has an unknown status.
 	result := params.ApplicationStatusResult{
 		Units: make(map[string]params.StatusResult),
 	}
-	appStatus := status.StatusInfo{
-		Status: status.Unknown,
-	}
-	app, err := s.model.Application(application.Name())
-	if err == nil {
-		appStatus = app.Status()
-	}
-	result.Application = s.toStatusResult(appStatus)
+	result.Application = s.toStatusResult(s.applicationStatus(application))
 
 	unitStatuses, err := application.UnitStatuses()
 	if err != nil {
@@ -195,6 +190,23 @@ func (s *StatusAPI) getAppAndUnitStatus(application *state.Application) params.A
 	return result
 }
 
+// applicationStatus returns the status of the application from the model
+// cache, or from state while the cache is degraded. If for some reason the
+// application isn't yet in the cache, then it has an unknown status.
+func (s *StatusAPI) applicationStatus(application *state.Application) status.StatusInfo {
+	if s.model.Degraded() {
+		info, err := application.DerivedStatus()
+		if err == nil {
+			return info
+		}
+	} else if app, err := s.model.Application(application.Name()); err == nil {
+		return app.Status()
+	}
+	return status.StatusInfo{
+		Status: status.Unknown,
+	}
+}
+
 type cacheShim struct {
 	model *cache.Model
 }
@@ -206,3 +218,7 @@ func (c cacheShim) Application(name string) (CachedApplication, error) {
 	}
 	return &app, nil
 }
+
+func (c cacheShim) Degraded() bool {
+	return c.model.Degraded()
+}
@@ -140,6 +140,24 @@ func (s *ApplicationStatusAPISuite) TestGetUnitStatusIsLeader(c *gc.C) {
 	c.Assert(unitStatus.Status, gc.Equals, status.Maintenance.String())
 }
 
+func (s *ApplicationStatusAPISuite) TestGetStatusCacheDegraded(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Status: &status.StatusInfo{
+		Status: status.Maintenance,
+	}})
+	// While the cache is degraded, its stale status is ignored, and
+	// the unset application status is derived from the unit in state.
+	s.model.info.Status = status.Active
+	s.model.degraded = true
+	result, err := s.api.ApplicationStatus(params.Entities{[]params.Entity{{
+		unit.Tag().String(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	r := result.Results[0]
+	c.Assert(r.Error, gc.IsNil)
+	c.Assert(r.Application.Status, gc.Equals, status.Maintenance.String())
+}
+
 func (s *ApplicationStatusAPISuite) TestBulk(c *gc.C) {
 	s.badTag = names.NewMachineTag("42")
 	machine := s.Factory.MakeMachine(c, nil)
@@ -158,8 +176,9 @@ func (s *ApplicationStatusAPISuite) TestBulk(c *gc.C) {
 }
 
 type fakeCachedModel struct {
-	err  error
-	info status.StatusInfo
+	err      error
+	info     status.StatusInfo
+	degraded bool
 }
 
 func (f *fakeCachedModel) Application(name string) (uniter.CachedApplication, error) {
@@ -172,3 +191,7 @@ func (f *fakeCachedModel) Application(name string) (uniter.CachedApplication, er
 func (f *fakeCachedModel) Status() status.StatusInfo {
 	return f.info
 }
+
+func (f *fakeCachedModel) Degraded() bool {
+	return f.degraded
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/charm/v9"
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -29,6 +30,7 @@ import (
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/feature"
@@ -970,8 +972,9 @@ func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResul
 				if err == nil {
 					err = unit.SetCharmURL(curl)
 				}
-				if err == nil {
-					// Wait for the change to propagate to the cache controller.
+				// Wait for the change to propagate to the cache controller,
+				// unless it is degraded and may not see the change.
+				if err == nil && !u.cacheModel.Degraded() {
 					err = u.waitForCacheCharmURL(unit.Name(), curl.String())
 				}
 			}
@@ -1273,14 +1276,10 @@ func (u *UniterAPI) ConfigSettings(args params.Entities) (params.ConfigSettingsR
 		}
 		err = apiservererrors.ErrPerm
 		if canAccess(tag) {
-			var unit cache.Unit
-			unit, err = u.getCacheUnit(tag)
+			var settings charm.Settings
+			settings, err = u.unitConfigSettings(tag)
 			if err == nil {
-				var settings charm.Settings
-				settings, err = unit.ConfigSettings()
-				if err == nil {
-					result.Results[i].Settings = params.ConfigSettings(settings)
-				}
+				result.Results[i].Settings = params.ConfigSettings(settings)
 			}
 		}
 		result.Results[i].Error = apiservererrors.ServerError(err)
@@ -1288,6 +1287,37 @@ func (u *UniterAPI) ConfigSettings(args params.Entities) (params.ConfigSettingsR
 	return result, nil
 }
 
+// unitConfigSettings returns the charm config settings for the unit from
+// the model cache. While the cache is degraded, they are read from state,
+// with the changes of any branch the unit is assigned to applied.
+func (u *UniterAPI) unitConfigSettings(tag names.UnitTag) (charm.Settings, error) {
+	if !u.cacheModel.Degraded() {
+		unit, err := u.getCacheUnit(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return unit.ConfigSettings()
+	}
+
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	branches, err := u.st.Branches()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	branchName := model.GenerationMaster
+	for _, branch := range branches {
+		assigned := branch.AssignedUnits()[unit.ApplicationName()]
+		if set.NewStrings(assigned...).Contains(unit.Name()) {
+			branchName = branch.BranchName()
+			break
+		}
+	}
+	return unit.BranchConfigSettings(branchName)
+}
+
 // CharmArchiveSha256 returns the SHA256 digest of the charm archive
 // (bundle) data for each charm url in the given parameters.
 func (u *UniterAPI) CharmArchiveSha256(args params.CharmURLs) (params.StringResults, error) {
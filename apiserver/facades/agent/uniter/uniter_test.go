@@ -1399,6 +1399,30 @@ func (s *uniterSuite) TestConfigSettings(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestConfigSettingsCacheDegraded(c *gc.C) {
+	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.State.AddBranch("new-branch", "branch-user"), jc.ErrorIsNil)
+	branch, err := s.State.Branch("new-branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignUnit(s.wordpressUnit.Name()), jc.ErrorIsNil)
+	err = s.wordpress.UpdateCharmConfig("new-branch", charm.Settings{"blog-title": "Branch Title"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// While the cache is degraded, the settings are read from state,
+	// including the changes made in the unit's branch.
+	s.Controller.SetDegraded(errors.New("boom"))
+	result, err := s.uniter.ConfigSettings(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ConfigSettingsResults{
+		Results: []params.ConfigSettingsResult{
+			{Settings: params.ConfigSettings{"blog-title": "Branch Title"}},
+		},
+	})
+}
+
 func (s *uniterSuite) TestWatchUnitRelations(c *gc.C) {
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 
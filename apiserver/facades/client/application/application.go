@@ -341,6 +341,34 @@ func (api *APIBase) checkCanWrite() error {
 	return api.checkPermission(api.model.ModelTag(), permission.WriteAccess)
 }
 
+// checkCanModifyBranch returns an error if the API user may not stage
+// changes under the input branch. Model and controller admins may modify
+// any branch. Other users may only modify the branches they created.
+// It is assumed that write access to the model has already been checked.
+func (api *APIBase) checkCanModifyBranch(branchName string) error {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		if isAdmin, err = api.authorizer.HasPermission(permission.AdminAccess, api.model.ModelTag()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if isAdmin {
+		return nil
+	}
+
+	gen, err := api.backend.Branch(branchName)
+	if err != nil {
+		return errors.Annotate(err, "retrieving next generation")
+	}
+	if gen.CreatedBy() != api.authorizer.GetAuthTag().Id() {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *APIBase) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -1038,6 +1066,11 @@ func (api *APIBase) setConfig(app Application, generation, settingsYAML string,
 	if generation == "" {
 		generation = model.GenerationMaster
 	}
+	if generation != model.GenerationMaster {
+		if err := api.checkCanModifyBranch(generation); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Update settings for charm and/or application.
 	ch, _, err := app.Charm()
@@ -2667,6 +2700,12 @@ func (api *APIBase) UnsetApplicationsConfig(args params.ApplicationConfigUnsetAr
 }
 
 func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
+	if arg.BranchName != "" && arg.BranchName != model.GenerationMaster {
+		if err := api.checkCanModifyBranch(arg.BranchName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	app, err := api.backend.Application(arg.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
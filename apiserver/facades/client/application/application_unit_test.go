@@ -1953,6 +1953,71 @@ func (s *ApplicationSuite) TestSetConfigBranch(c *gc.C) {
 	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
 }
 
+func (s *ApplicationSuite) TestSetConfigBranchCreator(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("write"))
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.generation = &mockGeneration{createdBy: "write"}
+
+	result, err := s.api.SetConfigs(params.ConfigSetArgs{
+		Args: []params.ConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+			Generation:      "new-branch",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "UpdateCharmConfig", "new-branch", charm.Settings{"stringOption": "stringVal"})
+	s.backend.generation.CheckCallNames(c, "CreatedBy", "AssignApplication")
+}
+
+func (s *ApplicationSuite) TestSetConfigBranchOtherWriteUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("write"))
+	s.backend.generation = &mockGeneration{createdBy: "someone-else"}
+
+	result, err := s.api.SetConfigs(params.ConfigSetArgs{
+		Args: []params.ConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+			Generation:      "new-branch",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
+	s.backend.generation.CheckCallNames(c, "CreatedBy")
+}
+
+func (s *ApplicationSuite) TestSetConfigBranchReadUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("read"))
+
+	_, err := s.api.SetConfigs(params.ConfigSetArgs{
+		Args: []params.ConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"stringOption": "stringVal"},
+			Generation:      "new-branch",
+		}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestUnsetApplicationsConfigBranchOtherWriteUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("write"))
+	s.backend.generation = &mockGeneration{createdBy: "someone-else"}
+
+	result, err := s.api.UnsetApplicationsConfig(params.ApplicationConfigUnsetArgs{
+		Args: []params.ApplicationUnset{{
+			ApplicationName: "postgresql",
+			BranchName:      "new-branch",
+			Options:         []string{"stringOption"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+
+	s.backend.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	api := &application.APIv12{s.api}
@@ -204,6 +204,7 @@ type Resources interface {
 
 type Generation interface {
 	AssignApplication(string) error
+	CreatedBy() string
 }
 
 type stateShim struct {
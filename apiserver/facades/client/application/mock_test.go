@@ -1174,6 +1174,7 @@ func (m *mockCaasBroker) Version() (*version.Number, error) {
 
 type mockGeneration struct {
 	jtesting.Stub
+	createdBy string
 }
 
 func (g *mockGeneration) AssignApplication(appName string) error {
@@ -1181,6 +1182,11 @@ func (g *mockGeneration) AssignApplication(appName string) error {
 	return g.NextErr()
 }
 
+func (g *mockGeneration) CreatedBy() string {
+	g.MethodCall(g, "CreatedBy")
+	return g.createdBy
+}
+
 type mockRepo struct {
 	application.Repository
 	*jtesting.CallMocker
@@ -61,6 +61,13 @@ type APIv4 struct {
 	*BundleAPI
 }
 
+// APIv5 provides the Bundle API facade for version 5. It is otherwise
+// identical to V4 with the exception that the V5 adds Validate, which
+// verifies a bundle without computing the changes required to deploy it.
+type APIv5 struct {
+	*BundleAPI
+}
+
 // BundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
 type BundleAPI struct {
@@ -110,6 +117,16 @@ func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*BundleAPI, error) {
 	authorizer := ctx.Auth()
@@ -182,10 +199,17 @@ func (b *APIv1) GetChanges(args params.BundleChangesParams) (params.BundleChange
 	return getChanges(args, vs, func(changes []bundlechanges.Change, results *params.BundleChangesResults) error {
 		results.Changes = make([]*params.BundleChange, len(changes))
 		for i, c := range changes {
+			var guiArgs []interface{}
+			switch c := c.(type) {
+			case *bundlechanges.ExposeChange:
+				guiArgs = exposeGUIArgs(c)
+			default:
+				guiArgs = c.GUIArgs()
+			}
 			results.Changes[i] = &params.BundleChange{
 				Id:       c.Id(),
 				Method:   c.Method(),
-				Args:     c.GUIArgs(),
+				Args:     guiArgs,
 				Requires: c.Requires(),
 			}
 		}
@@ -193,15 +217,36 @@ func (b *APIv1) GetChanges(args params.BundleChangesParams) (params.BundleChange
 	})
 }
 
+// exposeGUIArgs returns the GUI args for the input expose change. When the
+// bundle exposes specific endpoints, the per-endpoint expose settings are
+// appended, so that the change does not appear to expose every endpoint.
+func exposeGUIArgs(change *bundlechanges.ExposeChange) []interface{} {
+	args := change.GUIArgs()
+	if len(change.Params.ExposedEndpoints) == 0 {
+		return args
+	}
+	exposedEndpoints := make(map[string]params.ExposedEndpoint, len(change.Params.ExposedEndpoints))
+	for endpointName, exposeDetails := range change.Params.ExposedEndpoints {
+		exposedEndpoints[endpointName] = params.ExposedEndpoint{
+			ExposeToSpaces: exposeDetails.ExposeToSpaces,
+			ExposeToCIDRs:  exposeDetails.ExposeToCIDRs,
+		}
+	}
+	return append(args, exposedEndpoints)
+}
+
 type validators struct {
 	verifyConstraints func(string) error
 	verifyStorage     func(string) error
 	verifyDevices     func(string) error
 }
 
-func getBundleChanges(args params.BundleChangesParams,
+// verifyBundle reads the bundle data and verifies it using the input
+// validators. Any verification errors are returned separately from
+// errors reading or verifying the bundle.
+func verifyBundle(args params.BundleChangesParams,
 	vs validators,
-) ([]bundlechanges.Change, []error, error) {
+) (*charm.BundleData, []error, error) {
 	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "cannot read bundle YAML")
@@ -217,6 +262,16 @@ func getBundleChanges(args params.BundleChangesParams,
 		// This should never happen as Verify only returns verification errors.
 		return nil, nil, errors.Annotate(err, "cannot verify bundle")
 	}
+	return data, nil, nil
+}
+
+func getBundleChanges(args params.BundleChangesParams,
+	vs validators,
+) ([]bundlechanges.Change, []error, error) {
+	data, validationErrors, err := verifyBundle(args, vs)
+	if err != nil || len(validationErrors) > 0 {
+		return nil, validationErrors, errors.Trace(err)
+	}
 	changes, err := bundlechanges.FromData(
 		bundlechanges.ChangesConfig{
 			Bundle:    data,
@@ -278,6 +333,8 @@ func (b *BundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 			switch c := c.(type) {
 			case *bundlechanges.AddApplicationChange:
 				guiArgs = c.GUIArgsWithDevices()
+			case *bundlechanges.ExposeChange:
+				guiArgs = exposeGUIArgs(c)
 			default:
 				guiArgs = c.GUIArgs()
 			}
@@ -334,6 +391,47 @@ func (b *BundleAPI) GetChangesMapArgs(args params.BundleChangesParams) (params.B
 	})
 }
 
+// Validate is not in V4 API or less.
+// Mask the new method from V4 API or less.
+func (u *APIv4) Validate(_, _ struct{}) {}
+
+// Validate is not in V4 API or less.
+func (u *APIv3) Validate(_, _ struct{}) {}
+
+// Validate is not in V4 API or less.
+func (u *APIv2) Validate(_, _ struct{}) {}
+
+// Validate checks the structural validity of the given bundle data,
+// returning any problems found without computing the changes required
+// to deploy the bundle. Unlike GetChanges, bundle YAML that can not
+// be read is reported as a validation error.
+func (b *BundleAPI) Validate(args params.BundleChangesParams) (params.BundleValidationResults, error) {
+	vs := validators{
+		verifyConstraints: func(s string) error {
+			_, err := constraints.Parse(s)
+			return err
+		},
+		verifyStorage: func(s string) error {
+			_, err := storage.ParseConstraints(s)
+			return err
+		},
+		verifyDevices: func(s string) error {
+			_, err := devices.ParseConstraints(s)
+			return err
+		},
+	}
+	var results params.BundleValidationResults
+	_, validationErrors, err := verifyBundle(args, vs)
+	if err != nil {
+		results.Errors = []string{err.Error()}
+		return results, nil
+	}
+	for _, e := range validationErrors {
+		results.Errors = append(results.Errors, e.Error())
+	}
+	return results, nil
+}
+
 func getChangesMapArgs(
 	args params.BundleChangesParams,
 	vs validators,
@@ -59,6 +59,16 @@ func (s *bundleSuite) makeAPIv1(c *gc.C) *bundle.APIv1 {
 	return &bundle.APIv1{api}
 }
 
+func (s *bundleSuite) makeAPIv5(c *gc.C) *bundle.APIv5 {
+	api, err := bundle.NewBundleAPI(
+		s.st,
+		s.auth,
+		s.modelTag,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return &bundle.APIv5{api}
+}
+
 func (s *bundleSuite) TestGetChangesBundleContentError(c *gc.C) {
 	args := params.BundleChangesParams{
 		BundleDataYAML: ":",
@@ -217,6 +227,49 @@ func (s *bundleSuite) TestGetChangesSuccessV2(c *gc.C) {
 	c.Assert(r.Errors, gc.IsNil)
 }
 
+func (s *bundleSuite) TestGetChangesExposedEndpoints(c *gc.C) {
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                django:
+                    charm: django
+                    expose: true
+                haproxy:
+                    charm: cs:trusty/haproxy-42
+                    exposed-endpoints:
+                        website:
+                            expose-to-cidrs:
+                                - 10.0.0.0/24
+        `,
+	}
+	r, err := s.facade.GetChanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, gc.IsNil)
+
+	var exposeChanges []*params.BundleChange
+	for _, change := range r.Changes {
+		if change.Method == "expose" {
+			exposeChanges = append(exposeChanges, change)
+		}
+	}
+	c.Assert(exposeChanges, jc.DeepEquals, []*params.BundleChange{{
+		Id:       "expose-4",
+		Method:   "expose",
+		Args:     []interface{}{"$deploy-1"},
+		Requires: []string{"deploy-1"},
+	}, {
+		Id:     "expose-5",
+		Method: "expose",
+		Args: []interface{}{
+			"$deploy-3",
+			map[string]params.ExposedEndpoint{
+				"website": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+			},
+		},
+		Requires: []string{"deploy-3"},
+	}})
+}
+
 func (s *bundleSuite) TestGetChangesKubernetes(c *gc.C) {
 	args := params.BundleChangesParams{
 		BundleDataYAML: `
@@ -394,6 +447,66 @@ func (s *bundleSuite) TestGetChangesBundleEndpointBindingsSuccess(c *gc.C) {
 	}
 }
 
+func (s *bundleSuite) TestValidateBundleVerificationErrors(c *gc.C) {
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                django:
+                    charm: django
+                    to: [1]
+                    devices:
+                        bitcoinminer: -1,nvidia.com/gpu
+                haproxy:
+                    charm: 42
+                    num_units: -1
+        `,
+	}
+	r, err := s.makeAPIv5(c).Validate(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, jc.SameContents, []string{
+		`placement "1" refers to a machine not defined in this bundle`,
+		`too many units specified in unit placement for application "django"`,
+		`invalid device "bitcoinminer" in application "django": count must be greater than zero, got "-1"`,
+		`invalid charm URL in application "haproxy": cannot parse URL "42": name "42" not valid`,
+		`negative number of units specified on application "haproxy"`,
+	})
+}
+
+func (s *bundleSuite) TestValidateBundleContentError(c *gc.C) {
+	args := params.BundleChangesParams{
+		BundleDataYAML: ":",
+	}
+	r, err := s.makeAPIv5(c).Validate(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, jc.DeepEquals, []string{
+		`cannot read bundle YAML: unmarshal document 0: yaml: did not find expected key`,
+	})
+}
+
+func (s *bundleSuite) TestValidateBundleSuccess(c *gc.C) {
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                django:
+                    charm: django
+                    options:
+                        debug: true
+                    storage:
+                        tmpfs: tmpfs,1G
+                    devices:
+                        bitcoinminer: 2,nvidia.com/gpu
+                haproxy:
+                    charm: cs:trusty/haproxy-42
+            relations:
+                - - django:web
+                  - haproxy:web
+        `,
+	}
+	r, err := s.makeAPIv5(c).Validate(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, gc.HasLen, 0)
+}
+
 func (s *bundleSuite) TestGetChangesMapArgsBundleContentError(c *gc.C) {
 	args := params.BundleChangesParams{
 		BundleDataYAML: ":",
@@ -39,11 +39,17 @@ type Client interface {
 	Find(ctx context.Context, query string) ([]transport.FindResponse, error)
 }
 
+// InfoCache represents a cache of CharmHub info responses.
+type InfoCache interface {
+	Info(ctx context.Context, client charmhub.InfoGetter, name, channel string) (transport.InfoResponse, error)
+}
+
 // CharmHubAPI API provides the CharmHub API facade for version 1.
 type CharmHubAPI struct {
-	backend Backend
-	auth    facade.Authorizer
-	client  Client
+	backend   Backend
+	auth      facade.Authorizer
+	client    Client
+	infoCache InfoCache
 }
 
 // NewFacade creates a new CharmHubAPI facade.
@@ -53,10 +59,15 @@ func NewFacade(ctx facade.Context) (*CharmHubAPI, error) {
 		return nil, errors.Trace(err)
 	}
 
-	return newCharmHubAPI(m, ctx.Auth(), charmHubClientFactory{})
+	// The info cache is shared by all the models on the controller.
+	var infoCache InfoCache
+	if cache := ctx.CharmhubInfoCache(); cache != nil {
+		infoCache = cache
+	}
+	return newCharmHubAPI(m, ctx.Auth(), charmHubClientFactory{}, infoCache)
 }
 
-func newCharmHubAPI(backend Backend, authorizer facade.Authorizer, clientFactory ClientFactory) (*CharmHubAPI, error) {
+func newCharmHubAPI(backend Backend, authorizer facade.Authorizer, clientFactory ClientFactory, infoCache InfoCache) (*CharmHubAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
 	}
@@ -72,8 +83,9 @@ func newCharmHubAPI(backend Backend, authorizer facade.Authorizer, clientFactory
 	}
 
 	return &CharmHubAPI{
-		auth:   authorizer,
-		client: client,
+		auth:      authorizer,
+		client:    client,
+		infoCache: infoCache,
 	}, nil
 }
 
@@ -86,17 +98,26 @@ func (api *CharmHubAPI) Info(arg params.Info) (params.CharmHubEntityInfoResult,
 		return params.CharmHubEntityInfoResult{}, errors.BadRequestf("tag value is empty")
 	}
 
-	var options []charmhub.InfoOption
+	var channel string
 	if arg.Channel != "" {
 		ch, err := charm.ParseChannelNormalize(arg.Channel)
 		if err != nil {
 			return params.CharmHubEntityInfoResult{}, errors.BadRequestf("channel %q is invalid", arg.Channel)
 		}
-		options = append(options, charmhub.WithChannel(ch.String()))
+		channel = ch.String()
 	}
 
 	// TODO (stickupkid): Create a proper context to be used here.
-	info, err := api.client.Info(context.TODO(), tag.Id(), options...)
+	var info transport.InfoResponse
+	if api.infoCache != nil {
+		info, err = api.infoCache.Info(context.TODO(), api.client, tag.Id(), channel)
+	} else {
+		var options []charmhub.InfoOption
+		if channel != "" {
+			options = append(options, charmhub.WithChannel(channel))
+		}
+		info, err = api.client.Info(context.TODO(), tag.Id(), options...)
+	}
 	if err != nil {
 		return params.CharmHubEntityInfoResult{}, errors.Trace(err)
 	}
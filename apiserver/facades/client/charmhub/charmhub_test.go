@@ -4,13 +4,17 @@
 package charmhub
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	facademocks "github.com/juju/juju/apiserver/facade/mocks"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/charmhub/transport"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
@@ -23,6 +27,7 @@ type charmHubAPISuite struct {
 	authorizer    *facademocks.MockAuthorizer
 	clientFactory *MockClientFactory
 	client        *MockClient
+	infoCache     InfoCache
 }
 
 func (s *charmHubAPISuite) TestInfo(c *gc.C) {
@@ -45,6 +50,27 @@ func (s *charmHubAPISuite) TestInfoWithChannel(c *gc.C) {
 	assertInfoResponseSameContents(c, obtained.Result, getParamsInfoResponse())
 }
 
+func (s *charmHubAPISuite) TestInfoCached(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	cache, err := charmhub.NewInfoCache(charmhub.InfoCacheConfig{
+		Clock:      testclock.NewClock(time.Now()),
+		TTL:        time.Minute,
+		MaxEntries: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.infoCache = cache
+
+	// Only the first request is sent to charmhub.
+	s.client.EXPECT().URL().Return("https://someurl.com").Times(2)
+	s.client.EXPECT().Info(gomock.Any(), "wordpress", gomock.Any()).Return(getCharmHubInfoResponse(), nil)
+	arg := params.Info{Tag: names.NewApplicationTag("wordpress").String(), Channel: "stable"}
+	for i := 0; i < 2; i++ {
+		obtained, err := s.newCharmHubAPIForTest(c).Info(arg)
+		c.Assert(err, jc.ErrorIsNil)
+		assertInfoResponseSameContents(c, obtained.Result, getParamsInfoResponse())
+	}
+}
+
 func (s *charmHubAPISuite) TestFind(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	s.expectFind()
@@ -60,7 +86,7 @@ func (s *charmHubAPISuite) newCharmHubAPIForTest(c *gc.C) *CharmHubAPI {
 	s.expectModelConfig(c)
 	s.expectAuth()
 	s.expectClient()
-	api, err := newCharmHubAPI(s.backend, s.authorizer, s.clientFactory)
+	api, err := newCharmHubAPI(s.backend, s.authorizer, s.clientFactory, s.infoCache)
 	c.Assert(err, jc.ErrorIsNil)
 	return api
 }
@@ -71,6 +97,7 @@ func (s *charmHubAPISuite) setupMocks(c *gc.C) *gomock.Controller {
 	s.authorizer = facademocks.NewMockAuthorizer(ctrl)
 	s.clientFactory = NewMockClientFactory(ctrl)
 	s.client = NewMockClient(ctrl)
+	s.infoCache = nil
 	return ctrl
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/charms/mocks"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/arch"
 	"github.com/juju/juju/core/cache"
@@ -59,6 +60,7 @@ func (ctx *charmsSuiteContext) StatePool() *state.StatePool                   {
 func (ctx *charmsSuiteContext) ID() string                                    { return "" }
 func (ctx *charmsSuiteContext) Presence() facade.Presence                     { return nil }
 func (ctx *charmsSuiteContext) Hub() facade.Hub                               { return nil }
+func (ctx *charmsSuiteContext) CharmhubInfoCache() *charmhub.InfoCache        { return nil }
 func (ctx *charmsSuiteContext) Controller() *cache.Controller                 { return nil }
 func (ctx *charmsSuiteContext) CachedModel(uuid string) (*cache.Model, error) { return nil, nil }
 func (ctx *charmsSuiteContext) MultiwatcherFactory() multiwatcher.Factory     { return nil }
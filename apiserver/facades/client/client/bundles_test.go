@@ -79,3 +79,60 @@ func (s *serverSuite) TestGetBundleChangesSuccess(c *gc.C) {
 	}})
 	c.Assert(r.Errors, gc.IsNil)
 }
+
+func (s *serverSuite) TestGetBundleChangesPlacementOnUndeclaredMachine(c *gc.C) {
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                django:
+                    charm: django
+                    num_units: 1
+                    to: ["0"]
+                haproxy:
+                    charm: cs:trusty/haproxy-42
+                    num_units: 1
+                    to: ["1"]
+            machines:
+                0:
+        `,
+	}
+	r, err := s.client.GetBundleChanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Changes, gc.IsNil)
+	c.Assert(r.Errors, jc.SameContents, []string{
+		`placement "1" refers to a machine not defined in this bundle`,
+	})
+}
+
+func (s *serverSuite) TestGetBundleChangesExposedEndpoints(c *gc.C) {
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                haproxy:
+                    charm: cs:trusty/haproxy-42
+                    exposed-endpoints:
+                        website:
+                            expose-to-cidrs:
+                                - 10.0.0.0/24
+                        admin:
+                            expose-to-cidrs:
+                                - 192.168.0.0/24
+        `,
+	}
+	r, err := s.client.GetBundleChanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, gc.IsNil)
+	c.Assert(r.Changes, gc.HasLen, 3)
+	c.Assert(r.Changes[2], jc.DeepEquals, &params.BundleChange{
+		Id:     "expose-2",
+		Method: "expose",
+		Args: []interface{}{
+			"$deploy-1",
+			map[string]params.ExposedEndpoint{
+				"website": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+				"admin":   {ExposeToCIDRs: []string{"192.168.0.0/24"}},
+			},
+		},
+		Requires: []string{"deploy-1"},
+	})
+}
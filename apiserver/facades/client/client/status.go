@@ -248,7 +248,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if context.controllerTimestamp, err = c.api.stateAccessor.ControllerTimestamp(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch controller timestamp")
 	}
-	context.branches = fetchBranches(c.api.modelCache)
+	if context.branches, err = fetchBranches(c.api.modelCache, context.model); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch branches")
+	}
 
 	logger.Tracef("Applications: %v", context.allAppsUnitsCharmBindings.applications)
 	logger.Tracef("Remote applications: %v", context.consumerRemoteApplications)
@@ -403,7 +405,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	}, nil
 }
 
-func filterBranches(ctxBranches map[string]cache.Branch, matchedApps, matchedForBranches set.Strings) map[string]cache.Branch {
+func filterBranches(ctxBranches map[string]statusBranch, matchedApps, matchedForBranches set.Strings) map[string]statusBranch {
 	// Filter branches based on matchedApps which contains
 	// the application name if matching on application or unit.
 	unmatchedBranches := set.NewStrings()
@@ -552,7 +554,7 @@ type statusContext struct {
 	relations                 map[string][]*state.Relation
 	relationsById             map[int]*state.Relation
 	leaders                   map[string]string
-	branches                  map[string]cache.Branch
+	branches                  map[string]statusBranch
 
 	// Information about all spaces.
 	spaceInfos network.SpaceInfos
@@ -904,20 +906,40 @@ func fetchRelations(st Backend) (map[string][]*state.Relation, map[int]*state.Re
 	return out, outById, nil
 }
 
-func fetchBranches(m *cache.Model) map[string]cache.Branch {
+// statusBranch describes the details of an active branch shown in status.
+// It is satisfied by both cache.Branch and state.Generation.
+type statusBranch interface {
+	AssignedUnits() map[string][]string
+	Created() int64
+	CreatedBy() string
+}
+
+func fetchBranches(m *cache.Model, model *state.Model) (map[string]statusBranch, error) {
 	// Unless you're using the generations feature flag,
 	// the model cache model will be nil.  See note in
 	// newFacade().
 	if m == nil {
-		return make(map[string]cache.Branch)
+		return make(map[string]statusBranch), nil
+	}
+	// While the cache may be stale, the branches are read from state.
+	if m.Degraded() {
+		b, err := model.Branches()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		branches := make(map[string]statusBranch, len(b))
+		for _, branch := range b {
+			branches[branch.BranchName()] = branch
+		}
+		return branches, nil
 	}
 	// m.Branches() returns only active branches.
 	b := m.Branches()
-	branches := make(map[string]cache.Branch, len(b))
-	for _, branch := range b {
-		branches[branch.Name()] = branch
+	branches := make(map[string]statusBranch, len(b))
+	for i := range b {
+		branches[b[i].Name()] = &b[i]
 	}
-	return branches
+	return branches, nil
 }
 
 func (c *statusContext) processMachines() map[string]params.MachineStatus {
@@ -1207,13 +1229,7 @@ func (context *statusContext) processApplication(application *state.Application)
 		processedStatus.Units = context.processUnits(units, applicationCharm.URL().String(), expectWorkload)
 	}
 
-	// If for whatever reason the application isn't yet in the cache,
-	// we have an unknown status.
-	applicationStatus := status.StatusInfo{Status: status.Unknown}
-	cachedApp, err := context.cachedModel.Application(application.Name())
-	if err == nil {
-		applicationStatus = cachedApp.DisplayStatus()
-	}
+	applicationStatus := context.applicationStatus(application, units)
 	processedStatus.Status.Status = applicationStatus.Status.String()
 	processedStatus.Status.Info = applicationStatus.Message
 	processedStatus.Status.Data = applicationStatus.Data
@@ -1493,6 +1509,53 @@ func (context *statusContext) unitByName(name string) *state.Unit {
 	return context.allAppsUnitsCharmBindings.units[applicationName][name]
 }
 
+// applicationStatus returns the display status of the application from
+// the model cache. While the cache is degraded, or if the application is
+// not in it, the status is read from state instead. If it cannot be read,
+// we have an unknown status.
+func (context *statusContext) applicationStatus(application *state.Application, units map[string]*state.Unit) status.StatusInfo {
+	if !context.cachedModel.Degraded() {
+		cachedApp, err := context.cachedModel.Application(application.Name())
+		if err == nil {
+			return cachedApp.DisplayStatus()
+		}
+	}
+
+	unknown := status.StatusInfo{Status: status.Unknown}
+	info, err := context.status.Application(application.Name())
+	if err != nil {
+		return unknown
+	}
+	expectWorkload, err := state.CheckApplicationExpectsWorkload(context.model, application.Name())
+	if err != nil {
+		return unknown
+	}
+
+	// As in the cache, an unset status is derived from those of the units.
+	if info.Status == status.Unset {
+		statuses := make([]status.StatusInfo, 0, len(units))
+		for name := range units {
+			unitStatus, err := context.status.UnitWorkload(name, expectWorkload)
+			if err != nil {
+				return unknown
+			}
+			statuses = append(statuses, unitStatus)
+		}
+		derived := status.DeriveStatus(statuses)
+		if derived.Since == nil {
+			derived.Since = info.Since
+		}
+		info = derived
+	}
+
+	// Only applications in CAAS models have an operator.
+	operatorInfo, err := context.status.ApplicationOperator(application.Name())
+	if err != nil {
+		return info
+	}
+	return status.ApplicationDisplayStatus(info, operatorInfo, expectWorkload)
+}
+
 func (context *statusContext) processApplicationRelations(application *state.Application) (related map[string][]string, subord []string, err error) {
 	subordSet := make(set.Strings)
 	related = make(map[string][]string)
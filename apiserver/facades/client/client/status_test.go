@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
@@ -529,6 +530,40 @@ func (s *statusUnitTestSuite) TestWorkloadVersionOkWithUnset(c *gc.C) {
 	checkUnitVersion(c, appStatus, unit, "")
 }
 
+func (s *statusUnitTestSuite) TestApplicationStatusCacheDegraded(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	s.Controller.SetDegraded(errors.New("boom"))
+
+	// While the cache is degraded, the status is read from state.
+	err := application.SetStatus(status.StatusInfo{Status: status.Blocked, Message: "from state"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fullStatus, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, found := fullStatus.Applications[application.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Check(appStatus.Status.Status, gc.Equals, status.Blocked.String())
+	c.Check(appStatus.Status.Info, gc.Equals, "from state")
+}
+
+func (s *statusUnitTestSuite) TestApplicationStatusDerivedWhenCacheDegraded(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Controller.SetDegraded(errors.New("boom"))
+
+	// An unset application status is derived from its units.
+	err = unit.SetStatus(status.StatusInfo{Status: status.Maintenance, Message: "installing"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fullStatus, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, found := fullStatus.Applications[application.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Check(appStatus.Status.Status, gc.Equals, status.Maintenance.String())
+	c.Check(appStatus.Status.Info, gc.Equals, "installing")
+}
+
 func (s *statusUnitTestSuite) TestMigrationInProgress(c *gc.C) {
 	setGenerationsControllerConfig(c, s.State)
 	// Create a host model because controller models can't be migrated.
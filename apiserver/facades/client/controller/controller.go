@@ -57,10 +57,16 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the CacheHealth method.
+type ControllerAPIv9 struct {
+	*ControllerAPI
+}
+
 // ControllerAPIv8 provides the v8 Controller API. The only difference
 // between this and v9 is that v8 doesn't have the model summary watchers.
 type ControllerAPIv8 struct {
-	*ControllerAPI
+	*ControllerAPIv9
 }
 
 // ControllerAPIv7 provides the v7 Controller API. The only difference
@@ -95,10 +101,10 @@ type ControllerAPIv3 struct {
 
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = NewControllerAPIv9
+var LatestAPI = NewControllerAPIv10
 
-// NewControllerAPIv9 creates a new ControllerAPIv9.
-func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -120,6 +126,15 @@ func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
 // NewControllerAPIv8 creates a new ControllerAPIv8.
 func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
 	v9, err := NewControllerAPIv9(ctx)
@@ -236,6 +251,37 @@ func (c *ControllerAPI) checkIsSuperUser() error {
 	return nil
 }
 
+// CacheHealth isn't on the v9 API.
+func (c *ControllerAPIv9) CacheHealth(_, _ struct{}) {}
+
+// CacheHealth returns the health of the controller's model cache, which
+// is degraded while the cache may be stale. The error that degraded the
+// cache is only reported to controller superusers.
+func (c *ControllerAPI) CacheHealth() (params.ControllerCacheHealth, error) {
+	health := c.controller.Health()
+	result := params.ControllerCacheHealth{
+		Status: string(health.Status),
+	}
+	if err := c.checkIsSuperUser(); err == nil {
+		result.LastError = health.LastError
+	}
+	return result, nil
+}
+
+// watchSummaries returns a watcher for the summaries of the models the
+// user can see, or of all models if all is true. While the model cache
+// is degraded the summaries are read from state, as those from the cache
+// would be stale.
+func (c *ControllerAPI) watchSummaries(user names.UserTag, all bool) cache.ModelSummaryWatcher {
+	if c.controller.Health().Status == cache.HealthDegraded {
+		return newStateModelSummaryWatcher(c.statePool, c.controller.Name(), user, all)
+	}
+	if all {
+		return c.controller.WatchAllModels()
+	}
+	return c.controller.WatchModelsAsUser(user.Id())
+}
+
 // ControllerVersion isn't on the v7 API.
 func (c *ControllerAPIv7) ControllerVersion(_, _ struct{}) {}
 
@@ -513,14 +559,15 @@ func (c *ControllerAPI) WatchAllModels() (params.AllWatcherId, error) {
 	}, nil
 }
 
-// WatchAllModelSummaries starts watching the summary updates from the cache.
+// WatchAllModelSummaries starts watching the summary updates from the cache,
+// or from state while the cache is degraded.
 // This method is superuser access only, and watches all models in the
 // controller.
 func (c *ControllerAPI) WatchAllModelSummaries() (params.SummaryWatcherID, error) {
 	if err := c.checkIsSuperUser(); err != nil {
 		return params.SummaryWatcherID{}, errors.Trace(err)
 	}
-	w := c.controller.WatchAllModels()
+	w := c.watchSummaries(c.apiUser, true)
 	return params.SummaryWatcherID{
 		WatcherID: c.resources.Register(w),
 	}, nil
@@ -529,11 +576,11 @@ func (c *ControllerAPI) WatchAllModelSummaries() (params.SummaryWatcherID, error
 // WatchAllModelSummaries isn't on the v8 API.
 func (c *ControllerAPIv8) WatchAllModelSummaries(_, _ struct{}) {}
 
-// WatchModelSummaries starts watching the summary updates from the cache.
+// WatchModelSummaries starts watching the summary updates from the cache,
+// or from state while the cache is degraded.
 // Only models that the user has access to are returned.
 func (c *ControllerAPI) WatchModelSummaries() (params.SummaryWatcherID, error) {
-	user := c.apiUser.Id()
-	w := c.controller.WatchModelsAsUser(user)
+	w := c.watchSummaries(c.apiUser, false)
 	return params.SummaryWatcherID{
 		WatcherID: c.resources.Register(w),
 	}, nil
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -1120,6 +1121,109 @@ func (s *controllerSuite) TestWatchAllModelSummariesByNonAdmin(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *controllerSuite) TestCacheHealth(c *gc.C) {
+	result, err := s.controller.CacheHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, params.ControllerCacheHealth{Status: "synced"})
+
+	s.context.Controller_.SetDegraded(errors.New("boom"))
+	result, err = s.controller.CacheHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, params.ControllerCacheHealth{Status: "degraded", LastError: "boom"})
+}
+
+func (s *controllerSuite) TestCacheHealthByNonAdmin(c *gc.C) {
+	endPoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:      s.State,
+			Resources_:  s.resources,
+			Auth_:       apiservertesting.FakeAuthorizer{Tag: names.NewLocalUserTag("bob")},
+			Controller_: s.context.Controller_,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The error degrading the cache is not reported.
+	s.context.Controller_.SetDegraded(errors.New("boom"))
+	result, err := endPoint.CacheHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.Equals, params.ControllerCacheHealth{Status: "degraded"})
+}
+
+func (s *controllerSuite) TestWatchModelSummariesCacheDegraded(c *gc.C) {
+	controller.SetSummaryRefreshDelay(s, testing.ShortWait)
+	s.context.Controller_.SetDegraded(errors.New("boom"))
+
+	result, err := s.controller.WatchModelSummaries()
+	c.Assert(err, jc.ErrorIsNil)
+	watcherAPI := s.newSummaryWatcherFacade(c, result.WatcherID)
+
+	next := func() params.SummaryWatcherNextResults {
+		resultC := make(chan params.SummaryWatcherNextResults)
+		go func() {
+			result, err := watcherAPI.Next()
+			c.Check(err, jc.ErrorIsNil)
+			resultC <- result
+		}()
+		var result params.SummaryWatcherNextResults
+		select {
+		case result = <-resultC:
+		case <-time.After(testing.LongWait):
+			c.Fatal("timed out")
+		}
+		return result
+	}
+
+	// The summaries are read from state rather than the cache.
+	expected := params.ModelAbstract{
+		UUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Name:     "controller",
+		Admins:   []string{"test-admin"},
+		Cloud:    "dummy",
+		Region:   "dummy-region",
+		Status:   "green",
+		Messages: []params.ModelSummaryMessage{},
+	}
+	c.Assert(next(), jc.DeepEquals, params.SummaryWatcherNextResults{
+		Models: []params.ModelAbstract{expected},
+	})
+
+	// Changes to the model contents are picked up on refresh.
+	s.Factory.MakeMachine(c, nil)
+	expected.Size.Machines = 1
+	c.Assert(next(), jc.DeepEquals, params.SummaryWatcherNextResults{
+		Models: []params.ModelAbstract{expected},
+	})
+}
+
+func (s *controllerSuite) TestWatchAllModelSummariesCacheDegraded(c *gc.C) {
+	uuid := s.makeBobsModel(c)
+	s.context.Controller_.SetDegraded(errors.New("boom"))
+
+	result, err := s.controller.WatchAllModelSummaries()
+	c.Assert(err, jc.ErrorIsNil)
+	watcherAPI := s.newSummaryWatcherFacade(c, result.WatcherID)
+
+	resultC := make(chan params.SummaryWatcherNextResults)
+	go func() {
+		result, err := watcherAPI.Next()
+		c.Check(err, jc.ErrorIsNil)
+		resultC <- result
+	}()
+
+	select {
+	case result := <-resultC:
+		uuids := set.NewStrings()
+		for _, model := range result.Models {
+			uuids.Add(model.UUID)
+		}
+		c.Check(uuids.SortedValues(), jc.DeepEquals, set.NewStrings(
+			"deadbeef-0bad-400d-8000-4b1d0d06f00d", uuid,
+		).SortedValues())
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out")
+	}
+}
+
 func (s *controllerSuite) makeBobsModel(c *gc.C) string {
 	bob := s.Factory.MakeUser(c, &factory.UserParams{
 		Name:        "bob",
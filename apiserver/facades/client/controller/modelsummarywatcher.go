@@ -0,0 +1,306 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// summaryRefreshDelay is how often the state model summary watcher
+// rebuilds the summaries to pick up changes to the models' contents.
+var summaryRefreshDelay = 30 * time.Second
+
+// stateModelSummaryWatcher is a cache.ModelSummaryWatcher that reads the
+// model summaries from state. It is used while the model cache is
+// degraded, as summaries from the cache would be stale. The summaries
+// are rebuilt whenever a model document changes, and every
+// summaryRefreshDelay to pick up changes to the models' contents.
+type stateModelSummaryWatcher struct {
+	tomb       tomb.Tomb
+	statePool  *state.StatePool
+	controller string
+	user       names.UserTag
+	all        bool
+	changes    chan []cache.ModelSummary
+
+	// summaries holds the last summary sent for each model.
+	summaries map[string]cache.ModelSummary
+}
+
+func newStateModelSummaryWatcher(
+	statePool *state.StatePool, controller string, user names.UserTag, all bool,
+) *stateModelSummaryWatcher {
+	w := &stateModelSummaryWatcher{
+		statePool:  statePool,
+		controller: controller,
+		user:       user,
+		all:        all,
+		changes:    make(chan []cache.ModelSummary),
+		summaries:  make(map[string]cache.ModelSummary),
+	}
+	w.tomb.Go(w.loop)
+	return w
+}
+
+// Changes returns the channel on which the changed model summaries are sent.
+func (w *stateModelSummaryWatcher) Changes() <-chan []cache.ModelSummary {
+	return w.changes
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *stateModelSummaryWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *stateModelSummaryWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Stop is part of the cache.Watcher interface.
+func (w *stateModelSummaryWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *stateModelSummaryWatcher) loop() error {
+	defer close(w.changes)
+	modelWatcher := w.statePool.SystemState().WatchModels()
+	defer func() { _ = modelWatcher.Stop() }()
+
+	pending, err := w.update(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// We want the first call to Next to get an empty list if that is
+	// all the user can see.
+	first := true
+	refresh := time.After(summaryRefreshDelay)
+	for {
+		var changes chan []cache.ModelSummary
+		if first || len(pending) > 0 {
+			changes = w.changes
+		}
+
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-modelWatcher.Changes():
+			if !ok {
+				return errors.Errorf("model watcher closed: %v", modelWatcher.Err())
+			}
+			if pending, err = w.update(pending); err != nil {
+				return errors.Trace(err)
+			}
+		case <-refresh:
+			if pending, err = w.update(pending); err != nil {
+				return errors.Trace(err)
+			}
+			refresh = time.After(summaryRefreshDelay)
+		case changes <- pending:
+			pending = nil
+			first = false
+		}
+	}
+}
+
+// update reads the summaries from state, and merges those that changed
+// since they were last sent into pending. Models the user can no longer
+// see are reported as removed.
+func (w *stateModelSummaryWatcher) update(pending []cache.ModelSummary) ([]cache.ModelSummary, error) {
+	summaries, err := w.readSummaries()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for uuid, summary := range summaries {
+		if last, ok := w.summaries[uuid]; ok && reflect.DeepEqual(last, summary) {
+			continue
+		}
+		w.summaries[uuid] = summary
+		pending = mergeSummary(pending, summary)
+	}
+	for uuid := range w.summaries {
+		if _, ok := summaries[uuid]; ok {
+			continue
+		}
+		delete(w.summaries, uuid)
+		pending = mergeSummary(pending, cache.ModelSummary{UUID: uuid, Removed: true})
+	}
+	return pending, nil
+}
+
+// mergeSummary replaces the pending summary for the same model, or adds
+// the summary to the end if there isn't one.
+func mergeSummary(pending []cache.ModelSummary, summary cache.ModelSummary) []cache.ModelSummary {
+	for i, value := range pending {
+		if value.UUID == summary.UUID {
+			pending[i] = summary
+			return pending
+		}
+	}
+	return append(pending, summary)
+}
+
+func (w *stateModelSummaryWatcher) readSummaries() (map[string]cache.ModelSummary, error) {
+	modelSummaries, err := w.statePool.SystemState().ModelSummariesForUser(w.user, w.all)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	summaries := make(map[string]cache.ModelSummary, len(modelSummaries))
+	for _, modelSummary := range modelSummaries {
+		summary, err := w.readSummary(modelSummary.UUID)
+		if errors.IsNotFound(err) {
+			// The model has been removed since it was listed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		summaries[summary.UUID] = summary
+	}
+	return summaries, nil
+}
+
+// readSummary builds the summary for the model the same way the model
+// cache does.
+func (w *stateModelSummaryWatcher) readSummary(uuid string) (cache.ModelSummary, error) {
+	st, err := w.statePool.Get(uuid)
+	if err != nil {
+		return cache.ModelSummary{}, errors.Trace(err)
+	}
+	defer st.Release()
+	model, err := st.Model()
+	if err != nil {
+		return cache.ModelSummary{}, errors.Trace(err)
+	}
+
+	statuses, err := model.LoadModelStatus()
+	if err != nil {
+		return cache.ModelSummary{}, errors.Trace(err)
+	}
+
+	overallStatus := cache.StatusGreen
+	var messages []cache.ModelSummaryMessage
+	var machineCount, containerCount, unitCount int
+
+	machines, err := st.AllMachines()
+	if err != nil {
+		return cache.ModelSummary{}, errors.Trace(err)
+	}
+	for _, machine := range machines {
+		if machine.IsContainer() {
+			containerCount++
+		} else {
+			machineCount++
+		}
+		info, err := statuses.MachineAgent(machine.Id())
+		if err != nil && !errors.IsNotFound(err) {
+			return cache.ModelSummary{}, errors.Trace(err)
+		}
+		if info.Status == status.Error {
+			overallStatus = cache.StatusRed
+			messages = append(messages, cache.ModelSummaryMessage{
+				Agent:   machine.Id(),
+				Message: info.Message,
+			})
+		}
+	}
+
+	applications, err := st.AllApplications()
+	if err != nil {
+		return cache.ModelSummary{}, errors.Trace(err)
+	}
+	for _, application := range applications {
+		units, err := application.AllUnits()
+		if err != nil {
+			return cache.ModelSummary{}, errors.Trace(err)
+		}
+		expectWorkload, err := state.CheckApplicationExpectsWorkload(model, application.Name())
+		if err != nil {
+			return cache.ModelSummary{}, errors.Trace(err)
+		}
+		unitCount += len(units)
+		for _, unit := range units {
+			// An agent in error is reported as the workload status.
+			info, err := statuses.UnitWorkload(unit.Name(), expectWorkload)
+			if err != nil && !errors.IsNotFound(err) {
+				return cache.ModelSummary{}, errors.Trace(err)
+			}
+			switch info.Status {
+			case status.Error:
+				overallStatus = cache.StatusRed
+			case status.Blocked:
+				if overallStatus == cache.StatusGreen {
+					overallStatus = cache.StatusYellow
+				}
+			default:
+				continue
+			}
+			messages = append(messages, cache.ModelSummaryMessage{
+				Agent:   unit.Name(),
+				Message: info.Message,
+			})
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Agent < messages[j].Agent })
+
+	relations, err := st.AllRelations()
+	if err != nil {
+		return cache.ModelSummary{}, errors.Trace(err)
+	}
+
+	users, err := model.Users()
+	if err != nil {
+		return cache.ModelSummary{}, errors.Trace(err)
+	}
+	var admins []string
+	for _, user := range users {
+		if user.Access == permission.AdminAccess {
+			admins = append(admins, strings.ToLower(user.UserTag.Id()))
+		}
+	}
+	sort.Strings(admins)
+
+	annotations, err := model.Annotations(model)
+	if err != nil {
+		return cache.ModelSummary{}, errors.Trace(err)
+	}
+
+	var credential string
+	if tag, ok := model.CloudCredentialTag(); ok {
+		credential = tag.Id()
+	}
+
+	return cache.ModelSummary{
+		UUID:        model.UUID(),
+		Controller:  w.controller,
+		Namespace:   model.Owner().Id(),
+		Name:        model.Name(),
+		Admins:      admins,
+		Status:      overallStatus,
+		Annotations: annotations,
+		Messages:    messages,
+
+		Cloud:      model.CloudName(),
+		Region:     model.CloudRegion(),
+		Credential: credential,
+
+		MachineCount:     machineCount,
+		ContainerCount:   containerCount,
+		ApplicationCount: len(applications),
+		UnitCount:        unitCount,
+		RelationCount:    len(relations),
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 )
 
@@ -33,6 +34,10 @@ type Model interface {
 // ModelCache describes a cached model used by the model generation API.
 type ModelCache interface {
 	Branch(string) (cache.Branch, error)
+
+	// Degraded returns true if the cache may be stale,
+	// in which case the model should be read from state.
+	Degraded() bool
 }
 
 // Generation defines the methods used by a generation.
@@ -42,13 +47,19 @@ type Generation interface {
 	CreatedBy() string
 	Completed() int64
 	CompletedBy() string
+	Comment() string
 	AssignAllUnits(string) error
 	AssignUnits(string, int) error
 	AssignUnit(string) error
+	UnassignAllUnits(string) error
+	UnassignUnit(string) error
 	AssignedUnits() map[string][]string
-	Commit(string) (int, error)
+	TrackingPolicy(string) model.BranchTrackingPolicy
+	SetTrackingPolicy(string, model.BranchTrackingPolicy) error
+	Commit(string, bool, string) (int, error)
 	Abort(string) error
 	Config() map[string]settings.ItemChanges
+	ConfigConflicts() (map[string][]settings.Conflict, error)
 	GenerationId() int
 }
 
@@ -9,6 +9,7 @@ import (
 	charm "github.com/juju/charm/v9"
 	modelgeneration "github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	cache "github.com/juju/juju/core/cache"
+	model "github.com/juju/juju/core/model"
 	settings "github.com/juju/juju/core/settings"
 	names "github.com/juju/names/v4"
 	reflect "reflect"
@@ -299,19 +300,33 @@ func (mr *MockGenerationMockRecorder) BranchName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchName", reflect.TypeOf((*MockGeneration)(nil).BranchName))
 }
 
+// Comment mocks base method
+func (m *MockGeneration) Comment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Comment indicates an expected call of Comment
+func (mr *MockGenerationMockRecorder) Comment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comment", reflect.TypeOf((*MockGeneration)(nil).Comment))
+}
+
 // Commit mocks base method
-func (m *MockGeneration) Commit(arg0 string) (int, error) {
+func (m *MockGeneration) Commit(arg0 string, arg1 bool, arg2 string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Commit", arg0)
+	ret := m.ctrl.Call(m, "Commit", arg0, arg1, arg2)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Commit indicates an expected call of Commit
-func (mr *MockGenerationMockRecorder) Commit(arg0 interface{}) *gomock.Call {
+func (mr *MockGenerationMockRecorder) Commit(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGeneration)(nil).Commit), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockGeneration)(nil).Commit), arg0, arg1, arg2)
 }
 
 // Completed mocks base method
@@ -356,6 +371,21 @@ func (mr *MockGenerationMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockGeneration)(nil).Config))
 }
 
+// ConfigConflicts mocks base method
+func (m *MockGeneration) ConfigConflicts() (map[string][]settings.Conflict, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigConflicts")
+	ret0, _ := ret[0].(map[string][]settings.Conflict)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigConflicts indicates an expected call of ConfigConflicts
+func (mr *MockGenerationMockRecorder) ConfigConflicts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigConflicts", reflect.TypeOf((*MockGeneration)(nil).ConfigConflicts))
+}
+
 // Created mocks base method
 func (m *MockGeneration) Created() int64 {
 	m.ctrl.T.Helper()
@@ -398,6 +428,62 @@ func (mr *MockGenerationMockRecorder) GenerationId() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerationId", reflect.TypeOf((*MockGeneration)(nil).GenerationId))
 }
 
+// SetTrackingPolicy mocks base method
+func (m *MockGeneration) SetTrackingPolicy(arg0 string, arg1 model.BranchTrackingPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTrackingPolicy", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTrackingPolicy indicates an expected call of SetTrackingPolicy
+func (mr *MockGenerationMockRecorder) SetTrackingPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrackingPolicy", reflect.TypeOf((*MockGeneration)(nil).SetTrackingPolicy), arg0, arg1)
+}
+
+// TrackingPolicy mocks base method
+func (m *MockGeneration) TrackingPolicy(arg0 string) model.BranchTrackingPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackingPolicy", arg0)
+	ret0, _ := ret[0].(model.BranchTrackingPolicy)
+	return ret0
+}
+
+// TrackingPolicy indicates an expected call of TrackingPolicy
+func (mr *MockGenerationMockRecorder) TrackingPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackingPolicy", reflect.TypeOf((*MockGeneration)(nil).TrackingPolicy), arg0)
+}
+
+// UnassignAllUnits mocks base method
+func (m *MockGeneration) UnassignAllUnits(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignAllUnits", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignAllUnits indicates an expected call of UnassignAllUnits
+func (mr *MockGenerationMockRecorder) UnassignAllUnits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignAllUnits", reflect.TypeOf((*MockGeneration)(nil).UnassignAllUnits), arg0)
+}
+
+// UnassignUnit mocks base method
+func (m *MockGeneration) UnassignUnit(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignUnit", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignUnit indicates an expected call of UnassignUnit
+func (mr *MockGenerationMockRecorder) UnassignUnit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignUnit", reflect.TypeOf((*MockGeneration)(nil).UnassignUnit), arg0)
+}
+
 // MockApplication is a mock of Application interface
 type MockApplication struct {
 	ctrl     *gomock.Controller
@@ -488,3 +574,17 @@ func (mr *MockModelCacheMockRecorder) Branch(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Branch", reflect.TypeOf((*MockModelCache)(nil).Branch), arg0)
 }
+
+// Degraded mocks base method
+func (m *MockModelCache) Degraded() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Degraded")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Degraded indicates an expected call of Degraded
+func (mr *MockModelCacheMockRecorder) Degraded() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Degraded", reflect.TypeOf((*MockModelCache)(nil).Degraded))
+}
@@ -5,6 +5,7 @@ package modelgeneration
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -17,6 +18,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/settings"
+	stateerrors "github.com/juju/juju/state/errors"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.modelgeneration")
@@ -32,10 +35,22 @@ type API struct {
 	modelCache        ModelCache
 }
 
-type APIV3 struct {
+type APIV6 struct {
 	*API
 }
 
+type APIV5 struct {
+	*APIV6
+}
+
+type APIV4 struct {
+	*APIV5
+}
+
+type APIV3 struct {
+	*APIV4
+}
+
 type APIV2 struct {
 	*APIV3
 }
@@ -44,19 +59,47 @@ type APIV1 struct {
 	*APIV2
 }
 
-// NewModelGenerationFacadeV4 provides the signature required for facade registration.
-func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
+// NewModelGenerationFacadeV7 provides the signature required for facade registration.
+func NewModelGenerationFacadeV7(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	st := &stateShim{State: ctx.State()}
 	m, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	mc, err := ctx.Controller().Model(st.ModelUUID())
+	ctrl := ctx.Controller()
+	mc, err := ctrl.Model(st.ModelUUID())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return NewModelGenerationAPI(st, authorizer, m, &modelCacheShim{Model: mc})
+	return NewModelGenerationAPI(st, authorizer, m, &modelCacheShim{Model: mc, controller: ctrl})
+}
+
+// NewModelGenerationFacadeV6 provides the signature required for facade registration.
+func NewModelGenerationFacadeV6(ctx facade.Context) (*APIV6, error) {
+	v7, err := NewModelGenerationFacadeV7(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV6{v7}, nil
+}
+
+// NewModelGenerationFacadeV5 provides the signature required for facade registration.
+func NewModelGenerationFacadeV5(ctx facade.Context) (*APIV5, error) {
+	v6, err := NewModelGenerationFacadeV6(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV5{v6}, nil
+}
+
+// NewModelGenerationFacadeV4 provides the signature required for facade registration.
+func NewModelGenerationFacadeV4(ctx facade.Context) (*APIV4, error) {
+	v5, err := NewModelGenerationFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{v5}, nil
 }
 
 // NewModelGenerationFacadeV3 provides the signature required for facade registration.
@@ -123,14 +166,42 @@ func (api *API) hasAdminAccess() (bool, error) {
 	return canWrite, err
 }
 
+func (api *API) hasWriteAccess() (bool, error) {
+	canWrite, err := api.authorizer.HasPermission(permission.WriteAccess, api.model.ModelTag())
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	return canWrite, err
+}
+
+// canModifyBranch returns true if the API user may make changes to the
+// input branch. Model and controller admins may modify any branch.
+// Other users may only modify the branches they created,
+// and then only if they have write access to the model.
+func (api *API) canModifyBranch(branch Generation) (bool, error) {
+	if api.isControllerAdmin {
+		return true, nil
+	}
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil || isModelAdmin {
+		return isModelAdmin, errors.Trace(err)
+	}
+	if branch.CreatedBy() != api.apiUser.Name() {
+		return false, nil
+	}
+	return api.hasWriteAccess()
+}
+
 // AddBranch adds a new branch with the input name to the model.
+// Any user with write access to the model may add a branch,
+// and is recorded as its creator.
 func (api *API) AddBranch(arg params.BranchArg) (params.ErrorResult, error) {
 	result := params.ErrorResult{}
-	isModelAdmin, err := api.hasAdminAccess()
+	canWrite, err := api.hasWriteAccess()
 	if err != nil {
 		return result, errors.Trace(err)
 	}
-	if !isModelAdmin && !api.isControllerAdmin {
+	if !canWrite && !api.isControllerAdmin {
 		return result, apiservererrors.ErrPerm
 	}
 
@@ -152,12 +223,15 @@ func (api *APIV2) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, e
 
 // TrackBranch marks the input units and/or applications as tracking the input
 // branch, causing them to realise changes made under that branch.
+// If NumUnits is set for an application, only that many of its untracked
+// units are set to track the branch, lowest unit numbers first.
+// Only model admins and the creator of the branch may track entities to it.
 func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, error) {
-	isModelAdmin, err := api.hasAdminAccess()
+	canWrite, err := api.hasWriteAccess()
 	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
-	if !isModelAdmin && !api.isControllerAdmin {
+	if !canWrite && !api.isControllerAdmin {
 		return params.ErrorResults{}, apiservererrors.ErrPerm
 	}
 	// Ensure we guard against the numUnits being greater than 0 and the number
@@ -168,10 +242,30 @@ func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, err
 		return params.ErrorResults{}, errors.Errorf("number of units and unit IDs can not be specified at the same time")
 	}
 
+	// Unless specified, tracking a whole application means that its
+	// units added while the branch is in-flight will also track it.
+	policy := model.BranchTrackingPolicy(arg.TrackingPolicy)
+	if policy == "" {
+		policy = model.BranchTrackAll
+		if arg.NumUnits > 0 {
+			policy = model.BranchTrackExplicitUnits
+		}
+	}
+	if err := policy.Validate(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
 	branch, err := api.model.Branch(arg.BranchName)
 	if err != nil {
 		return params.ErrorResults{}, errors.Trace(err)
 	}
+	canModify, err := api.canModifyBranch(branch)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if !canModify {
+		return params.ErrorResults{}, apiservererrors.ErrPerm
+	}
 
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(arg.Entities)),
@@ -184,8 +278,17 @@ func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, err
 		}
 		switch tag.Kind() {
 		case names.ApplicationTagKind:
-			result.Results[i].Error = apiservererrors.ServerError(branch.AssignUnits(tag.Id(), arg.NumUnits))
+			if err := branch.AssignUnits(tag.Id(), arg.NumUnits); err != nil {
+				result.Results[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+			result.Results[i].Error = apiservererrors.ServerError(branch.SetTrackingPolicy(tag.Id(), policy))
 		case names.UnitTagKind:
+			if arg.NumUnits > 0 {
+				result.Results[i].Error = apiservererrors.ServerError(
+					errors.NotValidf("number of units for unit %q", tag.Id()))
+				continue
+			}
 			result.Results[i].Error = apiservererrors.ServerError(branch.AssignUnit(tag.Id()))
 		default:
 			result.Results[i].Error = apiservererrors.ServerError(
@@ -195,28 +298,111 @@ func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, err
 	return result, nil
 }
 
+// UntrackBranch is not available before V7.
+func (api *APIV6) UntrackBranch(_, _ struct{}) {}
+
+// UntrackBranch marks the input units and/or applications as no longer
+// tracking the input branch. Untracking an application untracks all of its
+// units, and units added to it later do not track the branch. Config changes
+// made under the branch are retained. Units cannot be untracked from a branch
+// that was committed or aborted.
+// Only model admins and the creator of the branch may untrack entities from it.
+func (api *API) UntrackBranch(arg params.BranchTrackArg) (params.ErrorResults, error) {
+	canWrite, err := api.hasWriteAccess()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if !canWrite && !api.isControllerAdmin {
+		return params.ErrorResults{}, apiservererrors.ErrPerm
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	canModify, err := api.canModifyBranch(branch)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if !canModify {
+		return params.ErrorResults{}, apiservererrors.ErrPerm
+	}
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(arg.Entities)),
+	}
+	for i, entity := range arg.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		switch tag.Kind() {
+		case names.ApplicationTagKind:
+			result.Results[i].Error = apiservererrors.ServerError(branch.UnassignAllUnits(tag.Id()))
+		case names.UnitTagKind:
+			result.Results[i].Error = apiservererrors.ServerError(branch.UnassignUnit(tag.Id()))
+		default:
+			result.Results[i].Error = apiservererrors.ServerError(
+				errors.Errorf("expected names.UnitTag or names.ApplicationTag, got %T", tag))
+		}
+	}
+	return result, nil
+}
+
 // CommitBranch commits the input branch, making its changes applicable to
 // the whole model and marking it complete.
-func (api *API) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
-	result := params.IntResult{}
+// Only model admins and the creator of the branch may commit it.
+// Config conflicts are not checked before V6, preserving the behaviour
+// expected by older clients of branch values overwriting those on master.
+func (api *APIV5) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
+	res, err := api.API.CommitBranch(params.BranchCommitArg{
+		BranchName: arg.BranchName,
+		Force:      true,
+	})
+	return params.IntResult{
+		Result: res.GenerationId,
+		Error:  res.Error,
+	}, err
+}
 
-	isModelAdmin, err := api.hasAdminAccess()
+// CommitBranch commits the input branch, making its changes applicable to
+// the whole model and marking it complete.
+// If config changed under the branch has since been changed on master,
+// the branch is not committed and the conflicts are returned,
+// unless the commit is forced.
+// The optional comment is recorded with the commit.
+// Only model admins and the creator of the branch may commit it.
+func (api *API) CommitBranch(arg params.BranchCommitArg) (params.BranchCommitResult, error) {
+	result := params.BranchCommitResult{}
+
+	canWrite, err := api.hasWriteAccess()
 	if err != nil {
 		return result, errors.Trace(err)
 	}
-	if !isModelAdmin && !api.isControllerAdmin {
+	if !canWrite && !api.isControllerAdmin {
 		return result, apiservererrors.ErrPerm
 	}
 
 	branch, err := api.model.Branch(arg.BranchName)
 	if err != nil {
-		return intResultsError(err)
+		result.Error = apiservererrors.ServerError(err)
+		return result, nil
+	}
+	canModify, err := api.canModifyBranch(branch)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !canModify {
+		return result, apiservererrors.ErrPerm
 	}
 
-	if genId, err := branch.Commit(api.apiUser.Name()); err != nil {
+	genId, err := branch.Commit(api.apiUser.Name(), arg.Force, arg.Comment)
+	if err != nil {
+		result.Conflicts = applicationConfigConflicts(stateerrors.BranchConflicts(err))
 		result.Error = apiservererrors.ServerError(err)
 	} else {
-		result.Result = genId
+		result.GenerationId = genId
 	}
 	return result, nil
 }
@@ -224,14 +410,15 @@ func (api *API) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
 // AbortBranch aborts the input branch, marking it complete.  However no
 // changes are made applicable to the whole model.  No units may be assigned
 // to the branch when aborting.
+// Only model admins and the creator of the branch may abort it.
 func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
 	result := params.ErrorResult{}
 
-	isModelAdmin, err := api.hasAdminAccess()
+	canWrite, err := api.hasWriteAccess()
 	if err != nil {
 		return result, errors.Trace(err)
 	}
-	if !isModelAdmin && !api.isControllerAdmin {
+	if !canWrite && !api.isControllerAdmin {
 		return result, apiservererrors.ErrPerm
 	}
 
@@ -240,6 +427,13 @@ func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
 		result.Error = apiservererrors.ServerError(err)
 		return result, nil
 	}
+	canModify, err := api.canModifyBranch(branch)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !canModify {
+		return result, apiservererrors.ErrPerm
+	}
 
 	if err := branch.Abort(api.apiUser.Name()); err != nil {
 		result.Error = apiservererrors.ServerError(err)
@@ -255,11 +449,11 @@ func (api *API) BranchInfo(
 	args params.BranchInfoArgs) (params.BranchResults, error) {
 	result := params.BranchResults{}
 
-	isModelAdmin, err := api.hasAdminAccess()
+	canWrite, err := api.hasWriteAccess()
 	if err != nil {
 		return result, errors.Trace(err)
 	}
-	if !isModelAdmin && !api.isControllerAdmin {
+	if !canWrite && !api.isControllerAdmin {
 		return result, apiservererrors.ErrPerm
 	}
 
@@ -285,11 +479,63 @@ func (api *API) BranchInfo(
 		if results[i], err = api.oneBranchInfo(b, args.Detailed); err != nil {
 			return branchResultsError(err)
 		}
+
+		// Show any changes that would prevent the branch being committed.
+		conflicts, err := b.ConfigConflicts()
+		if err != nil {
+			return branchResultsError(err)
+		}
+		for j, app := range results[i].Applications {
+			results[i].Applications[j].ConfigConflicts = configConflicts(conflicts[app.ApplicationName])
+		}
 	}
 	result.Generations = results
 	return result, nil
 }
 
+// BranchSummaries returns a compact summary of each "in-flight" branch,
+// including the number of applications and units tracking it.
+// Unlike BranchInfo, the application units are not listed, making this
+// suitable for inclusion in model status.
+func (api *API) BranchSummaries() (params.BranchSummaryResults, error) {
+	result := params.BranchSummaryResults{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, apiservererrors.ErrPerm
+	}
+
+	branches, err := api.model.Branches()
+	if err != nil {
+		result.Error = apiservererrors.ServerError(err)
+		return result, nil
+	}
+
+	summaries := make([]params.BranchSummary, len(branches))
+	for i, b := range branches {
+		assigned := b.AssignedUnits()
+		var tracking int
+		for _, units := range assigned {
+			tracking += len(units)
+		}
+		summaries[i] = params.BranchSummary{
+			BranchName:       b.BranchName(),
+			Created:          b.Created(),
+			CreatedBy:        b.CreatedBy(),
+			TrackedUnitCount: tracking,
+			ApplicationCount: len(assigned),
+		}
+	}
+	result.Summaries = summaries
+	return result, nil
+}
+
+// BranchSummaries is not available before V5.
+func (api *APIV4) BranchSummaries(_, _ struct{}) {}
+
 // ShowCommit will return details a commit given by its generationId
 // An error is returned if either no branch can be found corresponding to the generation id.
 // Or the generation id given is below 1.
@@ -347,6 +593,7 @@ func (api *API) ListCommits() (params.BranchResults, error) {
 			BranchName:   b.BranchName(),
 			Completed:    b.Completed(),
 			CompletedBy:  b.CompletedBy(),
+			Comment:      b.Comment(),
 			GenerationId: b.GenerationId(),
 		}
 		results[i] = gen
@@ -373,6 +620,7 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		branchApp := params.GenerationApplication{
 			ApplicationName: appName,
 			UnitProgress:    fmt.Sprintf("%d/%d", len(tracking), len(allUnits)),
+			TrackingPolicy:  string(branch.TrackingPolicy(appName)),
 		}
 
 		// Determine the effective charm configuration changes.
@@ -396,10 +644,13 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		apps = append(apps, branchApp)
 	}
 
+	createdBy := branch.CreatedBy()
 	return params.Generation{
 		BranchName:   branch.BranchName(),
 		Created:      branch.Created(),
-		CreatedBy:    branch.CreatedBy(),
+		CreatedBy:    createdBy,
+		OwnedByYou:   createdBy == api.apiUser.Name(),
+		Comment:      branch.Comment(),
 		Applications: apps,
 	}, nil
 }
@@ -413,6 +664,7 @@ func (api *API) getGenerationCommit(branch Generation) (params.Generation, error
 		BranchName:   branch.BranchName(),
 		Completed:    branch.Completed(),
 		CompletedBy:  branch.CompletedBy(),
+		Comment:      generation.Comment,
 		GenerationId: branch.GenerationId(),
 		Created:      branch.Created(),
 		CreatedBy:    branch.CreatedBy(),
@@ -424,15 +676,21 @@ func (api *API) getGenerationCommit(branch Generation) (params.Generation, error
 // branch matching the input name.
 func (api *API) HasActiveBranch(arg params.BranchArg) (params.BoolResult, error) {
 	result := params.BoolResult{}
-	isModelAdmin, err := api.hasAdminAccess()
+	canWrite, err := api.hasWriteAccess()
 	if err != nil {
 		return result, errors.Trace(err)
 	}
-	if !isModelAdmin && !api.isControllerAdmin {
+	if !canWrite && !api.isControllerAdmin {
 		return result, apiservererrors.ErrPerm
 	}
 
-	if _, err := api.modelCache.Branch(arg.BranchName); err != nil {
+	// While the cache may be stale, the branch is read from state.
+	if api.modelCache.Degraded() {
+		_, err = api.model.Branch(arg.BranchName)
+	} else {
+		_, err = api.modelCache.Branch(arg.BranchName)
+	}
+	if err != nil {
 		if errors.IsNotFound(err) {
 			result.Result = false
 		} else {
@@ -444,6 +702,45 @@ func (api *API) HasActiveBranch(arg params.BranchArg) (params.BoolResult, error)
 	return result, nil
 }
 
+// applicationConfigConflicts converts the input config
+// conflicts, keyed by application name, to their params
+// representation, ordered by application name.
+func applicationConfigConflicts(conflicts map[string][]settings.Conflict) []params.ApplicationConfigConflicts {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	appNames := make([]string, 0, len(conflicts))
+	for appName := range conflicts {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	result := make([]params.ApplicationConfigConflicts, len(appNames))
+	for i, appName := range appNames {
+		result[i] = params.ApplicationConfigConflicts{
+			ApplicationName: appName,
+			Conflicts:       configConflicts(conflicts[appName]),
+		}
+	}
+	return result
+}
+
+func configConflicts(conflicts []settings.Conflict) []params.ConfigConflict {
+	if len(conflicts) == 0 {
+		return nil
+	}
+	result := make([]params.ConfigConflict, len(conflicts))
+	for i, conflict := range conflicts {
+		result[i] = params.ConfigConflict{
+			Key:      conflict.Key,
+			Baseline: conflict.Baseline,
+			Master:   conflict.Master,
+			Branch:   conflict.Branch,
+		}
+	}
+	return result
+}
+
 func branchResultsError(err error) (params.BranchResults, error) {
 	return params.BranchResults{Error: apiservererrors.ServerError(err)}, nil
 }
@@ -451,7 +748,3 @@ func branchResultsError(err error) (params.BranchResults, error) {
 func generationResultError(err error) (params.GenerationResult, error) {
 	return params.GenerationResult{Error: apiservererrors.ServerError(err)}, nil
 }
-
-func intResultsError(err error) (params.IntResult, error) {
-	return params.IntResult{Error: apiservererrors.ServerError(err)}, nil
-}
@@ -16,7 +16,9 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration/mocks"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/settings"
+	stateerrors "github.com/juju/juju/state/errors"
 )
 
 type modelGenerationSuite struct {
@@ -44,9 +46,6 @@ func (s *modelGenerationSuite) TearDownTest(c *gc.C) {
 	s.api = nil
 }
 
-// TODO (hml) 17-jan-2019
-// Add more explicit permissions tests once that requirement is ironed out.
-
 func (s *modelGenerationSuite) TestAddBranchInvalidNameError(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 
@@ -69,6 +68,7 @@ func (s *modelGenerationSuite) TestAddBranchSuccess(c *gc.C) {
 func (s *modelGenerationSuite) TestTrackBranchEntityTypeError(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectAssignUnits("ghost", 0)
+	s.expectSetTrackingPolicy("ghost", model.BranchTrackAll)
 	s.expectAssignUnit("mysql/0")
 	s.expectBranch()
 
@@ -92,6 +92,7 @@ func (s *modelGenerationSuite) TestTrackBranchEntityTypeError(c *gc.C) {
 func (s *modelGenerationSuite) TestTrackBranchSuccess(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectAssignUnits("ghost", 0)
+	s.expectSetTrackingPolicy("ghost", model.BranchTrackAll)
 	s.expectAssignUnit("mysql/0")
 	s.expectBranch()
 
@@ -126,12 +127,176 @@ func (s *modelGenerationSuite) TestTrackBranchWithTooManyNumUnits(c *gc.C) {
 	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult(nil))
 }
 
+func (s *modelGenerationSuite) TestTrackBranchNumUnitsExplicitPolicy(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectAssignUnits("ghost", 2)
+	s.expectSetTrackingPolicy("ghost", model.BranchTrackExplicitUnits)
+	s.expectBranch()
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities:   []params.Entity{{Tag: names.NewApplicationTag("ghost").String()}},
+		NumUnits:   2,
+	}
+	result, err := s.api.TrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{{Error: nil}})
+}
+
+func (s *modelGenerationSuite) TestTrackBranchNumUnitsForUnit(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities:   []params.Entity{{Tag: names.NewUnitTag("mysql/0").String()}},
+		NumUnits:   1,
+	}
+	result, err := s.api.TrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `number of units for unit "mysql/0" not valid`)
+}
+
+func (s *modelGenerationSuite) TestTrackBranchSuppliedPolicy(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectAssignUnits("ghost", 0)
+	s.expectSetTrackingPolicy("ghost", model.BranchTrackExplicitUnits)
+	s.expectBranch()
+
+	arg := params.BranchTrackArg{
+		BranchName:     s.newBranchName,
+		Entities:       []params.Entity{{Tag: names.NewApplicationTag("ghost").String()}},
+		TrackingPolicy: "explicit-units",
+	}
+	result, err := s.api.TrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{{Error: nil}})
+}
+
+func (s *modelGenerationSuite) TestTrackBranchInvalidPolicy(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+
+	arg := params.BranchTrackArg{
+		BranchName:     s.newBranchName,
+		Entities:       []params.Entity{{Tag: names.NewApplicationTag("ghost").String()}},
+		TrackingPolicy: "some-units",
+	}
+	_, err := s.api.TrackBranch(arg)
+	c.Assert(err, gc.ErrorMatches, `branch tracking policy "some-units" not valid`)
+}
+
+func (s *modelGenerationSuite) TestUntrackBranchSuccess(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().UnassignUnit("mysql/0").Return(nil)
+	s.mockGen.EXPECT().UnassignAllUnits("ghost").Return(nil)
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities: []params.Entity{
+			{Tag: names.NewUnitTag("mysql/0").String()},
+			{Tag: names.NewApplicationTag("ghost").String()},
+			{Tag: names.NewMachineTag("7").String()},
+		},
+	}
+	result, err := s.api.UntrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: nil},
+		{Error: &params.Error{Message: "expected names.UnitTag or names.ApplicationTag, got names.MachineTag"}},
+	})
+}
+
+func (s *modelGenerationSuite) TestUntrackBranchCommittedError(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().UnassignUnit("mysql/0").Return(errors.New("branch was already committed"))
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities:   []params.Entity{{Tag: names.NewUnitTag("mysql/0").String()}},
+	}
+	result, err := s.api.UntrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{Message: "branch was already committed"}},
+	})
+}
+
+func (s *modelGenerationSuite) TestUntrackBranchOtherWriteUser(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, "other-user", permission.WriteAccess).Finish()
+	s.expectBranch()
+	s.expectCreatedBy()
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities:   []params.Entity{{Tag: names.NewUnitTag("mysql/0").String()}},
+	}
+	_, err := s.api.UntrackBranch(arg)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelGenerationSuite) TestCommitBranchSuccess(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectCommit()
 	s.expectBranch()
 
-	result, err := s.api.CommitBranch(s.newBranchArg())
+	result, err := s.api.CommitBranch(s.newCommitArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BranchCommitResult{GenerationId: 3})
+}
+
+func (s *modelGenerationSuite) TestCommitBranchConfigConflict(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().Commit(s.apiUser, false, "").Return(0, stateerrors.NewBranchConflictError(
+		s.newBranchName, map[string][]settings.Conflict{"redis": s.configConflicts()}))
+
+	result, err := s.api.CommitBranch(s.newCommitArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `branch "new-branch" config changed on master .*: redis \(port\)`)
+	c.Check(result.GenerationId, gc.Equals, 0)
+	c.Check(result.Conflicts, gc.DeepEquals, []params.ApplicationConfigConflicts{{
+		ApplicationName: "redis",
+		Conflicts: []params.ConfigConflict{
+			{Key: "port", Baseline: 7000, Master: 7500, Branch: 8000},
+		},
+	}})
+}
+
+func (s *modelGenerationSuite) TestCommitBranchForce(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().Commit(s.apiUser, true, "").Return(3, nil)
+
+	arg := s.newCommitArg()
+	arg.Force = true
+	result, err := s.api.CommitBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BranchCommitResult{GenerationId: 3})
+}
+
+func (s *modelGenerationSuite) TestCommitBranchWithComment(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().Commit(s.apiUser, false, "rolling out the new port").Return(3, nil)
+
+	arg := s.newCommitArg()
+	arg.Comment = "rolling out the new port"
+	result, err := s.api.CommitBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BranchCommitResult{GenerationId: 3})
+}
+
+func (s *modelGenerationSuite) TestCommitBranchV5Forced(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().Commit(s.apiUser, true, "").Return(3, nil)
+
+	api := &modelgeneration.APIV5{APIV6: &modelgeneration.APIV6{API: s.api}}
+	result, err := api.CommitBranch(s.newBranchArg())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, params.IntResult{Result: 3, Error: nil})
 }
@@ -146,6 +311,98 @@ func (s *modelGenerationSuite) TestAbortBranchSuccess(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
 }
 
+func (s *modelGenerationSuite) TestAddBranchWriteUser(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, s.apiUser, permission.WriteAccess).Finish()
+	s.expectAddBranch()
+
+	result, err := s.api.AddBranch(s.newBranchArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+}
+
+func (s *modelGenerationSuite) TestAddBranchReadUser(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, s.apiUser, permission.ReadAccess).Finish()
+
+	_, err := s.api.AddBranch(s.newBranchArg())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelGenerationSuite) TestTrackBranchCreator(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, s.apiUser, permission.WriteAccess).Finish()
+	s.expectBranch()
+	s.expectCreatedBy()
+	s.expectAssignUnit("mysql/0")
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities:   []params.Entity{{Tag: names.NewUnitTag("mysql/0").String()}},
+	}
+	result, err := s.api.TrackBranch(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{{Error: nil}})
+}
+
+func (s *modelGenerationSuite) TestTrackBranchOtherWriteUser(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, "other-user", permission.WriteAccess).Finish()
+	s.expectBranch()
+	s.expectCreatedBy()
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities:   []params.Entity{{Tag: names.NewUnitTag("mysql/0").String()}},
+	}
+	_, err := s.api.TrackBranch(arg)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelGenerationSuite) TestTrackBranchReadUser(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, s.apiUser, permission.ReadAccess).Finish()
+
+	arg := params.BranchTrackArg{
+		BranchName: s.newBranchName,
+		Entities:   []params.Entity{{Tag: names.NewUnitTag("mysql/0").String()}},
+	}
+	_, err := s.api.TrackBranch(arg)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelGenerationSuite) TestCommitBranchCreator(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, s.apiUser, permission.WriteAccess).Finish()
+	s.expectBranch()
+	s.expectCreatedBy()
+	s.expectCommit()
+
+	result, err := s.api.CommitBranch(s.newCommitArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BranchCommitResult{GenerationId: 3})
+}
+
+func (s *modelGenerationSuite) TestCommitBranchOtherWriteUser(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, "other-user", permission.WriteAccess).Finish()
+	s.expectBranch()
+	s.expectCreatedBy()
+
+	_, err := s.api.CommitBranch(s.newCommitArg())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelGenerationSuite) TestCommitBranchOtherAdminUser(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, "other-user", permission.AdminAccess).Finish()
+	s.expectBranch()
+	s.mockGen.EXPECT().Commit("other-user", false, "").Return(3, nil)
+
+	result, err := s.api.CommitBranch(s.newCommitArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BranchCommitResult{GenerationId: 3})
+}
+
+func (s *modelGenerationSuite) TestCommitBranchReadUser(c *gc.C) {
+	defer s.setupModelGenerationAPIForUser(c, s.apiUser, permission.ReadAccess).Finish()
+
+	_, err := s.api.CommitBranch(s.newCommitArg())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranchTrue(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectHasActiveBranch(nil)
@@ -166,6 +423,17 @@ func (s *modelGenerationSuite) TestHasActiveBranchFalse(c *gc.C) {
 	c.Check(result.Result, jc.IsFalse)
 }
 
+func (s *modelGenerationSuite) TestHasActiveBranchCacheDegraded(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.mockModelCache.EXPECT().Degraded().Return(true)
+	s.mockModel.EXPECT().Branch(s.newBranchName).Return(nil, errors.NotFoundf(s.newBranchName))
+
+	result, err := s.api.HasActiveBranch(s.newBranchArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Result, jc.IsFalse)
+}
+
 func (s *modelGenerationSuite) TestBranchInfoDetailed(c *gc.C) {
 	s.testBranchInfo(c, nil, true)
 }
@@ -181,10 +449,13 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	units := []string{"redis/0", "redis/1", "redis/2"}
 
 	s.expectConfig()
+	s.expectConfigConflicts()
 	s.expectBranchName()
 	s.expectAssignedUnits(units[:2])
+	s.expectTrackingPolicy("redis", model.BranchTrackAll)
 	s.expectCreated()
 	s.expectCreatedBy()
+	s.mockGen.EXPECT().Comment().Return("")
 
 	// Flex the code path based on whether we are getting all branches
 	// or a sub-set.
@@ -208,16 +479,22 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	c.Assert(gen.BranchName, gc.Equals, s.newBranchName)
 	c.Assert(gen.Created, gc.Equals, int64(666))
 	c.Assert(gen.CreatedBy, gc.Equals, s.apiUser)
+	c.Assert(gen.OwnedByYou, jc.IsTrue)
+	c.Assert(gen.Comment, gc.Equals, "")
 	c.Assert(gen.Applications, gc.HasLen, 1)
 
 	genApp := gen.Applications[0]
 	c.Check(genApp.ApplicationName, gc.Equals, "redis")
 	c.Check(genApp.UnitProgress, gc.Equals, "2/3")
+	c.Check(genApp.TrackingPolicy, gc.Equals, "track-all")
 	c.Check(genApp.ConfigChanges, gc.DeepEquals, map[string]interface{}{
 		"password":  "added-pass",
 		"databases": 16,
 		"port":      8000,
 	})
+	c.Check(genApp.ConfigConflicts, gc.DeepEquals, []params.ConfigConflict{
+		{Key: "port", Baseline: 7000, Master: 7500, Branch: 8000},
+	})
 
 	// Unit lists are only populated when detailed is true.
 	if detailed {
@@ -229,6 +506,32 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	}
 }
 
+func (s *modelGenerationSuite) TestBranchSummaries(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+
+	s.expectBranches()
+	s.expectBranchName()
+	s.expectCreated()
+	s.expectCreatedBy()
+	s.mockGen.EXPECT().AssignedUnits().Return(map[string][]string{
+		"redis": {"redis/0", "redis/1"},
+		"mysql": {"mysql/0"},
+	})
+
+	// No application is retrieved from state;
+	// the mock state would fail the test if one were.
+	result, err := s.api.BranchSummaries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Summaries, gc.DeepEquals, []params.BranchSummary{{
+		BranchName:       s.newBranchName,
+		Created:          666,
+		CreatedBy:        s.apiUser,
+		TrackedUnitCount: 3,
+		ApplicationCount: 2,
+	}})
+}
+
 func (s *modelGenerationSuite) setupModelGenerationAPI(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
@@ -238,7 +541,7 @@ func (s *modelGenerationSuite) setupModelGenerationAPI(c *gc.C) *gomock.Controll
 	s.mockState.EXPECT().ControllerTag().Return(names.NewControllerTag(s.modelUUID))
 
 	s.mockModel = mocks.NewMockModel(ctrl)
-	s.mockModel.EXPECT().ModelTag().Return(names.NewModelTag(s.modelUUID))
+	s.mockModel.EXPECT().ModelTag().Return(names.NewModelTag(s.modelUUID)).AnyTimes()
 
 	mockAuthorizer := facademocks.NewMockAuthorizer(ctrl)
 	aExp := mockAuthorizer.EXPECT()
@@ -255,10 +558,51 @@ func (s *modelGenerationSuite) setupModelGenerationAPI(c *gc.C) *gomock.Controll
 	return ctrl
 }
 
+// setupModelGenerationAPIForUser sets up the API for a user who is not a
+// controller admin, and who has the input access level on the model.
+func (s *modelGenerationSuite) setupModelGenerationAPIForUser(
+	c *gc.C, user string, access permission.Access,
+) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+
+	s.mockGen = mocks.NewMockGeneration(ctrl)
+
+	s.mockState = mocks.NewMockState(ctrl)
+	s.mockState.EXPECT().ControllerTag().Return(names.NewControllerTag(s.modelUUID))
+
+	s.mockModel = mocks.NewMockModel(ctrl)
+	s.mockModel.EXPECT().ModelTag().Return(names.NewModelTag(s.modelUUID)).AnyTimes()
+
+	mockAuthorizer := facademocks.NewMockAuthorizer(ctrl)
+	aExp := mockAuthorizer.EXPECT()
+	aExp.HasPermission(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(operation permission.Access, _ names.Tag) (bool, error) {
+			if operation == permission.SuperuserAccess {
+				return false, nil
+			}
+			return access.EqualOrGreaterModelAccessThan(operation), nil
+		},
+	).AnyTimes()
+	aExp.GetAuthTag().Return(names.NewUserTag(user))
+	aExp.AuthClient().Return(true)
+
+	s.mockModelCache = mocks.NewMockModelCache(ctrl)
+
+	var err error
+	s.api, err = modelgeneration.NewModelGenerationAPI(s.mockState, mockAuthorizer, s.mockModel, s.mockModelCache)
+	c.Assert(err, jc.ErrorIsNil)
+
+	return ctrl
+}
+
 func (s *modelGenerationSuite) newBranchArg() params.BranchArg {
 	return params.BranchArg{BranchName: s.newBranchName}
 }
 
+func (s *modelGenerationSuite) newCommitArg() params.BranchCommitArg {
+	return params.BranchCommitArg{BranchName: s.newBranchName}
+}
+
 func (s *modelGenerationSuite) expectAddBranch() {
 	s.mockModel.EXPECT().AddBranch(s.newBranchName, s.apiUser).Return(nil)
 }
@@ -272,6 +616,7 @@ func (s *modelGenerationSuite) expectBranch() {
 }
 
 func (s *modelGenerationSuite) expectHasActiveBranch(err error) {
+	s.mockModelCache.EXPECT().Degraded().Return(false)
 	s.mockModelCache.EXPECT().Branch(s.newBranchName).Return(cache.Branch{}, err)
 }
 
@@ -287,12 +632,20 @@ func (s *modelGenerationSuite) expectAssignUnit(unitName string) {
 	s.mockGen.EXPECT().AssignUnit(unitName).Return(nil)
 }
 
+func (s *modelGenerationSuite) expectSetTrackingPolicy(appName string, policy model.BranchTrackingPolicy) {
+	s.mockGen.EXPECT().SetTrackingPolicy(appName, policy).Return(nil)
+}
+
+func (s *modelGenerationSuite) expectTrackingPolicy(appName string, policy model.BranchTrackingPolicy) {
+	s.mockGen.EXPECT().TrackingPolicy(appName).Return(policy)
+}
+
 func (s *modelGenerationSuite) expectAbort() {
 	s.mockGen.EXPECT().Abort(s.apiUser).Return(nil)
 }
 
 func (s *modelGenerationSuite) expectCommit() {
-	s.mockGen.EXPECT().Commit(s.apiUser).Return(3, nil)
+	s.mockGen.EXPECT().Commit(s.apiUser, false, "").Return(3, nil)
 }
 
 func (s *modelGenerationSuite) expectAssignedUnits(units []string) {
@@ -319,6 +672,16 @@ func (s *modelGenerationSuite) expectConfig() {
 	}})
 }
 
+func (s *modelGenerationSuite) expectConfigConflicts() {
+	s.mockGen.EXPECT().ConfigConflicts().Return(map[string][]settings.Conflict{"redis": s.configConflicts()}, nil)
+}
+
+// configConflicts returns a conflict for the
+// port modification returned by expectConfig.
+func (s *modelGenerationSuite) configConflicts() []settings.Conflict {
+	return []settings.Conflict{{Key: "port", Baseline: 7000, Master: 7500, Branch: 8000}}
+}
+
 func (s *modelGenerationSuite) setupMockApp(ctrl *gomock.Controller, units []string) {
 	mockApp := mocks.NewMockApplication(ctrl)
 	mockApp.EXPECT().DefaultCharmConfig().Return(map[string]interface{}{
@@ -95,4 +95,9 @@ func (st *stateShim) Application(name string) (Application, error) {
 
 type modelCacheShim struct {
 	*cache.Model
+	controller *cache.Controller
+}
+
+func (s *modelCacheShim) Degraded() bool {
+	return s.controller.Health().Status == cache.HealthDegraded
 }
@@ -49,3 +49,18 @@ func (mr *MockCharmHubMockRecorder) Refresh(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockCharmHub)(nil).Refresh), arg0, arg1)
 }
+
+// ResourceInfo mocks base method
+func (m *MockCharmHub) ResourceInfo(arg0 context.Context, arg1, arg2 string, arg3 int) (transport.ResourceRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceInfo", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(transport.ResourceRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResourceInfo indicates an expected call of ResourceInfo
+func (mr *MockCharmHubMockRecorder) ResourceInfo(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceInfo", reflect.TypeOf((*MockCharmHub)(nil).ResourceInfo), arg0, arg1, arg2, arg3)
+}
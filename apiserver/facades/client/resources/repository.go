@@ -41,6 +41,7 @@ type CharmID struct {
 
 type CharmHub interface {
 	Refresh(ctx context.Context, config charmhub.RefreshConfig) ([]transport.RefreshResponse, error)
+	ResourceInfo(ctx context.Context, charm, resource string, revision int) (transport.ResourceRevision, error)
 }
 
 // ResourceClient requests the resource info for a given charm URL,
@@ -199,7 +200,16 @@ func (ch *charmHubClient) ResourceInfo(curl *charm.URL, origin corecharm.Origin,
 			}
 		}
 	}
-	return charmresource.Resource{}, errors.NotFoundf("charm resource %q at revision %d", name, revision)
+
+	// The refresh responses only include the resource revisions associated
+	// with the charm revision, so ask for any other revision directly.
+	rev, err := ch.client.ResourceInfo(context.TODO(), curl.Name, name, revision)
+	if errors.IsNotFound(err) {
+		return charmresource.Resource{}, errors.NotFoundf("charm resource %q at revision %d", name, revision)
+	} else if err != nil {
+		return charmresource.Resource{}, errors.Trace(err)
+	}
+	return resourceFromRevision(rev)
 }
 
 // listResources composes, a map of details for each of the charm's
@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/juju/charm/v9"
 	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2/hash"
 	gc "gopkg.in/check.v1"
@@ -95,6 +96,7 @@ func (s *CharmHubClientSuite) TestResolveResourcesNoMatchingRevision(c *gc.C) {
 	s.client = mocks.NewMockCharmHub(ctrl)
 	s.expectRefresh()
 	s.expectRefreshWithRevision(99)
+	s.client.EXPECT().ResourceInfo(gomock.Any(), "ubuntu", "wal-e", 1).Return(transport.ResourceRevision{}, errors.NotFoundf("revision 1"))
 
 	_, err := s.newClient().ResolveResources([]charmresource.Resource{{
 		Meta:     charmresource.Meta{Name: "wal-e", Type: 1, Path: "wal-e.snap", Description: "WAL-E Snap Package"},
@@ -102,9 +104,43 @@ func (s *CharmHubClientSuite) TestResolveResourcesNoMatchingRevision(c *gc.C) {
 		Revision: 1,
 		Size:     0,
 	}})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 	c.Assert(err, gc.ErrorMatches, `charm resource "wal-e" at revision 1 not found`)
 }
 
+func (s *CharmHubClientSuite) TestResolveResourcesFromResourceRevisions(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	s.client = mocks.NewMockCharmHub(ctrl)
+	s.expectRefresh()
+	s.expectRefreshWithRevision(99)
+	s.client.EXPECT().ResourceInfo(gomock.Any(), "ubuntu", "wal-e", 1).Return(transport.ResourceRevision{
+		Download:    transport.ResourceDownload{HashSHA384: "38b060a751ac96384cd9327eb1b1e36a21fdb71114be07434c0cc7bf63f6e1da274edebfe76f65fbd51ad2f14898b95b", Size: 10},
+		Name:        "wal-e",
+		Revision:    1,
+		Type:        "file",
+		Filename:    "wal-e.snap",
+		Description: "WAL-E Snap Package",
+	}, nil)
+
+	fp, err := charmresource.ParseFingerprint("38b060a751ac96384cd9327eb1b1e36a21fdb71114be07434c0cc7bf63f6e1da274edebfe76f65fbd51ad2f14898b95b")
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.newClient().ResolveResources([]charmresource.Resource{{
+		Meta:     charmresource.Meta{Name: "wal-e", Type: 1, Path: "wal-e.snap", Description: "WAL-E Snap Package"},
+		Origin:   charmresource.OriginStore,
+		Revision: 1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, []charmresource.Resource{{
+		Meta:        charmresource.Meta{Name: "wal-e", Type: 1, Path: "wal-e.snap", Description: "WAL-E Snap Package"},
+		Origin:      charmresource.OriginStore,
+		Revision:    1,
+		Fingerprint: fp,
+		Size:        10,
+	}})
+}
+
 func (s *CharmHubClientSuite) TestResolveResourcesUpload(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
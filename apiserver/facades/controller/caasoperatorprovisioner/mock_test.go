@@ -15,6 +15,7 @@ import (
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
@@ -86,6 +87,16 @@ func (st *mockState) StateServingInfo() (controller.StateServingInfo, error) {
 	}, nil
 }
 
+// mockAppWatcherState adapts mockState for use
+// by the common ApplicationWatcherFacade.
+type mockAppWatcherState struct {
+	*mockState
+}
+
+func (st mockAppWatcherState) Application(appName string) (common.AppWatcherApplication, error) {
+	return nil, errors.NotImplementedf("Application")
+}
+
 type mockStorageRegistry struct {
 	storage.ProviderRegistry
 }
@@ -94,6 +105,15 @@ func (m *mockStorageRegistry) StorageProvider(p storage.ProviderType) (storage.P
 	return nil, errors.NotFoundf("provider %q", p)
 }
 
+type mockImageChecker struct {
+	testing.Stub
+}
+
+func (m *mockImageChecker) CheckImage(details resources.DockerImageDetails) error {
+	m.MethodCall(m, "CheckImage", details)
+	return m.NextErr()
+}
+
 type mockStoragePoolManager struct {
 	testing.Stub
 	poolmanager.PoolManager
@@ -5,6 +5,8 @@ package caasoperatorprovisioner
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider"
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/cloudconfig/podcfg"
+	coreresources "github.com/juju/juju/core/resources"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/pki"
@@ -30,13 +33,19 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.caasoperatorprovisioner")
 
+// imageCheckTimeout bounds the time spent verifying
+// that an operator image can be pulled from its registry.
+const imageCheckTimeout = 30 * time.Second
+
 type APIGroup struct {
 	*common.ApplicationWatcherFacade
 	*API
 }
 
-// TODO (manadart 2020-10-21): Remove the ModelUUID method
-// from the next version of this facade.
+// APIGroupV1 provides v1 of the CAAS operator provisioner facade.
+type APIGroupV1 struct {
+	*APIGroup
+}
 
 // API is CAAS operator provisioner API facade.
 type API struct {
@@ -51,6 +60,16 @@ type API struct {
 	state              CAASOperatorProvisionerState
 	storagePoolManager poolmanager.PoolManager
 	registry           storage.ProviderRegistry
+	imageChecker       coreresources.RegistryChecker
+}
+
+// NewStateCAASOperatorProvisionerAPIV1 provides the signature required for facade V1 registration.
+func NewStateCAASOperatorProvisionerAPIV1(ctx facade.Context) (*APIGroupV1, error) {
+	api, err := NewStateCAASOperatorProvisionerAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIGroupV1{api}, nil
 }
 
 // NewStateCAASOperatorProvisionerAPI provides the signature required for facade registration.
@@ -72,7 +91,9 @@ func NewStateCAASOperatorProvisionerAPI(ctx facade.Context) (*APIGroup, error) {
 	api, err := NewCAASOperatorProvisionerAPI(resources, authorizer,
 		stateShim{ctx.StatePool().SystemState()},
 		stateShim{ctx.State()},
-		pm, registry)
+		pm, registry,
+		coreresources.NewRegistryChecker(&http.Client{Timeout: imageCheckTimeout}),
+	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -91,6 +112,7 @@ func NewCAASOperatorProvisionerAPI(
 	st CAASOperatorProvisionerState,
 	storagePoolManager poolmanager.PoolManager,
 	registry storage.ProviderRegistry,
+	imageChecker coreresources.RegistryChecker,
 ) (*API, error) {
 	if !authorizer.AuthController() {
 		return nil, apiservererrors.ErrPerm
@@ -105,6 +127,7 @@ func NewCAASOperatorProvisionerAPI(
 		state:              st,
 		storagePoolManager: storagePoolManager,
 		registry:           registry,
+		imageChecker:       imageChecker,
 	}, nil
 }
 
@@ -258,11 +281,54 @@ func (a *API) IssueOperatorCertificate(args params.Entities) (params.IssueOperat
 	return res, nil
 }
 
+// WatchApplicationsLife starts a StringsWatcher to watch CAAS applications
+// deployed to this model. The life of each application in the watcher's
+// initial event is returned with the watcher, so that callers do not need
+// to look it up separately.
+func (a *APIGroup) WatchApplicationsLife() (params.StringsLifeWatchResult, error) {
+	watch, err := a.WatchApplications()
+	if err != nil {
+		return params.StringsLifeWatchResult{}, errors.Trace(err)
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(watch.Changes))}
+	for i, appName := range watch.Changes {
+		args.Entities[i].Tag = names.NewApplicationTag(appName).String()
+	}
+	lives, err := a.Life(args)
+	if err != nil {
+		_ = a.resources.Stop(watch.StringsWatcherId)
+		return params.StringsLifeWatchResult{}, errors.Trace(err)
+	}
+	return params.StringsLifeWatchResult{
+		StringsWatcherId: watch.StringsWatcherId,
+		Changes:          watch.Changes,
+		Life:             lives.Results,
+	}, nil
+}
+
+// WatchApplicationsLife is not available in V1.
+func (*APIGroupV1) WatchApplicationsLife(_, _ struct{}) {}
+
+// CheckOperatorImage returns an error if the operator
+// image with the input path can not be pulled from its registry.
+func (a *API) CheckOperatorImage(args params.CheckOperatorImageArgs) (params.ErrorResult, error) {
+	err := a.imageChecker.CheckImage(coreresources.DockerImageDetails{RegistryPath: args.ImagePath})
+	if err != nil {
+		return params.ErrorResult{
+			Error: apiservererrors.ServerError(errors.Annotate(err, "checking operator image")),
+		}, nil
+	}
+	return params.ErrorResult{}, nil
+}
+
+// CheckOperatorImage is not available in V1.
+func (*APIGroupV1) CheckOperatorImage(_, _ struct{}) {}
+
 // ModelUUID returns the model UUID that this facade is used to operate.
 // It is implemented here directly as a result of removing it from
 // embedded APIAddresser *without* bumping the facade version.
-// It should be blanked when this facade version is next incremented.
-func (a *API) ModelUUID() params.StringResult {
+// It is not available from V2 of the facade.
+func (a *APIGroupV1) ModelUUID() params.StringResult {
 	m, err := a.state.Model()
 	if err != nil {
 		return params.StringResult{Error: apiservererrors.ServerError(err)}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/pki"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
@@ -34,6 +35,7 @@ type CAASProvisionerSuite struct {
 	st                 *mockState
 	storagePoolManager *mockStoragePoolManager
 	registry           *mockStorageRegistry
+	imageChecker       *mockImageChecker
 }
 
 func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
@@ -51,8 +53,9 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.st = newMockState()
 	s.storagePoolManager = &mockStoragePoolManager{}
 	s.registry = &mockStorageRegistry{}
+	s.imageChecker = &mockImageChecker{}
 	api, err := caasoperatorprovisioner.NewCAASOperatorProvisionerAPI(
-		s.resources, s.authorizer, s.st, s.st, s.storagePoolManager, s.registry)
+		s.resources, s.authorizer, s.st, s.st, s.storagePoolManager, s.registry, s.imageChecker)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
 }
@@ -62,7 +65,7 @@ func (s *CAASProvisionerSuite) TestPermission(c *gc.C) {
 		Tag: names.NewMachineTag("0"),
 	}
 	_, err := caasoperatorprovisioner.NewCAASOperatorProvisionerAPI(
-		s.resources, s.authorizer, s.st, s.st, s.storagePoolManager, s.registry)
+		s.resources, s.authorizer, s.st, s.st, s.storagePoolManager, s.registry, s.imageChecker)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
@@ -113,6 +116,34 @@ func (s *CAASProvisionerSuite) TestLife(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) TestWatchApplicationsLife(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag: names.NewApplicationTag("app"),
+	}
+	api := &caasoperatorprovisioner.APIGroup{
+		ApplicationWatcherFacade: common.NewApplicationWatcherFacade(
+			mockAppWatcherState{s.st}, s.resources, common.ApplicationFilterNone),
+		API: s.api,
+	}
+	s.st.applicationWatcher.changes <- []string{"app", "gone"}
+
+	result, err := api.WatchApplicationsLife()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsLifeWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"app", "gone"},
+		Life: []params.LifeResult{{
+			Life: life.Alive,
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `entity application-gone not found`,
+			},
+		}},
+	})
+	c.Assert(s.resources.Get("1"), gc.Equals, s.st.applicationWatcher)
+}
+
 func (s *CAASProvisionerSuite) TestOperatorProvisioningInfoDefault(c *gc.C) {
 	s.st.app = &mockApplication{
 		charm: &mockCharm{meta: &charm.Meta{}},
@@ -255,3 +286,24 @@ func (s *CAASProvisionerSuite) TestIssueOperatorCertificate(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *CAASProvisionerSuite) TestCheckOperatorImage(c *gc.C) {
+	result, err := s.api.CheckOperatorImage(params.CheckOperatorImageArgs{
+		ImagePath: "jujusolutions/jujud-operator:2.9.0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.imageChecker.CheckCall(c, 0, "CheckImage", resources.DockerImageDetails{
+		RegistryPath: "jujusolutions/jujud-operator:2.9.0",
+	})
+}
+
+func (s *CAASProvisionerSuite) TestCheckOperatorImageNotFound(c *gc.C) {
+	s.imageChecker.SetErrors(errors.NotFoundf(`image "jujusolutions/jujud-operator:6.6.6"`))
+	result, err := s.api.CheckOperatorImage(params.CheckOperatorImageArgs{
+		ImagePath: "jujusolutions/jujud-operator:6.6.6",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(result.Error, gc.ErrorMatches, `checking operator image: image "jujusolutions/jujud-operator:6.6.6" not found`)
+}
@@ -145,13 +145,14 @@ type mockApplication struct {
 	life         state.Life
 	scaleWatcher *statetesting.MockNotifyWatcher
 
-	tag        names.Tag
-	scale      int
-	units      []caasunitprovisioner.Unit
-	ops        *state.UpdateUnitsOperation
-	providerId string
-	addresses  []network.SpaceAddress
-	charm      *mockCharm
+	tag         names.Tag
+	scale       int
+	units       []caasunitprovisioner.Unit
+	ops         *state.UpdateUnitsOperation
+	providerId  string
+	addresses   []network.SpaceAddress
+	charm       *mockCharm
+	annotations map[string]string
 }
 
 func (a *mockApplication) Tag() names.Tag {
@@ -189,6 +190,25 @@ func (a *mockApplication) ClearResources() error {
 	return nil
 }
 
+func (a *mockApplication) Annotation(key string) (string, error) {
+	a.MethodCall(a, "Annotation", key)
+	return a.annotations[key], a.NextErr()
+}
+
+func (a *mockApplication) SetAnnotations(annotations map[string]string) error {
+	a.MethodCall(a, "SetAnnotations", annotations)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	if a.annotations == nil {
+		a.annotations = make(map[string]string)
+	}
+	for k, v := range annotations {
+		a.annotations[k] = v
+	}
+	return nil
+}
+
 func (a *mockApplication) CharmModifiedVersion() int {
 	a.MethodCall(a, "CharmModifiedVersion")
 	return 888
@@ -259,6 +279,15 @@ func (m *mockApplication) UpdateCloudService(providerId string, addresses []netw
 	return nil
 }
 
+func (m *mockApplication) SetCloudServiceAddresses(addresses []network.SpaceAddress) error {
+	m.MethodCall(m, "SetCloudServiceAddresses", addresses)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.addresses = addresses
+	return nil
+}
+
 var addOp = &state.AddUnitOperation{}
 
 func (m *mockApplication) AddOperation(props state.UnitUpdateProperties) *state.AddUnitOperation {
@@ -6,6 +6,8 @@ package caasunitprovisioner
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/charm/v9"
@@ -39,6 +41,10 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.controller.caasunitprovisioner")
 
+// serviceHashAnnotation is the application annotation used to record
+// the hash of the service definition last applied to the cloud.
+const serviceHashAnnotation = "juju-service-hash"
+
 type Facade struct {
 	*common.LifeGetter
 	*common.ApplicationWatcherFacade
@@ -52,6 +58,48 @@ type Facade struct {
 	clock              clock.Clock
 }
 
+// FacadeV3 provides v3 of the CAAS unit provisioner facade.
+type FacadeV3 struct {
+	*Facade
+}
+
+// FacadeV2 provides v2 of the CAAS unit provisioner facade.
+type FacadeV2 struct {
+	*FacadeV3
+}
+
+// FacadeV1 provides v1 of the CAAS unit provisioner facade.
+type FacadeV1 struct {
+	*FacadeV2
+}
+
+// NewStateFacadeV1 provides the signature required for facade V1 registration.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
+// NewStateFacadeV2 provides the signature required for facade V2 registration.
+func NewStateFacadeV2(ctx facade.Context) (*FacadeV2, error) {
+	f, err := NewStateFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV2{f}, nil
+}
+
+// NewStateFacadeV3 provides the signature required for facade V3 registration.
+func NewStateFacadeV3(ctx facade.Context) (*FacadeV3, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV3{f}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -501,6 +549,191 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 	return app.ApplicationConfig()
 }
 
+// ApplicationsServiceHash returns the hash of the service definition
+// last applied to the cloud for each of the specified applications.
+func (f *Facade) ApplicationsServiceHash(args params.Entities) (params.StringResults, error) {
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		result, err := f.applicationServiceHash(arg.Tag)
+		results.Results[i].Result = result
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (f *Facade) applicationServiceHash(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return app.Annotation(serviceHashAnnotation)
+}
+
+// ApplicationsServiceHash is not available in V1.
+func (*FacadeV1) ApplicationsServiceHash(_, _ struct{}) {}
+
+// SetApplicationsServiceHash records the hash of the service definition
+// last applied to the cloud for each of the specified applications.
+func (f *Facade) SetApplicationsServiceHash(args params.SetApplicationServiceHashArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		app, err := f.state.Application(tag.Id())
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = app.SetAnnotations(map[string]string{serviceHashAnnotation: arg.Value})
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetApplicationsServiceHash is not available in V1.
+func (*FacadeV1) SetApplicationsServiceHash(_, _ struct{}) {}
+
+// SetApplicationsServiceAddresses replaces the recorded addresses of
+// the service for each of the specified applications. An empty list
+// of addresses clears those recorded.
+func (f *Facade) SetApplicationsServiceAddresses(args params.SetApplicationServiceAddressesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		app, err := f.state.Application(tag.Id())
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		sAddrs, err := params.ToProviderAddresses(arg.Addresses...).ToSpaceAddresses(f.state)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = app.SetCloudServiceAddresses(sAddrs)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetApplicationsServiceAddresses is not available in V1 or V2.
+func (*FacadeV2) SetApplicationsServiceAddresses(_, _ struct{}) {}
+
+// StopApplicationsUnits marks the units of each application that are
+// surplus to the scale it is about to be reduced to as dying, so that
+// their agents run the stop hook before the cloud removes their pods.
+// The names of the units marked are returned; the caller waits for
+// them to become dead.
+func (f *Facade) StopApplicationsUnits(args params.StopApplicationUnitsArgs) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		units, err := f.stopApplicationUnits(arg.ApplicationTag, arg.Scale)
+		results.Results[i].Result = units
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (f *Facade) stopApplicationUnits(tagString string, scale int) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	allUnits, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var units []removalCandidate
+	for _, u := range allUnits {
+		if u.Life() != state.Alive {
+			continue
+		}
+		var providerId string
+		info, err := u.ContainerInfo()
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil {
+			providerId = info.ProviderId()
+		}
+		units = append(units, removalCandidate{Unit: u, providerId: providerId})
+	}
+	if len(units) <= scale {
+		return nil, nil
+	}
+	sortRemovalCandidates(app.Name(), units)
+
+	var (
+		unitUpdate state.UpdateUnitsOperation
+		stopping   []string
+	)
+	for _, u := range units[:len(units)-scale] {
+		unitUpdate.Deletes = append(unitUpdate.Deletes, u.DestroyOperation())
+		stopping = append(stopping, u.Name())
+	}
+	if err := app.UpdateUnits(&unitUpdate); err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("stopping units %v of %q to reduce its scale to %d", stopping, app.Name(), scale)
+	return stopping, nil
+}
+
+// removalCandidate is an alive unit which may be stopped
+// when its application's scale is reduced.
+type removalCandidate struct {
+	Unit
+	providerId string
+}
+
+// sortRemovalCandidates sorts the units in the order the cloud removes
+// their pods when the application's scale is reduced. Units without pods
+// come first. Stateful set pods are removed from the highest ordinal
+// down; other pods cannot be ordered reliably, so the newest units are
+// assumed to be removed first.
+func sortRemovalCandidates(appName string, units []removalCandidate) {
+	ordinal := func(u removalCandidate) (int, bool) {
+		n, err := strconv.Atoi(strings.TrimPrefix(u.providerId, appName+"-"))
+		return n, err == nil && u.providerId != ""
+	}
+	sort.SliceStable(units, func(i, j int) bool {
+		if (units[i].providerId == "") != (units[j].providerId == "") {
+			return units[i].providerId == ""
+		}
+		iOrdinal, iStateful := ordinal(units[i])
+		jOrdinal, jStateful := ordinal(units[j])
+		if iStateful && jStateful {
+			return iOrdinal > jOrdinal
+		}
+		return units[i].UnitTag().Number() > units[j].UnitTag().Number()
+	})
+}
+
+// StopApplicationsUnits is not available in V1, V2 or V3.
+func (*FacadeV3) StopApplicationsUnits(_, _ struct{}) {}
+
 // UpdateApplicationsUnits updates the Juju data model to reflect the given
 // units of the specified application.
 func (a *Facade) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs) (params.UpdateApplicationUnitResults, error) {
@@ -644,6 +877,10 @@ func (a *Facade) updateUnitsFromCloud(app Application, scale *int,
 		// extraStateIds holds the provider ids of units in state which
 		// no longer exist in the cloud.
 		extraStateIds = set.NewStrings()
+
+		// stoppingIds holds the provider ids of units in state which
+		// are no longer alive.
+		stoppingIds = set.NewStrings()
 	)
 
 	// Loop over any existing state units and record those which do not yet have
@@ -660,6 +897,11 @@ func (a *Facade) updateUnitsFromCloud(app Application, scale *int,
 
 		unitAlive := u.Life() == state.Alive
 		if !unitAlive {
+			// The pod of a unit that is stopping ahead of a scale
+			// down is not a new pod to be given a unit.
+			if providerId != "" {
+				stoppingIds.Add(providerId)
+			}
 			continue
 		}
 		if providerId == "" {
@@ -707,6 +949,10 @@ func (a *Facade) updateUnitsFromCloud(app Application, scale *int,
 			unitInfo.existingCloudPods = append(unitInfo.existingCloudPods, u)
 			continue
 		}
+		if stoppingIds.Contains(id) {
+			logger.Debugf("pod %v belongs to a stopping unit", id)
+			continue
+		}
 
 		// First attempt to add any new cloud pod not yet represented in state
 		// to a unit which does not yet have a provider id.
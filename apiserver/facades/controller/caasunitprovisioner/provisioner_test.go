@@ -386,6 +386,50 @@ func (s *CAASProvisionerSuite) TestClearApplicationsResources(c *gc.C) {
 	s.st.application.CheckCallNames(c, "ClearResources")
 }
 
+func (s *CAASProvisionerSuite) TestApplicationsServiceHash(c *gc.C) {
+	s.st.application.annotations = map[string]string{"juju-service-hash": "deadbeef"}
+
+	results, err := s.facade.ApplicationsServiceHash(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringResults{
+		Results: []params.StringResult{
+			{Result: "deadbeef"},
+			{
+				Error: &params.Error{
+					Message: `"unit-gitlab-0" is not a valid application tag`,
+				},
+			}},
+	})
+	s.st.application.CheckCall(c, 0, "Annotation", "juju-service-hash")
+}
+
+func (s *CAASProvisionerSuite) TestSetApplicationsServiceHash(c *gc.C) {
+	results, err := s.facade.SetApplicationsServiceHash(params.SetApplicationServiceHashArgs{
+		Args: []params.EntityString{
+			{Tag: "application-gitlab", Value: "deadbeef"},
+			{Tag: "unit-gitlab-0", Value: "deadbeef"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{
+				Error: &params.Error{
+					Message: `"unit-gitlab-0" is not a valid application tag`,
+				},
+			}},
+	})
+	s.st.CheckCallNames(c, "Application")
+	s.st.application.CheckCall(c, 0, "SetAnnotations", map[string]string{"juju-service-hash": "deadbeef"})
+	c.Assert(s.st.application.annotations, jc.DeepEquals, map[string]string{"juju-service-hash": "deadbeef"})
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -875,6 +919,106 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsService(c *gc.C) {
 	c.Assert(s.st.application.addresses, jc.DeepEquals, []network.SpaceAddress{addr})
 }
 
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsSkipsStoppingUnits(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+		&mockUnit{name: "gitlab/1", containerInfo: &mockContainerInfo{providerId: "uuid2"}, life: state.Dying},
+	}
+	s.st.application.scale = 1
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"},
+			Status: "running", Info: ""},
+		{ProviderId: "uuid2", Address: "another-address", Ports: []string{"another-port"},
+			Status: "running", Info: ""},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units, Scale: intPtr(1)},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	// The pod of the stopping unit is not given a new unit.
+	for _, call := range s.st.application.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "AddOperation")
+	}
+	s.st.application.units[0].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+	s.st.application.units[1].(*mockUnit).CheckCallNames(c, "Life")
+}
+
+func (s *CAASProvisionerSuite) TestStopApplicationsUnits(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "gitlab-2"}, life: state.Alive},
+		&mockUnit{name: "gitlab/1", containerInfo: &mockContainerInfo{providerId: "gitlab-0"}, life: state.Alive},
+		&mockUnit{name: "gitlab/2", containerInfo: &mockContainerInfo{providerId: "gitlab-1"}, life: state.Alive},
+		&mockUnit{name: "gitlab/3", life: state.Alive},
+		&mockUnit{name: "gitlab/4", containerInfo: &mockContainerInfo{providerId: "gitlab-3"}, life: state.Dying},
+	}
+
+	results, err := s.facade.StopApplicationsUnits(params.StopApplicationUnitsArgs{
+		Args: []params.StopApplicationUnitsArg{
+			{ApplicationTag: "application-gitlab", Scale: 1},
+			{ApplicationTag: "unit-gitlab-0", Scale: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"gitlab/3", "gitlab/0", "gitlab/2"}},
+			{Error: &params.Error{Message: `"unit-gitlab-0" is not a valid application tag`}},
+		},
+	})
+	c.Assert(s.st.application.ops, gc.NotNil)
+	c.Assert(s.st.application.ops.Deletes, gc.HasLen, 3)
+	s.st.application.units[1].(*mockUnit).CheckCallNames(c, "Life")
+	s.st.application.units[4].(*mockUnit).CheckCallNames(c, "Life")
+}
+
+func (s *CAASProvisionerSuite) TestStopApplicationsUnitsWithinScale(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "gitlab-0"}, life: state.Alive},
+	}
+
+	results, err := s.facade.StopApplicationsUnits(params.StopApplicationUnitsArgs{
+		Args: []params.StopApplicationUnitsArg{{ApplicationTag: "application-gitlab", Scale: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{}},
+	})
+	c.Assert(s.st.application.ops, gc.IsNil)
+}
+
+func (s *CAASProvisionerSuite) TestSetApplicationsServiceAddresses(c *gc.C) {
+	addr := network.NewSpaceAddress("203.0.113.1")
+	results, err := s.facade.SetApplicationsServiceAddresses(params.SetApplicationServiceAddressesArgs{
+		Args: []params.ApplicationServiceAddresses{
+			{
+				ApplicationTag: "application-gitlab",
+				Addresses:      params.FromMachineAddresses(addr.MachineAddress),
+			}, {
+				ApplicationTag: "unit-gitlab-0",
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{
+				Error: &params.Error{
+					Message: `"unit-gitlab-0" is not a valid application tag`,
+				},
+			}},
+	})
+	s.st.application.CheckCall(c, 0, "SetCloudServiceAddresses", []network.SpaceAddress{addr})
+	c.Assert(s.st.application.addresses, jc.DeepEquals, []network.SpaceAddress{addr})
+}
+
 func (s *CAASProvisionerSuite) TestSetOperatorStatus(c *gc.C) {
 	results, err := s.facade.SetOperatorStatus(params.SetStatus{
 		Entities: []params.EntityStatusArgs{
@@ -80,6 +80,7 @@ type Application interface {
 	AddOperation(state.UnitUpdateProperties) *state.AddUnitOperation
 	UpdateUnits(*state.UpdateUnitsOperation) error
 	UpdateCloudService(providerId string, addresses []network.SpaceAddress) error
+	SetCloudServiceAddresses(addresses []network.SpaceAddress) error
 	StorageConstraints() (map[string]state.StorageConstraints, error)
 	DeviceConstraints() (map[string]state.DeviceConstraints, error)
 	Life() state.Life
@@ -91,6 +92,8 @@ type Application interface {
 	SetStatus(statusInfo status.StatusInfo) error
 	Charm() (Charm, bool, error)
 	ClearResources() error
+	Annotation(key string) (string, error)
+	SetAnnotations(annotations map[string]string) error
 	CharmModifiedVersion() int
 }
 
@@ -103,7 +106,7 @@ func (s stateShim) Application(id string) (Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	return applicationShim{app}, nil
+	return applicationShim{Application: app, st: s.State}, nil
 }
 
 func (s stateShim) Model() (Model, error) {
@@ -116,6 +119,7 @@ func (s stateShim) Model() (Model, error) {
 
 type applicationShim struct {
 	*state.Application
+	st *state.State
 }
 
 func (a applicationShim) AllUnits() ([]Unit, error) {
@@ -134,6 +138,22 @@ func (a applicationShim) Charm() (Charm, bool, error) {
 	return a.Application.Charm()
 }
 
+func (a applicationShim) Annotation(key string) (string, error) {
+	model, err := a.st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return model.Annotation(a.Application, key)
+}
+
+func (a applicationShim) SetAnnotations(annotations map[string]string) error {
+	model, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return model.SetAnnotations(a.Application, annotations)
+}
+
 type Charm interface {
 	Meta() *charm.Meta
 }
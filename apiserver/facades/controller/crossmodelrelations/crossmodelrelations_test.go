@@ -887,3 +887,7 @@ func (f *fakeCachedModel) Application(name string) (commoncrossmodel.CachedAppli
 func (f *fakeCachedModel) Status() status.StatusInfo {
 	return status.StatusInfo{Status: status.Waiting}
 }
+
+func (f *fakeCachedModel) Degraded() bool {
+	return false
+}
@@ -5,6 +5,7 @@ package machineundertaker
 
 import (
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
 
@@ -26,6 +27,10 @@ type Backend interface {
 	// Machine gets a specific machine, so we can collect details of
 	// its network interfaces.
 	Machine(id string) (Machine, error)
+
+	// Model returns the model whose machines are being removed,
+	// so that removal progress can be recorded on it.
+	Model() (Model, error)
 }
 
 // Machine defines the methods we need from state.Machine.
@@ -35,6 +40,15 @@ type Machine interface {
 	AllProviderInterfaceInfos() ([]network.ProviderInterfaceInfo, error)
 }
 
+// Model defines the methods we need from state.Model.
+type Model interface {
+	// Life returns the current life of the model.
+	Life() state.Life
+
+	// SetStatus sets the status of the model.
+	SetStatus(status.StatusInfo) error
+}
+
 type backendShim struct {
 	*state.State
 }
@@ -43,3 +57,12 @@ type backendShim struct {
 func (b *backendShim) Machine(id string) (Machine, error) {
 	return b.State.Machine(id)
 }
+
+// Model implements Backend.
+func (b *backendShim) Model() (Model, error) {
+	m, err := b.State.Model()
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
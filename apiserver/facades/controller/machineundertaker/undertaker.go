@@ -4,6 +4,8 @@
 package machineundertaker
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -12,13 +14,14 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.machineundertaker")
 
-// API implements the API facade used by the machine undertaker.
+// API implements version 2 of the API facade used by the machine undertaker.
 type API struct {
 	backend        Backend
 	resources      facade.Resources
@@ -43,11 +46,27 @@ func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Autho
 	return api, nil
 }
 
+// APIV1 implements version 1 of the machine undertaker facade,
+// which does not support recording removal progress.
+type APIV1 struct {
+	*API
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*API, error) {
 	return NewAPI(&backendShim{st}, res, auth)
 }
 
+// NewFacadeV1 provides the signature required for
+// registration of version 1 of the facade.
+func NewFacadeV1(st *state.State, res facade.Resources, auth facade.Authorizer) (*APIV1, error) {
+	api, err := NewFacade(st, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // AllMachineRemovals returns tags for all of the machines that have
 // been marked for removal in the requested model.
 func (m *API) AllMachineRemovals(models params.Entities) params.EntitiesResults {
@@ -155,6 +174,49 @@ func (m *API) watchRemovalsForTag(tag string) (string, error) {
 	}
 }
 
+// SetMachineRemovalProgress records the progress made in removing the
+// machines of a dying model on the model's status, so that it can be
+// seen by users while the model is being destroyed.
+// Progress for a model that is not dying is ignored, as is progress for
+// a model that has already been removed. Progress with no pending
+// removals is also ignored, so that the status messages set by the
+// model undertaker are not overwritten.
+func (m *API) SetMachineRemovalProgress(args params.MachineRemovalProgress) error {
+	if err := m.checkModelAuthorization(args.ModelTag); err != nil {
+		return errors.Trace(err)
+	}
+	model, err := m.backend.Model()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Life() != state.Dying || args.Pending == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("removing machines: %d remaining", args.Pending)
+	if args.Failures > 0 {
+		message += fmt.Sprintf(" (%d failed)", args.Failures)
+	}
+	err = model.SetStatus(status.StatusInfo{
+		Status:  status.Destroying,
+		Message: message,
+		Data: map[string]interface{}{
+			"machines-pending-removal": args.Pending,
+			"addresses-released":       args.AddressesReleased,
+			"machine-removal-failures": args.Failures,
+		},
+	})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// SetMachineRemovalProgress is not available in version 1 of the facade.
+func (*APIV1) SetMachineRemovalProgress(_, _ struct{}) {}
+
 func (m *API) checkModelAuthorization(tag string) error {
 	modelTag, err := names.ParseModelTag(tag)
 	if err != nil {
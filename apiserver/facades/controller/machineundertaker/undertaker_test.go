@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
 
@@ -219,6 +220,75 @@ func (*undertakerSuite) TestWatchMachineRemovalsError(c *gc.C) {
 	backend.CheckCallNames(c, "WatchMachineRemovals")
 }
 
+func (*undertakerSuite) TestSetMachineRemovalProgress(c *gc.C) {
+	backend, _, api := makeAPI(c, uuid1)
+	backend.model = &mockModel{Stub: backend.Stub, life: state.Dying}
+
+	err := api.SetMachineRemovalProgress(params.MachineRemovalProgress{
+		ModelTag:          tag1,
+		Pending:           12,
+		AddressesReleased: 3,
+		Failures:          1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	backend.CheckCallNames(c, "Model", "SetStatus")
+	backend.CheckCall(c, 1, "SetStatus", status.StatusInfo{
+		Status:  status.Destroying,
+		Message: "removing machines: 12 remaining (1 failed)",
+		Data: map[string]interface{}{
+			"machines-pending-removal": 12,
+			"addresses-released":       3,
+			"machine-removal-failures": 1,
+		},
+	})
+}
+
+func (*undertakerSuite) TestSetMachineRemovalProgressNonePending(c *gc.C) {
+	backend, _, api := makeAPI(c, uuid1)
+	backend.model = &mockModel{Stub: backend.Stub, life: state.Dying}
+
+	// With no removals pending, the status set
+	// by the model undertaker is left alone.
+	err := api.SetMachineRemovalProgress(params.MachineRemovalProgress{ModelTag: tag1})
+	c.Assert(err, jc.ErrorIsNil)
+	backend.CheckCallNames(c, "Model")
+}
+
+func (*undertakerSuite) TestSetMachineRemovalProgressModelAlive(c *gc.C) {
+	backend, _, api := makeAPI(c, uuid1)
+	backend.model = &mockModel{Stub: backend.Stub, life: state.Alive}
+
+	err := api.SetMachineRemovalProgress(params.MachineRemovalProgress{ModelTag: tag1, Pending: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	backend.CheckCallNames(c, "Model")
+}
+
+func (*undertakerSuite) TestSetMachineRemovalProgressModelGone(c *gc.C) {
+	backend, _, api := makeAPI(c, uuid1)
+	backend.SetErrors(errors.NotFoundf("model"))
+
+	err := api.SetMachineRemovalProgress(params.MachineRemovalProgress{ModelTag: tag1, Pending: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	backend.CheckCallNames(c, "Model")
+}
+
+func (*undertakerSuite) TestSetMachineRemovalProgressModelRemovedWhileSetting(c *gc.C) {
+	backend, _, api := makeAPI(c, uuid1)
+	backend.model = &mockModel{Stub: backend.Stub, life: state.Dying}
+	backend.SetErrors(nil, errors.Annotate(errors.NotFoundf("model"), "cannot set status"))
+
+	err := api.SetMachineRemovalProgress(params.MachineRemovalProgress{ModelTag: tag1, Pending: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	backend.CheckCallNames(c, "Model", "SetStatus")
+}
+
+func (*undertakerSuite) TestSetMachineRemovalProgressPermissionError(c *gc.C) {
+	backend, _, api := makeAPI(c, uuid1)
+	err := api.SetMachineRemovalProgress(params.MachineRemovalProgress{ModelTag: tag2, Pending: 1})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	backend.CheckNoCalls(c)
+}
+
 func makeAPI(c *gc.C, modelUUID string) (*mockBackend, *common.Resources, *machineundertaker.API) {
 	backend := &mockBackend{Stub: &testing.Stub{}}
 	res := common.NewResources()
@@ -259,6 +329,7 @@ type mockBackend struct {
 
 	removals       []string
 	machines       map[string]*mockMachine
+	model          *mockModel
 	watcherBlowsUp bool
 }
 
@@ -288,6 +359,28 @@ func (b *mockBackend) Machine(id string) (machineundertaker.Machine, error) {
 	return b.machines[id], b.NextErr()
 }
 
+func (b *mockBackend) Model() (machineundertaker.Model, error) {
+	b.AddCall("Model")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.model, nil
+}
+
+type mockModel struct {
+	*testing.Stub
+	life state.Life
+}
+
+func (m *mockModel) Life() state.Life {
+	return m.life
+}
+
+func (m *mockModel) SetStatus(info status.StatusInfo) error {
+	m.AddCall("SetStatus", info)
+	return m.NextErr()
+}
+
 type mockMachine struct {
 	*testing.Stub
 	interfaceInfos []network.ProviderInterfaceInfo
@@ -555,3 +555,7 @@ func (f *stubPresence) ModelPresence(modelUUID string) facade.ModelPresence {
 func (f *stubPresence) AgentStatus(agent string) (presence.Status, error) {
 	return presence.Alive, nil
 }
+
+func (f *stubPresence) Flapping(agent string) (bool, error) {
+	return false, nil
+}
@@ -5,6 +5,7 @@ package logsink
 
 import (
 	"net/http"
+	"sort"
 
 	gc "gopkg.in/check.v1"
 )
@@ -13,6 +14,7 @@ func NewHTTPHandlerForTest(
 	newLogWriteCloser NewLogWriteCloserFunc,
 	abort <-chan struct{},
 	ratelimit *RateLimitConfig,
+	limiter *IngestionLimiter,
 	metrics MetricsCollector,
 	modelUUID string,
 	makeChannel func() (chan struct{}, func()),
@@ -21,6 +23,7 @@ func NewHTTPHandlerForTest(
 		newLogWriteCloser: newLogWriteCloser,
 		abort:             abort,
 		ratelimit:         ratelimit,
+		limiter:           limiter,
 		newStopChannel:    makeChannel,
 		metrics:           metrics,
 		modelUUID:         modelUUID,
@@ -34,3 +37,22 @@ func ReceiverStopped(c *gc.C, handler http.Handler) bool {
 	defer h.mu.Unlock()
 	return h.receiverStopped
 }
+
+// Allow reports whether the limiter accepts a
+// log message from a new connection for the model.
+func Allow(l *IngestionLimiter, modelUUID string) bool {
+	return l.newConnectionLimiter(modelUUID).allow() == ""
+}
+
+// QuotaModels returns the UUIDs of the models for
+// which the limiter is tracking a daily quota.
+func QuotaModels(l *IngestionLimiter) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var uuids []string
+	for uuid := range l.quotas {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	return uuids
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logsink
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/loggo"
+	"github.com/juju/ratelimit"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const (
+	metricLogDropLabelRateLimit  = "ratelimit"
+	metricLogDropLabelDailyQuota = "daily-quota"
+)
+
+// dropWarningInterval is the minimum time between the warnings written
+// to a model's log to record that log messages have been dropped.
+const dropWarningInterval = time.Minute
+
+// IngestionLimits holds the limits applied to log messages received by
+// the logsink handler. Messages in excess of the limits are dropped.
+type IngestionLimits struct {
+	// Rate is the number of log messages per second that each connection
+	// may send. A value of zero disables the rate limit.
+	Rate int
+
+	// Burst is the number of log messages that each connection may send
+	// in excess of the rate limit before messages are dropped.
+	Burst int
+
+	// DailyQuota is the number of log messages that may be received for
+	// each model in a (UTC) day. A value of zero disables the quota.
+	DailyQuota int
+}
+
+// IngestionLimiter applies ingestion limits to the log messages received
+// by logsink handlers. The limits may be updated at any time, and take
+// effect for both new and established connections.
+type IngestionLimiter struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	limits  IngestionLimits
+	version int
+
+	// quotaDay is the (UTC) day for which quotas records the number
+	// of log messages received for each model. The quotas are reset
+	// when the day rolls over, so models that have since been removed
+	// do not accumulate entries.
+	quotaDay string
+	quotas   map[string]int
+}
+
+// NewIngestionLimiter returns a new IngestionLimiter applying the input limits.
+func NewIngestionLimiter(clock clock.Clock, limits IngestionLimits) *IngestionLimiter {
+	return &IngestionLimiter{
+		clock:  clock,
+		limits: limits,
+		quotas: make(map[string]int),
+	}
+}
+
+// Limits returns the limits currently being applied.
+func (l *IngestionLimiter) Limits() IngestionLimits {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limits
+}
+
+// SetLimits updates the limits being applied.
+func (l *IngestionLimiter) SetLimits(limits IngestionLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limits == l.limits {
+		return
+	}
+	logger.Debugf("updating logsink ingestion limits to %+v", limits)
+	l.limits = limits
+	l.version++
+}
+
+// newConnectionLimiter returns a connectionLimiter for
+// a connection sending log messages for the input model.
+func (l *IngestionLimiter) newConnectionLimiter(modelUUID string) *connectionLimiter {
+	return &connectionLimiter{
+		limiter:   l,
+		modelUUID: modelUUID,
+		version:   -1,
+	}
+}
+
+// connectionLimiter applies the ingestion limits to a single connection.
+type connectionLimiter struct {
+	limiter   *IngestionLimiter
+	modelUUID string
+
+	// version is the version of the limits used to create the bucket.
+	version int
+	bucket  *ratelimit.Bucket
+
+	dropped     int
+	lastWarning time.Time
+}
+
+// allow returns an empty string if a log message may be accepted,
+// otherwise it returns the reason for which the message must be dropped.
+func (c *connectionLimiter) allow() string {
+	l := c.limiter
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if c.version != l.version {
+		c.version = l.version
+		c.bucket = nil
+		if l.limits.Rate > 0 {
+			burst := l.limits.Burst
+			if burst <= 0 {
+				burst = 1
+			}
+			c.bucket = ratelimit.NewBucketWithRateAndClock(
+				float64(l.limits.Rate), int64(burst), ratelimitClock{l.clock})
+		}
+	}
+	if c.bucket != nil && c.bucket.TakeAvailable(1) == 0 {
+		return metricLogDropLabelRateLimit
+	}
+
+	if l.limits.DailyQuota > 0 {
+		day := l.clock.Now().UTC().Format("2006-01-02")
+		if day != l.quotaDay {
+			l.quotaDay = day
+			l.quotas = make(map[string]int)
+		}
+		if l.quotas[c.modelUUID] >= l.limits.DailyQuota {
+			return metricLogDropLabelDailyQuota
+		}
+		l.quotas[c.modelUUID]++
+	}
+	return ""
+}
+
+// recordDrop records that a log message was dropped. If sufficient
+// time has passed since the last warning, a log record warning that
+// messages have been dropped is returned, along with true.
+func (c *connectionLimiter) recordDrop(reason string) (params.LogRecord, bool) {
+	c.dropped++
+	now := c.limiter.clock.Now()
+	if !c.lastWarning.IsZero() && now.Sub(c.lastWarning) < dropWarningInterval {
+		return params.LogRecord{}, false
+	}
+
+	description := "logsink rate limit"
+	if reason == metricLogDropLabelDailyQuota {
+		description = "daily model log quota"
+	}
+	record := params.LogRecord{
+		Time:    now.UTC(),
+		Module:  logger.Name(),
+		Level:   loggo.WARNING.String(),
+		Message: fmt.Sprintf("%d log messages dropped: %s exceeded", c.dropped, description),
+	}
+	c.dropped = 0
+	c.lastWarning = now
+	return record, true
+}
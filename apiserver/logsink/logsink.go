@@ -107,6 +107,11 @@ type MetricsCollector interface {
 	// the log that happened. It's split on the success/error/disconnect, so
 	// the charts will have to take that into account.
 	LogReadCount(modelUUID, state string) prometheus.Counter
+
+	// LogDropCount returns a prometheus metric for the number of log
+	// messages that were dropped for exceeding the ingestion limits. It's
+	// split on the limit that was exceeded.
+	LogDropCount(modelUUID, reason string) prometheus.Counter
 }
 
 // NewHTTPHandler returns a new http.Handler for receiving log messages over a
@@ -115,10 +120,14 @@ type MetricsCollector interface {
 //
 // ratelimit defines an optional rate-limit configuration. If nil, no rate-
 // limiting will be applied.
+//
+// limiter defines optional ingestion limits, beyond which log messages are
+// dropped rather than delayed. If nil, no messages will be dropped.
 func NewHTTPHandler(
 	newLogWriteCloser NewLogWriteCloserFunc,
 	abort <-chan struct{},
 	ratelimit *RateLimitConfig,
+	limiter *IngestionLimiter,
 	metrics MetricsCollector,
 	modelUUID string,
 ) http.Handler {
@@ -126,6 +135,7 @@ func NewHTTPHandler(
 		newLogWriteCloser: newLogWriteCloser,
 		abort:             abort,
 		ratelimit:         ratelimit,
+		limiter:           limiter,
 		newStopChannel: func() (chan struct{}, func()) {
 			ch := make(chan struct{})
 			return ch, func() { close(ch) }
@@ -139,6 +149,7 @@ type logSinkHandler struct {
 	newLogWriteCloser NewLogWriteCloserFunc
 	abort             <-chan struct{}
 	ratelimit         *RateLimitConfig
+	limiter           *IngestionLimiter
 	metrics           MetricsCollector
 	modelUUID         string
 	mu                sync.Mutex
@@ -297,6 +308,11 @@ func (h *logSinkHandler) receiveLogs(socket *websocket.Conn,
 		)
 	}
 
+	var limiter *connectionLimiter
+	if h.limiter != nil {
+		limiter = h.limiter.newConnectionLimiter(resolvedModelUUID)
+	}
+
 	go func() {
 		// Close the channel to signal ServeHTTP to finish. Otherwise
 		// we leak goroutines on client disconnect, because the server
@@ -340,6 +356,20 @@ func (h *logSinkHandler) receiveLogs(socket *websocket.Conn,
 				}
 			}
 
+			// Drop log messages in excess of the ingestion limits,
+			// periodically replacing a dropped message with a warning
+			// so that operators can see that the log is incomplete.
+			if limiter != nil {
+				if reason := limiter.allow(); reason != "" {
+					h.metrics.LogDropCount(resolvedModelUUID, reason).Inc()
+					warning, ok := limiter.recordDrop(reason)
+					if !ok {
+						continue
+					}
+					m = warning
+				}
+			}
+
 			// Send the log message.
 			select {
 			case <-h.abort:
@@ -210,6 +210,7 @@ func (s *logsinkSuite) TestRateLimit(c *gc.C) {
 			Refill: time.Second,
 			Clock:  testClock,
 		},
+		nil,
 		metricsCollector,
 		modelUUID.String(),
 	))
@@ -260,6 +261,144 @@ func (s *logsinkSuite) TestRateLimit(c *gc.C) {
 	expectNoRecord()
 }
 
+func (s *logsinkSuite) TestIngestionRateLimit(c *gc.C) {
+	testClock := testclock.NewClock(time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC))
+	limiter := logsink.NewIngestionLimiter(testClock, logsink.IngestionLimits{
+		Rate:  1,
+		Burst: 2,
+	})
+	conn, finish := s.dialLimitedServer(c, limiter)
+	defer finish()
+
+	record := params.LogRecord{
+		Time:     time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.INFO.String(),
+		Message:  "all is well",
+	}
+	for i := 0; i < 4; i++ {
+		err := conn.WriteJSON(&record)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The burst is let through, then the first dropped
+	// message is replaced with a warning.
+	c.Assert(s.nextRecord(c), jc.DeepEquals, record)
+	c.Assert(s.nextRecord(c), jc.DeepEquals, record)
+	c.Assert(s.nextRecord(c), jc.DeepEquals, params.LogRecord{
+		Time:    testClock.Now(),
+		Module:  "juju.apiserver.logsink",
+		Level:   loggo.WARNING.String(),
+		Message: "1 log messages dropped: logsink rate limit exceeded",
+	})
+	s.assertNoRecord(c)
+
+	// Lifting the limit applies to the established connection.
+	limiter.SetLimits(logsink.IngestionLimits{})
+	err := conn.WriteJSON(&record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextRecord(c), jc.DeepEquals, record)
+}
+
+func (s *logsinkSuite) TestIngestionDailyQuota(c *gc.C) {
+	testClock := testclock.NewClock(time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC))
+	limiter := logsink.NewIngestionLimiter(testClock, logsink.IngestionLimits{
+		DailyQuota: 2,
+	})
+	conn, finish := s.dialLimitedServer(c, limiter)
+	defer finish()
+
+	record := params.LogRecord{
+		Time:     time.Date(2015, time.June, 1, 23, 2, 1, 0, time.UTC),
+		Module:   "some.where",
+		Location: "foo.go:42",
+		Level:    loggo.INFO.String(),
+		Message:  "all is well",
+	}
+	for i := 0; i < 4; i++ {
+		err := conn.WriteJSON(&record)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	c.Assert(s.nextRecord(c), jc.DeepEquals, record)
+	c.Assert(s.nextRecord(c), jc.DeepEquals, record)
+	warning := s.nextRecord(c)
+	c.Assert(warning.Message, gc.Equals, "1 log messages dropped: daily model log quota exceeded")
+	s.assertNoRecord(c)
+
+	// The quota is reset the following day.
+	testClock.Advance(24 * time.Hour)
+	err := conn.WriteJSON(&record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextRecord(c), jc.DeepEquals, record)
+}
+
+func (s *logsinkSuite) TestIngestionDailyQuotaForgetsStaleModels(c *gc.C) {
+	testClock := testclock.NewClock(time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC))
+	limiter := logsink.NewIngestionLimiter(testClock, logsink.IngestionLimits{
+		DailyQuota: 1,
+	})
+	c.Assert(logsink.Allow(limiter, "model-a"), jc.IsTrue)
+	c.Assert(logsink.Allow(limiter, "model-b"), jc.IsTrue)
+	c.Assert(logsink.Allow(limiter, "model-b"), jc.IsFalse)
+	c.Assert(logsink.QuotaModels(limiter), jc.DeepEquals, []string{"model-a", "model-b"})
+
+	// Only models that log on the following day
+	// are tracked once the quota rolls over.
+	testClock.Advance(24 * time.Hour)
+	c.Assert(logsink.Allow(limiter, "model-b"), jc.IsTrue)
+	c.Assert(logsink.QuotaModels(limiter), jc.DeepEquals, []string{"model-b"})
+}
+
+func (s *logsinkSuite) dialLimitedServer(c *gc.C, limiter *logsink.IngestionLimiter) (*websocket.Conn, func()) {
+	modelUUID, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+
+	metricsCollector, finishMetrics := createMockMetrics(c, modelUUID.String())
+	srv := httptest.NewServer(logsink.NewHTTPHandler(
+		func(req *http.Request) (logsink.LogWriteCloser, error) {
+			s.stub.AddCall("Open")
+			return &mockLogWriteCloser{
+				s.stub,
+				s.written,
+				nil,
+			}, s.stub.NextErr()
+		},
+		s.abort,
+		nil,
+		limiter,
+		metricsCollector,
+		modelUUID.String(),
+	))
+
+	conn := s.dialWebsocket(c, srv)
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+	return conn, func() {
+		finishMetrics()
+		srv.Close()
+	}
+}
+
+func (s *logsinkSuite) nextRecord(c *gc.C) params.LogRecord {
+	select {
+	case written, ok := <-s.written:
+		c.Assert(ok, jc.IsTrue)
+		return written
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for log record to be written")
+	}
+	return params.LogRecord{}
+}
+
+func (s *logsinkSuite) assertNoRecord(c *gc.C) {
+	select {
+	case <-s.written:
+		c.Fatal("unexpected log record")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
 func (s *logsinkSuite) TestReceiverStopsWhenAsked(c *gc.C) {
 	myStopCh := make(chan struct{})
 
@@ -276,6 +415,7 @@ func (s *logsinkSuite) TestReceiverStopsWhenAsked(c *gc.C) {
 		},
 		s.abort,
 		nil,
+		nil,
 		metricsCollector,
 		modelUUID.String(),
 		func() (chan struct{}, func()) {
@@ -335,6 +475,7 @@ func (s *logsinkSuite) TestHandlerClosesStopChannel(c *gc.C) {
 		},
 		s.abort,
 		nil,
+		nil,
 		metricsCollector,
 		modelUUID.String(),
 		func() (chan struct{}, func()) {
@@ -402,6 +543,7 @@ func (s *logsinkSuite) createServer(c *gc.C) (*httptest.Server, func()) {
 		},
 		s.abort,
 		nil, // no rate-limiting
+		nil, // no ingestion limits
 		metricsCollector,
 		modelUUID.String(),
 	))
@@ -460,6 +602,7 @@ func createMockMetrics(c *gc.C, modelUUID string) (*mocks.MockMetricsCollector,
 	metricsCollector.EXPECT().Connections().Return(gauge).AnyTimes()
 	metricsCollector.EXPECT().LogWriteCount(modelUUID, gomock.Any()).Return(counter).AnyTimes()
 	metricsCollector.EXPECT().LogReadCount(modelUUID, gomock.Any()).Return(counter).AnyTimes()
+	metricsCollector.EXPECT().LogDropCount(modelUUID, gomock.Any()).Return(counter).AnyTimes()
 
 	return metricsCollector, ctrl.Finish
 }
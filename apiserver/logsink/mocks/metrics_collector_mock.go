@@ -47,6 +47,20 @@ func (mr *MockMetricsCollectorMockRecorder) Connections() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connections", reflect.TypeOf((*MockMetricsCollector)(nil).Connections))
 }
 
+// LogDropCount mocks base method
+func (m *MockMetricsCollector) LogDropCount(arg0, arg1 string) prometheus.Counter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogDropCount", arg0, arg1)
+	ret0, _ := ret[0].(prometheus.Counter)
+	return ret0
+}
+
+// LogDropCount indicates an expected call of LogDropCount
+func (mr *MockMetricsCollectorMockRecorder) LogDropCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogDropCount", reflect.TypeOf((*MockMetricsCollector)(nil).LogDropCount), arg0, arg1)
+}
+
 // LogReadCount mocks base method
 func (m *MockMetricsCollector) LogReadCount(arg0, arg1 string) prometheus.Counter {
 	m.ctrl.T.Helper()
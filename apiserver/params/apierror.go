@@ -213,6 +213,7 @@ const (
 	CodeCloudRegionRequired       = "cloud region required"
 	CodeIncompatibleClouds        = "incompatible clouds"
 	CodeQuotaLimitExceeded        = "quota limit exceeded"
+	CodeRequestTooLarge           = "request too large"
 )
 
 // ErrCode returns the error code associated with
@@ -396,3 +397,9 @@ func IsCodeCloudRegionRequired(err error) bool {
 func IsCodeQuotaLimitExceeded(err error) bool {
 	return ErrCode(err) == CodeQuotaLimitExceeded
 }
+
+// IsCodeRequestTooLarge returns true if err includes a RequestTooLarge
+// error code.
+func IsCodeRequestTooLarge(err error) bool {
+	return ErrCode(err) == CodeRequestTooLarge
+}
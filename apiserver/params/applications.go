@@ -425,6 +425,38 @@ type UpdateApplicationServiceArg struct {
 	Generation *int64 `json:"generation,omitempty"`
 }
 
+// SetApplicationServiceAddressesArgs holds the parameters for
+// replacing the recorded addresses of application services.
+type SetApplicationServiceAddressesArgs struct {
+	Args []ApplicationServiceAddresses `json:"args"`
+}
+
+// ApplicationServiceAddresses holds the addresses of an
+// application's service, as reported by the cloud.
+type ApplicationServiceAddresses struct {
+	ApplicationTag string    `json:"application-tag"`
+	Addresses      []Address `json:"addresses"`
+}
+
+// StopApplicationUnitsArgs holds the parameters for stopping the units
+// of applications that are surplus to the scale they are being reduced to.
+type StopApplicationUnitsArgs struct {
+	Args []StopApplicationUnitsArg `json:"args"`
+}
+
+// StopApplicationUnitsArg holds the scale an application's
+// workload is about to be reduced to.
+type StopApplicationUnitsArg struct {
+	ApplicationTag string `json:"application-tag"`
+	Scale          int    `json:"scale"`
+}
+
+// SetApplicationServiceHashArgs holds the parameters for recording
+// the hash of the service definitions last applied to the cloud.
+type SetApplicationServiceHashArgs struct {
+	Args []EntityString `json:"args"`
+}
+
 // ApplicationDestroy holds the parameters for making the deprecated
 // Application.Destroy call.
 type ApplicationDestroy struct {
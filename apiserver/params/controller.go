@@ -109,6 +109,12 @@ const (
 	RevokeControllerAccess ControllerAction = "revoke"
 )
 
+// ControllerCacheHealth holds the health of the controller's model cache.
+type ControllerCacheHealth struct {
+	Status    string `json:"status"`
+	LastError string `json:"last-error,omitempty"`
+}
+
 // ControllerVersionResults holds the results from an api call
 // to get the controller's version information.
 type ControllerVersionResults struct {
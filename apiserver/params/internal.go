@@ -1033,3 +1033,12 @@ type ContainerTypeResult struct {
 	Type  instance.ContainerType `json:"container-type"`
 	Error *Error                 `json:"error"`
 }
+
+// MachineRemovalProgress holds the progress made by the machine
+// undertaker in removing the machines of a model.
+type MachineRemovalProgress struct {
+	ModelTag          string `json:"model-tag"`
+	Pending           int    `json:"pending"`
+	AddressesReleased int    `json:"addresses-released"`
+	Failures          int    `json:"failures"`
+}
@@ -85,6 +85,14 @@ type PinApplicationResult struct {
 	Error *Error `json:"error,omitempty"`
 }
 
+// PinApplicationsArgs holds the arguments to a request
+// to pin leadership for applications.
+type PinApplicationsArgs struct {
+	// Reason is an optional description of why leadership
+	// is being pinned, recorded for the pinning entity.
+	Reason string `json:"reason,omitempty"`
+}
+
 // PinnedLeadershipResults holds data representing the current applications for
 // which leadership is pinned
 type PinnedLeadershipResult struct {
@@ -94,3 +102,23 @@ type PinnedLeadershipResult struct {
 	//   behaviour for each application.
 	Result map[string][]string `json:"result,omitempty"`
 }
+
+// PinnedLeadershipReasonsResult holds data representing the current
+// applications for which leadership is pinned, along with the reasons
+// recorded by each entity requiring the pinned behaviour.
+type PinnedLeadershipReasonsResult struct {
+	// Result has:
+	// - Application name keys representing the application pinned.
+	// - Values recording each entity requiring pinned behaviour
+	//   for the application, with the reason it supplied.
+	Result map[string][]PinnedLeadershipReason `json:"result,omitempty"`
+}
+
+// PinnedLeadershipReason records an entity requiring
+// pinned leadership and the reason it gave for the pin.
+type PinnedLeadershipReason struct {
+	// Entity is the tag of the entity that pinned leadership.
+	Entity string `json:"entity"`
+	// Reason is the reason supplied when pinning, if any.
+	Reason string `json:"reason,omitempty"`
+}
@@ -436,6 +436,11 @@ type ModelCredential struct {
 	// and whether this credential works for this model, i.e. all model
 	// machines can be accessed with this credential.
 	Valid bool `json:"valid,omitempty"`
+
+	// Missing indicates that the model refers to a cloud credential
+	// whose content is no longer stored on the controller, for
+	// example because the credential has been removed.
+	Missing bool `json:"missing,omitempty"`
 }
 
 // ChangeModelCredentialParams holds the argument to replace cloud credential
@@ -374,6 +374,21 @@ type IssueOperatorCertificateResults struct {
 	Results []IssueOperatorCertificateResult `json:"results"`
 }
 
+// StringsLifeWatchResult holds a StringsWatcher id and its initial
+// changes, along with the life of each entity in those changes.
+type StringsLifeWatchResult struct {
+	StringsWatcherId string       `json:"watcher-id"`
+	Changes          []string     `json:"changes,omitempty"`
+	Life             []LifeResult `json:"life,omitempty"`
+	Error            *Error       `json:"error,omitempty"`
+}
+
+// CheckOperatorImageArgs holds the path of an operator
+// image whose availability is to be checked.
+type CheckOperatorImageArgs struct {
+	ImagePath string `json:"image-path"`
+}
+
 // PublicAddress holds parameters for the PublicAddress call.
 type PublicAddress struct {
 	Target string `json:"target"`
@@ -1052,6 +1067,13 @@ type BundleChangesResults struct {
 	Errors []string `json:"errors,omitempty"`
 }
 
+// BundleValidationResults holds results of the Bundle.Validate call.
+type BundleValidationResults struct {
+	// Errors holds any problems found with the bundle.
+	// It is empty if the bundle is valid.
+	Errors []string `json:"errors,omitempty"`
+}
+
 // BundleChange holds a single change required to deploy a bundle.
 type BundleChange struct {
 	// Id is the unique identifier for this change.
@@ -1250,6 +1272,19 @@ func (e UpgradeSeriesValidationErrorInfo) AsMap() map[string]interface{} {
 	return serializeToMap(e)
 }
 
+// RequestTooLargeErrorInfo provides additional information for
+// CodeRequestTooLarge errors.
+type RequestTooLargeErrorInfo struct {
+	// MaxSize is the maximum size in bytes of the request body
+	// accepted by the endpoint.
+	MaxSize int64 `json:"max-size"`
+}
+
+// AsMap encodes the error info as a map that can be attached to an Error.
+func (e RequestTooLargeErrorInfo) AsMap() map[string]interface{} {
+	return serializeToMap(e)
+}
+
 type ProfileArg struct {
 	Entity   Entity `json:"entity"`
 	UnitName string `json:"unit-name"`
@@ -1302,6 +1337,62 @@ type BranchArg struct {
 	BranchName string `json:"branch"`
 }
 
+// BranchCommitArg identifies an in-flight branch to be committed.
+type BranchCommitArg struct {
+	BranchName string `json:"branch"`
+
+	// Force indicates that the branch should be committed even if its
+	// config changes conflict with changes since made on master,
+	// overwriting the master values.
+	Force bool `json:"force,omitempty"`
+
+	// Comment is an optional note recorded with the commit,
+	// explaining why the branch was committed.
+	Comment string `json:"comment,omitempty"`
+}
+
+// BranchCommitResult transports the result of committing a branch.
+type BranchCommitResult struct {
+	// GenerationId is the new generation ID of the model.
+	GenerationId int `json:"generation-id"`
+
+	// Conflicts holds the config changes under the branch that conflict
+	// with changes since made on master, if they prevented the commit.
+	Conflicts []ApplicationConfigConflicts `json:"conflicts,omitempty"`
+
+	// Error holds the value of any error that occurred processing the request.
+	Error *Error `json:"error,omitempty"`
+}
+
+// ApplicationConfigConflicts holds the conflicting
+// config changes for an application under a branch.
+type ApplicationConfigConflicts struct {
+	// ApplicationsName is the name of the application.
+	ApplicationName string `json:"application"`
+
+	// Conflicts holds the application's conflicting config changes.
+	Conflicts []ConfigConflict `json:"conflicts"`
+}
+
+// ConfigConflict describes a charm config setting changed under a
+// branch that has since been changed on master.
+type ConfigConflict struct {
+	// Key is the name of the setting.
+	Key string `json:"key"`
+
+	// Baseline is the master value when the setting was first
+	// changed under the branch. It is absent if it was not set.
+	Baseline interface{} `json:"baseline,omitempty"`
+
+	// Master is the current master value.
+	// It is absent if the setting is not set.
+	Master interface{} `json:"master,omitempty"`
+
+	// Branch is the value under the branch.
+	// It is absent if the setting is deleted under the branch.
+	Branch interface{} `json:"branch,omitempty"`
+}
+
 // GenerationId represents an GenerationId from a branch.
 type GenerationId struct {
 	GenerationId int `json:"generation-id"`
@@ -1323,6 +1414,10 @@ type BranchTrackArg struct {
 	BranchName string   `json:"branch"`
 	Entities   []Entity `json:"entities"`
 	NumUnits   int      `json:"num-units,omitempty"`
+
+	// TrackingPolicy, if set, is recorded against applications in Entities
+	// to determine whether their subsequently added units track the branch.
+	TrackingPolicy string `json:"tracking-policy,omitempty"`
 }
 
 // GenerationApplication represents changes to an application
@@ -1334,6 +1429,10 @@ type GenerationApplication struct {
 	// UnitProgress is summary information about units tracking the branch.
 	UnitProgress string `json:"progress"`
 
+	// TrackingPolicy indicates whether units added to the application
+	// while the branch is in-flight will track it.
+	TrackingPolicy string `json:"tracking-policy,omitempty"`
+
 	// UnitsTracking is the names of application units that have been set to
 	// track the branch.
 	UnitsTracking []string `json:"tracking,omitempty"`
@@ -1345,6 +1444,10 @@ type GenerationApplication struct {
 	// Config changes are the effective new configuration values resulting from
 	// changes made under this branch.
 	ConfigChanges map[string]interface{} `json:"config"`
+
+	// ConfigConflicts are the config changes under this branch that
+	// conflict with changes since made on master.
+	ConfigConflicts []ConfigConflict `json:"config-conflicts,omitempty"`
 }
 
 // Generation represents a model generation's details including config changes.
@@ -1358,12 +1461,19 @@ type Generation struct {
 	// Created is the user who created the generation.
 	CreatedBy string `json:"created-by"`
 
+	// OwnedByYou is true if the generation was
+	// created by the user making the request.
+	OwnedByYou bool `json:"owned-by-you,omitempty"`
+
 	// Completed is the Unix timestamp at generation completion/commit.
 	Completed int64 `json:"completed,omitempty"`
 
 	// CompletedBy is the user who committed/completed the generation.
 	CompletedBy string `json:"completed-by,omitempty"`
 
+	// Comment is the note recorded when the generation was committed.
+	Comment string `json:"comment,omitempty"`
+
 	// GenerationId is the id .
 	GenerationId int `json:"generation-id,omitempty"`
 
@@ -1381,6 +1491,34 @@ type BranchResults struct {
 	Error *Error `json:"error,omitempty"`
 }
 
+// BranchSummary is a compact representation of an "in-flight" branch.
+type BranchSummary struct {
+	// BranchName uniquely identifies a branch *amongst in-flight branches*.
+	BranchName string `json:"branch"`
+
+	// Created is the Unix timestamp at branch creation.
+	Created int64 `json:"created"`
+
+	// CreatedBy is the user who created the branch.
+	CreatedBy string `json:"created-by"`
+
+	// TrackedUnitCount is the number of units tracking the branch.
+	TrackedUnitCount int `json:"tracked-unit-count"`
+
+	// ApplicationCount is the number of applications with units
+	// tracking the branch.
+	ApplicationCount int `json:"application-count"`
+}
+
+// BranchSummaryResults transports a collection of branch summaries.
+type BranchSummaryResults struct {
+	// Summaries holds the summaries of the in-flight branches.
+	Summaries []BranchSummary `json:"summaries"`
+
+	// Error holds the value of any error that occurred processing the request.
+	Error *Error `json:"error,omitempty"`
+}
+
 // GenerationResult transports a generation detail.
 type GenerationResult struct {
 	// Generation holds the details of the requested generation.
@@ -4,8 +4,10 @@
 package apiserver
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime"
 	"net/http"
 	"path"
@@ -17,6 +19,7 @@ import (
 
 	api "github.com/juju/juju/api/resources"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 )
@@ -44,6 +47,10 @@ type ResourcesBackend interface {
 type ResourcesHandler struct {
 	StateAuthFunc     func(*http.Request, ...string) (ResourcesBackend, state.PoolHelper, names.Tag, error)
 	ChangeAllowedFunc func(*http.Request) error
+
+	// RegistryChecker, if set, is used to verify that uploaded container
+	// image resources can be pulled using the supplied credentials.
+	RegistryChecker resources.RegistryChecker
 }
 
 // ServeHTTP implements http.Handler.
@@ -155,12 +162,19 @@ func (h *ResourcesHandler) readResource(backend ResourcesBackend, req *http.Requ
 		return nil, errors.Trace(err)
 	}
 
+	data := req.Body
 	switch res.Type {
 	case charmresource.TypeFile:
 		ext := path.Ext(res.Path)
 		if path.Ext(uReq.Filename) != ext {
 			return nil, errors.Errorf("incorrect extension on resource upload %q, expected %q", uReq.Filename, ext)
 		}
+	case charmresource.TypeContainerImage:
+		if h.RegistryChecker != nil && !uReq.SkipRegistryCheck {
+			if data, err = h.checkRegistry(uReq.Name, req.Body); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 	}
 
 	chRes, err := updateResource(res.Resource, uReq.Fingerprint, uReq.Size)
@@ -171,10 +185,31 @@ func (h *ResourcesHandler) readResource(backend ResourcesBackend, req *http.Requ
 		Application: uReq.Application,
 		PendingID:   uReq.PendingID,
 		Resource:    chRes,
-		Data:        req.Body,
+		Data:        data,
 	}, nil
 }
 
+// checkRegistry verifies that the container image described by the
+// uploaded data can be retrieved using the credentials it contains.
+// The data is consumed, so a reader over the same data is returned.
+func (h *ResourcesHandler) checkRegistry(name string, body io.Reader) (io.ReadCloser, error) {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	details, err := resources.UnmarshalDockerResource(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.RegistryChecker.CheckImage(details); err != nil {
+		// Registry failures are reported as a bad request, so that
+		// rejected registry credentials are not mistaken by the client
+		// for a failure to authenticate with the controller.
+		return nil, errors.NewBadRequest(err, fmt.Sprintf("validating image for resource %q", name))
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
 // updateResource returns a copy of the provided resource, updated with
 // the given information.
 func updateResource(res charmresource.Resource, fp charmresource.Fingerprint, size int64) (charmresource.Resource, error) {
@@ -211,6 +246,7 @@ func extractUploadRequest(req *http.Request) (api.UploadRequest, error) {
 	fingerprint := req.Header.Get(api.HeaderContentSha384) // This parallels "Content-MD5".
 	sizeRaw := req.Header.Get(api.HeaderContentLength)
 	pendingID := req.URL.Query().Get(api.QueryParamPendingID)
+	skipRegistryCheck, _ := strconv.ParseBool(req.URL.Query().Get(api.QueryParamSkipRegistryCheck))
 
 	fp, err := charmresource.ParseFingerprint(fingerprint)
 	if err != nil {
@@ -234,6 +270,8 @@ func extractUploadRequest(req *http.Request) (api.UploadRequest, error) {
 		Size:        size,
 		Fingerprint: fp,
 		PendingID:   pendingID,
+
+		SkipRegistryCheck: skipRegistryCheck,
 	}
 	return ur, nil
 }
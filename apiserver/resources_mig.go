@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
 	charmresource "github.com/juju/charm/v9/resource"
 	"github.com/juju/errors"
@@ -78,14 +79,16 @@ func (h *resourcesMigrationUploadHandler) processPost(r *http.Request, st *state
 		return empty, errors.Trace(err)
 	}
 
-	reader := r.Body
-
-	// Don't associate content with a placeholder resource.
-	if isPlaceholder(query) {
-		reader = nil
+	if !isUnit && !isPlaceholder(query) {
+		outRes, err := importResource(target, userID, res, query, r.Body, rSt)
+		if err != nil {
+			return empty, errors.Annotate(err, "resource upload failed")
+		}
+		return outRes, nil
 	}
 
-	outRes, err := setResource(isUnit, target, userID, res, reader, rSt)
+	// Don't associate content with a placeholder resource.
+	outRes, err := setResource(isUnit, target, userID, res, nil, rSt)
 	if err != nil {
 		return empty, errors.Annotate(err, "resource upload failed")
 	}
@@ -101,6 +104,25 @@ func setResource(isUnit bool, target, user string, res charmresource.Resource, r
 	return rSt.SetResource(target, user, res, r)
 }
 
+// importResource stores the content of an application resource,
+// keeping the metadata recorded by the source controller.
+func importResource(appName, user string, chRes charmresource.Resource, query url.Values, r io.Reader, rSt state.Resources) (
+	resource.Resource, error,
+) {
+	nanos, err := strconv.ParseInt(query.Get("timestamp"), 10, 64)
+	if err != nil {
+		return resource.Resource{}, errors.BadRequestf("invalid timestamp")
+	}
+	res := resource.Resource{
+		Resource:      chRes,
+		ApplicationID: appName,
+		Username:      user,
+		Timestamp:     time.Unix(0, nanos).UTC(),
+	}
+	// The revisions used by units are uploaded separately.
+	return rSt.ImportResource(res, nil, r)
+}
+
 func isPlaceholder(query url.Values) bool {
 	return query.Get("timestamp") == ""
 }
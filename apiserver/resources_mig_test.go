@@ -14,6 +14,7 @@ import (
 	"time"
 
 	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -173,6 +174,37 @@ func (s *resourcesUploadSuite) TestUpload(c *gc.C) {
 	c.Assert(res.ID, gc.Equals, outResp.ID)
 }
 
+func (s *resourcesUploadSuite) TestUploadKeepsSourceMetadata(c *gc.C) {
+	timestamp := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	q := s.makeUploadArgs(c)
+	q.Set("timestamp", fmt.Sprint(timestamp.UnixNano()))
+	outResp := s.uploadAppResource(c, &q)
+	c.Check(outResp.Timestamp.Equal(timestamp), jc.IsTrue)
+
+	rSt, err := s.importingState.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	res, err := rSt.GetResource(s.appName, "bin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Timestamp.Equal(timestamp), jc.IsTrue)
+	c.Check(res.Username, gc.Equals, "napoleon")
+}
+
+func (s *resourcesUploadSuite) TestUploadFingerprintMismatch(c *gc.C) {
+	q := s.makeUploadArgs(c)
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         s.resourcesURI(q.Encode()),
+		ContentType: "application/octet-stream",
+		Body:        strings.NewReader("STUFF"),
+	})
+	c.Check(resp.StatusCode, gc.Not(gc.Equals), http.StatusOK)
+
+	rSt, err := s.importingState.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = rSt.GetResource(s.appName, "bin")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *resourcesUploadSuite) TestUnitUpload(c *gc.C) {
 	// Upload application resource first. A unit resource can't be
 	// uploaded without the application resource being there first.
@@ -27,6 +27,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreresources "github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
@@ -171,6 +172,70 @@ func (s *ResourcesHandlerSuite) TestPutSuccessDockerResource(c *gc.C) {
 	s.checkResp(c, http.StatusOK, "application/json", string(expected))
 }
 
+func (s *ResourcesHandlerSuite) TestPutDockerResourceChecksRegistry(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/me/image:1.0","Username":"user","Password":"pass"}`
+	res := newDockerResource(c, "spam", "a-user", content)
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.backend.ReturnSetResource = res
+	checker := &fakeRegistryChecker{}
+	s.handler.RegistryChecker = checker
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	s.handler.ServeHTTP(s.recorder, req)
+
+	expected := mustMarshalJSON(&params.UploadResult{
+		Resource: api.Resource2API(res),
+	})
+	s.checkResp(c, http.StatusOK, "application/json", string(expected))
+	c.Check(checker.checked, jc.DeepEquals, []coreresources.DockerImageDetails{{
+		RegistryPath: "registry.example.com/me/image:1.0",
+		Username:     "user",
+		Password:     "pass",
+	}})
+
+	// The data checked is still passed on to be stored.
+	c.Check(string(s.backend.SetResourceData), gc.Equals, uploadContent)
+}
+
+func (s *ResourcesHandlerSuite) TestPutDockerResourceRegistryCheckFailure(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/me/image:1.0","Username":"user","Password":"wrong"}`
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.handler.RegistryChecker = &fakeRegistryChecker{
+		err: errors.Unauthorizedf("credentials rejected for image %q", "registry.example.com/me/image:1.0"),
+	}
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	s.handler.ServeHTTP(s.recorder, req)
+
+	_, expected := apiFailure(
+		`validating image for resource "spam": credentials rejected for image "registry.example.com/me/image:1.0"`,
+		params.CodeBadRequest)
+	s.checkResp(c, http.StatusBadRequest, "application/json", expected)
+	c.Check(s.backend.SetResourceData, gc.IsNil)
+}
+
+func (s *ResourcesHandlerSuite) TestPutDockerResourceSkipRegistryCheck(c *gc.C) {
+	uploadContent := `{"ImageName":"registry.example.com/me/image:1.0"}`
+	res := newDockerResource(c, "spam", "a-user", content)
+	stored := newDockerResource(c, "spam", "", "")
+	s.backend.ReturnGetResource = stored
+	s.backend.ReturnSetResource = res
+	checker := &fakeRegistryChecker{err: errors.New("should not be called")}
+	s.handler.RegistryChecker = checker
+
+	req, _ := newUploadRequest(c, "spam", "a-application", uploadContent)
+	req.URL.RawQuery += "&skip-registry-check=true"
+	s.handler.ServeHTTP(s.recorder, req)
+
+	expected := mustMarshalJSON(&params.UploadResult{
+		Resource: api.Resource2API(res),
+	})
+	s.checkResp(c, http.StatusOK, "application/json", string(expected))
+	c.Check(checker.checked, gc.HasLen, 0)
+}
+
 func (s *ResourcesHandlerSuite) TestPutExtensionMismatch(c *gc.C) {
 	content := "<some data>"
 
@@ -240,6 +305,7 @@ type fakeBackend struct {
 	ReturnGetPendingResource    resource.Resource
 	ReturnSetResource           resource.Resource
 	SetResourceErr              error
+	SetResourceData             []byte
 	ReturnUpdatePendingResource resource.Resource
 }
 
@@ -264,9 +330,24 @@ func (s *fakeBackend) SetResource(applicationID, userID string, res charmresourc
 	if s.SetResourceErr != nil {
 		return resource.Resource{}, s.SetResourceErr
 	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return resource.Resource{}, err
+	}
+	s.SetResourceData = data
 	return s.ReturnSetResource, nil
 }
 
+type fakeRegistryChecker struct {
+	checked []coreresources.DockerImageDetails
+	err     error
+}
+
+func (c *fakeRegistryChecker) CheckImage(details coreresources.DockerImageDetails) error {
+	c.checked = append(c.checked, details)
+	return c.err
+}
+
 func (s *fakeBackend) UpdatePendingResource(applicationID, pendingID, userID string, res charmresource.Resource, r io.Reader) (resource.Resource, error) {
 	return s.ReturnUpdatePendingResource, nil
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
@@ -201,9 +202,11 @@ func newAPIRoot(clock clock.Clock, st *state.State, shared *sharedServerContext,
 	// require it for model config and others.
 	// In all real cases we have a state object, but some test code avoids passing one
 	// in, in order to just probe endpoints.
+	// While the cache is degraded, the model may never appear in it, so
+	// the connection is allowed once the model is known to exist in state.
 	if st != nil {
 		_, err := r.cachedModel(st.ModelUUID())
-		if err != nil {
+		if err != nil && errors.Cause(err) != errModelCacheDegraded {
 			return nil, errors.Annotate(err, "model cache")
 		}
 	}
@@ -391,14 +394,34 @@ func (r *apiRoot) dispose(key objectKey) {
 	delete(r.objectCache, key)
 }
 
+// errModelCacheDegraded is returned by cachedModel for a model
+// that exists, but is missing from the degraded model cache.
+var errModelCacheDegraded = errors.New("model cache is degraded, try again later")
+
 func (r *apiRoot) cachedModel(uuid string) (*cache.Model, error) {
-	model, err := r.shared.controller.WaitForModel(uuid, r.clock)
+	controller := r.shared.controller
+	degraded := controller.Health().Status == cache.HealthDegraded
+
+	var (
+		model *cache.Model
+		err   error
+	)
+	if degraded {
+		// The cache is not receiving changes,
+		// so there is no point waiting for the model.
+		model, err = controller.Model(uuid)
+	} else {
+		model, err = controller.WaitForModel(uuid, r.clock)
+	}
 	if err != nil {
 		// Check the database...
 		exists, err2 := r.state.ModelExists(uuid)
 		if err2 != nil {
 			return nil, errors.Trace(err2)
 		}
+		if exists && degraded {
+			return nil, errModelCacheDegraded
+		}
 		if exists {
 			return nil, errors.Trace(err)
 		}
@@ -450,6 +473,11 @@ func (ctx *facadeContext) ModelPresence(modelUUID string) facade.ModelPresence {
 	return ctx.r.shared.presence.Connections().ForModel(modelUUID)
 }
 
+// CharmhubInfoCache implements facade.Context.
+func (ctx *facadeContext) CharmhubInfoCache() *charmhub.InfoCache {
+	return ctx.r.shared.charmhubInfoCache
+}
+
 // Hub implements facade.Context.
 func (ctx *facadeContext) Hub() facade.Hub {
 	return ctx.r.shared.centralHub
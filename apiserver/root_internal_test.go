@@ -13,6 +13,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/core/cache"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -124,3 +125,30 @@ func (s *facadeContextSuite) TestCachedModelTimeout(c *gc.C) {
 		c.Error("CachedModel didn't return")
 	}
 }
+
+func (s *facadeContextSuite) TestCachedModelDegraded(c *gc.C) {
+	// Make a model in the DB, but don't tell the cache about it.
+	state := s.Factory.MakeModel(c, nil)
+	defer state.Close()
+	s.controller.SetDegraded(errors.New("boom"))
+
+	// The degraded cache is not waited on.
+	ctx := s.newContext()
+	model, err := ctx.CachedModel(state.ModelUUID())
+	c.Check(err, gc.Equals, errModelCacheDegraded)
+	c.Check(model, gc.IsNil)
+}
+
+func (s *facadeContextSuite) TestNewAPIRootCacheDegraded(c *gc.C) {
+	// Make a model in the DB, but don't tell the cache about it.
+	state := s.Factory.MakeModel(c, nil)
+	defer state.Close()
+	s.controller.SetDegraded(errors.New("boom"))
+
+	shared := &sharedServerContext{
+		controller: s.controller,
+		logger:     loggo.GetLogger("test"),
+	}
+	_, err := newAPIRoot(s.clock, state, shared, nil, common.NewResources(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/charmhub"
 	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/lease"
@@ -42,6 +43,7 @@ type sharedServerContext struct {
 	centralHub          SharedHub
 	presence            presence.Recorder
 	leaseManager        lease.Manager
+	charmhubInfoCache   *charmhub.InfoCache
 	logger              loggo.Logger
 	cancel              <-chan struct{}
 
@@ -60,6 +62,7 @@ type sharedServerConfig struct {
 	presence            presence.Recorder
 	leaseManager        lease.Manager
 	controllerConfig    jujucontroller.Config
+	charmhubInfoCache   *charmhub.InfoCache
 	logger              loggo.Logger
 }
 
@@ -85,6 +88,9 @@ func (c *sharedServerConfig) validate() error {
 	if c.controllerConfig == nil {
 		return errors.NotValidf("nil controllerConfig")
 	}
+	if c.charmhubInfoCache == nil {
+		return errors.NotValidf("nil charmhubInfoCache")
+	}
 	return nil
 }
 
@@ -99,6 +105,7 @@ func newSharedServerContext(config sharedServerConfig) (*sharedServerContext, er
 		centralHub:          config.centralHub,
 		presence:            config.presence,
 		leaseManager:        config.leaseManager,
+		charmhubInfoCache:   config.charmhubInfoCache,
 		logger:              config.logger,
 		controllerConfig:    config.controllerConfig,
 	}
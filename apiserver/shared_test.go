@@ -15,6 +15,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/charmhub"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/presence"
@@ -70,6 +71,13 @@ func (s *sharedServerContextSuite) SetUpTest(c *gc.C) {
 	controllerConfig, err := s.State.ControllerConfig()
 	c.Assert(err, jc.ErrorIsNil)
 
+	charmhubInfoCache, err := charmhub.NewInfoCache(charmhub.InfoCacheConfig{
+		Clock:      clock.WallClock,
+		TTL:        time.Minute,
+		MaxEntries: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
 	s.config = sharedServerConfig{
 		statePool:           s.StatePool,
 		controller:          controller,
@@ -78,6 +86,7 @@ func (s *sharedServerContextSuite) SetUpTest(c *gc.C) {
 		presence:            presence.New(clock.WallClock),
 		leaseManager:        &lease.Manager{},
 		controllerConfig:    controllerConfig,
+		charmhubInfoCache:   charmhubInfoCache,
 		logger:              loggo.GetLogger("test"),
 	}
 }
@@ -131,6 +140,13 @@ func (s *sharedServerContextSuite) TestConfigNoControllerconfig(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "nil controllerConfig not valid")
 }
 
+func (s *sharedServerContextSuite) TestConfigNoCharmhubInfoCache(c *gc.C) {
+	s.config.charmhubInfoCache = nil
+	err := s.config.validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil charmhubInfoCache not valid")
+}
+
 func (s *sharedServerContextSuite) TestNewCallsConfigValidate(c *gc.C) {
 	s.config.statePool = nil
 	ctx, err := newSharedServerContext(s.config)
@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"net/http"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/controller"
+)
+
+// uploadCategory identifies the limit applied to
+// the size of requests sent to an upload endpoint.
+type uploadCategory int
+
+const (
+	noUploadLimit uploadCategory = iota
+	charmUploads
+	resourceUploads
+	agentBinaryUploads
+	backupUploads
+)
+
+// uploadLimits holds the maximum size in bytes of request bodies
+// for each category of upload endpoint. A limit of zero indicates
+// that request bodies are not limited.
+type uploadLimits map[uploadCategory]int64
+
+// uploadLimitsFromConfig returns the upload
+// limits defined by the controller config.
+func uploadLimitsFromConfig(cfg controller.Config) uploadLimits {
+	const mb = 1024 * 1024
+	return uploadLimits{
+		charmUploads:       int64(cfg.MaxCharmUploadSizeMB()) * mb,
+		resourceUploads:    int64(cfg.MaxResourceUploadSizeMB()) * mb,
+		agentBinaryUploads: int64(cfg.MaxAgentBinaryUploadSizeMB()) * mb,
+		backupUploads:      int64(cfg.MaxBackupUploadSizeMB()) * mb,
+	}
+}
+
+// setUploadLimits replaces the limits applied to upload endpoints.
+func (srv *Server) setUploadLimits(limits uploadLimits) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.uploadLimits = limits
+}
+
+// uploadLimit returns the current limit for the input category.
+func (srv *Server) uploadLimit(category uploadCategory) int64 {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.uploadLimits[category]
+}
+
+// limitUploads wraps a http.Handler, limiting the size of request bodies
+// to the current limit for the input category. Requests declaring a
+// larger body are rejected with a 413 status before the handler is
+// called. Otherwise reading beyond the limit fails with an error that
+// handlers send to the client with the same status.
+func (srv *Server) limitUploads(category uploadCategory, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		limit := srv.uploadLimit(category)
+		if limit <= 0 || req.Body == nil {
+			handler.ServeHTTP(w, req)
+			return
+		}
+		if req.ContentLength > limit {
+			logger.Warningf("rejecting %s %s: body of %d bytes exceeds limit of %d bytes",
+				req.Method, req.URL.Path, req.ContentLength, limit)
+			if err := sendError(w, &apiservererrors.RequestTooLargeError{MaxSize: limit}); err != nil {
+				logger.Errorf("%v", err)
+			}
+			return
+		}
+		req.Body = &limitedBody{
+			ReadCloser: http.MaxBytesReader(w, req.Body, limit),
+			limit:      limit,
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
+
+// limitedBody wraps a request body limited by http.MaxBytesReader,
+// replacing the error returned when the limit is exceeded with a
+// *apiservererrors.RequestTooLargeError.
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+// Read is part of the io.Reader interface.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if err != nil && err != io.EOF && b.read >= b.limit {
+		err = &apiservererrors.RequestTooLargeError{MaxSize: b.limit}
+	}
+	return n, err
+}
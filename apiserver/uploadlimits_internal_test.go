@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type uploadLimitsSuite struct {
+	testing.BaseSuite
+
+	srv     *Server
+	handler http.Handler
+}
+
+var _ = gc.Suite(&uploadLimitsSuite{})
+
+func (s *uploadLimitsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.srv = &Server{}
+	s.srv.setUploadLimits(uploadLimits{charmUploads: 10})
+
+	// The handler reads the whole body, sending any error as the
+	// upload handlers do.
+	s.handler = s.srv.limitUploads(charmUploads, http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			data, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				_ = sendError(w, errors.Annotate(err, "processing upload"))
+				return
+			}
+			_, _ = w.Write(data)
+		},
+	))
+}
+
+func (s *uploadLimitsSuite) serve(body string, contentLength int64) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/charms", strings.NewReader(body))
+	req.ContentLength = contentLength
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *uploadLimitsSuite) assertTooLarge(c *gc.C, rec *httptest.ResponseRecorder) {
+	c.Assert(rec.Code, gc.Equals, http.StatusRequestEntityTooLarge)
+	var result params.ErrorResult
+	err := json.Unmarshal(rec.Body.Bytes(), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, params.CodeRequestTooLarge)
+	c.Check(result.Error.Message, gc.Matches, ".*request body exceeds the maximum size of 10 bytes")
+	c.Check(result.Error.Info, jc.DeepEquals, map[string]interface{}{"max-size": float64(10)})
+}
+
+func (s *uploadLimitsSuite) TestWithinLimit(c *gc.C) {
+	rec := s.serve("0123456789", 10)
+	c.Assert(rec.Code, gc.Equals, http.StatusOK)
+	c.Check(rec.Body.String(), gc.Equals, "0123456789")
+}
+
+func (s *uploadLimitsSuite) TestContentLengthExceedsLimit(c *gc.C) {
+	s.assertTooLarge(c, s.serve("0123456789a", 11))
+}
+
+func (s *uploadLimitsSuite) TestUnknownLengthExceedsLimit(c *gc.C) {
+	s.assertTooLarge(c, s.serve("0123456789a", -1))
+}
+
+func (s *uploadLimitsSuite) TestLimitUpdated(c *gc.C) {
+	s.srv.setUploadLimits(uploadLimits{charmUploads: 20})
+	rec := s.serve("0123456789a", 11)
+	c.Check(rec.Code, gc.Equals, http.StatusOK)
+
+	// A zero limit disables the check.
+	s.srv.setUploadLimits(uploadLimits{})
+	rec = s.serve(strings.Repeat("x", 100), 100)
+	c.Check(rec.Code, gc.Equals, http.StatusOK)
+}
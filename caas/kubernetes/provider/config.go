@@ -23,6 +23,7 @@ const (
 	serviceLoadBalancerSourceRangesKey = "kubernetes-service-loadbalancer-sourceranges"
 	serviceExternalNameKey             = "kubernetes-service-externalname"
 	serviceAnnotationsKey              = "kubernetes-service-annotations"
+	TerminationGracePeriodKey          = "kubernetes-termination-grace-period"
 
 	ingressClassKey          = "kubernetes-ingress-class"
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
@@ -66,6 +67,11 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.ProviderGroup,
 	},
+	TerminationGracePeriodKey: {
+		Description: "seconds a unit pod is given to shut down cleanly when the unit is removed",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
 	ingressClassKey: {
 		Description: "the class of the ingress controller to be used by the ingress resource",
 		Type:        environschema.Tstring,
@@ -89,12 +95,13 @@ var configFields = environschema.Fields{
 }
 
 var schemaDefaults = schema.Defaults{
-	ServiceTypeConfigKey:     schema.Omit,
-	serviceAnnotationsKey:    schema.Omit,
-	ingressClassKey:          defaultIngressClass,
-	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
-	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
-	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+	ServiceTypeConfigKey:      schema.Omit,
+	serviceAnnotationsKey:     schema.Omit,
+	TerminationGracePeriodKey: schema.Omit,
+	ingressClassKey:           defaultIngressClass,
+	ingressSSLRedirectKey:     defaultIngressSSLRedirect,
+	ingressSSLPassthroughKey:  defaultIngressSSLPassthrough,
+	ingressAllowHTTPKey:       defaultIngressAllowHTTPKey,
 }
 
 // ConfigSchema returns the configuration schema for
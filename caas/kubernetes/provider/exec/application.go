@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/juju/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8sutils "github.com/juju/juju/caas/kubernetes/provider/utils"
+)
+
+// defaultMaxConcurrency is the number of pods commands are run
+// on at once by ExecAcrossApplication if MaxConcurrency is not set.
+const defaultMaxConcurrency = 10
+
+// ApplicationExecParams holds all the necessary parameters
+// for ExecAcrossApplication.
+type ApplicationExecParams struct {
+	ApplicationName string
+	// LegacyLabels is true if the application's pods
+	// are labelled with the legacy labels.
+	LegacyLabels bool
+
+	Commands      []string
+	Env           []string
+	ContainerName string
+	WorkingDir    string
+
+	// MaxConcurrency is the maximum number of pods the commands are
+	// run on at once. If zero, defaultMaxConcurrency is used.
+	MaxConcurrency int
+}
+
+func (p *ApplicationExecParams) validate() error {
+	if p.ApplicationName == "" {
+		return errors.NotValidf("empty application name")
+	}
+	if len(p.Commands) == 0 {
+		return errors.NotValidf("empty commands")
+	}
+	if p.MaxConcurrency < 0 {
+		return errors.NotValidf("negative MaxConcurrency")
+	}
+	return nil
+}
+
+// PodExecResult holds the result of running commands on one pod.
+type PodExecResult struct {
+	Stdout string
+	Stderr string
+	// Error is the error returned by Exec, which
+	// is an ExitError if the commands failed.
+	Error error
+}
+
+// ExecAcrossApplication runs commands on each pod of an application,
+// found by its labels, returning the results keyed by pod name. The
+// commands are run on at most MaxConcurrency pods at once. A failure
+// on one pod does not prevent the commands being run on the others;
+// it is reported in that pod's result. If cancel is closed, the
+// commands running are cancelled and those not yet started are not run.
+func (c client) ExecAcrossApplication(params ApplicationExecParams, cancel <-chan struct{}) (map[string]PodExecResult, error) {
+	if err := params.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	pods, err := c.podGetter.List(context.TODO(), metav1.ListOptions{
+		LabelSelector: k8sutils.LabelsToSelector(
+			k8sutils.SelectorLabelsForApp(params.ApplicationName, params.LegacyLabels)).String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	maxConcurrency := params.MaxConcurrency
+	if maxConcurrency == 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]PodExecResult, len(pods.Items))
+		slots   = make(chan struct{}, maxConcurrency)
+	)
+	setResult := func(podName string, result PodExecResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[podName] = result
+	}
+	for _, pod := range pods.Items {
+		podName := pod.Name
+		select {
+		case slots <- struct{}{}:
+		case <-cancel:
+			setResult(podName, PodExecResult{
+				Error: errors.Errorf("exec on pod %q cancelled", podName),
+			})
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			var stdout, stderr bytes.Buffer
+			err := c.Exec(ExecParams{
+				Commands:      params.Commands,
+				Env:           params.Env,
+				PodName:       podName,
+				ContainerName: params.ContainerName,
+				WorkingDir:    params.WorkingDir,
+				Stdout:        &stdout,
+				Stderr:        &stderr,
+			}, cancel)
+			setResult(podName, PodExecResult{
+				Stdout: stdout.String(),
+				Stderr: stderr.String(),
+				Error:  err,
+			})
+		}()
+	}
+	wg.Wait()
+	return results, nil
+}
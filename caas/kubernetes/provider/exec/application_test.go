@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package exec_test
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	k8sexec "k8s.io/client-go/util/exec"
+
+	"github.com/juju/juju/caas/kubernetes/provider/exec"
+)
+
+type applicationSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&applicationSuite{})
+
+func (s *applicationSuite) TestExecAcrossApplicationValidate(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	_, err := s.execClient.ExecAcrossApplication(exec.ApplicationExecParams{
+		Commands: []string{"echo", "hello"},
+	}, nil)
+	c.Check(err, gc.ErrorMatches, "empty application name not valid")
+
+	_, err = s.execClient.ExecAcrossApplication(exec.ApplicationExecParams{
+		ApplicationName: "gitlab",
+	}, nil)
+	c.Check(err, gc.ErrorMatches, "empty commands not valid")
+
+	_, err = s.execClient.ExecAcrossApplication(exec.ApplicationExecParams{
+		ApplicationName: "gitlab",
+		Commands:        []string{"echo", "hello"},
+		MaxConcurrency:  -1,
+	}, nil)
+	c.Check(err, gc.ErrorMatches, "negative MaxConcurrency not valid")
+}
+
+func (s *applicationSuite) TestExecAcrossApplication(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	podNames := []string{"gitlab-0", "gitlab-1", "gitlab-2"}
+	var pods []core.Pod
+	for _, name := range podNames {
+		pod := core.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+			},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{Name: "gitlab-container"}},
+			},
+			Status: core.PodStatus{
+				Phase: core.PodRunning,
+				ContainerStatuses: []core.ContainerStatus{{
+					Name:  "gitlab-container",
+					State: core.ContainerState{Running: &core.ContainerStateRunning{}},
+				}},
+			},
+		}
+		pods = append(pods, pod)
+		podCopy := pod
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), name, metav1.GetOptions{}).Return(&podCopy, nil)
+	}
+	s.mockPodGetter.EXPECT().List(gomock.Any(), metav1.ListOptions{
+		LabelSelector: "app.kubernetes.io/name=gitlab",
+	}).Return(&core.PodList{Items: pods}, nil)
+
+	// Each exec is sent its own request, as the
+	// requests are built concurrently.
+	s.restClient.EXPECT().Post().Times(len(podNames)).DoAndReturn(func() *rest.Request {
+		return rest.NewRequestWithClient(
+			&url.URL{Path: "/path/"},
+			"",
+			rest.ClientContentConfig{GroupVersion: core.SchemeGroupVersion},
+			nil,
+		)
+	})
+	executor := &podExecutor{failPod: "gitlab-1"}
+	s.suiteMocks.EXPECT().RemoteCmdExecutorGetter(gomock.Any(), "POST", gomock.Any()).
+		Times(len(podNames)).DoAndReturn(
+		func(_ *rest.Config, _ string, u *url.URL) (remotecommand.Executor, error) {
+			return executor.forURL(u), nil
+		},
+	)
+
+	results, err := s.execClient.ExecAcrossApplication(exec.ApplicationExecParams{
+		ApplicationName: "gitlab",
+		Commands:        []string{"echo", "hello"},
+		MaxConcurrency:  2,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Each pod is sent the command.
+	c.Check(executor.podsRun(), jc.SameContents, podNames)
+	for _, command := range executor.commands() {
+		c.Check(command, jc.Contains, "exec sh -c 'echo hello'")
+	}
+
+	c.Assert(results, gc.HasLen, len(podNames))
+	for _, name := range []string{"gitlab-0", "gitlab-2"} {
+		c.Check(results[name].Error, jc.ErrorIsNil)
+		c.Check(results[name].Stdout, gc.Equals, "hello from "+name+"\n")
+	}
+	failed := results["gitlab-1"]
+	c.Check(failed.Stderr, gc.Equals, "failed on gitlab-1\n")
+	exitErr, ok := errors.Cause(failed.Error).(exec.ExitError)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(exitErr.ExitStatus(), gc.Equals, 1)
+}
+
+func (s *applicationSuite) TestExecAcrossApplicationLegacyLabels(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	s.mockPodGetter.EXPECT().List(gomock.Any(), metav1.ListOptions{
+		LabelSelector: "juju-app=gitlab",
+	}).Return(&core.PodList{}, nil)
+
+	results, err := s.execClient.ExecAcrossApplication(exec.ApplicationExecParams{
+		ApplicationName: "gitlab",
+		LegacyLabels:    true,
+		Commands:        []string{"echo", "hello"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.HasLen, 0)
+}
+
+// podExecutor records the commands run on each pod, which are found from
+// the exec request URLs, writing output for each. The commands fail on
+// failPod.
+type podExecutor struct {
+	failPod string
+
+	mu   sync.Mutex
+	pods []string
+	cmds []string
+}
+
+func (e *podExecutor) forURL(u *url.URL) remotecommand.Executor {
+	// The request path ends with "pods/<name>/exec".
+	parts := strings.Split(strings.TrimSuffix(u.Path, "/"), "/")
+	podName := parts[len(parts)-2]
+	command := strings.Join(u.Query()["command"], " ")
+
+	e.mu.Lock()
+	e.pods = append(e.pods, podName)
+	e.cmds = append(e.cmds, command)
+	e.mu.Unlock()
+	return streamFunc(func(options remotecommand.StreamOptions) error {
+		if podName == e.failPod {
+			fmt.Fprintf(options.Stderr, "failed on %s\n", podName)
+			return k8sexec.CodeExitError{Err: errors.New("command failed"), Code: 1}
+		}
+		fmt.Fprintf(options.Stdout, "hello from %s\n", podName)
+		return nil
+	})
+}
+
+func (e *podExecutor) podsRun() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.pods...)
+}
+
+func (e *podExecutor) commands() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.cmds...)
+}
+
+// streamFunc is a remotecommand.Executor running the function.
+type streamFunc func(options remotecommand.StreamOptions) error
+
+func (f streamFunc) Stream(options remotecommand.StreamOptions) error {
+	return f(options)
+}
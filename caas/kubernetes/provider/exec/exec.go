@@ -10,12 +10,14 @@ import (
 	"io"
 	"net/url"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	jujuclock "github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/retry"
 	"github.com/juju/utils/v2"
 	"github.com/kballard/go-shellquote"
 	"golang.org/x/crypto/ssh/terminal"
@@ -41,6 +43,12 @@ const (
 	sigkillRetryDelay = 100 * time.Millisecond
 	gracefulKillDelay = 10 * time.Second
 	maxTries          = 10
+
+	// containerRetryAttempts is the number of attempts made to exec
+	// into a container that is not running, such as one still being
+	// created, doubling containerRetryDelay between each attempt.
+	containerRetryAttempts = 5
+	containerRetryDelay    = time.Second
 )
 
 var randomString = utils.RandomString
@@ -60,6 +68,7 @@ type client struct {
 type Executor interface {
 	Status(params StatusParams) (*Status, error)
 	Exec(params ExecParams, cancel <-chan struct{}) error
+	ExecAcrossApplication(params ApplicationExecParams, cancel <-chan struct{}) (map[string]PodExecResult, error)
 	Copy(params CopyParams, cancel <-chan struct{}) error
 	RawClient() kubernetes.Interface
 	NameSpace() string
@@ -178,6 +187,11 @@ type ExecParams struct {
 	Stderr io.Writer
 	TTY    bool
 
+	// Combined, if not nil, is written both stdout and stderr, in
+	// the order in which the output is received from the pod.
+	// It may not be used with Stdout, Stderr or TTY.
+	Combined io.Writer
+
 	Signal <-chan syscall.Signal
 }
 
@@ -185,6 +199,9 @@ func (ep *ExecParams) validate(podGetter typedcorev1.PodInterface) (err error) {
 	if len(ep.Commands) == 0 {
 		return errors.NotValidf("empty commands")
 	}
+	if ep.Combined != nil && (ep.Stdout != nil || ep.Stderr != nil || ep.TTY) {
+		return errors.NotValidf("combined output with Stdout, Stderr or TTY")
+	}
 
 	if ep.PodName, ep.ContainerName, err = getValidatedPodContainer(
 		podGetter, ep.PodName, ep.ContainerName,
@@ -204,12 +221,41 @@ func (c client) NameSpace() string {
 	return c.namespace
 }
 
-// Exec runs commands on a pod in the cluster.
+// Exec runs commands on a pod in the cluster. If the container is not
+// running, which is transient while it is being created during a rolling
+// update, the pod is fetched again to check the container's readiness and
+// the exec retried with backoff, for a bounded number of attempts.
+// Other errors, such as the pod not being found, are returned immediately.
 func (c client) Exec(params ExecParams, cancel <-chan struct{}) error {
-	if err := params.validate(c.podGetter); err != nil {
-		return errors.Trace(err)
+	var lastErr error
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			// The pod and container are validated against a copy of
+			// the params, so each attempt resolves them afresh.
+			attemptParams := params
+			if err := attemptParams.validate(c.podGetter); err != nil {
+				lastErr = err
+				return errors.Trace(err)
+			}
+			lastErr = c.exec(attemptParams, cancel)
+			return errors.Trace(lastErr)
+		},
+		IsFatalError: func(err error) bool {
+			return !IsContainerNotRunningError(err)
+		},
+		NotifyFunc: func(err error, attempt int) {
+			logger.Debugf("retrying exec on pod %q, attempt %d: %v", params.PodName, attempt, err)
+		},
+		Attempts:    containerRetryAttempts,
+		Delay:       containerRetryDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       c.clock,
+		Stop:        cancel,
+	})
+	if retry.IsAttemptsExceeded(err) || retry.IsRetryStopped(err) {
+		return errors.Trace(lastErr)
 	}
-	return errors.Trace(c.exec(params, cancel))
+	return errors.Trace(err)
 }
 
 func processEnv(env []string) (string, error) {
@@ -233,6 +279,11 @@ func (c client) safeRun(opts ExecParams, executor remotecommand.Executor) (err e
 		Stderr: opts.Stderr,
 		Tty:    opts.TTY,
 	}
+	if opts.Combined != nil {
+		out := &combinedWriter{w: opts.Combined}
+		streamOptions.Stdout = out
+		streamOptions.Stderr = out
+	}
 
 	if opts.TTY {
 		inFd := getFdInfo(opts.Stdin)
@@ -281,8 +332,8 @@ func (c client) exec(opts ExecParams, cancel <-chan struct{}) (err error) {
 			Container: opts.ContainerName,
 			Command:   cmdArgs,
 			Stdin:     opts.Stdin != nil,
-			Stdout:    opts.Stdout != nil,
-			Stderr:    opts.Stderr != nil,
+			Stdout:    opts.Stdout != nil || opts.Combined != nil,
+			Stderr:    opts.Stderr != nil || opts.Combined != nil,
 			TTY:       opts.TTY,
 		}, scheme.ParameterCodec)
 
@@ -342,6 +393,11 @@ func (c client) exec(opts ExecParams, cancel <-chan struct{}) (err error) {
 	for {
 		select {
 		case err := <-errChan:
+			if err != nil {
+				// The kubelet reports a container that is not yet
+				// created as not found.
+				err = handleContainerNotFoundError(err)
+			}
 			return errors.Trace(err)
 		case <-cancel:
 			cancel = nil
@@ -490,3 +546,16 @@ func getValidatedPodContainer(
 
 	return pod.Name, containerName, nil
 }
+
+// combinedWriter serialises the writes of the stdout and stderr
+// streams, which are copied concurrently, to a single writer.
+type combinedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (c *combinedWriter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.w.Write(p)
+}
@@ -5,6 +5,7 @@ package exec_test
 
 import (
 	"bytes"
+	"io"
 	"net/url"
 	"sync"
 	"time"
@@ -72,6 +73,15 @@ func (s *execSuite) TestExecParamsValidateCommandsAndPodName(c *gc.C) {
 			},
 			Err: `podName "pod/" not valid`,
 		},
+		{
+			Params: exec.ExecParams{
+				Commands: []string{"echo", "'hello world'"},
+				PodName:  "gitlab-k8s-uid",
+				Stdout:   &bytes.Buffer{},
+				Combined: &bytes.Buffer{},
+			},
+			Err: `combined output with Stdout, Stderr or TTY not valid`,
+		},
 	} {
 		c.Check(tc.Params.Validate(s.mockPodGetter), gc.ErrorMatches, tc.Err)
 	}
@@ -368,6 +378,91 @@ func (s *execSuite) TestExec(c *gc.C) {
 	}
 }
 
+func (s *execSuite) TestExecCombinedOutput(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	s.suiteMocks.EXPECT().RemoteCmdExecutorGetter(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(s.mockRemoteCmdExecutor, nil)
+
+	var combined bytes.Buffer
+	params := exec.ExecParams{
+		Commands: []string{"echo", "'hello world'"},
+		PodName:  "gitlab-k8s-uid",
+		Combined: &combined,
+	}
+	pod := core.Pod{
+		Spec: core.PodSpec{
+			Containers: []core.Container{
+				{Name: "gitlab-container"},
+			},
+		},
+		Status: core.PodStatus{
+			Phase: core.PodRunning,
+			ContainerStatuses: []core.ContainerStatus{
+				{Name: "gitlab-container", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+			},
+		},
+	}
+	pod.SetUID("gitlab-k8s-uid")
+	pod.SetName("gitlab-k8s-0")
+
+	request := rest.NewRequestWithClient(
+		&url.URL{Path: "/path/"},
+		"",
+		rest.ClientContentConfig{GroupVersion: core.SchemeGroupVersion},
+		nil,
+	).Resource("pods").Name("gitlab-k8s-0").Namespace("test").
+		SubResource("exec").Param("container", "gitlab-container").VersionedParams(
+		&core.PodExecOptions{
+			Container: "gitlab-container",
+			Command:   []string{""},
+			Stdout:    true,
+			Stderr:    true,
+			TTY:       false,
+		}, scheme.ParameterCodec)
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-uid", metav1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockPodGetter.EXPECT().List(gomock.Any(), metav1.ListOptions{}).
+			Return(&core.PodList{Items: []core.Pod{pod}}, nil),
+
+		s.restClient.EXPECT().Post().Return(request),
+		s.mockRemoteCmdExecutor.EXPECT().Stream(gomock.Any()).DoAndReturn(
+			func(opts remotecommand.StreamOptions) error {
+				c.Check(opts.Stdout, gc.Equals, opts.Stderr)
+				// The remote emits output on both streams, interleaved.
+				for _, w := range []struct {
+					out  io.Writer
+					data string
+				}{
+					{opts.Stdout, "out 1\n"},
+					{opts.Stderr, "err 1\n"},
+					{opts.Stdout, "out 2\n"},
+					{opts.Stderr, "err 2\n"},
+				} {
+					_, err := w.out.Write([]byte(w.data))
+					c.Check(err, jc.ErrorIsNil)
+				}
+				return nil
+			},
+		),
+	)
+
+	cancel := make(<-chan struct{}, 1)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- s.execClient.Exec(params, cancel)
+	}()
+
+	select {
+	case err := <-errChan:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.ShortWait):
+		c.Fatalf("timed out waiting for Exec return")
+	}
+	c.Assert(combined.String(), gc.Equals, "out 1\nerr 1\nout 2\nerr 2\n")
+}
+
 func (s *execSuite) TestExecCancel(c *gc.C) {
 	ctrl := s.setupExecClient(c)
 	defer ctrl.Finish()
@@ -476,6 +571,95 @@ func (s *execSuite) TestExecCancel(c *gc.C) {
 	}
 }
 
+func (s *execSuite) TestExecRetriesContainerCreating(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	s.suiteMocks.EXPECT().RemoteCmdExecutorGetter(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(s.mockRemoteCmdExecutor, nil)
+
+	var stdout bytes.Buffer
+	params := exec.ExecParams{
+		Commands:      []string{"echo", "'hello world'"},
+		PodName:       "gitlab-k8s-0",
+		ContainerName: "gitlab-container",
+		Stdout:        &stdout,
+	}
+	creating := core.Pod{
+		Spec: core.PodSpec{
+			Containers: []core.Container{
+				{Name: "gitlab-container"},
+			},
+		},
+		Status: core.PodStatus{
+			Phase: core.PodRunning,
+			ContainerStatuses: []core.ContainerStatus{
+				{Name: "gitlab-container", State: core.ContainerState{
+					Waiting: &core.ContainerStateWaiting{Reason: "ContainerCreating"},
+				}},
+			},
+		},
+	}
+	creating.SetName("gitlab-k8s-0")
+	running := creating
+	running.Status = core.PodStatus{
+		Phase: core.PodRunning,
+		ContainerStatuses: []core.ContainerStatus{
+			{Name: "gitlab-container", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+		},
+	}
+
+	request := rest.NewRequestWithClient(
+		&url.URL{Path: "/path/"},
+		"",
+		rest.ClientContentConfig{GroupVersion: core.SchemeGroupVersion},
+		nil,
+	)
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(&creating, nil),
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(&running, nil),
+		s.restClient.EXPECT().Post().Return(request),
+		s.mockRemoteCmdExecutor.EXPECT().Stream(gomock.Any()).Return(nil),
+	)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- s.execClient.Exec(params, nil)
+	}()
+
+	// The container is checked again after a delay.
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-errChan:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Exec return")
+	}
+}
+
+func (s *execSuite) TestExecPodNotFoundNotRetried(c *gc.C) {
+	ctrl := s.setupExecClient(c)
+	defer ctrl.Finish()
+
+	params := exec.ExecParams{
+		Commands: []string{"echo", "'hello world'"},
+		PodName:  "gitlab-k8s-0",
+	}
+	gomock.InOrder(
+		s.mockPodGetter.EXPECT().Get(gomock.Any(), "gitlab-k8s-0", metav1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockPodGetter.EXPECT().List(gomock.Any(), metav1.ListOptions{}).
+			Return(&core.PodList{}, nil),
+	)
+
+	err := s.execClient.Exec(params, nil)
+	c.Assert(err, gc.ErrorMatches, `pod "gitlab-k8s-0" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *execSuite) TestErrorHandling(c *gc.C) {
 	err := exec.HandleContainerNotFoundError(errors.New(`unable to upgrade connection: container not found ("mariadb-k8s")`))
 	c.Assert(err, gc.FitsTypeOf, &exec.ContainerNotRunningError{})
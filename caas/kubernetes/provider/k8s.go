@@ -1065,6 +1065,13 @@ func (k *kubernetesClient) ensureService(
 	if err := processConstraints(&workloadSpec.Pod.PodSpec, appName, params.Constraints); err != nil {
 		return errors.Trace(err)
 	}
+	// A grace period set in the charm's pod spec takes precedence
+	// over that from the application config.
+	if gracePeriod := config.GetInt(TerminationGracePeriodKey, 0); gracePeriod > 0 &&
+		workloadSpec.Pod.TerminationGracePeriodSeconds == nil {
+		seconds := int64(gracePeriod)
+		workloadSpec.Pod.TerminationGracePeriodSeconds = &seconds
+	}
 
 	for _, c := range params.PodSpec.Containers {
 		if c.ImageDetails.Password == "" {
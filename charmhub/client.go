@@ -81,6 +81,14 @@ type Config struct {
 	// and allow overriding existing headers.
 	Headers http.Header
 
+	// TransportOptions tune the HTTP transport used to send requests,
+	// such as the size of the idle connection pool.
+	TransportOptions []TransportOption
+
+	// ResponseMetrics, if not nil, records the sizes of the API
+	// responses received, before and after decompression.
+	ResponseMetrics ResponseMetrics
+
 	Logger Logger
 }
 
@@ -169,15 +177,26 @@ func (c Config) BasePath() (charmhubpath.Path, error) {
 	return charmhubpath.MakePath(url), nil
 }
 
+// RootPath returns the root path of the server, at which its metadata is
+// served.
+func (c Config) RootPath() (charmhubpath.Path, error) {
+	url, err := url.Parse(strings.TrimRight(c.URL, "/") + "/")
+	if err != nil {
+		return charmhubpath.Path{}, errors.Trace(err)
+	}
+	return charmhubpath.MakePath(url), nil
+}
+
 // Client represents the client side of a charm store.
 type Client struct {
-	url             string
-	infoClient      *InfoClient
-	findClient      *FindClient
-	downloadClient  *DownloadClient
-	refreshClient   *RefreshClient
-	resourcesClient *ResourcesClient
-	logger          Logger
+	url              string
+	infoClient       *InfoClient
+	findClient       *FindClient
+	downloadClient   *DownloadClient
+	refreshClient    *RefreshClient
+	resourcesClient  *ResourcesClient
+	serverInfoClient *ServerInfoClient
+	logger           Logger
 }
 
 // NewClient creates a new charmHub client from the supplied configuration.
@@ -214,23 +233,33 @@ func NewClientWithFileSystem(config Config, fileSystem FileSystem) (*Client, err
 		return nil, errors.Annotate(err, "constructing resources path")
 	}
 
+	rootPath, err := config.RootPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing root path")
+	}
+
 	config.Logger.Tracef("NewClient to %q", config.URL)
 
-	httpClient := DefaultHTTPTransport()
-	apiRequester := NewAPIRequester(httpClient, config.Logger)
+	httpClient := DefaultHTTPTransport(config.TransportOptions...)
+	apiRequester := NewAPIRequester(httpClient, config.Logger).WithMetrics(config.ResponseMetrics)
 	restClient := NewHTTPRESTClient(apiRequester, config.Headers)
 
+	// Info and refresh responses include the channel maps of
+	// charms, which may be large for charms with many revisions.
+	largeRESTClient := restClient.WithMaxResponseSize(LargeMaxResponseSize)
+
 	return &Client{
 		url:           base.String(),
-		infoClient:    NewInfoClient(infoPath, restClient, config.Logger),
+		infoClient:    NewInfoClient(infoPath, largeRESTClient, config.Logger),
 		findClient:    NewFindClient(findPath, restClient, config.Logger),
-		refreshClient: NewRefreshClient(refreshPath, restClient, config.Logger),
+		refreshClient: NewRefreshClient(refreshPath, largeRESTClient, config.Logger),
 		// download client doesn't require a path here, as the download could
 		// be from any server in theory. That information is found from the
 		// refresh response.
-		downloadClient:  NewDownloadClient(httpClient, fileSystem, config.Logger),
-		resourcesClient: NewResourcesClient(resourcesPath, restClient, config.Logger),
-		logger:          config.Logger,
+		downloadClient:   NewDownloadClient(httpClient, fileSystem, config.Logger),
+		resourcesClient:  NewResourcesClient(resourcesPath, restClient, config.Logger),
+		serverInfoClient: NewServerInfoClient(rootPath, restClient, config.Logger),
+		logger:           config.Logger,
 	}, nil
 }
 
@@ -279,3 +308,15 @@ func (c *Client) DownloadResource(ctx context.Context, resourceURL *url.URL) (r
 func (c *Client) ListResourceRevisions(ctx context.Context, charm, resource string) ([]transport.ResourceRevision, error) {
 	return c.resourcesClient.ListResourceRevisions(ctx, charm, resource)
 }
+
+// ResourceInfo returns the details of a single revision of the provided
+// charm resource.
+func (c *Client) ResourceInfo(ctx context.Context, charm, resource string, revision int) (transport.ResourceRevision, error) {
+	return c.resourcesClient.ResourceInfo(ctx, charm, resource, revision)
+}
+
+// ServerInfo returns the API version reported by the CharmHub server,
+// or an error if the server can not be reached.
+func (c *Client) ServerInfo(ctx context.Context) (transport.ServerInfoResponse, error) {
+	return c.serverInfoClient.ServerInfo(ctx)
+}
@@ -5,16 +5,24 @@ package charmhub
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
+	"mime"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
-	"gopkg.in/httprequest.v1"
+	"github.com/juju/retry"
+	"github.com/juju/utils/v2"
 
 	"github.com/juju/juju/charmhub/path"
 )
@@ -26,9 +34,91 @@ type Transport interface {
 	Do(*http.Request) (*http.Response, error)
 }
 
-// DefaultHTTPTransport creates a new HTTPTransport.
-func DefaultHTTPTransport() *http.Client {
-	return &http.Client{}
+const (
+	// DefaultMaxIdleConns is the default maximum number of idle
+	// connections kept open across all hosts.
+	DefaultMaxIdleConns = 100
+
+	// DefaultMaxIdleConnsPerHost is the default maximum number of idle
+	// connections kept open to a single host.
+	DefaultMaxIdleConnsPerHost = 10
+
+	// DefaultIdleConnTimeout is the default time an idle connection is
+	// kept open before being closed.
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// TransportOption customises the transport created by
+// DefaultHTTPTransport.
+type TransportOption func(*transportOptions)
+
+type transportOptions struct {
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept
+// open across all hosts.
+func WithMaxIdleConns(n int) TransportOption {
+	return func(options *transportOptions) {
+		options.maxIdleConns = n
+	}
+}
+
+// WithMaxIdleConnsPerHost sets the maximum number of idle connections
+// kept open to a single host.
+func WithMaxIdleConnsPerHost(n int) TransportOption {
+	return func(options *transportOptions) {
+		options.maxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets the time an idle connection is kept open
+// before being closed.
+func WithIdleConnTimeout(d time.Duration) TransportOption {
+	return func(options *transportOptions) {
+		options.idleConnTimeout = d
+	}
+}
+
+// DefaultHTTPTransport creates a new HTTPTransport. Idle connections
+// are pooled so that bulk requests to the same server reuse them; the
+// pool can be tuned with the input options.
+func DefaultHTTPTransport(options ...TransportOption) *http.Client {
+	opts := &transportOptions{
+		maxIdleConns:        DefaultMaxIdleConns,
+		maxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		idleConnTimeout:     DefaultIdleConnTimeout,
+	}
+	for _, option := range options {
+		option(opts)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = opts.maxIdleConns
+	transport.MaxIdleConnsPerHost = opts.maxIdleConnsPerHost
+	transport.IdleConnTimeout = opts.idleConnTimeout
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
+// acceptEncoding is the value of the Accept-Encoding header sent by an
+// APIRequester. Setting the header explicitly disables the transparent
+// decompression done by the standard library transport, so the
+// APIRequester decompresses response bodies itself.
+const acceptEncoding = "gzip, deflate"
+
+// ResponseMetrics records the sizes of response bodies
+// read from the server via an APIRequester.
+type ResponseMetrics interface {
+	// RecordResponseSize records the number of bytes of a response body
+	// received from the server, and the number of bytes that were read from
+	// it once decompressed according to the input content encoding, which
+	// is empty if the body was not compressed.
+	// It is called when the response body is closed.
+	RecordResponseSize(encoding string, received, decompressed int64)
 }
 
 // APIRequester creates a wrapper around the transport to allow for better
@@ -36,6 +126,7 @@ func DefaultHTTPTransport() *http.Client {
 type APIRequester struct {
 	transport Transport
 	logger    Logger
+	metrics   ResponseMetrics
 }
 
 // NewAPIRequester creates a new http.Client for making requests to a server.
@@ -46,9 +137,26 @@ func NewAPIRequester(transport Transport, logger Logger) *APIRequester {
 	}
 }
 
+// WithMetrics returns a copy of the requester that
+// records the sizes of response bodies with the input metrics.
+func (t *APIRequester) WithMetrics(metrics ResponseMetrics) *APIRequester {
+	requester := *t
+	requester.metrics = metrics
+	return &requester
+}
+
 // Do performs the *http.Request and returns a *http.Response or an error
 // if it fails to construct the transport.
+// Compressed responses are requested, and the body of the returned
+// response is decompressed as it is read.
 func (t *APIRequester) Do(req *http.Request) (*http.Response, error) {
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
 	if t.logger.IsTraceEnabled() {
 		if data, err := httputil.DumpRequest(req, true); err == nil {
 			t.logger.Tracef("%s request %s", req.Method, data)
@@ -61,6 +169,9 @@ func (t *APIRequester) Do(req *http.Request) (*http.Response, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The body is decoded before anything reads it, including
+	// the dumps of the response logged below.
+	t.decodeBody(resp)
 
 	if t.logger.IsTraceEnabled() {
 		if data, err := httputil.DumpResponse(resp, true); err == nil {
@@ -100,6 +211,103 @@ func (t *APIRequester) Do(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// decodeBody replaces the body of the input response with one that is
+// decompressed according to its content encoding. The content encoding
+// and length headers no longer apply to the body, so are removed.
+// Bodies with an encoding that was not requested are left as they are.
+func (t *APIRequester) decodeBody(resp *http.Response) {
+	if resp.Body == nil {
+		return
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	var decode func(io.Reader) (io.Reader, error)
+	switch encoding {
+	case "":
+	case "gzip", "x-gzip":
+		decode = func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }
+	case "deflate":
+		decode = func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) }
+	default:
+		t.logger.Debugf("not decoding response body with unexpected content encoding %q", encoding)
+		return
+	}
+
+	received := &countingReader{r: resp.Body}
+	body := &decodedBody{
+		body:     resp.Body,
+		received: received,
+		encoding: encoding,
+		metrics:  t.metrics,
+	}
+	if decode == nil {
+		body.decoded = received
+	} else {
+		body.decode = decode
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	resp.Body = body
+}
+
+// decodedBody is a response body that is decompressed as it is read.
+// The decompressor is created on the first read, so that closing an
+// unread body does not read it.
+type decodedBody struct {
+	body     io.Closer
+	received *countingReader
+	encoding string
+	metrics  ResponseMetrics
+
+	decode  func(io.Reader) (io.Reader, error)
+	decoded io.Reader
+	size    int64
+	closed  bool
+}
+
+// Read is part of the io.Reader interface.
+func (b *decodedBody) Read(p []byte) (int, error) {
+	if b.decoded == nil {
+		decoded, err := b.decode(b.received)
+		if err == io.EOF {
+			// An empty body has no compression header.
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, errors.Annotatef(err, "decoding %s response body", b.encoding)
+		}
+		b.decoded = decoded
+	}
+	n, err := b.decoded.Read(p)
+	b.size += int64(n)
+	return n, err
+}
+
+// Close is part of the io.Closer interface. The sizes of
+// the body are recorded when it is first closed.
+func (b *decodedBody) Close() error {
+	if !b.closed && b.metrics != nil {
+		b.metrics.RecordResponseSize(b.encoding, b.received.n, b.size)
+	}
+	b.closed = true
+	return b.body.Close()
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read is part of the io.Reader interface.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
 // RESTResponse abstracts away the underlying response from the implementation.
 type RESTResponse struct {
 	StatusCode int
@@ -113,21 +321,68 @@ type RESTClient interface {
 	Post(context.Context, path.Path, http.Header, interface{}, interface{}) (RESTResponse, error)
 }
 
+// IdempotencyKeyHeader is the header used to identify a logical POST
+// request, so that the server can discard duplicates sent when the request
+// is retried.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	// defaultPostAttempts is the number of times a POST request is sent
+	// when it fails with a network error.
+	defaultPostAttempts = 3
+
+	// defaultPostRetryDelay is the time to wait between POST attempts.
+	defaultPostRetryDelay = time.Second
+)
+
+const (
+	// DefaultMaxResponseSize is the default maximum size, in bytes, of a
+	// response body read by a HTTPRESTClient. It is sufficient for the
+	// metadata returned by most endpoints.
+	DefaultMaxResponseSize = 4 * 1024 * 1024
+
+	// LargeMaxResponseSize is the maximum size, in bytes, of a response
+	// body for endpoints that may legitimately return large responses,
+	// such as the channel maps of charms with many revisions.
+	LargeMaxResponseSize = 64 * 1024 * 1024
+)
+
+// ErrResponseTooLarge is returned when the body of a response
+// exceeds the maximum size accepted by a HTTPRESTClient.
+var ErrResponseTooLarge = errors.New("response body too large")
+
 // HTTPRESTClient represents a RESTClient that expects to interact with a
 // HTTP transport.
 type HTTPRESTClient struct {
 	transport Transport
 	headers   http.Header
+
+	clock           clock.Clock
+	postAttempts    int
+	postRetryDelay  time.Duration
+	maxResponseSize int64
 }
 
 // NewHTTPRESTClient creates a new HTTPRESTClient
 func NewHTTPRESTClient(transport Transport, headers http.Header) *HTTPRESTClient {
 	return &HTTPRESTClient{
-		transport: transport,
-		headers:   headers,
+		transport:       transport,
+		headers:         headers,
+		clock:           clock.WallClock,
+		postAttempts:    defaultPostAttempts,
+		postRetryDelay:  defaultPostRetryDelay,
+		maxResponseSize: DefaultMaxResponseSize,
 	}
 }
 
+// WithMaxResponseSize returns a copy of the client that accepts response
+// bodies of up to the input number of bytes.
+func (c *HTTPRESTClient) WithMaxResponseSize(size int64) *HTTPRESTClient {
+	client := *c
+	client.maxResponseSize = size
+	return &client
+}
+
 // Get makes a GET request to the given path in the CharmHub (not
 // including the host name or version prefix but including a leading /),
 // parsing the result as JSON into the given result value, which should
@@ -153,7 +408,7 @@ func (c *HTTPRESTClient) Get(ctx context.Context, path path.Path, result interfa
 	defer func() { _ = resp.Body.Close() }()
 
 	// Parse the response.
-	if err := httprequest.UnmarshalJSONResponse(resp, result); err != nil {
+	if err := c.decodeJSONResponse(resp, result); err != nil {
 		return RESTResponse{}, errors.Annotate(err, "charm hub client get")
 	}
 
@@ -167,15 +422,67 @@ func (c *HTTPRESTClient) Get(ctx context.Context, path path.Path, result interfa
 // parsing the result as JSON into the given result value, which should
 // be a pointer to the expected data, but may be nil if no result is
 // desired.
+// Requests failing with a network error are retried. Every attempt
+// carries the same idempotency key, so that the server can recognise
+// retries of a request it has already processed.
 func (c *HTTPRESTClient) Post(ctx context.Context, path path.Path, headers http.Header, body, result interface{}) (RESTResponse, error) {
 	buffer := new(bytes.Buffer)
 	if err := json.NewEncoder(buffer).Encode(body); err != nil {
 		return RESTResponse{}, errors.Trace(err)
 	}
+	data := buffer.Bytes()
 
-	req, err := http.NewRequestWithContext(ctx, "POST", path.String(), buffer)
+	key, err := utils.NewUUID()
 	if err != nil {
-		return RESTResponse{}, errors.Annotate(err, "can not make new request")
+		return RESTResponse{}, errors.Trace(err)
+	}
+
+	var (
+		resp    *http.Response
+		lastErr error
+	)
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			req, err := c.newPostRequest(ctx, path, headers, key.String(), data)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			resp, lastErr = c.transport.Do(req)
+			return errors.Trace(lastErr)
+		},
+		IsFatalError: func(err error) bool {
+			return !isNetworkError(err)
+		},
+		Attempts: c.postAttempts,
+		Delay:    c.postRetryDelay,
+		Clock:    c.clock,
+		Stop:     ctx.Done(),
+	})
+	if retry.IsAttemptsExceeded(err) {
+		return RESTResponse{}, errors.Annotate(lastErr, "failed after retrying")
+	}
+	if err != nil {
+		return RESTResponse{}, errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Parse the response.
+	if err := c.decodeJSONResponse(resp, result); err != nil {
+		return RESTResponse{}, errors.Annotate(err, "charm hub client post")
+	}
+	return RESTResponse{
+		StatusCode: resp.StatusCode,
+	}, nil
+}
+
+// newPostRequest creates a single attempt at a POST request, carrying
+// the input idempotency key.
+func (c *HTTPRESTClient) newPostRequest(
+	ctx context.Context, path path.Path, headers http.Header, key string, data []byte,
+) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", path.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Annotate(err, "can not make new request")
 	}
 
 	// Compose the request headers.
@@ -183,6 +490,7 @@ func (c *HTTPRESTClient) Post(ctx context.Context, path path.Path, headers http.
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header = c.composeHeaders(req.Header)
+	req.Header.Set(IdempotencyKeyHeader, key)
 
 	// Add any headers specific to this request (in sorted order).
 	keys := make([]string, 0, len(headers))
@@ -195,20 +503,39 @@ func (c *HTTPRESTClient) Post(ctx context.Context, path path.Path, headers http.
 			req.Header.Add(k, v)
 		}
 	}
+	return req, nil
+}
 
-	resp, err := c.transport.Do(req)
-	if err != nil {
-		return RESTResponse{}, errors.Trace(err)
+// decodeJSONResponse decodes the JSON body of the input response into the
+// input result value, which may be nil if no result is desired. The body
+// is decoded as it is read, and no more than the client's maximum
+// response size is read; ErrResponseTooLarge is returned if the body
+// exceeds that size.
+func (c *HTTPRESTClient) decodeJSONResponse(resp *http.Response, result interface{}) error {
+	if result == nil {
+		return nil
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || mediaType != "application/json" {
+		return errors.Errorf("unexpected content-type from server %q", contentType)
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	// Parse the response.
-	if err := httprequest.UnmarshalJSONResponse(resp, result); err != nil {
-		return RESTResponse{}, errors.Annotate(err, "charm hub client post")
+	// Read one byte beyond the limit, so that a body of exactly
+	// the maximum size can be distinguished from a larger one.
+	body := &io.LimitedReader{R: resp.Body, N: c.maxResponseSize + 1}
+	err := json.NewDecoder(body).Decode(result)
+	if body.N <= 0 {
+		return errors.Trace(ErrResponseTooLarge)
 	}
-	return RESTResponse{
-		StatusCode: resp.StatusCode,
-	}, nil
+	return errors.Annotate(err, "decoding response body")
+}
+
+// isNetworkError returns true if the input error indicates that the
+// request did not complete due to a failure in the network, rather than
+// an error response from the server.
+func isNetworkError(err error) bool {
+	_, ok := errors.Cause(err).(net.Error)
+	return ok
 }
 
 // composeHeaders creates a new set of headers from scratch.
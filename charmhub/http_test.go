@@ -5,8 +5,16 @@ package charmhub
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/errors"
@@ -79,6 +87,107 @@ func (s *APIRequesterSuite) TestDoWithNotFoundResponse(c *gc.C) {
 	c.Assert(resp.StatusCode, gc.Equals, http.StatusNotFound)
 }
 
+func (s *APIRequesterSuite) TestDefaultHTTPTransport(c *gc.C) {
+	client := DefaultHTTPTransport()
+	transport, ok := client.Transport.(*http.Transport)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(transport.MaxIdleConns, gc.Equals, DefaultMaxIdleConns)
+	c.Check(transport.MaxIdleConnsPerHost, gc.Equals, DefaultMaxIdleConnsPerHost)
+	c.Check(transport.IdleConnTimeout, gc.Equals, DefaultIdleConnTimeout)
+	c.Check(transport.Proxy, gc.NotNil)
+}
+
+func (s *APIRequesterSuite) TestDefaultHTTPTransportWithOptions(c *gc.C) {
+	client := DefaultHTTPTransport(
+		WithMaxIdleConns(50),
+		WithMaxIdleConnsPerHost(25),
+		WithIdleConnTimeout(time.Minute),
+	)
+	transport, ok := client.Transport.(*http.Transport)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(transport.MaxIdleConns, gc.Equals, 50)
+	c.Check(transport.MaxIdleConnsPerHost, gc.Equals, 25)
+	c.Check(transport.IdleConnTimeout, gc.Equals, time.Minute)
+
+	// The shared default transport is left untouched.
+	c.Check(http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost, gc.Equals, 0)
+}
+
+func (s *APIRequesterSuite) TestDoDecodesGzipResponse(c *gc.C) {
+	s.assertDecodesResponse(c, "gzip", func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) })
+}
+
+func (s *APIRequesterSuite) TestDoDecodesDeflateResponse(c *gc.C) {
+	s.assertDecodesResponse(c, "deflate", func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) })
+}
+
+func (s *APIRequesterSuite) assertDecodesResponse(c *gc.C, encoding string, compress func(io.Writer) io.WriteCloser) {
+	data := strings.Repeat("x", 4096)
+	body := fmt.Sprintf(`{"data": %q}`, data)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Header.Get("Accept-Encoding"), gc.Equals, "gzip, deflate")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", encoding)
+		cw := compress(w)
+		_, _ = io.WriteString(cw, body)
+		_ = cw.Close()
+	}))
+	defer server.Close()
+
+	metrics := &recordingResponseMetrics{}
+	requester := NewAPIRequester(server.Client(), &FakeLogger{}).WithMetrics(metrics)
+	client := NewHTTPRESTClient(requester, nil)
+
+	var result map[string]string
+	_, err := client.Get(context.TODO(), MustMakePath(c, server.URL), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result["data"], gc.Equals, data)
+
+	c.Assert(metrics.records, gc.HasLen, 1)
+	record := metrics.records[0]
+	c.Check(record.encoding, gc.Equals, encoding)
+	c.Check(record.decompressed, gc.Equals, int64(len(body)))
+	c.Check(record.received < record.decompressed, jc.IsTrue)
+}
+
+func (s *APIRequesterSuite) TestDoRecordsUncompressedResponse(c *gc.C) {
+	server := newLargeResponseServer(16)
+	defer server.Close()
+
+	metrics := &recordingResponseMetrics{}
+	requester := NewAPIRequester(server.Client(), &FakeLogger{}).WithMetrics(metrics)
+	client := NewHTTPRESTClient(requester, nil)
+
+	var result map[string]string
+	_, err := client.Get(context.TODO(), MustMakePath(c, server.URL), &result)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(metrics.records, gc.HasLen, 1)
+	c.Check(metrics.records[0].encoding, gc.Equals, "")
+	c.Check(metrics.records[0].received, gc.Equals, metrics.records[0].decompressed)
+}
+
+func (s *APIRequesterSuite) TestDoDecodesErrorResponse(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusInternalServerError)
+		gw := gzip.NewWriter(w)
+		_, _ = io.WriteString(gw, "something went wrong")
+		_ = gw.Close()
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	requester := NewAPIRequester(server.Client(), logger)
+	_, err := requester.Do(MustNewRequest(c, server.URL))
+	c.Assert(err, gc.ErrorMatches, `unexpected content-type from server "text/plain"`)
+
+	// The logged response is decompressed.
+	c.Assert(logger.errors, gc.HasLen, 1)
+	c.Check(logger.errors[0], jc.Contains, "something went wrong")
+}
+
 type RESTSuite struct {
 	testing.IsolationSuite
 }
@@ -172,6 +281,150 @@ func (s *RESTSuite) TestComposeHeaders(c *gc.C) {
 	})
 }
 
+func (s *RESTSuite) TestPostRetriesWithSameIdempotencyKey(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	var keys []string
+	recordKey := func(req *http.Request) {
+		keys = append(keys, req.Header.Get(IdempotencyKeyHeader))
+	}
+	networkErr := &url.Error{Op: "Post", URL: "http://api.foo.bar", Err: errors.New("connection reset by peer")}
+
+	mockTransport := NewMockTransport(ctrl)
+	gomock.InOrder(
+		mockTransport.EXPECT().Do(gomock.Any()).Do(recordKey).Return(nil, networkErr),
+		mockTransport.EXPECT().Do(gomock.Any()).Do(recordKey).Return(emptyResponse(), nil),
+		mockTransport.EXPECT().Do(gomock.Any()).Do(recordKey).Return(nil, networkErr),
+		mockTransport.EXPECT().Do(gomock.Any()).Do(recordKey).Return(emptyResponse(), nil),
+	)
+
+	client := NewHTTPRESTClient(mockTransport, nil)
+	client.postRetryDelay = time.Millisecond
+
+	base := MustMakePath(c, "http://api.foo.bar")
+
+	var result interface{}
+	_, err := client.Post(context.TODO(), base, nil, struct{}{}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.Post(context.TODO(), base, nil, struct{}{}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(keys, gc.HasLen, 4)
+	c.Check(keys[0], gc.Not(gc.Equals), "")
+	c.Check(keys[1], gc.Equals, keys[0])
+	c.Check(keys[2], gc.Not(gc.Equals), keys[0])
+	c.Check(keys[3], gc.Equals, keys[2])
+}
+
+func (s *RESTSuite) TestPostRetriesExhausted(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	networkErr := &url.Error{Op: "Post", URL: "http://api.foo.bar", Err: errors.New("connection reset by peer")}
+
+	mockTransport := NewMockTransport(ctrl)
+	mockTransport.EXPECT().Do(gomock.Any()).Return(nil, networkErr).Times(3)
+
+	client := NewHTTPRESTClient(mockTransport, nil)
+	client.postRetryDelay = time.Millisecond
+
+	base := MustMakePath(c, "http://api.foo.bar")
+
+	var result interface{}
+	_, err := client.Post(context.TODO(), base, nil, struct{}{}, &result)
+	c.Assert(err, gc.ErrorMatches, `failed after retrying: Post "?http://api.foo.bar"?: connection reset by peer`)
+}
+
+func (s *RESTSuite) TestPostDoesNotRetryServerErrors(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockTransport := NewMockTransport(ctrl)
+	mockTransport.EXPECT().Do(gomock.Any()).Return(nil, errors.Errorf("boom"))
+
+	client := NewHTTPRESTClient(mockTransport, nil)
+	client.postRetryDelay = time.Millisecond
+
+	base := MustMakePath(c, "http://api.foo.bar")
+
+	var result interface{}
+	_, err := client.Post(context.TODO(), base, nil, struct{}{}, &result)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *RESTSuite) TestGetResponseTooLarge(c *gc.C) {
+	server := newLargeResponseServer(4096)
+	defer server.Close()
+
+	client := NewHTTPRESTClient(server.Client(), nil).WithMaxResponseSize(1024)
+
+	var result map[string]string
+	_, err := client.Get(context.TODO(), MustMakePath(c, server.URL), &result)
+	c.Assert(errors.Cause(err), gc.Equals, ErrResponseTooLarge)
+}
+
+func (s *RESTSuite) TestGetResponseWithinLimit(c *gc.C) {
+	server := newLargeResponseServer(4096)
+	defer server.Close()
+
+	client := NewHTTPRESTClient(server.Client(), nil).WithMaxResponseSize(8192)
+
+	var result map[string]string
+	_, err := client.Get(context.TODO(), MustMakePath(c, server.URL), &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result["data"], gc.HasLen, 4096)
+}
+
+func (s *RESTSuite) TestPostResponseTooLarge(c *gc.C) {
+	server := newLargeResponseServer(DefaultMaxResponseSize)
+	defer server.Close()
+
+	client := NewHTTPRESTClient(server.Client(), nil)
+
+	var result map[string]string
+	_, err := client.Post(context.TODO(), MustMakePath(c, server.URL), nil, struct{}{}, &result)
+	c.Assert(errors.Cause(err), gc.Equals, ErrResponseTooLarge)
+}
+
+// newLargeResponseServer returns a server responding to all requests
+// with a JSON object holding a string of the input size.
+func newLargeResponseServer(size int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data": %q}`, strings.Repeat("x", size))
+	}))
+}
+
+type responseSizeRecord struct {
+	encoding     string
+	received     int64
+	decompressed int64
+}
+
+// recordingResponseMetrics is a ResponseMetrics recording the response sizes.
+type recordingResponseMetrics struct {
+	records []responseSizeRecord
+}
+
+func (m *recordingResponseMetrics) RecordResponseSize(encoding string, received, decompressed int64) {
+	m.records = append(m.records, responseSizeRecord{
+		encoding:     encoding,
+		received:     received,
+		decompressed: decompressed,
+	})
+}
+
+// recordingLogger is a Logger recording the errors logged.
+type recordingLogger struct {
+	FakeLogger
+	errors []string
+}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.errors = append(l.errors, fmt.Sprintf(format, args...))
+}
+
 func emptyResponse() *http.Response {
 	return &http.Response{
 		Header:     MakeContentTypeHeader("application/json"),
@@ -0,0 +1,225 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+// The results of a lookup in an InfoCache, as recorded by InfoCacheMetrics.
+const (
+	// InfoCacheHit is recorded when a lookup is served from the cache.
+	InfoCacheHit = "hit"
+
+	// InfoCacheMiss is recorded when a lookup is sent to charmhub.
+	InfoCacheMiss = "miss"
+
+	// InfoCacheShared is recorded when a lookup shares the result of an
+	// identical request to charmhub that was already in progress.
+	InfoCacheShared = "shared"
+)
+
+// InfoGetter is the part of the charmhub client used by an InfoCache.
+type InfoGetter interface {
+	URL() string
+	Info(ctx context.Context, name string, options ...InfoOption) (transport.InfoResponse, error)
+}
+
+// InfoCacheMetrics records the results of lookups in an InfoCache.
+type InfoCacheMetrics interface {
+	// RecordLookup records a lookup with the
+	// input result, such as InfoCacheHit.
+	RecordLookup(result string)
+}
+
+// InfoCacheConfig holds the configuration for an InfoCache.
+type InfoCacheConfig struct {
+	// Clock is used to expire cached responses.
+	Clock clock.Clock
+
+	// TTL is how long responses are cached for.
+	TTL time.Duration
+
+	// MaxEntries is the maximum number of responses
+	// that are cached. The oldest responses are evicted
+	// to make room for new ones.
+	MaxEntries int
+
+	// Metrics, if not nil, records the results of lookups.
+	Metrics InfoCacheMetrics
+}
+
+// Validate returns an error if the config is not valid.
+func (config InfoCacheConfig) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.TTL <= 0 {
+		return errors.NotValidf("non-positive TTL")
+	}
+	if config.MaxEntries <= 0 {
+		return errors.NotValidf("non-positive MaxEntries")
+	}
+	return nil
+}
+
+// InfoCache caches charmhub info responses, so that they may be shared
+// by the clients for many models. Responses are keyed by the charmhub
+// URL as well as the charm and channel, so a change to a model's
+// charmhub URL means that responses cached for the old URL are no
+// longer used for it. Concurrent lookups of the same key result in a
+// single request to charmhub, made with the context of the first caller.
+// If that caller's context is cancelled, the other callers retry the
+// request with their own contexts. Errors are not cached.
+//
+// InfoCache is safe for concurrent use.
+type InfoCache struct {
+	config InfoCacheConfig
+
+	mu       sync.Mutex
+	entries  map[infoCacheKey]infoCacheEntry
+	inFlight map[infoCacheKey]*infoCall
+}
+
+// infoCacheKey identifies an info request.
+type infoCacheKey struct {
+	url     string
+	name    string
+	channel string
+}
+
+// infoCacheEntry is a cached info response.
+type infoCacheEntry struct {
+	response transport.InfoResponse
+	fetched  time.Time
+}
+
+// infoCall is an info request to charmhub that is in progress.
+// Its result is available once done is closed.
+type infoCall struct {
+	done     chan struct{}
+	response transport.InfoResponse
+	err      error
+
+	// cancelled is true if the request failed because the
+	// context of the caller that made it was done.
+	cancelled bool
+}
+
+// NewInfoCache returns a new empty InfoCache.
+func NewInfoCache(config InfoCacheConfig) (*InfoCache, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &InfoCache{
+		config:   config,
+		entries:  make(map[infoCacheKey]infoCacheEntry),
+		inFlight: make(map[infoCacheKey]*infoCall),
+	}, nil
+}
+
+// Info returns the info response for the named charm in the input
+// channel, which may be empty, from the client's charmhub. The response
+// may be shared with other callers, so must not be modified.
+func (c *InfoCache) Info(ctx context.Context, client InfoGetter, name, channel string) (transport.InfoResponse, error) {
+	key := infoCacheKey{url: client.URL(), name: name, channel: channel}
+
+	for {
+		c.mu.Lock()
+		if entry, ok := c.entries[key]; ok {
+			if c.config.Clock.Now().Sub(entry.fetched) < c.config.TTL {
+				c.mu.Unlock()
+				c.record(InfoCacheHit)
+				return entry.response, nil
+			}
+			delete(c.entries, key)
+		}
+		call, ok := c.inFlight[key]
+		if !ok {
+			return c.fetch(ctx, client, key)
+		}
+		c.mu.Unlock()
+		c.record(InfoCacheShared)
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return transport.InfoResponse{}, errors.Trace(ctx.Err())
+		}
+		// A request abandoned by its caller says nothing about the
+		// charm, so try again unless this caller has also gone away.
+		if !call.cancelled || ctx.Err() != nil {
+			return call.response, errors.Trace(call.err)
+		}
+	}
+}
+
+// fetch requests the info response for the input key from charmhub,
+// sharing the result with concurrent lookups of the same key. It must
+// be called with the mutex held, and releases it.
+func (c *InfoCache) fetch(ctx context.Context, client InfoGetter, key infoCacheKey) (transport.InfoResponse, error) {
+	call := &infoCall{done: make(chan struct{})}
+	c.inFlight[key] = call
+	c.mu.Unlock()
+	c.record(InfoCacheMiss)
+
+	var options []InfoOption
+	if key.channel != "" {
+		options = append(options, WithChannel(key.channel))
+	}
+	call.response, call.err = client.Info(ctx, key.name, options...)
+	call.cancelled = call.err != nil && ctx.Err() != nil
+
+	c.mu.Lock()
+	delete(c.inFlight, key)
+	if call.err == nil {
+		c.add(key, call.response)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.response, errors.Trace(call.err)
+}
+
+// add caches the input response, evicting expired entries, or failing
+// that the oldest entry, if the cache is full. It must be called with
+// the mutex held.
+func (c *InfoCache) add(key infoCacheKey, response transport.InfoResponse) {
+	now := c.config.Clock.Now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.config.MaxEntries {
+		var oldestKey infoCacheKey
+		var oldest time.Time
+		for k, entry := range c.entries {
+			if now.Sub(entry.fetched) >= c.config.TTL {
+				delete(c.entries, k)
+				continue
+			}
+			if oldest.IsZero() || entry.fetched.Before(oldest) {
+				oldestKey, oldest = k, entry.fetched
+			}
+		}
+		if len(c.entries) >= c.config.MaxEntries {
+			delete(c.entries, oldestKey)
+		}
+	}
+	c.entries[key] = infoCacheEntry{response: response, fetched: now}
+}
+
+// Len returns the number of responses in the cache.
+func (c *InfoCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *InfoCache) record(result string) {
+	if c.config.Metrics != nil {
+		c.config.Metrics.RecordLookup(result)
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+// longWait is how long the tests wait for concurrent lookups.
+const longWait = 10 * time.Second
+
+type InfoCacheSuite struct {
+	clock   *testclock.Clock
+	metrics *recordingMetrics
+	client  *fakeInfoGetter
+}
+
+var _ = gc.Suite(&InfoCacheSuite{})
+
+func (s *InfoCacheSuite) SetUpTest(c *gc.C) {
+	s.clock = testclock.NewClock(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	s.metrics = &recordingMetrics{}
+	s.client = &fakeInfoGetter{url: "https://api.charmhub.io"}
+}
+
+func (s *InfoCacheSuite) newCache(c *gc.C, maxEntries int) *InfoCache {
+	cache, err := NewInfoCache(InfoCacheConfig{
+		Clock:      s.clock,
+		TTL:        time.Minute,
+		MaxEntries: maxEntries,
+		Metrics:    s.metrics,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return cache
+}
+
+func (s *InfoCacheSuite) TestValidate(c *gc.C) {
+	_, err := NewInfoCache(InfoCacheConfig{TTL: time.Minute, MaxEntries: 1})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+	_, err = NewInfoCache(InfoCacheConfig{Clock: s.clock, MaxEntries: 1})
+	c.Check(err, gc.ErrorMatches, "non-positive TTL not valid")
+	_, err = NewInfoCache(InfoCacheConfig{Clock: s.clock, TTL: time.Minute})
+	c.Check(err, gc.ErrorMatches, "non-positive MaxEntries not valid")
+}
+
+func (s *InfoCacheSuite) TestInfoCached(c *gc.C) {
+	cache := s.newCache(c, 10)
+
+	for i := 0; i < 2; i++ {
+		resp, err := cache.Info(context.Background(), s.client, "wordpress", "stable")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(resp.Name, gc.Equals, "wordpress")
+	}
+	c.Check(s.client.requests(), jc.DeepEquals, []string{"wordpress@stable"})
+	c.Check(s.metrics.lookups(), jc.DeepEquals, []string{InfoCacheMiss, InfoCacheHit})
+}
+
+func (s *InfoCacheSuite) TestInfoKeyedByChannelAndURL(c *gc.C) {
+	cache := s.newCache(c, 10)
+
+	_, err := cache.Info(context.Background(), s.client, "wordpress", "stable")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cache.Info(context.Background(), s.client, "wordpress", "edge")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Changing the charmhub URL means cached responses are not used.
+	s.client.url = "https://charmhub.example.com"
+	_, err = cache.Info(context.Background(), s.client, "wordpress", "stable")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.client.requests(), jc.DeepEquals, []string{
+		"wordpress@stable", "wordpress@edge", "wordpress@stable",
+	})
+}
+
+func (s *InfoCacheSuite) TestInfoExpires(c *gc.C) {
+	cache := s.newCache(c, 10)
+
+	_, err := cache.Info(context.Background(), s.client, "wordpress", "")
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Minute)
+	_, err = cache.Info(context.Background(), s.client, "wordpress", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.client.requests(), jc.DeepEquals, []string{"wordpress@", "wordpress@"})
+}
+
+func (s *InfoCacheSuite) TestInfoErrorNotCached(c *gc.C) {
+	cache := s.newCache(c, 10)
+
+	s.client.err = errors.NotFoundf("charm")
+	_, err := cache.Info(context.Background(), s.client, "wordpress", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.client.err = nil
+	_, err = cache.Info(context.Background(), s.client, "wordpress", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cache.Len(), gc.Equals, 1)
+	c.Check(s.client.requests(), gc.HasLen, 2)
+}
+
+func (s *InfoCacheSuite) TestInfoBounded(c *gc.C) {
+	cache := s.newCache(c, 2)
+
+	for _, name := range []string{"one", "two", "three"} {
+		_, err := cache.Info(context.Background(), s.client, name, "")
+		c.Assert(err, jc.ErrorIsNil)
+		s.clock.Advance(time.Second)
+	}
+	c.Check(cache.Len(), gc.Equals, 2)
+
+	// The oldest response was evicted.
+	_, err := cache.Info(context.Background(), s.client, "three", "")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cache.Info(context.Background(), s.client, "one", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.requests(), jc.DeepEquals, []string{"one@", "two@", "three@", "one@"})
+}
+
+func (s *InfoCacheSuite) TestInfoConcurrentRequestsDeduplicated(c *gc.C) {
+	cache := s.newCache(c, 10)
+	s.client.started = make(chan struct{}, 1)
+	s.client.release = make(chan struct{})
+
+	const callers = 5
+	var wg sync.WaitGroup
+	results := make(chan error, callers)
+	call := func() {
+		defer wg.Done()
+		_, err := cache.Info(context.Background(), s.client, "wordpress", "stable")
+		results <- err
+	}
+	wg.Add(1)
+	go call()
+	select {
+	case <-s.client.started:
+	case <-time.After(longWait):
+		c.Fatalf("request not sent to charmhub")
+	}
+
+	// The remaining lookups wait for the request in progress.
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go call()
+	}
+	timeout := time.After(longWait)
+	for s.metrics.count(InfoCacheShared) < callers-1 {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			c.Fatalf("lookups not shared")
+		}
+	}
+	close(s.client.release)
+	wg.Wait()
+	close(results)
+
+	for err := range results {
+		c.Check(err, jc.ErrorIsNil)
+	}
+	c.Check(s.client.requests(), jc.DeepEquals, []string{"wordpress@stable"})
+}
+
+func (s *InfoCacheSuite) TestInfoSharedRequestCancelled(c *gc.C) {
+	cache := s.newCache(c, 10)
+	s.client.started = make(chan struct{}, 1)
+	s.client.release = make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first := make(chan error, 1)
+	go func() {
+		_, err := cache.Info(ctx, s.client, "wordpress", "stable")
+		first <- err
+	}()
+	select {
+	case <-s.client.started:
+	case <-time.After(longWait):
+		c.Fatalf("request not sent to charmhub")
+	}
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := cache.Info(context.Background(), s.client, "wordpress", "stable")
+		second <- err
+	}()
+	timeout := time.After(longWait)
+	for s.metrics.count(InfoCacheShared) < 1 {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			c.Fatalf("lookup not shared")
+		}
+	}
+
+	// Cancelling the first caller's context fails only its lookup;
+	// the waiting caller makes the request again.
+	cancel()
+	select {
+	case err := <-first:
+		c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+	case <-time.After(longWait):
+		c.Fatalf("first lookup not cancelled")
+	}
+	select {
+	case <-s.client.started:
+	case <-time.After(longWait):
+		c.Fatalf("request not retried")
+	}
+	close(s.client.release)
+	select {
+	case err := <-second:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(longWait):
+		c.Fatalf("second lookup not completed")
+	}
+	c.Check(s.client.requests(), jc.DeepEquals, []string{"wordpress@stable", "wordpress@stable"})
+	c.Check(cache.Len(), gc.Equals, 1)
+}
+
+// fakeInfoGetter is an InfoGetter recording the requests made.
+type fakeInfoGetter struct {
+	url string
+	err error
+
+	// started, if not nil, is sent a value when a request is made,
+	// which then blocks until release is closed or the request's
+	// context is done.
+	started chan struct{}
+	release chan struct{}
+
+	mu   sync.Mutex
+	reqs []string
+}
+
+func (f *fakeInfoGetter) URL() string {
+	return f.url
+}
+
+func (f *fakeInfoGetter) Info(ctx context.Context, name string, options ...InfoOption) (transport.InfoResponse, error) {
+	opts := newInfoOptions()
+	for _, option := range options {
+		option(opts)
+	}
+	var channel string
+	if opts.channel != nil {
+		channel = *opts.channel
+	}
+	f.mu.Lock()
+	f.reqs = append(f.reqs, name+"@"+channel)
+	f.mu.Unlock()
+
+	if f.started != nil {
+		f.started <- struct{}{}
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return transport.InfoResponse{}, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return transport.InfoResponse{}, f.err
+	}
+	return transport.InfoResponse{Name: name}, nil
+}
+
+func (f *fakeInfoGetter) requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.reqs...)
+}
+
+// recordingMetrics is an InfoCacheMetrics recording the lookups.
+type recordingMetrics struct {
+	mu      sync.Mutex
+	results []string
+}
+
+func (m *recordingMetrics) RecordLookup(result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results = append(m.results, result)
+}
+
+func (m *recordingMetrics) lookups() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.results...)
+}
+
+func (m *recordingMetrics) count(result string) int {
+	count := 0
+	for _, r := range m.lookups() {
+		if r == result {
+			count++
+		}
+	}
+	return count
+}
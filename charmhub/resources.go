@@ -44,12 +44,32 @@ func (c *ResourcesClient) ListResourceRevisions(ctx context.Context, charm, reso
 		return nil, errors.Trace(err)
 	}
 	if restResp.StatusCode == http.StatusNotFound {
-		return nil, errors.NotFoundf("%q for %q", charm, resource)
+		return nil, errors.NotFoundf("resource %q for charm %q", resource, charm)
+	}
+	if resultErr := resp.ErrorList.Combine(); resultErr != nil {
+		return nil, resultErr
 	}
 	c.logger.Tracef("ListResourceRevisions(%s, %s) unmarshalled: %s", charm, resource, pretty.Sprint(resp.Revisions))
 	return resp.Revisions, nil
 }
 
+// ResourceInfo returns the details of the given revision of the provided
+// resource of the given charm. A NotFound error is returned if either the
+// resource or the revision is unknown.
+func (c *ResourcesClient) ResourceInfo(ctx context.Context, charm, resource string, revision int) (transport.ResourceRevision, error) {
+	c.logger.Tracef("ResourceInfo(%s, %s, %d)", charm, resource, revision)
+	revisions, err := c.ListResourceRevisions(ctx, charm, resource)
+	if err != nil {
+		return transport.ResourceRevision{}, errors.Trace(err)
+	}
+	for _, rev := range revisions {
+		if rev.Revision == revision {
+			return rev, nil
+		}
+	}
+	return transport.ResourceRevision{}, errors.NotFoundf("revision %d of resource %q for charm %q", revision, resource, charm)
+}
+
 var resourceFilter = []string{
 	"download.hash-sha256",
 	"download.hash-sha3-384",
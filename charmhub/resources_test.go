@@ -62,6 +62,62 @@ func (s *ResourcesSuite) TestListResourceRevisionsFailure(c *gc.C) {
 	c.Assert(err, gc.Not(jc.ErrorIsNil))
 }
 
+func (s *ResourcesSuite) TestListResourceRevisionsNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	path := path.MakePath(MustParseURL(c, "http://api.foo.bar"))
+
+	restClient := NewMockRESTClient(ctrl)
+	restClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(RESTResponse{StatusCode: http.StatusNotFound}, nil)
+
+	client := NewResourcesClient(path, restClient, &FakeLogger{})
+	_, err := client.ListResourceRevisions(context.TODO(), "meshuggah", "unknown")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `resource "unknown" for charm "meshuggah" not found`)
+}
+
+func (s *ResourcesSuite) TestResourceInfo(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	path := path.MakePath(MustParseURL(c, "http://api.foo.bar"))
+
+	restClient := NewMockRESTClient(ctrl)
+	s.expectGetRevisions(c, restClient, path, "meshuggah", "image", 3, 2, 1)
+
+	client := NewResourcesClient(path, restClient, &FakeLogger{})
+	response, err := client.ResourceInfo(context.TODO(), "meshuggah", "image", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(response, gc.DeepEquals, transport.ResourceRevision{Name: "image", Revision: 2})
+}
+
+func (s *ResourcesSuite) TestResourceInfoRevisionNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	path := path.MakePath(MustParseURL(c, "http://api.foo.bar"))
+
+	restClient := NewMockRESTClient(ctrl)
+	s.expectGetRevisions(c, restClient, path, "meshuggah", "image", 3, 2, 1)
+
+	client := NewResourcesClient(path, restClient, &FakeLogger{})
+	_, err := client.ResourceInfo(context.TODO(), "meshuggah", "image", 4)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `revision 4 of resource "image" for charm "meshuggah" not found`)
+}
+
+func (s *ResourcesSuite) expectGetRevisions(c *gc.C, client *MockRESTClient, p path.Path, charm, resource string, revisions ...int) {
+	namedPath, err := p.Join(charm, resource, "revisions")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.EXPECT().Get(gomock.Any(), namedPath, gomock.Any()).Do(func(_ context.Context, _ path.Path, response *transport.ResourcesResponse) {
+		for _, rev := range revisions {
+			response.Revisions = append(response.Revisions, transport.ResourceRevision{Name: resource, Revision: rev})
+		}
+	}).Return(RESTResponse{StatusCode: http.StatusOK}, nil)
+}
+
 func (s *ResourcesSuite) expectGet(c *gc.C, client *MockRESTClient, p path.Path, charm, resource string) {
 	namedPath, err := p.Join(charm, resource, "revisions")
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,48 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/path"
+	"github.com/juju/juju/charmhub/transport"
+)
+
+// ServerInfoClient defines a client for querying the metadata of the
+// CharmHub server itself.
+type ServerInfoClient struct {
+	path   path.Path
+	client RESTClient
+	logger Logger
+}
+
+// NewServerInfoClient creates a ServerInfoClient for requesting
+// server metadata.
+func NewServerInfoClient(path path.Path, client RESTClient, logger Logger) *ServerInfoClient {
+	return &ServerInfoClient{
+		path:   path,
+		client: client,
+		logger: logger,
+	}
+}
+
+// ServerInfo requests the metadata of the CharmHub server, which can be
+// used to check that the server is reachable and reports an API version.
+func (c *ServerInfoClient) ServerInfo(ctx context.Context) (transport.ServerInfoResponse, error) {
+	c.logger.Tracef("ServerInfo()")
+	var resp transport.ServerInfoResponse
+	if _, err := c.client.Get(ctx, c.path, &resp); err != nil {
+		return resp, errors.Annotatef(err, "contacting charm hub server %q", c.path.String())
+	}
+	if resultErr := resp.ErrorList.Combine(); resultErr != nil {
+		return resp, errors.Trace(resultErr)
+	}
+	if resp.Version == "" {
+		return resp, errors.Errorf("charm hub server %q did not report an API version", c.path.String())
+	}
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	path "github.com/juju/juju/charmhub/path"
+	"github.com/juju/juju/charmhub/transport"
+)
+
+type ServerInfoSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ServerInfoSuite{})
+
+func (s *ServerInfoSuite) TestServerInfo(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	rootPath := path.MakePath(MustParseURL(c, "http://api.foo.bar/"))
+
+	restClient := NewMockRESTClient(ctrl)
+	restClient.EXPECT().Get(gomock.Any(), rootPath, gomock.Any()).Do(func(_ context.Context, _ path.Path, response *transport.ServerInfoResponse) {
+		response.Version = "v2"
+	}).Return(RESTResponse{StatusCode: http.StatusOK}, nil)
+
+	client := NewServerInfoClient(rootPath, restClient, &FakeLogger{})
+	response, err := client.ServerInfo(context.TODO())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(response.Version, gc.Equals, "v2")
+}
+
+func (s *ServerInfoSuite) TestServerInfoNoVersion(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	rootPath := path.MakePath(MustParseURL(c, "http://api.foo.bar/"))
+
+	restClient := NewMockRESTClient(ctrl)
+	restClient.EXPECT().Get(gomock.Any(), rootPath, gomock.Any()).Return(RESTResponse{StatusCode: http.StatusOK}, nil)
+
+	client := NewServerInfoClient(rootPath, restClient, &FakeLogger{})
+	_, err := client.ServerInfo(context.TODO())
+	c.Assert(err, gc.ErrorMatches, `charm hub server "http://api.foo.bar/" did not report an API version`)
+}
+
+func (s *ServerInfoSuite) TestServerInfoRequestPayload(c *gc.C) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, gc.Equals, "/")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err := json.NewEncoder(w).Encode(transport.ServerInfoResponse{Version: "v2"})
+		c.Assert(err, jc.ErrorIsNil)
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	config := Config{
+		URL: server.URL,
+	}
+	rootPath, err := config.RootPath()
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiRequester := NewAPIRequester(DefaultHTTPTransport(), &FakeLogger{})
+	restClient := NewHTTPRESTClient(apiRequester, nil)
+
+	client := NewServerInfoClient(rootPath, restClient, &FakeLogger{})
+	response, err := client.ServerInfo(context.TODO())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(response.Version, gc.Equals, "v2")
+}
+
+func (s *ServerInfoSuite) TestServerInfoUnreachable(c *gc.C) {
+	// Start and immediately stop a server, so that its address is
+	// known not to be listening.
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	config := Config{
+		URL: server.URL,
+	}
+	rootPath, err := config.RootPath()
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiRequester := NewAPIRequester(DefaultHTTPTransport(), &FakeLogger{})
+	restClient := NewHTTPRESTClient(apiRequester, nil)
+
+	client := NewServerInfoClient(rootPath, restClient, &FakeLogger{})
+	_, err = client.ServerInfo(context.TODO())
+	c.Assert(err, gc.ErrorMatches, `contacting charm hub server ".*": .*connection refused`)
+}
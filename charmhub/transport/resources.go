@@ -5,6 +5,7 @@ package transport
 
 type ResourcesResponse struct {
 	Revisions []ResourceRevision `json:"revisions"`
+	ErrorList APIErrors          `json:"error-list,omitempty"`
 }
 
 type ResourceRevision struct {
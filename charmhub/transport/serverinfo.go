@@ -0,0 +1,11 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package transport
+
+// ServerInfoResponse holds the metadata served from the root of the
+// CharmHub API.
+type ServerInfoResponse struct {
+	Version   string    `json:"version"`
+	ErrorList APIErrors `json:"error-list,omitempty"`
+}
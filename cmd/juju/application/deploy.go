@@ -486,6 +486,15 @@ option may be repeated if multiple resources are needed:
 
 Where 'bar' and 'baz' are named in the metadata file for charm 'foo'.
 
+The content of a file resource may instead be downloaded from a URL:
+
+  juju deploy foo --resource bar=url=https://example.com/file.tgz
+
+A container image resource may be given as a registry path, or as a file
+or inline JSON holding the path along with credentials for a private registry:
+
+  juju deploy foo --resource image='{"ImageName": "reg.example.com/me/image:1.0", "Username": "me", "Password": "secret"}'
+
 Use the '--to' option to deploy to an existing machine or container by
 specifying a "placement directive". The ` + "`status`" + ` command should be used for
 guidance on how to refer to machines. A few placement directives are
@@ -53,6 +53,7 @@ import (
 	"github.com/juju/juju/cmd/juju/application/store"
 	apputils "github.com/juju/juju/cmd/juju/application/utils"
 	"github.com/juju/juju/cmd/juju/common"
+	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/arch"
 	corecharm "github.com/juju/juju/core/charm"
@@ -166,7 +167,7 @@ func (s *DeploySuiteBase) SetUpTest(c *gc.C) {
 		resources map[string]charmresource.Meta,
 		conn base.APICallCloser,
 		filesystem modelcmd.Filesystem,
-	) (ids map[string]string, err error) {
+	) ([]resourcecmd.DeployedResource, error) {
 		return deployResources(s.State, applicationID, resources)
 	}
 	cfgAttrs := map[string]interface{}{
@@ -192,7 +193,7 @@ func deployResources(
 	st *state.State,
 	applicationID string,
 	resources map[string]charmresource.Meta,
-) (ids map[string]string, err error) {
+) ([]resourcecmd.DeployedResource, error) {
 	if len(resources) == 0 {
 		return nil, nil
 	}
@@ -200,7 +201,7 @@ func deployResources(
 	if err != nil {
 		return nil, err
 	}
-	ids = make(map[string]string)
+	var deployed []resourcecmd.DeployedResource
 	for _, res := range resources {
 		content := res.Name + " content"
 		origin := charmresource.OriginStore
@@ -220,7 +221,17 @@ func deployResources(
 		if err != nil {
 			return nil, err
 		}
-		ids[res.Name] = pendingID
+		item := resourcecmd.DeployedResource{
+			Name:      res.Name,
+			PendingID: pendingID,
+			Origin:    origin,
+			Revision:  -1,
+		}
+		if origin == charmresource.OriginUpload {
+			item.Source = res.Path
+			item.Size = chRes.Size
+		}
+		deployed = append(deployed, item)
 		if origin == charmresource.OriginUpload {
 			_, err := stRes.UpdatePendingResource(applicationID, pendingID, user, chRes, strings.NewReader(content))
 			if err != nil {
@@ -231,7 +242,10 @@ func deployResources(
 			}
 		}
 	}
-	return ids, nil
+	sort.Slice(deployed, func(i, j int) bool {
+		return deployed[i].Name < deployed[j].Name
+	})
+	return deployed, nil
 }
 
 type DeploySuite struct {
@@ -1166,7 +1180,7 @@ func (s *CAASDeploySuite) TestDevices(c *gc.C) {
 		resources map[string]charmresource.Meta,
 		conn base.APICallCloser,
 		filesystem modelcmd.Filesystem,
-	) (ids map[string]string, err error) {
+	) ([]resourcecmd.DeployedResource, error) {
 		fakeAPI.AddCall("DeployResources", applicationID, chID, csMac, filesAndRevisions, resources, conn)
 		return nil, fakeAPI.NextErr()
 	}
@@ -2479,7 +2493,7 @@ func newDeployCommandForTest(fakeAPI *fakeDeployAPI) *DeployCommand {
 			resources map[string]charmresource.Meta,
 			conn base.APICallCloser,
 			filesystem modelcmd.Filesystem,
-		) (ids map[string]string, err error) {
+		) ([]resourcecmd.DeployedResource, error) {
 			return nil, nil
 		},
 		NewCharmRepo: func() (*store.CharmStoreAdaptor, error) {
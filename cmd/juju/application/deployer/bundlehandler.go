@@ -34,6 +34,7 @@ import (
 	appbundle "github.com/juju/juju/cmd/juju/application/bundle"
 	"github.com/juju/juju/cmd/juju/application/store"
 	"github.com/juju/juju/cmd/juju/application/utils"
+	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	"github.com/juju/juju/cmd/modelcmd"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
@@ -719,7 +720,7 @@ func (h *bundleHandler) addApplication(change *bundlechanges.AddApplicationChang
 		return errors.Trace(err)
 	}
 
-	resNames2IDs, err := h.deployResources(
+	deployed, err := h.deployResources(
 		p.Application,
 		client.CharmID{
 			URL:    chID.URL,
@@ -734,6 +735,7 @@ func (h *bundleHandler) addApplication(change *bundlechanges.AddApplicationChang
 	if err != nil {
 		return errors.Trace(err)
 	}
+	resNames2IDs := resourcecmd.PendingIDs(deployed)
 
 	// Figure out what series we need to deploy with.
 	supportedSeries := charmInfo.Meta.ComputedSeries()
@@ -1054,7 +1056,7 @@ func (h *bundleHandler) upgradeCharm(change *bundlechanges.UpgradeCharmChange) e
 	}
 	var resNames2IDs map[string]string
 	if len(filtered) != 0 {
-		resNames2IDs, err = h.deployResources(
+		deployed, err := h.deployResources(
 			p.Application,
 			client.CharmID{
 				URL:    chID.URL,
@@ -1069,6 +1071,7 @@ func (h *bundleHandler) upgradeCharm(change *bundlechanges.UpgradeCharmChange) e
 		if err != nil {
 			return errors.Trace(err)
 		}
+		resNames2IDs = resourcecmd.PendingIDs(deployed)
 	}
 
 	cfg := application.SetCharmConfig{
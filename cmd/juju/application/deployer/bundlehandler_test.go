@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/api/resources/client"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application/deployer/mocks"
+	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/instance"
@@ -857,7 +858,7 @@ func (s *BundleDeployCharmStoreSuite) bundleDeploySpec() bundleDeploySpec {
 		_ map[string]charmresource.Meta,
 		_ base.APICallCloser,
 		_ modelcmd.Filesystem,
-	) (_ map[string]string, _ error) {
+	) ([]resourcecmd.DeployedResource, error) {
 		return nil, nil
 	}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/cmd/juju/application/store"
 	"github.com/juju/juju/cmd/juju/application/utils"
 	"github.com/juju/juju/cmd/juju/common"
+	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
@@ -214,7 +215,7 @@ func (d *deployCharm) deploy(
 			strings.Join(charmInfo.Meta.Terms, " "))
 	}
 
-	ids, err := d.deployResources(
+	deployed, err := d.deployResources(
 		applicationName,
 		client.CharmID{
 			URL:    id.URL,
@@ -229,6 +230,9 @@ func (d *deployCharm) deploy(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	for _, res := range deployed {
+		ctx.Infof("%s", res)
+	}
 
 	if len(appConfig) == 0 {
 		appConfig = nil
@@ -248,7 +252,7 @@ func (d *deployCharm) deploy(
 		Storage:          d.storage,
 		Devices:          d.devices,
 		AttachStorage:    d.attachStorage,
-		Resources:        ids,
+		Resources:        resourcecmd.PendingIDs(deployed),
 		EndpointBindings: d.bindings,
 	}
 	return errors.Trace(deployAPI.Deploy(args))
@@ -26,6 +26,7 @@ import (
 	commoncharm "github.com/juju/juju/api/common/charm"
 	"github.com/juju/juju/api/resources/client"
 	"github.com/juju/juju/cmd/juju/application/deployer/mocks"
+	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	"github.com/juju/juju/cmd/modelcmd"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/model"
@@ -46,14 +47,13 @@ type deployerSuite struct {
 	bundle            *mocks.MockBundle
 	modelConfigGetter *mocks.MockModelConfigGetter
 
-	deployResourceIDs map[string]string
+	deployedResources []resourcecmd.DeployedResource
 	output            *bytes.Buffer
 }
 
 var _ = gc.Suite(&deployerSuite{})
 
 func (s *deployerSuite) SetUpTest(_ *gc.C) {
-	s.deployResourceIDs = make(map[string]string)
 	s.output = bytes.NewBuffer([]byte{})
 }
 
@@ -278,8 +278,8 @@ func (s *deployerSuite) newDeployerFactory() DeployerFactory {
 			map[string]charmresource.Meta,
 			base.APICallCloser,
 			modelcmd.Filesystem,
-		) (ids map[string]string, err error) {
-			return s.deployResourceIDs, nil
+		) ([]resourcecmd.DeployedResource, error) {
+			return s.deployedResources, nil
 		},
 		Model:                s.modelCommand,
 		NewConsumeDetailsAPI: func(url *charm.OfferURL) (ConsumeDetails, error) { return s.consumeDetails, nil },
@@ -38,6 +38,7 @@ import (
 	"github.com/juju/juju/cmd/juju/application/utils"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
+	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	"github.com/juju/juju/cmd/modelcmd"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/environs/config"
@@ -582,7 +583,7 @@ func (c *refreshCommand) upgradeResources(
 
 	// Note: the validity of user-supplied resources to be uploaded will be
 	// checked further down the stack.
-	ids, err := c.DeployResources(
+	deployed, err := c.DeployResources(
 		c.ApplicationName,
 		client.CharmID{
 			URL:    chID.URL,
@@ -594,7 +595,10 @@ func (c *refreshCommand) upgradeResources(
 		apiRoot,
 		c.Filesystem(),
 	)
-	return ids, errors.Trace(err)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resourcecmd.PendingIDs(deployed), nil
 }
 
 func newCharmAdder(
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/api/resources/client"
 	"github.com/juju/juju/cmd/juju/application/store"
 	"github.com/juju/juju/cmd/juju/application/utils"
+	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/testcharms"
@@ -142,7 +143,7 @@ func (s *RefreshStoreResourceSuite) TestDeployStarsaySuccess(c *gc.C) {
 		resources map[string]charmresource.Meta,
 		conn base.APICallCloser,
 		filesystem modelcmd.Filesystem,
-	) (ids map[string]string, err error) {
+	) ([]resourcecmd.DeployedResource, error) {
 		return deployResources(s.State, applicationID, resources)
 	}
 	deploy.NewCharmRepo = func() (*store.CharmStoreAdaptor, error) {
@@ -153,6 +154,9 @@ func (s *RefreshStoreResourceSuite) TestDeployStarsaySuccess(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	expectedOutput := `Located charm "cs:bionic/starsay-1".
+using latest revision of resource "install-resource" from store
+using latest revision of resource "store-resource" from store
+using resource "upload-resource" uploaded from "somename.xml" (9 bytes)
 Deploying charm "cs:bionic/starsay-1".`
 	c.Assert(output, gc.Equals, expectedOutput)
 	s.assertCharmsUploaded(c, "cs:bionic/starsay-1")
@@ -267,7 +271,7 @@ Deploying charm "cs:bionic/starsay-1".`
 			resources map[string]charmresource.Meta,
 			conn base.APICallCloser,
 			filesystem modelcmd.Filesystem,
-		) (ids map[string]string, err error) {
+		) ([]resourcecmd.DeployedResource, error) {
 			return deployResources(s.State, applicationID, resources)
 		},
 		func(conn base.APICallCloser) CharmRefreshClient {
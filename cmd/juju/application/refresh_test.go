@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/cmd/juju/application/store"
 	"github.com/juju/juju/cmd/juju/application/utils"
 	"github.com/juju/juju/cmd/juju/common"
+	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	"github.com/juju/juju/cmd/modelcmd"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/instance"
@@ -98,7 +99,7 @@ func (s *BaseRefreshSuite) SetUpTest(c *gc.C) {
 		resources map[string]charmresource.Meta,
 		conn base.APICallCloser,
 		filesystem modelcmd.Filesystem,
-	) (ids map[string]string, err error) {
+	) ([]resourcecmd.DeployedResource, error) {
 		s.AddCall("DeployResources", applicationID, chID, csMac, filesAndRevisions, resources, conn)
 		return nil, s.NextErr()
 	}
@@ -6,8 +6,10 @@ package commands
 import (
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
@@ -43,6 +45,7 @@ import (
 	"github.com/juju/juju/cmd/juju/subnet"
 	"github.com/juju/juju/cmd/juju/user"
 	"github.com/juju/juju/cmd/modelcmd"
+	coreresources "github.com/juju/juju/core/resources"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju"
 	"github.com/juju/juju/juju/osenv"
@@ -54,6 +57,10 @@ import (
 
 var logger = loggo.GetLogger("juju.cmd.juju.commands")
 
+// registryCheckTimeout bounds the time spent verifying that a container
+// image resource can be retrieved before it is attached.
+const registryCheckTimeout = 30 * time.Second
+
 func init() {
 	featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey, osenv.JujuFeatures)
 }
@@ -535,6 +542,9 @@ func registerCommands(r commandRegistry) {
 			}
 			return resourceadapters.NewAPIClient(apiRoot)
 		},
+		RegistryChecker: coreresources.NewRegistryChecker(&http.Client{
+			Timeout: registryCheckTimeout,
+		}),
 	}))
 	r.Register(resource.NewListCommand(resource.ListDeps{
 		NewClient: func(c *resource.ListCommand) (resource.ListClient, error) {
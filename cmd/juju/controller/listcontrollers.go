@@ -157,6 +157,12 @@ func (c *listControllersCommand) refreshControllerDetails(client ControllerAcces
 		return err
 	}
 
+	// Older controllers don't report the health of their model cache.
+	cacheHealth, err := client.CacheHealth()
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Trace(err)
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	// Use the model information to update the cached controller details.
@@ -179,6 +185,7 @@ func (c *listControllersCommand) refreshControllerDetails(client ControllerAcces
 	}
 	details.MachineCount = &machineCount
 	details.ActiveControllerMachineCount, details.ControllerMachineCount = ControllerMachineCounts(controllerModelUUID, modelStatus)
+	details.CacheStatus = cacheHealth.Status
 	return c.store.UpdateController(controllerName, *details)
 }
 
@@ -144,6 +144,31 @@ mark-test-prodstack  -             admin  (unknown)  prodstack                -
 	s.assertListControllers(c, "--refresh")
 }
 
+func (s *ListControllersSuite) TestListControllersCacheDegraded(c *gc.C) {
+	store := s.createTestClientStore(c)
+	s.api = func(controllerName string) controller.ControllerAccessAPI {
+		fakeController := &fakeController{controllerName: controllerName}
+		fakeController.cacheHealth.Status = "synced"
+		if controllerName == "mallards" {
+			fakeController.cacheHealth.Status = "degraded"
+		}
+		return fakeController
+	}
+	s.expectedOutput = `
+Controller           Model         User   Access     Cloud/Region        Models  Nodes  HA  Version
+aws-test             controller    admin  (unknown)  aws/us-east-1            1      2   -  2.0.1      
+k8s-controller       my-k8s-model  admin  superuser  microk8s/localhost       2      4   -  6.6.6      
+mallards*            my-model      admin  superuser  mallards/mallards1       2      4   -  (unknown)  
+mark-test-prodstack  -             admin  (unknown)  prodstack                -      -   -  (unknown)  
+
+Controller "mallards" cache: degraded
+
+`[1:]
+	s.assertListControllers(c, "--refresh")
+	c.Assert(store.Controllers["mallards"].CacheStatus, gc.Equals, "degraded")
+	c.Assert(store.Controllers["aws-test"].CacheStatus, gc.Equals, "synced")
+}
+
 func (s *ListControllersSuite) TestListControllersYaml(c *gc.C) {
 	s.expectedOutput = `
 controllers:
@@ -46,6 +46,7 @@ type ControllerItem struct {
 	ModelCount         *int                `yaml:"model-count,omitempty" json:"model-count,omitempty"`
 	MachineCount       *int                `yaml:"machine-count,omitempty" json:"machine-count,omitempty"`
 	ControllerMachines *ControllerMachines `yaml:"controller-machines,omitempty" json:"controller-machines,omitempty"`
+	CacheStatus        string              `yaml:"cache,omitempty" json:"cache,omitempty"`
 
 	// k8s controllers are not called machines
 	NodeCount       *int                `yaml:"node-count,omitempty" json:"node-count,omitempty"`
@@ -123,6 +124,7 @@ func (c *listControllersCommand) convertControllerDetails(storeControllers map[s
 			Cloud:             details.Cloud,
 			CloudRegion:       details.CloudRegion,
 			AgentVersion:      details.AgentVersion,
+			CacheStatus:       details.CacheStatus,
 		}
 		isCaas := details.CloudType == string(k8sconstants.StorageProviderType)
 		if details.MachineCount != nil && *details.MachineCount > 0 {
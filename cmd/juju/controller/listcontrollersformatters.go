@@ -18,6 +18,9 @@ import (
 const (
 	noValueDisplay  = "-"
 	notKnownDisplay = "(unknown)"
+
+	// cacheSynced is the status of a healthy controller model cache.
+	cacheSynced = "synced"
 )
 
 func (c *listControllersCommand) formatControllersListTabular(writer io.Writer, value interface{}) error {
@@ -108,6 +111,21 @@ func formatControllersTabular(writer io.Writer, set ControllerSet, promptRefresh
 		w.Println()
 	}
 	tw.Flush()
+
+	// Call out controllers whose model cache isn't synced, as they
+	// serve reads from state rather than the cache.
+	first := true
+	for _, name := range names {
+		status := set.Controllers[name].CacheStatus
+		if status == "" || status == cacheSynced {
+			continue
+		}
+		if first {
+			fmt.Fprintln(writer)
+			first = false
+		}
+		fmt.Fprintf(writer, "Controller %q cache: %s\n", name, status)
+	}
 	return nil
 }
 
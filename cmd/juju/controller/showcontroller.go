@@ -99,6 +99,7 @@ type ControllerAccessAPI interface {
 	MongoVersion() (string, error)
 	IdentityProviderURL() (string, error)
 	ControllerVersion() (controller.ControllerVersion, error)
+	CacheHealth() (controller.CacheHealth, error)
 	Close() error
 }
 
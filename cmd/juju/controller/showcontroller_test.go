@@ -668,6 +668,7 @@ type fakeController struct {
 	bestAPIVersion    int
 	identityURL       string
 	controllerVersion apicontroller.ControllerVersion
+	cacheHealth       apicontroller.CacheHealth
 }
 
 func (c *fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -728,6 +729,10 @@ func (c *fakeController) ControllerVersion() (apicontroller.ControllerVersion, e
 	return c.controllerVersion, nil
 }
 
+func (c *fakeController) CacheHealth() (apicontroller.CacheHealth, error) {
+	return c.cacheHealth, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
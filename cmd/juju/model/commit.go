@@ -23,8 +23,15 @@ Committing a branch writes changes to charm configuration made under the
 branch, to the model. All units who's applications were changed under the 
 branch realise those changes, as will any new units.
 
+If configuration changed under the branch has since been changed in the
+model, the branch is not committed and the conflicting settings are
+reported. Use "juju diff" to view the conflicting values. The
+--force option commits the branch regardless, overwriting the values
+changed in the model.
+
 Examples:
     juju commit upgrade-postgresql
+    juju commit --force upgrade-postgresql
 
 See also:
     add-branch
@@ -48,6 +55,7 @@ type commitCommand struct {
 	api CommitCommandAPI
 
 	branchName string
+	force      bool
 }
 
 // CommitCommandAPI defines an API interface to be used during testing.
@@ -59,7 +67,9 @@ type CommitCommandAPI interface {
 	// effectively completing it and applying
 	// all branch changes across the model.
 	// The new generation ID of the model is returned.
-	CommitBranch(branchName string) (int, error)
+	// Unless force is true, the branch is not committed if config
+	// changed under it has since been changed on master.
+	CommitBranch(branchName string, force bool) (int, error)
 }
 
 // Info implements part of the cmd.Command interface.
@@ -76,6 +86,7 @@ func (c *commitCommand) Info() *cmd.Info {
 // SetFlags implements part of the cmd.Command interface.
 func (c *commitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Commit the branch even if its configuration changes conflict with changes made in the model")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -109,7 +120,7 @@ func (c *commitCommand) Run(ctx *cmd.Context) error {
 	}
 	defer func() { _ = client.Close() }()
 
-	newGenId, err := client.CommitBranch(c.branchName)
+	newGenId, err := client.CommitBranch(c.branchName, c.force)
 	if err != nil {
 		return err
 	}
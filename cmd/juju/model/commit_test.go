@@ -36,7 +36,7 @@ func (s *commitSuite) TestRunCommandAborted(c *gc.C) {
 	ctrl, api := setUpCancelMocks(c)
 	defer ctrl.Finish()
 
-	api.EXPECT().CommitBranch(s.branchName).Return(0, nil)
+	api.EXPECT().CommitBranch(s.branchName, false).Return(0, nil)
 
 	ctx, err := s.runCommand(c, api)
 	c.Assert(err, jc.ErrorIsNil)
@@ -56,7 +56,7 @@ func (s *commitSuite) TestRunCommandCommitted(c *gc.C) {
 	ctrl, api := setUpCancelMocks(c)
 	defer ctrl.Finish()
 
-	api.EXPECT().CommitBranch(s.branchName).Return(3, nil)
+	api.EXPECT().CommitBranch(s.branchName, false).Return(3, nil)
 
 	ctx, err := s.runCommand(c, api)
 	c.Assert(err, jc.ErrorIsNil)
@@ -76,12 +76,22 @@ func (s *commitSuite) TestRunCommandFail(c *gc.C) {
 	ctrl, api := setUpCancelMocks(c)
 	defer ctrl.Finish()
 
-	api.EXPECT().CommitBranch(s.branchName).Return(0, errors.Errorf("fail"))
+	api.EXPECT().CommitBranch(s.branchName, false).Return(0, errors.Errorf("fail"))
 
 	_, err := s.runCommand(c, api)
 	c.Assert(err, gc.ErrorMatches, "fail")
 }
 
+func (s *commitSuite) TestRunCommandForce(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().CommitBranch(s.branchName, true).Return(3, nil)
+
+	_, err := cmdtesting.RunCommand(c, model.NewCommitCommandForTest(api, s.store), "--force", s.branchName)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *commitSuite) runInit(args ...string) error {
 	return cmdtesting.InitCommand(model.NewCommitCommandForTest(nil, s.store), args)
 }
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/juju/juju/core/model"
 )
 
 // MockTrackBranchCommandAPI is a mock of TrackBranchCommandAPI interface
@@ -59,13 +60,13 @@ func (mr *MockTrackBranchCommandAPIMockRecorder) HasActiveBranch(arg0 interface{
 }
 
 // TrackBranch mocks base method
-func (m *MockTrackBranchCommandAPI) TrackBranch(arg0 string, arg1 []string, arg2 int) error {
-	ret := m.ctrl.Call(m, "TrackBranch", arg0, arg1, arg2)
+func (m *MockTrackBranchCommandAPI) TrackBranch(arg0 string, arg1 []string, arg2 int, arg3 model.BranchTrackingPolicy) error {
+	ret := m.ctrl.Call(m, "TrackBranch", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // TrackBranch indicates an expected call of TrackBranch
-func (mr *MockTrackBranchCommandAPIMockRecorder) TrackBranch(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBranch", reflect.TypeOf((*MockTrackBranchCommandAPI)(nil).TrackBranch), arg0, arg1, arg2)
+func (mr *MockTrackBranchCommandAPIMockRecorder) TrackBranch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackBranch", reflect.TypeOf((*MockTrackBranchCommandAPI)(nil).TrackBranch), arg0, arg1, arg2, arg3)
 }
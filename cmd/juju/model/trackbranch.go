@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/modelgeneration"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 )
 
 const (
@@ -24,10 +25,16 @@ Specific units can be set to track a branch by supplying multiple unit IDs.
 All units of an application can be set to track a branch by passing an
 application name. Units can only track one branch at a time.
 
+By default, units added to an application while the application tracks
+a branch also track it, unless only some of the application's units were
+requested with -n. The --policy option overrides this, and may be either
+"track-all" or "explicit-units".
+
 Examples:
     juju track test-branch redis/0
     juju track test-branch redis
     juju track test-branch redis -n 2
+    juju track test-branch redis --policy explicit-units
     juju track test-branch redis/0 mysql
 
 See also:
@@ -58,6 +65,10 @@ type trackBranchCommand struct {
 	// picked to track the number of units if there are more than the number
 	// requested.
 	numUnits autoIntValue
+
+	// policy determines whether units added to the tracked applications
+	// track the branch. If empty, the controller chooses the policy.
+	policy string
 }
 
 // TrackBranchCommandAPI describes API methods required
@@ -68,7 +79,7 @@ type TrackBranchCommandAPI interface {
 
 	// TrackBranch sets the input units and/or applications
 	// to track changes made under the input branch name.
-	TrackBranch(branchName string, entities []string, numUnits int) error
+	TrackBranch(branchName string, entities []string, numUnits int, policy model.BranchTrackingPolicy) error
 	HasActiveBranch(branchName string) (bool, error)
 }
 
@@ -87,6 +98,7 @@ func (c *trackBranchCommand) Info() *cmd.Info {
 func (c *trackBranchCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.Var(&c.numUnits, "n", "The number of units to track")
+	f.StringVar(&c.policy, "policy", "", "Whether units added to the applications track the branch (track-all or explicit-units)")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -109,6 +121,12 @@ func (c *trackBranchCommand) Init(args []string) error {
 	}
 	c.numUnits.v = &flagNumUnits
 
+	if c.policy != "" {
+		if err := model.BranchTrackingPolicy(c.policy).Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	var numUnits int
 	var numApplications int
 
@@ -181,7 +199,8 @@ func (c *trackBranchCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("expected unit and/or application names(s)")
 	}
 
-	return errors.Trace(client.TrackBranch(c.branchName, c.entities, *c.numUnits.v))
+	return errors.Trace(client.TrackBranch(
+		c.branchName, c.entities, *c.numUnits.v, model.BranchTrackingPolicy(c.policy)))
 }
 
 // autoIntValue allows the value of nil to mean something when attempting
@@ -83,7 +83,7 @@ func (s *trackBranchSuite) TestRunCommand(c *gc.C) {
 	mockController, api := setUpAdvanceMocks(c)
 	defer mockController.Finish()
 
-	api.EXPECT().TrackBranch(s.branchName, []string{"ubuntu/0", "redis"}, 0).Return(nil)
+	api.EXPECT().TrackBranch(s.branchName, []string{"ubuntu/0", "redis"}, 0, coremodel.BranchTrackingPolicy("")).Return(nil)
 
 	_, err := s.runCommand(c, api, s.branchName, "ubuntu/0", "redis")
 	c.Assert(err, jc.ErrorIsNil)
@@ -93,12 +93,27 @@ func (s *trackBranchSuite) TestRunCommandNumUnits(c *gc.C) {
 	mockController, api := setUpAdvanceMocks(c)
 	defer mockController.Finish()
 
-	api.EXPECT().TrackBranch(s.branchName, []string{"redis"}, 3).Return(nil)
+	api.EXPECT().TrackBranch(s.branchName, []string{"redis"}, 3, coremodel.BranchTrackingPolicy("")).Return(nil)
 
 	_, err := s.runCommand(c, api, s.branchName, "-n", "3", "redis")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *trackBranchSuite) TestRunCommandPolicy(c *gc.C) {
+	mockController, api := setUpAdvanceMocks(c)
+	defer mockController.Finish()
+
+	api.EXPECT().TrackBranch(s.branchName, []string{"redis"}, 0, coremodel.BranchTrackExplicitUnits).Return(nil)
+
+	_, err := s.runCommand(c, api, s.branchName, "--policy", "explicit-units", "redis")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *trackBranchSuite) TestInitInvalidPolicy(c *gc.C) {
+	err := s.runInit(s.branchName, "--policy", "some-units", "redis")
+	c.Assert(err, gc.ErrorMatches, `branch tracking policy "some-units" not valid`)
+}
+
 func (s *trackBranchSuite) TestRunCommandNumUnitsWithInvalidNumber(c *gc.C) {
 	mockController, api := setUpAdvanceMocksWithoutAPI(c)
 	defer mockController.Finish()
@@ -114,7 +129,7 @@ func (s *trackBranchSuite) TestRunCommandFail(c *gc.C) {
 	ctrl, api := setUpAdvanceMocks(c)
 	defer ctrl.Finish()
 
-	api.EXPECT().TrackBranch(s.branchName, []string{"ubuntu/0"}, 0).Return(errors.Errorf("fail"))
+	api.EXPECT().TrackBranch(s.branchName, []string{"ubuntu/0"}, 0, coremodel.BranchTrackingPolicy("")).Return(errors.Errorf("fail"))
 
 	_, err := s.runCommand(c, api, s.branchName, "ubuntu/0")
 	c.Assert(err, gc.ErrorMatches, "fail")
@@ -9,10 +9,17 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/juju/charm/v9"
 	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/macaroon.v2"
 	"gopkg.in/yaml.v2"
@@ -22,6 +29,16 @@ import (
 	"github.com/juju/juju/core/resources"
 )
 
+const (
+	// urlResourcePrefix marks a file resource value as
+	// a URL from which the resource content is downloaded.
+	urlResourcePrefix = "url="
+
+	// maxURLResourceSize is the largest resource
+	// that will be downloaded from a URL.
+	maxURLResourceSize = 1 << 30
+)
+
 // DeployClient exposes the functionality of the resources API needed
 // for deploy.
 type DeployClient interface {
@@ -61,12 +78,89 @@ type DeployResourcesArgs struct {
 
 	// Filesystem provides access to the filesystem.
 	Filesystem modelcmd.Filesystem
+
+	// UploadTimeout, if non-zero, is the time allowed for each
+	// uploaded resource, and for adding the store resources,
+	// before giving up on the deploy.
+	UploadTimeout time.Duration
+
+	// Clock is used to enforce the upload timeout.
+	// If nil, the wall clock is used.
+	Clock clock.Clock
+
+	// HTTPClient is used to download file resources given as
+	// "url=<url>" values. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// DeployedResource describes a resource added to the
+// controller as a pending resource during deploy.
+type DeployedResource struct {
+	// Name is the name of the resource.
+	Name string
+
+	// PendingID is the ID of the pending resource.
+	PendingID string
+
+	// Origin indicates whether the resource was
+	// uploaded or is to be retrieved from the store.
+	Origin charmresource.Origin
+
+	// Revision is the store revision requested for the resource.
+	// It is -1 if the latest revision is used, or the resource
+	// was uploaded.
+	Revision int
+
+	// Source is the file, URL or registry path from which
+	// an uploaded resource was taken.
+	Source string
+
+	// Size is the number of bytes uploaded for a file
+	// resource. It is zero if not known.
+	Size int64
+}
+
+// String returns a summary of the resource suitable for deploy output.
+func (r DeployedResource) String() string {
+	switch {
+	case r.Origin == charmresource.OriginStore && r.Revision >= 0:
+		return fmt.Sprintf("using resource %q revision %d from store", r.Name, r.Revision)
+	case r.Origin == charmresource.OriginStore:
+		return fmt.Sprintf("using latest revision of resource %q from store", r.Name)
+	case r.Size > 0:
+		return fmt.Sprintf("using resource %q uploaded from %q (%d bytes)", r.Name, r.Source, r.Size)
+	default:
+		return fmt.Sprintf("using resource %q uploaded from %q", r.Name, r.Source)
+	}
+}
+
+// PendingIDs returns a map of resource name to
+// pending resource ID for the input resources.
+func PendingIDs(deployed []DeployedResource) map[string]string {
+	if len(deployed) == 0 {
+		return nil
+	}
+	ids := make(map[string]string, len(deployed))
+	for _, res := range deployed {
+		ids[res.Name] = res.PendingID
+	}
+	return ids
+}
+
+// DeployResourceIDs is DeployResources, returning only a
+// map of resource name to pending resource ID.
+func DeployResourceIDs(args DeployResourcesArgs) (map[string]string, error) {
+	deployed, err := DeployResources(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return PendingIDs(deployed), nil
 }
 
 // DeployResources uploads the bytes for the given files to the server and
 // creates pending resource metadata for the all resource mentioned in the
-// metadata. It returns a map of resource name to pending resource IDs.
-func DeployResources(args DeployResourcesArgs) (ids map[string]string, err error) {
+// metadata. It returns the resources that were added, ordered by name.
+func DeployResources(args DeployResourcesArgs) ([]DeployedResource, error) {
 	d := deployUploader{
 		applicationID: args.ApplicationID,
 		chID:          args.CharmID,
@@ -74,13 +168,23 @@ func DeployResources(args DeployResourcesArgs) (ids map[string]string, err error
 		client:        args.Client,
 		resources:     args.ResourcesMeta,
 		filesystem:    args.Filesystem,
+		timeout:       args.UploadTimeout,
+		clock:         args.Clock,
+		httpClient:    args.HTTPClient,
+		maxURLSize:    maxURLResourceSize,
+	}
+	if d.clock == nil {
+		d.clock = clock.WallClock
+	}
+	if d.httpClient == nil {
+		d.httpClient = http.DefaultClient
 	}
 
-	ids, err = d.upload(args.ResourceValues, args.Revisions)
+	deployed, err := d.upload(args.ResourceValues, args.Revisions)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return ids, nil
+	return deployed, nil
 }
 
 type osOpenFunc func(path string) (modelcmd.ReadSeekCloser, error)
@@ -92,9 +196,13 @@ type deployUploader struct {
 	resources     map[string]charmresource.Meta
 	client        DeployClient
 	filesystem    modelcmd.Filesystem
+	timeout       time.Duration
+	clock         clock.Clock
+	httpClient    *http.Client
+	maxURLSize    int64
 }
 
-func (d deployUploader) upload(resourceValues map[string]string, revisions map[string]int) (map[string]string, error) {
+func (d deployUploader) upload(resourceValues map[string]string, revisions map[string]int) ([]DeployedResource, error) {
 	if err := d.validateResources(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -103,60 +211,133 @@ func (d deployUploader) upload(resourceValues map[string]string, revisions map[s
 		return nil, errors.Trace(err)
 	}
 
-	if err := d.validateResourceDetails(resourceValues); err != nil {
+	registryPaths, err := d.validateResourceDetails(resourceValues)
+	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	storeResources := d.charmStoreResources(resourceValues, revisions)
-	pending := map[string]string{}
+	var deployed []DeployedResource
 	if len(storeResources) > 0 {
-		ids, err := d.client.AddPendingResources(d.applicationID, d.chID, d.csMac, storeResources)
+		var ids []string
+		err := d.withTimeout("adding store resources", func() (err error) {
+			ids, err = d.client.AddPendingResources(d.applicationID, d.chID, d.csMac, storeResources)
+			return err
+		})
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 		// guaranteed 1:1 correlation between ids and resources.
 		for i, res := range storeResources {
-			pending[res.Name] = ids[i]
+			deployed = append(deployed, DeployedResource{
+				Name:      res.Name,
+				PendingID: ids[i],
+				Origin:    charmresource.OriginStore,
+				Revision:  res.Revision,
+			})
 		}
 	}
 
 	for name, resValue := range resourceValues {
-		r, err := OpenResource(resValue, d.resources[name].Type, d.filesystem.Open)
+		uploaded := DeployedResource{
+			Name:     name,
+			Origin:   charmresource.OriginUpload,
+			Revision: -1,
+			Source:   resValue,
+		}
+		if resURL, ok := resourceURL(resValue); ok {
+			id, size, err := d.uploadURLResource(name, resURL)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			uploaded.PendingID = id
+			uploaded.Source = resURL
+			uploaded.Size = size
+			deployed = append(deployed, uploaded)
+			continue
+		}
+		resType := d.resources[name].Type
+		r, err := OpenResource(resValue, resType, d.filesystem.Open)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		id, err := d.uploadPendingResource(name, resValue, r)
+		// Inline image details may hold credentials,
+		// which must not be sent as the filename.
+		filename := resValue
+		if isInlineDockerDetails(resValue) {
+			filename = name
+		}
+		switch resType {
+		case charmresource.TypeContainerImage:
+			// The registry path is reported rather than the
+			// file or inline details, which may hold credentials.
+			uploaded.Source = registryPaths[name]
+		case charmresource.TypeFile:
+			if uploaded.Size, err = readerSize(r); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		id, err := d.uploadPendingResource(name, filename, r)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		pending[name] = id
+		uploaded.PendingID = id
+		deployed = append(deployed, uploaded)
 	}
 
-	return pending, nil
+	sort.Slice(deployed, func(i, j int) bool {
+		return deployed[i].Name < deployed[j].Name
+	})
+	return deployed, nil
+}
+
+// readerSize returns the size of the content of the input
+// reader, which is left positioned at the start of the content.
+func readerSize(r io.Seeker) (int64, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return size, nil
 }
 
-func (d deployUploader) validateResourceDetails(res map[string]string) error {
+// validateResourceDetails checks the values given for the resources,
+// returning the registry path of each container image resource.
+func (d deployUploader) validateResourceDetails(res map[string]string) (map[string]string, error) {
+	registryPaths := make(map[string]string)
 	for name, value := range res {
 		var err error
+		resURL, isURL := resourceURL(value)
 		switch d.resources[name].Type {
 		case charmresource.TypeFile:
-			err = d.checkFile(name, value)
+			if isURL {
+				err = checkURL(name, resURL)
+			} else {
+				err = d.checkFile(name, value)
+			}
 		case charmresource.TypeContainerImage:
+			if isURL {
+				return nil, errors.NotSupportedf("downloading container image resource %q from a URL", name)
+			}
 			var dockerDetails resources.DockerImageDetails
 			dockerDetails, err = getDockerDetailsData(value, d.filesystem.Open)
 			if err != nil {
-				return err
+				return nil, errors.Annotatef(err, "resource %q is a container image", name)
 			}
 			// At the moment this is the same validation that occurs in getDockerDetailsData
 			err = resources.CheckDockerDetails(name, dockerDetails)
+			registryPaths[name] = dockerDetails.RegistryPath
 		default:
-			return fmt.Errorf("unknown resource: %s", name)
+			return nil, fmt.Errorf("unknown resource: %s", name)
 		}
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return registryPaths, nil
 }
 
 func (d deployUploader) checkFile(name, path string) error {
@@ -170,6 +351,26 @@ func (d deployUploader) checkFile(name, path string) error {
 	return nil
 }
 
+// resourceURL returns the URL given by a resource value
+// of the form "url=<url>", and true if it is one.
+func resourceURL(value string) (string, bool) {
+	if !strings.HasPrefix(value, urlResourcePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(value, urlResourcePrefix), true
+}
+
+func checkURL(name, resURL string) error {
+	u, err := url.Parse(resURL)
+	if err != nil {
+		return errors.Annotatef(err, "URL for resource %q", name)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return errors.NotValidf("URL %q for resource %q", resURL, name)
+	}
+	return nil
+}
+
 func (d deployUploader) validateResources() error {
 	var errs []error
 	for _, meta := range d.resources {
@@ -222,7 +423,105 @@ func (d deployUploader) uploadPendingResource(resourcename, resourcevalue string
 		Origin: charmresource.OriginUpload,
 	}
 
-	return d.client.UploadPendingResource(d.applicationID, res, resourcevalue, data)
+	// The result is only read once the upload has completed,
+	// since an abandoned upload may still be writing to it.
+	var uploadedID string
+	err = d.withTimeout(fmt.Sprintf("uploading resource %q", resourcename), func() (err error) {
+		uploadedID, err = d.client.UploadPendingResource(d.applicationID, res, resourcevalue, data)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return uploadedID, nil
+}
+
+// uploadURLResource downloads the content for the named resource from
+// the input URL and uploads it as a pending resource, returning its
+// pending ID and size. The content is spooled to a temporary file, as
+// the upload requires the content to be read twice; once for its
+// fingerprint and again to send it.
+func (d deployUploader) uploadURLResource(name, resURL string) (string, int64, error) {
+	f, size, err := d.downloadURLResource(name, resURL)
+	if err != nil {
+		return "", 0, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	filename := name
+	if u, err := url.Parse(resURL); err == nil {
+		if base := path.Base(u.Path); base != "." && base != "/" {
+			filename = base
+		}
+	}
+	id, err := d.uploadPendingResource(name, filename, f)
+	if err != nil {
+		return "", 0, errors.Trace(err)
+	}
+	return id, size, nil
+}
+
+// downloadURLResource writes the content at the input URL to a temporary
+// file, which is returned positioned at the start of the content along
+// with the size of the content.
+func (d deployUploader) downloadURLResource(name, resURL string) (_ *os.File, _ int64, err error) {
+	resp, err := d.httpClient.Get(resURL)
+	if err != nil {
+		return nil, 0, errors.Annotatef(err, "downloading resource %q", name)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, errors.Errorf("downloading resource %q from %q: %s", name, resURL, resp.Status)
+	}
+	if resp.ContentLength > d.maxURLSize {
+		return nil, 0, errors.Errorf("resource %q at %q is larger than %d bytes", name, resURL, d.maxURLSize)
+	}
+
+	f, err := ioutil.TempFile("", "juju-resource-")
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	n, err := io.Copy(f, io.LimitReader(resp.Body, d.maxURLSize+1))
+	if err != nil {
+		return nil, 0, errors.Annotatef(err, "downloading resource %q", name)
+	}
+	if n > d.maxURLSize {
+		return nil, 0, errors.Errorf("resource %q at %q is larger than %d bytes", name, resURL, d.maxURLSize)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	return f, n, nil
+}
+
+// withTimeout runs the input call, returning a timeout error if
+// it does not complete within the upload timeout. The resources
+// client does not support cancellation, so a call that times out
+// is abandoned and its result discarded.
+func (d deployUploader) withTimeout(what string, call func() error) error {
+	if d.timeout <= 0 {
+		return call()
+	}
+	result := make(chan error, 1)
+	go func() {
+		result <- call()
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-d.clock.After(d.timeout):
+		return errors.Timeoutf("%s after %v", what, d.timeout)
+	}
 }
 
 func (d deployUploader) checkExpectedResources(filenames map[string]string, revisions map[string]int) error {
@@ -243,20 +542,46 @@ func (d deployUploader) checkExpectedResources(filenames map[string]string, revi
 	if len(unknown) > 1 {
 		return errors.Errorf("unrecognized resources: %s", strings.Join(unknown, ", "))
 	}
+
+	// Resources for local charms are not held in a store,
+	// so they can only be uploaded.
+	if d.chID.URL != nil && charm.Local.Matches(d.chID.URL.Schema) && len(revisions) > 0 {
+		var names []string
+		for name := range revisions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return errors.Errorf("resource %q of a local charm must be uploaded, not given a revision", names[0])
+	}
 	return nil
 }
 
-// getDockerDetailsData determines if path is a local file path and extracts the
-// details from that otherwise path is considered to be a registry path.
+// isInlineDockerDetails returns true if the container image resource
+// value holds the image details as JSON, rather than naming a file
+// or registry path.
+func isInlineDockerDetails(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), "{")
+}
+
+// getDockerDetailsData extracts the image details from path if it is inline
+// JSON or a local file path, otherwise path is considered to be a registry path.
+// A file that exists but cannot be read is an error, rather than being taken
+// as a registry path, so that its credentials are not silently dropped.
 func getDockerDetailsData(path string, osOpen osOpenFunc) (resources.DockerImageDetails, error) {
+	if isInlineDockerDetails(path) {
+		details, err := unMarshalDockerDetails(strings.NewReader(path))
+		return details, errors.Annotate(err, "inline image details")
+	}
 	f, err := osOpen(path)
 	if err == nil {
 		defer f.Close()
 		details, err := unMarshalDockerDetails(f)
 		if err != nil {
-			return details, errors.Trace(err)
+			return details, errors.Annotatef(err, "file %q does not contain image details", path)
 		}
 		return details, nil
+	} else if os.IsPermission(errors.Cause(err)) {
+		return resources.DockerImageDetails{}, errors.Annotatef(err, "cannot open image details file %q", path)
 	} else if err := resources.ValidateDockerRegistryPath(path); err == nil {
 		return resources.DockerImageDetails{
 			RegistryPath: path,
@@ -7,12 +7,16 @@ import (
 	"bytes"
 	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/charm/v9"
 	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -22,6 +26,7 @@ import (
 	"github.com/juju/juju/api/resources/client"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/resources"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type DeploySuite struct {
@@ -58,7 +63,7 @@ func (s DeploySuite) TestDeployResourcesWithoutFiles(c *gc.C) {
 		},
 	}
 
-	ids, err := DeployResources(DeployResourcesArgs{
+	deployed, err := DeployResources(DeployResourcesArgs{
 		ApplicationID:      "mysql",
 		CharmID:            chID,
 		CharmStoreMacaroon: csMac,
@@ -68,10 +73,17 @@ func (s DeploySuite) TestDeployResourcesWithoutFiles(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	c.Check(ids, gc.DeepEquals, map[string]string{
-		"store-tarball": "id-store-tarball",
-		"store-zip":     "id-store-zip",
-	})
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "store-tarball",
+		PendingID: "id-store-tarball",
+		Origin:    charmresource.OriginStore,
+		Revision:  -1,
+	}, {
+		Name:      "store-zip",
+		PendingID: "id-store-zip",
+		Origin:    charmresource.OriginStore,
+		Revision:  -1,
+	}})
 
 	s.stub.CheckCallNames(c, "AddPendingResources")
 	s.stub.CheckCall(c, 0, "AddPendingResources", "mysql", chID, csMac, []charmresource.Resource{{
@@ -116,12 +128,20 @@ func (s DeploySuite) TestUploadFilesOnly(c *gc.C) {
 		"upload": "foobar.txt",
 	}
 	revisions := map[string]int{}
-	ids, err := du.upload(files, revisions)
+	deployed, err := du.upload(files, revisions)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Check(ids, gc.DeepEquals, map[string]string{
-		"upload": "id-upload",
-		"store":  "id-store",
-	})
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "store",
+		PendingID: "id-store",
+		Origin:    charmresource.OriginStore,
+		Revision:  -1,
+	}, {
+		Name:      "upload",
+		PendingID: "id-upload",
+		Origin:    charmresource.OriginUpload,
+		Revision:  -1,
+		Source:    "foobar.txt",
+	}})
 
 	s.stub.CheckCallNames(c, "Stat", "AddPendingResources", "Open", "UploadPendingResource")
 	expectedStore := []charmresource.Resource{
@@ -172,12 +192,19 @@ func (s DeploySuite) TestUploadRevisionsOnly(c *gc.C) {
 	revisions := map[string]int{
 		"store": 3,
 	}
-	ids, err := du.upload(files, revisions)
+	deployed, err := du.upload(files, revisions)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Check(ids, gc.DeepEquals, map[string]string{
-		"upload": "id-upload",
-		"store":  "id-store",
-	})
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "store",
+		PendingID: "id-store",
+		Origin:    charmresource.OriginStore,
+		Revision:  3,
+	}, {
+		Name:      "upload",
+		PendingID: "id-upload",
+		Origin:    charmresource.OriginStore,
+		Revision:  -1,
+	}})
 
 	s.stub.CheckCallNames(c, "AddPendingResources")
 	expectedStore := []charmresource.Resource{{
@@ -225,12 +252,20 @@ func (s DeploySuite) TestUploadFilesAndRevisions(c *gc.C) {
 	revisions := map[string]int{
 		"store": 3,
 	}
-	ids, err := du.upload(files, revisions)
+	deployed, err := du.upload(files, revisions)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Check(ids, gc.DeepEquals, map[string]string{
-		"upload": "id-upload",
-		"store":  "id-store",
-	})
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "store",
+		PendingID: "id-store",
+		Origin:    charmresource.OriginStore,
+		Revision:  3,
+	}, {
+		Name:      "upload",
+		PendingID: "id-upload",
+		Origin:    charmresource.OriginUpload,
+		Revision:  -1,
+		Source:    "foobar.txt",
+	}})
 
 	s.stub.CheckCallNames(c, "Stat", "AddPendingResources", "Open", "UploadPendingResource")
 	expectedStore := []charmresource.Resource{
@@ -250,6 +285,163 @@ func (s DeploySuite) TestUploadFilesAndRevisions(c *gc.C) {
 	s.stub.CheckCall(c, 3, "UploadPendingResource", "mysql", expectedUpload, "foobar.txt", "file contents")
 }
 
+func (s DeploySuite) newURLUploader(deps uploadDeps) deployUploader {
+	return deployUploader{
+		applicationID: "mysql",
+		chID:          client.CharmID{URL: charm.MustParseURL("cs:~a-user/trusty/spam-5")},
+		client:        deps,
+		resources: map[string]charmresource.Meta{
+			"upload": {
+				Name: "upload",
+				Type: charmresource.TypeFile,
+				Path: "upload",
+			},
+		},
+		filesystem: deps,
+		httpClient: http.DefaultClient,
+		maxURLSize: 1024,
+	}
+}
+
+func (s DeploySuite) TestUploadFromURL(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.URL.Path, gc.Equals, "/artifacts/upload.tgz")
+		_, _ = w.Write([]byte("remote contents"))
+	}))
+	defer srv.Close()
+
+	du := s.newURLUploader(uploadDeps{stub: s.stub})
+	deployed, err := du.upload(map[string]string{"upload": "url=" + srv.URL + "/artifacts/upload.tgz"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "upload",
+		PendingID: "id-upload",
+		Origin:    charmresource.OriginUpload,
+		Revision:  -1,
+		Source:    srv.URL + "/artifacts/upload.tgz",
+		Size:      15,
+	}})
+
+	s.stub.CheckCallNames(c, "UploadPendingResource")
+	expectedUpload := charmresource.Resource{
+		Meta:   du.resources["upload"],
+		Origin: charmresource.OriginUpload,
+	}
+	s.stub.CheckCall(c, 0, "UploadPendingResource", "mysql", expectedUpload, "upload.tgz", "remote contents")
+}
+
+func (s DeploySuite) TestUploadFromURLHTTPError(c *gc.C) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	du := s.newURLUploader(uploadDeps{stub: s.stub})
+	_, err := du.upload(map[string]string{"upload": "url=" + srv.URL + "/missing"}, nil)
+	c.Check(err, gc.ErrorMatches, `downloading resource "upload" from ".*/missing": 404 Not Found`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s DeploySuite) TestUploadFromURLTooLarge(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Flushing forces a chunked response of unknown length.
+		_, _ = w.Write(bytes.Repeat([]byte("x"), 1000))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write(bytes.Repeat([]byte("x"), 1000))
+	}))
+	defer srv.Close()
+
+	du := s.newURLUploader(uploadDeps{stub: s.stub})
+	_, err := du.upload(map[string]string{"upload": "url=" + srv.URL + "/big"}, nil)
+	c.Check(err, gc.ErrorMatches, `resource "upload" at ".*/big" is larger than 1024 bytes`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s DeploySuite) TestUploadFromURLInvalid(c *gc.C) {
+	du := s.newURLUploader(uploadDeps{stub: s.stub})
+	_, err := du.upload(map[string]string{"upload": "url=ftp://example.com/file"}, nil)
+	c.Check(err, gc.ErrorMatches, `URL "ftp://example.com/file" for resource "upload" not valid`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s DeploySuite) TestUploadTimeout(c *gc.C) {
+	release := make(chan struct{})
+	defer close(release)
+	deps := blockingUploadDeps{
+		uploadDeps: uploadDeps{stub: s.stub, data: []byte("file contents")},
+		release:    release,
+	}
+	clock := testclock.NewClock(time.Now())
+	du := deployUploader{
+		applicationID: "mysql",
+		client:        deps,
+		resources: map[string]charmresource.Meta{
+			"upload": {
+				Name: "upload",
+				Type: charmresource.TypeFile,
+				Path: "upload",
+			},
+		},
+		filesystem: deps,
+		timeout:    time.Minute,
+		clock:      clock,
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := du.upload(map[string]string{"upload": "foobar.txt"}, nil)
+		done <- err
+	}()
+
+	err := clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.Satisfies, errors.IsTimeout)
+		c.Assert(err, gc.ErrorMatches, `uploading resource "upload" after 1m0s timeout`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("upload did not time out")
+	}
+}
+
+func (s DeploySuite) TestAddPendingResourcesTimeout(c *gc.C) {
+	release := make(chan struct{})
+	defer close(release)
+	deps := blockingUploadDeps{
+		uploadDeps: uploadDeps{stub: s.stub},
+		release:    release,
+	}
+	clock := testclock.NewClock(time.Now())
+	du := deployUploader{
+		applicationID: "mysql",
+		client:        deps,
+		resources: map[string]charmresource.Meta{
+			"store": {
+				Name: "store",
+				Type: charmresource.TypeFile,
+				Path: "store",
+			},
+		},
+		filesystem: deps,
+		timeout:    time.Minute,
+		clock:      clock,
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := du.upload(nil, nil)
+		done <- err
+	}()
+
+	err := clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.Satisfies, errors.IsTimeout)
+		c.Assert(err, gc.ErrorMatches, `adding store resources after 1m0s timeout`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("adding store resources did not time out")
+	}
+}
+
 func (s DeploySuite) TestUploadUnexpectedResourceFile(c *gc.C) {
 	deps := uploadDeps{stub: s.stub}
 	du := deployUploader{
@@ -321,6 +513,70 @@ func (s DeploySuite) TestMissingResource(c *gc.C) {
 	c.Check(errors.Cause(err), jc.Satisfies, os.IsNotExist)
 }
 
+func (s DeploySuite) TestUploadFileForContainerImage(c *gc.C) {
+	deps := uploadDeps{stub: s.stub, data: []byte("file contents")}
+	du := deployUploader{
+		applicationID: "mysql",
+		chID:          client.CharmID{URL: charm.MustParseURL("cs:~a-user/mysql-k8s-5")},
+		client:        deps,
+		resources: map[string]charmresource.Meta{
+			"mysql_image": {
+				Name: "mysql_image",
+				Type: charmresource.TypeContainerImage,
+			},
+		},
+		filesystem: deps,
+	}
+
+	_, err := du.upload(map[string]string{"mysql_image": "foobar.txt"}, nil)
+	c.Check(err, gc.ErrorMatches, `resource "mysql_image" is a container image: file "foobar.txt" does not contain image details: .*`)
+	s.stub.CheckCallNames(c, "Open")
+}
+
+func (s DeploySuite) TestUploadUnreadableFileForContainerImage(c *gc.C) {
+	deps := uploadDeps{stub: s.stub}
+	du := deployUploader{
+		applicationID: "mysql",
+		chID:          client.CharmID{URL: charm.MustParseURL("cs:~a-user/mysql-k8s-5")},
+		client:        deps,
+		resources: map[string]charmresource.Meta{
+			"mysql_image": {
+				Name: "mysql_image",
+				Type: charmresource.TypeContainerImage,
+			},
+		},
+		filesystem: deps,
+	}
+	s.stub.SetErrors(os.ErrPermission)
+
+	// The file name is also a valid registry path, but is
+	// not uploaded as one when the file cannot be read.
+	_, err := du.upload(map[string]string{"mysql_image": "image.yaml"}, nil)
+	c.Check(err, gc.ErrorMatches, `resource "mysql_image" is a container image: cannot open image details file "image.yaml": permission denied`)
+	s.stub.CheckCallNames(c, "Open")
+}
+
+func (s DeploySuite) TestUploadRevisionForLocalCharm(c *gc.C) {
+	deps := uploadDeps{stub: s.stub}
+	du := deployUploader{
+		applicationID: "mysql",
+		chID:          client.CharmID{URL: charm.MustParseURL("local:trusty/spam-5")},
+		client:        deps,
+		resources: map[string]charmresource.Meta{
+			"res1": {
+				Name: "res1",
+				Type: charmresource.TypeFile,
+				Path: "path",
+			},
+		},
+		filesystem: deps,
+	}
+
+	_, err := du.upload(nil, map[string]int{"res1": 3})
+	c.Check(err, gc.ErrorMatches, `resource "res1" of a local charm must be uploaded, not given a revision`)
+	s.stub.CheckNoCalls(c)
+}
+
 func (s DeploySuite) TestDeployDockerResourceRegistryPathString(c *gc.C) {
 	deps := uploadDeps{stub: s.stub}
 	cURL := charm.MustParseURL("cs:~a-user/mysql-k8s-5")
@@ -347,11 +603,15 @@ func (s DeploySuite) TestDeployDockerResourceRegistryPathString(c *gc.C) {
 		resources:     resourceMeta,
 		filesystem:    deps,
 	}
-	ids, err := du.upload(passedResourceValues, map[string]int{})
+	deployed, err := du.upload(passedResourceValues, map[string]int{})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Check(ids, gc.DeepEquals, map[string]string{
-		"mysql_image": "id-mysql_image",
-	})
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "mysql_image",
+		PendingID: "id-mysql_image",
+		Origin:    charmresource.OriginUpload,
+		Revision:  -1,
+		Source:    "mariadb:10.3.8",
+	}})
 
 	expectedUpload := charmresource.Resource{
 		Meta:   resourceMeta["mysql_image"],
@@ -403,11 +663,15 @@ func (s DeploySuite) TestDeployDockerResourceJSONFile(c *gc.C) {
 		resources:     resourceMeta,
 		filesystem:    deps,
 	}
-	ids, err := du.upload(passedResourceValues, map[string]int{})
+	deployed, err := du.upload(passedResourceValues, map[string]int{})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Check(ids, gc.DeepEquals, map[string]string{
-		"mysql_image": "id-mysql_image",
-	})
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "mysql_image",
+		PendingID: "id-mysql_image",
+		Origin:    charmresource.OriginUpload,
+		Revision:  -1,
+		Source:    "registry.staging.jujucharms.com/wallyworld/mysql-k8s/mysql_image",
+	}})
 
 	expectedUpload := charmresource.Resource{
 		Meta:   resourceMeta["mysql_image"],
@@ -457,11 +721,15 @@ password: hunter2
 		resources:     resourceMeta,
 		filesystem:    deps,
 	}
-	ids, err := du.upload(passedResourceValues, map[string]int{})
+	deployed, err := du.upload(passedResourceValues, map[string]int{})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Check(ids, gc.DeepEquals, map[string]string{
-		"mysql_image": "id-mysql_image",
-	})
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "mysql_image",
+		PendingID: "id-mysql_image",
+		Origin:    charmresource.OriginUpload,
+		Revision:  -1,
+		Source:    "registry.staging.jujucharms.com/wallyworld/mysql-k8s/mysql_image",
+	}})
 
 	expectedUpload := charmresource.Resource{
 		Meta:   resourceMeta["mysql_image"],
@@ -477,6 +745,66 @@ password: hunter2
 	s.stub.CheckCall(c, 2, "UploadPendingResource", "mysql", expectedUpload, jsonFile, expectedUploadData)
 }
 
+func (s DeploySuite) TestDeployDockerResourceInlineCredentials(c *gc.C) {
+	deps := uploadDeps{stub: s.stub}
+	resourceMeta := map[string]charmresource.Meta{
+		"mysql_image": {
+			Name: "mysql_image",
+			Type: charmresource.TypeContainerImage,
+		},
+	}
+	du := deployUploader{
+		applicationID: "mysql",
+		chID:          client.CharmID{URL: charm.MustParseURL("cs:~a-user/mysql-k8s-5")},
+		client:        deps,
+		resources:     resourceMeta,
+		filesystem:    deps,
+	}
+	value := `{"ImageName": "registry.example.com/me/mysql:8.0", "Username": "docker-registry", "Password": "hunter2"}`
+	deployed, err := du.upload(map[string]string{"mysql_image": value}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deployed, jc.DeepEquals, []DeployedResource{{
+		Name:      "mysql_image",
+		PendingID: "id-mysql_image",
+		Origin:    charmresource.OriginUpload,
+		Revision:  -1,
+		Source:    "registry.example.com/me/mysql:8.0",
+	}})
+
+	expectedUpload := charmresource.Resource{
+		Meta:   resourceMeta["mysql_image"],
+		Origin: charmresource.OriginUpload,
+	}
+	expectedUploadData := `
+registrypath: registry.example.com/me/mysql:8.0
+username: docker-registry
+password: hunter2
+`[1:]
+	// The credentials are not sent as the filename.
+	s.stub.CheckCallNames(c, "UploadPendingResource")
+	s.stub.CheckCall(c, 0, "UploadPendingResource", "mysql", expectedUpload, "mysql_image", expectedUploadData)
+}
+
+func (s DeploySuite) TestDeployDockerResourceInlineInvalidPath(c *gc.C) {
+	deps := uploadDeps{stub: s.stub}
+	du := deployUploader{
+		applicationID: "mysql",
+		chID:          client.CharmID{URL: charm.MustParseURL("cs:~a-user/mysql-k8s-5")},
+		client:        deps,
+		resources: map[string]charmresource.Meta{
+			"mysql_image": {
+				Name: "mysql_image",
+				Type: charmresource.TypeContainerImage,
+			},
+		},
+		filesystem: deps,
+	}
+	value := `{"ImageName": "Not/A/Valid:Path:", "Username": "docker-registry", "Password": "hunter2"}`
+	_, err := du.upload(map[string]string{"mysql_image": value}, nil)
+	c.Check(err, gc.ErrorMatches, `resource "mysql_image" is a container image: inline image details: docker image path "Not/A/Valid:Path:" not valid`)
+	s.stub.CheckNoCalls(c)
+}
+
 func (s DeploySuite) TestUnMarshallingDockerDetails(c *gc.C) {
 	content := `
 registrypath: registry.staging.jujucharms.com/wallyworld/mysql-k8s/mysql_image
@@ -555,6 +883,39 @@ func (s DeploySuite) TestGetDockerDetailsData(c *gc.C) {
 	})
 }
 
+func (s DeploySuite) TestDeployedResourceString(c *gc.C) {
+	for i, test := range []struct {
+		res    DeployedResource
+		expect string
+	}{{
+		res:    DeployedResource{Name: "store", Origin: charmresource.OriginStore, Revision: 4},
+		expect: `using resource "store" revision 4 from store`,
+	}, {
+		res:    DeployedResource{Name: "store", Origin: charmresource.OriginStore, Revision: -1},
+		expect: `using latest revision of resource "store" from store`,
+	}, {
+		res:    DeployedResource{Name: "upload", Origin: charmresource.OriginUpload, Revision: -1, Source: "foo.tgz", Size: 42},
+		expect: `using resource "upload" uploaded from "foo.tgz" (42 bytes)`,
+	}, {
+		res:    DeployedResource{Name: "image", Origin: charmresource.OriginUpload, Revision: -1, Source: "mariadb:10.3.8"},
+		expect: `using resource "image" uploaded from "mariadb:10.3.8"`,
+	}} {
+		c.Logf("test %d: %s", i, test.expect)
+		c.Check(test.res.String(), gc.Equals, test.expect)
+	}
+}
+
+func (s DeploySuite) TestPendingIDs(c *gc.C) {
+	c.Check(PendingIDs(nil), gc.IsNil)
+	c.Check(PendingIDs([]DeployedResource{
+		{Name: "store", PendingID: "id-store"},
+		{Name: "upload", PendingID: "id-upload"},
+	}), jc.DeepEquals, map[string]string{
+		"store":  "id-store",
+		"upload": "id-upload",
+	})
+}
+
 type uploadDeps struct {
 	modelcmd.Filesystem
 	stub *testing.Stub
@@ -589,6 +950,23 @@ func (s uploadDeps) UploadPendingResource(applicationID string, resource charmre
 	return "id-" + resource.Name, nil
 }
 
+// blockingUploadDeps is an uploadDeps for which adding
+// and uploading resources block until released.
+type blockingUploadDeps struct {
+	uploadDeps
+	release <-chan struct{}
+}
+
+func (s blockingUploadDeps) AddPendingResources(applicationID string, charmID client.CharmID, csMac *macaroon.Macaroon, resources []charmresource.Resource) (ids []string, err error) {
+	<-s.release
+	return nil, errors.New("released")
+}
+
+func (s blockingUploadDeps) UploadPendingResource(applicationID string, resource charmresource.Resource, filename string, r io.ReadSeeker) (id string, err error) {
+	<-s.release
+	return "", errors.New("released")
+}
+
 func (s uploadDeps) Open(name string) (modelcmd.ReadSeekCloser, error) {
 	s.stub.AddCall("Open", name)
 	if err := s.stub.NextErr(); err != nil {
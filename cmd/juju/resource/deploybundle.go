@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/macaroon.v2"
+
+	apiresources "github.com/juju/juju/api/resources/client"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// BundleApplicationResources holds the resources
+// for one application deployed from a bundle.
+type BundleApplicationResources struct {
+	// CharmID identifies the application's charm.
+	CharmID apiresources.CharmID
+
+	// CharmStoreMacaroon is the macaroon to use for the charm when
+	// interacting with the charm store.
+	CharmStoreMacaroon *macaroon.Macaroon
+
+	// ResourceValues is the set of resources for which
+	// a value was provided in the bundle.
+	ResourceValues map[string]string
+
+	// Revisions is the set of resources for which
+	// a revision was provided in the bundle.
+	Revisions map[string]int
+
+	// ResourcesMeta holds the charm metadata for each of the resources
+	// that should be added/updated on the controller.
+	ResourcesMeta map[string]charmresource.Meta
+}
+
+// DeployBundleResourcesArgs holds the arguments to DeployBundleResources().
+type DeployBundleResourcesArgs struct {
+	// Applications holds the resources for each
+	// application, keyed by application name.
+	Applications map[string]BundleApplicationResources
+
+	// Client is the resources API client to use during deploy.
+	Client DeployClient
+
+	// Filesystem provides access to the filesystem.
+	Filesystem modelcmd.Filesystem
+
+	// UploadTimeout, if non-zero, is the time allowed for each
+	// uploaded resource, and for adding the store resources of
+	// each application, before giving up on the application.
+	UploadTimeout time.Duration
+
+	// Clock is used to enforce the upload timeout.
+	// If nil, the wall clock is used.
+	Clock clock.Clock
+
+	// HTTPClient is used to download file resources given as
+	// "url=<url>" values. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// DeployBundleResources adds the pending resources for each application
+// deployed from a bundle, validating and uploading them as DeployResources
+// does for a single application. It returns a map of resource name to
+// pending resource ID for each application, keyed by application name.
+//
+// A local file used by more than one application is opened and read from
+// once. Its content is still uploaded for each application, as pending
+// resources belong to a single application on the controller.
+//
+// A failure for one application does not prevent the resources of the
+// others being added. The errors for all applications are returned
+// together, along with the pending IDs of the applications that succeeded.
+func DeployBundleResources(args DeployBundleResourcesArgs) (map[string]map[string]string, error) {
+	filesystem := newSharedFilesystem(args.Filesystem)
+	defer filesystem.closeAll()
+
+	appNames := make([]string, 0, len(args.Applications))
+	for name := range args.Applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+
+	var errs []string
+	pendingIDs := make(map[string]map[string]string, len(appNames))
+	for _, name := range appNames {
+		app := args.Applications[name]
+		ids, err := DeployResourceIDs(DeployResourcesArgs{
+			ApplicationID:      name,
+			CharmID:            app.CharmID,
+			CharmStoreMacaroon: app.CharmStoreMacaroon,
+			ResourceValues:     app.ResourceValues,
+			Revisions:          app.Revisions,
+			ResourcesMeta:      app.ResourcesMeta,
+			Client:             args.Client,
+			Filesystem:         filesystem,
+			UploadTimeout:      args.UploadTimeout,
+			Clock:              args.Clock,
+			HTTPClient:         args.HTTPClient,
+		})
+		if err != nil {
+			errs = append(errs, errors.Annotatef(err, "application %q", name).Error())
+			// An upload that timed out may still be reading from
+			// the files, so they are not shared with later uploads.
+			filesystem.forget()
+			continue
+		}
+		pendingIDs[name] = ids
+	}
+	if len(errs) > 0 {
+		return pendingIDs, errors.New(strings.Join(errs, "\n"))
+	}
+	return pendingIDs, nil
+}
+
+// sharedFilesystem is a modelcmd.Filesystem opening each file once,
+// so that a file used for the resources of more than one application
+// is only read from disk once. Each Open returns the same file,
+// positioned at the start. The files are closed by closeAll.
+type sharedFilesystem struct {
+	modelcmd.Filesystem
+
+	files  map[string]*sharedFile
+	stats  map[string]os.FileInfo
+	opened []*sharedFile
+}
+
+func newSharedFilesystem(filesystem modelcmd.Filesystem) *sharedFilesystem {
+	return &sharedFilesystem{
+		Filesystem: filesystem,
+		files:      make(map[string]*sharedFile),
+		stats:      make(map[string]os.FileInfo),
+	}
+}
+
+// Open is part of the modelcmd.Filesystem interface.
+func (fs *sharedFilesystem) Open(name string) (modelcmd.ReadSeekCloser, error) {
+	key := filepath.Clean(name)
+	f, ok := fs.files[key]
+	if !ok {
+		opened, err := fs.Filesystem.Open(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		f = &sharedFile{ReadSeekCloser: opened}
+		fs.files[key] = f
+		fs.opened = append(fs.opened, f)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+// Stat is part of the modelcmd.Filesystem interface.
+func (fs *sharedFilesystem) Stat(name string) (os.FileInfo, error) {
+	key := filepath.Clean(name)
+	if info, ok := fs.stats[key]; ok {
+		return info, nil
+	}
+	info, err := fs.Filesystem.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	fs.stats[key] = info
+	return info, nil
+}
+
+// forget stops the files opened so far being shared,
+// so that they are opened again when next used.
+func (fs *sharedFilesystem) forget() {
+	fs.files = make(map[string]*sharedFile)
+}
+
+func (fs *sharedFilesystem) closeAll() {
+	for _, f := range fs.opened {
+		_ = f.ReadSeekCloser.Close()
+	}
+}
+
+// sharedFile is a file opened by a sharedFilesystem,
+// which is only closed once all uploads are done.
+type sharedFile struct {
+	modelcmd.ReadSeekCloser
+}
+
+// Close is part of the io.Closer interface.
+func (*sharedFile) Close() error {
+	return nil
+}
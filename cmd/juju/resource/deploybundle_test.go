@@ -0,0 +1,138 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/juju/charm/v9"
+	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/resources/client"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+type DeployBundleSuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+}
+
+var _ = gc.Suite(&DeployBundleSuite{})
+
+func (s *DeployBundleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+}
+
+func (s *DeployBundleSuite) TestSharedLocalFile(c *gc.C) {
+	uploadMeta := charmresource.Meta{
+		Name: "upload",
+		Type: charmresource.TypeFile,
+		Path: "upload",
+	}
+	storeMeta := charmresource.Meta{
+		Name: "store",
+		Type: charmresource.TypeFile,
+		Path: "store",
+	}
+	mysqlID := client.CharmID{URL: charm.MustParseURL("cs:mysql-5")}
+	wordpressID := client.CharmID{URL: charm.MustParseURL("cs:wordpress-7")}
+
+	ids, err := DeployBundleResources(DeployBundleResourcesArgs{
+		Applications: map[string]BundleApplicationResources{
+			"mysql": {
+				CharmID:        mysqlID,
+				ResourceValues: map[string]string{"upload": "foobar.txt"},
+				ResourcesMeta:  map[string]charmresource.Meta{"upload": uploadMeta, "store": storeMeta},
+			},
+			"wordpress": {
+				CharmID:        wordpressID,
+				ResourceValues: map[string]string{"upload": "./foobar.txt"},
+				ResourcesMeta:  map[string]charmresource.Meta{"upload": uploadMeta},
+			},
+		},
+		Client:     uploadDeps{stub: s.stub},
+		Filesystem: bundleFiles{stub: s.stub, data: []byte("file contents")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, map[string]map[string]string{
+		"mysql":     {"store": "id-store", "upload": "id-upload"},
+		"wordpress": {"upload": "id-upload"},
+	})
+
+	// The file is checked and opened once, and
+	// its content uploaded for each application.
+	s.stub.CheckCallNames(c,
+		"Stat", "AddPendingResources", "Open", "UploadPendingResource",
+		"UploadPendingResource",
+	)
+	uploaded := charmresource.Resource{Meta: uploadMeta, Origin: charmresource.OriginUpload}
+	s.stub.CheckCall(c, 3, "UploadPendingResource", "mysql", uploaded, "foobar.txt", "file contents")
+	s.stub.CheckCall(c, 4, "UploadPendingResource", "wordpress", uploaded, "./foobar.txt", "file contents")
+}
+
+func (s *DeployBundleSuite) TestApplicationErrors(c *gc.C) {
+	meta := map[string]charmresource.Meta{
+		"upload": {
+			Name: "upload",
+			Type: charmresource.TypeFile,
+			Path: "upload",
+		},
+	}
+	ids, err := DeployBundleResources(DeployBundleResourcesArgs{
+		Applications: map[string]BundleApplicationResources{
+			"mysql": {
+				CharmID:        client.CharmID{URL: charm.MustParseURL("cs:mysql-5")},
+				ResourceValues: map[string]string{"bogus": "foobar.txt"},
+				ResourcesMeta:  meta,
+			},
+			"redis": {
+				CharmID:       client.CharmID{URL: charm.MustParseURL("local:redis-1")},
+				Revisions:     map[string]int{"upload": 3},
+				ResourcesMeta: meta,
+			},
+			"wordpress": {
+				CharmID:        client.CharmID{URL: charm.MustParseURL("cs:wordpress-7")},
+				ResourceValues: map[string]string{"upload": "foobar.txt"},
+				ResourcesMeta:  meta,
+			},
+		},
+		Client:     uploadDeps{stub: s.stub},
+		Filesystem: bundleFiles{stub: s.stub, data: []byte("file contents")},
+	})
+	c.Assert(err, gc.ErrorMatches, `application "mysql": unrecognized resource "bogus"
+application "redis": resource "upload" of a local charm must be uploaded, not given a revision`)
+
+	// The resources of the other applications are still added.
+	c.Check(ids, jc.DeepEquals, map[string]map[string]string{
+		"wordpress": {"upload": "id-upload"},
+	})
+}
+
+// bundleFiles is a modelcmd.Filesystem in which each file
+// holds the same data, which may be read more than once.
+type bundleFiles struct {
+	modelcmd.Filesystem
+	stub *testing.Stub
+	data []byte
+}
+
+func (f bundleFiles) Open(name string) (modelcmd.ReadSeekCloser, error) {
+	f.stub.AddCall("Open", name)
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return noopCloser{bytes.NewReader(f.data)}, nil
+}
+
+func (f bundleFiles) Stat(name string) (os.FileInfo, error) {
+	f.stub.AddCall("Stat", name)
+	return nil, f.stub.NextErr()
+}
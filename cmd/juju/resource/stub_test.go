@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/resource"
 	"github.com/juju/testing"
 
+	apiresources "github.com/juju/juju/api/resources/client"
 	"github.com/juju/juju/charmstore"
 )
 
@@ -35,8 +36,8 @@ type stubAPIClient struct {
 	resources resource.ApplicationResources
 }
 
-func (s *stubAPIClient) Upload(application, name, filename string, resource io.ReadSeeker) error {
-	s.stub.AddCall("Upload", application, name, filename, resource)
+func (s *stubAPIClient) Upload(application, name, filename string, resource io.ReadSeeker, opts apiresources.UploadOptions) error {
+	s.stub.AddCall("Upload", application, name, filename, resource, opts)
 	if err := s.stub.NextErr(); err != nil {
 		return errors.Trace(err)
 	}
@@ -5,22 +5,26 @@ package resource
 
 import (
 	"io"
+	"io/ioutil"
 
 	charmresource "github.com/juju/charm/v9/resource"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
+	apiresources "github.com/juju/juju/api/resources/client"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 )
 
 // UploadClient has the API client methods needed by UploadCommand.
 type UploadClient interface {
 	// Upload sends the resource to Juju.
-	Upload(application, name, filename string, resource io.ReadSeeker) error
+	Upload(application, name, filename string, resource io.ReadSeeker, opts apiresources.UploadOptions) error
 
 	// ListResources returns info about resources for applications in the model.
 	ListResources(applications []string) ([]resource.ApplicationResources, error)
@@ -34,14 +38,20 @@ type UploadClient interface {
 type UploadDeps struct {
 	// NewClient returns the value that wraps the API for uploading to the server.
 	NewClient func(*UploadCommand) (UploadClient, error)
+
+	// RegistryChecker, if set, is used to verify that a container
+	// image resource can be retrieved from its registry before it
+	// is uploaded.
+	RegistryChecker resources.RegistryChecker
 }
 
 // UploadCommand implements the upload command.
 type UploadCommand struct {
 	deps UploadDeps
 	modelcmd.ModelCommandBase
-	application   string
-	resourceValue resourceValue
+	application       string
+	resourceValue     resourceValue
+	skipRegistryCheck bool
 }
 
 // NewUploadCommand returns a new command that lists resources defined
@@ -60,6 +70,11 @@ streamed to the charm when "resource-get" is called by a hook.
 For OCI image resources used by k8s applications, an OCI image or file path is specified.
 A file is specified when a private OCI image is needed and the username/password used to
 access the image is needed along with the image path.
+
+Container image resources are checked against their registry, both here and
+by the controller, to ensure that the image can be retrieved with the supplied
+credentials. Use --skip-registry-check when the registry cannot be reached
+from the client or the controller.
 `
 )
 
@@ -74,6 +89,12 @@ func (c *UploadCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.SetFlags.
+func (c *UploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.skipRegistryCheck, "skip-registry-check", false, "Do not verify that a container image can be retrieved from its registry")
+}
+
 // Init implements cmd.Command.Init. It will return an error satisfying
 // errors.BadRequest if you give it an incorrect number of arguments.
 func (c *UploadCommand) Init(args []string) error {
@@ -146,9 +167,36 @@ func (c *UploadCommand) upload(rf resourceValue, client UploadClient) error {
 		return errors.Trace(err)
 	}
 	defer f.Close()
-	err = client.Upload(rf.application, rf.name, rf.value, f)
+	if rf.resourceType == charmresource.TypeContainerImage && !c.skipRegistryCheck && c.deps.RegistryChecker != nil {
+		if err := c.checkImage(f); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	err = client.Upload(rf.application, rf.name, rf.value, f, apiresources.UploadOptions{
+		SkipRegistryCheck: c.skipRegistryCheck,
+	})
 	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
 }
+
+// checkImage verifies that the container image described by the
+// given resource content can be retrieved from its registry, so that
+// bad credentials are reported before anything is sent to Juju. The
+// content is rewound afterwards, ready for upload.
+func (c *UploadCommand) checkImage(f io.ReadSeeker) error {
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details, err := resources.UnmarshalDockerResource(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.deps.RegistryChecker.CheckImage(details); err != nil {
+		return errors.Annotate(err, "checking image registry (use --skip-registry-check to bypass)")
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return errors.Trace(err)
+}
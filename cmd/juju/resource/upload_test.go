@@ -8,14 +8,17 @@ import (
 
 	charmresource "github.com/juju/charm/v9/resource"
 	jujucmd "github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	apiresources "github.com/juju/juju/api/resources/client"
 	"github.com/juju/juju/apiserver/params"
 	resourcecmd "github.com/juju/juju/cmd/juju/resource"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/resource"
 )
 
@@ -108,6 +111,11 @@ streamed to the charm when "resource-get" is called by a hook.
 For OCI image resources used by k8s applications, an OCI image or file path is specified.
 A file is specified when a private OCI image is needed and the username/password used to
 access the image is needed along with the image path.
+
+Container image resources are checked against their registry, both here and
+by the controller, to ensure that the image can be retrieved with the supplied
+credentials. Use --skip-registry-check when the registry cannot be reached
+from the client or the controller.
 `,
 		Aliases:        []string{"attach"},
 		FlagKnownAs:    "option",
@@ -138,7 +146,7 @@ func (s *UploadSuite) TestUploadFileResource(c *gc.C) {
 	)
 	s.stub.CheckCall(c, 1, "ListResources", []string{"svc"})
 	s.stub.CheckCall(c, 2, "OpenResource", "bar")
-	s.stub.CheckCall(c, 3, "Upload", "svc", "foo", "bar", file)
+	s.stub.CheckCall(c, 3, "Upload", "svc", "foo", "bar", file, apiresources.UploadOptions{})
 }
 
 func (s *UploadSuite) TestUploadFileChangeBlocked(c *gc.C) {
@@ -171,7 +179,7 @@ func (s *UploadSuite) TestUploadFileChangeBlocked(c *gc.C) {
 	)
 	s.stub.CheckCall(c, 1, "ListResources", []string{"svc"})
 	s.stub.CheckCall(c, 2, "OpenResource", "bar")
-	s.stub.CheckCall(c, 3, "Upload", "svc", "foo", "bar", file)
+	s.stub.CheckCall(c, 3, "Upload", "svc", "foo", "bar", file, apiresources.UploadOptions{})
 }
 
 type rsc struct {
@@ -221,6 +229,112 @@ password: hunter2
 	s.stub.CheckCall(c, 2, "OpenResource", "bar")
 }
 
+func (s *UploadSuite) setUpDockerResource() {
+	fileContents := `
+registrypath: registry.staging.jujucharms.com/wallyworld/mysql-k8s/mysql_image
+username: docker-registry
+password: hunter2
+`
+	s.stubDeps.file = rsc{bytes.NewBuffer([]byte(fileContents))}
+	s.stubDeps.client.(*stubAPIClient).resources = resource.ApplicationResources{
+		Resources: []resource.Resource{{Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name: "foo",
+				Type: charmresource.TypeContainerImage,
+			},
+		}}},
+	}
+}
+
+func (s *UploadSuite) TestUploadDockerResourceChecksRegistry(c *gc.C) {
+	s.setUpDockerResource()
+	checker := &stubRegistryChecker{stub: s.stub}
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{
+		NewClient:       s.stubDeps.NewClient,
+		RegistryChecker: checker,
+	}, s.stubDeps,
+	)
+	err := u.Init([]string{"svc", "foo=bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"ListResources",
+		"OpenResource",
+		"CheckImage",
+		"Upload",
+		"Close",
+	)
+	s.stub.CheckCall(c, 3, "CheckImage", resources.DockerImageDetails{
+		RegistryPath: "registry.staging.jujucharms.com/wallyworld/mysql-k8s/mysql_image",
+		Username:     "docker-registry",
+		Password:     "hunter2",
+	})
+}
+
+func (s *UploadSuite) TestUploadDockerResourceRegistryCheckFails(c *gc.C) {
+	s.setUpDockerResource()
+	checker := &stubRegistryChecker{stub: s.stub}
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{
+		NewClient:       s.stubDeps.NewClient,
+		RegistryChecker: checker,
+	}, s.stubDeps,
+	)
+	err := u.Init([]string{"svc", "foo=bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.SetErrors(nil, nil, nil, errors.Unauthorizedf("registry credentials"))
+
+	err = u.Run(nil)
+	c.Assert(err, gc.ErrorMatches, `failed to upload resource "foo": checking image registry \(use --skip-registry-check to bypass\): registry credentials`)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"ListResources",
+		"OpenResource",
+		"CheckImage",
+		"Close",
+	)
+}
+
+func (s *UploadSuite) TestUploadDockerResourceSkipRegistryCheck(c *gc.C) {
+	s.setUpDockerResource()
+	checker := &stubRegistryChecker{stub: s.stub}
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{
+		NewClient:       s.stubDeps.NewClient,
+		RegistryChecker: checker,
+	}, s.stubDeps,
+	)
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo=bar", "--skip-registry-check"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"ListResources",
+		"OpenResource",
+		"Upload",
+		"Close",
+	)
+	c.Check(s.stub.Calls()[3].Args[4], jc.DeepEquals, apiresources.UploadOptions{
+		SkipRegistryCheck: true,
+	})
+}
+
+type stubRegistryChecker struct {
+	stub *testing.Stub
+}
+
+func (s *stubRegistryChecker) CheckImage(details resources.DockerImageDetails) error {
+	s.stub.AddCall("CheckImage", details)
+	return s.stub.NextErr()
+}
+
 type stubUploadDeps struct {
 	modelcmd.Filesystem
 	stub   *testing.Stub
@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/pubsub/lease"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage/plans"
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
@@ -62,6 +63,7 @@ import (
 	"github.com/juju/juju/worker/httpserverargs"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/instancemutater"
+	"github.com/juju/juju/worker/leadershippinner"
 	leasemanager "github.com/juju/juju/worker/lease/manifold"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
@@ -422,6 +424,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			InitializedGateName:  modelCacheInitializedGateName,
 			Logger:               loggo.GetLogger("juju.worker.modelcache"),
 			PrometheusRegisterer: config.PrometheusRegisterer,
+			GetControllerConfig:  modelcache.GetControllerConfig,
 			NewWorker:            modelcache.NewWorker,
 		}))),
 
@@ -931,6 +934,19 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     upgradeseries.NewWorker,
 		})),
 
+		// The leadership pinner keeps leadership pinned for applications
+		// with units on the machine while it is locked for upgrade-series,
+		// re-establishing pins that may have been lost if the agent
+		// restarted part way through the upgrade.
+		leadershipPinnerName: ifNotMigrating(leadershippinner.Manifold(leadershippinner.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Logger:        loggo.GetLogger("juju.worker.leadershippinner"),
+			NewFacade:     leadershippinner.NewFacade,
+			NewWorker:     leadershippinner.NewWorker,
+		})),
+
 		// The deployer worker is primary for deploying and recalling unit
 		// agents, according to changes in a set of state units; and for the
 		// final removal of its agents' units from state when they are no
@@ -965,6 +981,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName:                apiCallerName,
 			Clock:                        config.Clock,
 			Logger:                       loggo.GetLogger("juju.worker.storageprovisioner"),
+			Plans:                        plans.DefaultRegistry,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 		}))),
 		brokerTrackerName: ifNotMigrating(lxdbroker.Manifold(lxdbroker.ManifoldConfig{
@@ -1133,6 +1150,7 @@ const (
 	leaseManagerName              = "lease-manager"
 
 	upgradeSeriesWorkerName = "upgrade-series"
+	leadershipPinnerName    = "leadership-pinner"
 
 	httpServerName     = "http-server"
 	httpServerArgsName = "http-server-args"
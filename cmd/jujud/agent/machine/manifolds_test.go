@@ -81,6 +81,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"instance-mutater",
 			"is-controller-flag",
 			"is-primary-controller-flag",
+			"leadership-pinner",
 			"lease-clock-updater",
 			"lease-manager",
 			"log-sender",
@@ -624,6 +625,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"leadership-pinner": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"lease-clock-updater": {
 		"agent",
 		"central-hub",
@@ -544,6 +544,7 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 					return caasunitprovisionerapi.NewClient(caller)
 				},
 				NewWorker: caasunitprovisioner.NewWorker,
+				Clock:     config.Clock,
 				Logger:    config.LoggingContext.GetLogger("juju.worker.caasunitprovisioner"),
 			},
 		)),
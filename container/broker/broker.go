@@ -4,6 +4,7 @@
 package broker
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/collections/set"
@@ -181,13 +182,34 @@ func matchHostArchTools(allTools coretools.List) (coretools.List, error) {
 
 var newMachineInitReader = cloudconfig.NewMachineInitReader
 
+// cloudInitListKeys are the cloud-init user data keys that juju
+// merges with its own configuration, and which must be lists.
+var cloudInitListKeys = []string{"packages", "preruncmd", "postruncmd"}
+
 // combinedCloudInitData returns a combined map of the given cloudInitData
 // and instance cloud init properties provided.
+// The model's cloud-init user data is merged with the inherited
+// properties as follows:
+//   - lists are appended, with the model's entries following the host's;
+//   - maps are merged recursively;
+//   - where both supply a scalar value, the model's value is used.
+//
+// A value that is a list or map on one side only can not be merged,
+// and results in an error rather than a broken container.
+// The input cloudInitData is not modified.
 func combinedCloudInitData(
 	cloudInitData map[string]interface{},
 	containerInheritProperties, series string,
 	log loggo.Logger,
 ) (map[string]interface{}, error) {
+	for _, key := range cloudInitListKeys {
+		if v, ok := cloudInitData[key]; ok {
+			if _, isList := v.([]interface{}); !isList {
+				return nil, errors.NotValidf("cloudinit-userdata %q of type %T (expected a list)", key, v)
+			}
+		}
+	}
+
 	if containerInheritProperties == "" {
 		return cloudInitData, nil
 	}
@@ -205,10 +227,6 @@ func combinedCloudInitData(
 		return cloudInitData, nil
 	}
 
-	if cloudInitData == nil {
-		cloudInitData = make(map[string]interface{})
-	}
-
 	props := strings.Split(containerInheritProperties, ",")
 	for i, p := range props {
 		props[i] = strings.TrimSpace(p)
@@ -225,12 +243,86 @@ func combinedCloudInitData(
 		props = append(props, "apt-sources_list")
 	}
 
+	combined := make(map[string]interface{}, len(cloudInitData))
+	for k, v := range cloudInitData {
+		combined[k] = v
+	}
 	resultsMap := reader.ExtractPropertiesFromConfig(props, machineData, log)
 	for k, v := range resultsMap {
-		cloudInitData[k] = v
+		modelValue, ok := combined[k]
+		if !ok {
+			combined[k] = v
+			continue
+		}
+		merged, err := mergeCloudInitValue(k, v, modelValue)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		combined[k] = merged
 	}
 
-	return cloudInitData, nil
+	return combined, nil
+}
+
+// mergeCloudInitValue merges the model's value for the input cloud-init
+// key with that inherited from the host, as described for
+// combinedCloudInitData.
+func mergeCloudInitValue(key string, inherited, model interface{}) (interface{}, error) {
+	switch modelValue := model.(type) {
+	case []interface{}:
+		inheritedList, ok := inherited.([]interface{})
+		if !ok {
+			return nil, errors.NotValidf("cloudinit-userdata %q is a list, but the inherited value is %T", key, inherited)
+		}
+		merged := make([]interface{}, 0, len(inheritedList)+len(modelValue))
+		merged = append(merged, inheritedList...)
+		return append(merged, modelValue...), nil
+	case map[string]interface{}, map[interface{}]interface{}:
+		inheritedMap, ok := cloudInitMap(inherited)
+		if !ok {
+			return nil, errors.NotValidf("cloudinit-userdata %q is a map, but the inherited value is %T", key, inherited)
+		}
+		modelMap, _ := cloudInitMap(modelValue)
+		merged := make(map[string]interface{}, len(inheritedMap)+len(modelMap))
+		for k, v := range inheritedMap {
+			merged[k] = v
+		}
+		for k, v := range modelMap {
+			inheritedV, ok := merged[k]
+			if !ok {
+				merged[k] = v
+				continue
+			}
+			mergedV, err := mergeCloudInitValue(key+"."+k, inheritedV, v)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			merged[k] = mergedV
+		}
+		return merged, nil
+	}
+	switch inherited.(type) {
+	case []interface{}, map[string]interface{}, map[interface{}]interface{}:
+		return nil, errors.NotValidf("cloudinit-userdata %q of type %T, but the inherited value is %T", key, model, inherited)
+	}
+	return model, nil
+}
+
+// cloudInitMap returns the input cloud-init value as a map with string
+// keys, and true if it is a map. YAML decoding of cloud-init files
+// results in maps keyed by interface{} values.
+func cloudInitMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			result[fmt.Sprint(k)] = v
+		}
+		return result, true
+	}
+	return nil, false
 }
 
 // proxyConfigurationFromContainerCfg populates a ProxyConfiguration object
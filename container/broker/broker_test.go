@@ -8,6 +8,7 @@ import (
 	"net"
 	"path/filepath"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	gitjujutesting "github.com/juju/testing"
@@ -75,6 +76,63 @@ func (s *brokerSuite) TestCombinedCloudInitDataNoContainerInheritProperties(c *g
 	assertCloudInitUserData(obtained, containerConfig.CloudInitUserData, c)
 }
 
+func (s *brokerSuite) TestCombinedCloudInitDataMerges(c *gc.C) {
+	cloudInitUserData := map[string]interface{}{
+		"packages": []interface{}{"python-keystoneclient"},
+		"apt": map[string]interface{}{
+			"proxy": "http://squid.internal:3128",
+		},
+		"ca-certs": map[string]interface{}{
+			"remove-defaults": false,
+			"trusted":         []interface{}{"MODEL-CA-CERT"},
+		},
+	}
+	obtained, err := broker.CombinedCloudInitData(cloudInitUserData, "ca-certs,apt-security", "xenial", loggo.Logger{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(obtained, jc.DeepEquals, map[string]interface{}{
+		"packages": []interface{}{"python-keystoneclient"},
+		"apt": map[string]interface{}{
+			"proxy": "http://squid.internal:3128",
+			"security": []interface{}{
+				map[interface{}]interface{}{
+					"arches": []interface{}{"default"},
+					"uri":    "http://archive.ubuntu.com/ubuntu",
+				},
+			},
+		},
+		"ca-certs": map[string]interface{}{
+			"remove-defaults": false,
+			"trusted": []interface{}{
+				"-----BEGIN CERTIFICATE-----\nYOUR-ORGS-TRUSTED-CA-CERT-HERE\n-----END CERTIFICATE-----\n",
+				"MODEL-CA-CERT",
+			},
+		},
+	})
+
+	// The model's user data is not modified.
+	c.Check(cloudInitUserData["apt"], jc.DeepEquals, map[string]interface{}{
+		"proxy": "http://squid.internal:3128",
+	})
+}
+
+func (s *brokerSuite) TestCombinedCloudInitDataConflict(c *gc.C) {
+	cloudInitUserData := map[string]interface{}{
+		"ca-certs": "MODEL-CA-CERT",
+	}
+	_, err := broker.CombinedCloudInitData(cloudInitUserData, "ca-certs", "xenial", loggo.Logger{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `cloudinit-userdata "ca-certs" of type string, but the inherited value is map.* not valid`)
+}
+
+func (s *brokerSuite) TestCombinedCloudInitDataMalformedList(c *gc.C) {
+	cloudInitUserData := map[string]interface{}{
+		"packages": "python-keystoneclient",
+	}
+	_, err := broker.CombinedCloudInitData(cloudInitUserData, "", "xenial", loggo.Logger{})
+	c.Assert(err, gc.ErrorMatches, `cloudinit-userdata "packages" of type string \(expected a list\) not valid`)
+}
+
 type fakeAddr struct{ value string }
 
 func (f *fakeAddr) Network() string { return "net" }
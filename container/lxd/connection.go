@@ -21,8 +21,12 @@ type Protocol string
 const (
 	LXDProtocol           Protocol = "lxd"
 	SimpleStreamsProtocol Protocol = "simplestreams"
+	OCIProtocol           Protocol = "oci"
 )
 
+// ociScheme is the URL scheme identifying an OCI registry image source.
+const ociScheme = "oci://"
+
 // ServerSpec describes the location and connection details for a
 // server utilized in LXD workflows.
 type ServerSpec struct {
@@ -93,6 +97,22 @@ func MakeSimpleStreamsServerSpec(name, host string) ServerSpec {
 	}
 }
 
+// MakeOCIServerSpec creates a ServerSpec for an OCI registry,
+// ensuring that the host is HTTPS.
+func MakeOCIServerSpec(name, host string) ServerSpec {
+	return ServerSpec{
+		Name:     name,
+		Host:     EnsureHTTPS(host),
+		Protocol: OCIProtocol,
+	}
+}
+
+// IsOCIImageSource returns true if the input image source URL
+// identifies an OCI registry.
+func IsOCIImageSource(source string) bool {
+	return strings.HasPrefix(source, ociScheme)
+}
+
 // Validate ensures that the ServerSpec is valid.
 func (s *ServerSpec) Validate() error {
 	return nil
@@ -123,10 +143,20 @@ func connectImageRemote(remote ServerSpec) (lxd.ImageServer, error) {
 		return lxd.ConnectPublicLXD(remote.Host, remote.connectionArgs)
 	case SimpleStreamsProtocol:
 		return lxd.ConnectSimpleStreams(remote.Host, remote.connectionArgs)
+	case OCIProtocol:
+		return connectOCIRemote(remote)
 	}
 	return nil, fmt.Errorf("bad protocol supplied for connection: %v", remote.Protocol)
 }
 
+// connectOCIRemote connects to an OCI registry as an image remote.
+// The LXD client library that Juju is currently built against predates
+// support for OCI remotes, so this reports the remote as unsupported
+// rather than attempting to speak the registry protocol directly.
+func connectOCIRemote(remote ServerSpec) (lxd.ImageServer, error) {
+	return nil, errors.NotSupportedf("connecting to OCI image remote %q", remote.Host)
+}
+
 func connectLocal() (lxd.ContainerServer, error) {
 	client, err := lxd.ConnectLXDUnix(SocketPath(nil), nil)
 	return client, errors.Trace(err)
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/retry"
 	"github.com/juju/utils/v2/arch"
+	lxd "github.com/lxc/lxd/client"
 	"github.com/lxc/lxd/shared/api"
 	"github.com/lxc/lxd/shared/units"
 	"github.com/lxc/lxd/shared/version"
@@ -27,7 +28,11 @@ const (
 	UserDataKey         = UserNamespacePrefix + "user-data"
 	NetworkConfigKey    = UserNamespacePrefix + "network-config"
 	JujuModelKey        = UserNamespacePrefix + "juju-model"
-	AutoStartKey        = "boot.autostart"
+
+	// JujuImageFingerprintKey records the fingerprint
+	// of the image from which the container was created.
+	JujuImageFingerprintKey = UserNamespacePrefix + "juju-image-fingerprint"
+	AutoStartKey            = "boot.autostart"
 )
 
 // ContainerSpec represents the data required to create a new container.
@@ -103,6 +108,10 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 // Container extends the upstream LXD container type.
 type Container struct {
 	api.Container
+
+	// inDefaultProject is true if the container was found in the
+	// default project rather than the project the server is scoped to.
+	inDefaultProject bool
 }
 
 // Metadata returns the value from container config for the input key.
@@ -196,8 +205,35 @@ func (s *Server) AliveContainers(prefix string) ([]Container, error) {
 
 // FilterContainers retrieves the list of containers from the server and filters
 // them based on the input namespace prefix and any supplied statuses.
+// When scoped to a project, containers in the default project that were
+// created before projects were used are included.
 func (s *Server) FilterContainers(prefix string, statuses ...string) ([]Container, error) {
-	containers, err := s.GetContainers()
+	results, err := filterContainers(s.ContainerServer, prefix, statuses)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	legacy, err := s.defaultProjectContainers(prefix, statuses)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(legacy) == 0 {
+		return results, nil
+	}
+	found := make(map[string]bool, len(results))
+	for _, c := range results {
+		found[c.Name] = true
+	}
+	for _, c := range legacy {
+		if !found[c.Name] {
+			results = append(results, c)
+		}
+	}
+	return results, nil
+}
+
+func filterContainers(svr lxd.ContainerServer, prefix string, statuses []string) ([]Container, error) {
+	containers, err := svr.GetContainers()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -210,16 +246,22 @@ func (s *Server) FilterContainers(prefix string, statuses ...string) ([]Containe
 		if len(statuses) > 0 && !containerHasStatus(c, statuses) {
 			continue
 		}
-		results = append(results, Container{c})
+		results = append(results, Container{Container: c})
 	}
 	return results, nil
 }
 
 // ContainerAddresses gets usable network addresses for the container
 // identified by the input name.
+// If the container is not found in the project the server is scoped to,
+// it is looked for in the default project.
 func (s *Server) ContainerAddresses(name string) ([]corenetwork.ProviderAddress, error) {
 	state, _, err := s.GetContainerState(name)
 	if err != nil {
+		if unscoped := s.defaultProjectView(); unscoped != nil && IsLXDNotFound(errors.Cause(err)) {
+			addrs, err := unscoped.ContainerAddresses(name)
+			return addrs, errors.Trace(err)
+		}
 		return nil, errors.Trace(err)
 	}
 
@@ -292,7 +334,7 @@ func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	c := Container{*container}
+	c := Container{Container: *container}
 	return &c, nil
 }
 
@@ -334,9 +376,14 @@ func (s *Server) RemoveContainers(names []string) error {
 
 // Remove container first ensures that the container is stopped,
 // then deletes it.
+// If the container is not found in the project the server is scoped to,
+// it is removed from the default project.
 func (s *Server) RemoveContainer(name string) error {
 	state, eTag, err := s.GetContainerState(name)
 	if err != nil {
+		if unscoped := s.defaultProjectView(); unscoped != nil && IsLXDNotFound(errors.Cause(err)) {
+			return errors.Trace(unscoped.RemoveContainer(name))
+		}
 		return errors.Trace(err)
 	}
 
@@ -396,7 +443,7 @@ func (s *Server) RemoveContainer(name string) error {
 // WriteContainer writes the current representation of the input container to
 // the server.
 func (s *Server) WriteContainer(c *Container) error {
-	resp, err := s.UpdateContainer(c.Name, c.Writable(), "")
+	resp, err := s.containerServerFor(*c).UpdateContainer(c.Name, c.Writable(), "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -117,7 +117,7 @@ func (s *containerSuite) TestFilterContainers(c *gc.C) {
 
 	expected := make([]lxd.Container, len(matching))
 	for i, v := range matching {
-		expected[i] = lxd.Container{v}
+		expected[i] = lxd.Container{Container: v}
 	}
 
 	c.Check(filtered, gc.DeepEquals, expected)
@@ -156,7 +156,7 @@ func (s *containerSuite) TestAliveContainers(c *gc.C) {
 
 	expected := make([]lxd.Container, len(matching))
 	for i, v := range matching {
-		expected[i] = lxd.Container{v}
+		expected[i] = lxd.Container{Container: v}
 	}
 	c.Check(filtered, gc.DeepEquals, expected)
 }
@@ -35,6 +35,14 @@ func PatchConnectRemote(patcher patcher, remotes map[string]lxdclient.ImageServe
 	})
 }
 
+// PatchImageFingerprint ensures that the ImageFingerprint function always
+// returns the supplied fingerprint and error.
+func PatchImageFingerprint(patcher patcher, fingerprint string, err error) {
+	patcher.PatchValue(&ImageFingerprint, func(ServerSpec, string, string, string, bool) (string, error) {
+		return fingerprint, err
+	})
+}
+
 func PatchGenerateVirtualMACAddress(patcher patcher) {
 	patcher.PatchValue(&network.GenerateVirtualMACAddress, func() string {
 		return "00:16:3e:00:00:00"
@@ -49,10 +57,22 @@ func PatchHostSeries(patcher patcher, series string) {
 	patcher.PatchValue(&hostSeries, func() (string, error) { return series, nil })
 }
 
+func PatchSysClassNetPath(patcher patcher, path string) {
+	patcher.PatchValue(&sysClassNetPath, path)
+}
+
 func PatchGetSnapManager(patcher patcher, mgr SnapManager) {
 	patcher.PatchValue(&getSnapManager, func() SnapManager { return mgr })
 }
 
+// LockImageMutex acquires the lock held by the manager while finding
+// an image, and returns the function that releases it.
+func LockImageMutex(mgr container.Manager) func() {
+	m := mgr.(*containerManager)
+	m.imageMutex.Lock()
+	return m.imageMutex.Unlock
+}
+
 func GetImageSources(mgr container.Manager) ([]ServerSpec, error) {
 	return mgr.(*containerManager).getImageSources()
 }
@@ -10,12 +10,16 @@ import (
 	"github.com/juju/errors"
 	jujuos "github.com/juju/os/v2"
 	jujuseries "github.com/juju/os/v2/series"
+	"github.com/juju/utils/v2"
 	jujuarch "github.com/juju/utils/v2/arch"
 	lxd "github.com/lxc/lxd/client"
 	"github.com/lxc/lxd/shared/api"
 
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
 )
 
 // SourcedImage is the result of a successful image acquisition.
@@ -67,7 +71,7 @@ func (s *Server) FindImage(
 	}
 
 	sourced := SourcedImage{}
-	lastErr := fmt.Errorf("no matching image found")
+	lastErr := errors.NotFoundf("image for series %q and architecture %q", series, arch)
 
 	// We don't have an image locally with the juju-specific alias,
 	// so look in each of the provided remote sources for any of the aliases
@@ -77,18 +81,30 @@ func (s *Server) FindImage(
 		return sourced, errors.Trace(err)
 	}
 	for _, remote := range sources {
+		remoteAliases := aliases
+		if remote.Protocol == OCIProtocol {
+			if remoteAliases, err = ociRemoteAliases(series); err != nil {
+				logger.Infof("skipping OCI remote %q: %s", remote.Name, err)
+				lastErr = errors.Trace(err)
+				continue
+			}
+		}
+
 		source, err := ConnectImageRemote(remote)
 		if err != nil {
 			logger.Infof("failed to connect to %q: %s", remote.Host, err)
-			lastErr = errors.Trace(err)
+			lastErr = errors.Annotatef(err, "connecting to image remote %q", remote.Name)
 			continue
 		}
-		for _, alias := range aliases {
+		for _, alias := range remoteAliases {
 			if result, _, err := source.GetImageAlias(alias); err == nil && result != nil && result.Target != "" {
 				target = result.Target
 				break
 			}
 		}
+		if target == "" && remote.Protocol == OCIProtocol {
+			lastErr = errors.NotFoundf("OCI image %q on remote %q", remoteAliases[0], remote.Name)
+		}
 		if target != "" {
 			image, _, err := source.GetImage(target)
 			if err == nil {
@@ -120,6 +136,128 @@ func (s *Server) FindImage(
 	return sourced, nil
 }
 
+// FindPinnedImage is like FindImage, but only returns the image with the
+// input fingerprint, such as one resolved by ImageFingerprint.
+// A locally cached image for the series and architecture with a different
+// fingerprint is stale; the image with the input fingerprint is
+// fetched from the sources, and the local alias moved to it.
+// Unlike FindImage, the sources are searched by fingerprint rather than by
+// alias, so that an alias repointed on a remote does not change the image.
+func (s *Server) FindPinnedImage(
+	series, arch, fingerprint string,
+	sources []ServerSpec,
+	copyLocal bool,
+	callback environs.StatusCallbackFunc,
+) (SourcedImage, error) {
+	if callback != nil {
+		_ = callback(status.Provisioning, "acquiring LXD image", nil)
+	}
+
+	localAlias := seriesLocalAlias(series, arch)
+	entry, _, err := s.GetImageAlias(localAlias)
+	if err != nil && !IsLXDNotFound(err) {
+		return SourcedImage{}, errors.Trace(err)
+	}
+	if entry != nil && entry.Target != fingerprint {
+		logger.Infof("cached image %q for %q is stale, expected fingerprint %q", entry.Target, localAlias, fingerprint)
+		if err := s.DeleteImageAlias(localAlias); err != nil {
+			return SourcedImage{}, errors.Annotatef(err, "removing stale image alias %q", localAlias)
+		}
+		entry = nil
+	}
+
+	// The image may already be cached locally,
+	// with or without the juju-specific alias.
+	image, _, err := s.GetImage(fingerprint)
+	if err == nil {
+		logger.Debugf("Found pinned image locally - %q %q", image.Filename, fingerprint)
+		if entry == nil {
+			if err := s.CreateImageAlias(api.ImageAliasesPost{
+				ImageAliasesEntry: api.ImageAliasesEntry{
+					Name:                 localAlias,
+					ImageAliasesEntryPut: api.ImageAliasesEntryPut{Target: fingerprint},
+				},
+			}); err != nil {
+				return SourcedImage{}, errors.Annotatef(err, "adding image alias %q", localAlias)
+			}
+		}
+		return SourcedImage{
+			Image:     image,
+			LXDServer: s.ContainerServer,
+		}, nil
+	}
+	if !IsLXDNotFound(err) {
+		return SourcedImage{}, errors.Trace(err)
+	}
+
+	sourced := SourcedImage{}
+	lastErr := errors.NotFoundf("image %q for series %q and architecture %q", fingerprint, series, arch)
+	for _, remote := range sources {
+		// OCI images are not identified by fingerprints
+		// published in simplestreams metadata.
+		if remote.Protocol == OCIProtocol {
+			continue
+		}
+		source, err := ConnectImageRemote(remote)
+		if err != nil {
+			logger.Infof("failed to connect to %q: %s", remote.Host, err)
+			lastErr = errors.Annotatef(err, "connecting to image remote %q", remote.Name)
+			continue
+		}
+		image, _, err := source.GetImage(fingerprint)
+		if err != nil {
+			lastErr = errors.Trace(err)
+			continue
+		}
+		logger.Debugf("Found pinned image remotely - %q %q %q", remote.Name, image.Filename, fingerprint)
+		sourced.Image = image
+		sourced.LXDServer = source
+		break
+	}
+	if sourced.Image == nil {
+		return sourced, lastErr
+	}
+
+	if copyLocal {
+		if err := s.CopyRemoteImage(sourced, []string{localAlias}, callback); err != nil {
+			return sourced, errors.Trace(err)
+		}
+		sourced.LXDServer = s.ContainerServer
+	}
+	return sourced, nil
+}
+
+// ImageFingerprint returns the fingerprint of the image published for the
+// input series and architecture, resolved from the simplestreams
+// image-downloads metadata of the input remote and stream.
+// If requireSigned is true, only signed metadata is used.
+var ImageFingerprint = imageFingerprint
+
+func imageFingerprint(remote ServerSpec, series, arch, stream string, requireSigned bool) (string, error) {
+	if remote.Protocol != SimpleStreamsProtocol {
+		return "", errors.NotSupportedf("image fingerprints from %q remote %q", remote.Protocol, remote.Name)
+	}
+	source := func() simplestreams.DataSource {
+		return simplestreams.NewDataSource(simplestreams.Config{
+			Description:          "LXD image fingerprints",
+			BaseURL:              remote.Host,
+			PublicSigningKey:     imagemetadata.SimplestreamsImagesPublicKey,
+			HostnameVerification: utils.VerifySSLHostnames,
+			Priority:             simplestreams.DEFAULT_CLOUD_DATA,
+			RequireSigned:        requireSigned,
+		})
+	}
+	md, err := imagedownloads.One(arch, series, stream, "lxd.tar.xz", source)
+	if err != nil {
+		return "", errors.Annotatef(err, "resolving image fingerprint from %q", remote.Name)
+	}
+	fingerprint := md.LXDFingerprint()
+	if fingerprint == "" {
+		return "", errors.NotFoundf("image fingerprint for series %q and architecture %q on %q", series, arch, remote.Name)
+	}
+	return fingerprint, nil
+}
+
 // CopyRemoteImage accepts an image sourced from a remote server and copies it
 // to the local cache
 func (s *Server) CopyRemoteImage(
@@ -177,6 +315,28 @@ func seriesLocalAlias(series, arch string) string {
 	return fmt.Sprintf("juju/%s/%s", series, arch)
 }
 
+// ociRemoteAliases returns the image references to look for in OCI
+// remotes. OCI images are published per OS release, with architecture
+// variants resolved by the registry's image index.
+func ociRemoteAliases(series string) ([]string, error) {
+	seriesOS, err := jujuseries.GetOSFromSeries(series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch seriesOS {
+	case jujuos.Ubuntu:
+		return []string{"ubuntu:" + series}, nil
+	case jujuos.CentOS:
+		switch series {
+		case "centos7":
+			return []string{"centos:7"}, nil
+		case "centos8":
+			return []string{"centos:8"}, nil
+		}
+	}
+	return nil, errors.NotSupportedf("series %q from an OCI remote", series)
+}
+
 // seriesRemoteAliases returns the aliases to look for in remotes.
 func seriesRemoteAliases(series, arch string) ([]string, error) {
 	seriesOS, err := jujuseries.GetOSFromSeries(series)
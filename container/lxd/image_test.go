@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/golang/mock/gomock"
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	lxdclient "github.com/lxc/lxd/client"
 	lxdapi "github.com/lxc/lxd/shared/api"
@@ -184,6 +185,162 @@ func (s *imageSuite) TestFindImageRemoteServersNotFound(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*failed to retrieve image.*")
 }
 
+func (s *imageSuite) TestFindPinnedImageLocalServer(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	alias := &lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "pinned"}}
+	image := lxdapi.Image{Filename: "this-is-our-image", Fingerprint: "pinned"}
+	gomock.InOrder(
+		iSvr.EXPECT().GetImageAlias("juju/xenial/"+s.Arch()).Return(alias, lxdtesting.ETag, nil),
+		iSvr.EXPECT().GetImage("pinned").Return(&image, lxdtesting.ETag, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := jujuSvr.FindPinnedImage("xenial", s.Arch(), "pinned", []lxd.ServerSpec{{}}, true, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, iSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindPinnedImageStaleLocalImage(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	s.patch(map[string]lxdclient.ImageServer{
+		"server-that-has-image": rSvr,
+	})
+
+	copyOp := lxdtesting.NewMockRemoteOperation(ctrl)
+	copyOp.EXPECT().Wait().Return(nil).AnyTimes()
+	copyOp.EXPECT().GetTarget().Return(&lxdapi.Operation{StatusCode: lxdapi.Success}, nil)
+
+	// The remote alias has been repointed, but the
+	// image is fetched by the pinned fingerprint.
+	localAlias := "juju/xenial/" + s.Arch()
+	stale := &lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "stale"}}
+	image := lxdapi.Image{Filename: "this-is-our-image", Fingerprint: "pinned"}
+	copyReq := &lxdclient.ImageCopyArgs{Aliases: []lxdapi.ImageAlias{{Name: localAlias}}}
+	gomock.InOrder(
+		iSvr.EXPECT().GetImageAlias(localAlias).Return(stale, lxdtesting.ETag, nil),
+		iSvr.EXPECT().DeleteImageAlias(localAlias).Return(nil),
+		iSvr.EXPECT().GetImage("pinned").Return(nil, "", errors.New("not found")),
+		rSvr.EXPECT().GetImage("pinned").Return(&image, lxdtesting.ETag, nil),
+		iSvr.EXPECT().CopyImage(rSvr, image, copyReq).Return(copyOp, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remotes := []lxd.ServerSpec{
+		{Name: "server-that-has-image", Protocol: lxd.SimpleStreamsProtocol},
+	}
+	found, err := jujuSvr.FindPinnedImage("xenial", s.Arch(), "pinned", remotes, true, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, iSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindPinnedImageCachedWithoutAlias(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	localAlias := "juju/xenial/" + s.Arch()
+	image := lxdapi.Image{Filename: "this-is-our-image", Fingerprint: "pinned"}
+	aliasReq := lxdapi.ImageAliasesPost{ImageAliasesEntry: lxdapi.ImageAliasesEntry{
+		Name:                 localAlias,
+		ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "pinned"},
+	}}
+	gomock.InOrder(
+		iSvr.EXPECT().GetImageAlias(localAlias).Return(nil, "", errors.New("not found")),
+		iSvr.EXPECT().GetImage("pinned").Return(&image, lxdtesting.ETag, nil),
+		iSvr.EXPECT().CreateImageAlias(aliasReq).Return(nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := jujuSvr.FindPinnedImage("xenial", s.Arch(), "pinned", nil, true, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, iSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindImageOCIRemote(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	s.patch(map[string]lxdclient.ImageServer{
+		"registry.example.com": rSvr,
+	})
+
+	image := lxdapi.Image{Filename: "this-is-our-image"}
+	alias := lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "foo-oci-target"}}
+	gomock.InOrder(
+		iSvr.EXPECT().GetImageAlias("juju/focal/"+s.Arch()).Return(nil, lxdtesting.ETag, errors.New("not found")),
+		rSvr.EXPECT().GetImageAlias("ubuntu:focal").Return(&alias, lxdtesting.ETag, nil),
+		rSvr.EXPECT().GetImage("foo-oci-target").Return(&image, lxdtesting.ETag, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remotes := []lxd.ServerSpec{lxd.MakeOCIServerSpec("registry.example.com", "registry.example.com")}
+	found, err := jujuSvr.FindImage("focal", s.Arch(), remotes, false, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, rSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindImageOCIRemoteNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	s.patch(map[string]lxdclient.ImageServer{
+		"registry.example.com": rSvr,
+	})
+
+	gomock.InOrder(
+		iSvr.EXPECT().GetImageAlias("juju/focal/"+s.Arch()).Return(nil, lxdtesting.ETag, errors.New("not found")),
+		rSvr.EXPECT().GetImageAlias("ubuntu:focal").Return(nil, lxdtesting.ETag, errors.New("not found")),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remotes := []lxd.ServerSpec{lxd.MakeOCIServerSpec("registry.example.com", "registry.example.com")}
+	_, err = jujuSvr.FindImage("focal", s.Arch(), remotes, false, nil)
+	c.Assert(err, gc.ErrorMatches, `OCI image "ubuntu:focal" on remote "registry.example.com" not found`)
+	c.Check(jujuerrors.IsNotFound(err), jc.IsTrue)
+}
+
+func (s *imageSuite) TestFindImageOCIRemoteUnreachable(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	s.patch(map[string]lxdclient.ImageServer{})
+
+	iSvr.EXPECT().GetImageAlias("juju/focal/"+s.Arch()).Return(nil, lxdtesting.ETag, errors.New("not found"))
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remotes := []lxd.ServerSpec{lxd.MakeOCIServerSpec("registry.example.com", "registry.example.com")}
+	_, err = jujuSvr.FindImage("focal", s.Arch(), remotes, false, nil)
+	c.Assert(err, gc.ErrorMatches, `connecting to image remote "registry.example.com": unrecognized remote server`)
+}
+
 func (s *imageSuite) TestSeriesRemoteAliasesNotSupported(c *gc.C) {
 	_, err := lxd.SeriesRemoteAliases("centos7", "arm64")
 	c.Assert(err, gc.ErrorMatches, `series "centos7" not supported`)
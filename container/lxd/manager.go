@@ -5,6 +5,7 @@ package lxd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -46,6 +47,10 @@ type containerManager struct {
 	imageStream      string
 	imageMutex       sync.Mutex
 
+	// requireSignedImageMetadata indicates that images may only be
+	// pinned to fingerprints resolved from signed metadata.
+	requireSignedImageMetadata bool
+
 	profileMutex sync.Mutex
 }
 
@@ -74,19 +79,37 @@ func NewContainerManager(cfg container.ManagerConfig, svr *Server) (container.Ma
 	imageMetaDataURL := cfg.PopValue(config.ContainerImageMetadataURLKey)
 	imageStream := cfg.PopValue(config.ContainerImageStreamKey)
 
+	var requireSigned bool
+	if value := cfg.PopValue(config.ContainerImageRequireSignedMetadataKey); value != "" {
+		if requireSigned, err = strconv.ParseBool(value); err != nil {
+			return nil, errors.Annotatef(err, "parsing %s", config.ContainerImageRequireSignedMetadataKey)
+		}
+	}
+
 	// This value is also popped by the provisioner worker; the following
 	// dummy pop operation ensures that we don't get a spurious warning
 	// for it when calling WarnAboutUnused() below.
 	_ = cfg.PopValue(config.LXDSnapChannel)
 
 	cfg.WarnAboutUnused()
+
+	// Scope the model's containers, profiles and images to its own
+	// project, so that they do not collide with those of other models
+	// and controllers sharing the host.
+	if svr != nil {
+		if svr, err = svr.UseModelProject(modelUUID); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	return &containerManager{
-		server:           svr,
-		modelUUID:        modelUUID,
-		namespace:        namespace,
-		availabilityZone: availabilityZone,
-		imageMetadataURL: imageMetaDataURL,
-		imageStream:      imageStream,
+		server:                     svr,
+		modelUUID:                  modelUUID,
+		namespace:                  namespace,
+		availabilityZone:           availabilityZone,
+		imageMetadataURL:           imageMetaDataURL,
+		imageStream:                imageStream,
+		requireSignedImageMetadata: requireSigned,
 	}, nil
 }
 
@@ -137,7 +160,7 @@ func (m *containerManager) ListContainers() ([]instances.Instance, error) {
 
 	var result []instances.Instance
 	for _, i := range containers {
-		result = append(result, &lxdInstance{i.Name, m.server.ContainerServer})
+		result = append(result, &lxdInstance{i.Name, m.server.containerServerFor(i)})
 	}
 	return result, nil
 }
@@ -163,12 +186,21 @@ func (m *containerManager) getContainerSpec(
 		return ContainerSpec{}, errors.Trace(err)
 	}
 
+	// The fingerprint is resolved before taking the lock below, so that
+	// fetching the simplestreams metadata does not block the creation of
+	// other containers.
+	arch := jujuarch.HostArch()
+	fingerprint, err := m.imageFingerprint(series, arch, imageSources)
+	if err != nil {
+		return ContainerSpec{}, errors.Annotatef(err, "acquiring LXD image")
+	}
+
 	// Lock around finding an image.
 	// The provisioner works concurrently to create containers.
 	// If an image needs to be copied from a remote, we don't want many
 	// goroutines attempting to do it at once.
 	m.imageMutex.Lock()
-	found, err := m.server.FindImage(series, jujuarch.HostArch(), imageSources, true, callback)
+	found, err := m.findImage(series, arch, fingerprint, imageSources, callback)
 	m.imageMutex.Unlock()
 	if err != nil {
 		return ContainerSpec{}, errors.Annotatef(err, "acquiring LXD image")
@@ -229,6 +261,9 @@ func (m *containerManager) getContainerSpec(
 		// Extra info to indicate the origin of this container.
 		JujuModelKey: m.modelUUID,
 	}
+	if found.Image.Fingerprint != "" {
+		cfg[JujuImageFingerprintKey] = found.Image.Fingerprint
+	}
 
 	spec := ContainerSpec{
 		Name:     name,
@@ -245,6 +280,36 @@ func (m *containerManager) getContainerSpec(
 	return spec, nil
 }
 
+// imageFingerprint returns the fingerprint published for the input series
+// and architecture in the simplestreams metadata of the preferred image
+// source. If no fingerprint can be resolved, an empty fingerprint is
+// returned, unless signed metadata is required.
+func (m *containerManager) imageFingerprint(series, arch string, sources []ServerSpec) (string, error) {
+	fingerprint, err := ImageFingerprint(sources[0], series, arch, m.imageStream, m.requireSignedImageMetadata)
+	if err == nil {
+		return fingerprint, nil
+	}
+	if m.requireSignedImageMetadata {
+		return "", errors.Annotate(err, "signed image metadata is required")
+	}
+	logger.Debugf("not pinning image for %s/%s: %v", series, arch, err)
+	return "", nil
+}
+
+// findImage returns the image for the input series and architecture,
+// copied to the local cache.
+// If a fingerprint is supplied, the image is pinned to it, so that a
+// cached image is refreshed when it differs. Otherwise the image is
+// found by alias.
+func (m *containerManager) findImage(
+	series, arch, fingerprint string, sources []ServerSpec, callback environs.StatusCallbackFunc,
+) (SourcedImage, error) {
+	if fingerprint != "" {
+		return m.server.FindPinnedImage(series, arch, fingerprint, sources, true, callback)
+	}
+	return m.server.FindImage(series, arch, sources, true, callback)
+}
+
 // getImageSources returns a list of LXD remote image sources based on the
 // configuration that was passed into the container manager.
 func (m *containerManager) getImageSources() ([]ServerSpec, error) {
@@ -261,6 +326,15 @@ func (m *containerManager) getImageSources() ([]ServerSpec, error) {
 		return []ServerSpec{CloudImagesDailyRemote}, nil
 	}
 
+	if IsOCIImageSource(imURL) {
+		// An OCI registry is used as-is; there is no simplestreams
+		// metadata to locate within it. The cloud-images remotes are
+		// not used as fallbacks, so that a failure to use the registry
+		// is reported rather than silently using a different image.
+		host := strings.TrimSuffix(strings.TrimPrefix(imURL, ociScheme), "/")
+		return []ServerSpec{MakeOCIServerSpec(host, host)}, nil
+	}
+
 	imURL, err := imagemetadata.ImageMetadataURL(imURL, m.imageStream)
 	if err != nil {
 		return nil, errors.Annotatef(err, "generating image metadata source")
@@ -314,9 +388,15 @@ func (m *containerManager) networkDevicesFromConfig(netConfig *container.Network
 // an arg.
 // MaybeWriteLXDProfile implements container.LXDProfileManager.
 func (m *containerManager) MaybeWriteLXDProfile(pName string, put lxdprofile.Profile) error {
+	return errors.Trace(m.maybeWriteLXDProfile(m.server, pName, put))
+}
+
+// maybeWriteLXDProfile writes the input profile to the project
+// of the input server, if it does not already exist there.
+func (m *containerManager) maybeWriteLXDProfile(svr *Server, pName string, put lxdprofile.Profile) error {
 	m.profileMutex.Lock()
 	defer m.profileMutex.Unlock()
-	hasProfile, err := m.server.HasProfile(pName)
+	hasProfile, err := svr.HasProfile(pName)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -333,11 +413,11 @@ func (m *containerManager) MaybeWriteLXDProfile(pName string, put lxdprofile.Pro
 			Devices:     put.Devices,
 		},
 	}
-	if err = m.server.CreateProfile(post); err != nil {
+	if err = svr.CreateProfile(post); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("wrote lxd profile %q", pName)
-	if err := m.verifyProfile(pName); err != nil {
+	if err := verifyProfile(svr, pName); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
@@ -346,11 +426,11 @@ func (m *containerManager) MaybeWriteLXDProfile(pName string, put lxdprofile.Pro
 // verifyProfile gets the actual profile from lxd for the name provided
 // and logs the result. For informational purposes only. Returns an error
 // if the call to GetProfile fails.
-func (m *containerManager) verifyProfile(pName string) error {
+func verifyProfile(svr *Server, pName string) error {
 	// As there are configs where we do not have the option of looking at
 	// the profile on the machine to verify, verify here that what we thought
 	// was written, is what was written.
-	profile, _, err := m.server.GetProfile(pName)
+	profile, _, err := svr.GetProfile(pName)
 	if err != nil {
 		return err
 	}
@@ -374,12 +454,21 @@ func (m *containerManager) AssignLXDProfiles(instID string, profilesNames []stri
 		return currentProfiles, err
 	}
 
+	// Profiles are scoped to a project, so they are written to and
+	// deleted from the project holding the container, which may be
+	// the default project if it was created before projects were used.
+	container, eTag, err := m.server.getContainer(instID)
+	if err != nil {
+		return report(errors.Annotatef(err, "failed to get %q", instID))
+	}
+	svr := m.server.serverFor(container)
+
 	// Write any new profilePosts and gather a slice of profile
 	// names to be deleted, after removal.
 	var deleteProfiles []string
 	for _, p := range profilePosts {
 		if p.Profile != nil {
-			if err := m.MaybeWriteLXDProfile(p.Name, *p.Profile); err != nil {
+			if err := m.maybeWriteLXDProfile(svr, p.Name, *p.Profile); err != nil {
 				return report(err)
 			}
 		} else {
@@ -387,12 +476,12 @@ func (m *containerManager) AssignLXDProfiles(instID string, profilesNames []stri
 		}
 	}
 
-	if err := m.server.UpdateContainerProfiles(instID, profilesNames); err != nil {
+	if err := svr.updateContainerProfiles(instID, container, eTag, profilesNames); err != nil {
 		return report(errors.Trace(err))
 	}
 
 	for _, name := range deleteProfiles {
-		if err := m.server.DeleteProfile(name); err != nil {
+		if err := svr.DeleteProfile(name); err != nil {
 			// Most likely the failure is because the profile is already in use.
 			logger.Debugf("failed to delete profile %q: %s", name, err)
 		}
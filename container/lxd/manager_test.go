@@ -5,7 +5,11 @@ package lxd_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	stdtesting "testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/names/v4"
@@ -49,6 +53,42 @@ type managerSuite struct {
 
 var _ = gc.Suite(&managerSuite{})
 
+func (s *managerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	// Host interfaces used as NIC parents, keyed by name,
+	// with values indicating the device type.
+	s.patchHostInterfaces(c, map[string]string{
+		"eth0":                   "",
+		"br-eth0":                "bridge",
+		"br-eth1":                "bridge",
+		network.DefaultLXDBridge: "bridge",
+	})
+
+	// Unless a test pins images, they are found by alias.
+	lxd.PatchImageFingerprint(s, "", errors.New("no image fingerprint"))
+}
+
+// patchHostInterfaces creates a fake SYSFS network directory containing
+// the input interfaces, and uses it for host interface discovery.
+// The path of the directory is returned.
+func (s *managerSuite) patchHostInterfaces(c *gc.C, interfaces map[string]string) string {
+	sysPath := c.MkDir()
+	for name, devType := range interfaces {
+		dir := filepath.Join(sysPath, name)
+		c.Assert(os.Mkdir(dir, 0755), jc.ErrorIsNil)
+
+		uevent := "INTERFACE=" + name + "\n"
+		if devType != "" {
+			uevent = "DEVTYPE=" + devType + "\n" + uevent
+		}
+		err := ioutil.WriteFile(filepath.Join(dir, "uevent"), []byte(uevent), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	lxd.PatchSysClassNetPath(s, sysPath)
+	return sysPath
+}
+
 func (s *managerSuite) patch() {
 	lxd.PatchConnectRemote(s, map[string]lxdclient.ImageServer{"cloud-images.ubuntu.com": s.cSvr})
 	lxd.PatchGenerateVirtualMACAddress(s)
@@ -254,6 +294,109 @@ func (s *managerSuite) TestCreateContainerCreateFailed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*create failed")
 }
 
+func (s *managerSuite) TestCreateContainerRefreshesStaleImage(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+	s.patch()
+	lxd.PatchImageFingerprint(s, "new-fingerprint", nil)
+
+	s.expectCreateRemoteOp(ctrl, &lxdapi.Operation{StatusCode: lxdapi.Failure, Err: "create failed"})
+	copyOp := lxdtesting.NewMockRemoteOperation(ctrl)
+	copyOp.EXPECT().Wait().Return(nil).AnyTimes()
+	copyOp.EXPECT().GetTarget().Return(&lxdapi.Operation{StatusCode: lxdapi.Success}, nil)
+	copyOp.EXPECT().AddHandler(gomock.Any()).Return(nil, nil)
+
+	// The cached image has a different fingerprint to that pinned,
+	// so the pinned image is copied from the remote in its place.
+	localAlias := "juju/xenial/" + s.Arch()
+	stale := &lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "old-fingerprint"}}
+	image := lxdapi.Image{Filename: "this-is-our-image", Fingerprint: "new-fingerprint"}
+	copyReq := &lxdclient.ImageCopyArgs{Aliases: []lxdapi.ImageAlias{{Name: localAlias}}}
+
+	var containerConfig map[string]string
+	exp := s.cSvr.EXPECT()
+	gomock.InOrder(
+		exp.GetImageAlias(localAlias).Return(stale, lxdtesting.ETag, nil),
+		exp.DeleteImageAlias(localAlias).Return(nil),
+		exp.GetImage("new-fingerprint").Return(nil, "", errors.New("not found")),
+		exp.GetImage("new-fingerprint").Return(&image, lxdtesting.ETag, nil),
+		exp.CopyImage(s.cSvr, image, copyReq).Return(copyOp, nil),
+		exp.CreateContainerFromImage(s.cSvr, image, gomock.Any()).DoAndReturn(
+			func(_ lxdclient.ImageServer, _ lxdapi.Image, req lxdapi.ContainersPost) (lxdclient.RemoteOperation, error) {
+				containerConfig = req.Config
+				return s.createRemoteOp, nil
+			}),
+	)
+
+	s.makeManager(c)
+	_, _, err := s.manager.CreateContainer(
+		prepInstanceConfig(c),
+		constraints.Value{},
+		"xenial",
+		prepNetworkConfig(),
+		&container.StorageConfig{},
+		lxdtesting.NoOpCallback,
+	)
+	c.Assert(err, gc.ErrorMatches, ".*create failed")
+
+	// The fingerprint is recorded in the container's metadata.
+	c.Check(containerConfig[lxd.JujuImageFingerprintKey], gc.Equals, "new-fingerprint")
+}
+
+func (s *managerSuite) TestCreateContainerRequiresSignedImageMetadata(c *gc.C) {
+	defer s.setup(c).Finish()
+	lxd.PatchImageFingerprint(s, "", errors.New("no signed metadata"))
+
+	cfg := getBaseConfig()
+	cfg[config.ContainerImageRequireSignedMetadataKey] = "true"
+	s.makeManagerForConfig(c, cfg)
+	_, _, err := s.manager.CreateContainer(
+		prepInstanceConfig(c),
+		constraints.Value{},
+		"xenial",
+		prepNetworkConfig(),
+		&container.StorageConfig{},
+		lxdtesting.NoOpCallback,
+	)
+	c.Assert(err, gc.ErrorMatches, "acquiring LXD image: signed image metadata is required: no signed metadata")
+}
+
+func (s *managerSuite) TestCreateContainerResolvesFingerprintWithoutImageLock(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	// Resolving the fingerprint must not wait for other
+	// containers to finish finding their images.
+	var locked bool
+	s.PatchValue(&lxd.ImageFingerprint, func(lxd.ServerSpec, string, string, string, bool) (string, error) {
+		acquired := make(chan func())
+		go func() {
+			acquired <- lxd.LockImageMutex(s.manager)
+		}()
+		select {
+		case unlock := <-acquired:
+			locked = true
+			unlock()
+		case <-time.After(coretesting.LongWait):
+			c.Errorf("image lock held while resolving fingerprint")
+		}
+		return "", errors.New("no signed metadata")
+	})
+
+	cfg := getBaseConfig()
+	cfg[config.ContainerImageRequireSignedMetadataKey] = "true"
+	s.makeManagerForConfig(c, cfg)
+	_, _, err := s.manager.CreateContainer(
+		prepInstanceConfig(c),
+		constraints.Value{},
+		"xenial",
+		prepNetworkConfig(),
+		&container.StorageConfig{},
+		lxdtesting.NoOpCallback,
+	)
+	c.Assert(err, gc.ErrorMatches, "acquiring LXD image: signed image metadata is required: no signed metadata")
+	c.Check(locked, jc.IsTrue)
+}
+
 func (s *managerSuite) TestCreateContainerSpecCreationError(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -394,6 +537,164 @@ func (s *managerSuite) TestNetworkDevicesFromConfigWithParentDevice(c *gc.C) {
 	c.Check(unknown, gc.HasLen, 0)
 }
 
+func (s *managerSuite) TestNetworkDevicesFromConfigMultipleBridgesWithVLANs(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	interfaces := corenetwork.InterfaceInfos{{
+		ParentInterfaceName: "br-eth0",
+		InterfaceName:       "eth0",
+		InterfaceType:       "ethernet",
+		CIDR:                "10.10.0.0/24",
+		MACAddress:          "aa:bb:cc:dd:ee:f0",
+	}, {
+		ParentInterfaceName: "br-eth1",
+		InterfaceName:       "eth1",
+		InterfaceType:       "ethernet",
+		CIDR:                "10.20.0.0/24",
+		MACAddress:          "aa:bb:cc:dd:ee:f1",
+		VLANTag:             42,
+	}, {
+		ParentInterfaceName: "eth0",
+		InterfaceName:       "eth2",
+		InterfaceType:       "ethernet",
+		CIDR:                "10.30.0.0/24",
+		MACAddress:          "aa:bb:cc:dd:ee:f2",
+		VLANTag:             100,
+	}}
+
+	expected := map[string]map[string]string{
+		"eth0": {
+			"hwaddr":    "aa:bb:cc:dd:ee:f0",
+			"name":      "eth0",
+			"nictype":   "bridged",
+			"parent":    "br-eth0",
+			"type":      "nic",
+			"host_name": "1lxd2-0",
+		},
+		"eth1": {
+			"hwaddr":    "aa:bb:cc:dd:ee:f1",
+			"name":      "eth1",
+			"nictype":   "bridged",
+			"parent":    "br-eth1",
+			"type":      "nic",
+			"host_name": "1lxd2-1",
+			"vlan":      "42",
+		},
+		"eth2": {
+			"hwaddr":  "aa:bb:cc:dd:ee:f2",
+			"name":    "eth2",
+			"nictype": "macvlan",
+			"parent":  "eth0",
+			"type":    "nic",
+			"vlan":    "100",
+		},
+	}
+
+	s.makeManager(c)
+	result, unknown, err := lxd.NetworkDevicesFromConfig(s.manager, &container.NetworkConfig{
+		Interfaces: interfaces,
+	}, "1/lxd/2")
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, expected)
+	c.Check(unknown, gc.HasLen, 0)
+}
+
+func (s *managerSuite) TestNetworkDevicesFromConfigParentsOnVLANs(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	// MAAS bridges each VLAN device on the host, and
+	// the container's NICs on the VLAN use that bridge.
+	sysPath := s.patchHostInterfaces(c, map[string]string{
+		"eth0":        "",
+		"eth0.100":    "vlan",
+		"br-eth0.100": "bridge",
+		"eth0.200":    "vlan",
+	})
+	err := os.MkdirAll(filepath.Join(sysPath, "br-eth0.100", "brif", "eth0.100"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	interfaces := corenetwork.InterfaceInfos{{
+		ParentInterfaceName: "br-eth0.100",
+		InterfaceName:       "eth0",
+		InterfaceType:       "ethernet",
+		CIDR:                "10.100.0.0/24",
+		MACAddress:          "aa:bb:cc:dd:ee:f0",
+		VLANTag:             100,
+	}, {
+		ParentInterfaceName: "eth0.200",
+		InterfaceName:       "eth1",
+		InterfaceType:       "ethernet",
+		CIDR:                "10.200.0.0/24",
+		MACAddress:          "aa:bb:cc:dd:ee:f1",
+		VLANTag:             200,
+	}}
+
+	expected := map[string]map[string]string{
+		"eth0": {
+			"hwaddr":    "aa:bb:cc:dd:ee:f0",
+			"name":      "eth0",
+			"nictype":   "bridged",
+			"parent":    "br-eth0.100",
+			"type":      "nic",
+			"host_name": "1lxd2-0",
+		},
+		"eth1": {
+			"hwaddr":  "aa:bb:cc:dd:ee:f1",
+			"name":    "eth1",
+			"nictype": "macvlan",
+			"parent":  "eth0.200",
+			"type":    "nic",
+		},
+	}
+
+	s.makeManager(c)
+	result, unknown, err := lxd.NetworkDevicesFromConfig(s.manager, &container.NetworkConfig{
+		Interfaces: interfaces,
+	}, "1/lxd/2")
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, expected)
+	c.Check(unknown, gc.HasLen, 0)
+}
+
+func (s *managerSuite) TestNetworkDevicesFromConfigInvalidVLAN(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	interfaces := corenetwork.InterfaceInfos{{
+		ParentInterfaceName: "br-eth1",
+		InterfaceName:       "eth1",
+		InterfaceType:       "ethernet",
+		VLANTag:             4095,
+	}}
+
+	s.makeManager(c)
+	result, _, err := lxd.NetworkDevicesFromConfig(s.manager, &container.NetworkConfig{
+		Interfaces: interfaces,
+	}, "1/lxd/2")
+
+	c.Assert(err, gc.ErrorMatches, `VLAN ID 4095 for interface "eth1" not valid`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *managerSuite) TestNetworkDevicesFromConfigMissingParent(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	interfaces := corenetwork.InterfaceInfos{{
+		ParentInterfaceName: "br-eth2",
+		InterfaceName:       "eth2",
+		InterfaceType:       "ethernet",
+	}}
+
+	s.makeManager(c)
+	result, _, err := lxd.NetworkDevicesFromConfig(s.manager, &container.NetworkConfig{
+		Interfaces: interfaces,
+	}, "1/lxd/2")
+
+	c.Assert(err, gc.ErrorMatches, `parent device "br-eth2" on host not found`)
+	c.Assert(result, gc.IsNil)
+}
+
 func (s *managerSuite) TestNetworkDevicesFromConfigUnknownCIDR(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -515,6 +816,26 @@ func (s *managerSuite) TestGetImageSourcesImageMetadataURLDailyStream(c *gc.C) {
 	c.Check(sources, gc.DeepEquals, expectedSources)
 }
 
+func (s *managerSuite) TestGetImageSourcesOCIRemote(c *gc.C) {
+	defer s.setup(c).Finish()
+
+	cfg := getBaseConfig()
+	cfg[config.ContainerImageMetadataURLKey] = "oci://registry.example.com/"
+	s.makeManagerForConfig(c, cfg)
+
+	sources, err := lxd.GetImageSources(s.manager)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectedSources := []lxd.ServerSpec{
+		{
+			Name:     "registry.example.com",
+			Host:     "https://registry.example.com",
+			Protocol: lxd.OCIProtocol,
+		},
+	}
+	c.Check(sources, gc.DeepEquals, expectedSources)
+}
+
 func (s *managerSuite) TestMaybeWriteLXDProfile(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -582,6 +903,55 @@ func (s *managerSuite) TestAssignLXDProfiles(c *gc.C) {
 	c.Assert(obtained, gc.DeepEquals, newProfiles)
 }
 
+func (s *managerSuite) TestAssignLXDProfilesDefaultProjectContainer(c *gc.C) {
+	ctrl := s.setupWithExtensions(c, "projects")
+	defer ctrl.Finish()
+	s.expectUpdateOp(ctrl, "Updating container", nil)
+
+	// The manager is scoped to the model's project, but the container
+	// was created in the default project before projects were used.
+	pSvr := lxdtesting.NewMockContainerServer(ctrl)
+	projectName := lxd.ModelProjectName(coretesting.ModelTag.Id())
+	s.cSvr.EXPECT().UseProject(projectName).Return(pSvr)
+	s.cSvr.EXPECT().GetProjectNames().Return([]string{"default", projectName}, nil)
+
+	newProfiles := []string{"default", "juju-default", "juju-model-app-1"}
+	put := lxdprofile.Profile{
+		Config:      map[string]string{"security.nesting": "true"},
+		Description: "test profile",
+	}
+	post := lxdapi.ProfilesPost{Name: "juju-model-app-1", ProfilePut: lxdapi.ProfilePut(put)}
+	legacy := &lxdapi.Container{
+		Name:         "juju-legacy-0",
+		ContainerPut: lxdapi.ContainerPut{Profiles: []string{"default", "juju-default"}},
+	}
+
+	// The profile is written to, and the container updated in,
+	// the default project rather than the model's project.
+	cExp := s.cSvr.EXPECT()
+	gomock.InOrder(
+		pSvr.EXPECT().GetContainer("juju-legacy-0").Return(nil, "", errors.New("not found")),
+		cExp.GetContainer("juju-legacy-0").Return(legacy, lxdtesting.ETag, nil),
+		cExp.GetProfileNames().Return([]string{"default", "juju-default"}, nil),
+		cExp.CreateProfile(post).Return(nil),
+		cExp.GetProfile(post.Name).Return(&lxdapi.Profile{ProfilePut: post.ProfilePut}, "etag", nil),
+		cExp.UpdateContainer("juju-legacy-0", gomock.Any(), lxdtesting.ETag).Return(s.updateOp, nil),
+		pSvr.EXPECT().GetContainer("juju-legacy-0").Return(nil, "", errors.New("not found")),
+		cExp.GetContainer("juju-legacy-0").Return(
+			&lxdapi.Container{ContainerPut: lxdapi.ContainerPut{Profiles: newProfiles}}, lxdtesting.ETag, nil),
+	)
+
+	s.makeManager(c)
+	proMgr, ok := s.manager.(container.LXDProfileManager)
+	c.Assert(ok, jc.IsTrue)
+
+	obtained, err := proMgr.AssignLXDProfiles("juju-legacy-0", newProfiles, []lxdprofile.ProfilePost{
+		{Name: post.Name, Profile: &put},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, gc.DeepEquals, newProfiles)
+}
+
 func (s *managerSuite) setup(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 	s.cSvr = s.NewMockServer(ctrl)
@@ -670,15 +1040,15 @@ func (s *managerSuite) expectUpdateContainerProfiles(old, new string, newProfile
 	expProfile := lxdapi.Profile{ProfilePut: put}
 	cExp := s.cSvr.EXPECT()
 	gomock.InOrder(
-		cExp.GetProfileNames().Return(oldProfiles, nil),
-		cExp.CreateProfile(post).Return(nil),
-		cExp.GetProfile(post.Name).Return(&expProfile, "etag", nil),
 		cExp.GetContainer(instId).Return(
 			&lxdapi.Container{
 				ContainerPut: lxdapi.ContainerPut{
 					Profiles: oldProfiles,
 				},
 			}, "", nil),
+		cExp.GetProfileNames().Return(oldProfiles, nil),
+		cExp.CreateProfile(post).Return(nil),
+		cExp.GetProfile(post.Name).Return(&expProfile, "etag", nil),
 		cExp.UpdateContainer(instId, gomock.Any(), gomock.Any()).Return(s.updateOp, nil),
 		cExp.DeleteProfile(old).Return(nil),
 		cExp.GetContainer(instId).Return(
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,8 +24,16 @@ const (
 	nicTypeBridged = "bridged"
 	nicTypeMACVLAN = "macvlan"
 	netTypeBridge  = "bridge"
+
+	// maxVLANTag is the largest valid IEEE 802.1Q VLAN ID.
+	maxVLANTag = 4094
 )
 
+// sysClassNetPath is the location from which host network interfaces
+// are discovered when generating NIC devices. It is a variable so that
+// it can be changed in tests.
+var sysClassNetPath = network.SysClassNetPath
+
 // device is a type alias for profile devices.
 type device = map[string]string
 
@@ -471,6 +480,12 @@ const errIPV6NotSupported = `socket: address family not supported by protocol`
 // networks without a known CIDR are returned in a slice. The machineID arg is
 // used for generating predictable host interface names for the container's
 // ethernet devices.
+// The parent of each interface must exist on the host. Interfaces with a
+// VLAN tag are bridged if their parent is a bridge, otherwise they are
+// created as macvlan devices on the parent. The VLAN is only set on the
+// device if the parent is not already on a VLAN, such as the per-VLAN
+// bridges created on MAAS hosts, which would otherwise tag the container's
+// traffic twice.
 func DevicesFromInterfaceInfo(interfaces corenetwork.InterfaceInfos, machineID string) (map[string]device, []string, error) {
 	nics := make(map[string]device, len(interfaces))
 	var unknown []string
@@ -485,25 +500,71 @@ func DevicesFromInterfaceInfo(interfaces corenetwork.InterfaceInfos, machineID s
 		if v.ParentInterfaceName == "" {
 			return nil, nil, errors.Errorf("parent interface name is empty")
 		}
+		if v.VLANTag < 0 || v.VLANTag > maxVLANTag {
+			return nil, nil, errors.NotValidf("VLAN ID %d for interface %q", v.VLANTag, v.InterfaceName)
+		}
+		parentType, err := hostInterfaceType(v.ParentInterfaceName)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
 		if v.CIDR == "" {
 			unknown = append(unknown, v.ParentInterfaceName)
 		}
 		hostIfaceName := makeHostInterfaceName(machineID, nicCount)
-		nics[v.InterfaceName] = newNICDevice(v.InterfaceName, v.ParentInterfaceName, hostIfaceName, v.MACAddress, v.MTU)
+		nic := newNICDevice(v.InterfaceName, v.ParentInterfaceName, hostIfaceName, v.MACAddress, v.MTU)
+		if v.VLANTag > 0 {
+			if !hostInterfaceOnVLAN(v.ParentInterfaceName, parentType) {
+				nic["vlan"] = strconv.Itoa(v.VLANTag)
+			}
+			if parentType != corenetwork.BridgeInterface {
+				// LXD does not allow the host-side name
+				// to be set for macvlan devices.
+				nic["nictype"] = nicTypeMACVLAN
+				delete(nic, "host_name")
+			}
+		}
+		nics[v.InterfaceName] = nic
 		nicCount++
 	}
 
 	return nics, unknown, nil
 }
 
-// newNICDevice creates and returns a LXD-compatible config for a network
-// device from the input arguments.
-// TODO (manadart 2018-06-21) We want to support nictype=macvlan too.
-// This will involve interrogating the parent device, via the server if it is
-// LXD managed, or via the container.NetworkConfig.DeviceType that this is
-// being generated from. If the hostIfaceName argument is not empty, LXD will
-// use its value as the name of the container's virtual eth device on the host.
-// Otherwise, the host interface will be assigned a random unique value by LXD.
+// hostInterfaceType returns the type of the named host network interface,
+// or an error satisfying errors.IsNotFound if there is no such interface.
+func hostInterfaceType(name string) (corenetwork.InterfaceType, error) {
+	if _, err := os.Stat(filepath.Join(sysClassNetPath, name)); err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.NotFoundf("parent device %q on host", name)
+		}
+		return "", errors.Trace(err)
+	}
+	return network.ParseInterfaceType(sysClassNetPath, name), nil
+}
+
+// hostInterfaceOnVLAN reports whether the host network interface with the
+// input name and type already carries traffic for a single VLAN, either
+// because it is a VLAN device, or because it is a bridge with a VLAN device
+// as a port.
+func hostInterfaceOnVLAN(name string, ifaceType corenetwork.InterfaceType) bool {
+	switch ifaceType {
+	case corenetwork.VLAN_8021QInterface:
+		return true
+	case corenetwork.BridgeInterface:
+		for _, port := range network.GetBridgePorts(sysClassNetPath, name) {
+			if network.ParseInterfaceType(sysClassNetPath, port) == corenetwork.VLAN_8021QInterface {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newNICDevice creates and returns a LXD-compatible config for a bridged
+// network device from the input arguments.
+// If the hostIfaceName argument is not empty, LXD will use its value as the
+// name of the container's virtual eth device on the host. Otherwise, the host
+// interface will be assigned a random unique value by LXD.
 func newNICDevice(deviceName, parentDevice, hostIfaceName, hwAddr string, mtu int) device {
 	device := map[string]string{
 		"type":    "nic",
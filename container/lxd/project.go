@@ -0,0 +1,182 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	lxd "github.com/lxc/lxd/client"
+	"github.com/lxc/lxd/shared/api"
+)
+
+// DefaultProject is the LXD project used when none is specified, and
+// the only project available on LXD versions without project support.
+const DefaultProject = "default"
+
+// ModelProjectName returns the name of the LXD project used for
+// containers belonging to the model with the input UUID.
+func ModelProjectName(modelUUID string) string {
+	return "juju-" + names.NewModelTag(modelUUID).ShortId()
+}
+
+// ProjectSupported returns true if the LXD server supports projects.
+func (s *Server) ProjectSupported() bool {
+	return s.projectAPISupport
+}
+
+// Project returns the name of the LXD project that this server's
+// container, profile and image operations are scoped to.
+func (s *Server) Project() string {
+	if s.project == "" {
+		return DefaultProject
+	}
+	return s.project
+}
+
+// UseModelProject returns a new Server with all operations scoped to the
+// project for the model with the input UUID, creating the project if it
+// does not exist. If the LXD server does not support projects, the
+// receiver is returned, using the default project.
+//
+// Containers created in the default project before projects were used
+// are still returned when listing, and can be removed, via the returned
+// Server.
+func (s *Server) UseModelProject(modelUUID string) (*Server, error) {
+	if !s.projectAPISupport {
+		logger.Debugf("LXD server does not support projects, using the %q project", DefaultProject)
+		return s, nil
+	}
+
+	name := ModelProjectName(modelUUID)
+	scoped := *s
+	scoped.ContainerServer = s.UseProject(name)
+	scoped.project = name
+	scoped.defaultProjectServer = s.ContainerServer
+
+	if err := s.ensureProject(name, scoped.ContainerServer); err != nil {
+		return nil, errors.Annotatef(err, "ensuring LXD project %q", name)
+	}
+	return &scoped, nil
+}
+
+// ensureProject creates the project with the input name if it does not
+// already exist. Images and profiles are scoped to the project, so its
+// default profile is seeded from the one in the default project.
+func (s *Server) ensureProject(name string, projectServer lxd.ContainerServer) error {
+	exists, err := s.hasProject(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		return nil
+	}
+
+	logger.Infof("creating LXD project %q", name)
+	req := api.ProjectsPost{
+		Name: name,
+		ProjectPut: api.ProjectPut{
+			Description: "Juju managed containers",
+			Config: map[string]string{
+				"features.images":   "true",
+				"features.profiles": "true",
+			},
+		},
+	}
+	if err := s.CreateProject(req); err != nil {
+		// Another agent on this host may have created it concurrently.
+		if exists, _ := s.hasProject(name); exists {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	profile, _, err := s.GetProfile(lxdDefaultProfileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(projectServer.UpdateProfile(lxdDefaultProfileName, profile.Writable(), ""))
+}
+
+func (s *Server) hasProject(name string) (bool, error) {
+	projects, err := s.GetProjectNames()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, project := range projects {
+		if project == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// defaultProjectContainers returns containers matching the input prefix
+// and statuses from the default project, when this server is scoped to
+// another project. Such containers were created before the use of
+// projects, and are included so that they can still be managed.
+func (s *Server) defaultProjectContainers(prefix string, statuses []string) ([]Container, error) {
+	if s.defaultProjectServer == nil {
+		return nil, nil
+	}
+	containers, err := filterContainers(s.defaultProjectServer, prefix, statuses)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i := range containers {
+		containers[i].inDefaultProject = true
+	}
+	return containers, nil
+}
+
+// getContainer returns the container with the input name and its ETag.
+// If it is not found in the project this server is scoped to, it is
+// looked for in the default project, as it may have been created before
+// projects were used.
+func (s *Server) getContainer(name string) (Container, string, error) {
+	container, eTag, err := s.GetContainer(name)
+	if err == nil {
+		return Container{Container: *container}, eTag, nil
+	}
+	if s.defaultProjectServer == nil || !IsLXDNotFound(errors.Cause(err)) {
+		return Container{}, "", errors.Trace(err)
+	}
+	container, eTag, err = s.defaultProjectServer.GetContainer(name)
+	if err != nil {
+		return Container{}, "", errors.Trace(err)
+	}
+	return Container{Container: *container, inDefaultProject: true}, eTag, nil
+}
+
+// containerServerFor returns the LXD client for the project holding the
+// input container.
+func (s *Server) containerServerFor(c Container) lxd.ContainerServer {
+	if c.inDefaultProject && s.defaultProjectServer != nil {
+		return s.defaultProjectServer
+	}
+	return s.ContainerServer
+}
+
+// serverFor returns a Server scoped to the project holding the input
+// container, so that the profiles it uses are found in the same project.
+func (s *Server) serverFor(c Container) *Server {
+	if c.inDefaultProject {
+		if unscoped := s.defaultProjectView(); unscoped != nil {
+			return unscoped
+		}
+	}
+	return s
+}
+
+// defaultProjectView returns a Server scoped to the default project, or
+// nil if this server is already using it.
+func (s *Server) defaultProjectView() *Server {
+	if s.defaultProjectServer == nil {
+		return nil
+	}
+	unscoped := *s
+	unscoped.ContainerServer = s.defaultProjectServer
+	unscoped.project = ""
+	unscoped.defaultProjectServer = nil
+	return &unscoped
+}
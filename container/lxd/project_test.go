@@ -0,0 +1,183 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/container/lxd"
+	lxdtesting "github.com/juju/juju/container/lxd/testing"
+)
+
+const projectModelUUID = "1a2b3c4d-0000-4000-8000-000000000000"
+
+type projectSuite struct {
+	lxdtesting.BaseSuite
+}
+
+var _ = gc.Suite(&projectSuite{})
+
+func (s *projectSuite) TestModelProjectName(c *gc.C) {
+	c.Check(lxd.ModelProjectName(projectModelUUID), gc.Equals, "juju-1a2b3c")
+}
+
+func (s *projectSuite) TestUseModelProjectNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "network")
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(jujuSvr.ProjectSupported(), jc.IsFalse)
+
+	scoped, err := jujuSvr.UseModelProject(projectModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(scoped, gc.Equals, jujuSvr)
+	c.Check(scoped.Project(), gc.Equals, lxd.DefaultProject)
+}
+
+func (s *projectSuite) TestUseModelProjectExisting(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+	pSvr := lxdtesting.NewMockContainerServer(ctrl)
+
+	cSvr.EXPECT().UseProject("juju-1a2b3c").Return(pSvr)
+	cSvr.EXPECT().GetProjectNames().Return([]string{"default", "juju-1a2b3c"}, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(jujuSvr.ProjectSupported(), jc.IsTrue)
+
+	scoped, err := jujuSvr.UseModelProject(projectModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(scoped.Project(), gc.Equals, "juju-1a2b3c")
+	c.Check(scoped.ContainerServer, gc.Equals, pSvr)
+	c.Check(jujuSvr.Project(), gc.Equals, lxd.DefaultProject)
+}
+
+func (s *projectSuite) TestUseModelProjectCreates(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+	pSvr := lxdtesting.NewMockContainerServer(ctrl)
+
+	profile := &api.Profile{
+		Name: "default",
+		ProfilePut: api.ProfilePut{
+			Devices: map[string]map[string]string{
+				"eth0": {"type": "nic", "nictype": "bridged", "parent": "lxdbr0"},
+				"root": {"type": "disk", "path": "/", "pool": "default"},
+			},
+		},
+	}
+	createReq := api.ProjectsPost{
+		Name: "juju-1a2b3c",
+		ProjectPut: api.ProjectPut{
+			Description: "Juju managed containers",
+			Config: map[string]string{
+				"features.images":   "true",
+				"features.profiles": "true",
+			},
+		},
+	}
+	gomock.InOrder(
+		cSvr.EXPECT().UseProject("juju-1a2b3c").Return(pSvr),
+		cSvr.EXPECT().GetProjectNames().Return([]string{"default"}, nil),
+		cSvr.EXPECT().CreateProject(createReq).Return(nil),
+		cSvr.EXPECT().GetProfile("default").Return(profile, lxdtesting.ETag, nil),
+		pSvr.EXPECT().UpdateProfile("default", profile.Writable(), "").Return(nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	scoped, err := jujuSvr.UseModelProject(projectModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(scoped.Project(), gc.Equals, "juju-1a2b3c")
+}
+
+func (s *projectSuite) TestUseModelProjectCreateError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+	pSvr := lxdtesting.NewMockContainerServer(ctrl)
+
+	gomock.InOrder(
+		cSvr.EXPECT().UseProject("juju-1a2b3c").Return(pSvr),
+		cSvr.EXPECT().GetProjectNames().Return([]string{"default"}, nil),
+		cSvr.EXPECT().CreateProject(gomock.Any()).Return(errors.New("boom")),
+		cSvr.EXPECT().GetProjectNames().Return([]string{"default"}, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = jujuSvr.UseModelProject(projectModelUUID)
+	c.Assert(err, gc.ErrorMatches, `ensuring LXD project "juju-1a2b3c": boom`)
+}
+
+func (s *projectSuite) TestFilterContainersIncludesDefaultProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+	pSvr := lxdtesting.NewMockContainerServer(ctrl)
+
+	cSvr.EXPECT().UseProject("juju-1a2b3c").Return(pSvr)
+	cSvr.EXPECT().GetProjectNames().Return([]string{"default", "juju-1a2b3c"}, nil)
+
+	pSvr.EXPECT().GetContainers().Return([]api.Container{
+		{Name: "juju-1a2b3c-1", StatusCode: api.Running},
+	}, nil)
+	cSvr.EXPECT().GetContainers().Return([]api.Container{
+		{Name: "juju-1a2b3c-0", StatusCode: api.Running},
+		{Name: "juju-1a2b3c-1", StatusCode: api.Running},
+		{Name: "juju-ffffff-0", StatusCode: api.Running},
+	}, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	scoped, err := jujuSvr.UseModelProject(projectModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	containers, err := scoped.FilterContainers("juju-1a2b3c-")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var names []string
+	for _, container := range containers {
+		names = append(names, container.Name)
+	}
+	c.Check(names, jc.DeepEquals, []string{"juju-1a2b3c-1", "juju-1a2b3c-0"})
+}
+
+func (s *projectSuite) TestRemoveContainerFallsBackToDefaultProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+	pSvr := lxdtesting.NewMockContainerServer(ctrl)
+
+	cSvr.EXPECT().UseProject("juju-1a2b3c").Return(pSvr)
+	cSvr.EXPECT().GetProjectNames().Return([]string{"default", "juju-1a2b3c"}, nil)
+
+	deleteOp := lxdtesting.NewMockOperation(ctrl)
+	deleteOp.EXPECT().Wait().Return(nil)
+
+	pSvr.EXPECT().GetContainerState("juju-1a2b3c-0").Return(nil, "", errors.New("not found"))
+	cSvr.EXPECT().GetContainerState("juju-1a2b3c-0").Return(
+		&api.ContainerState{StatusCode: api.Stopped}, lxdtesting.ETag, nil)
+	cSvr.EXPECT().DeleteContainer("juju-1a2b3c-0").Return(deleteOp, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	scoped, err := jujuSvr.UseModelProject(projectModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = scoped.RemoveContainer("juju-1a2b3c-0")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -45,6 +45,16 @@ type Server struct {
 	networkAPISupport bool
 	clusterAPISupport bool
 	storageAPISupport bool
+	projectAPISupport bool
+
+	// project is the LXD project operations are scoped to.
+	// Empty indicates the default project.
+	project string
+
+	// defaultProjectServer is the client for the default project when
+	// operations are scoped to another project. It is used to find
+	// containers created before Juju used projects.
+	defaultProjectServer lxd.ContainerServer
 
 	localBridgeName string
 
@@ -129,6 +139,7 @@ func NewServer(svr lxd.ContainerServer) (*Server, error) {
 		networkAPISupport: shared.StringInSlice("network", apiExt),
 		clusterAPISupport: shared.StringInSlice("clustering", apiExt),
 		storageAPISupport: shared.StringInSlice("storage", apiExt),
+		projectAPISupport: shared.StringInSlice("projects", apiExt),
 		serverVersion:     info.Environment.ServerVersion,
 		clock:             clock.WallClock,
 	}, nil
@@ -161,7 +172,7 @@ func (s *Server) UpdateServerConfig(cfg map[string]string) error {
 // UpdateContainerConfig updates the configuration for the container with the
 // input name, using the input values.
 func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.getContainer(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -172,7 +183,7 @@ func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error
 		container.Config[k] = v
 	}
 
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.containerServerFor(container).UpdateContainer(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -182,7 +193,7 @@ func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error
 // GetContainerProfiles returns the list of profiles that are assocated with a
 // container.
 func (s *Server) GetContainerProfiles(name string) ([]string, error) {
-	container, _, err := s.GetContainer(name)
+	container, _, err := s.getContainer(name)
 	if err != nil {
 		return []string{}, errors.Trace(err)
 	}
@@ -195,14 +206,14 @@ func (s *Server) GetContainerProfiles(name string) ([]string, error) {
 // ReplaceOrAddContainerProfile updates the profiles for the container with the
 // input name, using the input values.
 func (s *Server) ReplaceOrAddContainerProfile(name, oldProfile, newProfile string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.getContainer(name)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to get container %q", name))
 	}
 	profiles := addRemoveReplaceProfileName(container.Profiles, oldProfile, newProfile)
 
 	container.Profiles = profiles
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.containerServerFor(container).UpdateContainer(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to updated container %q", name))
 	}
@@ -241,13 +252,18 @@ func addRemoveReplaceProfileName(profiles []string, oldProfile, newProfile strin
 // named container.  It is assumed the profiles have all been added to
 // the server before hand.
 func (s *Server) UpdateContainerProfiles(name string, profiles []string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.getContainer(name)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to get %q", name))
 	}
+	return errors.Trace(s.updateContainerProfiles(name, container, eTag, profiles))
+}
 
+// updateContainerProfiles applies the given profiles (by name) to
+// the input container, in the project holding it.
+func (s *Server) updateContainerProfiles(name string, container Container, eTag string, profiles []string) error {
 	container.Profiles = profiles
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.containerServerFor(container).UpdateContainer(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to update %q with profiles", name))
 	}
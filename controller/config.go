@@ -5,6 +5,7 @@ package controller
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"regexp"
 	"time"
@@ -19,6 +20,8 @@ import (
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/pki"
 )
@@ -75,6 +78,19 @@ const (
 	// (compressed).
 	AuditLogMaxBackups = "audit-log-max-backups"
 
+	// AuditLogMaxAge is the number of days old audit log files are
+	// kept for (or 0 to keep them regardless of age).
+	AuditLogMaxAge = "audit-log-max-age"
+
+	// AuditLogSink determines where audit records are written, either
+	// "file" or "syslog".
+	AuditLogSink = "audit-log-sink"
+
+	// AuditLogSyslogAddress is the host:port of the syslog server
+	// audit records are forwarded to when the audit log sink is
+	// "syslog".
+	AuditLogSyslogAddress = "audit-log-syslog-address"
+
 	// AuditLogExcludeMethods is a list of Facade.Method names that
 	// aren't interesting for audit logging purposes. A conversation
 	// with only calls to these will be excluded from the
@@ -199,6 +215,61 @@ const (
 	// when writing to the raft log by setting this value to true.
 	NonSyncedWritesToRaftLog = "non-synced-writes-to-raft-log"
 
+	// LogSinkIngestionRate is the number of log lines per second that
+	// each agent connection may send to the logsink endpoint. Lines in
+	// excess of the limit are dropped. A value of 0 disables the limit.
+	LogSinkIngestionRate = "logsink-ingestion-rate"
+
+	// LogSinkIngestionBurst is the number of log lines that an agent
+	// connection may send to the logsink endpoint in excess of the
+	// rate limit, before lines start being dropped.
+	LogSinkIngestionBurst = "logsink-ingestion-burst"
+
+	// ModelLogsDailyQuota is the maximum number of log lines that agents
+	// may send for each model in a day. Lines in excess of the quota are
+	// dropped. A value of 0 disables the quota.
+	ModelLogsDailyQuota = "model-logs-daily-quota"
+
+	// MaxCharmUploadSize is the maximum size of a charm archive that may
+	// be uploaded to the controller, eg "1G". A value of 0 disables the
+	// limit.
+	MaxCharmUploadSize = "max-charm-upload-size"
+
+	// MaxResourceUploadSize is the maximum size of a resource that may be
+	// uploaded to the controller, eg "10G". A value of 0 disables the
+	// limit.
+	MaxResourceUploadSize = "max-resource-upload-size"
+
+	// MaxAgentBinaryUploadSize is the maximum size of an agent binary
+	// archive that may be uploaded to the controller, eg "1G". A value
+	// of 0 disables the limit.
+	MaxAgentBinaryUploadSize = "max-agent-binary-upload-size"
+
+	// MaxBackupUploadSize is the maximum size of a backup archive that
+	// may be uploaded to the controller, eg "50G". A value of 0 disables
+	// the limit.
+	MaxBackupUploadSize = "max-backup-upload-size"
+
+	// AgentChurnDisconnects is the number of times a non controller agent
+	// may disconnect from the API servers within agent-churn-window
+	// before its status reports an unstable connection.
+	AgentChurnDisconnects = "agent-churn-disconnects"
+
+	// AgentChurnWindow is the period over which agent disconnections are
+	// counted towards agent-churn-disconnects.
+	AgentChurnWindow = "agent-churn-window"
+
+	// ModelCacheEvictionRetention is how long the controller's model cache
+	// retains dead machines and completed branches before evicting them.
+	// A value of 0 disables eviction.
+	ModelCacheEvictionRetention = "model-cache-eviction-retention"
+
+	// ModelCacheMaxResidents is a soft limit on the number of entities
+	// held in the controller's model cache. Once exceeded, the least
+	// recently accessed entities that are not being watched are evicted.
+	// A value of 0 disables the limit.
+	ModelCacheMaxResidents = "model-cache-max-residents"
+
 	// Attribute Defaults
 
 	// DefaultAgentRateLimitMax allows the first 10 agents to connect without any
@@ -225,6 +296,14 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogMaxAge is the default number of days to keep old
+	// audit log files for (0 meaning no limit).
+	DefaultAuditLogMaxAge = 0
+
+	// DefaultAuditLogSink is the default destination for audit
+	// records.
+	DefaultAuditLogSink = "file"
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -292,6 +371,45 @@ const (
 	// non-synced-writes-to-raft-log value. It is set to false by default.
 	DefaultNonSyncedWritesToRaftLog = false
 
+	// DefaultLogSinkIngestionRate leaves logsink ingestion unlimited.
+	DefaultLogSinkIngestionRate = 0
+
+	// DefaultLogSinkIngestionBurst allows an agent to send 1000 lines
+	// in excess of the logsink rate limit before lines are dropped.
+	DefaultLogSinkIngestionBurst = 1000
+
+	// DefaultModelLogsDailyQuota leaves the number of log lines
+	// that may be written for each model unlimited.
+	DefaultModelLogsDailyQuota = 0
+
+	// DefaultMaxCharmUploadSizeMB is the maximum size in MB
+	// of a charm archive uploaded to the controller.
+	DefaultMaxCharmUploadSizeMB = 1024
+
+	// DefaultMaxResourceUploadSizeMB leaves the size of
+	// resources uploaded to the controller unlimited.
+	DefaultMaxResourceUploadSizeMB = 0
+
+	// DefaultMaxAgentBinaryUploadSizeMB is the maximum size in MB
+	// of an agent binary archive uploaded to the controller.
+	DefaultMaxAgentBinaryUploadSizeMB = 1024
+
+	// DefaultMaxBackupUploadSizeMB leaves the size of
+	// backups uploaded to the controller unlimited.
+	DefaultMaxBackupUploadSizeMB = 0
+
+	// DefaultAgentChurnDisconnects is the number of disconnections
+	// within the churn window at which an agent is flapping.
+	DefaultAgentChurnDisconnects = 5
+
+	// DefaultAgentChurnWindow is the period over which
+	// agent disconnections are counted.
+	DefaultAgentChurnWindow = 10 * time.Minute
+
+	// DefaultModelCacheEvictionRetention is how long dead machines and
+	// completed branches are retained in the model cache by default.
+	DefaultModelCacheEvictionRetention = 10 * time.Minute
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -357,6 +475,9 @@ var (
 		AuditLogCaptureArgs,
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
+		AuditLogMaxAge,
+		AuditLogSink,
+		AuditLogSyslogAddress,
 		AuditLogExcludeMethods,
 		CAASOperatorImagePath,
 		CAASImageRepo,
@@ -365,6 +486,17 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
+		LogSinkIngestionRate,
+		LogSinkIngestionBurst,
+		ModelLogsDailyQuota,
+		MaxCharmUploadSize,
+		MaxResourceUploadSize,
+		MaxAgentBinaryUploadSize,
+		MaxBackupUploadSize,
+		AgentChurnDisconnects,
+		AgentChurnWindow,
+		ModelCacheEvictionRetention,
+		ModelCacheMaxResidents,
 	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
@@ -389,6 +521,8 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		AuditLogSink,
+		AuditLogSyslogAddress,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -411,6 +545,15 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
+		LogSinkIngestionRate,
+		LogSinkIngestionBurst,
+		ModelLogsDailyQuota,
+		MaxCharmUploadSize,
+		MaxResourceUploadSize,
+		MaxAgentBinaryUploadSize,
+		MaxBackupUploadSize,
+		AgentChurnDisconnects,
+		AgentChurnWindow,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -611,6 +754,26 @@ func (c Config) AuditLogMaxBackups() int {
 	return c.intOrDefault(AuditLogMaxBackups, DefaultAuditLogMaxBackups)
 }
 
+// AuditLogMaxAgeDays returns the number of days to keep backup audit
+// log files for.
+func (c Config) AuditLogMaxAgeDays() int {
+	return c.intOrDefault(AuditLogMaxAge, DefaultAuditLogMaxAge)
+}
+
+// AuditLogSink returns the destination audit records are written to.
+func (c Config) AuditLogSink() string {
+	if v := c.asString(AuditLogSink); v != "" {
+		return v
+	}
+	return DefaultAuditLogSink
+}
+
+// AuditLogSyslogAddress returns the host:port of the syslog server
+// audit records are forwarded to.
+func (c Config) AuditLogSyslogAddress() string {
+	return c.asString(AuditLogSyslogAddress)
+}
+
 // AuditLogExcludeMethods returns the set of method names that are
 // considered uninteresting for audit logging. Conversations
 // containing only these will be excluded from the audit log.
@@ -844,6 +1007,74 @@ func (c Config) MaxAgentStateSize() int {
 	return c.intOrDefault(MaxAgentStateSize, DefaultMaxAgentStateSize)
 }
 
+// LogSinkIngestionRate returns the number of log lines per second that
+// each agent connection may send to the logsink endpoint. A value of
+// zero indicates no limit.
+func (c Config) LogSinkIngestionRate() int {
+	return c.intOrDefault(LogSinkIngestionRate, DefaultLogSinkIngestionRate)
+}
+
+// LogSinkIngestionBurst returns the number of log lines that each agent
+// connection may send to the logsink endpoint in excess of the rate limit.
+func (c Config) LogSinkIngestionBurst() int {
+	return c.intOrDefault(LogSinkIngestionBurst, DefaultLogSinkIngestionBurst)
+}
+
+// ModelLogsDailyQuota returns the number of log lines that agents may send
+// for each model in a day. A value of zero indicates no limit.
+func (c Config) ModelLogsDailyQuota() int {
+	return c.intOrDefault(ModelLogsDailyQuota, DefaultModelLogsDailyQuota)
+}
+
+// MaxCharmUploadSizeMB returns the maximum size in MiB of a charm archive
+// that may be uploaded to the controller. Zero indicates no limit.
+func (c Config) MaxCharmUploadSizeMB() int {
+	return c.sizeMBOrDefault(MaxCharmUploadSize, DefaultMaxCharmUploadSizeMB)
+}
+
+// MaxResourceUploadSizeMB returns the maximum size in MiB of a resource
+// that may be uploaded to the controller. Zero indicates no limit.
+func (c Config) MaxResourceUploadSizeMB() int {
+	return c.sizeMBOrDefault(MaxResourceUploadSize, DefaultMaxResourceUploadSizeMB)
+}
+
+// MaxAgentBinaryUploadSizeMB returns the maximum size in MiB of an agent
+// binary archive that may be uploaded to the controller. Zero indicates
+// no limit.
+func (c Config) MaxAgentBinaryUploadSizeMB() int {
+	return c.sizeMBOrDefault(MaxAgentBinaryUploadSize, DefaultMaxAgentBinaryUploadSizeMB)
+}
+
+// MaxBackupUploadSizeMB returns the maximum size in MiB of a backup
+// archive that may be uploaded to the controller. Zero indicates no
+// limit.
+func (c Config) MaxBackupUploadSizeMB() int {
+	return c.sizeMBOrDefault(MaxBackupUploadSize, DefaultMaxBackupUploadSizeMB)
+}
+
+// AgentChurnThreshold returns the threshold at which an agent that
+// repeatedly disconnects from the API servers is considered to be
+// flapping.
+func (c Config) AgentChurnThreshold() presence.ChurnThreshold {
+	return presence.ChurnThreshold{
+		Disconnects: c.intOrDefault(AgentChurnDisconnects, DefaultAgentChurnDisconnects),
+		Window:      c.durationOrDefault(AgentChurnWindow, DefaultAgentChurnWindow),
+	}
+}
+
+// ModelCacheEvictionRetention returns how long the model cache retains
+// dead machines and completed branches before evicting them. Zero
+// indicates that they are not evicted.
+func (c Config) ModelCacheEvictionRetention() time.Duration {
+	return c.durationOrDefault(ModelCacheEvictionRetention, DefaultModelCacheEvictionRetention)
+}
+
+// ModelCacheMaxResidents returns the soft limit on the number of
+// entities held in the model cache. Zero indicates no limit.
+func (c Config) ModelCacheMaxResidents() int {
+	return c.intOrDefault(ModelCacheMaxResidents, 0)
+}
+
 // NonSyncedWritesToRaftLog returns true if fsync calls should be skipped
 // after each write to the raft log.
 func (c Config) NonSyncedWritesToRaftLog() bool {
@@ -907,6 +1138,32 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[LogSinkIngestionRate].(int); ok && v < 0 {
+		return errors.NotValidf("negative %s (%d)", LogSinkIngestionRate, v)
+	}
+	if v, ok := c[LogSinkIngestionBurst].(int); ok && v <= 0 {
+		return errors.NotValidf("non-positive %s (%d)", LogSinkIngestionBurst, v)
+	}
+	if v, ok := c[ModelLogsDailyQuota].(int); ok && v < 0 {
+		return errors.NotValidf("negative %s (%d)", ModelLogsDailyQuota, v)
+	}
+	churnThreshold := presence.ChurnThreshold{
+		Disconnects: DefaultAgentChurnDisconnects,
+		Window:      c.durationOrDefault(AgentChurnWindow, DefaultAgentChurnWindow),
+	}
+	if v, ok := c[AgentChurnDisconnects].(int); ok {
+		churnThreshold.Disconnects = v
+	}
+	if err := churnThreshold.Validate(); err != nil {
+		return errors.Annotatef(err, "invalid %s or %s", AgentChurnDisconnects, AgentChurnWindow)
+	}
+	if v, ok := c[ModelCacheEvictionRetention].(time.Duration); ok && v < 0 {
+		return errors.NotValidf("negative %s (%v)", ModelCacheEvictionRetention, v)
+	}
+	if v, ok := c[ModelCacheMaxResidents].(int); ok && v < 0 {
+		return errors.NotValidf("negative %s (%d)", ModelCacheMaxResidents, v)
+	}
+
 	if mgoMemProfile, ok := c[MongoMemoryProfile].(string); ok {
 		if mgoMemProfile != MongoProfLow && mgoMemProfile != MongoProfDefault {
 			return errors.Errorf("mongo-memory-profile: expected one of %q or %q got string(%q)", MongoProfLow, MongoProfDefault, mgoMemProfile)
@@ -950,6 +1207,19 @@ func Validate(c Config) error {
 		}
 	}
 
+	for _, name := range []string{
+		MaxCharmUploadSize,
+		MaxResourceUploadSize,
+		MaxAgentBinaryUploadSize,
+		MaxBackupUploadSize,
+	} {
+		if v, ok := c[name].(string); ok {
+			if _, err := utils.ParseSize(v); err != nil {
+				return errors.Annotatef(err, "invalid %s in configuration", name)
+			}
+		}
+	}
+
 	if v, ok := c[PruneTxnSleepTime].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotatef(err, `%s must be a valid duration (eg "10ms")`, PruneTxnSleepTime)
@@ -997,6 +1267,27 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogMaxAge].(int); ok {
+		if v < 0 {
+			return errors.Errorf("invalid audit log max age: should be a number of days (or 0 to keep all), got %d", v)
+		}
+	}
+
+	if v, ok := c[AuditLogSink].(string); ok {
+		if err := auditlog.SinkType(v).Validate(); err != nil {
+			return errors.Annotate(err, "invalid audit log sink")
+		}
+		if auditlog.SinkType(v) == auditlog.SyslogSink && c.AuditLogSyslogAddress() == "" {
+			return errors.Errorf("invalid audit log sink: %q requires %s to be set", v, AuditLogSyslogAddress)
+		}
+	}
+
+	if v, ok := c[AuditLogSyslogAddress].(string); ok && v != "" {
+		if _, _, err := net.SplitHostPort(v); err != nil {
+			return errors.Annotate(err, "invalid audit log syslog address")
+		}
+	}
+
 	if v, ok := c[AuditLogExcludeMethods].([]interface{}); ok {
 		for i, name := range v {
 			name := name.(string)
@@ -1109,87 +1400,115 @@ func (c Config) AsSpaceConstraints(spaces *[]string) *[]string {
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AgentRateLimitMax:        schema.ForceInt(),
-	AgentRateLimitRate:       schema.TimeDuration(),
-	AuditingEnabled:          schema.Bool(),
-	AuditLogCaptureArgs:      schema.Bool(),
-	AuditLogMaxSize:          schema.String(),
-	AuditLogMaxBackups:       schema.ForceInt(),
-	AuditLogExcludeMethods:   schema.List(schema.String()),
-	APIPort:                  schema.ForceInt(),
-	APIPortOpenDelay:         schema.String(),
-	ControllerAPIPort:        schema.ForceInt(),
-	ControllerName:           schema.String(),
-	StatePort:                schema.ForceInt(),
-	IdentityURL:              schema.String(),
-	IdentityPublicKey:        schema.String(),
-	SetNUMAControlPolicyKey:  schema.Bool(),
-	AutocertURLKey:           schema.String(),
-	AutocertDNSNameKey:       schema.String(),
-	AllowModelAccessKey:      schema.Bool(),
-	MongoMemoryProfile:       schema.String(),
-	JujuDBSnapChannel:        schema.String(),
-	MaxDebugLogDuration:      schema.TimeDuration(),
-	MaxTxnLogSize:            schema.String(),
-	MaxPruneTxnBatchSize:     schema.ForceInt(),
-	MaxPruneTxnPasses:        schema.ForceInt(),
-	ModelLogfileMaxBackups:   schema.ForceInt(),
-	ModelLogfileMaxSize:      schema.String(),
-	ModelLogsSize:            schema.String(),
-	PruneTxnQueryCount:       schema.ForceInt(),
-	PruneTxnSleepTime:        schema.String(),
-	PublicDNSAddress:         schema.String(),
-	JujuHASpace:              schema.String(),
-	JujuManagementSpace:      schema.String(),
-	CAASOperatorImagePath:    schema.String(),
-	CAASImageRepo:            schema.String(),
-	Features:                 schema.List(schema.String()),
-	CharmStoreURL:            schema.String(),
-	MeteringURL:              schema.String(),
-	MaxCharmStateSize:        schema.ForceInt(),
-	MaxAgentStateSize:        schema.ForceInt(),
-	NonSyncedWritesToRaftLog: schema.Bool(),
+	AgentRateLimitMax:           schema.ForceInt(),
+	AgentRateLimitRate:          schema.TimeDuration(),
+	AuditingEnabled:             schema.Bool(),
+	AuditLogCaptureArgs:         schema.Bool(),
+	AuditLogMaxSize:             schema.String(),
+	AuditLogMaxBackups:          schema.ForceInt(),
+	AuditLogMaxAge:              schema.ForceInt(),
+	AuditLogSink:                schema.String(),
+	AuditLogSyslogAddress:       schema.String(),
+	AuditLogExcludeMethods:      schema.List(schema.String()),
+	APIPort:                     schema.ForceInt(),
+	APIPortOpenDelay:            schema.String(),
+	ControllerAPIPort:           schema.ForceInt(),
+	ControllerName:              schema.String(),
+	StatePort:                   schema.ForceInt(),
+	IdentityURL:                 schema.String(),
+	IdentityPublicKey:           schema.String(),
+	SetNUMAControlPolicyKey:     schema.Bool(),
+	AutocertURLKey:              schema.String(),
+	AutocertDNSNameKey:          schema.String(),
+	AllowModelAccessKey:         schema.Bool(),
+	MongoMemoryProfile:          schema.String(),
+	JujuDBSnapChannel:           schema.String(),
+	MaxDebugLogDuration:         schema.TimeDuration(),
+	MaxTxnLogSize:               schema.String(),
+	MaxPruneTxnBatchSize:        schema.ForceInt(),
+	MaxPruneTxnPasses:           schema.ForceInt(),
+	ModelLogfileMaxBackups:      schema.ForceInt(),
+	ModelLogfileMaxSize:         schema.String(),
+	ModelLogsSize:               schema.String(),
+	PruneTxnQueryCount:          schema.ForceInt(),
+	PruneTxnSleepTime:           schema.String(),
+	PublicDNSAddress:            schema.String(),
+	JujuHASpace:                 schema.String(),
+	JujuManagementSpace:         schema.String(),
+	CAASOperatorImagePath:       schema.String(),
+	CAASImageRepo:               schema.String(),
+	Features:                    schema.List(schema.String()),
+	CharmStoreURL:               schema.String(),
+	MeteringURL:                 schema.String(),
+	MaxCharmStateSize:           schema.ForceInt(),
+	MaxAgentStateSize:           schema.ForceInt(),
+	NonSyncedWritesToRaftLog:    schema.Bool(),
+	LogSinkIngestionRate:        schema.ForceInt(),
+	LogSinkIngestionBurst:       schema.ForceInt(),
+	ModelLogsDailyQuota:         schema.ForceInt(),
+	MaxCharmUploadSize:          schema.String(),
+	MaxResourceUploadSize:       schema.String(),
+	MaxAgentBinaryUploadSize:    schema.String(),
+	MaxBackupUploadSize:         schema.String(),
+	AgentChurnDisconnects:       schema.ForceInt(),
+	AgentChurnWindow:            schema.TimeDuration(),
+	ModelCacheEvictionRetention: schema.TimeDuration(),
+	ModelCacheMaxResidents:      schema.ForceInt(),
 }, schema.Defaults{
-	AgentRateLimitMax:        schema.Omit,
-	AgentRateLimitRate:       schema.Omit,
-	APIPort:                  DefaultAPIPort,
-	APIPortOpenDelay:         DefaultAPIPortOpenDelay,
-	ControllerAPIPort:        schema.Omit,
-	ControllerName:           schema.Omit,
-	AuditingEnabled:          DefaultAuditingEnabled,
-	AuditLogCaptureArgs:      DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:          fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:       DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:   DefaultAuditLogExcludeMethods,
-	StatePort:                DefaultStatePort,
-	IdentityURL:              schema.Omit,
-	IdentityPublicKey:        schema.Omit,
-	SetNUMAControlPolicyKey:  DefaultNUMAControlPolicy,
-	AutocertURLKey:           schema.Omit,
-	AutocertDNSNameKey:       schema.Omit,
-	AllowModelAccessKey:      schema.Omit,
-	MongoMemoryProfile:       DefaultMongoMemoryProfile,
-	JujuDBSnapChannel:        DefaultJujuDBSnapChannel,
-	MaxDebugLogDuration:      DefaultMaxDebugLogDuration,
-	MaxTxnLogSize:            fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	MaxPruneTxnBatchSize:     DefaultMaxPruneTxnBatchSize,
-	MaxPruneTxnPasses:        DefaultMaxPruneTxnPasses,
-	ModelLogfileMaxBackups:   DefaultModelLogfileMaxBackups,
-	ModelLogfileMaxSize:      fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
-	ModelLogsSize:            fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
-	PruneTxnQueryCount:       DefaultPruneTxnQueryCount,
-	PruneTxnSleepTime:        DefaultPruneTxnSleepTime,
-	PublicDNSAddress:         schema.Omit,
-	JujuHASpace:              schema.Omit,
-	JujuManagementSpace:      schema.Omit,
-	CAASOperatorImagePath:    schema.Omit,
-	CAASImageRepo:            schema.Omit,
-	Features:                 schema.Omit,
-	CharmStoreURL:            csclient.ServerURL,
-	MeteringURL:              romulus.DefaultAPIRoot,
-	MaxCharmStateSize:        DefaultMaxCharmStateSize,
-	MaxAgentStateSize:        DefaultMaxAgentStateSize,
-	NonSyncedWritesToRaftLog: DefaultNonSyncedWritesToRaftLog,
+	AgentRateLimitMax:           schema.Omit,
+	AgentRateLimitRate:          schema.Omit,
+	APIPort:                     DefaultAPIPort,
+	APIPortOpenDelay:            DefaultAPIPortOpenDelay,
+	ControllerAPIPort:           schema.Omit,
+	ControllerName:              schema.Omit,
+	AuditingEnabled:             DefaultAuditingEnabled,
+	AuditLogCaptureArgs:         DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:             fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:          DefaultAuditLogMaxBackups,
+	AuditLogMaxAge:              schema.Omit,
+	AuditLogSink:                schema.Omit,
+	AuditLogSyslogAddress:       schema.Omit,
+	AuditLogExcludeMethods:      DefaultAuditLogExcludeMethods,
+	StatePort:                   DefaultStatePort,
+	IdentityURL:                 schema.Omit,
+	IdentityPublicKey:           schema.Omit,
+	SetNUMAControlPolicyKey:     DefaultNUMAControlPolicy,
+	AutocertURLKey:              schema.Omit,
+	AutocertDNSNameKey:          schema.Omit,
+	AllowModelAccessKey:         schema.Omit,
+	MongoMemoryProfile:          DefaultMongoMemoryProfile,
+	JujuDBSnapChannel:           DefaultJujuDBSnapChannel,
+	MaxDebugLogDuration:         DefaultMaxDebugLogDuration,
+	MaxTxnLogSize:               fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MaxPruneTxnBatchSize:        DefaultMaxPruneTxnBatchSize,
+	MaxPruneTxnPasses:           DefaultMaxPruneTxnPasses,
+	ModelLogfileMaxBackups:      DefaultModelLogfileMaxBackups,
+	ModelLogfileMaxSize:         fmt.Sprintf("%vM", DefaultModelLogfileMaxSize),
+	ModelLogsSize:               fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
+	PruneTxnQueryCount:          DefaultPruneTxnQueryCount,
+	PruneTxnSleepTime:           DefaultPruneTxnSleepTime,
+	PublicDNSAddress:            schema.Omit,
+	JujuHASpace:                 schema.Omit,
+	JujuManagementSpace:         schema.Omit,
+	CAASOperatorImagePath:       schema.Omit,
+	CAASImageRepo:               schema.Omit,
+	Features:                    schema.Omit,
+	CharmStoreURL:               csclient.ServerURL,
+	MeteringURL:                 romulus.DefaultAPIRoot,
+	MaxCharmStateSize:           DefaultMaxCharmStateSize,
+	MaxAgentStateSize:           DefaultMaxAgentStateSize,
+	NonSyncedWritesToRaftLog:    DefaultNonSyncedWritesToRaftLog,
+	LogSinkIngestionRate:        schema.Omit,
+	LogSinkIngestionBurst:       schema.Omit,
+	ModelLogsDailyQuota:         schema.Omit,
+	MaxCharmUploadSize:          schema.Omit,
+	MaxResourceUploadSize:       schema.Omit,
+	MaxAgentBinaryUploadSize:    schema.Omit,
+	MaxBackupUploadSize:         schema.Omit,
+	AgentChurnDisconnects:       schema.Omit,
+	AgentChurnWindow:            schema.Omit,
+	ModelCacheEvictionRetention: schema.Omit,
+	ModelCacheMaxResidents:      schema.Omit,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1220,6 +1539,18 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.Tint,
 		Description: "The number of old audit log files to keep (compressed)",
 	},
+	AuditLogMaxAge: {
+		Type:        environschema.Tint,
+		Description: "The number of days to keep old audit log files (or 0 to keep them regardless of age)",
+	},
+	AuditLogSink: {
+		Type:        environschema.Tstring,
+		Description: `Where audit records are written, either "file" or "syslog"`,
+	},
+	AuditLogSyslogAddress: {
+		Type:        environschema.Tstring,
+		Description: "The host:port of the syslog server audit records are forwarded to over UDP",
+	},
 	AuditLogExcludeMethods: {
 		Type:        environschema.FieldType("list of strings"),
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
@@ -1362,4 +1693,48 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tbool,
 		Description: `Do not perform fsync calls after appending entries to the raft log. Disabling sync improves performance at the cost of reliability`,
 	},
+	LogSinkIngestionRate: {
+		Type:        environschema.Tint,
+		Description: `The number of log lines per second each agent may send to the controller, excess lines are dropped (or 0 to disable limit)`,
+	},
+	LogSinkIngestionBurst: {
+		Type:        environschema.Tint,
+		Description: `The number of log lines each agent may send in excess of logsink-ingestion-rate before lines are dropped`,
+	},
+	ModelLogsDailyQuota: {
+		Type:        environschema.Tint,
+		Description: `The number of log lines agents may send for each model per day, excess lines are dropped (or 0 to disable quota)`,
+	},
+	MaxCharmUploadSize: {
+		Type:        environschema.Tstring,
+		Description: `The maximum size of a charm archive that may be uploaded to the controller (or 0 to disable limit)`,
+	},
+	MaxResourceUploadSize: {
+		Type:        environschema.Tstring,
+		Description: `The maximum size of a resource that may be uploaded to the controller (or 0 to disable limit)`,
+	},
+	MaxAgentBinaryUploadSize: {
+		Type:        environschema.Tstring,
+		Description: `The maximum size of an agent binary archive that may be uploaded to the controller (or 0 to disable limit)`,
+	},
+	MaxBackupUploadSize: {
+		Type:        environschema.Tstring,
+		Description: `The maximum size of a backup archive that may be uploaded to the controller (or 0 to disable limit)`,
+	},
+	AgentChurnDisconnects: {
+		Type:        environschema.Tint,
+		Description: `The number of times an agent may disconnect within agent-churn-window before its connection is reported as unstable (between 1 and 10)`,
+	},
+	AgentChurnWindow: {
+		Type:        environschema.Tstring,
+		Description: `The period over which agent disconnections are counted towards agent-churn-disconnects`,
+	},
+	ModelCacheEvictionRetention: {
+		Type:        environschema.Tstring,
+		Description: `How long the model cache retains dead machines and completed branches before evicting them (or 0 to disable eviction)`,
+	},
+	ModelCacheMaxResidents: {
+		Type:        environschema.Tint,
+		Description: `The number of entities held in the model cache above which the least recently accessed, unwatched entities are evicted (or 0 for no limit)`,
+	},
 }
@@ -15,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/testing"
 )
 
@@ -149,6 +150,31 @@ var newConfigTests = []struct {
 		controller.AuditLogMaxBackups: -10,
 	},
 	expectError: `invalid audit log max backups: should be a number of files \(or 0 to keep all\), got -10`,
+}, {
+	about: "invalid audit log max age",
+	config: controller.Config{
+		controller.AuditLogMaxAge: -1,
+	},
+	expectError: `invalid audit log max age: should be a number of days \(or 0 to keep all\), got -1`,
+}, {
+	about: "invalid audit log sink",
+	config: controller.Config{
+		controller.AuditLogSink: "carrier-pigeon",
+	},
+	expectError: `invalid audit log sink: audit log sink "carrier-pigeon" not valid`,
+}, {
+	about: "syslog audit log sink without address",
+	config: controller.Config{
+		controller.AuditLogSink: "syslog",
+	},
+	expectError: `invalid audit log sink: "syslog" requires audit-log-syslog-address to be set`,
+}, {
+	about: "invalid audit log syslog address",
+	config: controller.Config{
+		controller.AuditLogSink:          "syslog",
+		controller.AuditLogSyslogAddress: "syslog.example.com",
+	},
+	expectError: `invalid audit log syslog address: address syslog.example.com: missing port in address`,
 }, {
 	about: "invalid audit log exclude",
 	config: controller.Config{
@@ -302,6 +328,54 @@ var newConfigTests = []struct {
 		controller.AgentRateLimitRate: "4h",
 	},
 	expectError: `agent-ratelimit-rate must be between 0..1m`,
+}, {
+	about: "logsink-ingestion-rate negative",
+	config: controller.Config{
+		controller.LogSinkIngestionRate: -5,
+	},
+	expectError: `negative logsink-ingestion-rate \(-5\) not valid`,
+}, {
+	about: "logsink-ingestion-burst zero",
+	config: controller.Config{
+		controller.LogSinkIngestionBurst: 0,
+	},
+	expectError: `non-positive logsink-ingestion-burst \(0\) not valid`,
+}, {
+	about: "model-logs-daily-quota negative",
+	config: controller.Config{
+		controller.ModelLogsDailyQuota: -5,
+	},
+	expectError: `negative model-logs-daily-quota \(-5\) not valid`,
+}, {
+	about: "agent-churn-disconnects too large",
+	config: controller.Config{
+		controller.AgentChurnDisconnects: 11,
+	},
+	expectError: `invalid agent-churn-disconnects or agent-churn-window: disconnects 11, expected between 1 and 10 not valid`,
+}, {
+	about: "agent-churn-window negative",
+	config: controller.Config{
+		controller.AgentChurnWindow: "-1m",
+	},
+	expectError: `invalid agent-churn-disconnects or agent-churn-window: window -1m0s not valid`,
+}, {
+	about: "model-cache-eviction-retention negative",
+	config: controller.Config{
+		controller.ModelCacheEvictionRetention: "-1m",
+	},
+	expectError: `negative model-cache-eviction-retention \(-1m0s\) not valid`,
+}, {
+	about: "model-cache-max-residents negative",
+	config: controller.Config{
+		controller.ModelCacheMaxResidents: -1,
+	},
+	expectError: `negative model-cache-max-residents \(-1\) not valid`,
+}, {
+	about: "max-charm-upload-size not valid",
+	config: controller.Config{
+		controller.MaxCharmUploadSize: "lots",
+	},
+	expectError: `invalid max-charm-upload-size in configuration: expected a non-negative number, got "lots"`,
 }, {
 	about: "max-charm-state-size non-int",
 	config: controller.Config{
@@ -487,6 +561,9 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogCaptureArgs(), gc.Equals, false)
 	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 300)
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
+	c.Assert(cfg.AuditLogMaxAgeDays(), gc.Equals, 0)
+	c.Assert(cfg.AuditLogSink(), gc.Equals, "file")
+	c.Assert(cfg.AuditLogSyslogAddress(), gc.Equals, "")
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
 }
@@ -500,6 +577,9 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 			"audit-log-capture-args":    true,
 			"audit-log-max-size":        "100M",
 			"audit-log-max-backups":     10.0,
+			"audit-log-max-age":         30,
+			"audit-log-sink":            "syslog",
+			"audit-log-syslog-address":  "10.0.0.1:514",
 			"audit-log-exclude-methods": []string{"Fleet.Foxes", "King.Gizzard", "ReadOnlyMethods"},
 		},
 	)
@@ -508,6 +588,9 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 	c.Assert(cfg.AuditLogCaptureArgs(), gc.Equals, true)
 	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 100)
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
+	c.Assert(cfg.AuditLogMaxAgeDays(), gc.Equals, 30)
+	c.Assert(cfg.AuditLogSink(), gc.Equals, "syslog")
+	c.Assert(cfg.AuditLogSyslogAddress(), gc.Equals, "10.0.0.1:514")
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals, set.NewStrings(
 		"Fleet.Foxes",
 		"King.Gizzard",
@@ -755,6 +838,118 @@ func (s *ConfigSuite) TestAgentRateLimitRate(c *gc.C) {
 	c.Assert(cfg.AgentRateLimitRate(), gc.Equals, 500*time.Millisecond)
 }
 
+func (s *ConfigSuite) TestLogSinkLimits(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LogSinkIngestionRate(), gc.Equals, controller.DefaultLogSinkIngestionRate)
+	c.Assert(cfg.LogSinkIngestionBurst(), gc.Equals, controller.DefaultLogSinkIngestionBurst)
+	c.Assert(cfg.ModelLogsDailyQuota(), gc.Equals, controller.DefaultModelLogsDailyQuota)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"logsink-ingestion-rate":  "100",
+			"logsink-ingestion-burst": "500",
+			"model-logs-daily-quota":  "1000000",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LogSinkIngestionRate(), gc.Equals, 100)
+	c.Assert(cfg.LogSinkIngestionBurst(), gc.Equals, 500)
+	c.Assert(cfg.ModelLogsDailyQuota(), gc.Equals, 1000000)
+}
+
+func (s *ConfigSuite) TestAgentChurnThreshold(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentChurnThreshold(), jc.DeepEquals, presence.ChurnThreshold{
+		Disconnects: controller.DefaultAgentChurnDisconnects,
+		Window:      controller.DefaultAgentChurnWindow,
+	})
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"agent-churn-disconnects": "3",
+			"agent-churn-window":      "1h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AgentChurnThreshold(), jc.DeepEquals, presence.ChurnThreshold{
+		Disconnects: 3,
+		Window:      time.Hour,
+	})
+}
+
+func (s *ConfigSuite) TestModelCacheEvictionRetention(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ModelCacheEvictionRetention(), gc.Equals, controller.DefaultModelCacheEvictionRetention)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"model-cache-eviction-retention": "1h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ModelCacheEvictionRetention(), gc.Equals, time.Hour)
+}
+
+func (s *ConfigSuite) TestModelCacheMaxResidents(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ModelCacheMaxResidents(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"model-cache-max-residents": 5000,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ModelCacheMaxResidents(), gc.Equals, 5000)
+}
+
+func (s *ConfigSuite) TestUploadLimits(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxCharmUploadSizeMB(), gc.Equals, controller.DefaultMaxCharmUploadSizeMB)
+	c.Assert(cfg.MaxResourceUploadSizeMB(), gc.Equals, controller.DefaultMaxResourceUploadSizeMB)
+	c.Assert(cfg.MaxAgentBinaryUploadSizeMB(), gc.Equals, controller.DefaultMaxAgentBinaryUploadSizeMB)
+	c.Assert(cfg.MaxBackupUploadSizeMB(), gc.Equals, controller.DefaultMaxBackupUploadSizeMB)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"max-charm-upload-size":        "200M",
+			"max-resource-upload-size":     "2G",
+			"max-agent-binary-upload-size": "0",
+			"max-backup-upload-size":       "10G",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxCharmUploadSizeMB(), gc.Equals, 200)
+	c.Assert(cfg.MaxResourceUploadSizeMB(), gc.Equals, 2048)
+	c.Assert(cfg.MaxAgentBinaryUploadSizeMB(), gc.Equals, 0)
+	c.Assert(cfg.MaxBackupUploadSizeMB(), gc.Equals, 10240)
+}
+
 func (s *ConfigSuite) TestJujuDBSnapChannel(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -4,19 +4,13 @@ package auditlog
 
 import (
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
-	"io"
 	"math/rand"
-	"path/filepath"
 	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
-	"gopkg.in/natefinch/lumberjack.v2"
-
-	"github.com/juju/juju/core/paths"
 )
 
 var logger = loggo.GetLogger("core.auditlog")
@@ -179,7 +173,7 @@ func newConversationID() string {
 }
 
 type auditLogFile struct {
-	fileLogger io.WriteCloser
+	sink Sink
 }
 
 // NewLogFile returns an audit entry sink which writes to an audit.log
@@ -188,54 +182,30 @@ type auditLogFile struct {
 // the maximum number of old compressed log files to keep (or 0 to
 // keep all of them).
 func NewLogFile(logDir string, maxSize, maxBackups int) AuditLog {
-	logPath := filepath.Join(logDir, "audit.log")
-	if err := paths.PrimeLogFile(logPath); err != nil {
-		// This isn't a fatal error so log and continue if priming
-		// fails.
-		logger.Errorf("Unable to prime %s (proceeding anyway): %v", logPath, err)
-	}
-
 	return &auditLogFile{
-		fileLogger: &lumberjack.Logger{
-			Filename:   logPath,
-			MaxSize:    maxSize,
-			MaxBackups: maxBackups,
-			Compress:   true,
-		},
+		sink: NewFileSink(logDir, maxSize, maxBackups, 0),
 	}
 }
 
 // AddConversation implements AuditLog.
 func (a *auditLogFile) AddConversation(c Conversation) error {
-	return errors.Trace(a.addRecord(Record{Conversation: &c}))
+	return errors.Trace(a.sink.Write(Record{Conversation: &c}))
 }
 
 // AddRequest implements AuditLog.
 func (a *auditLogFile) AddRequest(m Request) error {
-	return errors.Trace(a.addRecord(Record{Request: &m}))
+	return errors.Trace(a.sink.Write(Record{Request: &m}))
 
 }
 
 // AddResponse implements AuditLog.
 func (a *auditLogFile) AddResponse(m ResponseErrors) error {
-	return errors.Trace(a.addRecord(Record{Errors: &m}))
+	return errors.Trace(a.sink.Write(Record{Errors: &m}))
 }
 
 // Close implements AuditLog.
 func (a *auditLogFile) Close() error {
-	return errors.Trace(a.fileLogger.Close())
-}
-
-func (a *auditLogFile) addRecord(r Record) error {
-	bytes, err := json.Marshal(r)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	// Add a linebreak to bytes rather than doing two calls to write
-	// just in case lumberjack rolls the file between them.
-	bytes = append(bytes, byte('\n'))
-	_, err = a.fileLogger.Write(bytes)
-	return errors.Trace(err)
+	return errors.Trace(a.sink.Close())
 }
 
 func idString(id uint64) string {
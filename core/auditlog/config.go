@@ -24,6 +24,18 @@ type Config struct {
 	// MaxBackups determines how many files back to keep.
 	MaxBackups int
 
+	// MaxAgeDays determines how many days old files are kept for
+	// (or 0 to keep them regardless of age).
+	MaxAgeDays int
+
+	// Sink determines where audit records are written. If empty,
+	// records are written to a file.
+	Sink SinkType
+
+	// SyslogAddress is the host:port of the syslog server records
+	// are forwarded to when Sink is SyslogSink.
+	SyslogAddress string
+
 	// ExcludeMethods is a set of facade.method names that we
 	// shouldn't consider to be interesting: if a conversation only
 	// consists of these method calls we won't log it.
@@ -38,5 +50,31 @@ func (cfg Config) Validate() error {
 	if cfg.Enabled && cfg.Target == nil {
 		return errors.NewNotValid(nil, "logging enabled but no target provided")
 	}
+	if cfg.Sink != "" {
+		if err := cfg.Sink.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if cfg.Sink == SyslogSink && cfg.SyslogAddress == "" {
+		return errors.NotValidf("syslog sink without an address")
+	}
 	return nil
 }
+
+// SinkChanged returns true if the input config selects a different
+// sink from this one, or changes how the log file is rotated,
+// requiring a new target to be created.
+func (cfg Config) SinkChanged(other Config) bool {
+	return cfg.sinkType() != other.sinkType() ||
+		cfg.SyslogAddress != other.SyslogAddress ||
+		cfg.MaxSizeMB != other.MaxSizeMB ||
+		cfg.MaxBackups != other.MaxBackups ||
+		cfg.MaxAgeDays != other.MaxAgeDays
+}
+
+func (cfg Config) sinkType() SinkType {
+	if cfg.Sink == "" {
+		return FileSink
+	}
+	return cfg.Sink
+}
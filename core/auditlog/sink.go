@@ -0,0 +1,269 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/core/paths"
+)
+
+// SinkType identifies the kind of destination audit records are
+// written to.
+type SinkType string
+
+const (
+	// FileSink writes audit records to a rotated file in the agent's
+	// log directory.
+	FileSink SinkType = "file"
+
+	// SyslogSink forwards audit records to a syslog server over UDP.
+	SyslogSink SinkType = "syslog"
+)
+
+// Validate returns an error if the sink type is not known.
+func (t SinkType) Validate() error {
+	switch t {
+	case FileSink, SyslogSink:
+		return nil
+	}
+	return errors.NotValidf("audit log sink %q", t)
+}
+
+// DefaultQueueSize is the default number of records that may be
+// waiting to be written to a sink before further records are dropped.
+const DefaultQueueSize = 1024
+
+// Sink is a destination for audit log records.
+type Sink interface {
+	// Write sends the record to the sink.
+	Write(Record) error
+
+	// Close releases any resources held by the sink.
+	Close() error
+}
+
+// NewSink returns the sink described by the input config. Files are
+// written to the specified log directory.
+func NewSink(cfg Config, logDir string, clock clock.Clock) Sink {
+	if cfg.Sink == SyslogSink {
+		return NewSyslogSink(cfg.SyslogAddress, clock)
+	}
+	return NewFileSink(logDir, cfg.MaxSizeMB, cfg.MaxBackups, cfg.MaxAgeDays)
+}
+
+type fileSink struct {
+	fileLogger io.WriteCloser
+}
+
+// NewFileSink returns a sink which writes to an audit.log file in the
+// specified directory. maxSize is the maximum size (in megabytes) of
+// the log file before it gets rotated. maxBackups is the maximum
+// number of old compressed log files to keep, and maxAge the maximum
+// number of days to keep them for (or 0 for no limit in either case).
+func NewFileSink(logDir string, maxSize, maxBackups, maxAge int) Sink {
+	logPath := filepath.Join(logDir, "audit.log")
+	if err := paths.PrimeLogFile(logPath); err != nil {
+		// This isn't a fatal error so log and continue if priming
+		// fails.
+		logger.Errorf("Unable to prime %s (proceeding anyway): %v", logPath, err)
+	}
+
+	return &fileSink{
+		fileLogger: &lumberjack.Logger{
+			Filename:   logPath,
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+			MaxAge:     maxAge,
+			Compress:   true,
+		},
+	}
+}
+
+// Write implements Sink.
+func (s *fileSink) Write(r Record) error {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Add a linebreak to bytes rather than doing two calls to write
+	// just in case lumberjack rolls the file between them.
+	bytes = append(bytes, byte('\n'))
+	_, err = s.fileLogger.Write(bytes)
+	return errors.Trace(err)
+}
+
+// Close implements Sink.
+func (s *fileSink) Close() error {
+	return errors.Trace(s.fileLogger.Close())
+}
+
+// syslogPriority is the PRI value for messages sent to syslog: the
+// "log audit" facility (13) with informational severity (6).
+const syslogPriority = 13<<3 | 6
+
+type syslogSink struct {
+	address  string
+	clock    clock.Clock
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink returns a sink which forwards records as RFC 5424
+// messages to the syslog server at the input host:port address, over
+// UDP. The connection is established when the first record is
+// written, and re-established after a failed write.
+func NewSyslogSink(address string, clock clock.Clock) Sink {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	return &syslogSink{
+		address:  address,
+		clock:    clock,
+		hostname: hostname,
+	}
+}
+
+// Write implements Sink.
+func (s *syslogSink) Write(r Record) error {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	msg := fmt.Sprintf("<%d>1 %s %s juju-audit - - - %s",
+		syslogPriority, s.clock.Now().UTC().Format(time.RFC3339), s.hostname, bytes)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		if s.conn, err = net.Dial("udp", s.address); err != nil {
+			s.conn = nil
+			return errors.Annotatef(err, "connecting to syslog server %q", s.address)
+		}
+	}
+	if _, err := s.conn.Write([]byte(msg)); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return errors.Annotatef(err, "writing to syslog server %q", s.address)
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return errors.Trace(err)
+}
+
+// QueuedLog is an AuditLog that writes records to a sink in the
+// background, so that a slow or failing sink never blocks the
+// caller. When the queue is full, records are dropped.
+type QueuedLog struct {
+	sink    Sink
+	dropped uint64
+
+	mu     sync.Mutex
+	closed bool
+	queue  chan Record
+	done   chan struct{}
+}
+
+// NewQueuedLog returns a QueuedLog that writes to the input sink,
+// holding up to size records while waiting for them to be written.
+func NewQueuedLog(sink Sink, size int) *QueuedLog {
+	l := &QueuedLog{
+		sink:  sink,
+		queue: make(chan Record, size),
+		done:  make(chan struct{}),
+	}
+	go l.loop()
+	return l
+}
+
+func (l *QueuedLog) loop() {
+	defer close(l.done)
+	for r := range l.queue {
+		if err := l.sink.Write(r); err != nil {
+			atomic.AddUint64(&l.dropped, 1)
+			logger.Debugf("writing audit record: %v", err)
+		}
+	}
+}
+
+// AddConversation implements AuditLog.
+func (l *QueuedLog) AddConversation(c Conversation) error {
+	l.enqueue(Record{Conversation: &c})
+	return nil
+}
+
+// AddRequest implements AuditLog.
+func (l *QueuedLog) AddRequest(r Request) error {
+	l.enqueue(Record{Request: &r})
+	return nil
+}
+
+// AddResponse implements AuditLog.
+func (l *QueuedLog) AddResponse(r ResponseErrors) error {
+	l.enqueue(Record{Errors: &r})
+	return nil
+}
+
+func (l *QueuedLog) enqueue(r Record) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		atomic.AddUint64(&l.dropped, 1)
+		return
+	}
+	select {
+	case l.queue <- r:
+	default:
+		if atomic.AddUint64(&l.dropped, 1) == 1 {
+			logger.Warningf("audit log queue full, dropping records")
+		}
+	}
+}
+
+// Dropped returns the number of records that were not written to the
+// sink, either because the queue was full or the write failed.
+func (l *QueuedLog) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// Close implements AuditLog. Records already queued are written
+// before the sink is closed; records added afterwards are dropped.
+func (l *QueuedLog) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	close(l.queue)
+	l.mu.Unlock()
+
+	<-l.done
+	return errors.Trace(l.sink.Close())
+}
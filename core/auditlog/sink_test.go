@@ -0,0 +1,194 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinkSuite{})
+
+func (s *SinkSuite) TestQueuedLogWritesToSink(c *gc.C) {
+	sink := newMemorySink()
+	log := auditlog.NewQueuedLog(sink, 10)
+
+	c.Assert(log.AddConversation(auditlog.Conversation{ConversationID: "abc"}), jc.ErrorIsNil)
+	c.Assert(log.AddRequest(auditlog.Request{ConversationID: "abc", RequestID: 1}), jc.ErrorIsNil)
+	c.Assert(log.AddResponse(auditlog.ResponseErrors{ConversationID: "abc", RequestID: 1}), jc.ErrorIsNil)
+
+	// Closing flushes the queued records before closing the sink.
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	c.Assert(sink.records, gc.DeepEquals, []auditlog.Record{
+		{Conversation: &auditlog.Conversation{ConversationID: "abc"}},
+		{Request: &auditlog.Request{ConversationID: "abc", RequestID: 1}},
+		{Errors: &auditlog.ResponseErrors{ConversationID: "abc", RequestID: 1}},
+	})
+	c.Assert(sink.closed, jc.IsTrue)
+	c.Assert(log.Dropped(), gc.Equals, uint64(0))
+}
+
+func (s *SinkSuite) TestQueuedLogDropsWhenFull(c *gc.C) {
+	sink := newMemorySink()
+	sink.block = make(chan struct{})
+	log := auditlog.NewQueuedLog(sink, 2)
+
+	// The first record is taken by the writer, which then blocks.
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	select {
+	case <-sink.writing:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for write")
+	}
+
+	// Two more fill the queue, and the rest are dropped without
+	// blocking the caller.
+	for i := 2; i <= 5; i++ {
+		c.Assert(log.AddRequest(auditlog.Request{RequestID: uint64(i)}), jc.ErrorIsNil)
+	}
+	c.Assert(log.Dropped(), gc.Equals, uint64(2))
+
+	close(sink.block)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	c.Assert(sink.records, gc.HasLen, 3)
+}
+
+func (s *SinkSuite) TestQueuedLogCountsWriteFailures(c *gc.C) {
+	sink := newMemorySink()
+	sink.err = errors.New("boom")
+	log := auditlog.NewQueuedLog(sink, 10)
+
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+	c.Assert(log.Dropped(), gc.Equals, uint64(1))
+}
+
+func (s *SinkSuite) TestQueuedLogDropsAfterClose(c *gc.C) {
+	sink := newMemorySink()
+	log := auditlog.NewQueuedLog(sink, 10)
+	c.Assert(log.Close(), jc.ErrorIsNil)
+
+	c.Assert(log.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+	c.Assert(log.Dropped(), gc.Equals, uint64(1))
+	c.Assert(sink.records, gc.HasLen, 0)
+
+	// Closing again is a no-op.
+	c.Assert(log.Close(), jc.ErrorIsNil)
+}
+
+func (s *SinkSuite) TestSyslogSink(c *gc.C) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	clock := testclock.NewClock(time.Date(2020, 10, 16, 12, 0, 0, 0, time.UTC))
+	sink := auditlog.NewSyslogSink(conn.LocalAddr().String(), clock)
+	defer sink.Close()
+
+	err = sink.Write(auditlog.Record{Request: &auditlog.Request{Facade: "Application", Method: "Deploy"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	buf := make([]byte, 4096)
+	c.Assert(conn.SetReadDeadline(time.Now().Add(coretesting.LongWait)), jc.ErrorIsNil)
+	n, _, err := conn.ReadFrom(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	msg := string(buf[:n])
+
+	c.Assert(msg, jc.HasPrefix, "<110>1 2020-10-16T12:00:00Z ")
+	idx := strings.Index(msg, " juju-audit - - - ")
+	c.Assert(idx, gc.Not(gc.Equals), -1)
+
+	var record auditlog.Record
+	err = json.Unmarshal([]byte(msg[idx+len(" juju-audit - - - "):]), &record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(record.Request, gc.NotNil)
+	c.Assert(record.Request.Method, gc.Equals, "Deploy")
+}
+
+func (s *SinkSuite) TestConfigValidateSink(c *gc.C) {
+	cfg := auditlog.Config{Sink: "carrier-pigeon"}
+	c.Assert(cfg.Validate(), gc.ErrorMatches, `audit log sink "carrier-pigeon" not valid`)
+
+	cfg = auditlog.Config{Sink: auditlog.SyslogSink}
+	c.Assert(cfg.Validate(), gc.ErrorMatches, "syslog sink without an address not valid")
+
+	cfg = auditlog.Config{Sink: auditlog.SyslogSink, SyslogAddress: "10.0.0.1:514"}
+	c.Assert(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *SinkSuite) TestConfigSinkChanged(c *gc.C) {
+	file := auditlog.Config{}
+	c.Assert(file.SinkChanged(auditlog.Config{Sink: auditlog.FileSink}), jc.IsFalse)
+
+	syslog := auditlog.Config{Sink: auditlog.SyslogSink, SyslogAddress: "10.0.0.1:514"}
+	c.Assert(file.SinkChanged(syslog), jc.IsTrue)
+
+	moved := syslog
+	moved.SyslogAddress = "10.0.0.2:514"
+	c.Assert(syslog.SinkChanged(moved), jc.IsTrue)
+
+	rotated := auditlog.Config{MaxSizeMB: 300, MaxBackups: 10}
+	c.Assert(rotated.SinkChanged(rotated), jc.IsFalse)
+	for _, change := range []func(*auditlog.Config){
+		func(cfg *auditlog.Config) { cfg.MaxSizeMB = 500 },
+		func(cfg *auditlog.Config) { cfg.MaxBackups = 5 },
+		func(cfg *auditlog.Config) { cfg.MaxAgeDays = 7 },
+	} {
+		changed := rotated
+		change(&changed)
+		c.Check(rotated.SinkChanged(changed), jc.IsTrue)
+	}
+}
+
+// memorySink is an in-memory auditlog.Sink.
+type memorySink struct {
+	mu      sync.Mutex
+	records []auditlog.Record
+	closed  bool
+	err     error
+
+	block   chan struct{}
+	writing chan struct{}
+}
+
+func newMemorySink() *memorySink {
+	return &memorySink{writing: make(chan struct{}, 10)}
+}
+
+func (s *memorySink) Write(r auditlog.Record) error {
+	s.writing <- struct{}{}
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.records = append(s.records, r)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
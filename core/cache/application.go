@@ -17,6 +17,8 @@ const (
 	applicationCharmURLChange = "application-charm-url-change"
 	// Application config has changed.
 	applicationConfigChange = "application-config-change"
+	// The application workload version has changed.
+	applicationWorkloadVersionChange = "application-workload-version-change"
 )
 
 func newApplication(model *Model, metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *Application {
@@ -55,6 +57,18 @@ func (a *Application) CharmURL() string {
 	return a.details.CharmURL
 }
 
+// WorkloadVersion returns the version of the workload
+// most recently reported by the application's units.
+func (a *Application) WorkloadVersion() string {
+	return a.details.WorkloadVersion
+}
+
+// MinUnits returns the minimum number of units
+// that should be maintained for this application.
+func (a *Application) MinUnits() int {
+	return a.details.MinUnits
+}
+
 // Config returns a copy of the current application config.
 func (a *Application) Config() map[string]interface{} {
 	a.metrics.ApplicationConfigReads.Inc()
@@ -128,6 +142,26 @@ func (a *Application) WatchConfig(keys ...string) *ConfigWatcher {
 	return w
 }
 
+// WatchWorkloadVersion returns a new watcher that will notify
+// when the workload version of this application changes.
+func (a *Application) WatchWorkloadVersion() NotifyWatcher {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	w := newNotifyWatcherBase()
+	deregister := a.registerWorker(w)
+	unsub := a.hub.Subscribe(a.topic(applicationWorkloadVersionChange), func(string, interface{}) {
+		w.notify()
+	})
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+	return w
+}
+
 // appCharmUrlChange contains an appName and it's charm URL.  To be used
 // when publishing for applicationCharmURLChange.
 type appCharmUrlChange struct {
@@ -147,16 +181,27 @@ func (a *Application) setDetails(details ApplicationChange) {
 	if a.details.CharmURL != details.CharmURL {
 		a.hub.Publish(applicationCharmURLChange, appCharmUrlChange{appName: a.details.Name, chURL: details.CharmURL})
 	}
+	if a.details.WorkloadVersion != details.WorkloadVersion {
+		a.hub.Publish(a.topic(applicationWorkloadVersionChange), details.WorkloadVersion)
+	}
 
 	a.details = details
+
+	// Abandon config hashing if the cache is being torn down.
+	var abort <-chan struct{}
+	if a.model != nil {
+		abort = a.model.dying
+	}
 	hashCache, configHash := newHashCache(
-		details.Config, a.metrics.ApplicationHashCacheHit, a.metrics.ApplicationHashCacheMiss)
+		details.Config, abort, a.metrics.ApplicationHashCacheHit, a.metrics.ApplicationHashCacheMiss)
 
-	if configHash != a.configHash {
+	a.metrics.ChangesReceived.Inc()
+	if configHash != "" && configHash != a.configHash {
 		a.configHash = configHash
 		a.hashCache = hashCache
 		a.hashCache.incMisses()
 		a.hub.Publish(a.topic(applicationConfigChange), hashCache)
+		a.metrics.ChangesEmitted.Inc()
 	}
 }
 
@@ -225,6 +225,40 @@ func (s *ApplicationSuite) TestUnitsSorted(c *gc.C) {
 	c.Assert(names, jc.DeepEquals, []string{"app/1", "app/10", "app/2"})
 }
 
+func (s *ApplicationSuite) TestWorkloadVersionAndMinUnits(c *gc.C) {
+	change := appChange
+	change.MinUnits = 3
+	a := s.NewApplication(change)
+
+	c.Check(a.WorkloadVersion(), gc.Equals, "666")
+	c.Check(a.MinUnits(), gc.Equals, 3)
+}
+
+func (s *ApplicationSuite) TestWatchWorkloadVersion(c *gc.C) {
+	a := s.NewApplication(appChange)
+	w := a.WatchWorkloadVersion()
+	defer workertest.CleanKill(c, w)
+
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// Changes not relating to the workload version are ignored.
+	change := appChange
+	change.MinUnits = 2
+	a.SetDetails(change)
+	wc.AssertNoChange()
+
+	change.WorkloadVersion = "667"
+	a.SetDetails(change)
+	wc.AssertOneChange()
+	c.Check(a.WorkloadVersion(), gc.Equals, "667")
+
+	// Setting the same values causes no notification.
+	a.SetDetails(change)
+	wc.AssertNoChange()
+}
+
 var appChange = cache.ApplicationChange{
 	ModelUUID:   "model-uuid",
 	Name:        "application-name",
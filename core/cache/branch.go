@@ -90,6 +90,24 @@ func (b *Branch) setDetails(details BranchChange) {
 	b.hub.Publish(branchChange, b.copy())
 }
 
+// clearApplication removes any units tracking the branch and any
+// configuration changes for the input application.
+// True is returned if the branch referenced the application.
+func (b *Branch) clearApplication(appName string) bool {
+	_, tracked := b.details.AssignedUnits[appName]
+	_, configured := b.details.Config[appName]
+	if !tracked && !configured {
+		return false
+	}
+
+	details := b.details.copy()
+	delete(details.AssignedUnits, appName)
+	delete(details.Config, appName)
+	b.details = details
+	b.hub.Publish(branchChange, b.copy())
+	return true
+}
+
 // copy returns a copy of the branch, ensuring appropriate deep copying.
 func (b *Branch) copy() Branch {
 	cb := *b
@@ -72,3 +72,13 @@ func BranchEvents(change interface{}) bool {
 	}
 	return false
 }
+
+func RelationEvents(change interface{}) bool {
+	switch change.(type) {
+	case cache.RelationChange:
+		return true
+	case cache.RemoveRelation:
+		return true
+	}
+	return false
+}
@@ -164,6 +164,11 @@ type UnitChange struct {
 	WorkloadStatus  status.StatusInfo
 	AgentStatus     status.StatusInfo
 	ContainerStatus status.StatusInfo // For CAAS models.
+
+	// Cloud container details, for CAAS models.
+	ProviderId       string
+	ContainerAddress string
+	ContainerPorts   []string
 }
 
 // copy returns a deep copy of the UnitChange.
@@ -172,6 +177,8 @@ func (u UnitChange) copy() UnitChange {
 	u.Annotations = copyStringMap(u.Annotations)
 	u.WorkloadStatus = copyStatusInfo(u.WorkloadStatus)
 	u.AgentStatus = copyStatusInfo(u.AgentStatus)
+	u.ContainerStatus = copyStatusInfo(u.ContainerStatus)
+	u.ContainerPorts = copyStringSlice(u.ContainerPorts)
 
 	return u
 }
@@ -188,6 +195,8 @@ type RemoveUnit struct {
 type RelationChange struct {
 	ModelUUID string
 	Key       string
+	Id        int
+	Life      life.Value
 	Endpoints []Endpoint
 }
 
@@ -206,10 +215,8 @@ type Endpoint struct {
 func (c RelationChange) copy() RelationChange {
 	if existing := c.Endpoints; existing != nil {
 		endpoints := make([]Endpoint, len(existing))
-		for i, ep := range existing {
-			endpoints[i] = ep
-		}
-		c.Endpoints = existing
+		copy(endpoints, existing)
+		c.Endpoints = endpoints
 	}
 	return c
 }
@@ -394,3 +401,12 @@ func copyStringMap(data map[string]string) map[string]string {
 	}
 	return cData
 }
+
+func copyStringSlice(data []string) []string {
+	var cData []string
+	if data != nil {
+		cData = make([]string, len(data))
+		copy(cData, data)
+	}
+	return cData
+}
@@ -95,7 +95,7 @@ func (s *charmConfigWatcherSuite) TestTrackingBranchMasterChangedNotified(c *gc.
 	s.assertOneChange(c, w, map[string]interface{}{"password": defaultPassword}, defaultCharmURL)
 
 	// Publish a change to master configuration.
-	hc, _ := newHashCache(map[string]interface{}{"databases": 4}, nil, nil)
+	hc, _ := newHashCache(map[string]interface{}{"databases": 4}, nil, nil, nil)
 	s.Hub.Publish(applicationConfigChange, hc)
 
 	s.assertOneChange(c, w, map[string]interface{}{"password": defaultPassword, "databases": 4}, defaultCharmURL)
@@ -0,0 +1,100 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/juju/pubsub"
+)
+
+// ConfigKeysWatcher watches a single entity's configuration, notifying
+// with the keys whose values have changed since the last notification.
+// Keys added to, or removed from the config count as changed.
+// If keys are specified, which may be glob patterns as for ConfigWatcher,
+// only changes to matching keys are notified. If no keys are specified,
+// any change in the config is notified.
+// The initial event contains the matching keys present in the config.
+type ConfigKeysWatcher struct {
+	*stringsWatcherBase
+
+	keys configKeys
+}
+
+// newConfigKeysWatcher returns a new watcher for the input config keys,
+// with an initial event containing the matching keys of the input config.
+func newConfigKeysWatcher(
+	keys []string, config map[string]interface{}, hub *pubsub.SimpleHub, topic string, res *Resident,
+) *ConfigKeysWatcher {
+	configKeys := newConfigKeys(keys)
+
+	initial := make([]string, 0, len(config))
+	for key := range config {
+		initial = append(initial, key)
+	}
+
+	w := &ConfigKeysWatcher{keys: configKeys}
+	w.stringsWatcherBase = newStringsWatcherBase(w.matching(initial)...)
+
+	deregister := res.registerWorker(w)
+	unsub := hub.Subscribe(topic, w.configChanged)
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+
+	return w
+}
+
+// configChanged is called with the keys whose values changed. Any that
+// are of interest are sent, combined with any pending notification.
+func (w *ConfigKeysWatcher) configChanged(topic string, value interface{}) {
+	changed, ok := value.([]string)
+	if !ok {
+		logger.Errorf("programming error, value not of type []string")
+		return
+	}
+	if matches := w.matching(changed); len(matches) > 0 {
+		w.notify(matches)
+	}
+}
+
+// matching returns the sorted input keys of interest to the watcher.
+func (w *ConfigKeysWatcher) matching(keys []string) []string {
+	var matches []string
+	if w.keys.cacheKey == "" {
+		matches = append(matches, keys...)
+	} else {
+		config := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			config[key] = nil
+		}
+		for key := range w.keys.match(config) {
+			matches = append(matches, key)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// changedConfigKeys returns the sorted keys whose values
+// differ between the input configs.
+func changedConfigKeys(before, after map[string]interface{}) []string {
+	var changed []string
+	for key, value := range after {
+		if old, found := before[key]; !found || !reflect.DeepEqual(old, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range before {
+		if _, found := after[key]; !found {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
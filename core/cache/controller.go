@@ -4,12 +4,15 @@
 package cache
 
 import (
+	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/pubsub"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/tomb.v2"
 )
 
@@ -47,6 +50,7 @@ var (
 // Clock defines the clockish methods used by the controller.
 type Clock interface {
 	After(time.Duration) <-chan time.Time
+	Now() time.Time
 }
 
 // ControllerConfig is a simple config value struct for the controller.
@@ -59,6 +63,30 @@ type ControllerConfig struct {
 	// called by the controller main processing loop after processing a change.
 	// The change processed is passed in as the arg to notify.
 	Notify func(interface{})
+
+	// EvictionRetention is how long dead machines and completed branches
+	// are retained in the cache before being evicted, if their removal
+	// has not been received in the meantime.
+	// A value of zero disables eviction, leaving such entities in the
+	// cache until they are removed or swept.
+	EvictionRetention time.Duration
+
+	// Clock is used to schedule the eviction of dead machines and
+	// completed branches. It must be non-nil if EvictionRetention is set.
+	Clock Clock
+
+	// MaxResidents is a soft limit on the number of entities in the cache.
+	// When it is exceeded, the least recently accessed applications,
+	// machines and units without live watchers are evicted until the
+	// cache is back within the limit, or no more can be evicted.
+	// Evicted entities are restored by the next change received for them.
+	// A value of zero disables the limit.
+	MaxResidents int
+
+	// PrometheusRegisterer, if not nil, is used to register the
+	// controller's gauges, which are labelled with the model UUID.
+	// The gauges are unregistered when the controller stops.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Validate ensures the controller has the right values to be created.
@@ -66,6 +94,15 @@ func (c *ControllerConfig) Validate() error {
 	if c.Changes == nil {
 		return errors.NotValidf("nil Changes")
 	}
+	if c.EvictionRetention < 0 {
+		return errors.NotValidf("negative EvictionRetention")
+	}
+	if c.EvictionRetention > 0 && c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.MaxResidents < 0 {
+		return errors.NotValidf("negative MaxResidents")
+	}
 	return nil
 }
 
@@ -81,8 +118,27 @@ type Controller struct {
 	hub      *pubsub.SimpleHub
 	models   map[string]*Model
 
-	tomb    tomb.Tomb
-	metrics *ControllerGauges
+	tomb       tomb.Tomb
+	metrics    *controllerGaugeVecs
+	registerer prometheus.Registerer
+
+	// hooks are run after each change is applied,
+	// in order to maintain cross-entity invariants.
+	hooks applyHooks
+
+	// clock and evictionRetention control the eviction of dead
+	// machines and completed branches. finished records when each
+	// entity eligible for eviction was first seen to be finished,
+	// keyed by the entity's removal message. It is only accessed
+	// from the main processing loop.
+	clock             Clock
+	evictionRetention time.Duration
+	finished          map[interface{}]time.Time
+
+	// maxResidents is the soft limit on the number of cache residents.
+	// evicted counts the residents evicted to stay within it.
+	maxResidents int
+	evicted      counter
 
 	// config is the controller config.
 	configMu sync.Mutex
@@ -97,6 +153,10 @@ type Controller struct {
 	// isInitialising method.
 	initializing bool
 
+	// health indicates whether the cache reflects the
+	// current state of the controller. See Health.
+	health *health
+
 	// modelsMu protects access to the controller's collection of models.
 	modelsMu sync.Mutex
 
@@ -121,13 +181,31 @@ func newController(config ControllerConfig, manager *residentManager) (*Controll
 	}
 
 	c := &Controller{
-		manager:  manager,
-		changes:  config.Changes,
-		notify:   config.Notify,
-		idleFunc: IdleFunc,
-		hub:      newPubSubHub(),
-		models:   make(map[string]*Model),
-		metrics:  createControllerGauges(),
+		manager:    manager,
+		changes:    config.Changes,
+		notify:     config.Notify,
+		idleFunc:   IdleFunc,
+		hub:        newPubSubHub(),
+		models:     make(map[string]*Model),
+		metrics:    createControllerGaugeVecs(),
+		registerer: config.PrometheusRegisterer,
+		hooks:      make(applyHooks),
+		health:     newHealth(),
+
+		clock:             config.Clock,
+		evictionRetention: config.EvictionRetention,
+		finished:          make(map[interface{}]time.Time),
+		maxResidents:      config.MaxResidents,
+	}
+	c.registerInvariantHooks()
+	if c.evictionRetention > 0 {
+		c.registerEvictionHooks()
+	}
+
+	if c.registerer != nil {
+		if err := c.registerer.Register(c.metrics); err != nil {
+			return nil, errors.Annotate(err, "registering cache gauges")
+		}
 	}
 
 	manager.dying = c.tomb.Dying()
@@ -136,12 +214,20 @@ func newController(config ControllerConfig, manager *residentManager) (*Controll
 }
 
 func (c *Controller) loop() error {
+	if c.registerer != nil {
+		defer c.registerer.Unregister(c.metrics)
+	}
+
 	idle := &time.Timer{}
 	if c.idleFunc != nil {
 		logger.Tracef("controller %p set idle timeout to %s", c, IdleTime)
 		idle = time.NewTimer(IdleTime)
 		defer idle.Stop()
 	}
+	var evict <-chan time.Time
+	if c.evictionRetention > 0 {
+		evict = c.clock.After(c.evictionRetention)
+	}
 	for {
 		select {
 		case <-c.tomb.Dying():
@@ -150,49 +236,19 @@ func (c *Controller) loop() error {
 			logger.Tracef("controller %p is idle", c)
 			c.idleFunc()
 			idle.Reset(IdleTime)
+		case <-evict:
+			c.evictFinished()
+			evict = c.clock.After(c.evictionRetention)
 		case change := <-c.changes:
-			var err error
-
-			switch ch := change.(type) {
-			case ControllerConfigChange:
-				c.configMu.Lock()
-				c.config = ch.Config
-				c.configMu.Unlock()
-			case ModelChange:
-				c.updateModel(ch)
-			case RemoveModel:
-				err = c.removeModel(ch)
-			case ApplicationChange:
-				c.updateApplication(ch)
-			case RemoveApplication:
-				err = c.removeApplication(ch)
-			case CharmChange:
-				c.updateCharm(ch)
-			case RemoveCharm:
-				err = c.removeCharm(ch)
-			case MachineChange:
-				c.updateMachine(ch)
-			case RemoveMachine:
-				err = c.removeMachine(ch)
-			case UnitChange:
-				c.updateUnit(ch)
-			case RemoveUnit:
-				err = c.removeUnit(ch)
-			case RelationChange:
-				c.updateRelation(ch)
-			case RemoveRelation:
-				err = c.removeRelation(ch)
-			case BranchChange:
-				c.updateBranch(ch)
-			case RemoveBranch:
-				err = c.removeBranch(ch)
+			if err := c.apply(change); err != nil {
+				logger.Errorf("processing cache change: %s", err.Error())
 			}
-			if c.notify != nil {
-				c.notify(change)
+			if c.maxResidents > 0 {
+				c.evictLeastRecent()
 			}
 
-			if err != nil {
-				logger.Errorf("processing cache change: %s", err.Error())
+			if c.notify != nil {
+				c.notify(change)
 			}
 
 			if c.idleFunc != nil {
@@ -202,10 +258,55 @@ func (c *Controller) loop() error {
 	}
 }
 
+// apply updates the cache with the input change,
+// then runs any hooks registered for the change.
+func (c *Controller) apply(change interface{}) error {
+	var err error
+
+	switch ch := change.(type) {
+	case ControllerConfigChange:
+		c.configMu.Lock()
+		c.config = ch.Config
+		c.configMu.Unlock()
+	case ModelChange:
+		c.updateModel(ch)
+	case RemoveModel:
+		err = c.removeModel(ch)
+	case ApplicationChange:
+		c.updateApplication(ch)
+	case RemoveApplication:
+		err = c.removeApplication(ch)
+	case CharmChange:
+		c.updateCharm(ch)
+	case RemoveCharm:
+		err = c.removeCharm(ch)
+	case MachineChange:
+		c.updateMachine(ch)
+	case RemoveMachine:
+		err = c.removeMachine(ch)
+	case UnitChange:
+		c.updateUnit(ch)
+	case RemoveUnit:
+		err = c.removeUnit(ch)
+	case RelationChange:
+		c.updateRelation(ch)
+	case RemoveRelation:
+		err = c.removeRelation(ch)
+	case BranchChange:
+		c.updateBranch(ch)
+	case RemoveBranch:
+		err = c.removeBranch(ch)
+	}
+	c.hooks.run(change)
+
+	return errors.Trace(err)
+}
+
 // Mark updates all cached entities to indicate they are stale.
 func (c *Controller) Mark() {
 	c.manager.mark()
 	c.setInitializing(true)
+	c.health.mark()
 }
 
 // Sweep evicts any stale entities from the cache,
@@ -215,6 +316,7 @@ func (c *Controller) Sweep() {
 	case <-c.manager.sweep():
 	case <-c.tomb.Dying():
 	}
+	c.health.sweep()
 
 	// If we are not currently initialising, then this call to `Sweep` was not
 	// the first after a `Mark`. This means that the cache is primed and
@@ -249,6 +351,10 @@ func (c *Controller) Report() map[string]interface{} {
 	}
 	c.modelsMu.Unlock()
 
+	if c.maxResidents > 0 {
+		result["evicted-count"] = c.evicted.last()
+	}
+
 	return result
 }
 
@@ -286,6 +392,13 @@ func (c *Controller) Name() string {
 	return ""
 }
 
+// MetricsSnapshot returns the activity recorded by the controller's cache
+// gauges since the previous call. It is intended for introspection and
+// tooling that samples periodically; the prometheus collectors are unaffected.
+func (c *Controller) MetricsSnapshot() MetricsSnapshot {
+	return c.metrics.Snapshot()
+}
+
 // Model returns the model for the specified UUID.
 // If the model isn't found, a NotFoundError is returned.
 func (c *Controller) Model(uuid string) (*Model, error) {
@@ -300,7 +413,11 @@ func (c *Controller) Model(uuid string) (*Model, error) {
 }
 
 // WaitForModel waits for a time for the specified model to appear in the cache.
+// A model already in the cache is returned without waiting.
 func (c *Controller) WaitForModel(uuid string, clock Clock) (*Model, error) {
+	if model, err := c.Model(uuid); err == nil {
+		return model, nil
+	}
 	watcher := c.modelWatcher(uuid)
 	defer watcher.Kill()
 	select {
@@ -311,6 +428,40 @@ func (c *Controller) WaitForModel(uuid string, clock Clock) (*Model, error) {
 	}
 }
 
+// WaitForModelContext waits for the specified model to appear in the cache,
+// returning it immediately if it is already present. If the context is done
+// before the model appears, a NotFound error wrapping the context's error is
+// returned.
+func (c *Controller) WaitForModelContext(ctx context.Context, uuid string) (*Model, error) {
+	if model, err := c.Model(uuid); err == nil {
+		return model, nil
+	}
+	watcher := c.modelWatcher(uuid)
+	defer watcher.Kill()
+	select {
+	case <-ctx.Done():
+		return nil, errors.NewNotFound(ctx.Err(), fmt.Sprintf("model %q did not appear in cache", uuid))
+	case model := <-watcher.Changes():
+		return model, nil
+	}
+}
+
+// WaitForModelCancel waits for the specified model to appear in the cache,
+// returning it immediately if it is already present. If the cancel channel
+// is closed before the model appears, a NotFound error is returned.
+func (c *Controller) WaitForModelCancel(uuid string, cancel <-chan struct{}) (*Model, error) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	go func() {
+		select {
+		case <-cancel:
+			cancelCtx()
+		case <-ctx.Done():
+		}
+	}()
+	return c.WaitForModelContext(ctx, uuid)
+}
+
 // modelWatcher creates a watcher that will pass the Model
 // down the changes channel when it becomes available. It may
 // be immediately available.
@@ -341,6 +492,7 @@ func (c *Controller) removeModel(ch RemoveModel) error {
 			return errors.Trace(err)
 		}
 		delete(c.models, ch.ModelUUID)
+		c.metrics.removeModel(ch.ModelUUID)
 		c.hub.Publish(modelRemovedTopic, ch.ModelUUID)
 	}
 	return nil
@@ -433,7 +585,9 @@ func (c *Controller) ensureModel(modelUUID string) *Model {
 	if !found {
 		model = newModel(modelConfig{
 			initializing: c.isInitializing,
-			metrics:      c.metrics,
+			degraded:     c.isDegraded,
+			dying:        c.tomb.Dying(),
+			metrics:      c.metrics.forModel(modelUUID),
 			hub:          newPubSubHub(),
 			chub:         c.hub,
 			res:          c.manager.new(),
@@ -447,6 +601,23 @@ func (c *Controller) ensureModel(modelUUID string) *Model {
 	return model
 }
 
+// Health returns the health of the cache. The cache is initializing until
+// the first Sweep, after which it is synced. It is degraded by a call to
+// SetDegraded, and is synced again by the first Sweep after the next Mark.
+func (c *Controller) Health() Health {
+	return c.health.get()
+}
+
+// SetDegraded records that the source of the cache's changes failed with
+// the input error, so that the cache may be stale until it is restarted.
+func (c *Controller) SetDegraded(err error) {
+	c.health.degrade(err)
+}
+
+func (c *Controller) isDegraded() bool {
+	return c.health.get().Status == HealthDegraded
+}
+
 func (c *Controller) isInitializing() bool {
 	c.initMu.Lock()
 	defer c.initMu.Unlock()
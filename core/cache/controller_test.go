@@ -3,7 +3,9 @@
 package cache_test
 
 import (
+	"context"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -68,6 +70,30 @@ func (s *ControllerSuite) TestAddModel(c *gc.C) {
 	s.AssertResident(c, mod.CacheId(), true)
 }
 
+func (s *ControllerSuite) TestReportBranchCount(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, branchChange, events)
+
+	// The controller reports each model as Model.Report does.
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(controller.Report(), gc.DeepEquals, map[string]interface{}{
+		"model-uuid": mod.Report(),
+	})
+	c.Check(controller.Report(), gc.DeepEquals, map[string]interface{}{
+		"model-uuid": map[string]interface{}{
+			"name":              "model-owner/test-model",
+			"life":              life.Value("alive"),
+			"application-count": 0,
+			"charm-count":       0,
+			"machine-count":     0,
+			"unit-count":        0,
+			"relation-count":    0,
+			"branch-count":      1,
+		}})
+}
+
 func (s *ControllerSuite) TestRemoveModel(c *gc.C) {
 	controller, events := s.New(c)
 	s.ProcessChange(c, modelChange, events)
@@ -86,22 +112,19 @@ func (s *ControllerSuite) TestRemoveModel(c *gc.C) {
 func (s *ControllerSuite) TestWaitForModelExists(c *gc.C) {
 	controller, events := s.New(c)
 	clock := testclock.NewClock(time.Now())
-	done := make(chan struct{})
 	// Process the change event before waiting on the model. This
 	// way we know the model exists in the cache before we ask.
 	s.ProcessChange(c, modelChange, events)
 
-	go func() {
-		defer close(done)
-		model, err := controller.WaitForModel(modelChange.ModelUUID, clock)
-		c.Check(err, jc.ErrorIsNil)
-		c.Check(model.UUID(), gc.Equals, modelChange.ModelUUID)
-	}()
+	model, err := controller.WaitForModel(modelChange.ModelUUID, clock)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.UUID(), gc.Equals, modelChange.ModelUUID)
 
+	// The model is returned without waiting on the clock.
 	select {
-	case <-done:
-	case <-time.After(testing.LongWait):
-		c.Errorf("WaitForModel did not return after %s", testing.LongWait)
+	case <-clock.Alarms():
+		c.Fatalf("WaitForModel waited for an existing model")
+	default:
 	}
 }
 
@@ -149,6 +172,104 @@ func (s *ControllerSuite) TestWaitForModelTimeout(c *gc.C) {
 	}
 }
 
+func (s *ControllerSuite) TestWaitForModelContextExists(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+
+	// The model is returned without waiting, even for a done context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	model, err := controller.WaitForModelContext(ctx, modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.UUID(), gc.Equals, modelChange.ModelUUID)
+}
+
+func (s *ControllerSuite) TestWaitForModelContextArrives(c *gc.C) {
+	controller, events := s.New(c)
+	ctx, cancel := context.WithTimeout(context.Background(), testing.LongWait)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		model, err := controller.WaitForModelContext(ctx, modelChange.ModelUUID)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(model.UUID(), gc.Equals, modelChange.ModelUUID)
+	}()
+
+	s.ProcessChange(c, modelChange, events)
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Errorf("WaitForModelContext did not return after %s", testing.LongWait)
+	}
+}
+
+func (s *ControllerSuite) TestWaitForModelContextDone(c *gc.C) {
+	controller, _ := s.New(c)
+	ctx, cancel := context.WithTimeout(context.Background(), testing.ShortWait)
+	defer cancel()
+
+	model, err := controller.WaitForModelContext(ctx, modelChange.ModelUUID)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `model ".*" did not appear in cache: context deadline exceeded`)
+	c.Check(model, gc.IsNil)
+}
+
+func (s *ControllerSuite) TestWaitForModelCancelExists(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+
+	// The model is returned without waiting, even when already cancelled.
+	cancel := make(chan struct{})
+	close(cancel)
+	model, err := controller.WaitForModelCancel(modelChange.ModelUUID, cancel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.UUID(), gc.Equals, modelChange.ModelUUID)
+}
+
+func (s *ControllerSuite) TestWaitForModelCancelArrives(c *gc.C) {
+	controller, events := s.New(c)
+	cancel := make(chan struct{})
+	defer close(cancel)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		model, err := controller.WaitForModelCancel(modelChange.ModelUUID, cancel)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(model.UUID(), gc.Equals, modelChange.ModelUUID)
+	}()
+
+	s.ProcessChange(c, modelChange, events)
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Errorf("WaitForModelCancel did not return after %s", testing.LongWait)
+	}
+}
+
+func (s *ControllerSuite) TestWaitForModelCancelled(c *gc.C) {
+	controller, _ := s.New(c)
+	cancel := make(chan struct{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		model, err := controller.WaitForModelCancel(modelChange.ModelUUID, cancel)
+		c.Check(err, jc.Satisfies, errors.IsNotFound)
+		c.Check(err, gc.ErrorMatches, `model ".*" did not appear in cache: context canceled`)
+		c.Check(model, gc.IsNil)
+	}()
+
+	close(cancel)
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Errorf("WaitForModelCancel did not return after %s", testing.LongWait)
+	}
+}
+
 func (s *ControllerSuite) TestAddApplication(c *gc.C) {
 	controller, events := s.New(c)
 	s.ProcessChange(c, appChange, events)
@@ -292,6 +413,10 @@ func (s *ControllerSuite) TestAddRelation(c *gc.C) {
 
 	relation, err := mod.Relation(relationChange.Key)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Check(relation.Key(), gc.Equals, relationChange.Key)
+	c.Check(relation.Id(), gc.Equals, relationChange.Id)
+	c.Check(relation.Life(), gc.Equals, life.Alive)
+	c.Check(relation.Endpoints(), jc.DeepEquals, relationChange.Endpoints)
 	s.AssertResident(c, relation.CacheId(), true)
 }
 
@@ -381,6 +506,44 @@ func (s *ControllerSuite) TestMarkAndSweep(c *gc.C) {
 	s.AssertNoResidents(c)
 }
 
+func (s *ControllerSuite) TestHealth(c *gc.C) {
+	controller, _ := s.New(c)
+	c.Check(controller.Health(), gc.Equals, cache.Health{Status: cache.HealthInitializing})
+
+	controller.Mark()
+	controller.Sweep()
+	c.Check(controller.Health(), gc.Equals, cache.Health{Status: cache.HealthSynced})
+
+	controller.SetDegraded(errors.New("watcher failed"))
+	degraded := cache.Health{Status: cache.HealthDegraded, LastError: "watcher failed"}
+	c.Check(controller.Health(), gc.Equals, degraded)
+
+	// Changes received before the failure do not recover the cache.
+	controller.Sweep()
+	c.Check(controller.Health(), gc.Equals, degraded)
+
+	// The first sweep after the cache is next marked does.
+	controller.Mark()
+	c.Check(controller.Health(), gc.Equals, degraded)
+	controller.Sweep()
+	c.Check(controller.Health(), gc.Equals, cache.Health{Status: cache.HealthSynced})
+}
+
+func (s *ControllerSuite) TestModelDegraded(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Degraded(), jc.IsFalse)
+
+	controller.SetDegraded(errors.New("watcher failed"))
+	c.Check(mod.Degraded(), jc.IsTrue)
+
+	controller.Mark()
+	controller.Sweep()
+	c.Check(mod.Degraded(), jc.IsFalse)
+}
+
 func (s *ControllerSuite) TestSweepWithConcurrentUpdates(c *gc.C) {
 	controller, events := s.New(c)
 	done := make(chan struct{})
@@ -534,6 +697,141 @@ func (s *ControllerSuite) TestWatchMachineGatherMachines(c *gc.C) {
 	wc.AssertMaybeCombinedChanges([]string{change.Id, change2.Id})
 }
 
+func (s *ControllerSuite) TestWatchMachineUpdatePredicate(c *gc.C) {
+	controller, events := s.newWithMachine(c)
+	m, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	container := cache.MachineChange{
+		ModelUUID: modelChange.ModelUUID,
+		Id:        "0/lxd/0",
+	}
+	s.ProcessChange(c, container, events)
+
+	w, err := m.WatchMachines(cache.WithUpdatablePredicate())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{machineChange.Id})
+
+	// Updating the predicate sends all matching machines.
+	err = w.UpdatePredicate(func(id string) bool {
+		return strings.Contains(id, "/")
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange([]string{container.Id})
+
+	// Subsequent changes are tested with the new predicate.
+	change := cache.MachineChange{
+		ModelUUID: modelChange.ModelUUID,
+		Id:        "2",
+	}
+	s.ProcessChange(c, change, events)
+	wc.AssertNoChange()
+
+	change.Id = "2/lxd/0"
+	s.ProcessChange(c, change, events)
+	wc.AssertOneChange([]string{change.Id})
+}
+
+func (s *ControllerSuite) TestWatchMachineUpdatePredicateNotSupported(c *gc.C) {
+	w, _ := s.setupWithWatchMachine(c)
+	defer workertest.CleanKill(c, w)
+
+	err := w.UpdatePredicate(func(string) bool { return true })
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ControllerSuite) TestWatchApplicationsStops(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, appChange, events)
+	m, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := m.WatchApplications()
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{appChange.Name})
+
+	// The worker is the first and only resource (1).
+	resourceId := uint64(1)
+	s.AssertWorkerResource(c, m.Resident, resourceId, true)
+	wc.AssertStops()
+	s.AssertWorkerResource(c, m.Resident, resourceId, false)
+}
+
+func (s *ControllerSuite) TestWatchApplicationsAddApplication(c *gc.C) {
+	w, events := s.setupWithWatchApplications(c)
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{appChange.Name})
+
+	change := appChange
+	change.Name = "mysql"
+	s.ProcessChange(c, change, events)
+	wc.AssertOneChange([]string{change.Name})
+}
+
+func (s *ControllerSuite) TestWatchApplicationsRemoveApplication(c *gc.C) {
+	w, events := s.setupWithWatchApplications(c)
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{appChange.Name})
+
+	change := cache.RemoveApplication{
+		ModelUUID: modelChange.ModelUUID,
+		Name:      appChange.Name,
+	}
+	s.ProcessChange(c, change, events)
+	wc.AssertOneChange([]string{change.Name})
+
+	// Removing an application no longer in the model causes no change.
+	s.ProcessChange(c, change, events)
+	wc.AssertNoChange()
+}
+
+func (s *ControllerSuite) TestWatchApplicationsChangeApplication(c *gc.C) {
+	w, events := s.setupWithWatchApplications(c)
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{appChange.Name})
+
+	change := appChange
+	change.Exposed = !change.Exposed
+	s.ProcessChange(c, change, events)
+	wc.AssertNoChange()
+}
+
+func (s *ControllerSuite) TestWatchApplicationsGatherApplications(c *gc.C) {
+	w, events := s.setupWithWatchApplications(c)
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{appChange.Name})
+
+	change := appChange
+	change.Name = "mysql"
+	s.ProcessChange(c, change, events)
+	change2 := appChange
+	change2.Name = "postgresql"
+	s.ProcessChange(c, change2, events)
+	wc.AssertMaybeCombinedChanges([]string{change.Name, change2.Name})
+}
+
+func (s *ControllerSuite) setupWithWatchApplications(c *gc.C) (*cache.PredicateStringsWatcher, <-chan interface{}) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, appChange, events)
+	m, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	return m.WatchApplications(), events
+}
+
 func (s *ControllerSuite) newWithMachine(c *gc.C) (*cache.Controller, <-chan interface{}) {
 	controller, events := s.New(c)
 	s.ProcessChange(c, modelChange, events)
@@ -6,6 +6,8 @@ package cache
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
@@ -22,35 +24,44 @@ type hashCache struct {
 	config map[string]interface{}
 
 	// The key to the hash map is the string keys of the watcher.
-	// They must be sorted and comma delimited.
+	// They must be sorted and comma delimited; see configKeys.
 	hash map[string]string
 
+	// abort is closed when the cache is shutting down.
+	// Hash generation is abandoned when this happens.
+	abort <-chan struct{}
+
 	cacheHits   prometheus.Gauge
 	cacheMisses prometheus.Gauge
 	mu          sync.Mutex
 }
 
-func newHashCache(config map[string]interface{}, cacheHits, cacheMisses prometheus.Gauge) (*hashCache, string) {
+// newHashCache returns a hash cache for the input config, along with the
+// hash of the entire config. If the abort channel is closed while the
+// hash is being generated, an empty hash is returned.
+func newHashCache(
+	config map[string]interface{}, abort <-chan struct{}, cacheHits, cacheMisses prometheus.Gauge,
+) (*hashCache, string) {
 	cache := &hashCache{
 		config: config,
 		hash:   make(map[string]string),
+		abort:  abort,
 
 		cacheHits:   cacheHits,
 		cacheMisses: cacheMisses,
 	}
 
 	// Generate the hash for the entire config.
-	allHash := cache.generateHash(nil)
+	allHash := cache.generateHash(configKeys{})
 	cache.hash[""] = allHash
 	return cache, allHash
 }
 
-func (c *hashCache) getHash(keys []string) string {
+func (c *hashCache) getHash(keys configKeys) string {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	key := strings.Join(keys, ",")
-	value, found := c.hash[key]
+	value, found := c.hash[keys.cacheKey]
 	if found {
 		c.incHits()
 		return value
@@ -58,28 +69,89 @@ func (c *hashCache) getHash(keys []string) string {
 
 	c.incMisses()
 	value = c.generateHash(keys)
-	c.hash[key] = value
+	c.hash[keys.cacheKey] = value
 	return value
 }
 
-func (c *hashCache) generateHash(keys []string) string {
+func (c *hashCache) generateHash(keys configKeys) string {
 	interested := c.config
-	if len(keys) > 0 {
-		interested = make(map[string]interface{})
-		for _, key := range keys {
-			if value, found := c.config[key]; found {
-				interested[key] = value
-			}
-		}
+	if keys.cacheKey != "" {
+		interested = keys.match(c.config)
 	}
-	h, err := hashSettings(interested)
+	h, err := hashSettingsAbortable(c.abort, interested)
 	if err != nil {
-		logger.Errorf("invariant error - model config should be yaml serializable and hashable, %v", err)
+		if err == errHashAborted {
+			logger.Debugf("config hash generation aborted")
+		} else {
+			logger.Errorf("invariant error - config should be yaml serializable and hashable, %v", err)
+		}
 		return ""
 	}
 	return h
 }
 
+// configKeys holds the config keys of interest to a watcher. Keys
+// containing '*' or '?' are glob patterns, where '*' matches any
+// sequence of characters and '?' matches any single character.
+// Patterns are compiled once, when the watcher is created.
+type configKeys struct {
+	// cacheKey identifies the keys in a hash cache. It is made from the
+	// sorted keys, so that watchers of the same keys, or of the same
+	// patterns, share a cache entry regardless of their ordering.
+	cacheKey string
+
+	exact    []string
+	patterns []*regexp.Regexp
+}
+
+// newConfigKeys returns the configKeys for the input
+// keys, which may include glob patterns.
+func newConfigKeys(keys []string) configKeys {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	result := configKeys{cacheKey: strings.Join(sorted, ",")}
+	for _, key := range sorted {
+		if strings.ContainsAny(key, "*?") {
+			result.patterns = append(result.patterns, compileGlob(key))
+		} else {
+			result.exact = append(result.exact, key)
+		}
+	}
+	return result
+}
+
+// match returns the subset of the input config with keys of interest.
+func (k configKeys) match(config map[string]interface{}) map[string]interface{} {
+	interested := make(map[string]interface{})
+	for _, key := range k.exact {
+		if value, found := config[key]; found {
+			interested[key] = value
+		}
+	}
+	if len(k.patterns) == 0 {
+		return interested
+	}
+	for key, value := range config {
+		for _, pattern := range k.patterns {
+			if pattern.MatchString(key) {
+				interested[key] = value
+				break
+			}
+		}
+	}
+	return interested
+}
+
+// compileGlob returns a regular expression
+// matching the whole of the input glob pattern.
+func compileGlob(pattern string) *regexp.Regexp {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.Replace(expr, `\*`, ".*", -1)
+	expr = strings.Replace(expr, `\?`, ".", -1)
+	return regexp.MustCompile("^" + expr + "$")
+}
+
 func (c *hashCache) incHits() {
 	if c.cacheHits != nil {
 		c.cacheHits.Inc()
@@ -104,13 +176,46 @@ func hash(values ...string) (string, error) {
 	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// errHashAborted is returned by hashSettingsAbortable
+// if the abort channel is closed during hashing.
+var errHashAborted = errors.New("hash generation aborted")
+
 // hashSettings returns a hash of the yaml serialized settings.
 // If the settings are not able to be serialized an error is returned.
 func hashSettings(settings map[string]interface{}, extras ...string) (string, error) {
-	encoded, err := yaml.Marshal(settings)
-	if err != nil {
-		return "", errors.Trace(err)
+	return hashSettingsAbortable(nil, settings, extras...)
+}
+
+// hashSettingsAbortable returns a hash of the yaml serialized settings.
+// The settings are serialized one key at a time in key order, so that
+// large settings need not be processed in full if the abort channel is
+// closed part way through, in which case errHashAborted is returned.
+func hashSettingsAbortable(abort <-chan struct{}, settings map[string]interface{}, extras ...string) (string, error) {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, s := range extras {
+		if _, err := h.Write([]byte(s)); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	for _, k := range keys {
+		select {
+		case <-abort:
+			return "", errHashAborted
+		default:
+		}
+		encoded, err := yaml.Marshal(map[string]interface{}{k: settings[k]})
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if _, err := h.Write(encoded); err != nil {
+			return "", errors.Trace(err)
+		}
 	}
-	values := append(extras, string(encoded))
-	return hash(values...)
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
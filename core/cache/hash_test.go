@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type hashSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&hashSuite{})
+
+func (s *hashSuite) TestHashSettingsAbortable(c *gc.C) {
+	settings := map[string]interface{}{"foo": "bar", "baz": 1}
+
+	h1, err := hashSettingsAbortable(nil, settings, "extra")
+	c.Assert(err, jc.ErrorIsNil)
+	h2, err := hashSettings(settings, "extra")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(h1, gc.Equals, h2)
+
+	abort := make(chan struct{})
+	close(abort)
+	_, err = hashSettingsAbortable(abort, settings, "extra")
+	c.Check(err, gc.Equals, errHashAborted)
+}
+
+func (s *hashSuite) TestControllerStopDuringLargeConfigHash(c *gc.C) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var marshalled int64
+
+	cfg := map[string]interface{}{
+		"a": blockingMarshaler{started: started, release: release},
+	}
+	for i := 0; i < 1000; i++ {
+		cfg[fmt.Sprintf("b%04d", i)] = countingMarshaler{count: &marshalled}
+	}
+
+	controller, err := s.NewController()
+	c.Assert(err, jc.ErrorIsNil)
+	s.SendChange(c, ModelChange{
+		ModelUUID: "model-uuid",
+		Name:      "model",
+		Config:    cfg,
+	})
+
+	select {
+	case <-started:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("config hashing did not start")
+	}
+
+	// Stop the controller while hashing is under way.
+	controller.Kill()
+	close(release)
+
+	done := make(chan error)
+	go func() { done <- controller.Wait() }()
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("controller did not stop")
+	}
+
+	// None of the remaining config was serialized.
+	c.Check(atomic.LoadInt64(&marshalled), gc.Equals, int64(0))
+}
+
+// blockingMarshaler signals when it is being serialized,
+// then blocks until released.
+type blockingMarshaler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m blockingMarshaler) MarshalYAML() (interface{}, error) {
+	close(m.started)
+	<-m.release
+	return "a", nil
+}
+
+// countingMarshaler records the number of times it is serialized.
+type countingMarshaler struct {
+	count *int64
+}
+
+func (m countingMarshaler) MarshalYAML() (interface{}, error) {
+	atomic.AddInt64(m.count, 1)
+	return "b", nil
+}
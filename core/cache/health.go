@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import "sync"
+
+// HealthStatus indicates whether the cache
+// reflects the current state of the controller.
+type HealthStatus string
+
+const (
+	// HealthInitializing indicates that the cache
+	// has not yet been primed with the controller's models.
+	HealthInitializing HealthStatus = "initializing"
+
+	// HealthSynced indicates that the cache has been primed,
+	// and is receiving changes as they occur.
+	HealthSynced HealthStatus = "synced"
+
+	// HealthDegraded indicates that the source of the cache's changes
+	// failed, so its contents may be stale until it is restarted.
+	HealthDegraded HealthStatus = "degraded"
+)
+
+// Health describes the health of the cache.
+type Health struct {
+	Status HealthStatus
+
+	// LastError is the error that caused the cache to be degraded.
+	// It is only set when Status is HealthDegraded.
+	LastError string
+}
+
+// health records the health of the cache.
+// The cache is initializing until the first sweep. It is degraded
+// by a failure of its source of changes, and recovers to synced
+// with the first sweep after the cache is next marked.
+type health struct {
+	mu     sync.Mutex
+	status HealthStatus
+	err    string
+
+	// marked is true if the cache has been marked
+	// since it was degraded.
+	marked bool
+}
+
+func newHealth() *health {
+	return &health{status: HealthInitializing}
+}
+
+func (h *health) get() Health {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return Health{
+		Status:    h.status,
+		LastError: h.err,
+	}
+}
+
+func (h *health) degrade(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.status = HealthDegraded
+	h.err = err.Error()
+	h.marked = false
+}
+
+func (h *health) mark() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.marked = true
+}
+
+// sweep sets the cache as synced, unless it is degraded and
+// has not been marked since, in which case the changes just
+// processed were received before the failure.
+func (h *health) sweep() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.status == HealthDegraded && !h.marked {
+		return
+	}
+	h.status = HealthSynced
+	h.err = ""
+}
+
+// Degraded returns true if the cache holding the model is degraded,
+// in which case the model may be stale and should be read from state.
+func (m *Model) Degraded() bool {
+	return m.degraded()
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"reflect"
+)
+
+// applyHook is run by the controller's main processing loop after a change
+// has been applied to the cache. Hooks are used to maintain invariants that
+// span more than one cached entity. They run synchronously, so that no
+// subsequent change is applied until all hooks for a change have completed.
+type applyHook func(change interface{})
+
+// applyHooks is a registry of hooks keyed by the type of change to which
+// they apply.
+type applyHooks map[reflect.Type][]applyHook
+
+// register adds the input hook to those run for changes
+// of the same type as the input change.
+func (h applyHooks) register(change interface{}, hook applyHook) {
+	t := reflect.TypeOf(change)
+	h[t] = append(h[t], hook)
+}
+
+// run calls each of the hooks registered for the type of the input
+// change in the order in which they were registered.
+func (h applyHooks) run(change interface{}) {
+	for _, hook := range h[reflect.TypeOf(change)] {
+		hook(change)
+	}
+}
+
+// registerInvariantHooks registers the hooks that maintain
+// the cross-entity invariants of the cache.
+func (c *Controller) registerInvariantHooks() {
+	c.hooks.register(RemoveApplication{}, c.onRemoveApplication)
+	c.hooks.register(ApplicationChange{}, c.onApplicationChange)
+	c.hooks.register(RemoveCharm{}, c.onRemoveCharm)
+	c.hooks.register(CharmChange{}, c.onCharmChange)
+}
+
+// onRemoveApplication clears any branch tracking for a removed application,
+// and drops any record of the application referencing a removed charm.
+func (c *Controller) onRemoveApplication(change interface{}) {
+	ch := change.(RemoveApplication)
+	c.withModel(ch.ModelUUID, func(m *Model) {
+		m.clearBranchTracking(ch.Name)
+		m.resolveMissingCharm(ch.Name)
+	})
+}
+
+// onApplicationChange drops any record of the application referencing a
+// removed charm if the application has moved to a different charm.
+func (c *Controller) onApplicationChange(change interface{}) {
+	ch := change.(ApplicationChange)
+	c.withModel(ch.ModelUUID, func(m *Model) {
+		if url, ok := m.missingCharms[ch.Name]; ok && url != ch.CharmURL {
+			m.resolveMissingCharm(ch.Name)
+		}
+	})
+}
+
+// onRemoveCharm flags any applications still referencing a removed charm.
+func (c *Controller) onRemoveCharm(change interface{}) {
+	ch := change.(RemoveCharm)
+	c.withModel(ch.ModelUUID, func(m *Model) {
+		m.flagMissingCharm(ch.CharmURL)
+	})
+}
+
+// onCharmChange drops any record of applications referencing
+// the charm, which has been (re)added to the cache.
+func (c *Controller) onCharmChange(change interface{}) {
+	ch := change.(CharmChange)
+	c.withModel(ch.ModelUUID, func(m *Model) {
+		for appName, url := range m.missingCharms {
+			if url == ch.CharmURL {
+				m.resolveMissingCharm(appName)
+			}
+		}
+	})
+}
+
+// withModel calls the input function with the model lock held,
+// if the model with the input UUID is in the cache.
+func (c *Controller) withModel(modelUUID string, f func(m *Model)) {
+	c.modelsMu.Lock()
+	model, ok := c.models[modelUUID]
+	c.modelsMu.Unlock()
+	if !ok {
+		return
+	}
+
+	model.mu.Lock()
+	f(model)
+	model.mu.Unlock()
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/settings"
+)
+
+// The invariant hooks hook into the ControllerSuite as it has
+// the base methods we need to enable this cleanly.
+
+func (s *ControllerSuite) TestRemoveApplicationClearsBranchTracking(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, appChange, events)
+
+	branch := branchChange
+	branch.AssignedUnits = map[string][]string{
+		"redis":        {"redis/0"},
+		appChange.Name: {appChange.Name + "/0"},
+	}
+	branch.Config = map[string]settings.ItemChanges{
+		appChange.Name: {settings.MakeAddition("key", "branch-value")},
+	}
+	s.ProcessChange(c, branch, events)
+
+	s.ProcessChange(c, cache.RemoveApplication{
+		ModelUUID: appChange.ModelUUID,
+		Name:      appChange.Name,
+	}, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	b, err := mod.Branch(branch.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(b.AssignedUnits(), jc.DeepEquals, map[string][]string{"redis": {"redis/0"}})
+	c.Check(b.Config(), gc.HasLen, 0)
+
+	// The change sent to the cache is not modified.
+	c.Check(branch.AssignedUnits, gc.HasLen, 2)
+
+	// Clearing branch tracking is not a violation.
+	c.Check(controller.MetricsSnapshot().InvariantViolations, gc.Equals, float64(0))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestRemoveCharmInUseFlagsApplication(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, charmChange, events)
+	s.ProcessChange(c, appChange, events)
+
+	mod := s.removeCharm(c, controller, events)
+	c.Check(mod.Report()["applications-with-removed-charm"], jc.DeepEquals, map[string]interface{}{
+		appChange.Name: charmChange.CharmURL,
+	})
+	c.Check(controller.MetricsSnapshot().InvariantViolations, gc.Equals, float64(1))
+
+	// A repeated removal is not counted again.
+	s.removeCharm(c, controller, events)
+	c.Check(controller.MetricsSnapshot().InvariantViolations, gc.Equals, float64(0))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestRemoveCharmNotInUse(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, charmChange, events)
+
+	app := appChange
+	app.CharmURL = "www.charm-url.com-2"
+	s.ProcessChange(c, app, events)
+
+	mod := s.removeCharm(c, controller, events)
+	_, ok := mod.Report()["applications-with-removed-charm"]
+	c.Check(ok, jc.IsFalse)
+	c.Check(controller.MetricsSnapshot().InvariantViolations, gc.Equals, float64(0))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestRemovedCharmResolvedByCharmUpgrade(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, charmChange, events)
+	s.ProcessChange(c, appChange, events)
+
+	// The charm removal arrives before the application change
+	// moving the application to its new charm.
+	mod := s.removeCharm(c, controller, events)
+
+	app := appChange
+	app.CharmURL = "www.charm-url.com-2"
+	s.ProcessChange(c, app, events)
+
+	_, ok := mod.Report()["applications-with-removed-charm"]
+	c.Check(ok, jc.IsFalse)
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestRemovedCharmResolvedByApplicationRemoval(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, charmChange, events)
+	s.ProcessChange(c, appChange, events)
+
+	// The charm removal arrives before the application removal.
+	mod := s.removeCharm(c, controller, events)
+	s.ProcessChange(c, cache.RemoveApplication{
+		ModelUUID: appChange.ModelUUID,
+		Name:      appChange.Name,
+	}, events)
+
+	_, ok := mod.Report()["applications-with-removed-charm"]
+	c.Check(ok, jc.IsFalse)
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestRemovedCharmResolvedByCharmReturning(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, charmChange, events)
+	s.ProcessChange(c, appChange, events)
+
+	mod := s.removeCharm(c, controller, events)
+
+	// An unchanged application does not resolve the violation.
+	s.ProcessChange(c, appChange, events)
+	c.Check(mod.Report()["applications-with-removed-charm"], gc.HasLen, 1)
+
+	s.ProcessChange(c, charmChange, events)
+	_, ok := mod.Report()["applications-with-removed-charm"]
+	c.Check(ok, jc.IsFalse)
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) removeCharm(c *gc.C, controller *cache.Controller, events <-chan interface{}) *cache.Model {
+	s.ProcessChange(c, cache.RemoveCharm{
+		ModelUUID: charmChange.ModelUUID,
+		CharmURL:  charmChange.CharmURL,
+	}, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	return mod
+}
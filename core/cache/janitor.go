@@ -0,0 +1,128 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"github.com/juju/juju/core/life"
+)
+
+// The controller periodically evicts dead machines and completed branches
+// that have been retained in the cache for longer than the configured
+// retention period. Such entities are otherwise only removed when their
+// removal is received, or by the mark/sweep cycle run when the source of
+// changes is restarted, so they can accumulate in long-lived controllers.
+
+// registerEvictionHooks registers the hooks that record when
+// entities eligible for eviction were first seen to be finished.
+func (c *Controller) registerEvictionHooks() {
+	c.hooks.register(MachineChange{}, c.onMachineChange)
+	c.hooks.register(RemoveMachine{}, c.onFinishedRemoval)
+	c.hooks.register(BranchChange{}, c.onBranchChange)
+	c.hooks.register(RemoveBranch{}, c.onFinishedRemoval)
+	c.hooks.register(RemoveModel{}, c.onRemoveModel)
+}
+
+// onMachineChange records when a machine was first seen to be dead.
+func (c *Controller) onMachineChange(change interface{}) {
+	ch := change.(MachineChange)
+	c.trackFinished(RemoveMachine{ModelUUID: ch.ModelUUID, Id: ch.Id}, ch.Life == life.Dead)
+}
+
+// onBranchChange records when a branch was first seen to be completed.
+func (c *Controller) onBranchChange(change interface{}) {
+	ch := change.(BranchChange)
+	c.trackFinished(RemoveBranch{ModelUUID: ch.ModelUUID, Id: ch.Id}, ch.Completed > 0)
+}
+
+// onFinishedRemoval stops tracking a removed machine or branch.
+// The removal message is the key used to track the entity.
+func (c *Controller) onFinishedRemoval(change interface{}) {
+	delete(c.finished, change)
+}
+
+// onRemoveModel stops tracking all entities in a removed model.
+func (c *Controller) onRemoveModel(change interface{}) {
+	ch := change.(RemoveModel)
+	for key := range c.finished {
+		if finishedModelUUID(key) == ch.ModelUUID {
+			delete(c.finished, key)
+		}
+	}
+}
+
+// trackFinished records the current time against the input removal
+// message if the entity is finished and is not already being tracked.
+// If the entity is not finished, it is no longer tracked.
+func (c *Controller) trackFinished(removal interface{}, finished bool) {
+	if !finished {
+		delete(c.finished, removal)
+		return
+	}
+	if _, ok := c.finished[removal]; !ok {
+		c.finished[removal] = c.clock.Now()
+	}
+}
+
+// evictFinished removes the entities that have been finished for longer
+// than the retention period from the cache, by applying their removal
+// messages. Entities with live watchers are retained until the watchers
+// are stopped.
+func (c *Controller) evictFinished() {
+	now := c.clock.Now()
+	for removal, finishedAt := range c.finished {
+		if now.Sub(finishedAt) < c.evictionRetention {
+			continue
+		}
+
+		res := c.finishedResident(removal)
+		if res == nil {
+			delete(c.finished, removal)
+			continue
+		}
+		if res.hasWorkers() {
+			logger.Tracef("not evicting cache resident %d with live workers", res.CacheId())
+			continue
+		}
+
+		logger.Debugf("evicting cache resident %d: %#v", res.CacheId(), removal)
+		if err := c.apply(removal); err != nil {
+			logger.Errorf("evicting cache resident %d: %s", res.CacheId(), err.Error())
+			continue
+		}
+		c.metrics.Evictions.WithLabelValues(finishedModelUUID(removal)).Inc()
+	}
+}
+
+// finishedResident returns the cache resident for the finished entity
+// identified by the input removal message, or nil if it is not cached.
+func (c *Controller) finishedResident(removal interface{}) *Resident {
+	var res *Resident
+	switch r := removal.(type) {
+	case RemoveMachine:
+		c.withModel(r.ModelUUID, func(m *Model) {
+			if machine, ok := m.machines[r.Id]; ok {
+				res = machine.Resident
+			}
+		})
+	case RemoveBranch:
+		c.withModel(r.ModelUUID, func(m *Model) {
+			if branch, ok := m.branches[r.Id]; ok {
+				res = branch.Resident
+			}
+		})
+	}
+	return res
+}
+
+// finishedModelUUID returns the UUID of the model
+// for the input removal message.
+func finishedModelUUID(removal interface{}) string {
+	switch r := removal.(type) {
+	case RemoveMachine:
+		return r.ModelUUID
+	case RemoveBranch:
+		return r.ModelUUID
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/testing"
+)
+
+const evictionRetention = time.Minute
+
+func (s *ControllerSuite) TestConfigEvictionWithoutClock(c *gc.C) {
+	s.Config.EvictionRetention = evictionRetention
+	err := s.Config.Validate()
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ControllerSuite) TestConfigNegativeEvictionRetention(c *gc.C) {
+	s.Config.EvictionRetention = -evictionRetention
+	err := s.Config.Validate()
+	c.Check(err, gc.ErrorMatches, "negative EvictionRetention not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ControllerSuite) TestEvictDeadMachine(c *gc.C) {
+	controller, events, clock := s.newEvictingController(c)
+
+	// The machine dies half way through the first retention period.
+	s.advanceEviction(c, clock, evictionRetention/2)
+	dead := machineChange
+	dead.Life = life.Dead
+	s.ProcessChange(c, dead, events)
+
+	// It is retained after the first check...
+	s.advanceEviction(c, clock, evictionRetention/2)
+	s.advanceEviction(c, clock, 0)
+	mod, err := controller.Model(dead.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mod.Machine(dead.Id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// ...and evicted after the next.
+	s.advanceEviction(c, clock, evictionRetention)
+	s.advanceEviction(c, clock, 0)
+	_, err = mod.Machine(dead.Id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(controller.MetricsSnapshot().Evictions, gc.Equals, float64(1))
+
+	// The removal arriving later is handled normally.
+	s.ProcessChange(c, cache.RemoveMachine{ModelUUID: dead.ModelUUID, Id: dead.Id}, events)
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestEvictRevivedMachine(c *gc.C) {
+	controller, events, clock := s.newEvictingController(c)
+
+	dead := machineChange
+	dead.Life = life.Dead
+	s.ProcessChange(c, dead, events)
+	s.ProcessChange(c, machineChange, events)
+
+	s.advanceEviction(c, clock, evictionRetention)
+	s.advanceEviction(c, clock, 0)
+	mod, err := controller.Model(machineChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mod.Machine(machineChange.Id)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(controller.MetricsSnapshot().Evictions, gc.Equals, float64(0))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestEvictDeadMachineWithWatcher(c *gc.C) {
+	controller, events, clock := s.newEvictingController(c)
+
+	dead := machineChange
+	dead.Life = life.Dead
+	s.ProcessChange(c, dead, events)
+
+	mod, err := controller.Model(dead.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := mod.Machine(dead.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	w := machine.WatchConfig()
+
+	// The machine is retained while the watcher is live.
+	s.advanceEviction(c, clock, evictionRetention)
+	s.advanceEviction(c, clock, 0)
+	_, err = mod.Machine(dead.Id)
+	c.Check(err, jc.ErrorIsNil)
+
+	workertest.CleanKill(c, w)
+	s.advanceEviction(c, clock, evictionRetention)
+	s.advanceEviction(c, clock, 0)
+	_, err = mod.Machine(dead.Id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestEvictCompletedBranch(c *gc.C) {
+	controller, events, clock := s.newEvictingController(c)
+
+	completed := branchChange
+	completed.Completed = time.Now().Unix()
+	s.ProcessChange(c, completed, events)
+
+	s.advanceEviction(c, clock, evictionRetention)
+	s.advanceEviction(c, clock, 0)
+	mod, err := controller.Model(completed.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mod.Branch(completed.Name)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(controller.MetricsSnapshot().Evictions, gc.Equals, float64(1))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) newEvictingController(c *gc.C) (*cache.Controller, <-chan interface{}, *testclock.Clock) {
+	clock := testclock.NewClock(time.Now())
+	s.Config.Clock = clock
+	s.Config.EvictionRetention = evictionRetention
+	controller, events := s.New(c)
+	return controller, events, clock
+}
+
+// advanceEviction waits for the controller to schedule its next
+// eviction check, then advances the clock by the input duration.
+// Advancing by zero ensures that the previous check has completed.
+func (s *ControllerSuite) advanceEviction(c *gc.C, clock *testclock.Clock, d time.Duration) {
+	c.Assert(clock.WaitAdvance(d, testing.LongWait, 1), jc.ErrorIsNil)
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/status"
+)
+
+// When the controller is configured with a maximum number of residents,
+// it evicts the least recently accessed applications, machines and units
+// once that number is exceeded. Eviction is not a removal of the entity
+// from the model, so no removal events are published for it, the hooks
+// maintaining cross-entity invariants are not run, and the model summary
+// still counts it. The next change received for an evicted entity simply
+// adds it back to the cache. Until then, looking it up returns an error
+// satisfying IsEvicted.
+//
+// An entity is only evicted if no worker is watching it, or watching the
+// entities of its model or machine, so that no watcher loses its source
+// of changes. Entities that contribute messages to the model summary,
+// such as units in error, are not evicted.
+// Models are never evicted in this way, as their removal is published
+// to the controller's model summary watchers.
+
+// evictedError is returned when looking up
+// an entity that was evicted from the cache.
+type evictedError struct {
+	what string
+}
+
+func newEvictedError(format string, args ...interface{}) error {
+	return &evictedError{what: fmt.Sprintf(format, args...)}
+}
+
+// Error is part of the error interface.
+func (e *evictedError) Error() string {
+	return e.what + " evicted from cache"
+}
+
+// IsEvicted returns true if the input error indicates that the entity
+// being looked up was evicted from the cache. The entity still exists,
+// but is not cached again until a change to it is received.
+func IsEvicted(err error) bool {
+	_, ok := errors.Cause(err).(*evictedError)
+	return ok
+}
+
+// Complete returns true if none of the model's applications, machines
+// or units have been evicted from the cache. Only then do the model's
+// listings, such as Units, include every entity in the model.
+func (m *Model) Complete() bool {
+	defer m.doLocked()()
+	return m.evictedApplications.IsEmpty() && m.evictedMachines.IsEmpty() && m.evictedUnits.IsEmpty()
+}
+
+// evict removes the entity identified by the input removal message from
+// the cache without removing it from the model, unless it is observed or
+// contributes to the model summary. It returns true if it was evicted.
+func (m *Model) evict(removal interface{}) (bool, error) {
+	defer m.doLocked()()
+
+	if m.hasObservers() {
+		return false, nil
+	}
+
+	switch r := removal.(type) {
+	case RemoveApplication:
+		app, ok := m.applications[r.Name]
+		if !ok {
+			return false, nil
+		}
+		if err := app.evict(); err != nil {
+			return false, errors.Trace(err)
+		}
+		delete(m.applications, r.Name)
+		m.evictedApplications.Add(r.Name)
+	case RemoveMachine:
+		machine, ok := m.machines[r.Id]
+		if !ok || !m.machineEvictable(machine) {
+			return false, nil
+		}
+		if err := machine.evict(); err != nil {
+			return false, errors.Trace(err)
+		}
+		delete(m.machines, r.Id)
+		m.evictedMachines.Add(r.Id)
+	case RemoveUnit:
+		unit, ok := m.units[r.Name]
+		if !ok || !m.unitEvictable(unit) {
+			return false, nil
+		}
+		if err := unit.evict(); err != nil {
+			return false, errors.Trace(err)
+		}
+		delete(m.units, r.Name)
+		m.evictedUnits.Add(r.Name)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// machineEvictable returns true if the input machine may be evicted.
+// Machines in error are reported in the model summary, and containers
+// may be watched by their host machine. It assumes the model lock is held.
+func (m *Model) machineEvictable(machine *Machine) bool {
+	if machine.details.AgentStatus.Status == status.Error {
+		return false
+	}
+	if parent := names.NewMachineTag(machine.details.Id).Parent(); parent != nil {
+		if host, ok := m.machines[parent.Id()]; ok && host.hasObservers() {
+			return false
+		}
+	}
+	return true
+}
+
+// unitEvictable returns true if the input unit may be evicted. Units in
+// error or with blocked workloads are reported in the model summary, and
+// units may be watched by the machine they are on, or that their
+// principal is on. It assumes the model lock is held.
+func (m *Model) unitEvictable(unit *Unit) bool {
+	if unit.details.AgentStatus.Status == status.Error || unit.details.WorkloadStatus.Status == status.Blocked {
+		return false
+	}
+	machineId := unit.details.MachineId
+	if principal, ok := m.units[unit.details.Principal]; ok && machineId == "" {
+		machineId = principal.details.MachineId
+	}
+	if machine, ok := m.machines[machineId]; ok && machine.hasObservers() {
+		return false
+	}
+	return true
+}
+
+// evictLeastRecent evicts the least recently accessed cache residents
+// until the number of residents is within the configured maximum,
+// or there are no more residents that can be evicted.
+func (c *Controller) evictLeastRecent() {
+	excess := c.manager.count() - c.maxResidents
+	if excess <= 0 {
+		return
+	}
+
+	for _, res := range c.manager.leastRecentlyAccessed() {
+		if excess <= 0 {
+			return
+		}
+		removal := res.removal()
+		if !evictableRemoval(removal) || res.hasWorkers() {
+			continue
+		}
+
+		evicted, err := c.evictResident(removal)
+		if err != nil {
+			logger.Errorf("evicting cache resident %d: %s", res.CacheId(), err.Error())
+			continue
+		}
+		if !evicted {
+			continue
+		}
+		logger.Debugf("evicted least recently accessed cache resident %d: %#v", res.CacheId(), removal)
+		c.evicted.next()
+		c.metrics.Evictions.WithLabelValues(evictedModelUUID(removal)).Inc()
+		excess--
+	}
+}
+
+// evictResident evicts the entity identified by the input
+// removal message from its cached model, if it may be evicted.
+func (c *Controller) evictResident(removal interface{}) (bool, error) {
+	c.modelsMu.Lock()
+	defer c.modelsMu.Unlock()
+
+	model, ok := c.models[evictedModelUUID(removal)]
+	if !ok {
+		return false, nil
+	}
+	evicted, err := model.evict(removal)
+	return evicted, errors.Trace(err)
+}
+
+// evictableRemoval returns true if the input removal message
+// is for an entity that may be evicted when the cache is full.
+func evictableRemoval(removal interface{}) bool {
+	switch removal.(type) {
+	case RemoveApplication, RemoveMachine, RemoveUnit:
+		return true
+	}
+	return false
+}
+
+// evictedModelUUID returns the UUID of the model
+// for the input removal message.
+func evictedModelUUID(removal interface{}) string {
+	switch r := removal.(type) {
+	case RemoveApplication:
+		return r.ModelUUID
+	case RemoveMachine:
+		return r.ModelUUID
+	case RemoveUnit:
+		return r.ModelUUID
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+)
+
+func (s *ControllerSuite) TestConfigNegativeMaxResidents(c *gc.C) {
+	s.Config.MaxResidents = -1
+	err := s.Config.Validate()
+	c.Check(err, gc.ErrorMatches, "negative MaxResidents not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ControllerSuite) TestEvictLeastRecentlyAccessed(c *gc.C) {
+	controller, events, mod := s.newFullController(c)
+
+	// The machine was accessed before the application,
+	// so it is evicted when the unit is added.
+	s.accessMachineThenApplication(c, mod)
+	s.ProcessChange(c, unitChange, events)
+
+	_, err := mod.Machine(machineChange.Id)
+	c.Check(err, jc.Satisfies, cache.IsEvicted)
+	_, err = mod.Application(appChange.Name)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = mod.Unit(unitChange.Name)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(mod.Complete(), jc.IsFalse)
+
+	c.Check(controller.Report()["evicted-count"], gc.Equals, uint64(1))
+	c.Check(controller.MetricsSnapshot().Evictions, gc.Equals, float64(1))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestEvictLeastRecentlyAccessedWithWatcher(c *gc.C) {
+	controller, events, mod := s.newFullController(c)
+
+	machine, err := mod.Machine(machineChange.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	w := machine.WatchConfig()
+	defer workertest.CleanKill(c, w)
+	s.accessMachineThenApplication(c, mod)
+
+	// The machine was accessed least recently,
+	// but is retained for having a live watcher.
+	s.ProcessChange(c, unitChange, events)
+
+	_, err = mod.Machine(machineChange.Id)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = mod.Application(appChange.Name)
+	c.Check(err, jc.Satisfies, cache.IsEvicted)
+
+	c.Check(controller.Report()["evicted-count"], gc.Equals, uint64(1))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestEvictedResidentRestoredByChange(c *gc.C) {
+	controller, events, mod := s.newFullController(c)
+
+	s.accessMachineThenApplication(c, mod)
+	s.ProcessChange(c, unitChange, events)
+	_, err := mod.Machine(machineChange.Id)
+	c.Assert(err, jc.Satisfies, cache.IsEvicted)
+
+	s.ProcessChange(c, machineChange, events)
+	machine, err := mod.Machine(machineChange.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machine.Config(), jc.DeepEquals, machineChange.Config)
+
+	// The cache remains within its limit.
+	c.Check(controller.Report()["evicted-count"], gc.Equals, uint64(2))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestEvictionDoesNotChangeSummary(c *gc.C) {
+	controller, events, mod := s.newFullController(c)
+
+	s.accessMachineThenApplication(c, mod)
+	s.ProcessChange(c, unitChange, events)
+	_, err := mod.Machine(machineChange.Id)
+	c.Assert(err, jc.Satisfies, cache.IsEvicted)
+
+	// A further change recalculates the summary,
+	// which still counts the evicted machine.
+	s.ProcessChange(c, appChange, events)
+	summary, _ := mod.Summary()
+	c.Check(summary.MachineCount, gc.Equals, 1)
+	c.Check(summary.ApplicationCount, gc.Equals, 1)
+	c.Check(summary.UnitCount, gc.Equals, 1)
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestNoEvictionWithModelObserver(c *gc.C) {
+	controller, events, mod := s.newFullController(c)
+
+	w, err := mod.WatchMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.accessMachineThenApplication(c, mod)
+
+	// Every entity in the model is retained while
+	// the model's machines are being watched.
+	s.ProcessChange(c, unitChange, events)
+
+	_, err = mod.Machine(machineChange.Id)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = mod.Application(appChange.Name)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(mod.Complete(), jc.IsTrue)
+	c.Check(controller.Report()["evicted-count"], gc.Equals, uint64(0))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestNoEvictionOfUnitOnObservedMachine(c *gc.C) {
+	s.Config.MaxResidents = 4
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, machineChange, events)
+	s.ProcessChange(c, unitChange, events)
+	s.ProcessChange(c, appChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := mod.Machine(machineChange.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	w := machine.WatchUnits()
+	defer workertest.CleanKill(c, w)
+	_, err = mod.Application(appChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit was accessed least recently, but is retained
+	// for being on a machine with its units being watched.
+	machine1 := machineChange
+	machine1.Id = "1"
+	s.ProcessChange(c, machine1, events)
+
+	_, err = mod.Unit(unitChange.Name)
+	c.Check(err, jc.ErrorIsNil)
+	_, err = mod.Application(appChange.Name)
+	c.Check(err, jc.Satisfies, cache.IsEvicted)
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestReportWithoutMaxResidents(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+
+	_, ok := controller.Report()["evicted-count"]
+	c.Check(ok, jc.IsFalse)
+
+	workertest.CleanKill(c, controller)
+}
+
+// newFullController returns a controller limited to three residents,
+// and populates it with a model, an application and a machine.
+func (s *ControllerSuite) newFullController(c *gc.C) (*cache.Controller, <-chan interface{}, *cache.Model) {
+	s.Config.MaxResidents = 3
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, appChange, events)
+	s.ProcessChange(c, machineChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	return controller, events, mod
+}
+
+func (s *ControllerSuite) accessMachineThenApplication(c *gc.C, mod *cache.Model) {
+	_, err := mod.Machine(machineChange.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = mod.Application(appChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -61,7 +61,7 @@ func newMachineLXDProfileWatcher(config MachineLXDProfileWatcherConfig) (*Machin
 		modeler:           config.modeler,
 	}
 
-	deregister := config.resident.registerWorker(w)
+	deregister := config.resident.registerObserver(w)
 	multi := config.hub.NewMultiplexer()
 	multi.Add(config.appTopic, w.applicationCharmURLChange)
 	multi.Add(config.provisionedTopic, w.provisionedChange)
@@ -6,6 +6,7 @@ package cache
 import (
 	"fmt"
 	"regexp"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -14,7 +15,9 @@ import (
 )
 
 const (
-	machineProvisioned = "machine-provisioned"
+	machineProvisioned  = "machine-provisioned"
+	machineLifeChange   = "machine-life-change"
+	machineConfigChange = "machine-config-change"
 )
 
 func newMachine(model *Model, res *Resident) *Machine {
@@ -35,6 +38,12 @@ type Machine struct {
 
 	details    MachineChange
 	configHash string
+	hashCache  *hashCache
+
+	// configUnhashable is true when the most recently received
+	// config could not be hashed. It is used to ensure that a
+	// persistently unhashable config is only published once.
+	configUnhashable bool
 }
 
 // Note that these property accessors are not lock-protected.
@@ -98,6 +107,28 @@ func (m *Machine) Units() ([]Unit, error) {
 	return result, nil
 }
 
+// Containers returns copies of the container machines hosted by this
+// machine, including those nested within other containers.
+// The containers are sorted by machine ID.
+func (m *Machine) Containers() []Machine {
+	compiled, err := m.containerRegexp()
+	if err != nil {
+		logger.Errorf("invariant error - machine %q ID should form a valid expression, %v", m.details.Id, err)
+		return nil
+	}
+
+	var containers []Machine
+	for _, machine := range m.model.Machines() {
+		if compiled.MatchString(machine.details.Id) {
+			containers = append(containers, machine)
+		}
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].details.Id < containers[j].details.Id
+	})
+	return containers
+}
+
 // WatchContainers creates a PredicateStringsWatcher (strings watcher) to notify
 // about added and removed containers on this machine.  The initial event
 // contains a slice of the current container machine ids.
@@ -107,7 +138,29 @@ func (m *Machine) WatchContainers() (*PredicateStringsWatcher, error) {
 	if err != nil {
 		return nil, err
 	}
+	return m.watchContainers(compiled), nil
+}
+
+// WatchContainersOfType creates a PredicateStringsWatcher (strings watcher)
+// to notify about added and removed containers of the input type hosted
+// directly by this machine. The initial event contains a slice of the
+// current matching container ids.
+func (m *Machine) WatchContainersOfType(ct instance.ContainerType) (*PredicateStringsWatcher, error) {
+	if _, err := instance.ParseContainerType(string(ct)); err != nil {
+		return nil, errors.NotValidf("container type %q", ct)
+	}
+
+	// Create a compiled regexp to match containers of the type on this machine.
+	compiled, err := m.containerOfTypeRegexp(ct)
+	if err != nil {
+		return nil, err
+	}
+	return m.watchContainers(compiled), nil
+}
 
+// watchContainers returns a PredicateStringsWatcher notifying about
+// machines in the model with IDs matching the input expression.
+func (m *Machine) watchContainers(compiled *regexp.Regexp) *PredicateStringsWatcher {
 	// Gather initial slice of containers on this machine.
 	machines := make([]string, 0)
 	for k, v := range m.model.Machines() {
@@ -117,7 +170,7 @@ func (m *Machine) WatchContainers() (*PredicateStringsWatcher, error) {
 	}
 
 	w := newPredicateStringsWatcher(regexpPredicate(compiled), machines...)
-	deregister := m.registerWorker(w)
+	deregister := m.registerObserver(w)
 	unsub := m.model.hub.Subscribe(modelAddRemoveMachine, w.changed)
 
 	w.tomb.Go(func() error {
@@ -128,7 +181,24 @@ func (m *Machine) WatchContainers() (*PredicateStringsWatcher, error) {
 	})
 
 	m.registerWorker(w)
-	return w, nil
+	return w
+}
+
+// WatchApplications returns a watcher that notifies with the names of the
+// applications that have units on this machine, including subordinates.
+// The initial event contains the current application names, and subsequent
+// events are sent whenever the set changes as units are added, removed or
+// assigned to other machines.
+func (m *Machine) WatchApplications() *MachineApplicationsWatcher {
+	return newMachineApplicationsWatcher(m.copy(), m.model.hub, m.Resident)
+}
+
+// WatchUnits returns a watcher that notifies with the names of units
+// assigned to, or removed from this machine, including subordinate units
+// whose principal is on the machine. The initial event contains the names
+// of the units currently on the machine.
+func (m *Machine) WatchUnits() *MachineUnitsWatcher {
+	return newMachineUnitsWatcher(m.copy(), m.model.hub, m.Resident)
 }
 
 // WatchLXDProfileVerificationNeeded notifies if any of the following happen
@@ -154,11 +224,43 @@ func (m *Machine) WatchLXDProfileVerificationNeeded() (*MachineLXDProfileWatcher
 	})
 }
 
+// WatchLife returns a notify watcher that fires when the life of this
+// machine changes. Changes to other machines in the model do not trigger
+// the watcher. The watcher is stopped if the machine is removed from the
+// cache.
+func (m *Machine) WatchLife() NotifyWatcher {
+	w := newNotifyWatcherBase()
+	deregister := m.registerWorker(w)
+	unsub := m.model.hub.Subscribe(m.topic(machineLifeChange), func(string, interface{}) {
+		w.notify()
+	})
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+	return w
+}
+
+// WatchConfig returns a watcher that fires when the config of this
+// machine changes. If keys are supplied, which may be glob patterns as
+// for Model.WatchConfig, the watcher only fires when their values change.
+// The watcher is stopped if the machine is removed from the cache.
+func (m *Machine) WatchConfig(keys ...string) *ConfigWatcher {
+	return newConfigWatcher(keys, m.hashCache, m.model.hub, m.topic(machineConfigChange), m.Resident)
+}
+
 func (m *Machine) containerRegexp() (*regexp.Regexp, error) {
 	regExp := fmt.Sprintf("^%s%s", m.details.Id, names.ContainerSnippet)
 	return regexp.Compile(regExp)
 }
 
+func (m *Machine) containerOfTypeRegexp(ct instance.ContainerType) (*regexp.Regexp, error) {
+	regExp := fmt.Sprintf("^%s/%s/%s$", m.details.Id, ct, names.NumberSnippet)
+	return regexp.Compile(regExp)
+}
+
 func (m *Machine) setDetails(details MachineChange) {
 	m.setRemovalMessage(RemoveMachine{
 		ModelUUID: details.ModelUUID,
@@ -166,21 +268,40 @@ func (m *Machine) setDetails(details MachineChange) {
 	})
 
 	provisioned := details.InstanceId != m.details.InstanceId
+	lifeChanged := details.Life != m.details.Life
 	m.details = details
 
+	// Publish change event for those that may be waiting.
+	toPublish := m.copy()
+	m.model.hub.Publish(machineChangeTopic(details.Id), &toPublish)
+
 	if provisioned {
 		m.model.hub.Publish(m.topic(machineProvisioned), nil)
 	}
+	if lifeChanged {
+		m.model.hub.Publish(m.topic(machineLifeChange), nil)
+	}
 
-	configHash, err := hashSettings(details.Config)
-	if err != nil {
-		logger.Errorf("invariant error - machine config should be yaml serializable and hashable, %v", err)
-		configHash = ""
+	hashCache, configHash := newHashCache(
+		details.Config, m.model.dying, m.model.metrics.MachineHashCacheHit, m.model.metrics.MachineHashCacheMiss)
+	m.model.metrics.ChangesReceived.Inc()
+	unhashable := configHash == ""
+	if unhashable && m.configUnhashable {
+		// Only treat the config becoming unhashable as a change.
+		// Subsequent unhashable configs can not be distinguished
+		// from one another, so are not published.
+		logger.Debugf("machine %q config is still not hashable", details.Id)
+		return
 	}
-	if configHash != m.configHash {
-		m.configHash = configHash
-		// TODO: publish config change...
+	if !unhashable && !m.configUnhashable && configHash == m.configHash {
+		return
 	}
+	m.configUnhashable = unhashable
+	m.configHash = configHash
+	m.hashCache = hashCache
+	m.hashCache.incMisses()
+	m.model.hub.Publish(m.topic(machineConfigChange), hashCache)
+	m.model.metrics.ChangesEmitted.Inc()
 }
 
 func (m *Machine) copy() Machine {
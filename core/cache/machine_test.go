@@ -10,8 +10,10 @@ import (
 
 	"github.com/juju/juju/testing"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v2/workertest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/cache"
@@ -56,6 +58,126 @@ func (s *machineSuite) TestEmptyInstanceId(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "machine 0 not provisioned")
 }
 
+func (s *machineSuite) TestWatchLife(c *gc.C) {
+	s.model.UpdateMachine(machineChange, s.Manager)
+	mc1 := machineChange
+	mc1.Id = "1"
+	s.model.UpdateMachine(mc1, s.Manager)
+
+	machine0, err := s.model.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	machine1, err := s.model.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w0 := machine0.WatchLife()
+	defer workertest.CleanKill(c, w0)
+	w1 := machine1.WatchLife()
+	defer workertest.CleanKill(c, w1)
+
+	wc0 := cache.NewNotifyWatcherC(c, w0)
+	wc1 := cache.NewNotifyWatcherC(c, w1)
+
+	// Sends initial events.
+	wc0.AssertOneChange()
+	wc1.AssertOneChange()
+
+	// Changes that do not affect life are ignored.
+	mc1.InstanceStatus = status.StatusInfo{Status: status.Error}
+	s.model.UpdateMachine(mc1, s.Manager)
+	wc0.AssertNoChange()
+	wc1.AssertNoChange()
+
+	// Only the watcher for the machine whose life changed fires.
+	mc1.Life = life.Dying
+	s.model.UpdateMachine(mc1, s.Manager)
+	wc1.AssertOneChange()
+	wc0.AssertNoChange()
+}
+
+func (s *machineSuite) TestWatchConfigUnhashable(c *gc.C) {
+	mc := machineChange
+	mc.Config = map[string]interface{}{"key": "value"}
+	s.model.UpdateMachine(mc, s.Manager)
+
+	machine, err := s.model.Machine(mc.Id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := machine.WatchConfig()
+	defer workertest.CleanKill(c, w)
+
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// The config becoming unhashable is published once.
+	mc.Config = map[string]interface{}{"key": unhashable{}}
+	s.model.UpdateMachine(mc, s.Manager)
+	wc.AssertOneChange()
+
+	// Repeated unhashable updates are not published.
+	for i := 0; i < 3; i++ {
+		s.model.UpdateMachine(mc, s.Manager)
+	}
+	wc.AssertNoChange()
+
+	// The config becoming hashable again is published.
+	mc.Config = map[string]interface{}{"key": "value"}
+	s.model.UpdateMachine(mc, s.Manager)
+	wc.AssertOneChange()
+
+	s.model.UpdateMachine(mc, s.Manager)
+	wc.AssertNoChange()
+}
+
+func (s *machineSuite) TestWatchConfigKeys(c *gc.C) {
+	mc := machineChange
+	mc.Config = map[string]interface{}{"key": "value", "another": "foo"}
+	s.model.UpdateMachine(mc, s.Manager)
+
+	machine, err := s.model.Machine(mc.Id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := machine.WatchConfig("key")
+	defer workertest.CleanKill(c, w)
+	w2 := machine.WatchConfig("key")
+	defer workertest.CleanKill(c, w2)
+
+	// One cache miss for the published config, and one for the key.
+	// The second watcher gets a hit.
+	c.Check(testutil.ToFloat64(s.Gauges.MachineHashCacheMiss), gc.Equals, float64(2))
+	c.Check(testutil.ToFloat64(s.Gauges.MachineHashCacheHit), gc.Equals, float64(1))
+
+	wc := cache.NewNotifyWatcherC(c, w)
+	wc2 := cache.NewNotifyWatcherC(c, w2)
+	// Sends initial events.
+	wc.AssertOneChange()
+	wc2.AssertOneChange()
+
+	// Changes to other keys are ignored.
+	mc.Config = map[string]interface{}{"key": "value", "another": "changed"}
+	s.model.UpdateMachine(mc, s.Manager)
+	wc.AssertNoChange()
+	wc2.AssertNoChange()
+
+	mc.Config = map[string]interface{}{"key": "changed", "another": "changed"}
+	s.model.UpdateMachine(mc, s.Manager)
+	wc.AssertOneChange()
+	wc2.AssertOneChange()
+
+	// Another change to other keys is still ignored.
+	mc.Config = map[string]interface{}{"key": "changed", "another": "foo"}
+	s.model.UpdateMachine(mc, s.Manager)
+	wc.AssertNoChange()
+	wc2.AssertNoChange()
+}
+
+// unhashable is a config value that can not be serialized to YAML.
+type unhashable struct{}
+
+func (unhashable) MarshalYAML() (interface{}, error) {
+	return nil, errors.New("boom")
+}
+
 func (s *machineSuite) TestCharmProfiles(c *gc.C) {
 	mc := cache.MachineChange{
 		Id:            "0",
@@ -119,6 +241,33 @@ func (s *machineSuite) TestUnitsTwoMachines(c *gc.C) {
 	c.Assert(obtainedUnits1, jc.DeepEquals, expectedUnits1)
 }
 
+func (s *machineSuite) TestContainers(c *gc.C) {
+	s.setupMachine0(c)
+	for _, id := range []string{"0/lxd/1", "0/lxd/0", "0/lxd/0/kvm/0", "1", "1/lxd/0", "10/lxd/0"} {
+		mc := machineChange
+		mc.Id = id
+		s.model.UpdateMachine(mc, s.Manager)
+	}
+
+	containers := s.machine0.Containers()
+	ids := make([]string, len(containers))
+	for i, m := range containers {
+		ids[i] = m.Id()
+	}
+	c.Check(ids, jc.DeepEquals, []string{"0/lxd/0", "0/lxd/0/kvm/0", "0/lxd/1"})
+
+	// The containers are copies; changing them does not affect the cache.
+	containers[0].Config()["key"] = "changed"
+	container, err := s.model.Machine("0/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(container.Config()["key"], gc.Equals, "value")
+
+	// Machines without containers return none.
+	machine1, err := s.model.Machine("10/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machine1.Containers(), gc.HasLen, 0)
+}
+
 func (s *machineSuite) TestWatchContainersStops(c *gc.C) {
 	s.setupMachine0WithContainerWatcher(c, false)
 
@@ -177,6 +326,190 @@ func (s *machineSuite) TestWatchContainersRemoveContainer(c *gc.C) {
 	s.wc0.AssertOneChange([]string{rm.Id})
 }
 
+func (s *machineSuite) TestWatchContainersOfType(c *gc.C) {
+	s.setupMachine0(c)
+	for _, id := range []string{"0/lxd/0", "0/kvm/0", "0/lxd/0/lxd/0", "1/lxd/0"} {
+		mc := machineChange
+		mc.Id = id
+		s.model.UpdateMachine(mc, s.Manager)
+	}
+
+	w, err := s.machine0.WatchContainersOfType(instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{"0/lxd/0"})
+
+	// Containers of other types are not notified.
+	mc := machineChange
+	mc.Id = "0/kvm/1"
+	s.model.UpdateMachine(mc, s.Manager)
+	wc.AssertNoChange()
+
+	mc.Id = "0/lxd/1"
+	s.model.UpdateMachine(mc, s.Manager)
+	wc.AssertOneChange([]string{mc.Id})
+
+	rm := cache.RemoveMachine{
+		ModelUUID: modelChange.ModelUUID,
+		Id:        "0/lxd/0",
+	}
+	c.Assert(s.model.RemoveMachine(rm), jc.ErrorIsNil)
+	wc.AssertOneChange([]string{rm.Id})
+}
+
+func (s *machineSuite) TestWatchContainersOfTypeNotValid(c *gc.C) {
+	s.setupMachine0(c)
+
+	for _, ct := range []instance.ContainerType{"", instance.NONE, "docker"} {
+		_, err := s.machine0.WatchContainersOfType(ct)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *machineSuite) TestWatchApplications(c *gc.C) {
+	machine, _ := s.setupMachineWithUnits(c, "0", []string{"test1", "test2"})
+
+	w := machine.WatchApplications()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{"test1", "test2"})
+
+	// Another unit of an application already on the machine.
+	uc := unitChange
+	uc.Name = "test1/1"
+	uc.Application = "test1"
+	uc.MachineId = "0"
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertNoChange()
+
+	// A unit on another machine.
+	uc.Name = "test3/0"
+	uc.Application = "test3"
+	uc.MachineId = "1"
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertNoChange()
+
+	// A subordinate of a unit on the machine counts toward its application.
+	uc.Name = "test5/0"
+	uc.Application = "test5"
+	uc.MachineId = ""
+	uc.Principal = "test1/0"
+	uc.Subordinate = true
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertOneChange([]string{"test1", "test2", "test5"})
+
+	c.Assert(s.model.RemoveUnit(cache.RemoveUnit{
+		ModelUUID: modelChange.ModelUUID,
+		Name:      "test2/0",
+	}), jc.ErrorIsNil)
+	wc.AssertOneChange([]string{"test1", "test5"})
+
+	// Moving the principal to another machine takes its subordinate
+	// with it, but another unit of the same application remains.
+	uc = unitChange
+	uc.Name = "test1/0"
+	uc.Application = "test1"
+	uc.MachineId = "1"
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertOneChange([]string{"test1"})
+}
+
+func (s *machineSuite) TestWatchApplicationsStops(c *gc.C) {
+	machine, _ := s.setupMachineWithUnits(c, "0", []string{"test1"})
+
+	w := machine.WatchApplications()
+	wc := cache.NewStringsWatcherC(c, w)
+	wc.AssertOneChange([]string{"test1"})
+
+	// The worker is the first and only resource (1).
+	resourceId := uint64(1)
+	s.AssertWorkerResource(c, machine.Resident, resourceId, true)
+	wc.AssertStops()
+	s.AssertWorkerResource(c, machine.Resident, resourceId, false)
+}
+
+func (s *machineSuite) TestWatchUnits(c *gc.C) {
+	machine, _ := s.setupMachineWithUnits(c, "0", []string{"test1", "test2"})
+
+	w := machine.WatchUnits()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{"test1/0", "test2/0"})
+
+	// A unit assigned to the machine.
+	uc := unitChange
+	uc.Name = "test1/1"
+	uc.Application = "test1"
+	uc.MachineId = "0"
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertOneChange([]string{"test1/1"})
+
+	// An unchanged unit.
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertNoChange()
+
+	// A unit on another machine.
+	uc.Name = "test3/0"
+	uc.Application = "test3"
+	uc.MachineId = "1"
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertNoChange()
+
+	// A subordinate of a unit on the machine.
+	uc.Name = "test5/0"
+	uc.Application = "test5"
+	uc.MachineId = ""
+	uc.Principal = "test1/0"
+	uc.Subordinate = true
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertOneChange([]string{"test5/0"})
+
+	// A subordinate of a unit on another machine.
+	uc.Name = "test5/1"
+	uc.Principal = "test3/0"
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertNoChange()
+
+	c.Assert(s.model.RemoveUnit(cache.RemoveUnit{
+		ModelUUID: modelChange.ModelUUID,
+		Name:      "test2/0",
+	}), jc.ErrorIsNil)
+	wc.AssertOneChange([]string{"test2/0"})
+
+	// Removing a unit on another machine.
+	c.Assert(s.model.RemoveUnit(cache.RemoveUnit{
+		ModelUUID: modelChange.ModelUUID,
+		Name:      "test3/0",
+	}), jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Moving the principal to another machine takes its subordinate with it.
+	uc = unitChange
+	uc.Name = "test1/0"
+	uc.Application = "test1"
+	uc.MachineId = "1"
+	s.model.UpdateUnit(uc, s.Manager)
+	wc.AssertOneChange([]string{"test1/0", "test5/0"})
+}
+
+func (s *machineSuite) TestWatchUnitsStops(c *gc.C) {
+	machine, _ := s.setupMachineWithUnits(c, "0", []string{"test1"})
+
+	w := machine.WatchUnits()
+	wc := cache.NewStringsWatcherC(c, w)
+	wc.AssertOneChange([]string{"test1/0"})
+
+	// The worker is the first and only resource (1).
+	resourceId := uint64(1)
+	s.AssertWorkerResource(c, machine.Resident, resourceId, true)
+	wc.AssertStops()
+	s.AssertWorkerResource(c, machine.Resident, resourceId, false)
+}
+
 func (s *machineSuite) TestMachineArrivesProvisionedPublished(c *gc.C) {
 	msg := make(chan struct{}, 1)
 	unsub := s.Hub.Subscribe(
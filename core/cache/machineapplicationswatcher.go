@@ -0,0 +1,97 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/pubsub"
+)
+
+// MachineApplicationsWatcher notifies with the names of the applications
+// that have units on a machine, including subordinate units. Each change
+// contains the complete set of application names.
+type MachineApplicationsWatcher struct {
+	*stringsWatcherBase
+
+	machine Machine
+
+	mu      sync.Mutex
+	current set.Strings
+}
+
+func newMachineApplicationsWatcher(
+	machine Machine, hub *pubsub.SimpleHub, resident *Resident,
+) *MachineApplicationsWatcher {
+	current := machineApplications(machine)
+	w := &MachineApplicationsWatcher{
+		stringsWatcherBase: newStringsWatcherBase(current.SortedValues()...),
+		machine:            machine,
+		current:            current,
+	}
+
+	deregister := resident.registerObserver(w)
+	multi := hub.NewMultiplexer()
+	multi.Add(modelUnitAdd, w.unitChanged)
+	multi.Add(modelUnitRemove, w.unitChanged)
+
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		multi.Unsubscribe()
+		deregister()
+		return nil
+	})
+	return w
+}
+
+// unitChanged recalculates the machine's applications when a unit that
+// may be, or may have been, on the machine is added, removed or moved.
+func (w *MachineApplicationsWatcher) unitChanged(_ string, value interface{}) {
+	unit, ok := value.(Unit)
+	if !ok {
+		logger.Errorf("programming error, value not of type Unit")
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	relevant := unit.Subordinate() ||
+		unit.MachineId() == w.machine.Id() ||
+		w.current.Contains(unit.Application())
+	if !relevant {
+		return
+	}
+
+	apps := machineApplications(w.machine)
+	if apps.Difference(w.current).IsEmpty() && w.current.Difference(apps).IsEmpty() {
+		return
+	}
+	w.current = apps
+	w.notifyReplace(apps.SortedValues())
+}
+
+// machineApplications returns the names of the applications with units
+// on the input machine. Subordinate units are located by their principal.
+func machineApplications(machine Machine) set.Strings {
+	units := machine.model.Units()
+	apps := set.NewStrings()
+	for _, unit := range units {
+		machineId := unit.MachineId()
+		if unit.Subordinate() {
+			// A subordinate may be seen before its principal, in which
+			// case the principal's arrival causes a recalculation.
+			principal, ok := units[unit.Principal()]
+			if !ok {
+				continue
+			}
+			machineId = principal.MachineId()
+		}
+		if machineId == machine.Id() {
+			apps.Add(unit.Application())
+		}
+	}
+	return apps
+}
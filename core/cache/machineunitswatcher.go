@@ -0,0 +1,116 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/pubsub"
+)
+
+// MachineUnitsWatcher notifies with the names of units that are assigned
+// to, or removed from a machine, including subordinate units whose
+// principal is on the machine. Changes not yet received are combined.
+type MachineUnitsWatcher struct {
+	*stringsWatcherBase
+
+	machine Machine
+
+	mu      sync.Mutex
+	current set.Strings
+}
+
+func newMachineUnitsWatcher(
+	machine Machine, hub *pubsub.SimpleHub, resident *Resident,
+) *MachineUnitsWatcher {
+	current := machineUnits(machine)
+	w := &MachineUnitsWatcher{
+		stringsWatcherBase: newStringsWatcherBase(current.SortedValues()...),
+		machine:            machine,
+		current:            current,
+	}
+
+	deregister := resident.registerObserver(w)
+	multi := hub.NewMultiplexer()
+	multi.Add(modelUnitAdd, w.unitAdded)
+	multi.Add(modelUnitRemove, w.unitRemoved)
+
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		multi.Unsubscribe()
+		deregister()
+		return nil
+	})
+	return w
+}
+
+// unitAdded recalculates the machine's units when a unit
+// that may be, or may have been, on the machine is added
+// or assigned to a machine.
+func (w *MachineUnitsWatcher) unitAdded(_ string, value interface{}) {
+	unit, ok := value.(Unit)
+	if !ok {
+		logger.Errorf("programming error, value not of type Unit")
+		return
+	}
+	w.unitChanged(unit, false)
+}
+
+// unitRemoved notifies if the removed unit was on the machine.
+func (w *MachineUnitsWatcher) unitRemoved(_ string, value interface{}) {
+	unit, ok := value.(Unit)
+	if !ok {
+		logger.Errorf("programming error, value not of type Unit")
+		return
+	}
+	w.unitChanged(unit, true)
+}
+
+func (w *MachineUnitsWatcher) unitChanged(unit Unit, removed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	relevant := unit.Subordinate() ||
+		unit.MachineId() == w.machine.Id() ||
+		w.current.Contains(unit.Name())
+	if !relevant {
+		return
+	}
+
+	units := machineUnits(w.machine)
+	if removed {
+		// The unit may not yet have been removed from the model.
+		units.Remove(unit.Name())
+	}
+	changed := units.Difference(w.current).Union(w.current.Difference(units))
+	if changed.IsEmpty() {
+		return
+	}
+	w.current = units
+	w.notify(changed.SortedValues())
+}
+
+// machineUnits returns the names of the units on the input
+// machine. Subordinate units are located by their principal.
+func machineUnits(machine Machine) set.Strings {
+	units := machine.model.Units()
+	names := set.NewStrings()
+	for name, unit := range units {
+		machineId := unit.MachineId()
+		if unit.Subordinate() {
+			// A subordinate may be seen before its principal, in which
+			// case the principal's arrival causes a recalculation.
+			principal, ok := units[unit.Principal()]
+			if !ok {
+				continue
+			}
+			machineId = principal.MachineId()
+		}
+		if machineId == machine.Id() {
+			names.Add(name)
+		}
+	}
+	return names
+}
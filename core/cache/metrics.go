@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/loggo"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 const (
@@ -22,6 +23,7 @@ const (
 	agentStatusLabel      = "agent_status"
 	instanceStatusLabel   = "instance_status"
 	workloadStatusLabel   = "workload_status"
+	modelUUIDLabel        = "model_uuid"
 )
 
 var (
@@ -46,6 +48,10 @@ var (
 		statusLabel,
 	}
 
+	modelGaugeLabelNames = []string{
+		modelUUIDLabel,
+	}
+
 	userLabelNames = []string{
 		controllerAccessLabel,
 		deletedLabel,
@@ -57,7 +63,9 @@ var (
 )
 
 // ControllerGauges holds the prometheus gauges for ever increasing
-// values used by the controller.
+// values recorded by the controller for a single cached model.
+// They are obtained from the controller's gauge vectors, which are
+// labelled with the model UUID.
 type ControllerGauges struct {
 	ModelConfigReads   prometheus.Gauge
 	ModelHashCacheHit  prometheus.Gauge
@@ -70,127 +78,398 @@ type ControllerGauges struct {
 	CharmConfigHashCacheHit  prometheus.Gauge
 	CharmConfigHashCacheMiss prometheus.Gauge
 
+	MachineHashCacheHit  prometheus.Gauge
+	MachineHashCacheMiss prometheus.Gauge
+
 	LXDProfileChangeError        prometheus.Gauge
 	LXDProfileChangeNotification prometheus.Gauge
 	LXDProfileNoChange           prometheus.Gauge
+
+	InvariantViolations prometheus.Gauge
+	Evictions           prometheus.Gauge
+
+	ChangesReceived prometheus.Gauge
+	ChangesEmitted  prometheus.Gauge
 }
 
-func createControllerGauges() *ControllerGauges {
-	return &ControllerGauges{
-		ModelConfigReads: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "model_config_reads",
-				Help:      "The number of times the model config is read.",
-			},
+// MetricsSnapshot holds the change in the controller gauges between
+// two consecutive calls to Snapshot.
+type MetricsSnapshot struct {
+	ModelConfigReads   float64
+	ModelHashCacheHit  float64
+	ModelHashCacheMiss float64
+
+	ApplicationConfigReads   float64
+	ApplicationHashCacheHit  float64
+	ApplicationHashCacheMiss float64
+
+	CharmConfigHashCacheHit  float64
+	CharmConfigHashCacheMiss float64
+
+	MachineHashCacheHit  float64
+	MachineHashCacheMiss float64
+
+	LXDProfileChangeError        float64
+	LXDProfileChangeNotification float64
+	LXDProfileNoChange           float64
+
+	InvariantViolations float64
+	Evictions           float64
+
+	ChangesReceived float64
+	ChangesEmitted  float64
+}
+
+// add returns the element-wise sum of s and other.
+func (s MetricsSnapshot) add(other MetricsSnapshot) MetricsSnapshot {
+	return MetricsSnapshot{
+		ModelConfigReads:   s.ModelConfigReads + other.ModelConfigReads,
+		ModelHashCacheHit:  s.ModelHashCacheHit + other.ModelHashCacheHit,
+		ModelHashCacheMiss: s.ModelHashCacheMiss + other.ModelHashCacheMiss,
+
+		ApplicationConfigReads:   s.ApplicationConfigReads + other.ApplicationConfigReads,
+		ApplicationHashCacheHit:  s.ApplicationHashCacheHit + other.ApplicationHashCacheHit,
+		ApplicationHashCacheMiss: s.ApplicationHashCacheMiss + other.ApplicationHashCacheMiss,
+
+		CharmConfigHashCacheHit:  s.CharmConfigHashCacheHit + other.CharmConfigHashCacheHit,
+		CharmConfigHashCacheMiss: s.CharmConfigHashCacheMiss + other.CharmConfigHashCacheMiss,
+
+		MachineHashCacheHit:  s.MachineHashCacheHit + other.MachineHashCacheHit,
+		MachineHashCacheMiss: s.MachineHashCacheMiss + other.MachineHashCacheMiss,
+
+		LXDProfileChangeError:        s.LXDProfileChangeError + other.LXDProfileChangeError,
+		LXDProfileChangeNotification: s.LXDProfileChangeNotification + other.LXDProfileChangeNotification,
+		LXDProfileNoChange:           s.LXDProfileNoChange + other.LXDProfileNoChange,
+
+		InvariantViolations: s.InvariantViolations + other.InvariantViolations,
+		Evictions:           s.Evictions + other.Evictions,
+
+		ChangesReceived: s.ChangesReceived + other.ChangesReceived,
+		ChangesEmitted:  s.ChangesEmitted + other.ChangesEmitted,
+	}
+}
+
+// sub returns the element-wise difference between s and other.
+func (s MetricsSnapshot) sub(other MetricsSnapshot) MetricsSnapshot {
+	return MetricsSnapshot{
+		ModelConfigReads:   s.ModelConfigReads - other.ModelConfigReads,
+		ModelHashCacheHit:  s.ModelHashCacheHit - other.ModelHashCacheHit,
+		ModelHashCacheMiss: s.ModelHashCacheMiss - other.ModelHashCacheMiss,
+
+		ApplicationConfigReads:   s.ApplicationConfigReads - other.ApplicationConfigReads,
+		ApplicationHashCacheHit:  s.ApplicationHashCacheHit - other.ApplicationHashCacheHit,
+		ApplicationHashCacheMiss: s.ApplicationHashCacheMiss - other.ApplicationHashCacheMiss,
+
+		CharmConfigHashCacheHit:  s.CharmConfigHashCacheHit - other.CharmConfigHashCacheHit,
+		CharmConfigHashCacheMiss: s.CharmConfigHashCacheMiss - other.CharmConfigHashCacheMiss,
+
+		MachineHashCacheHit:  s.MachineHashCacheHit - other.MachineHashCacheHit,
+		MachineHashCacheMiss: s.MachineHashCacheMiss - other.MachineHashCacheMiss,
+
+		LXDProfileChangeError:        s.LXDProfileChangeError - other.LXDProfileChangeError,
+		LXDProfileChangeNotification: s.LXDProfileChangeNotification - other.LXDProfileChangeNotification,
+		LXDProfileNoChange:           s.LXDProfileNoChange - other.LXDProfileNoChange,
+
+		InvariantViolations: s.InvariantViolations - other.InvariantViolations,
+		Evictions:           s.Evictions - other.Evictions,
+
+		ChangesReceived: s.ChangesReceived - other.ChangesReceived,
+		ChangesEmitted:  s.ChangesEmitted - other.ChangesEmitted,
+	}
+}
+
+// controllerGaugeVecs holds the prometheus gauge vectors for ever increasing
+// values used by the controller, labelled with the UUID of the model to
+// which each value relates. Each cached model records its values with the
+// ControllerGauges returned by forModel.
+type controllerGaugeVecs struct {
+	ModelConfigReads   *prometheus.GaugeVec
+	ModelHashCacheHit  *prometheus.GaugeVec
+	ModelHashCacheMiss *prometheus.GaugeVec
+
+	ApplicationConfigReads   *prometheus.GaugeVec
+	ApplicationHashCacheHit  *prometheus.GaugeVec
+	ApplicationHashCacheMiss *prometheus.GaugeVec
+
+	CharmConfigHashCacheHit  *prometheus.GaugeVec
+	CharmConfigHashCacheMiss *prometheus.GaugeVec
+
+	MachineHashCacheHit  *prometheus.GaugeVec
+	MachineHashCacheMiss *prometheus.GaugeVec
+
+	LXDProfileChangeError        *prometheus.GaugeVec
+	LXDProfileChangeNotification *prometheus.GaugeVec
+	LXDProfileNoChange           *prometheus.GaugeVec
+
+	InvariantViolations *prometheus.GaugeVec
+	Evictions           *prometheus.GaugeVec
+
+	ChangesReceived *prometheus.GaugeVec
+	ChangesEmitted  *prometheus.GaugeVec
+
+	// mu guards retired, which accumulates the gauge values of removed
+	// models, and lastSnapshot, which holds the totals observed by the most
+	// recent call to Snapshot.
+	mu           sync.Mutex
+	retired      MetricsSnapshot
+	lastSnapshot MetricsSnapshot
+}
+
+func createControllerGaugeVecs() *controllerGaugeVecs {
+	return &controllerGaugeVecs{
+		ModelConfigReads: newModelGaugeVec(
+			"model_config_reads",
+			"The number of times the model config is read.",
 		),
-		ModelHashCacheHit: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "model_hash_cache_hit",
-				Help:      "The number of times the model config change hash was determined using the cached value.",
-			},
+		ModelHashCacheHit: newModelGaugeVec(
+			"model_hash_cache_hit",
+			"The number of times the model config change hash was determined using the cached value.",
 		),
-		ModelHashCacheMiss: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "model_hash_cache_miss",
-				Help:      "The number of times the model config change hash was generated.",
-			},
+		ModelHashCacheMiss: newModelGaugeVec(
+			"model_hash_cache_miss",
+			"The number of times the model config change hash was generated.",
 		),
-		CharmConfigHashCacheHit: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "charm_config_watcher_hash_hit",
-				Help:      "The number of times a change in master or branch config required no notification to config watcher(s)",
-			},
+		ApplicationConfigReads: newModelGaugeVec(
+			"application_config_reads",
+			"The number of times the application config is read.",
 		),
-		CharmConfigHashCacheMiss: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "charm_config_watcher_hash_miss",
-				Help:      "The number of times a change in master or branch config required notification to config watcher(s)",
-			},
+		ApplicationHashCacheHit: newModelGaugeVec(
+			"application_hash_cache_hit",
+			"The number of times the application config change hash was determined using the cached value.",
 		),
-		ApplicationConfigReads: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "application_config_reads",
-				Help:      "The number of times the application config is read.",
-			},
+		ApplicationHashCacheMiss: newModelGaugeVec(
+			"application_hash_cache_miss",
+			"The number of times the application config change hash was generated.",
 		),
-		ApplicationHashCacheHit: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "application_hash_cache_hit",
-				Help:      "The number of times the application config change hash was determined using the cached value.",
-			},
+		CharmConfigHashCacheHit: newModelGaugeVec(
+			"charm_config_watcher_hash_hit",
+			"The number of times a change in master or branch config required no notification to config watcher(s)",
 		),
-		ApplicationHashCacheMiss: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "application_hash_cache_miss",
-				Help:      "The number of times the application config change hash was generated.",
-			},
+		CharmConfigHashCacheMiss: newModelGaugeVec(
+			"charm_config_watcher_hash_miss",
+			"The number of times a change in master or branch config required notification to config watcher(s)",
 		),
-		LXDProfileChangeError: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "lxdprofile_change_error",
-				Help:      "The number of times there was an error calculating LXD profile related changes.",
-			},
+		MachineHashCacheHit: newModelGaugeVec(
+			"machine_hash_cache_hit",
+			"The number of times the machine config change hash was determined using the cached value.",
 		),
-		LXDProfileChangeNotification: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "lxdprofile_change_notify",
-				Help:      "The number of times an LXD Profile related change triggered a notification.",
-			},
+		MachineHashCacheMiss: newModelGaugeVec(
+			"machine_hash_cache_miss",
+			"The number of times the machine config change hash was generated.",
 		),
-		LXDProfileNoChange: prometheus.NewGauge(
-			prometheus.GaugeOpts{
-				Namespace: metricsNamespace,
-				Name:      "lxdprofile_no_change",
-				Help:      "The number of times an LXD Profile related change did not trigger a notification.",
-			},
+		LXDProfileChangeError: newModelGaugeVec(
+			"lxdprofile_change_error",
+			"The number of times there was an error calculating LXD profile related changes.",
+		),
+		LXDProfileChangeNotification: newModelGaugeVec(
+			"lxdprofile_change_notify",
+			"The number of times an LXD Profile related change triggered a notification.",
+		),
+		LXDProfileNoChange: newModelGaugeVec(
+			"lxdprofile_no_change",
+			"The number of times an LXD Profile related change did not trigger a notification.",
+		),
+		InvariantViolations: newModelGaugeVec(
+			"invariant_violations",
+			"The number of times a change left the cache referencing an entity that had been removed.",
+		),
+		Evictions: newModelGaugeVec(
+			"evictions",
+			"The number of entities evicted from the cache before their removal was received.",
+		),
+		ChangesReceived: newModelGaugeVec(
+			"changes_received",
+			"The number of model, application, machine and unit changes received.",
+		),
+		ChangesEmitted: newModelGaugeVec(
+			"changes_emitted",
+			"The number of received changes that resulted in a config or status notification.",
 		),
 	}
 }
 
-// Describe is part of the prometheus.Collector interface.
-func (c *ControllerGauges) Describe(ch chan<- *prometheus.Desc) {
-	c.ModelConfigReads.Describe(ch)
-	c.ModelHashCacheHit.Describe(ch)
-	c.ModelHashCacheMiss.Describe(ch)
+// newModelGaugeVec returns a new gauge vector labelled with the model UUID.
+func newModelGaugeVec(name, help string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      name,
+			Help:      help,
+		},
+		modelGaugeLabelNames,
+	)
+}
+
+// forModel returns the gauges for the model with the input UUID.
+func (c *controllerGaugeVecs) forModel(modelUUID string) *ControllerGauges {
+	return &ControllerGauges{
+		ModelConfigReads:   c.ModelConfigReads.WithLabelValues(modelUUID),
+		ModelHashCacheHit:  c.ModelHashCacheHit.WithLabelValues(modelUUID),
+		ModelHashCacheMiss: c.ModelHashCacheMiss.WithLabelValues(modelUUID),
+
+		ApplicationConfigReads:   c.ApplicationConfigReads.WithLabelValues(modelUUID),
+		ApplicationHashCacheHit:  c.ApplicationHashCacheHit.WithLabelValues(modelUUID),
+		ApplicationHashCacheMiss: c.ApplicationHashCacheMiss.WithLabelValues(modelUUID),
+
+		CharmConfigHashCacheHit:  c.CharmConfigHashCacheHit.WithLabelValues(modelUUID),
+		CharmConfigHashCacheMiss: c.CharmConfigHashCacheMiss.WithLabelValues(modelUUID),
+
+		MachineHashCacheHit:  c.MachineHashCacheHit.WithLabelValues(modelUUID),
+		MachineHashCacheMiss: c.MachineHashCacheMiss.WithLabelValues(modelUUID),
 
-	c.CharmConfigHashCacheHit.Describe(ch)
-	c.CharmConfigHashCacheMiss.Describe(ch)
+		LXDProfileChangeError:        c.LXDProfileChangeError.WithLabelValues(modelUUID),
+		LXDProfileChangeNotification: c.LXDProfileChangeNotification.WithLabelValues(modelUUID),
+		LXDProfileNoChange:           c.LXDProfileNoChange.WithLabelValues(modelUUID),
 
-	c.ApplicationConfigReads.Describe(ch)
-	c.ApplicationHashCacheHit.Describe(ch)
-	c.ApplicationHashCacheMiss.Describe(ch)
+		InvariantViolations: c.InvariantViolations.WithLabelValues(modelUUID),
+		Evictions:           c.Evictions.WithLabelValues(modelUUID),
 
-	c.LXDProfileChangeError.Describe(ch)
-	c.LXDProfileChangeNotification.Describe(ch)
-	c.LXDProfileNoChange.Describe(ch)
+		ChangesReceived: c.ChangesReceived.WithLabelValues(modelUUID),
+		ChangesEmitted:  c.ChangesEmitted.WithLabelValues(modelUUID),
+	}
 }
 
-// Collect is part of the prometheus.Collector interface.
-func (c *ControllerGauges) Collect(ch chan<- prometheus.Metric) {
-	c.ModelConfigReads.Collect(ch)
-	c.ModelHashCacheHit.Collect(ch)
-	c.ModelHashCacheMiss.Collect(ch)
+// removeModel deletes the gauges for the model with the input UUID,
+// so that they are no longer reported to prometheus. Their values are
+// retained in the totals reported by Snapshot.
+func (c *controllerGaugeVecs) removeModel(modelUUID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.retired = c.retired.add(c.forModel(modelUUID).values())
+	for _, vec := range c.vecs() {
+		vec.DeleteLabelValues(modelUUID)
+	}
+}
+
+// Snapshot returns the activity recorded by the gauges of all models since
+// the previous call to Snapshot, or since the gauges were created for the
+// first call. The gauges themselves are left untouched, so the values
+// reported to prometheus continue to accumulate.
+func (c *controllerGaugeVecs) Snapshot() MetricsSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current := MetricsSnapshot{
+		ModelConfigReads:   gaugeVecSum(c.ModelConfigReads),
+		ModelHashCacheHit:  gaugeVecSum(c.ModelHashCacheHit),
+		ModelHashCacheMiss: gaugeVecSum(c.ModelHashCacheMiss),
 
-	c.CharmConfigHashCacheHit.Collect(ch)
-	c.CharmConfigHashCacheMiss.Collect(ch)
+		ApplicationConfigReads:   gaugeVecSum(c.ApplicationConfigReads),
+		ApplicationHashCacheHit:  gaugeVecSum(c.ApplicationHashCacheHit),
+		ApplicationHashCacheMiss: gaugeVecSum(c.ApplicationHashCacheMiss),
 
-	c.ApplicationConfigReads.Collect(ch)
-	c.ApplicationHashCacheHit.Collect(ch)
-	c.ApplicationHashCacheMiss.Collect(ch)
+		CharmConfigHashCacheHit:  gaugeVecSum(c.CharmConfigHashCacheHit),
+		CharmConfigHashCacheMiss: gaugeVecSum(c.CharmConfigHashCacheMiss),
 
-	c.LXDProfileChangeError.Collect(ch)
-	c.LXDProfileChangeNotification.Collect(ch)
-	c.LXDProfileNoChange.Collect(ch)
+		MachineHashCacheHit:  gaugeVecSum(c.MachineHashCacheHit),
+		MachineHashCacheMiss: gaugeVecSum(c.MachineHashCacheMiss),
+
+		LXDProfileChangeError:        gaugeVecSum(c.LXDProfileChangeError),
+		LXDProfileChangeNotification: gaugeVecSum(c.LXDProfileChangeNotification),
+		LXDProfileNoChange:           gaugeVecSum(c.LXDProfileNoChange),
+
+		InvariantViolations: gaugeVecSum(c.InvariantViolations),
+		Evictions:           gaugeVecSum(c.Evictions),
+
+		ChangesReceived: gaugeVecSum(c.ChangesReceived),
+		ChangesEmitted:  gaugeVecSum(c.ChangesEmitted),
+	}.add(c.retired)
+	delta := current.sub(c.lastSnapshot)
+	c.lastSnapshot = current
+	return delta
+}
+
+// values returns the current values of the gauges.
+func (g *ControllerGauges) values() MetricsSnapshot {
+	return MetricsSnapshot{
+		ModelConfigReads:   gaugeValue(g.ModelConfigReads),
+		ModelHashCacheHit:  gaugeValue(g.ModelHashCacheHit),
+		ModelHashCacheMiss: gaugeValue(g.ModelHashCacheMiss),
+
+		ApplicationConfigReads:   gaugeValue(g.ApplicationConfigReads),
+		ApplicationHashCacheHit:  gaugeValue(g.ApplicationHashCacheHit),
+		ApplicationHashCacheMiss: gaugeValue(g.ApplicationHashCacheMiss),
+
+		CharmConfigHashCacheHit:  gaugeValue(g.CharmConfigHashCacheHit),
+		CharmConfigHashCacheMiss: gaugeValue(g.CharmConfigHashCacheMiss),
+
+		MachineHashCacheHit:  gaugeValue(g.MachineHashCacheHit),
+		MachineHashCacheMiss: gaugeValue(g.MachineHashCacheMiss),
+
+		LXDProfileChangeError:        gaugeValue(g.LXDProfileChangeError),
+		LXDProfileChangeNotification: gaugeValue(g.LXDProfileChangeNotification),
+		LXDProfileNoChange:           gaugeValue(g.LXDProfileNoChange),
+
+		InvariantViolations: gaugeValue(g.InvariantViolations),
+		Evictions:           gaugeValue(g.Evictions),
+
+		ChangesReceived: gaugeValue(g.ChangesReceived),
+		ChangesEmitted:  gaugeValue(g.ChangesEmitted),
+	}
+}
+
+// vecs returns all of the controller's gauge vectors.
+func (c *controllerGaugeVecs) vecs() []*prometheus.GaugeVec {
+	return []*prometheus.GaugeVec{
+		c.ModelConfigReads,
+		c.ModelHashCacheHit,
+		c.ModelHashCacheMiss,
+		c.ApplicationConfigReads,
+		c.ApplicationHashCacheHit,
+		c.ApplicationHashCacheMiss,
+		c.CharmConfigHashCacheHit,
+		c.CharmConfigHashCacheMiss,
+		c.MachineHashCacheHit,
+		c.MachineHashCacheMiss,
+		c.LXDProfileChangeError,
+		c.LXDProfileChangeNotification,
+		c.LXDProfileNoChange,
+		c.InvariantViolations,
+		c.Evictions,
+		c.ChangesReceived,
+		c.ChangesEmitted,
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *controllerGaugeVecs) Describe(ch chan<- *prometheus.Desc) {
+	for _, vec := range c.vecs() {
+		vec.Describe(ch)
+	}
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *controllerGaugeVecs) Collect(ch chan<- prometheus.Metric) {
+	for _, vec := range c.vecs() {
+		vec.Collect(ch)
+	}
+}
+
+// gaugeVecSum returns the sum of the values
+// of all of the gauges in the input vector.
+func gaugeVecSum(vec *prometheus.GaugeVec) float64 {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		vec.Collect(ch)
+		close(ch)
+	}()
+
+	var sum float64
+	for m := range ch {
+		sum += gaugeValue(m)
+	}
+	return sum
+}
+
+// gaugeValue returns the current value of the input gauge.
+func gaugeValue(m prometheus.Metric) float64 {
+	var pb dto.Metric
+	if err := m.Write(&pb); err != nil {
+		logger.Warningf("reading gauge value: %v", err)
+		return 0
+	}
+	return pb.GetGauge().GetValue()
 }
 
 // Collector is a prometheus.Collector that collects metrics about
@@ -276,8 +555,6 @@ func NewMetricsCollector(controller *Controller) *Collector {
 
 // Describe is part of the prometheus.Collector interface.
 func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
-	c.controller.metrics.Describe(ch)
-
 	c.models.Describe(ch)
 	c.machines.Describe(ch)
 	c.applications.Describe(ch)
@@ -307,7 +584,6 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 
 	c.updateMetrics()
 
-	c.controller.metrics.Collect(ch)
 	c.models.Collect(ch)
 	c.machines.Collect(ch)
 	c.applications.Collect(ch)
@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
 	"github.com/juju/worker/v2/workertest"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	gc "gopkg.in/check.v1"
 
@@ -100,3 +101,112 @@ juju_cache_models{life="alive",status="active"} 10
 	}
 	wg.Wait()
 }
+
+func (s *ControllerSuite) TestMetricsSnapshot(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	s.Config.PrometheusRegisterer = registry
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+
+	model, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The first snapshot reflects all activity since creation.
+	model.Config()
+	model.Config()
+	snapshot := controller.MetricsSnapshot()
+	c.Check(snapshot.ModelConfigReads, gc.Equals, float64(2))
+
+	// Subsequent snapshots only reflect activity since the previous one.
+	model.Config()
+	snapshot = controller.MetricsSnapshot()
+	c.Check(snapshot.ModelConfigReads, gc.Equals, float64(1))
+
+	snapshot = controller.MetricsSnapshot()
+	c.Check(snapshot.ModelConfigReads, gc.Equals, float64(0))
+
+	// The prometheus gauges continue to accumulate.
+	expected := bytes.NewBuffer([]byte(`
+# HELP juju_cache_model_config_reads The number of times the model config is read.
+# TYPE juju_cache_model_config_reads gauge
+juju_cache_model_config_reads{model_uuid="model-uuid"} 3
+		`[1:]))
+	err = testutil.GatherAndCompare(registry, expected, "juju_cache_model_config_reads")
+	c.Check(err, jc.ErrorIsNil)
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestChangeCoalescingMetrics(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, appChange, events)
+
+	snapshot := controller.MetricsSnapshot()
+	c.Check(snapshot.ChangesReceived, gc.Equals, float64(2))
+	c.Check(snapshot.ChangesEmitted, gc.Equals, float64(2))
+
+	// Identical updates are received, but suppressed by
+	// the config hashing so no notifications are emitted.
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, appChange, events)
+
+	snapshot = controller.MetricsSnapshot()
+	c.Check(snapshot.ChangesReceived, gc.Equals, float64(2))
+	c.Check(snapshot.ChangesEmitted, gc.Equals, float64(0))
+
+	// A config change is emitted.
+	change := appChange
+	change.Config = map[string]interface{}{"key": "changed"}
+	s.ProcessChange(c, change, events)
+
+	snapshot = controller.MetricsSnapshot()
+	c.Check(snapshot.ChangesReceived, gc.Equals, float64(1))
+	c.Check(snapshot.ChangesEmitted, gc.Equals, float64(1))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestModelGaugesRemovedWithModel(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	s.Config.PrometheusRegisterer = registry
+	controller, events := s.New(c)
+
+	other := modelChange
+	other.ModelUUID = "other-model-uuid"
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, other, events)
+
+	for _, uuid := range []string{modelChange.ModelUUID, other.ModelUUID, other.ModelUUID} {
+		model, err := controller.Model(uuid)
+		c.Assert(err, jc.ErrorIsNil)
+		model.Config()
+	}
+
+	expected := bytes.NewBuffer([]byte(`
+# HELP juju_cache_model_config_reads The number of times the model config is read.
+# TYPE juju_cache_model_config_reads gauge
+juju_cache_model_config_reads{model_uuid="model-uuid"} 1
+juju_cache_model_config_reads{model_uuid="other-model-uuid"} 2
+		`[1:]))
+	err := testutil.GatherAndCompare(registry, expected, "juju_cache_model_config_reads")
+	c.Check(err, jc.ErrorIsNil)
+
+	// A removed model no longer has its gauges reported,
+	// but its activity is still included in the snapshot.
+	s.ProcessChange(c, cache.RemoveModel{ModelUUID: other.ModelUUID}, events)
+
+	expected = bytes.NewBuffer([]byte(`
+# HELP juju_cache_model_config_reads The number of times the model config is read.
+# TYPE juju_cache_model_config_reads gauge
+juju_cache_model_config_reads{model_uuid="model-uuid"} 1
+		`[1:]))
+	err = testutil.GatherAndCompare(registry, expected, "juju_cache_model_config_reads")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(controller.MetricsSnapshot().ModelConfigReads, gc.Equals, float64(3))
+
+	// The gauges are unregistered when the controller stops.
+	workertest.CleanKill(c, controller)
+	err = testutil.GatherAndCompare(registry, bytes.NewBuffer(nil), "juju_cache_model_config_reads")
+	c.Check(err, jc.ErrorIsNil)
+}
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/pubsub"
@@ -21,19 +22,28 @@ import (
 const (
 	// Model config has changed.
 	modelConfigChange = "model-config-change"
+	// Values in the model config have changed.
+	// The keys of the changed values are published.
+	modelConfigKeysChange = "model-config-keys-change"
 	// A machine has been added to, or removed from the model.
 	modelAddRemoveMachine = "model-add-remove-machine"
+	// An application has been added to, or removed from the model.
+	modelAddRemoveApplication = "model-add-remove-application"
 	// A unit has landed on a machine, or a subordinate unit has been changed,
 	// Either of which likely indicate the addition of a unit to the model.
 	modelUnitAdd = "model-unit-add"
 	// A unit has been removed from the model.
 	modelUnitRemove = "model-unit-remove"
+	// A unit has been assigned to a machine, or reassigned to another.
+	modelUnitAssignment = "model-unit-assignment"
 	// A branch has been removed from the model.
 	modelBranchRemove = "model-branch-remove"
 )
 
 type modelConfig struct {
 	initializing func() bool
+	degraded     func() bool
+	dying        <-chan struct{}
 	metrics      *ControllerGauges
 	hub          *pubsub.SimpleHub
 	chub         *pubsub.SimpleHub
@@ -43,6 +53,8 @@ type modelConfig struct {
 func newModel(config modelConfig) *Model {
 	m := &Model{
 		initializing:  config.initializing,
+		degraded:      config.degraded,
+		dying:         config.dying,
 		Resident:      config.res,
 		metrics:       config.metrics,
 		hub:           config.hub,
@@ -53,6 +65,11 @@ func newModel(config modelConfig) *Model {
 		units:         make(map[string]*Unit),
 		relations:     make(map[string]*Relation),
 		branches:      make(map[string]*Branch),
+		missingCharms: make(map[string]string),
+
+		evictedApplications: set.NewStrings(),
+		evictedMachines:     set.NewStrings(),
+		evictedUnits:        set.NewStrings(),
 	}
 	return m
 }
@@ -65,6 +82,8 @@ type Model struct {
 	*Resident
 
 	initializing  func() bool
+	degraded      func() bool
+	dying         <-chan struct{}
 	metrics       *ControllerGauges
 	hub           *pubsub.SimpleHub
 	controllerHub *pubsub.SimpleHub
@@ -83,6 +102,17 @@ type Model struct {
 	relations    map[string]*Relation
 	branches     map[string]*Branch
 
+	// missingCharms records applications that reference a charm
+	// removed from the cache, keyed by application name.
+	missingCharms map[string]string
+
+	// evictedApplications, evictedMachines and evictedUnits record the
+	// entities evicted to keep the cache within its configured size,
+	// which remain in the model until a change restores them.
+	evictedApplications set.Strings
+	evictedMachines     set.Strings
+	evictedUnits        set.Strings
+
 	// lastSummaryPublish is here for testing purposes to ensure
 	// synchronisation between the test and the handling of the
 	// published summary event. This channel is returned by the pubsub
@@ -154,6 +184,9 @@ func (m *Model) visibleTo(user string) bool {
 }
 
 // WatchConfig creates a watcher for the model config.
+// If keys are supplied, the watcher only notifies of changes to them.
+// Keys may be glob patterns, such as "logging-*", where '*' matches
+// any sequence of characters and '?' matches any single character.
 func (m *Model) WatchConfig(keys ...string) *ConfigWatcher {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -161,11 +194,21 @@ func (m *Model) WatchConfig(keys ...string) *ConfigWatcher {
 	return newConfigWatcher(keys, m.hashCache, m.hub, modelConfigChange, m.Resident)
 }
 
+// WatchConfigKeys creates a watcher for the model config that notifies
+// with the keys whose values have changed. Keys may be supplied as for
+// WatchConfig, to restrict the notifications to changes of interest.
+func (m *Model) WatchConfigKeys(keys ...string) *ConfigKeysWatcher {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return newConfigKeysWatcher(keys, m.details.Config, m.hub, modelConfigKeysChange, m.Resident)
+}
+
 // Report returns information that is used in the dependency engine report.
 func (m *Model) Report() map[string]interface{} {
 	defer m.doLocked()()
 
-	return map[string]interface{}{
+	report := map[string]interface{}{
 		"name":              m.details.Owner + "/" + m.details.Name,
 		"life":              m.details.Life,
 		"application-count": len(m.applications),
@@ -175,6 +218,14 @@ func (m *Model) Report() map[string]interface{} {
 		"relation-count":    len(m.relations),
 		"branch-count":      len(m.branches),
 	}
+	if len(m.missingCharms) > 0 {
+		missing := make(map[string]interface{}, len(m.missingCharms))
+		for appName, url := range m.missingCharms {
+			missing[appName] = url
+		}
+		report["applications-with-removed-charm"] = missing
+	}
+	return report
 }
 
 // Branches returns all active branches in the model.
@@ -210,6 +261,7 @@ func (m *Model) Branch(name string) (Branch, error) {
 }
 
 // Applications makes a copy of the model's application collection and returns it.
+// Applications evicted from the cache are not included; see Complete.
 func (m *Model) Applications() map[string]Application {
 	m.mu.Lock()
 
@@ -224,13 +276,19 @@ func (m *Model) Applications() map[string]Application {
 
 // Application returns the application for the input name.
 // If the application is not found, a NotFoundError is returned.
+// If it was evicted from the cache, an error satisfying IsEvicted
+// is returned.
 func (m *Model) Application(appName string) (Application, error) {
 	defer m.doLocked()()
 
 	app, found := m.applications[appName]
 	if !found {
+		if m.evictedApplications.Contains(appName) {
+			return Application{}, newEvictedError("application %q", appName)
+		}
 		return Application{}, errors.NotFoundf("application %q", appName)
 	}
+	app.touch()
 	return app.copy(), nil
 }
 
@@ -240,7 +298,8 @@ func (m *Model) Metrics() *ControllerGauges {
 	return m.metrics
 }
 
-// Units returns all units in the model.
+// Units returns all units in the model. Units evicted from the
+// cache are not included; see Complete.
 func (m *Model) Units() map[string]Unit {
 	m.mu.Lock()
 
@@ -270,17 +329,24 @@ func (m *Model) applicationUnits(appName string) []Unit {
 
 // Unit returns the unit with the input name.
 // If the unit is not found, a NotFoundError is returned.
+// If it was evicted from the cache, an error satisfying IsEvicted
+// is returned.
 func (m *Model) Unit(unitName string) (Unit, error) {
 	defer m.doLocked()()
 
 	unit, found := m.units[unitName]
 	if !found {
+		if m.evictedUnits.Contains(unitName) {
+			return Unit{}, newEvictedError("unit %q", unitName)
+		}
 		return Unit{}, errors.NotFoundf("unit %q", unitName)
 	}
+	unit.touch()
 	return unit.copy(), nil
 }
 
 // Machines makes a copy of the model's machine collection and returns it.
+// Machines evicted from the cache are not included; see Complete.
 func (m *Model) Machines() map[string]Machine {
 	m.mu.Lock()
 
@@ -295,13 +361,19 @@ func (m *Model) Machines() map[string]Machine {
 
 // Machine returns the machine with the input id.
 // If the machine is not found, a NotFoundError is returned.
+// If it was evicted from the cache, an error satisfying IsEvicted
+// is returned.
 func (m *Model) Machine(machineID string) (Machine, error) {
 	defer m.doLocked()()
 
 	machine, found := m.machines[machineID]
 	if !found {
+		if m.evictedMachines.Contains(machineID) {
+			return Machine{}, newEvictedError("machine %q", machineID)
+		}
 		return Machine{}, errors.NotFoundf("machine %q", machineID)
 	}
+	machine.touch()
 	return machine.copy(), nil
 }
 
@@ -317,10 +389,60 @@ func (m *Model) Charm(charmURL string) (Charm, error) {
 	return charm.copy(), nil
 }
 
+// WatchUnitAssignments returns a watcher that notifies with the machine
+// IDs of units as they are assigned or reassigned to machines.
+// The initial event contains the assignments of all units in the model
+// that are currently on a machine.
+func (m *Model) WatchUnitAssignments() *UnitAssignmentsWatcher {
+	defer m.doLocked()()
+
+	assignments := make(map[string]string)
+	for name, unit := range m.units {
+		if unit.details.MachineId != "" {
+			assignments[name] = unit.details.MachineId
+		}
+	}
+
+	w := newUnitAssignmentsWatcher(assignments)
+	deregister := m.registerObserver(w)
+	unsub := m.hub.Subscribe(modelUnitAssignment, w.changed)
+
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+
+	return w
+}
+
+// WatchMachinesOption customises the watcher returned by WatchMachines.
+type WatchMachinesOption func(*watchMachinesOptions)
+
+type watchMachinesOptions struct {
+	updatable bool
+}
+
+// WithUpdatablePredicate causes WatchMachines to return a watcher
+// whose predicate may be replaced by calling UpdatePredicate.
+// The replacement predicate is tested against all of the machines
+// in the model, including containers.
+func WithUpdatablePredicate() WatchMachinesOption {
+	return func(opts *watchMachinesOptions) {
+		opts.updatable = true
+	}
+}
+
 // WatchMachines returns a PredicateStringsWatcher to notify about
 // added and removed machines in the model.  The initial event contains
 // a slice of the current machine ids.  Containers are excluded.
-func (m *Model) WatchMachines() (*PredicateStringsWatcher, error) {
+func (m *Model) WatchMachines(options ...WatchMachinesOption) (*PredicateStringsWatcher, error) {
+	opts := &watchMachinesOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
 	defer m.doLocked()()
 
 	// Create a compiled regexp to match machines not containers.
@@ -339,7 +461,10 @@ func (m *Model) WatchMachines() (*PredicateStringsWatcher, error) {
 	}
 
 	w := newPredicateStringsWatcher(fn, machines...)
-	deregister := m.registerWorker(w)
+	if opts.updatable {
+		w.current = m.machineIds
+	}
+	deregister := m.registerObserver(w)
 	unsub := m.hub.Subscribe(modelAddRemoveMachine, w.changed)
 
 	w.tomb.Go(func() error {
@@ -352,6 +477,43 @@ func (m *Model) WatchMachines() (*PredicateStringsWatcher, error) {
 	return w, nil
 }
 
+// machineIds returns the IDs of all machines in the model.
+func (m *Model) machineIds() []string {
+	defer m.doLocked()()
+
+	ids := make([]string, 0, len(m.machines))
+	for id := range m.machines {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// WatchApplications returns a PredicateStringsWatcher to notify about
+// added and removed applications in the model. The initial event
+// contains a slice of the current application names.
+func (m *Model) WatchApplications() *PredicateStringsWatcher {
+	defer m.doLocked()()
+
+	// Gather initial slice of applications in this model.
+	applications := make([]string, 0, len(m.applications))
+	for name := range m.applications {
+		applications = append(applications, name)
+	}
+
+	w := newChangeWatcher(applications...)
+	deregister := m.registerObserver(w)
+	unsub := m.hub.Subscribe(modelAddRemoveApplication, w.changed)
+
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+
+	return w
+}
+
 // updateApplication adds or updates the application in the model.
 func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 	m.mu.Lock()
@@ -360,6 +522,8 @@ func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 	if !found {
 		app = newApplication(m, m.metrics, m.hub, rm.new())
 		m.applications[ch.Name] = app
+		m.evictedApplications.Remove(ch.Name)
+		m.hub.Publish(modelAddRemoveApplication, []string{ch.Name})
 	}
 	app.setDetails(ch)
 	m.updateSummary()
@@ -370,8 +534,10 @@ func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 func (m *Model) removeApplication(ch RemoveApplication) error {
 	defer m.doLocked()()
 
+	m.evictedApplications.Remove(ch.Name)
 	app, ok := m.applications[ch.Name]
 	if ok {
+		m.hub.Publish(modelAddRemoveApplication, []string{ch.Name})
 		if err := app.evict(); err != nil {
 			return errors.Trace(err)
 		}
@@ -381,6 +547,17 @@ func (m *Model) removeApplication(ch RemoveApplication) error {
 	return nil
 }
 
+// clearBranchTracking removes all references to the input application
+// from the model's branches. It is called after the application
+// has been removed, and assumes the model lock is held.
+func (m *Model) clearBranchTracking(appName string) {
+	for _, branch := range m.branches {
+		if branch.clearApplication(appName) {
+			logger.Debugf("cleared tracking of removed application %q from branch %q", appName, branch.Name())
+		}
+	}
+}
+
 // updateCharm adds or updates the charm in the model.
 func (m *Model) updateCharm(ch CharmChange, rm *residentManager) {
 	m.mu.Lock()
@@ -409,6 +586,30 @@ func (m *Model) removeCharm(ch RemoveCharm) error {
 	return nil
 }
 
+// flagMissingCharm records any applications still referencing the input
+// charm URL, which has been removed from the cache.
+// It assumes the model lock is held.
+func (m *Model) flagMissingCharm(charmURL string) {
+	for appName, app := range m.applications {
+		if app.details.CharmURL != charmURL {
+			continue
+		}
+		if _, ok := m.missingCharms[appName]; ok {
+			continue
+		}
+		logger.Warningf("charm %q removed while still in use by application %q", charmURL, appName)
+		m.missingCharms[appName] = charmURL
+		m.metrics.InvariantViolations.Inc()
+	}
+}
+
+// resolveMissingCharm drops any record of the input
+// application referencing a removed charm.
+// It assumes the model lock is held.
+func (m *Model) resolveMissingCharm(appName string) {
+	delete(m.missingCharms, appName)
+}
+
 // updateUnit adds or updates the unit in the model.
 func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 	m.mu.Lock()
@@ -417,6 +618,7 @@ func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 	if !found {
 		unit = newUnit(m, rm.new())
 		m.units[ch.Name] = unit
+		m.evictedUnits.Remove(ch.Name)
 	}
 	unit.setDetails(ch)
 
@@ -428,6 +630,7 @@ func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 func (m *Model) removeUnit(ch RemoveUnit) error {
 	defer m.doLocked()()
 
+	m.evictedUnits.Remove(ch.Name)
 	unit, ok := m.units[ch.Name]
 	if ok {
 		m.hub.Publish(modelUnitRemove, unit.copy())
@@ -501,6 +704,7 @@ func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 	if !found {
 		machine = newMachine(m, rm.new())
 		m.machines[ch.Id] = machine
+		m.evictedMachines.Remove(ch.Id)
 		m.hub.Publish(modelAddRemoveMachine, []string{ch.Id})
 	}
 	machine.setDetails(ch)
@@ -513,6 +717,7 @@ func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 func (m *Model) removeMachine(ch RemoveMachine) error {
 	defer m.doLocked()()
 
+	m.evictedMachines.Remove(ch.Id)
 	machine, ok := m.machines[ch.Id]
 	if ok {
 		m.hub.Publish(modelAddRemoveMachine, []string{ch.Id})
@@ -563,14 +768,21 @@ func (m *Model) setDetails(details ModelChange) {
 	m.setRemovalMessage(RemoveModel{
 		ModelUUID: details.ModelUUID,
 	})
+	changedKeys := changedConfigKeys(m.details.Config, details.Config)
 	m.details = details
 
-	hashCache, configHash := newHashCache(details.Config, m.metrics.ModelHashCacheHit, m.metrics.ModelHashCacheMiss)
-	if configHash != m.configHash {
+	hashCache, configHash := newHashCache(
+		details.Config, m.dying, m.metrics.ModelHashCacheHit, m.metrics.ModelHashCacheMiss)
+	m.metrics.ChangesReceived.Inc()
+	if configHash != "" && configHash != m.configHash {
 		m.configHash = configHash
 		m.hashCache = hashCache
 		m.hashCache.incMisses()
 		m.hub.Publish(modelConfigChange, hashCache)
+		m.metrics.ChangesEmitted.Inc()
+	}
+	if len(changedKeys) > 0 {
+		m.hub.Publish(modelConfigKeysChange, changedKeys)
 	}
 
 	m.updateSummary()
@@ -591,6 +803,10 @@ func unitChangeTopic(source string) string {
 	return "unit-change." + source
 }
 
+func machineChangeTopic(source string) string {
+	return "machine-change." + source
+}
+
 // WaitForUnit is the second attempt at providing a genericish way to wait for
 // the cache to be updated. The method subscribes to the hub with the topic
 // "unit-change.<source>". The expected payload is a *Unit. The method
@@ -599,9 +815,48 @@ func unitChangeTopic(source string) string {
 // signalled. This method is not responsible for checking the current value, it
 // only deals with changes.
 func (m *Model) WaitForUnit(name string, predicate func(*Unit) bool, cancel <-chan struct{}) <-chan struct{} {
+	return m.waitForChange(unitChangeTopic(name), func(payload interface{}) bool {
+		unit, ok := payload.(*Unit)
+		if !ok {
+			logger.Criticalf("programming error, payload type incorrect %T", payload)
+			return false
+		}
+		return predicate(unit)
+	}, func() (interface{}, bool) {
+		unit, err := m.Unit(name)
+		return &unit, err == nil
+	}, cancel)
+}
+
+// WaitForMachine works in the same way as WaitForUnit, closing the result
+// channel when the machine with the input ID satisfies the predicate, or the
+// cancel channel is signalled. A predicate that always returns true can be
+// used to wait for a machine that has just been added to appear in the cache.
+func (m *Model) WaitForMachine(id string, predicate func(*Machine) bool, cancel <-chan struct{}) <-chan struct{} {
+	return m.waitForChange(machineChangeTopic(id), func(payload interface{}) bool {
+		machine, ok := payload.(*Machine)
+		if !ok {
+			logger.Criticalf("programming error, payload type incorrect %T", payload)
+			return false
+		}
+		return predicate(machine)
+	}, func() (interface{}, bool) {
+		machine, err := m.Machine(id)
+		return &machine, err == nil
+	}, cancel)
+}
+
+// waitForChange returns a channel that is closed when the payload of a
+// message published to the input topic satisfies the predicate, or when
+// the cancel channel is signalled. The current value, if found, is also
+// checked against the predicate after subscribing, so that a value already
+// satisfying it is not missed.
+func (m *Model) waitForChange(
+	topic string, predicate func(interface{}) bool, current func() (interface{}, bool), cancel <-chan struct{},
+) <-chan struct{} {
 	result := make(chan struct{})
 
-	wait := &waitUnitChange{
+	wait := &waitChange{
 		predicate: predicate,
 		done:      result,
 	}
@@ -609,13 +864,13 @@ func (m *Model) WaitForUnit(name string, predicate func(*Unit) bool, cancel <-ch
 	wait.mu.Lock()
 	// The closure that is created below captures the unsub function pointer by reference
 	// allowing the function closure to unsubscribe from the hub.
-	wait.unsub = m.hub.Subscribe(unitChangeTopic(name), wait.onChange)
+	wait.unsub = m.hub.Subscribe(topic, wait.onChange)
 	wait.mu.Unlock()
 	go wait.loop(cancel)
 
 	// Do the check now, just in case we are already good.
-	if unit, err := m.Unit(name); err == nil {
-		if predicate(&unit) {
+	if value, found := current(); found {
+		if predicate(value) {
 			wait.mu.Lock()
 			wait.close()
 			wait.mu.Unlock()
@@ -625,27 +880,22 @@ func (m *Model) WaitForUnit(name string, predicate func(*Unit) bool, cancel <-ch
 	return result
 }
 
-type waitUnitChange struct {
+type waitChange struct {
 	mu        sync.Mutex
 	unsub     func()
-	predicate func(*Unit) bool
+	predicate func(interface{}) bool
 	done      chan struct{}
 }
 
-func (w *waitUnitChange) onChange(_ string, payload interface{}) {
+func (w *waitChange) onChange(_ string, payload interface{}) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
-	unit, ok := payload.(*Unit)
-	if !ok {
-		logger.Criticalf("programming error, payload type incorrect %T", payload)
-		return
-	}
-	if w.predicate(unit) {
+	if w.predicate(payload) {
 		w.close()
 	}
 }
 
-func (w *waitUnitChange) loop(cancel <-chan struct{}) {
+func (w *waitChange) loop(cancel <-chan struct{}) {
 	select {
 	case <-cancel:
 		w.mu.Lock()
@@ -659,7 +909,7 @@ func (w *waitUnitChange) loop(cancel <-chan struct{}) {
 // close unsubscribes and closes the done channel.
 // Due to the race potentials, both are checked prior to action.
 // The mutex is acquired outside this method.
-func (w *waitUnitChange) close() {
+func (w *waitChange) close() {
 	if w.unsub != nil {
 		w.unsub()
 		w.unsub = nil
@@ -702,6 +952,16 @@ func (m *Model) updateSummary() {
 		}
 	}
 
+	// Evicted entities are still in the model. Only those without
+	// errors or blocked workloads are evicted, so they add no messages.
+	for _, id := range m.evictedMachines.Values() {
+		if names.IsContainerMachine(id) {
+			containers++
+		} else {
+			machines++
+		}
+	}
+
 	for id, unit := range m.units {
 		if st := unit.details.AgentStatus; st.Status == status.Error {
 			overallStatus = StatusRed
@@ -744,8 +1004,8 @@ func (m *Model) updateSummary() {
 
 		MachineCount:     machines,
 		ContainerCount:   containers,
-		ApplicationCount: len(m.applications),
-		UnitCount:        len(m.units),
+		ApplicationCount: len(m.applications) + len(m.evictedApplications),
+		UnitCount:        len(m.units) + len(m.evictedUnits),
 		RelationCount:    len(m.relations),
 	}
 	m.summary = summary
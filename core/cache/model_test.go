@@ -164,6 +164,161 @@ func (s *ModelSuite) TestConfigWatcherSameValuesCacheHit(c *gc.C) {
 	c.Check(testutil.ToFloat64(s.Gauges.ModelHashCacheHit), gc.Equals, float64(1))
 }
 
+func (s *ModelSuite) TestConfigWatcherGlob(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchConfig("logging-*")
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// Changes to keys not matching the pattern cause no notification.
+	change := modelChange
+	change.Config = map[string]interface{}{
+		"key":             "changed",
+		"another":         "foo",
+		"not-logging-foo": "bar",
+	}
+	m.SetDetails(change)
+	wc.AssertNoChange()
+
+	change.Config = map[string]interface{}{
+		"key":             "changed",
+		"another":         "foo",
+		"logging-config":  "<root>=INFO",
+		"not-logging-foo": "bar",
+	}
+	m.SetDetails(change)
+	wc.AssertOneChange()
+}
+
+func (s *ModelSuite) TestConfigWatcherGlobSingleCharacter(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchConfig("ke?")
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	change := modelChange
+	change.Config = map[string]interface{}{
+		"key":     "value",
+		"another": "foo",
+		"keys":    "added",
+	}
+	m.SetDetails(change)
+	wc.AssertNoChange()
+
+	change.Config["key"] = "changed"
+	m.SetDetails(change)
+	wc.AssertOneChange()
+}
+
+func (s *ModelSuite) TestConfigWatcherSamePatternsCacheHit(c *gc.C) {
+	m := s.NewModel(modelChange)
+
+	w := m.WatchConfig("key", "an*")
+	defer workertest.CleanKill(c, w)
+
+	w2 := m.WatchConfig("an*", "key")
+	defer workertest.CleanKill(c, w2)
+
+	// One cache miss for the "all" hash, and one for the keys and patterns.
+	c.Check(testutil.ToFloat64(s.Gauges.ModelHashCacheMiss), gc.Equals, float64(2))
+
+	// The hash should get a hit despite the ordering.
+	c.Check(testutil.ToFloat64(s.Gauges.ModelHashCacheHit), gc.Equals, float64(1))
+}
+
+func (s *ModelSuite) TestConfigKeysWatcherStops(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchConfigKeys()
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{"another", "key"})
+	wc.AssertStops()
+}
+
+func (s *ModelSuite) TestConfigKeysWatcherChange(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchConfigKeys()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{"another", "key"})
+
+	change := modelChange
+	change.Config = map[string]interface{}{
+		"key":     "changed",
+		"another": "foo",
+		"new":     "added",
+	}
+	m.SetDetails(change)
+	wc.AssertOneChange([]string{"key", "new"})
+
+	// Removed keys are notified.
+	change.Config = map[string]interface{}{
+		"key": "changed",
+		"new": "added",
+	}
+	m.SetDetails(change)
+	wc.AssertOneChange([]string{"another"})
+
+	// Setting the same values causes no notification.
+	m.SetDetails(change)
+	wc.AssertNoChange()
+}
+
+func (s *ModelSuite) TestConfigKeysWatcherKeys(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchConfigKeys("key", "logging-*")
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{"key"})
+
+	change := modelChange
+	change.Config = map[string]interface{}{
+		"key":     "value",
+		"another": "changed",
+	}
+	m.SetDetails(change)
+	wc.AssertNoChange()
+
+	change.Config = map[string]interface{}{
+		"key":            "changed",
+		"another":        "changed-again",
+		"logging-config": "<root>=INFO",
+	}
+	m.SetDetails(change)
+	wc.AssertOneChange([]string{"key", "logging-config"})
+}
+
+func (s *ModelSuite) TestConfigKeysWatcherCombinesChanges(c *gc.C) {
+	m := s.NewModel(modelChange)
+	w := m.WatchConfigKeys()
+	defer workertest.CleanKill(c, w)
+	wc := cache.NewStringsWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange([]string{"another", "key"})
+
+	change := modelChange
+	change.Config = map[string]interface{}{
+		"key":     "changed",
+		"another": "foo",
+	}
+	m.SetDetails(change)
+
+	change.Config = map[string]interface{}{
+		"key":     "changed",
+		"another": "changed",
+	}
+	m.SetDetails(change)
+
+	// Changes not yet received are combined.
+	wc.AssertMaybeCombinedChanges([]string{"another", "key"})
+}
+
 func (s *ModelSuite) TestApplicationNotFoundError(c *gc.C) {
 	m := s.NewModel(modelChange)
 	_, err := m.Application("nope")
@@ -295,6 +450,19 @@ func (s *ModelSuite) TestBranchReturnsCopy(c *gc.C) {
 	c.Assert(b2.AssignedUnits(), gc.DeepEquals, branchChange.AssignedUnits)
 }
 
+func (s *ModelSuite) TestBranchUnitUntracked(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateBranch(branchChange, s.Manager)
+
+	change := branchChange
+	change.AssignedUnits = map[string][]string{"redis": {"redis/1"}}
+	m.UpdateBranch(change, s.Manager)
+
+	b, err := m.Branch(branchChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(b.AssignedUnits(), gc.DeepEquals, map[string][]string{"redis": {"redis/1"}})
+}
+
 func (s *ModelSuite) TestRemoveBranchPublishesName(c *gc.C) {
 	m := s.NewModel(modelChange)
 	m.UpdateBranch(branchChange, s.Manager)
@@ -354,6 +522,88 @@ func (s *ModelSuite) TestWaitForUnitExistingValue(c *gc.C) {
 	}
 }
 
+func (s *ModelSuite) TestWaitForMachineNewChange(c *gc.C) {
+	m := s.NewModel(modelChange)
+	done := m.WaitForMachine(machineChange.Id, func(*cache.Machine) bool { return true }, nil)
+
+	m.UpdateMachine(machineChange, s.Manager)
+
+	select {
+	case <-done:
+		// All good.
+	case <-time.After(testing.LongWait):
+		c.Errorf("change not noticed")
+	}
+}
+
+func (s *ModelSuite) TestWaitForMachineExistingValue(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateMachine(machineChange, s.Manager)
+
+	done := m.WaitForMachine(machineChange.Id, func(machine *cache.Machine) bool {
+		return machine.Id() == machineChange.Id
+	}, nil)
+
+	select {
+	case <-done:
+		// All good.
+	case <-time.After(testing.LongWait):
+		c.Errorf("change not noticed")
+	}
+}
+
+func (s *ModelSuite) TestWaitForMachinePredicate(c *gc.C) {
+	m := s.NewModel(modelChange)
+	change := machineChange
+	change.InstanceId = ""
+	m.UpdateMachine(change, s.Manager)
+
+	done := m.WaitForMachine(machineChange.Id, func(machine *cache.Machine) bool {
+		_, err := machine.InstanceId()
+		return err == nil
+	}, nil)
+
+	// Other machines are not considered.
+	other := machineChange
+	other.Id = "1"
+	m.UpdateMachine(other, s.Manager)
+	select {
+	case <-done:
+		c.Fatalf("change signalled")
+	case <-time.After(testing.ShortWait):
+	}
+
+	m.UpdateMachine(machineChange, s.Manager)
+	select {
+	case <-done:
+		// All good.
+	case <-time.After(testing.LongWait):
+		c.Errorf("change not noticed")
+	}
+}
+
+func (s *ModelSuite) TestWaitForMachineCancelClosesChannel(c *gc.C) {
+	m := s.NewModel(modelChange)
+	cancel := make(chan struct{})
+	done := m.WaitForMachine("anything", func(*cache.Machine) bool { return false }, cancel)
+
+	select {
+	case <-done:
+		c.Errorf("change signalled")
+	default:
+		// All good.
+	}
+
+	close(cancel)
+
+	select {
+	case <-done:
+		// All good.
+	case <-time.After(testing.LongWait):
+		c.Errorf("done channel not closed")
+	}
+}
+
 func (s *ModelSuite) TestWaitForUnitCancelClosesChannel(c *gc.C) {
 	m := s.NewModel(modelChange)
 	cancel := make(chan struct{})
@@ -395,3 +645,41 @@ var modelChange = cache.ModelChange{
 		"read-user":   permission.ReadAccess,
 	},
 }
+
+func (s *ModelSuite) TestWatchUnitAssignments(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	w := m.WatchUnitAssignments()
+	defer workertest.CleanKill(c, w)
+
+	// The initial event contains the current assignments.
+	select {
+	case change := <-w.Changes():
+		c.Check(change, jc.DeepEquals, map[string]string{unitChange.Name: "0"})
+	case <-time.After(testing.LongWait):
+		c.Fatalf("no initial event")
+	}
+
+	// Changes not affecting assignment do not notify.
+	change := unitChange
+	change.WorkloadStatus = status.StatusInfo{Status: status.Blocked}
+	m.UpdateUnit(change, s.Manager)
+
+	// Reassigning the unit produces one event with the new machine.
+	change.MachineId = "1"
+	m.UpdateUnit(change, s.Manager)
+
+	select {
+	case change := <-w.Changes():
+		c.Check(change, jc.DeepEquals, map[string]string{unitChange.Name: "1"})
+	case <-time.After(testing.LongWait):
+		c.Fatalf("no change event")
+	}
+
+	select {
+	case change := <-w.Changes():
+		c.Fatalf("unexpected change: %v", change)
+	case <-time.After(testing.ShortWait):
+	}
+}
@@ -147,13 +147,14 @@ type EntitySuite struct {
 func (s *EntitySuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
-	s.Gauges = createControllerGauges()
+	s.Gauges = createControllerGaugeVecs().forModel("model-uuid")
 	s.Hub = s.NewHub()
 }
 
 func (s *EntitySuite) NewModel(details ModelChange) *Model {
 	m := newModel(modelConfig{
 		initializing: func() bool { return false },
+		degraded:     func() bool { return false },
 		metrics:      s.Gauges,
 		hub:          s.Hub,
 		chub:         s.NewHub(),
@@ -3,6 +3,10 @@
 
 package cache
 
+import (
+	"github.com/juju/juju/core/life"
+)
+
 // Relation represents a relation in a cached model.
 type Relation struct {
 	// Resident identifies the relation as a type-agnostic cached entity
@@ -29,19 +33,30 @@ func (r *Relation) Key() string {
 	return r.details.Key
 }
 
+// Id returns the ID of this relation.
+func (r *Relation) Id() int {
+	return r.details.Id
+}
+
 // Endpoints returns the endpoints for this relation.
 func (r *Relation) Endpoints() []Endpoint {
 	return r.details.Endpoints
 }
 
+// Life returns the current life of this relation.
+func (r *Relation) Life() life.Value {
+	return r.details.Life
+}
+
 func (r *Relation) setDetails(details RelationChange) {
 	r.setRemovalMessage(RemoveRelation{
 		ModelUUID: details.ModelUUID,
 		Key:       details.Key,
 	})
+	r.details = details
 }
 
-// copy returns a copy of the unit, ensuring appropriate deep copying.
+// copy returns a copy of the relation, ensuring appropriate deep copying.
 func (r *Relation) copy() Relation {
 	cr := *r
 	cr.details = cr.details.copy()
@@ -4,14 +4,38 @@
 package cache_test
 
 import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/life"
 )
 
-// Mostly a placeholder file at this stage.
+func (s *ControllerSuite) TestRelationCopyIsDeep(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, relationChange, events)
+
+	mod, err := controller.Model(relationChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := mod.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Modifying the endpoints of one copy does not affect another.
+	relation.Endpoints()[0].Name = "changed"
+	relation, err = mod.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(relation.Endpoints()[0].Name, gc.Equals, "ep")
+
+	relations := mod.Relations()
+	c.Assert(relations, gc.HasLen, 1)
+	c.Check(relations[relationChange.Key].Endpoints(), jc.DeepEquals, relationChange.Endpoints)
+}
 
 var relationChange = cache.RelationChange{
 	ModelUUID: "model-uuid",
 	Key:       "provider:ep consumer:ep",
+	Id:        1,
+	Life:      life.Alive,
 	Endpoints: []cache.Endpoint{
 		{
 			Application: "provider",
@@ -48,6 +48,7 @@ func (c *counter) last() uint64 {
 type residentManager struct {
 	residentCount *counter
 	resourceCount *counter
+	accessCount   *counter
 
 	// residents are all the residents of the cache indexed by ID.
 	// Access to this map should be protected by the Mutex below.
@@ -71,10 +72,12 @@ type residentManager struct {
 func newResidentManager(removals chan<- interface{}) *residentManager {
 	residentC := counter(0)
 	resourceC := counter(0)
+	accessC := counter(0)
 
 	return &residentManager{
 		residentCount: &residentC,
 		resourceCount: &resourceC,
+		accessCount:   &accessC,
 		residents:     make(map[uint64]*Resident),
 		removals:      removals,
 	}
@@ -89,8 +92,11 @@ func (m *residentManager) new() *Resident {
 		id:             id,
 		deregister:     func() { m.deregister(id) },
 		nextResourceId: func() uint64 { return m.resourceCount.next() },
+		nextAccess:     func() uint64 { return m.accessCount.next() },
 		workers:        make(map[uint64]worker.Worker),
+		observers:      make(map[uint64]bool),
 	}
+	r.touch()
 
 	m.mu.Lock()
 	m.residents[r.id] = r
@@ -169,6 +175,29 @@ func (m *residentManager) evictions() ([]uint64, map[uint64]interface{}) {
 	return removalIds, removalMessages
 }
 
+// count returns the number of residents in the cache.
+func (m *residentManager) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.residents)
+}
+
+// leastRecentlyAccessed returns all of the cache residents
+// in ascending order of when they were last accessed.
+func (m *residentManager) leastRecentlyAccessed() []*Resident {
+	m.mu.Lock()
+	residents := make([]*Resident, 0, len(m.residents))
+	for _, r := range m.residents {
+		residents = append(residents, r)
+	}
+	m.mu.Unlock()
+
+	sort.Slice(residents, func(i, j int) bool {
+		return residents[i].lastAccessed() < residents[j].lastAccessed()
+	})
+	return residents
+}
+
 func (m *residentManager) deregister(id uint64) {
 	m.mu.Lock()
 	delete(m.residents, id)
@@ -193,6 +222,12 @@ type Resident struct {
 	// that were supplied by the same resident manager.
 	id uint64
 
+	// accessed orders this resident among all those supplied by the same
+	// resident manager by when it was last accessed. It is used to select
+	// residents for eviction when the cache exceeds its configured size.
+	// Access to this value should be atomic.
+	accessed uint64
+
 	// stale indicates that this cache resident is stale
 	// and is a candidate for removal.
 	stale bool
@@ -203,6 +238,9 @@ type Resident struct {
 	// nextResourceId is a factory method for acquiring unique resource IDs.
 	nextResourceId func() uint64
 
+	// nextAccess is a factory method for acquiring access sequence numbers.
+	nextAccess func() uint64
+
 	// removalMessage is a message that will be recognised by the cached
 	// controller, for removing the resident's specific type from the cache.
 	// See changes.go for the types of messages.
@@ -213,7 +251,15 @@ type Resident struct {
 	// Obvious examples are watchers created by the resident.
 	// Access to this map should be protected with the Mutex below.
 	workers map[uint64]worker.Worker
-	mu      sync.Mutex
+
+	// observers identifies those workers that watch the entities belonging
+	// to this resident, such as the machines of a model, rather than the
+	// resident itself. Such entities are not evicted to keep the cache
+	// within its configured size while they are being watched.
+	// Access to this map should be protected with the Mutex below.
+	observers map[uint64]bool
+
+	mu sync.Mutex
 }
 
 // CacheId returns the unique ID for this cache resident.
@@ -221,6 +267,17 @@ func (r *Resident) CacheId() uint64 {
 	return r.id
 }
 
+// touch records that the resident has just been accessed.
+func (r *Resident) touch() {
+	atomic.StoreUint64(&r.accessed, r.nextAccess())
+}
+
+// lastAccessed returns the sequence number
+// of the last access to this resident.
+func (r *Resident) lastAccessed() uint64 {
+	return atomic.LoadUint64(&r.accessed)
+}
+
 // registerWorker is used to indicate that the input worker needs to be stopped
 // when this resident is evicted from the cache.
 // The deregistration method is returned.
@@ -234,6 +291,35 @@ func (r *Resident) registerWorker(w worker.Worker) func() {
 	return func() { r.deregisterWorker(id) }
 }
 
+// registerObserver is used to indicate that the input worker watches
+// entities belonging to this resident. As for registerWorker, the
+// worker is stopped when this resident is evicted from the cache,
+// and the deregistration method is returned.
+func (r *Resident) registerObserver(w worker.Worker) func() {
+	id := r.nextResourceId()
+	r.mu.Lock()
+	r.workers[id] = w
+	r.observers[id] = true
+	r.mu.Unlock()
+	return func() { r.deregisterWorker(id) }
+}
+
+// hasObservers returns true if there are workers registered with this
+// resident that watch the entities belonging to it.
+func (r *Resident) hasObservers() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.observers) > 0
+}
+
+// hasWorkers returns true if there are workers registered with this
+// resident, such as watchers that have not yet been stopped.
+func (r *Resident) hasWorkers() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.workers) > 0
+}
+
 // evict cleans up any resources created by this resident,
 // then deregisters it.
 func (r *Resident) evict() error {
@@ -274,6 +360,7 @@ func (r *Resident) cleanupWorkers() error {
 func (r *Resident) deregisterWorker(id uint64) {
 	r.mu.Lock()
 	delete(r.workers, id)
+	delete(r.observers, id)
 	r.mu.Unlock()
 }
 
@@ -283,6 +370,14 @@ func (r *Resident) setStale(stale bool) {
 	r.mu.Unlock()
 }
 
+// removal returns the message that removes this resident from the cache,
+// or nil if it has not yet received its details.
+func (r *Resident) removal() interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.removalMessage
+}
+
 func (r *Resident) setRemovalMessage(msg interface{}) bool {
 	// If this is the first receipt of details, set the removal message.
 	r.mu.Lock()
@@ -0,0 +1,161 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+)
+
+// The types below describe the JSON document returned by
+// Controller.Snapshot. Maps are used for collections of entities
+// so that the document is stable, with keys sorted when serialised.
+
+type controllerSnapshot struct {
+	Models map[string]modelSnapshot `json:"models"`
+}
+
+type modelSnapshot struct {
+	Name         string                         `json:"name"`
+	Type         model.ModelType                `json:"type"`
+	Owner        string                         `json:"owner"`
+	Life         life.Value                     `json:"life"`
+	Status       statusSnapshot                 `json:"status"`
+	ConfigHash   string                         `json:"config-hash,omitempty"`
+	Applications map[string]applicationSnapshot `json:"applications"`
+	Machines     map[string]machineSnapshot     `json:"machines"`
+	Units        map[string]unitSnapshot        `json:"units"`
+	Branches     map[string]branchSnapshot      `json:"branches"`
+}
+
+type applicationSnapshot struct {
+	CharmURL   string         `json:"charm-url"`
+	Life       life.Value     `json:"life"`
+	Exposed    bool           `json:"exposed,omitempty"`
+	Status     statusSnapshot `json:"status"`
+	ConfigHash string         `json:"config-hash,omitempty"`
+}
+
+type machineSnapshot struct {
+	InstanceId     string         `json:"instance-id,omitempty"`
+	Life           life.Value     `json:"life"`
+	AgentStatus    statusSnapshot `json:"agent-status"`
+	InstanceStatus statusSnapshot `json:"instance-status"`
+	ConfigHash     string         `json:"config-hash,omitempty"`
+}
+
+type unitSnapshot struct {
+	Application    string         `json:"application"`
+	MachineId      string         `json:"machine-id,omitempty"`
+	Life           life.Value     `json:"life"`
+	WorkloadStatus statusSnapshot `json:"workload-status"`
+	AgentStatus    statusSnapshot `json:"agent-status"`
+}
+
+type branchSnapshot struct {
+	Name          string              `json:"name"`
+	AssignedUnits map[string][]string `json:"assigned-units,omitempty"`
+}
+
+type statusSnapshot struct {
+	Status  status.Status `json:"status,omitempty"`
+	Message string        `json:"message,omitempty"`
+}
+
+// Snapshot returns a JSON document describing the models in the cache,
+// along with their applications, machines, units and branches.
+// It is intended for introspection of a running controller.
+// The details of each model's entities are copied with the model locked,
+// so the locks are not held while the document is serialised.
+func (c *Controller) Snapshot() ([]byte, error) {
+	c.modelsMu.Lock()
+	models := make(map[string]*Model, len(c.models))
+	for uuid, m := range c.models {
+		models[uuid] = m
+	}
+	c.modelsMu.Unlock()
+
+	snapshot := controllerSnapshot{
+		Models: make(map[string]modelSnapshot, len(models)),
+	}
+	for uuid, m := range models {
+		snapshot.Models[uuid] = m.snapshot()
+	}
+	data, err := json.Marshal(snapshot)
+	return data, errors.Trace(err)
+}
+
+// snapshot returns a copy of the details of the
+// model and its entities for Controller.Snapshot.
+func (m *Model) snapshot() modelSnapshot {
+	defer m.doLocked()()
+
+	snapshot := modelSnapshot{
+		Name:         m.details.Name,
+		Type:         m.details.Type,
+		Owner:        m.details.Owner,
+		Life:         m.details.Life,
+		Status:       newStatusSnapshot(m.details.Status),
+		ConfigHash:   m.configHash,
+		Applications: make(map[string]applicationSnapshot, len(m.applications)),
+		Machines:     make(map[string]machineSnapshot, len(m.machines)),
+		Units:        make(map[string]unitSnapshot, len(m.units)),
+		Branches:     make(map[string]branchSnapshot, len(m.branches)),
+	}
+	for name, app := range m.applications {
+		snapshot.Applications[name] = app.snapshot()
+	}
+	for id, machine := range m.machines {
+		snapshot.Machines[id] = machineSnapshot{
+			InstanceId:     machine.details.InstanceId,
+			Life:           machine.details.Life,
+			AgentStatus:    newStatusSnapshot(machine.details.AgentStatus),
+			InstanceStatus: newStatusSnapshot(machine.details.InstanceStatus),
+			ConfigHash:     machine.configHash,
+		}
+	}
+	for name, unit := range m.units {
+		snapshot.Units[name] = unitSnapshot{
+			Application:    unit.details.Application,
+			MachineId:      unit.details.MachineId,
+			Life:           unit.details.Life,
+			WorkloadStatus: newStatusSnapshot(unit.details.WorkloadStatus),
+			AgentStatus:    newStatusSnapshot(unit.details.AgentStatus),
+		}
+	}
+	for id, branch := range m.branches {
+		snapshot.Branches[id] = branchSnapshot{
+			Name:          branch.details.Name,
+			AssignedUnits: branch.details.copy().AssignedUnits,
+		}
+	}
+	return snapshot
+}
+
+// snapshot returns a copy of the details of
+// the application for Controller.Snapshot.
+func (a *Application) snapshot() applicationSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return applicationSnapshot{
+		CharmURL:   a.details.CharmURL,
+		Life:       a.details.Life,
+		Exposed:    a.details.Exposed,
+		Status:     newStatusSnapshot(a.details.Status),
+		ConfigHash: a.configHash,
+	}
+}
+
+func newStatusSnapshot(info status.StatusInfo) statusSnapshot {
+	return statusSnapshot{
+		Status:  info.Status,
+		Message: info.Message,
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	"encoding/json"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+)
+
+func (s *ControllerSuite) TestSnapshot(c *gc.C) {
+	controller, events := s.New(c)
+	s.ProcessChange(c, modelChange, events)
+	s.ProcessChange(c, appChange, events)
+	s.ProcessChange(c, machineChange, events)
+	s.ProcessChange(c, unitChange, events)
+	s.ProcessChange(c, branchChange, events)
+
+	data, err := controller.Snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var snapshot struct {
+		Models map[string]struct {
+			Name         string                            `json:"name"`
+			Owner        string                            `json:"owner"`
+			Life         string                            `json:"life"`
+			Status       map[string]string                 `json:"status"`
+			ConfigHash   string                            `json:"config-hash"`
+			Applications map[string]map[string]interface{} `json:"applications"`
+			Machines     map[string]map[string]interface{} `json:"machines"`
+			Units        map[string]map[string]interface{} `json:"units"`
+			Branches     map[string]map[string]interface{} `json:"branches"`
+		} `json:"models"`
+	}
+	c.Assert(json.Unmarshal(data, &snapshot), jc.ErrorIsNil)
+	c.Assert(snapshot.Models, gc.HasLen, 1)
+
+	mod := snapshot.Models[modelChange.ModelUUID]
+	c.Check(mod.Name, gc.Equals, modelChange.Name)
+	c.Check(mod.Owner, gc.Equals, modelChange.Owner)
+	c.Check(mod.Life, gc.Equals, "alive")
+	c.Check(mod.Status, jc.DeepEquals, map[string]string{"status": "active"})
+	c.Check(mod.ConfigHash, gc.Not(gc.Equals), "")
+
+	app := mod.Applications[appChange.Name]
+	c.Check(app["charm-url"], gc.Equals, appChange.CharmURL)
+	c.Check(app["life"], gc.Equals, "alive")
+	c.Check(app["config-hash"], gc.Not(gc.Equals), "")
+
+	machine := mod.Machines[machineChange.Id]
+	c.Check(machine["instance-id"], gc.Equals, machineChange.InstanceId)
+	c.Check(machine["agent-status"], jc.DeepEquals, map[string]interface{}{"status": "active"})
+	c.Check(machine["config-hash"], gc.Not(gc.Equals), "")
+
+	unit := mod.Units[unitChange.Name]
+	c.Check(unit["application"], gc.Equals, unitChange.Application)
+	c.Check(unit["machine-id"], gc.Equals, unitChange.MachineId)
+	c.Check(unit["workload-status"], jc.DeepEquals, map[string]interface{}{"status": "active"})
+
+	branch := mod.Branches[branchChange.Id]
+	c.Check(branch["name"], gc.Equals, branchChange.Name)
+	c.Check(branch["assigned-units"], jc.DeepEquals, map[string]interface{}{
+		"redis": []interface{}{"redis/0", "redis/1"},
+	})
+
+	// The document is stable for unchanged residents.
+	again, err := controller.Snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(again), gc.Equals, string(data))
+
+	workertest.CleanKill(c, controller)
+}
+
+func (s *ControllerSuite) TestSnapshotEmpty(c *gc.C) {
+	controller, _ := s.New(c)
+
+	data, err := controller.Snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, `{"models":{}}`)
+
+	workertest.CleanKill(c, controller)
+}
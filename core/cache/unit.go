@@ -5,6 +5,7 @@ package cache
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/charm/v9"
 	"github.com/juju/collections/set"
@@ -17,6 +18,16 @@ import (
 	"github.com/juju/juju/core/status"
 )
 
+const (
+	// unitCloudContainerChange is the topic suffix used to publish
+	// changes to a unit's cloud container details.
+	unitCloudContainerChange = "unit-cloud-container-change"
+
+	// unitStatusChange is the topic suffix used to publish
+	// changes to a unit's workload or agent status.
+	unitStatusChange = "unit-status-change"
+)
+
 // Unit represents a unit in a cached model.
 type Unit struct {
 	// Resident identifies the unit as a type-agnostic cached entity
@@ -25,6 +36,23 @@ type Unit struct {
 
 	model   *Model
 	details UnitChange
+
+	// cloudContainerHash is a hash of the unit's cloud container details,
+	// used to suppress notifications when they have not changed.
+	cloudContainerHash string
+
+	// statusHash is a hash of the unit's workload and agent status,
+	// used to suppress notifications when they have not changed.
+	statusHash string
+}
+
+// CloudContainer holds the details of the cloud container
+// hosting a unit in a CAAS model.
+type CloudContainer struct {
+	ProviderId string
+	Address    string
+	Ports      []string
+	Status     status.StatusInfo
 }
 
 func newUnit(model *Model, res *Resident) *Unit {
@@ -104,6 +132,52 @@ func (u *Unit) DisplayWorkloadStatus() status.StatusInfo {
 		u.details.WorkloadStatus, u.details.ContainerStatus, app.ExpectsWorkload())
 }
 
+// CloudContainer returns a copy of the details of the cloud container
+// hosting this unit. It is only populated for units in CAAS models.
+func (u *Unit) CloudContainer() CloudContainer {
+	return CloudContainer{
+		ProviderId: u.details.ProviderId,
+		Address:    u.details.ContainerAddress,
+		Ports:      copyStringSlice(u.details.ContainerPorts),
+		Status:     copyStatusInfo(u.details.ContainerStatus),
+	}
+}
+
+// WatchCloudContainer returns a new watcher that will notify when the
+// details of the cloud container hosting this unit change.
+func (u *Unit) WatchCloudContainer() NotifyWatcher {
+	w := newNotifyWatcherBase()
+	deregister := u.registerWorker(w)
+	unsub := u.model.hub.Subscribe(u.topic(unitCloudContainerChange), func(string, interface{}) {
+		w.notify()
+	})
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+	return w
+}
+
+// WatchStatus returns a new watcher that will notify when the workload
+// or agent status of this unit changes. The watcher of a subordinate unit
+// is notified of changes to its own status, not that of its principal.
+func (u *Unit) WatchStatus() NotifyWatcher {
+	w := newNotifyWatcherBase()
+	deregister := u.registerWorker(w)
+	unsub := u.model.hub.Subscribe(u.topic(unitStatusChange), func(string, interface{}) {
+		w.notify()
+	})
+	w.tomb.Go(func() error {
+		<-w.tomb.Dying()
+		unsub()
+		deregister()
+		return nil
+	})
+	return w
+}
+
 // ConfigSettings returns the effective charm configuration for this unit
 // taking into account whether it is tracking a model branch.
 func (u *Unit) ConfigSettings() (charm.Settings, error) {
@@ -202,9 +276,68 @@ func (u *Unit) setDetails(details UnitChange) {
 	if landingOnMachine || newSubordinate {
 		u.model.hub.Publish(modelUnitAdd, toPublish)
 	}
+	if landingOnMachine && details.MachineId != "" {
+		u.model.hub.Publish(modelUnitAssignment, map[string]string{details.Name: details.MachineId})
+	}
 
 	// Publish change event for those that may be waiting.
 	u.model.hub.Publish(unitChangeTopic(details.Name), &toPublish)
+
+	u.model.metrics.ChangesReceived.Inc()
+	var emitted bool
+
+	cloudContainerHash := hashCloudContainer(details)
+	if cloudContainerHash != u.cloudContainerHash {
+		u.cloudContainerHash = cloudContainerHash
+		u.model.hub.Publish(u.topic(unitCloudContainerChange), nil)
+		emitted = true
+	}
+
+	statusHash := hashStatus(details)
+	if statusHash != u.statusHash {
+		u.statusHash = statusHash
+		u.model.hub.Publish(u.topic(unitStatusChange), nil)
+		emitted = true
+	}
+
+	if emitted {
+		u.model.metrics.ChangesEmitted.Inc()
+	}
+}
+
+// hashCloudContainer returns a hash of the cloud container
+// details in the input unit change.
+func hashCloudContainer(details UnitChange) string {
+	h, err := hashSettings(map[string]interface{}{
+		"provider-id": details.ProviderId,
+		"address":     details.ContainerAddress,
+		"ports":       strings.Join(details.ContainerPorts, ","),
+		"status":      string(details.ContainerStatus.Status),
+		"message":     details.ContainerStatus.Message,
+	})
+	if err != nil {
+		logger.Errorf("invariant error - cloud container details should be yaml serializable and hashable, %v", err)
+		return ""
+	}
+	return h
+}
+
+// hashStatus returns a hash of the workload and
+// agent status in the input unit change.
+func hashStatus(details UnitChange) string {
+	h, err := hashSettings(map[string]interface{}{
+		"workload-status":  string(details.WorkloadStatus.Status),
+		"workload-message": details.WorkloadStatus.Message,
+		"workload-data":    details.WorkloadStatus.Data,
+		"agent-status":     string(details.AgentStatus.Status),
+		"agent-message":    details.AgentStatus.Message,
+		"agent-data":       details.AgentStatus.Data,
+	})
+	if err != nil {
+		logger.Errorf("invariant error - unit status should be yaml serializable and hashable, %v", err)
+		return ""
+	}
+	return h
 }
 
 // copy returns a copy of the unit, ensuring appropriate deep copying.
@@ -213,3 +346,7 @@ func (u *Unit) copy() Unit {
 	cu.details = cu.details.copy()
 	return cu
 }
+
+func (u *Unit) topic(suffix string) string {
+	return u.details.Name + ":" + suffix
+}
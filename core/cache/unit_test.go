@@ -109,6 +109,143 @@ func (s *UnitSuite) TestConfigSettingsDefaultsOnly(c *gc.C) {
 	c.Assert(cfg, gc.DeepEquals, expected)
 }
 
+func (s *UnitSuite) TestCloudContainer(c *gc.C) {
+	m := s.NewModel(modelChange)
+	change := unitChange
+	change.ProviderId = "provider-id"
+	change.ContainerAddress = "10.0.0.1"
+	change.ContainerPorts = []string{"80/tcp"}
+	change.ContainerStatus = status.StatusInfo{Status: status.Running}
+	m.UpdateUnit(change, s.Manager)
+
+	u, err := m.Unit(unitChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cc := u.CloudContainer()
+	c.Check(cc, jc.DeepEquals, cache.CloudContainer{
+		ProviderId: "provider-id",
+		Address:    "10.0.0.1",
+		Ports:      []string{"80/tcp"},
+		Status:     status.StatusInfo{Status: status.Running},
+	})
+
+	// The result is a copy.
+	cc.Ports[0] = "443/tcp"
+	c.Check(u.CloudContainer().Ports, jc.DeepEquals, []string{"80/tcp"})
+}
+
+func (s *UnitSuite) TestWatchCloudContainer(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	u, err := m.Unit(unitChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := u.WatchCloudContainer()
+	defer workertest.CleanKill(c, w)
+
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// Changes not relating to the cloud container are ignored.
+	change := unitChange
+	change.WorkloadStatus = status.StatusInfo{Status: status.Blocked}
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertNoChange()
+
+	change.ProviderId = "provider-id"
+	change.ContainerAddress = "10.0.0.1"
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertOneChange()
+
+	// Setting the same values causes no notification.
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertNoChange()
+
+	change.ContainerPorts = []string{"80/tcp"}
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertOneChange()
+
+	change.ContainerStatus = status.StatusInfo{Status: status.Running}
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertOneChange()
+}
+
+func (s *UnitSuite) TestWatchStatus(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	u, err := m.Unit(unitChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := u.WatchStatus()
+	defer workertest.CleanKill(c, w)
+
+	wc := cache.NewNotifyWatcherC(c, w)
+	// Sends initial event.
+	wc.AssertOneChange()
+
+	// Changes not relating to the status are ignored.
+	change := unitChange
+	change.PublicAddress = "10.0.0.1"
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertNoChange()
+
+	change.WorkloadStatus = status.StatusInfo{Status: status.Blocked, Message: "waiting for db"}
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertOneChange()
+
+	// Setting the same values causes no notification.
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertNoChange()
+
+	change.WorkloadStatus = status.StatusInfo{Status: status.Blocked, Message: "waiting for cache"}
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertOneChange()
+
+	change.AgentStatus = status.StatusInfo{Status: status.Executing}
+	m.UpdateUnit(change, s.Manager)
+	wc.AssertOneChange()
+}
+
+func (s *UnitSuite) TestWatchStatusSubordinate(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	sub := unitChange
+	sub.Name = "subordinate/0"
+	sub.Application = "subordinate"
+	sub.Subordinate = true
+	sub.Principal = unitChange.Name
+	m.UpdateUnit(sub, s.Manager)
+
+	principal, err := m.Unit(unitChange.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	pw := principal.WatchStatus()
+	defer workertest.CleanKill(c, pw)
+	pwc := cache.NewNotifyWatcherC(c, pw)
+	pwc.AssertOneChange()
+
+	subordinate, err := m.Unit(sub.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	sw := subordinate.WatchStatus()
+	defer workertest.CleanKill(c, sw)
+	swc := cache.NewNotifyWatcherC(c, sw)
+	swc.AssertOneChange()
+
+	sub.WorkloadStatus = status.StatusInfo{Status: status.Blocked}
+	m.UpdateUnit(sub, s.Manager)
+	swc.AssertOneChange()
+	pwc.AssertNoChange()
+
+	change := unitChange
+	change.WorkloadStatus = status.StatusInfo{Status: status.Maintenance}
+	m.UpdateUnit(change, s.Manager)
+	pwc.AssertOneChange()
+	swc.AssertNoChange()
+}
+
 var unitChange = cache.UnitChange{
 	ModelUUID:                "model-uuid",
 	Name:                     "application-name/0",
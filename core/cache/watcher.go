@@ -5,10 +5,10 @@ package cache
 
 import (
 	"regexp"
-	"sort"
 	"sync"
 
 	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/pubsub"
 	"github.com/juju/worker/v2"
 	"gopkg.in/tomb.v2"
@@ -114,23 +114,23 @@ func (w *notifyWatcherBase) notify() {
 type ConfigWatcher struct {
 	*notifyWatcherBase
 
-	keys []string
+	keys configKeys
 	hash string
 }
 
-// newConfigWatcher returns a new watcher for the input config keys
-// with a baseline hash of their config values from the input hash cache.
-// As per the cache requirements, hashes are only generated from sorted keys.
+// newConfigWatcher returns a new watcher for the input config keys,
+// which may include glob patterns such as "logging-*", with a baseline
+// hash of their config values from the input hash cache.
 func newConfigWatcher(
 	keys []string, cache *hashCache, hub *pubsub.SimpleHub, topic string, res *Resident,
 ) *ConfigWatcher {
-	sort.Strings(keys)
+	configKeys := newConfigKeys(keys)
 
 	w := &ConfigWatcher{
 		notifyWatcherBase: newNotifyWatcherBase(),
 
-		keys: keys,
-		hash: cache.getHash(keys),
+		keys: configKeys,
+		hash: cache.getHash(configKeys),
 	}
 
 	deregister := res.registerWorker(w)
@@ -155,6 +155,7 @@ func (w *ConfigWatcher) configChanged(topic string, value interface{}) {
 		// Nothing that we care about has changed, so we're done.
 		return
 	}
+	w.hash = hash
 	w.notify()
 }
 
@@ -248,6 +249,24 @@ func (w *stringsWatcherBase) notify(values []string) {
 	w.mu.Unlock()
 }
 
+// notifyReplace sends the input values, replacing rather than amending
+// any pending change. It is used by watchers whose changes each report
+// a complete set of values.
+func (w *stringsWatcherBase) notifyReplace(values []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	select {
+	case <-w.changes:
+	default:
+	}
+	w.changes <- values
+}
+
 // amendBufferedChange alters the buffered notification to include new
 // information. This method assumes lock protection.
 func (w *stringsWatcherBase) amendBufferedChange(values []string) {
@@ -269,7 +288,14 @@ func (w *stringsWatcherBase) amendBufferedChange(values []string) {
 type PredicateStringsWatcher struct {
 	*stringsWatcherBase
 
-	fn predicateFunc
+	// fn may be replaced by UpdatePredicate while changes are being
+	// delivered, so access to it is protected by the Mutex below.
+	fn   predicateFunc
+	fnMu sync.Mutex
+
+	// current, if not nil, returns all of the values that the watcher
+	// can notify of. It is required in order to update the predicate.
+	current func() []string
 }
 
 // newChangeWatcher provides a PredicateStringsWatcher which notifies
@@ -296,12 +322,42 @@ func newPredicateStringsWatcher(fn predicateFunc, values ...string) *PredicateSt
 	}
 }
 
+// UpdatePredicate replaces the predicate used to test values before
+// notification. A change is then sent with all of the current values that
+// pass the new predicate, replacing any pending change, so that the
+// consumer can resynchronise as it would for the initial event.
+// A NotSupported error is returned if the watcher was not created with
+// the means to determine its current values.
+func (w *PredicateStringsWatcher) UpdatePredicate(fn func(string) bool) error {
+	if w.current == nil {
+		return errors.NotSupportedf("updating the predicate of this watcher")
+	}
+
+	w.fnMu.Lock()
+	defer w.fnMu.Unlock()
+
+	w.fn = fn
+	matches := make([]string, 0)
+	for _, s := range w.current() {
+		if fn(s) {
+			matches = append(matches, s)
+		}
+	}
+	w.notifyReplace(matches)
+	return nil
+}
+
 func (w *PredicateStringsWatcher) changed(topic string, value interface{}) {
 	strings, ok := value.([]string)
 	if !ok {
 		logger.Errorf("programming error, value not of type []string")
 	}
 
+	// The lock is held until notification, so that values tested with
+	// a replaced predicate are not sent after the resynchronising change.
+	w.fnMu.Lock()
+	defer w.fnMu.Unlock()
+
 	matches := set.NewStrings()
 	for _, s := range strings {
 		if w.fn(s) {
@@ -313,3 +369,97 @@ func (w *PredicateStringsWatcher) changed(topic string, value interface{}) {
 		w.notify(matches.Values())
 	}
 }
+
+// UnitAssignmentsWatcher notifies of the machines that units in a model
+// are assigned to. Each change is a map of unit name to machine ID.
+// An initial event is sent with the input given at creation.
+type UnitAssignmentsWatcher struct {
+	tomb    tomb.Tomb
+	changes chan map[string]string
+	// We can't send down a closed channel, so protect the sending
+	// with a mutex and bool. Since you can't really even ask a channel
+	// if it is closed.
+	closed bool
+	mu     sync.Mutex
+}
+
+func newUnitAssignmentsWatcher(assignments map[string]string) *UnitAssignmentsWatcher {
+	// We use a single entry buffered channel for the changes.
+	// If a change hasn't been consumed before the next one arrives,
+	// the changes are combined, with later assignments taking precedence.
+	ch := make(chan map[string]string, 1)
+
+	// Send initial event down the channel. We know that this will
+	// execute immediately because it is a buffered channel.
+	ch <- assignments
+
+	return &UnitAssignmentsWatcher{changes: ch}
+}
+
+// Changes is part of the core watcher definition.
+func (w *UnitAssignmentsWatcher) Changes() <-chan map[string]string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.changes
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *UnitAssignmentsWatcher) Kill() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	w.tomb.Kill(nil)
+	w.closed = true
+	close(w.changes)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *UnitAssignmentsWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Stop is currently required by the Resources wrapper in the apiserver.
+func (w *UnitAssignmentsWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *UnitAssignmentsWatcher) changed(topic string, value interface{}) {
+	assignments, ok := value.(map[string]string)
+	if !ok {
+		logger.Errorf("programming error, value not of type map[string]string")
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	select {
+	case w.changes <- assignments:
+	default:
+		// Already a pending change, so merge the new
+		// assignments into the pending change.
+		select {
+		case old := <-w.changes:
+			merged := make(map[string]string, len(old)+len(assignments))
+			for k, v := range old {
+				merged[k] = v
+			}
+			for k, v := range assignments {
+				merged[k] = v
+			}
+			w.changes <- merged
+		default:
+			// Someone read the channel in the meantime.
+			w.changes <- assignments
+		}
+	}
+}
@@ -28,6 +28,29 @@ func ValidateBranchName(name string) error {
 	return nil
 }
 
+// BranchTrackingPolicy describes how an application's units come to track
+// a branch.
+type BranchTrackingPolicy string
+
+const (
+	// BranchTrackAll indicates that the whole application tracks the branch,
+	// so units added while the branch is in-flight track it as well.
+	BranchTrackAll BranchTrackingPolicy = "track-all"
+
+	// BranchTrackExplicitUnits indicates that only units explicitly
+	// assigned to the branch track it.
+	BranchTrackExplicitUnits BranchTrackingPolicy = "explicit-units"
+)
+
+// Validate returns an error if the policy is not one that is recognised.
+func (p BranchTrackingPolicy) Validate() error {
+	switch p {
+	case BranchTrackAll, BranchTrackExplicitUnits:
+		return nil
+	}
+	return errors.NotValidf("branch tracking policy %q", p)
+}
+
 // GenerationUnits indicates which units from an application are and are not
 // tracking a model branch.
 type GenerationUnits struct {
@@ -49,6 +72,10 @@ type GenerationApplication struct {
 	// UnitProgress is summary information about units tracking the branch.
 	UnitProgress string `yaml:"progress,omitempty"`
 
+	// TrackingPolicy indicates whether units added to the application
+	// while the branch is in-flight will track it.
+	TrackingPolicy string `yaml:"tracking-policy,omitempty"`
+
 	// UnitDetail specifies which units are and are not tracking the branch.
 	UnitDetail *GenerationUnits `yaml:"units,omitempty"`
 
@@ -57,6 +84,28 @@ type GenerationApplication struct {
 	// TODO (manadart 2018-02-22) This data-type will evolve as more aspects
 	// of the application are made generational.
 	ConfigChanges map[string]interface{} `yaml:"config"`
+
+	// ConfigConflicts are the config changes under the branch that
+	// conflict with changes since made on master. They prevent
+	// the branch being committed unless the commit is forced.
+	ConfigConflicts []ConfigConflict `yaml:"config-conflicts,omitempty"`
+}
+
+// ConfigConflict describes a charm config setting changed under
+// a branch that has since been changed on master.
+type ConfigConflict struct {
+	// Key is the name of the setting.
+	Key string `yaml:"key"`
+
+	// Baseline is the master value when the setting
+	// was first changed under the branch.
+	Baseline interface{} `yaml:"baseline,omitempty"`
+
+	// Master is the current master value.
+	Master interface{} `yaml:"master,omitempty"`
+
+	// Branch is the value under the branch.
+	Branch interface{} `yaml:"branch,omitempty"`
 }
 
 // Generation represents detail of a model generation including config changes.
@@ -72,6 +121,25 @@ type Generation struct {
 	Applications []GenerationApplication `yaml:"applications"`
 }
 
+// BranchSummary is a compact representation of an "in-flight" branch.
+type BranchSummary struct {
+	// BranchName uniquely identifies a branch *amongst in-flight branches*.
+	BranchName string `yaml:"branch"`
+
+	// Created is the time at branch creation.
+	Created time.Time `yaml:"created"`
+
+	// CreatedBy is the user who created the branch.
+	CreatedBy string `yaml:"created-by"`
+
+	// TrackedUnitCount is the number of units tracking the branch.
+	TrackedUnitCount int `yaml:"tracked-units"`
+
+	// ApplicationCount is the number of applications
+	// with units tracking the branch.
+	ApplicationCount int `yaml:"applications"`
+}
+
 // GenerationCommit represents a model generation's commit details.
 type GenerationCommit struct {
 	// BranchName uniquely identifies a branch *amongst in-flight branches*.
@@ -375,6 +375,11 @@ type UnitInfo struct {
 	WorkloadStatus  StatusInfo
 	AgentStatus     StatusInfo
 	ContainerStatus StatusInfo // For CAAS models.
+
+	// Cloud container details, for CAAS models.
+	ProviderID       string
+	ContainerAddress string
+	ContainerPorts   []string
 }
 
 // EntityID returns a unique identifier for a unit across
@@ -398,6 +403,9 @@ func (i *UnitInfo) Clone() EntityInfo {
 	}
 
 	clone.OpenPortRangesByEndpoint = i.OpenPortRangesByEndpoint.Clone()
+	if i.ContainerPorts != nil {
+		clone.ContainerPorts = append([]string(nil), i.ContainerPorts...)
+	}
 	return &clone
 }
 
@@ -453,6 +461,7 @@ type RelationInfo struct {
 	ModelUUID string
 	Key       string
 	ID        int
+	Life      life.Value
 	Endpoints []Endpoint
 }
 
@@ -51,6 +51,10 @@ type Recorder interface {
 
 	// Connections returns all connections info that the recorder has.
 	Connections() Connections
+
+	// SetChurnThreshold replaces the threshold used to
+	// determine whether an agent is flapping.
+	SetChurnThreshold(threshold ChurnThreshold) error
 }
 
 // Connections provides a way to slice the full presence understanding
@@ -84,6 +88,10 @@ type Connections interface {
 	// For a given non controller agent, return the Status for that agent.
 	AgentStatus(agent string) (Status, error)
 
+	// Flapping returns whether the given non controller agent has
+	// disconnected more often than the recorder's churn threshold allows.
+	Flapping(agent string) (bool, error)
+
 	// Values returns the connection information for this collection.
 	Values() []Value
 }
@@ -149,6 +157,72 @@ type Value struct {
 type connections struct {
 	model  string
 	values []Value
+
+	// recorder is queried for the disconnection
+	// history of the agents, when it is needed.
+	recorder *recorder
+}
+
+// maxAgentDisconnects is the number of disconnection times retained for
+// each agent. It bounds the memory used to detect flapping agents.
+const maxAgentDisconnects = 10
+
+// ChurnThreshold defines when an agent that repeatedly disconnects
+// from the API servers is considered to be flapping.
+type ChurnThreshold struct {
+	// Disconnects is the number of disconnections within Window at which
+	// an agent is considered to be flapping. It must be between 1 and 10.
+	Disconnects int
+
+	// Window is the period over which disconnections are counted.
+	Window time.Duration
+}
+
+// DefaultChurnThreshold is the churn threshold used by recorders
+// until SetChurnThreshold is called.
+var DefaultChurnThreshold = ChurnThreshold{
+	Disconnects: 5,
+	Window:      10 * time.Minute,
+}
+
+// Validate returns an error if the threshold is not valid.
+func (t ChurnThreshold) Validate() error {
+	if t.Disconnects < 1 || t.Disconnects > maxAgentDisconnects {
+		return errors.NotValidf("disconnects %d, expected between 1 and %d", t.Disconnects, maxAgentDisconnects)
+	}
+	if t.Window <= 0 {
+		return errors.NotValidf("window %v", t.Window)
+	}
+	return nil
+}
+
+// agentKey identifies an agent within a model.
+type agentKey struct {
+	model string
+	agent string
+}
+
+// disconnectHistory is a ring buffer holding
+// the most recent times an agent disconnected.
+type disconnectHistory struct {
+	times [maxAgentDisconnects]time.Time
+	next  int
+}
+
+func (h *disconnectHistory) add(t time.Time) {
+	h.times[h.next] = t
+	h.next = (h.next + 1) % len(h.times)
+}
+
+// countSince returns the number of disconnections after the input time.
+func (h *disconnectHistory) countSince(t time.Time) int {
+	count := 0
+	for _, disconnected := range h.times {
+		if !disconnected.IsZero() && disconnected.After(t) {
+			count++
+		}
+	}
+	return count
 }
 
 // Clock provides an interface for dealing with clocks.
@@ -157,16 +231,38 @@ type Clock interface {
 	Now() time.Time
 }
 
-// New returns a new empty Recorder.
+// New returns a new empty Recorder, which uses
+// DefaultChurnThreshold to detect flapping agents.
 func New(clock Clock) Recorder {
-	return &recorder{clock: clock}
+	return &recorder{
+		clock:     clock,
+		threshold: DefaultChurnThreshold,
+	}
 }
 
 type recorder struct {
-	mu      sync.Mutex
-	enabled bool
-	clock   Clock
-	entries []Value
+	mu        sync.Mutex
+	enabled   bool
+	clock     Clock
+	threshold ChurnThreshold
+	entries   []Value
+
+	// disconnects records the recent disconnection times of non
+	// controller agents, and lastPruned when agents without any
+	// disconnections in the threshold window were last removed.
+	disconnects map[agentKey]*disconnectHistory
+	lastPruned  time.Time
+}
+
+// SetChurnThreshold implements Recorder.
+func (r *recorder) SetChurnThreshold(threshold ChurnThreshold) error {
+	if err := threshold.Validate(); err != nil {
+		return errors.Annotate(err, "churn threshold")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.threshold = threshold
+	return nil
 }
 
 // Disable implements Recorder.
@@ -175,6 +271,7 @@ func (r *recorder) Disable() {
 	defer r.mu.Unlock()
 	r.enabled = false
 	r.entries = nil
+	r.disconnects = nil
 }
 
 // Enable implements Recorder.
@@ -237,6 +334,7 @@ func (r *recorder) Disconnect(server string, id uint64) {
 	}
 
 	if pos := r.findIndex(server, id); pos >= 0 {
+		r.recordDisconnect(r.entries[pos])
 		if pos == 0 {
 			r.entries = r.entries[1:]
 		} else {
@@ -245,6 +343,56 @@ func (r *recorder) Disconnect(server string, id uint64) {
 	}
 }
 
+// recordDisconnect adds the current time to the
+// disconnection history of the input connection's agent.
+func (r *recorder) recordDisconnect(value Value) {
+	if value.ControllerAgent {
+		return
+	}
+	now := r.clock.Now()
+	if r.disconnects == nil {
+		r.disconnects = make(map[agentKey]*disconnectHistory)
+	}
+	r.pruneDisconnects(now)
+
+	key := agentKey{model: value.Model, agent: value.Agent}
+	history, ok := r.disconnects[key]
+	if !ok {
+		history = &disconnectHistory{}
+		r.disconnects[key] = history
+	}
+	history.add(now)
+}
+
+// pruneDisconnects drops the history of agents with no disconnections
+// within the threshold window, so it is only retained for agents that
+// may be flapping. The history is pruned at most once per window.
+func (r *recorder) pruneDisconnects(now time.Time) {
+	since := now.Add(-r.threshold.Window)
+	if r.lastPruned.After(since) {
+		return
+	}
+	for key, history := range r.disconnects {
+		if history.countSince(since) == 0 {
+			delete(r.disconnects, key)
+		}
+	}
+	r.lastPruned = now
+}
+
+// flapping returns whether the input agent has disconnected
+// at least as often as the threshold allows within its window.
+func (r *recorder) flapping(model, agent string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	history, ok := r.disconnects[agentKey{model: model, agent: agent}]
+	if !ok {
+		return false
+	}
+	since := r.clock.Now().Add(-r.threshold.Window)
+	return history.countSince(since) >= r.threshold.Disconnects
+}
+
 // Activity implements Recorder.
 func (r *recorder) Activity(server string, id uint64) {
 	r.mu.Lock()
@@ -321,7 +469,17 @@ func (r *recorder) Connections() Connections {
 
 	entries := make([]Value, len(r.entries))
 	copy(entries, r.entries)
-	return &connections{values: entries}
+	return &connections{values: entries, recorder: r}
+}
+
+// subset returns a Connections with the input values,
+// which queries the same recorder as c.
+func (c *connections) subset(model string, values []Value) *connections {
+	return &connections{
+		model:    model,
+		values:   values,
+		recorder: c.recorder,
+	}
 }
 
 // ForModel implements Connections.
@@ -332,7 +490,7 @@ func (c *connections) ForModel(model string) Connections {
 			values = append(values, value)
 		}
 	}
-	return &connections{model: model, values: values}
+	return c.subset(model, values)
 }
 
 // ForServer implements Connections.
@@ -343,7 +501,7 @@ func (c *connections) ForServer(server string) Connections {
 			values = append(values, value)
 		}
 	}
-	return &connections{model: c.model, values: values}
+	return c.subset(c.model, values)
 }
 
 // ForAgent implements Connections.
@@ -354,7 +512,7 @@ func (c *connections) ForAgent(agent string) Connections {
 			values = append(values, value)
 		}
 	}
-	return &connections{model: c.model, values: values}
+	return c.subset(c.model, values)
 }
 
 // Count implements Connections.
@@ -405,6 +563,14 @@ func (c *connections) AgentStatus(agent string) (Status, error) {
 	return result, nil
 }
 
+// Flapping implements Connections.
+func (c *connections) Flapping(agent string) (bool, error) {
+	if c.model == "" {
+		return false, errors.New("connections not limited to a model, agent ambiguous")
+	}
+	return c.recorder.flapping(c.model, agent), nil
+}
+
 // Values implements Connections.
 func (c *connections) Values() []Value {
 	return c.values
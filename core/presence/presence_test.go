@@ -284,6 +284,122 @@ func (s *suite) TestConnections(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *suite) TestFlapping(c *gc.C) {
+	r, clock := bootstrap(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	deployModel(r)
+
+	// Reconnect the unit agent every 30 seconds, up to the threshold.
+	for i := 0; i < presence.DefaultChurnThreshold.Disconnects; i++ {
+		flapping, err := r.Connections().ForModel(modelUUID).Flapping(modelUnit1.Agent)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(flapping, jc.IsFalse)
+
+		r.Disconnect(modelUnit1.Server, modelUnit1.ConnectionID)
+		clock.Advance(30 * time.Second)
+		connect(r, modelUnit1)
+	}
+
+	connections := r.Connections().ForModel(modelUUID)
+	flapping, err := connections.Flapping(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsTrue)
+	status, err := connections.AgentStatus(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, gc.Equals, presence.Alive)
+
+	// Other agents, and the same agent in other models, are unaffected.
+	flapping, err = connections.Flapping(modelUnit2.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsFalse)
+	flapping, err = r.Connections().ForModel(bootstrapUUID).Flapping(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsFalse)
+
+	// Once the disconnections fall outside the window,
+	// the agent is no longer considered to be flapping.
+	clock.Advance(presence.DefaultChurnThreshold.Window)
+	flapping, err = r.Connections().ForModel(modelUUID).Flapping(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsFalse)
+}
+
+func (s *suite) TestFlappingNotLimitedToModel(c *gc.C) {
+	r, _ := bootstrap()
+	flapping, err := r.Connections().Flapping("machine-0")
+	c.Assert(err, gc.ErrorMatches, "connections not limited to a model, agent ambiguous")
+	c.Assert(flapping, jc.IsFalse)
+}
+
+func (s *suite) TestFlappingWithChurnThreshold(c *gc.C) {
+	clock := testclock.NewClock(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	r := presence.New(clock)
+	err := r.SetChurnThreshold(presence.ChurnThreshold{
+		Disconnects: 10,
+		Window:      time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	r.Enable()
+
+	// Only the most recent disconnections are retained, which
+	// is sufficient to determine whether the agent is flapping.
+	for i := 0; i < 20; i++ {
+		connect(r, modelUnit1)
+		r.Disconnect(modelUnit1.Server, modelUnit1.ConnectionID)
+		clock.Advance(time.Minute)
+	}
+	flapping, err := r.Connections().ForModel(modelUUID).Flapping(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsTrue)
+
+	// One of the 10 most recent disconnections leaves the window.
+	clock.Advance(50 * time.Minute)
+	flapping, err = r.Connections().ForModel(modelUUID).Flapping(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsFalse)
+}
+
+func (s *suite) TestDisableClearsFlapping(c *gc.C) {
+	r, clock := bootstrap(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	for i := 0; i < presence.DefaultChurnThreshold.Disconnects; i++ {
+		connect(r, modelUnit1)
+		r.Disconnect(modelUnit1.Server, modelUnit1.ConnectionID)
+		clock.Advance(time.Second)
+	}
+	r.Disable()
+	r.Enable()
+
+	flapping, err := r.Connections().ForModel(modelUUID).Flapping(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsFalse)
+}
+
+func (s *suite) TestSetChurnThresholdAppliesToHistory(c *gc.C) {
+	r, clock := bootstrap(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	for i := 0; i < 2; i++ {
+		connect(r, modelUnit1)
+		r.Disconnect(modelUnit1.Server, modelUnit1.ConnectionID)
+		clock.Advance(time.Second)
+	}
+	connections := r.Connections().ForModel(modelUUID)
+	flapping, err := connections.Flapping(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsFalse)
+
+	err = r.SetChurnThreshold(presence.ChurnThreshold{Disconnects: 2, Window: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	flapping, err = connections.Flapping(modelUnit1.Agent)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(flapping, jc.IsTrue)
+}
+
+func (s *suite) TestSetChurnThresholdInvalid(c *gc.C) {
+	r := presence.New(testclock.NewClock(time.Time{}))
+	err := r.SetChurnThreshold(presence.ChurnThreshold{Disconnects: 11, Window: time.Minute})
+	c.Check(err, gc.ErrorMatches, `churn threshold: disconnects 11, expected between 1 and 10 not valid`)
+	err = r.SetChurnThreshold(presence.ChurnThreshold{Disconnects: 1})
+	c.Check(err, gc.ErrorMatches, `churn threshold: window 0s not valid`)
+}
+
 func bootstrap(initialTime ...time.Time) (presence.Recorder, *testclock.Clock) {
 	if len(initialTime) > 1 {
 		panic("initialTime should be zero or one values")
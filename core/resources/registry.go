@@ -0,0 +1,324 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+)
+
+// RegistryChecker verifies that a docker image can be accessed
+// using the credentials supplied with it.
+type RegistryChecker interface {
+	// CheckImage returns an error if the image described by
+	// the input details can not be retrieved from its registry.
+	CheckImage(details DockerImageDetails) error
+}
+
+// ManifestFetcher retrieves image manifests from a registry.
+type ManifestFetcher interface {
+	// FetchManifest returns the media type and content of the
+	// manifest of the image described by the input details.
+	FetchManifest(details DockerImageDetails) (string, []byte, error)
+}
+
+const (
+	// dockerHubDomain is the domain reported by the docker
+	// reference parser for images without an explicit registry.
+	dockerHubDomain = "docker.io"
+
+	// dockerHubRegistry is the host serving the registry
+	// API for images hosted on docker hub.
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+const (
+	dockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+	ociImageIndexMediaType      = "application/vnd.oci.image.index.v1+json"
+)
+
+// manifestMediaTypes are the manifest types we accept from a registry.
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	dockerManifestListMediaType,
+	"application/vnd.oci.image.manifest.v1+json",
+	ociImageIndexMediaType,
+}
+
+// NewRegistryChecker returns a RegistryChecker that requests
+// the image manifest from the registry using the input client.
+func NewRegistryChecker(client *http.Client) RegistryChecker {
+	return &registryChecker{client: client}
+}
+
+// NewManifestFetcher returns a ManifestFetcher that requests
+// the image manifest from the registry using the input client.
+func NewManifestFetcher(client *http.Client) ManifestFetcher {
+	return &registryChecker{client: client}
+}
+
+type registryChecker struct {
+	client *http.Client
+}
+
+// CheckImage is part of the RegistryChecker interface.
+func (c *registryChecker) CheckImage(details DockerImageDetails) error {
+	resp, err := c.requestManifest(http.MethodHead, details)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_ = resp.Body.Close()
+	return errors.Trace(checkManifestResponse(resp, details))
+}
+
+// FetchManifest is part of the ManifestFetcher interface.
+func (c *registryChecker) FetchManifest(details DockerImageDetails) (string, []byte, error) {
+	resp, err := c.requestManifest(http.MethodGet, details)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := checkManifestResponse(resp, details); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, errors.Annotatef(err, "reading manifest for image %q", details.RegistryPath)
+	}
+	// Registries may qualify the media type with parameters.
+	mediaType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	return mediaType, body, nil
+}
+
+// requestManifest requests the manifest of the image described by the
+// input details using the input method, authenticating with a token if
+// the registry demands one. The caller is responsible for closing the
+// body of the response.
+func (c *registryChecker) requestManifest(method string, details DockerImageDetails) (*http.Response, error) {
+	path, err := ParseDockerRegistryPath(details.RegistryPath)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	host := path.Registry
+	if host == dockerHubDomain {
+		host = dockerHubRegistry
+	}
+	ref := "latest"
+	if path.Digest != "" {
+		ref = path.Digest
+	} else if path.Tag != "" {
+		ref = path.Tag
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, path.Repository, ref)
+
+	resp, err := c.doManifestRequest(method, manifestURL, "", details)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	// Most registries use token authentication, in which case
+	// the challenge tells us where to exchange our credentials
+	// for a token.
+	_ = resp.Body.Close()
+	challenge := resp.Header.Get("Www-Authenticate")
+	if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		return nil, errors.Unauthorizedf("credentials rejected for image %q", details.RegistryPath)
+	}
+	token, err := c.fetchToken(challenge, details)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err = c.doManifestRequest(method, manifestURL, token, details)
+	return resp, errors.Trace(err)
+}
+
+// checkManifestResponse returns an error describing
+// an unsuccessful response to a manifest request.
+func checkManifestResponse(resp *http.Response, details DockerImageDetails) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errors.Unauthorizedf("credentials rejected for image %q", details.RegistryPath)
+	case http.StatusNotFound:
+		return errors.NotFoundf("image %q", details.RegistryPath)
+	}
+	return errors.Errorf("checking image %q: unexpected response %q", details.RegistryPath, resp.Status)
+}
+
+// doManifestRequest issues a request for the input manifest URL.
+// If a token is supplied it is used for authentication,
+// otherwise any credentials in the image details are used.
+func (c *registryChecker) doManifestRequest(method, manifestURL, token string, details DockerImageDetails) (*http.Response, error) {
+	req, err := http.NewRequest(method, manifestURL, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if details.Username != "" {
+		req.SetBasicAuth(details.Username, details.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Annotatef(err, "contacting registry for image %q", details.RegistryPath)
+	}
+	return resp, nil
+}
+
+// fetchToken exchanges the credentials in the image
+// details for a token, as directed by the input challenge.
+func (c *registryChecker) fetchToken(challenge string, details DockerImageDetails) (string, error) {
+	params := parseChallenge(challenge[len("bearer "):])
+	realm := params["realm"]
+	if realm == "" {
+		return "", errors.Errorf("registry for image %q sent an invalid authentication challenge", details.RegistryPath)
+	}
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", errors.Annotatef(err, "parsing token realm for image %q", details.RegistryPath)
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value := params[key]; value != "" {
+			query.Set(key, value)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if details.Username != "" {
+		req.SetBasicAuth(details.Username, details.Password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", errors.Annotatef(err, "requesting registry token for image %q", details.RegistryPath)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "", errors.Unauthorizedf("credentials rejected for image %q", details.RegistryPath)
+	default:
+		return "", errors.Errorf("requesting registry token for image %q: unexpected response %q", details.RegistryPath, resp.Status)
+	}
+
+	var result struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", errors.Annotatef(err, "decoding registry token for image %q", details.RegistryPath)
+	}
+	if result.Token != "" {
+		return result.Token, nil
+	}
+	return result.AccessToken, nil
+}
+
+// platformArches maps juju architectures to the names used for
+// them in the platforms of image manifest lists, where they differ.
+var platformArches = map[string]string{
+	arch.I386:    "386",
+	arch.PPC64EL: "ppc64le",
+}
+
+// ValidateDockerImageArchitecture confirms that the image described by the
+// input details is a multi-arch image, whose manifest list includes an
+// image for the input juju architecture (e.g. arm64). The manifest is
+// retrieved using the input fetcher.
+func ValidateDockerImageArchitecture(details DockerImageDetails, requiredArch string, fetcher ManifestFetcher) error {
+	if err := ValidateDockerRegistryPath(details.RegistryPath); err != nil {
+		return errors.Trace(err)
+	}
+	mediaType, content, err := fetcher.FetchManifest(details)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if mediaType != dockerManifestListMediaType && mediaType != ociImageIndexMediaType {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"image %q is not a multi-arch image (manifest type %q)", details.RegistryPath, mediaType,
+		))
+	}
+
+	var manifestList struct {
+		Manifests []struct {
+			Platform struct {
+				Architecture string `json:"architecture"`
+			} `json:"platform"`
+		} `json:"manifests"`
+	}
+	if err := json.Unmarshal(content, &manifestList); err != nil {
+		return errors.Annotatef(err, "decoding manifest list for image %q", details.RegistryPath)
+	}
+
+	platformArch := requiredArch
+	if name, ok := platformArches[requiredArch]; ok {
+		platformArch = name
+	}
+	var available []string
+	for _, manifest := range manifestList.Manifests {
+		if manifest.Platform.Architecture == platformArch {
+			return nil
+		}
+		available = append(available, manifest.Platform.Architecture)
+	}
+	sort.Strings(available)
+	return errors.NewNotSupported(nil, fmt.Sprintf(
+		"image %q does not support architecture %q (available: %s)",
+		details.RegistryPath, requiredArch, strings.Join(available, ", "),
+	))
+}
+
+// parseChallenge parses the comma separated key="value" pairs
+// from the parameters of a WWW-Authenticate challenge.
+func parseChallenge(s string) map[string]string {
+	params := make(map[string]string)
+	for s != "" {
+		s = strings.TrimLeft(s, " ,")
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				value, s = s, ""
+			} else {
+				value, s = s[:end], s[end:]
+			}
+		}
+		params[key] = value
+	}
+	return params
+}
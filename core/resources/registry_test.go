@@ -0,0 +1,219 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/resources"
+)
+
+type RegistrySuite struct {
+	server   *httptest.Server
+	checker  resources.RegistryChecker
+	requests []*http.Request
+}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) SetUpTest(c *gc.C) {
+	s.requests = nil
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serve))
+	s.checker = resources.NewRegistryChecker(s.server.Client())
+}
+
+func (s *RegistrySuite) TearDownTest(c *gc.C) {
+	s.server.Close()
+}
+
+// serve emulates a registry using token authentication, which only
+// grants access to the "me/image" repository for user "user".
+func (s *RegistrySuite) serve(w http.ResponseWriter, req *http.Request) {
+	s.requests = append(s.requests, req)
+	switch {
+	case req.URL.Path == "/token":
+		if user, pass, ok := req.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"token": "sekrit"}`)
+	case req.Header.Get("Authorization") != "Bearer sekrit":
+		w.Header().Set("Www-Authenticate", fmt.Sprintf(
+			`Bearer realm="%s/token",service="registry",scope="repository:me/image:pull"`, s.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+	case strings.HasPrefix(req.URL.Path, "/v2/me/image/manifests/"):
+		w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if req.Method == http.MethodGet {
+			_, _ = fmt.Fprint(w, manifestList)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *RegistrySuite) imagePath(path string) string {
+	return strings.TrimPrefix(s.server.URL, "https://") + "/" + path
+}
+
+func (s *RegistrySuite) TestCheckImage(c *gc.C) {
+	err := s.checker.CheckImage(resources.DockerImageDetails{
+		RegistryPath: s.imagePath("me/image:1.0"),
+		Username:     "user",
+		Password:     "pass",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 3)
+	c.Check(s.requests[0].Method, gc.Equals, http.MethodHead)
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/v2/me/image/manifests/1.0")
+	c.Check(s.requests[1].URL.Path, gc.Equals, "/token")
+	c.Check(s.requests[1].URL.Query().Get("service"), gc.Equals, "registry")
+	c.Check(s.requests[1].URL.Query().Get("scope"), gc.Equals, "repository:me/image:pull")
+	c.Check(s.requests[2].Header.Get("Authorization"), gc.Equals, "Bearer sekrit")
+}
+
+func (s *RegistrySuite) TestCheckImageDefaultTag(c *gc.C) {
+	err := s.checker.CheckImage(resources.DockerImageDetails{
+		RegistryPath: s.imagePath("me/image"),
+		Username:     "user",
+		Password:     "pass",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/v2/me/image/manifests/latest")
+}
+
+func (s *RegistrySuite) TestCheckImageBadCredentials(c *gc.C) {
+	err := s.checker.CheckImage(resources.DockerImageDetails{
+		RegistryPath: s.imagePath("me/image:1.0"),
+		Username:     "user",
+		Password:     "wrong",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(err, gc.ErrorMatches, `credentials rejected for image ".*/me/image:1.0"`)
+}
+
+func (s *RegistrySuite) TestCheckImageNotFound(c *gc.C) {
+	err := s.checker.CheckImage(resources.DockerImageDetails{
+		RegistryPath: s.imagePath("me/other:1.0"),
+		Username:     "user",
+		Password:     "pass",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RegistrySuite) TestCheckImageInvalidPath(c *gc.C) {
+	err := s.checker.CheckImage(resources.DockerImageDetails{
+		RegistryPath: "blah:sha256@",
+	})
+	c.Assert(err, gc.ErrorMatches, "docker image path .* not valid")
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *RegistrySuite) TestFetchManifest(c *gc.C) {
+	fetcher := resources.NewManifestFetcher(s.server.Client())
+	mediaType, content, err := fetcher.FetchManifest(resources.DockerImageDetails{
+		RegistryPath: s.imagePath("me/image:1.0"),
+		Username:     "user",
+		Password:     "pass",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mediaType, gc.Equals, "application/vnd.oci.image.index.v1+json")
+	c.Check(string(content), gc.Equals, manifestList)
+	c.Check(s.requests[0].Method, gc.Equals, http.MethodGet)
+}
+
+func (s *RegistrySuite) TestFetchManifestNotFound(c *gc.C) {
+	fetcher := resources.NewManifestFetcher(s.server.Client())
+	_, _, err := fetcher.FetchManifest(resources.DockerImageDetails{
+		RegistryPath: s.imagePath("me/other:1.0"),
+		Username:     "user",
+		Password:     "pass",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+const manifestList = `{
+	"schemaVersion": 2,
+	"manifests": [
+		{"digest": "sha256:aaaa", "platform": {"architecture": "amd64", "os": "linux"}},
+		{"digest": "sha256:bbbb", "platform": {"architecture": "ppc64le", "os": "linux"}}
+	]
+}`
+
+type ArchitectureSuite struct {
+	fetcher *fakeManifestFetcher
+	details resources.DockerImageDetails
+}
+
+var _ = gc.Suite(&ArchitectureSuite{})
+
+func (s *ArchitectureSuite) SetUpTest(c *gc.C) {
+	s.fetcher = &fakeManifestFetcher{
+		mediaType: "application/vnd.docker.distribution.manifest.list.v2+json",
+		content:   manifestList,
+	}
+	s.details = resources.DockerImageDetails{RegistryPath: "me/image:1.0"}
+}
+
+func (s *ArchitectureSuite) TestArchitecturePresent(c *gc.C) {
+	err := resources.ValidateDockerImageArchitecture(s.details, "amd64", s.fetcher)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fetcher.details, jc.DeepEquals, []resources.DockerImageDetails{s.details})
+}
+
+func (s *ArchitectureSuite) TestArchitecturePresentPlatformName(c *gc.C) {
+	err := resources.ValidateDockerImageArchitecture(s.details, "ppc64el", s.fetcher)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ArchitectureSuite) TestArchitectureMissing(c *gc.C) {
+	err := resources.ValidateDockerImageArchitecture(s.details, "arm64", s.fetcher)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `image "me/image:1.0" does not support architecture "arm64" \(available: amd64, ppc64le\)`)
+}
+
+func (s *ArchitectureSuite) TestNotManifestList(c *gc.C) {
+	s.fetcher.mediaType = "application/vnd.docker.distribution.manifest.v2+json"
+	err := resources.ValidateDockerImageArchitecture(s.details, "arm64", s.fetcher)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `image "me/image:1.0" is not a multi-arch image .*`)
+}
+
+func (s *ArchitectureSuite) TestFetchError(c *gc.C) {
+	s.fetcher.err = errors.NotFoundf("image")
+	err := resources.ValidateDockerImageArchitecture(s.details, "arm64", s.fetcher)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ArchitectureSuite) TestInvalidPath(c *gc.C) {
+	s.details.RegistryPath = "blah:sha256@"
+	err := resources.ValidateDockerImageArchitecture(s.details, "arm64", s.fetcher)
+	c.Assert(err, gc.ErrorMatches, "docker image path .* not valid")
+	c.Assert(s.fetcher.details, gc.HasLen, 0)
+}
+
+// fakeManifestFetcher is a ManifestFetcher returning a canned manifest.
+type fakeManifestFetcher struct {
+	mediaType string
+	content   string
+	err       error
+
+	details []resources.DockerImageDetails
+}
+
+func (f *fakeManifestFetcher) FetchManifest(details resources.DockerImageDetails) (string, []byte, error) {
+	f.details = append(f.details, details)
+	if f.err != nil {
+		return "", nil, f.err
+	}
+	return f.mediaType, []byte(f.content), nil
+}
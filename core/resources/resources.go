@@ -8,9 +8,11 @@ import (
 	_ "crypto/sha256"
 	_ "crypto/sha512"
 	"encoding/json"
+	"fmt"
 
 	"github.com/docker/distribution/reference"
 	"github.com/juju/errors"
+	"github.com/opencontainers/go-digest"
 	"gopkg.in/yaml.v2"
 )
 
@@ -26,6 +28,33 @@ type DockerImageDetails struct {
 	Password string `json:"Password,omitempty" yaml:"password"`
 }
 
+// redacted replaces credentials in the output of DockerImageDetails.
+const redacted = "<redacted>"
+
+// Redacted returns a copy of the details with the password masked,
+// suitable for logging. The username is left intact, since it is
+// often needed to diagnose registry authentication problems.
+func (did DockerImageDetails) Redacted() DockerImageDetails {
+	if did.Password != "" {
+		did.Password = redacted
+	}
+	return did
+}
+
+// String implements fmt.Stringer, masking the password so that
+// the details may be safely logged. Serialisation is unaffected.
+func (did DockerImageDetails) String() string {
+	r := did.Redacted()
+	return fmt.Sprintf("{RegistryPath:%s Username:%s Password:%s}", r.RegistryPath, r.Username, r.Password)
+}
+
+// GoString implements fmt.GoStringer, masking the password
+// when the details are formatted with %#v.
+func (did DockerImageDetails) GoString() string {
+	r := did.Redacted()
+	return fmt.Sprintf("resources.DockerImageDetails{RegistryPath:%q, Username:%q, Password:%q}", r.RegistryPath, r.Username, r.Password)
+}
+
 // ValidateDockerRegistryPath ensures the registry path is valid (i.e. api.jujucharms.com@sha256:deadbeef)
 func ValidateDockerRegistryPath(path string) error {
 	_, err := reference.ParseNormalizedNamed(path)
@@ -35,9 +64,87 @@ func ValidateDockerRegistryPath(path string) error {
 	return nil
 }
 
+// DockerRegistryPath holds the components of a docker image registry path.
+type DockerRegistryPath struct {
+	// Registry is the host, and optionally the port, of the registry
+	// holding the image. It is "docker.io" for paths without a host.
+	Registry string
+
+	// Repository is the path of the image within the registry. Docker
+	// hub's "library/" prefix is added to official images, so "nginx"
+	// has the repository "library/nginx".
+	Repository string
+
+	// Tag is the image tag, if the path has one.
+	Tag string
+
+	// Digest is the image digest (e.g. sha256:deadbeef),
+	// if the path has one.
+	Digest string
+}
+
+// ParseDockerRegistryPath splits a docker image registry path
+// (i.e. api.jujucharms.com/me/image@sha256:deadbeef) into its components.
+func ParseDockerRegistryPath(path string) (DockerRegistryPath, error) {
+	named, err := reference.ParseNormalizedNamed(path)
+	if err != nil {
+		return DockerRegistryPath{}, errors.NotValidf("docker image path %q", path)
+	}
+	result := DockerRegistryPath{
+		Registry:   reference.Domain(named),
+		Repository: reference.Path(named),
+	}
+	if tagged, ok := named.(reference.Tagged); ok {
+		result.Tag = tagged.Tag()
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		result.Digest = digested.Digest().String()
+	}
+	return result, nil
+}
+
+// CanonicalizeDockerRegistryPath returns the fully qualified form of
+// a docker image registry path, so that equivalent paths compare equal.
+// Paths without a host are qualified with "docker.io", official images
+// with docker hub's "library/" prefix, and paths without a tag or digest
+// are given the "latest" tag. So "me/image" becomes
+// "docker.io/me/image:latest".
+func CanonicalizeDockerRegistryPath(path string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(path)
+	if err != nil {
+		return "", errors.NotValidf("docker image path %q", path)
+	}
+	return reference.TagNameOnly(named).String(), nil
+}
+
+// VerifyImageDigest checks that the digest of a pulled image matches the
+// digest in the registry path of the image details, guarding against an
+// image being altered in the registry. The details must include a digest.
+func VerifyImageDigest(details DockerImageDetails, pulled string) error {
+	path, err := ParseDockerRegistryPath(details.RegistryPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if path.Digest == "" {
+		return errors.NotValidf("docker image path %q without digest", details.RegistryPath)
+	}
+	actual, err := digest.Parse(pulled)
+	if err != nil {
+		return errors.NotValidf("image digest %q", pulled)
+	}
+	if actual.String() != path.Digest {
+		return errors.Errorf(
+			"digest mismatch for image %q: expected %s, got %s",
+			details.RegistryPath, path.Digest, actual,
+		)
+	}
+	return nil
+}
+
 // CheckDockerDetails validates the provided resource is suitable for use.
+// It does not contact the registry; use a RegistryChecker to verify that
+// the image can be retrieved with the supplied credentials.
 func CheckDockerDetails(name string, details DockerImageDetails) error {
-	// TODO (veebers): Validate the URL actually works.
 	return ValidateDockerRegistryPath(details.RegistryPath)
 }
 
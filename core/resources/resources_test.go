@@ -4,6 +4,10 @@
 package resources_test
 
 import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -36,6 +40,165 @@ func (s *ResourceSuite) TestInvalidRegistryPath(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "docker image path .* not valid")
 }
 
+func (s *ResourceSuite) TestParseDockerRegistryPath(c *gc.C) {
+	for _, test := range []struct {
+		registryPath string
+		expected     resources.DockerRegistryPath
+	}{{
+		registryPath: "registry.staging.charmstore.com/me/awesomeimage@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		expected: resources.DockerRegistryPath{
+			Registry:   "registry.staging.charmstore.com",
+			Repository: "me/awesomeimage",
+			Digest:     "sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		},
+	}, {
+		registryPath: "gcr.io/kubeflow/jupyterhub-k8s@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		expected: resources.DockerRegistryPath{
+			Registry:   "gcr.io",
+			Repository: "kubeflow/jupyterhub-k8s",
+			Digest:     "sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		},
+	}, {
+		registryPath: "localhost:32000/me/image:1.0@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		expected: resources.DockerRegistryPath{
+			Registry:   "localhost:32000",
+			Repository: "me/image",
+			Tag:        "1.0",
+			Digest:     "sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		},
+	}, {
+		registryPath: "docker.io/me/mygitlab:latest",
+		expected: resources.DockerRegistryPath{
+			Registry:   "docker.io",
+			Repository: "me/mygitlab",
+			Tag:        "latest",
+		},
+	}, {
+		registryPath: "me/mygitlab:latest",
+		expected: resources.DockerRegistryPath{
+			Registry:   "docker.io",
+			Repository: "me/mygitlab",
+			Tag:        "latest",
+		},
+	}, {
+		registryPath: "me/mygitlab",
+		expected: resources.DockerRegistryPath{
+			Registry:   "docker.io",
+			Repository: "me/mygitlab",
+		},
+	}, {
+		registryPath: "nginx",
+		expected: resources.DockerRegistryPath{
+			Registry:   "docker.io",
+			Repository: "library/nginx",
+		},
+	}} {
+		c.Logf("registry path %q", test.registryPath)
+		path, err := resources.ParseDockerRegistryPath(test.registryPath)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(path, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *ResourceSuite) TestParseDockerRegistryPathInvalid(c *gc.C) {
+	_, err := resources.ParseDockerRegistryPath("blah:sha256@")
+	c.Assert(err, gc.ErrorMatches, `docker image path "blah:sha256@" not valid`)
+}
+
+func (s *ResourceSuite) TestCanonicalizeDockerRegistryPath(c *gc.C) {
+	for _, test := range []struct {
+		registryPath string
+		expected     string
+	}{{
+		registryPath: "registry.staging.charmstore.com/me/awesomeimage@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		expected:     "registry.staging.charmstore.com/me/awesomeimage@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+	}, {
+		registryPath: "gcr.io/kubeflow/jupyterhub-k8s@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		expected:     "gcr.io/kubeflow/jupyterhub-k8s@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+	}, {
+		registryPath: "localhost:32000/me/image:1.0@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+		expected:     "localhost:32000/me/image:1.0@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+	}, {
+		registryPath: "docker.io/me/mygitlab:latest",
+		expected:     "docker.io/me/mygitlab:latest",
+	}, {
+		registryPath: "me/mygitlab:latest",
+		expected:     "docker.io/me/mygitlab:latest",
+	}, {
+		registryPath: "me/mygitlab",
+		expected:     "docker.io/me/mygitlab:latest",
+	}, {
+		registryPath: "nginx",
+		expected:     "docker.io/library/nginx:latest",
+	}} {
+		c.Logf("registry path %q", test.registryPath)
+		path, err := resources.CanonicalizeDockerRegistryPath(test.registryPath)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(path, gc.Equals, test.expected)
+	}
+}
+
+func (s *ResourceSuite) TestCanonicalizeDockerRegistryPathInvalid(c *gc.C) {
+	_, err := resources.CanonicalizeDockerRegistryPath("blah:sha256@")
+	c.Assert(err, gc.ErrorMatches, `docker image path "blah:sha256@" not valid`)
+}
+
+func (s *ResourceSuite) TestVerifyImageDigest(c *gc.C) {
+	details := resources.DockerImageDetails{
+		RegistryPath: "gcr.io/kubeflow/jupyterhub-k8s@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+	}
+	err := resources.VerifyImageDigest(details, "sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ResourceSuite) TestVerifyImageDigestMismatch(c *gc.C) {
+	details := resources.DockerImageDetails{
+		RegistryPath: "gcr.io/kubeflow/jupyterhub-k8s@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+	}
+	err := resources.VerifyImageDigest(details, "sha256:0000000000000000000000000000000000000000000000000000000000000000")
+	c.Assert(err, gc.ErrorMatches, `digest mismatch for image "gcr.io/kubeflow/jupyterhub-k8s@sha256:5e2c.*": expected sha256:5e2c.*, got sha256:0000.*`)
+}
+
+func (s *ResourceSuite) TestVerifyImageDigestInvalid(c *gc.C) {
+	details := resources.DockerImageDetails{
+		RegistryPath: "gcr.io/kubeflow/jupyterhub-k8s@sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce",
+	}
+	err := resources.VerifyImageDigest(details, "sha256:nothex")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	details.RegistryPath = "docker.io/me/mygitlab:latest"
+	err = resources.VerifyImageDigest(details, "sha256:5e2c71d050bec85c258a31aa4507ca8adb3b2f5158a4dc919a39118b8879a5ce")
+	c.Assert(err, gc.ErrorMatches, `docker image path "docker.io/me/mygitlab:latest" without digest not valid`)
+}
+
+func (s *ResourceSuite) TestDockerImageDetailsRedacted(c *gc.C) {
+	details := resources.DockerImageDetails{
+		RegistryPath: "registry.staging.jujucharms.com/wallyworld/mysql-k8s/mysql_image",
+		Username:     "docker-registry",
+		Password:     "hunter2",
+	}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		out := fmt.Sprintf(format, details)
+		c.Check(out, gc.Not(jc.Contains), "hunter2", gc.Commentf("format %q", format))
+		c.Check(out, jc.Contains, "<redacted>", gc.Commentf("format %q", format))
+		c.Check(out, jc.Contains, "docker-registry", gc.Commentf("format %q", format))
+	}
+	c.Check(details.Redacted().Password, gc.Equals, "<redacted>")
+	c.Check(details.Password, gc.Equals, "hunter2")
+
+	// Serialisation for the API keeps the credentials.
+	data, err := json.Marshal(details)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := resources.UnmarshalDockerResource(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, details)
+}
+
+func (s *ResourceSuite) TestDockerImageDetailsRedactedNoPassword(c *gc.C) {
+	details := resources.DockerImageDetails{RegistryPath: "mysql/mysql"}
+	c.Check(details.String(), gc.Equals, "{RegistryPath:mysql/mysql Username: Password:}")
+}
+
 func (s *ResourceSuite) TestDockerImageDetailsUnmarshalJson(c *gc.C) {
 	data := []byte(`{"ImageName":"testing@sha256:beef-deed","Username":"docker-registry","Password":"fragglerock"}`)
 	result, err := resources.UnmarshalDockerResource(data)
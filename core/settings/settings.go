@@ -5,6 +5,7 @@ package settings
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/juju/charm/v9"
 	"github.com/juju/errors"
@@ -162,6 +163,45 @@ func (c ItemChanges) EffectiveChanges(defaults charm.Settings) map[string]interf
 	return result
 }
 
+// Conflict describes a setting changed under a branch,
+// whose master value has changed since the branch change was made.
+type Conflict struct {
+	// Key is the setting in conflict.
+	Key string
+	// Baseline is the master value of the setting when it was first
+	// changed under the branch. It is nil if the setting was not set.
+	Baseline interface{}
+	// Master is the current master value of the setting.
+	// It is nil if the setting is not set.
+	Master interface{}
+	// Branch is the value of the setting under the branch.
+	// It is nil if the setting is deleted under the branch.
+	Branch interface{}
+}
+
+// Conflicts returns the changes in this collection for which the input
+// master settings differ from the old value recorded for the change,
+// which is the master value when the setting was first changed.
+// Applying such a change would overwrite the newer master value.
+// Where master has since arrived at the same value as the change,
+// there is no conflict.
+func (c ItemChanges) Conflicts(master map[string]interface{}) []Conflict {
+	var conflicts []Conflict
+	for _, ch := range c {
+		current := master[ch.Key]
+		if reflect.DeepEqual(current, ch.OldValue) || reflect.DeepEqual(current, ch.NewValue) {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{
+			Key:      ch.Key,
+			Baseline: ch.OldValue,
+			Master:   current,
+			Branch:   ch.NewValue,
+		})
+	}
+	return conflicts
+}
+
 // Map is a convenience method for working with collections of changes.
 // It returns a map representation of the change collection,
 // indexed with the change key.
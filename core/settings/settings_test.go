@@ -112,3 +112,38 @@ func (*settingsSuite) TestEffectiveSettings(c *gc.C) {
 	}
 	c.Check(changes.EffectiveChanges(defaults), gc.DeepEquals, exp)
 }
+
+func (*settingsSuite) TestConflicts(c *gc.C) {
+	changes := ItemChanges{
+		MakeAddition("added", "branch-val"),
+		MakeAddition("added-unchanged", "branch-val"),
+		MakeModification("modified", "old-val", "branch-val"),
+		MakeModification("modified-unchanged", "old-val", "branch-val"),
+		MakeModification("modified-converged", "old-val", "branch-val"),
+		MakeDeletion("deleted", "old-val"),
+		MakeDeletion("deleted-converged", "old-val"),
+	}
+
+	master := map[string]interface{}{
+		"added":              "master-val",
+		"modified":           "master-val",
+		"modified-unchanged": "old-val",
+		"modified-converged": "branch-val",
+		"deleted":            "master-val",
+	}
+
+	c.Check(changes.Conflicts(master), jc.DeepEquals, []Conflict{
+		{Key: "added", Baseline: nil, Master: "master-val", Branch: "branch-val"},
+		{Key: "modified", Baseline: "old-val", Master: "master-val", Branch: "branch-val"},
+		{Key: "deleted", Baseline: "old-val", Master: "master-val", Branch: nil},
+	})
+}
+
+func (*settingsSuite) TestConflictsMasterUnset(c *gc.C) {
+	changes := ItemChanges{
+		MakeModification("modified", "old-val", "branch-val"),
+	}
+	c.Check(changes.Conflicts(nil), jc.DeepEquals, []Conflict{
+		{Key: "modified", Baseline: "old-val", Master: nil, Branch: "branch-val"},
+	})
+}
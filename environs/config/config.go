@@ -89,6 +89,11 @@ const (
 	// of OS image metadata for containers.
 	ContainerImageMetadataURLKey = "container-image-metadata-url"
 
+	// ContainerImageRequireSignedMetadataKey is the key used to specify
+	// whether the metadata used to pin container OS images to a
+	// fingerprint must be signed.
+	ContainerImageRequireSignedMetadataKey = "container-image-require-signed-metadata"
+
 	// DashboardStreamKey stores the key used to specify the stream
 	// to used when fetching a dashboard tarball.
 	DashboardStreamKey = "dashboard-stream"
@@ -506,6 +511,9 @@ var defaultConfigValues = map[string]interface{}{
 	ContainerImageStreamKey:      "released",
 	ContainerImageMetadataURLKey: "",
 
+	// Container image pinning settings.
+	ContainerImageRequireSignedMetadataKey: false,
+
 	// Log forward settings.
 	LogForwardEnabled: false,
 
@@ -721,6 +729,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	// The LXD client used for containers is not able to connect to OCI
+	// registries, so reject them rather than failing to start containers.
+	if v, ok := cfg.defined[ContainerImageMetadataURLKey].(string); ok && strings.HasPrefix(v, "oci://") {
+		return errors.NotSupportedf("OCI registry %q as %s", v, ContainerImageMetadataURLKey)
+	}
+
 	if raw, ok := cfg.defined[CloudInitUserDataKey].(string); ok && raw != "" {
 		userDataMap, err := ensureStringMaps(raw)
 		if err != nil {
@@ -1209,6 +1223,13 @@ func (c *Config) ContainerImageMetadataURL() (string, bool) {
 	return "", false
 }
 
+// ContainerImageRequireSignedMetadata returns whether the metadata used to
+// pin container OS images to a fingerprint must be signed.
+func (c *Config) ContainerImageRequireSignedMetadata() bool {
+	value, _ := c.defined[ContainerImageRequireSignedMetadataKey].(bool)
+	return value
+}
+
 // Development returns whether the environment is in development mode.
 func (c *Config) Development() bool {
 	value, _ := c.defined["development"].(bool)
@@ -1662,6 +1683,8 @@ var alwaysOptional = schema.Defaults{
 	DefaultSpace:                  schema.Omit,
 	LXDSnapChannel:                schema.Omit,
 	CharmHubURLKey:                schema.Omit,
+
+	ContainerImageRequireSignedMetadataKey: schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -2010,6 +2033,11 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ContainerImageRequireSignedMetadataKey: {
+		Description: `Whether the simplestreams metadata used to pin LXD container images to a fingerprint must be signed`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	"logging-config": {
 		Description: `The configuration string to use when configuring Juju agent logging (see http://godoc.org/github.com/juju/loggo#ParseConfigurationString for details)`,
 		Type:        environschema.Tstring,
@@ -105,6 +105,13 @@ var configTests = []configTest{
 			"agent-metadata-url":           "agent-metadata-url-value",
 			"container-image-metadata-url": "container-image-metadata-url-value",
 		}),
+	}, {
+		about:       "OCI container image metadata URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"container-image-metadata-url": "oci://registry.example.com",
+		}),
+		err: `OCI registry "oci://registry.example.com" as container-image-metadata-url not supported`,
 	}, {
 		about:       "Explicit series",
 		useDefaults: config.UseDefaults,
@@ -1509,6 +1516,16 @@ func (s *ConfigSuite) TestCoerceForStorage(c *gc.C) {
 	c.Assert(tagsMap, gc.DeepEquals, expectedTags)
 }
 
+func (s *ConfigSuite) TestContainerImageRequireSignedMetadata(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.ContainerImageRequireSignedMetadata(), jc.IsFalse)
+
+	config = newTestConfig(c, testing.Attrs{
+		"container-image-require-signed-metadata": true})
+	c.Assert(config.ContainerImageRequireSignedMetadata(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestLXDSnapChannelConfig(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{
@@ -41,3 +41,25 @@ func IsAvailabilityZoneIndependent(err error) bool {
 	}
 	return false
 }
+
+// QuotaExceededError provides an interface for compute providers to
+// indicate that an operation failed because it would exceed a limit
+// on the resources available to the model.
+type QuotaExceededError interface {
+	error
+
+	// QuotaExceeded reports whether or not the error
+	// is due to a limit on the model's resources.
+	QuotaExceeded() bool
+}
+
+// IsQuotaExceeded reports whether or not the given error, or its cause,
+// indicates that a limit on the resources available to the model has
+// been reached. Juju uses this to avoid reattempting operations that
+// cannot succeed until the limit is raised or resources are released.
+func IsQuotaExceeded(err error) bool {
+	if err, ok := errors.Cause(err).(QuotaExceededError); ok {
+		return err.QuotaExceeded()
+	}
+	return false
+}
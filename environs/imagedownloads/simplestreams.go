@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/os/v2/series"
@@ -65,6 +66,13 @@ type Metadata struct {
 	SHA256  string `json:"sha256,omitempty"`
 	Path    string `json:"path,omitempty"`
 	Size    int64  `json:"size,omitempty"`
+
+	// CombinedSquashFSSHA256 and CombinedRootXzSHA256 are published
+	// for LXD metadata tarballs. They are the fingerprints LXD gives the
+	// image combining the tarball with the squashfs or xz compressed
+	// root file system respectively.
+	CombinedSquashFSSHA256 string `json:"combined_squashfs_sha256,omitempty"`
+	CombinedRootXzSHA256   string `json:"combined_rootxz_sha256,omitempty"`
 }
 
 // DownloadURL returns the URL representing the image.
@@ -79,6 +87,20 @@ func (m *Metadata) DownloadURL(baseURL string) (*url.URL, error) {
 	return u, nil
 }
 
+// LXDFingerprint returns the fingerprint of the LXD image formed from
+// this metadata tarball, preferring that with a squashfs root file system
+// as LXD does. An empty string is returned if no fingerprint is published.
+func (m *Metadata) LXDFingerprint() string {
+	for _, sum := range []string{m.CombinedSquashFSSHA256, m.CombinedRootXzSHA256} {
+		// Older metadata has the sums as output by sha256sum,
+		// with the input file name "-" following the sum.
+		if fields := strings.Fields(sum); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
 // Fetch gets product results as Metadata from the provided datasources, given
 // some constraints and an optional filter function.
 func Fetch(
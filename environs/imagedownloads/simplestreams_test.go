@@ -241,6 +241,30 @@ func (Suite) TestOnePpc64elXenialImg(c *gc.C) {
 	})
 }
 
+func (Suite) TestOneLXDFingerprint(c *gc.C) {
+	ts := httptest.NewServer(&sstreamsHandler{})
+	defer ts.Close()
+	got, err := One("amd64", "xenial", "", "lxd.tar.xz", newTestDataSourceFunc(ts.URL))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got.Path, gc.Equals, "server/releases/xenial/release-20161020/ubuntu-16.04-server-cloudimg-amd64-lxd.tar.xz")
+
+	// The squashfs image is preferred.
+	c.Check(got.LXDFingerprint(), gc.Equals, "315bedd32580c3fb79fd2003746245b9fe6a8863fc9dd990c3a2dc90f4930039")
+
+	// Only an xz compressed root file system is published for precise.
+	got, err = One("amd64", "precise", "", "lxd.tar.xz", newTestDataSourceFunc(ts.URL))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got.LXDFingerprint(), gc.Equals, "9f871694e52c1d0977c94c04826c7aed01b4401bc3e0dc1b94ce3600ebe5dee2")
+}
+
+func (Suite) TestLXDFingerprintSHA256SumOutput(c *gc.C) {
+	md := &Metadata{
+		CombinedRootXzSHA256: "bcbb04aa0e05f16fd342d44bb4e50b0a896784f857abbb8bda5789606e608907  -",
+	}
+	c.Check(md.LXDFingerprint(), gc.Equals, "bcbb04aa0e05f16fd342d44bb4e50b0a896784f857abbb8bda5789606e608907")
+	c.Check((&Metadata{}).LXDFingerprint(), gc.Equals, "")
+}
+
 func (Suite) TestOneErrors(c *gc.C) {
 	table := []struct {
 		description, arch, series, stream, ftype, errorMatch string
@@ -225,6 +225,10 @@ application-config:
     description: determines how the Service is exposed
     source: unset
     type: string
+  kubernetes-termination-grace-period:
+    description: seconds a unit pod is given to shut down cleanly when the unit is removed
+    source: unset
+    type: int
   trust:
     default: false
     description: Does this application have access to trusted credentials
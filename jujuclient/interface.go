@@ -68,6 +68,11 @@ type ControllerDetails struct {
 	// which a user has access. It is cached here so under normal
 	// usage list-controllers does not need to hit the server.
 	MachineCount *int `yaml:"machine-count,omitempty"`
+
+	// CacheStatus is the health of the controller's model cache
+	// when the details were last refreshed. It is empty if the
+	// controller doesn't report it.
+	CacheStatus string `yaml:"cache-status,omitempty"`
 }
 
 // ModelDetails holds details of a model.
@@ -33,6 +33,33 @@ func (zoneIndependentError) AvailabilityZoneIndependent() bool {
 	return true
 }
 
+// QuotaExceededError wraps the given error such that it satisfies
+// environs.IsQuotaExceeded. Quotas apply to the model as a whole, so
+// the error also satisfies environs.IsAvailabilityZoneIndependent.
+func QuotaExceededError(err error) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.Wrap(err, quotaExceededError{err})
+	wrapped.(*errors.Err).SetLocation(1)
+	return wrapped
+}
+
+type quotaExceededError struct {
+	error
+}
+
+// QuotaExceeded is part of the environs.QuotaExceededError interface.
+func (quotaExceededError) QuotaExceeded() bool {
+	return true
+}
+
+// AvailabilityZoneIndependent is part of the
+// environs.AvailabilityZoneError interface.
+func (quotaExceededError) AvailabilityZoneIndependent() bool {
+	return true
+}
+
 // credentialNotValid represents an error when a provider credential is not valid.
 // Realistically, this is not a transient error. Without a valid credential we
 // cannot do much on the provider. This is fatal.
@@ -34,6 +34,17 @@ func (*ErrorsSuite) TestWrapZoneIndependentError(c *gc.C) {
 .*/provider/common/errors_test.go:.*: bar: foo`[1:])
 }
 
+func (*ErrorsSuite) TestWrapQuotaExceededError(c *gc.C) {
+	err1 := errors.New("foo")
+	c.Assert(err1, gc.Not(jc.Satisfies), environs.IsQuotaExceeded)
+
+	wrapped := common.QuotaExceededError(err1)
+	c.Assert(wrapped, jc.Satisfies, environs.IsQuotaExceeded)
+	c.Assert(wrapped, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+	c.Assert(wrapped, gc.ErrorMatches, "foo")
+	c.Assert(common.QuotaExceededError(nil), jc.ErrorIsNil)
+}
+
 func (s *ErrorsSuite) TestInvalidCredentialWrapped(c *gc.C) {
 	err1 := errors.New("foo")
 	err2 := errors.Annotate(err1, "bar")
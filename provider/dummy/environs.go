@@ -2036,6 +2036,7 @@ func (*fakePresence) Activity(server string, id uint64)
 func (*fakePresence) ServerDown(server string)                                       {}
 func (*fakePresence) UpdateServer(server string, connections []presence.Value) error { return nil }
 func (f *fakePresence) Connections() presence.Connections                            { return f }
+func (*fakePresence) SetChurnThreshold(presence.ChurnThreshold) error                { return nil }
 
 func (f *fakePresence) ForModel(model string) presence.Connections   { return f }
 func (f *fakePresence) ForServer(server string) presence.Connections { return f }
@@ -2053,6 +2054,10 @@ func (f *fakePresence) AgentStatus(agent string) (presence.Status, error) {
 	return presence.Alive, nil
 }
 
+func (*fakePresence) Flapping(agent string) (bool, error) {
+	return false, nil
+}
+
 type noopRegisterer struct {
 	prometheus.Registerer
 }
@@ -25,6 +25,7 @@ type Client interface {
 	ResourcePools(context.Context, string) ([]*object.ResourcePool, error)
 	CreateVirtualMachine(context.Context, vsphereclient.CreateVirtualMachineParams) (*mo.VirtualMachine, error)
 	Folders(ctx context.Context) (*object.DatacenterFolders, error)
+	HostSystems(context.Context, *mo.ComputeResource) ([]mo.HostSystem, error)
 	Datastores(context.Context) ([]mo.Datastore, error)
 	DeleteDatastoreFile(context.Context, string) error
 	DestroyVMFolder(context.Context, string) error
@@ -32,6 +33,8 @@ type Client interface {
 	MoveVMFolderInto(context.Context, string, string) error
 	MoveVMsInto(context.Context, string, ...types.ManagedObjectReference) error
 	RemoveVirtualMachines(context.Context, string) error
+	ResourceUsage(context.Context, string) (vsphereclient.ResourceUsage, error)
+	TagVirtualMachine(context.Context, *mo.VirtualMachine, map[string]string) error
 	UpdateVirtualMachineExtraConfig(context.Context, *mo.VirtualMachine, map[string]string) error
 	VirtualMachines(context.Context, string) ([]*mo.VirtualMachine, error)
 	UserHasRootLevelPrivilege(context.Context, string) (bool, error)
@@ -4,10 +4,14 @@
 package vsphere
 
 import (
+	"strings"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 )
 
 // The vmware-specific config keys.
@@ -16,6 +20,20 @@ const (
 	cfgExternalNetwork = "external-network"
 	cfgDatastore       = "datastore"
 	cfgEnableDiskUUID  = "enable-disk-uuid"
+	cfgVMTags          = "vm-tags"
+	cfgMaxVMs          = "max-vms"
+	cfgMaxCPU          = "max-cpu"
+	cfgMaxMemory       = "max-memory"
+	cfgSpaceNetworks   = "space-networks"
+)
+
+// vmTagKeys are the instance tags that may be applied
+// to virtual machines as vSphere tags.
+var vmTagKeys = set.NewStrings(
+	tags.JujuController,
+	tags.JujuModel,
+	tags.JujuMachine,
+	tags.JujuUnitsDeployed,
 )
 
 // configFields is the spec for each vmware config value's type.
@@ -25,6 +43,11 @@ var (
 		cfgDatastore:       schema.String(),
 		cfgPrimaryNetwork:  schema.String(),
 		cfgEnableDiskUUID:  schema.Bool(),
+		cfgVMTags:          schema.List(schema.String()),
+		cfgMaxVMs:          schema.ForceInt(),
+		cfgMaxCPU:          schema.ForceInt(),
+		cfgMaxMemory:       schema.ForceInt(),
+		cfgSpaceNetworks:   schema.List(schema.String()),
 	}
 
 	configDefaults = schema.Defaults{
@@ -32,6 +55,11 @@ var (
 		cfgDatastore:       schema.Omit,
 		cfgPrimaryNetwork:  schema.Omit,
 		cfgEnableDiskUUID:  true,
+		cfgVMTags:          schema.Omit,
+		cfgMaxVMs:          schema.Omit,
+		cfgMaxCPU:          schema.Omit,
+		cfgMaxMemory:       schema.Omit,
+		cfgSpaceNetworks:   schema.Omit,
 	}
 
 	configRequiredFields  = []string{}
@@ -99,6 +127,60 @@ func (c *environConfig) enableDiskUUID() bool {
 	return c.attrs[cfgEnableDiskUUID].(bool)
 }
 
+// vmTags returns the keys of the instance tags
+// to apply to virtual machines as vSphere tags.
+func (c *environConfig) vmTags() []string {
+	values, _ := c.attrs[cfgVMTags].([]interface{})
+	keys := make([]string, len(values))
+	for i, v := range values {
+		keys[i] = v.(string)
+	}
+	return keys
+}
+
+// maxVMs returns the maximum number of virtual machines
+// in the model, or zero if the number is unlimited.
+func (c *environConfig) maxVMs() int {
+	max, _ := c.attrs[cfgMaxVMs].(int)
+	return max
+}
+
+// maxCPU returns the maximum number of CPUs allocated to the
+// model's virtual machines, or zero if the number is unlimited.
+func (c *environConfig) maxCPU() int {
+	max, _ := c.attrs[cfgMaxCPU].(int)
+	return max
+}
+
+// maxMemory returns the maximum memory in MiB allocated to the
+// model's virtual machines, or zero if the memory is unlimited.
+func (c *environConfig) maxMemory() int {
+	max, _ := c.attrs[cfgMaxMemory].(int)
+	return max
+}
+
+// spaceNetworks returns the names of the vSphere networks, such as
+// port groups, keyed by the names of the Juju spaces mapped to them
+// by entries of the form <space>=<network> in the space-networks config.
+func (c *environConfig) spaceNetworks() map[string]string {
+	values, _ := c.attrs[cfgSpaceNetworks].([]interface{})
+	networks := make(map[string]string, len(values))
+	for _, v := range values {
+		if space, network, ok := parseSpaceNetwork(v.(string)); ok {
+			networks[space] = network
+		}
+	}
+	return networks
+}
+
+func parseSpaceNetwork(value string) (space, network string, ok bool) {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
 // validate checks vmware-specific config values.
 func (c environConfig) validate() error {
 	// All fields must be populated, even with just the default.
@@ -107,6 +189,29 @@ func (c environConfig) validate() error {
 			return errors.Errorf("%s: must not be empty", field)
 		}
 	}
+	for _, key := range c.vmTags() {
+		if !vmTagKeys.Contains(key) {
+			return errors.Errorf("%s: unknown tag %q, expected one of %s",
+				cfgVMTags, key, strings.Join(vmTagKeys.SortedValues(), ", "))
+		}
+	}
+	for _, field := range []string{cfgMaxVMs, cfgMaxCPU, cfgMaxMemory} {
+		if max, _ := c.attrs[field].(int); max < 0 {
+			return errors.Errorf("%s: must not be negative", field)
+		}
+	}
+	values, _ := c.attrs[cfgSpaceNetworks].([]interface{})
+	spaces := set.NewStrings()
+	for _, v := range values {
+		space, _, ok := parseSpaceNetwork(v.(string))
+		if !ok {
+			return errors.Errorf("%s: invalid entry %q, expected <space>=<network>", cfgSpaceNetworks, v)
+		}
+		if spaces.Contains(space) {
+			return errors.Errorf("%s: space %q mapped more than once", cfgSpaceNetworks, space)
+		}
+		spaces.Add(space)
+	}
 	return nil
 }
 
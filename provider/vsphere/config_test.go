@@ -240,3 +240,53 @@ func (s *ConfigSuite) TestSetConfig(c *gc.C) {
 		}
 	}
 }
+
+func (s *ConfigSuite) TestValidateVMTags(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{
+		"vm-tags": []interface{}{"juju-model-uuid", "juju-machine-id"},
+	})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg = fakeConfig(c, testing.Attrs{
+		"vm-tags": []interface{}{"juju-model-uuid", "owner"},
+	})
+	_, err = s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: vm-tags: unknown tag "owner", expected one of .*`)
+}
+
+func (s *ConfigSuite) TestValidateSpaceNetworks(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{
+		"space-networks": []interface{}{"db=db-portgroup", "public=VM Network"},
+	})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg = fakeConfig(c, testing.Attrs{
+		"space-networks": []interface{}{"db"},
+	})
+	_, err = s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: space-networks: invalid entry "db", expected <space>=<network>`)
+
+	cfg = fakeConfig(c, testing.Attrs{
+		"space-networks": []interface{}{"db=one", "db=two"},
+	})
+	_, err = s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: space-networks: space "db" mapped more than once`)
+}
+
+func (s *ConfigSuite) TestValidateResourceLimits(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{
+		"max-vms":    10,
+		"max-cpu":    "20",
+		"max-memory": 40960,
+	})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg = fakeConfig(c, testing.Attrs{
+		"max-cpu": -1,
+	})
+	_, err = s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: max-cpu: must not be negative`)
+}
@@ -17,7 +17,9 @@ import (
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
 	callcontext "github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/vsphere/internal/vsphereclient"
 )
 
 // Note: This provider/environment does *not* implement storage.
@@ -32,6 +34,14 @@ type environ struct {
 
 	lock sync.Mutex // lock protects access the following fields.
 	ecfg *environConfig
+
+	// reservationLock protects access to reserved.
+	reservationLock sync.Mutex
+
+	// reserved holds the resources requested by StartInstance
+	// calls that have not yet completed. They are counted
+	// against the model's limits along with existing VMs.
+	reserved vsphereclient.ResourceUsage
 }
 
 func newEnviron(
@@ -174,14 +184,104 @@ func (env *environ) AdoptResources(ctx callcontext.ProviderCallContext, controll
 	})
 }
 
+// adoptResourcesPrivileges are the privileges required to move a model's
+// VM folder under another controller's folder, and to rewrite the
+// ExtraConfig of the model's VMs.
+var adoptResourcesPrivileges = []string{
+	"Folder.Create",
+	"Folder.Move",
+	"VirtualMachine.Config.AdvancedConfig",
+}
+
 // AdoptResources is part of the Environ interface.
+// The model's VM folder is moved under the folder for the new controller,
+// and each VM has its controller UUID rewritten, both in its ExtraConfig
+// and, if the model's vm-tags config includes it, in its vSphere tags.
+// Each step is skipped if it has already been done, so a partial adoption
+// can be retried.
 func (env *sessionEnviron) AdoptResources(ctx callcontext.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
-	err := env.client.MoveVMFolderInto(env.ctx,
-		path.Join(env.getVMFolder(), controllerFolderName(controllerUUID)),
-		path.Join(env.getVMFolder(), controllerFolderName("*"), env.modelFolderName()),
-	)
-	HandleCredentialError(err, env, ctx)
-	return err
+	// Verify permissions up front, so that we don't leave the
+	// model half adopted if we are not able to complete it.
+	for _, privilege := range adoptResourcesPrivileges {
+		ok, err := env.client.UserHasRootLevelPrivilege(env.ctx, privilege)
+		if err != nil {
+			HandleCredentialError(err, env, ctx)
+			return errors.Trace(err)
+		}
+		if !ok {
+			return errors.Errorf("adopting resources requires the %q privilege", privilege)
+		}
+	}
+
+	vmFolder := env.getVMFolder()
+	controllerFolder := controllerFolderName(controllerUUID)
+	modelFolderPath := path.Join(vmFolder, controllerFolder, env.modelFolderName())
+
+	_, err := env.client.FindFolder(env.ctx, modelFolderPath)
+	if errors.IsNotFound(err) {
+		// The controller's folder will not exist if this
+		// is the first model in this cloud to be migrated to it.
+		if _, err := env.client.EnsureVMFolder(env.ctx, vmFolder, controllerFolder); err != nil {
+			HandleCredentialError(err, env, ctx)
+			return errors.Annotate(err, "creating controller folder")
+		}
+		if err := env.client.MoveVMFolderInto(env.ctx,
+			path.Join(vmFolder, controllerFolder),
+			path.Join(vmFolder, controllerFolderName("*"), env.modelFolderName()),
+		); err != nil {
+			HandleCredentialError(err, env, ctx)
+			return errors.Annotate(err, "moving model folder")
+		}
+	} else if err != nil {
+		HandleCredentialError(err, env, ctx)
+		return errors.Trace(err)
+	}
+
+	vms, err := env.client.VirtualMachines(env.ctx, modelFolderPath+"/*")
+	if err != nil {
+		HandleCredentialError(err, env, ctx)
+		return errors.Trace(err)
+	}
+	for _, vm := range vms {
+		if vmControllerUUID(vm) == controllerUUID {
+			continue
+		}
+		logger.Debugf("updating controller UUID for VM %q", vm.Name)
+		// The vSphere tag is updated before the ExtraConfig, since the
+		// latter is what marks the VM as adopted on a retry.
+		if env.hasVMTag(tags.JujuController) {
+			if err := env.client.TagVirtualMachine(env.ctx, vm, map[string]string{
+				tags.JujuController: controllerUUID,
+			}); err != nil {
+				HandleCredentialError(err, env, ctx)
+				return errors.Annotatef(err, "tagging VM %s", vm.Name)
+			}
+		}
+		if err := env.client.UpdateVirtualMachineExtraConfig(env.ctx, vm, map[string]string{
+			tags.JujuController: controllerUUID,
+		}); err != nil {
+			HandleCredentialError(err, env, ctx)
+			return errors.Annotatef(err, "updating VM %s", vm.Name)
+		}
+	}
+	return nil
+}
+
+// vmControllerUUID returns the controller UUID
+// recorded in the VM's ExtraConfig, if any.
+func vmControllerUUID(vm *mo.VirtualMachine) string {
+	if vm.Config == nil {
+		return ""
+	}
+	for _, item := range vm.Config.ExtraConfig {
+		value := item.GetOptionValue()
+		if value.Key == tags.JujuController {
+			if uuid, ok := value.Value.(string); ok {
+				return uuid
+			}
+		}
+	}
+	return ""
 }
 
 // Destroy is part of the environs.Environ interface.
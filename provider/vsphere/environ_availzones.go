@@ -177,13 +177,13 @@ func (env *environ) DeriveAvailabilityZones(ctx context.ProviderCallContext, arg
 // DeriveAvailabilityZones is part of the common.ZonedEnviron interface.
 func (env *sessionEnviron) DeriveAvailabilityZones(ctx context.ProviderCallContext, args environs.StartInstanceParams) ([]string, error) {
 	if args.Placement != "" {
-		// args.Placement will always be a zone name or empty.
+		// args.Placement will always include a zone name.
 		placement, err := env.parsePlacement(ctx, args.Placement)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if placement.Name() != "" {
-			return []string{placement.Name()}, nil
+		if placement.zone.Name() != "" {
+			return []string{placement.zone.Name()}, nil
 		}
 	}
 	return nil, nil
@@ -8,16 +8,19 @@ import (
 	"io"
 	"net/http"
 	"path"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/vmware/govmomi/vim25/mo"
 
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -79,8 +82,22 @@ func (env *sessionEnviron) StartInstance(ctx context.ProviderCallContext, args e
 	if err := env.finishMachineConfig(args, img); err != nil {
 		return nil, common.ZoneIndependentError(err)
 	}
+	interfaces, networkDevices, err := env.instanceNetworks(args)
+	if err != nil {
+		args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		return nil, common.ZoneIndependentError(err)
+	}
+
+	release, err := env.reserveResources(args)
+	if err != nil {
+		if environs.IsQuotaExceeded(err) {
+			args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		}
+		return nil, errors.Trace(err)
+	}
+	defer release()
 
-	vm, hw, err := env.newRawInstance(ctx, args, img)
+	vm, hw, err := env.newRawInstance(ctx, args, img, interfaces, networkDevices)
 	if err != nil {
 		args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
 		return nil, errors.Trace(err)
@@ -90,12 +107,154 @@ func (env *sessionEnviron) StartInstance(ctx context.ProviderCallContext, args e
 	logger.Tracef("instance data %+v", vm)
 	inst := newInstance(vm, env.environ)
 	result := environs.StartInstanceResult{
-		Instance: inst,
-		Hardware: hw,
+		Instance:    inst,
+		Hardware:    hw,
+		NetworkInfo: interfaces,
 	}
 	return &result, nil
 }
 
+// reserveResources checks that creating a VM for the input args would
+// not exceed the model's configured VM, CPU or memory limits, and if not,
+// reserves the requested resources until the returned function is called.
+// The model's usage is counted from the VMs in its folder, plus those
+// reserved by other StartInstance calls still in flight. CPUs and memory
+// not constrained are reserved at the size of the VM template.
+func (env *sessionEnviron) reserveResources(args environs.StartInstanceParams) (func(), error) {
+	maxVMs, maxCPU, maxMemory := env.ecfg.maxVMs(), env.ecfg.maxCPU(), env.ecfg.maxMemory()
+	if maxVMs == 0 && maxCPU == 0 && maxMemory == 0 {
+		return func() {}, nil
+	}
+
+	request := vsphereclient.ResourceUsage{
+		VirtualMachines: 1,
+		CPUs:            vsphereclient.DefaultCPUs,
+		MemoryMB:        vsphereclient.DefaultMemoryMB,
+	}
+	if args.Constraints.HasCpuCores() {
+		request.CPUs = int(*args.Constraints.CpuCores)
+	}
+	if args.Constraints.HasMem() {
+		request.MemoryMB = int64(*args.Constraints.Mem)
+	}
+
+	// The usage is read with the lock held, so that a VM created by a
+	// concurrent call is not missing from both the usage and reservations.
+	env.reservationLock.Lock()
+	defer env.reservationLock.Unlock()
+
+	modelFolderPath := path.Join(env.getVMFolder(), controllerFolderName("*"), env.modelFolderName())
+	usage, err := env.client.ResourceUsage(env.ctx, modelFolderPath+"/*")
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model resource usage")
+	}
+
+	vms := usage.VirtualMachines + env.reserved.VirtualMachines + request.VirtualMachines
+	cpus := usage.CPUs + env.reserved.CPUs + request.CPUs
+	memory := usage.MemoryMB + env.reserved.MemoryMB + request.MemoryMB
+	switch {
+	case maxVMs > 0 && vms > maxVMs:
+		return nil, common.QuotaExceededError(errors.Errorf(
+			"model limit of %d VMs reached", maxVMs))
+	case maxCPU > 0 && cpus > maxCPU:
+		return nil, common.QuotaExceededError(errors.Errorf(
+			"starting instance would exceed model limit of %d CPUs", maxCPU))
+	case maxMemory > 0 && memory > int64(maxMemory):
+		return nil, common.QuotaExceededError(errors.Errorf(
+			"starting instance would exceed model limit of %dMiB memory", maxMemory))
+	}
+
+	env.reserved.VirtualMachines += request.VirtualMachines
+	env.reserved.CPUs += request.CPUs
+	env.reserved.MemoryMB += request.MemoryMB
+	return func() {
+		env.reservationLock.Lock()
+		defer env.reservationLock.Unlock()
+		env.reserved.VirtualMachines -= request.VirtualMachines
+		env.reserved.CPUs -= request.CPUs
+		env.reserved.MemoryMB -= request.MemoryMB
+	}, nil
+}
+
+// instanceNetworks returns the network interfaces to configure on a new
+// instance, along with the network devices to attach to its VM. The first
+// interface is connected to the primary network, and the second to the
+// external network if one is configured. An interface is then added for
+// each network mapped by the space-networks config to a space required by
+// the instance's spaces constraints or endpoint bindings, unless the
+// network is already connected.
+func (env *sessionEnviron) instanceNetworks(
+	args environs.StartInstanceParams,
+) (corenetwork.InterfaceInfos, []vsphereclient.NetworkDevice, error) {
+	spaceNetworks := env.ecfg.spaceNetworks()
+	spaces, err := requiredSpaces(args.Constraints, args.EndpointBindings, spaceNetworks)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	networks := []string{env.ecfg.primaryNetwork()}
+	// TODO(wpk) We need to add a firewall -AND- make sure that if it's a controller we
+	// have API port open.
+	if externalNetwork := env.ecfg.externalNetwork(); externalNetwork != "" {
+		networks = append(networks, externalNetwork)
+	}
+	connected := set.NewStrings(networks...)
+	for _, space := range spaces {
+		if network := spaceNetworks[space]; !connected.Contains(network) {
+			networks = append(networks, network)
+			connected.Add(network)
+		}
+	}
+
+	interfaces := make(corenetwork.InterfaceInfos, len(networks))
+	networkDevices := make([]vsphereclient.NetworkDevice, len(networks))
+	for i, network := range networks {
+		mac, err := vsphereclient.GenerateMAC()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		interfaces[i] = corenetwork.InterfaceInfo{
+			DeviceIndex:       i,
+			InterfaceName:     fmt.Sprintf("eth%d", i),
+			MACAddress:        mac,
+			ProviderNetworkId: corenetwork.Id(network),
+			InterfaceType:     corenetwork.EthernetInterface,
+			ConfigType:        corenetwork.ConfigDHCP,
+			Origin:            corenetwork.OriginProvider,
+		}
+		networkDevices[i] = vsphereclient.NetworkDevice{MAC: mac, Network: network}
+	}
+	return interfaces, networkDevices, nil
+}
+
+// requiredSpaces returns the names of the spaces required by the input
+// spaces constraints and endpoint bindings that are mapped to networks
+// by the space-networks config, sorted by name. An error is returned if
+// any other space is required, except for the alpha space, which is
+// served by the primary network unless mapped otherwise.
+func requiredSpaces(
+	cons constraints.Value, bindings map[string]corenetwork.Id, spaceNetworks map[string]string,
+) ([]string, error) {
+	spaces := set.NewStrings(cons.IncludeSpaces()...)
+	for _, space := range bindings {
+		spaces.Add(string(space))
+	}
+
+	var required, missing []string
+	for _, space := range spaces.SortedValues() {
+		if _, ok := spaceNetworks[space]; ok {
+			required = append(required, space)
+		} else if space != "" && space != corenetwork.AlphaSpaceName {
+			missing = append(missing, space)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errors.NotFoundf("networks for spaces %s in %s config",
+			strings.Join(missing, ", "), cfgSpaceNetworks)
+	}
+	return required, nil
+}
+
 // FinishInstanceConfig is exported, because it has to be rewritten in external unit tests
 var FinishInstanceConfig = instancecfg.FinishInstanceConfig
 
@@ -119,6 +278,8 @@ func (env *sessionEnviron) newRawInstance(
 	ctx context.ProviderCallContext,
 	args environs.StartInstanceParams,
 	img *OvaFileMetadata,
+	interfaces corenetwork.InterfaceInfos,
+	networkDevices []vsphereclient.NetworkDevice,
 ) (_ *mo.VirtualMachine, _ *instance.HardwareCharacteristics, err error) {
 	if args.AvailabilityZone == "" {
 		return nil, nil, errors.NotValidf("empty available zone")
@@ -140,37 +301,6 @@ func (env *sessionEnviron) newRawInstance(
 	// Make sure the hostname is resolvable by adding it to /etc/hosts.
 	cloudcfg.ManageEtcHosts(true)
 
-	internalMac, err := vsphereclient.GenerateMAC()
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-
-	interfaces := corenetwork.InterfaceInfos{{
-		InterfaceName: "eth0",
-		MACAddress:    internalMac,
-		InterfaceType: corenetwork.EthernetInterface,
-		ConfigType:    corenetwork.ConfigDHCP,
-		Origin:        corenetwork.OriginProvider,
-	}}
-	networkDevices := []vsphereclient.NetworkDevice{{MAC: internalMac, Network: env.ecfg.primaryNetwork()}}
-
-	// TODO(wpk) We need to add a firewall -AND- make sure that if it's a controller we
-	// have API port open.
-	externalNetwork := env.ecfg.externalNetwork()
-	if externalNetwork != "" {
-		externalMac, err := vsphereclient.GenerateMAC()
-		if err != nil {
-			return nil, nil, errors.Trace(err)
-		}
-		interfaces = append(interfaces, corenetwork.InterfaceInfo{
-			InterfaceName: "eth1",
-			MACAddress:    externalMac,
-			InterfaceType: corenetwork.EthernetInterface,
-			ConfigType:    corenetwork.ConfigDHCP,
-			Origin:        corenetwork.OriginProvider,
-		})
-		networkDevices = append(networkDevices, vsphereclient.NetworkDevice{MAC: externalMac, Network: externalNetwork})
-	}
 	// TODO(wpk) There's no (known) way to tell cloud-init to disable network (using cloudinit.CloudInitNetworkConfigDisabled)
 	// so the network might be double-configured. That should be ok as long as we're using DHCP.
 	err = cloudcfg.AddNetworkConfig(interfaces)
@@ -243,6 +373,19 @@ func (env *sessionEnviron) newRawInstance(
 	createVMArgs.ComputeResource = &availZone.r
 	createVMArgs.ResourcePool = availZone.pool.Reference()
 
+	// A host may only be requested by placement, in which
+	// case the zone has been derived from the same placement.
+	if args.Placement != "" {
+		placement, err := env.parsePlacement(ctx, args.Placement)
+		if err != nil {
+			return nil, nil, common.ZoneIndependentError(err)
+		}
+		if placement.host != nil {
+			hostRef := placement.host.Reference()
+			createVMArgs.HostSystem = &hostRef
+		}
+	}
+
 	vm, err := env.client.CreateVirtualMachine(env.ctx, createVMArgs)
 	if vsphereclient.IsExtendDiskError(err) {
 		// Ensure we don't try to make the same extension across
@@ -254,6 +397,7 @@ func (env *sessionEnviron) newRawInstance(
 		return nil, nil, errors.Trace(err)
 
 	}
+	env.tagVirtualMachine(vm, args.InstanceConfig.Tags)
 
 	hw := &instance.HardwareCharacteristics{
 		Arch:           &img.Arch,
@@ -266,6 +410,36 @@ func (env *sessionEnviron) newRawInstance(
 	return vm, hw, err
 }
 
+// tagVirtualMachine applies the instance tags selected by the model's
+// vm-tags config to the virtual machine as vSphere tags. Tagging is not
+// essential to the operation of the machine, so failures are logged as
+// warnings rather than failing instance creation.
+func (env *sessionEnviron) tagVirtualMachine(vm *mo.VirtualMachine, instanceTags map[string]string) {
+	vmTags := make(map[string]string)
+	for _, key := range env.ecfg.vmTags() {
+		if value, ok := instanceTags[key]; ok && value != "" {
+			vmTags[key] = value
+		}
+	}
+	if len(vmTags) == 0 {
+		return
+	}
+	if err := env.client.TagVirtualMachine(env.ctx, vm, vmTags); err != nil {
+		logger.Warningf("failed to apply tags to VM %q: %v", vm.Name, err)
+	}
+}
+
+// hasVMTag reports whether the vm-tags config
+// includes the instance tag with the input key.
+func (env *sessionEnviron) hasVMTag(key string) bool {
+	for _, vmTag := range env.ecfg.vmTags() {
+		if vmTag == key {
+			return true
+		}
+	}
+	return false
+}
+
 // AllInstances implements environs.InstanceBroker.
 func (env *environ) AllInstances(ctx context.ProviderCallContext) (instances []instances.Instance, err error) {
 	err = env.withSession(ctx, func(env *sessionEnviron) error {
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/utils/v2/arch"
 	"github.com/juju/version"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
 	gc "gopkg.in/check.v1"
@@ -28,6 +29,7 @@ import (
 	corearch "github.com/juju/juju/core/arch"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -187,6 +189,66 @@ func (s *legacyEnvironBrokerSuite) TestStartInstanceNetwork(c *gc.C) {
 	c.Assert(createVMArgs.NetworkDevices[1].Network, gc.Equals, "bar")
 }
 
+func (s *legacyEnvironBrokerSuite) TestStartInstanceSpaceNetworks(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{
+			"primary-network":    "foo",
+			"external-network":   "bar",
+			"space-networks":     []interface{}{"db=db-pg", "public=bar", "unused=baz"},
+			"image-metadata-url": s.imageServer.URL,
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Constraints = constraints.MustParse("spaces=db,alpha")
+	startInstArgs.EndpointBindings = map[string]corenetwork.Id{
+		"":        "alpha",
+		"website": "public",
+	}
+	result, err := env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The public space's network is already connected as the external network.
+	call := s.client.Calls()[4]
+	createVMArgs := call.Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.NetworkDevices, gc.HasLen, 3)
+	c.Assert(result.NetworkInfo, gc.HasLen, 3)
+	for i, network := range []string{"foo", "bar", "db-pg"} {
+		device := createVMArgs.NetworkDevices[i]
+		c.Check(device.Network, gc.Equals, network)
+		c.Check(result.NetworkInfo[i].DeviceIndex, gc.Equals, i)
+		c.Check(result.NetworkInfo[i].InterfaceName, gc.Equals, fmt.Sprintf("eth%d", i))
+		c.Check(result.NetworkInfo[i].MACAddress, gc.Equals, device.MAC)
+		c.Check(result.NetworkInfo[i].ProviderNetworkId, gc.Equals, corenetwork.Id(network))
+	}
+}
+
+func (s *legacyEnvironBrokerSuite) TestStartInstanceSpaceWithoutNetwork(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{
+			"space-networks":     []interface{}{"db=db-pg"},
+			"image-metadata-url": s.imageServer.URL,
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Constraints = constraints.MustParse("spaces=db")
+	startInstArgs.EndpointBindings = map[string]corenetwork.Id{
+		"website": "public",
+		"storage": "san",
+	}
+	_, err = env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, gc.ErrorMatches, "networks for spaces public, san in space-networks config not found")
+	c.Assert(err, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+	for _, call := range s.client.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "CreateVirtualMachine")
+	}
+}
+
 func (s *legacyEnvironBrokerSuite) TestStartInstanceLongModelName(c *gc.C) {
 	env, err := s.provider.Open(environs.OpenParams{
 		Cloud: fakeCloudSpec(),
@@ -228,6 +290,148 @@ func (s *legacyEnvironBrokerSuite) TestStartInstanceDiskUUIDDisabled(c *gc.C) {
 	c.Assert(createVMArgs.EnableDiskUUID, gc.Equals, false)
 }
 
+func (s *legacyEnvironBrokerSuite) TestStartInstanceVMTags(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{
+			"vm-tags":            []interface{}{"juju-model-uuid", "juju-machine-id"},
+			"image-metadata-url": s.imageServer.URL,
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.InstanceConfig.Tags = map[string]string{
+		"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+		"juju-model-uuid":      "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+		"juju-machine-id":      "0",
+	}
+	_, err = env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "Folders", "ComputeResources", "ResourcePools", "ResourcePools",
+		"CreateVirtualMachine", "TagVirtualMachine", "Close")
+	call := s.client.Calls()[5]
+	c.Assert(call.Args, gc.HasLen, 3)
+	c.Assert(call.Args[1], gc.Equals, s.client.createdVirtualMachine)
+	c.Assert(call.Args[2], jc.DeepEquals, map[string]string{
+		"juju-model-uuid": "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+		"juju-machine-id": "0",
+	})
+}
+
+func (s *legacyEnvironBrokerSuite) TestStartInstanceVMTagsFailure(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{
+			"vm-tags":            []interface{}{"juju-model-uuid"},
+			"image-metadata-url": s.imageServer.URL,
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.InstanceConfig.Tags = map[string]string{
+		"juju-model-uuid": "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+	}
+	s.client.SetErrors(nil, nil, nil, nil, nil, errors.New("no tagging privilege"))
+
+	// Failing to tag the VM does not fail instance creation.
+	result, err := env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("new-vm"))
+	s.client.CheckCallNames(c, "Folders", "ComputeResources", "ResourcePools", "ResourcePools",
+		"CreateVirtualMachine", "TagVirtualMachine", "Close")
+	c.Check(c.GetTestLog(), jc.Contains, `WARNING juju.provider.vmware failed to apply tags to VM "new-vm": no tagging privilege`)
+}
+
+func (s *legacyEnvironBrokerSuite) openEnvironWithLimits(c *gc.C, limits coretesting.Attrs) environs.Environ {
+	attrs := coretesting.Attrs{"image-metadata-url": s.imageServer.URL}
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c, attrs.Merge(limits)),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return env
+}
+
+func (s *legacyEnvironBrokerSuite) TestStartInstanceWithinLimits(c *gc.C) {
+	env := s.openEnvironWithLimits(c, coretesting.Attrs{
+		"max-vms":    3,
+		"max-cpu":    8,
+		"max-memory": 8192,
+	})
+	s.client.resourceUsage = vsphereclient.ResourceUsage{
+		VirtualMachines: 2,
+		CPUs:            6,
+		MemoryMB:        6144,
+	}
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Constraints = constraints.MustParse("cores=2 mem=2G")
+	_, err := env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "ResourceUsage", "Folders", "ComputeResources", "ResourcePools", "ResourcePools",
+		"CreateVirtualMachine", "Close")
+	c.Assert(s.client.Calls()[0].Args[1], gc.Matches, `Juju Controller \(\*\)/Model "testmodel" \(.*\)/\*`)
+
+	// The reservation is released once the instance has been
+	// started, so the limits apply only to the reported usage.
+	s.client.ResetCalls()
+	_, err = env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *legacyEnvironBrokerSuite) TestStartInstanceVMLimitExceeded(c *gc.C) {
+	env := s.openEnvironWithLimits(c, coretesting.Attrs{"max-vms": 2})
+	s.client.resourceUsage = vsphereclient.ResourceUsage{VirtualMachines: 2}
+
+	_, err := env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, gc.ErrorMatches, "model limit of 2 VMs reached")
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+	c.Assert(err, jc.Satisfies, environs.IsAvailabilityZoneIndependent)
+	s.client.CheckCallNames(c, "ResourceUsage", "Close")
+	s.statusCallbackStub.CheckCall(c, 0, "StatusCallback",
+		status.ProvisioningError, "model limit of 2 VMs reached", map[string]interface{}(nil))
+}
+
+func (s *legacyEnvironBrokerSuite) TestStartInstanceCPULimitExceeded(c *gc.C) {
+	env := s.openEnvironWithLimits(c, coretesting.Attrs{"max-cpu": 4})
+	s.client.resourceUsage = vsphereclient.ResourceUsage{VirtualMachines: 1, CPUs: 3}
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Constraints = constraints.MustParse("cores=2")
+	_, err := env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, gc.ErrorMatches, "starting instance would exceed model limit of 4 CPUs")
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+}
+
+func (s *legacyEnvironBrokerSuite) TestStartInstanceMemoryLimitExceeded(c *gc.C) {
+	env := s.openEnvironWithLimits(c, coretesting.Attrs{"max-memory": 4096})
+	s.client.resourceUsage = vsphereclient.ResourceUsage{VirtualMachines: 1, MemoryMB: 3072}
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Constraints = constraints.MustParse("mem=2G")
+	_, err := env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, gc.ErrorMatches, "starting instance would exceed model limit of 4096MiB memory")
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+}
+
+func (s *legacyEnvironBrokerSuite) TestStartInstanceUnconstrainedReservesDefaultHardware(c *gc.C) {
+	env := s.openEnvironWithLimits(c, coretesting.Attrs{"max-cpu": 4, "max-memory": 4096})
+
+	// Without constraints, the VM is sized as per the template.
+	s.client.resourceUsage = vsphereclient.ResourceUsage{VirtualMachines: 1, CPUs: 3}
+	_, err := env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, gc.ErrorMatches, "starting instance would exceed model limit of 4 CPUs")
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+
+	s.client.resourceUsage = vsphereclient.ResourceUsage{VirtualMachines: 1, CPUs: 2, MemoryMB: 3584}
+	_, err = env.StartInstance(s.callCtx, s.createStartInstanceArgs(c))
+	c.Assert(err, gc.ErrorMatches, "starting instance would exceed model limit of 4096MiB memory")
+	c.Assert(err, jc.Satisfies, environs.IsQuotaExceeded)
+}
+
 func (s *legacyEnvironBrokerSuite) TestStartInstanceWithUnsupportedConstraints(c *gc.C) {
 	startInstArgs := s.createStartInstanceArgs(c)
 	startInstArgs.Tools[0].Version.Arch = "someArch"
@@ -349,6 +553,22 @@ func (s *legacyEnvironBrokerSuite) TestStartInstanceSelectZone(c *gc.C) {
 	c.Assert(createVMArgs.ComputeResource, jc.DeepEquals, s.client.computeResources[1].Resource)
 }
 
+func (s *legacyEnvironBrokerSuite) TestStartInstancePlacementHost(c *gc.C) {
+	s.client.hostSystems = map[string][]mo.HostSystem{
+		"z2": {newHostSystem("esxi-1", "host-1"), newHostSystem("esxi-2", "host-2")},
+	}
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.AvailabilityZone = "z2"
+	startInstArgs.Placement = "zone=z2,host=esxi-2"
+	_, err := s.env.StartInstance(s.callCtx, startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "Folders", "ComputeResources", "ResourcePools", "ResourcePools", "HostSystems", "CreateVirtualMachine", "Close")
+	createVMArgs := s.client.Calls()[5].Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.ComputeResource, jc.DeepEquals, s.client.computeResources[1].Resource)
+	c.Assert(createVMArgs.HostSystem, jc.DeepEquals, &types.ManagedObjectReference{Type: "HostSystem", Value: "host-2"})
+}
+
 func (s *legacyEnvironBrokerSuite) TestStartInstanceFailsWithAvailabilityZone(c *gc.C) {
 	s.client.SetErrors(nil, nil, nil, nil, errors.New("nope"))
 	startInstArgs := s.createStartInstanceArgs(c)
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/vmware/govmomi/vim25/mo"
 
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs"
@@ -100,28 +101,66 @@ func (env *sessionEnviron) ControllerInstances(ctx context.ProviderCallContext,
 	return results, nil
 }
 
-// parsePlacement extracts the availability zone from the placement
-// string and returns it. If no zone is found there then an error is
-// returned.
-func (env *sessionEnviron) parsePlacement(ctx context.ProviderCallContext, placement string) (*vmwareAvailZone, error) {
+// vmwarePlacement holds the location specified by a placement directive.
+type vmwarePlacement struct {
+	// zone is the availability zone in which to create the VM.
+	zone *vmwareAvailZone
+
+	// host, if non-nil, is the host within the zone's
+	// compute resource on which to create the VM.
+	host *mo.HostSystem
+}
+
+// parsePlacement extracts the availability zone, and optionally the host
+// within it, from the placement string and returns them. The placement
+// string is a comma-separated list of directives, such as
+// "zone=cluster1,host=esxi-1". If the zone or host cannot be found,
+// or a host is requested without a zone, an error is returned.
+func (env *sessionEnviron) parsePlacement(ctx context.ProviderCallContext, placement string) (*vmwarePlacement, error) {
 	if placement == "" {
 		return nil, nil
 	}
 
-	pos := strings.IndexRune(placement, '=')
-	if pos == -1 {
-		return nil, errors.Errorf("unknown placement directive: %v", placement)
+	var zoneName, hostName string
+	for _, directive := range strings.Split(placement, ",") {
+		pos := strings.IndexRune(directive, '=')
+		if pos == -1 {
+			return nil, errors.Errorf("unknown placement directive: %v", placement)
+		}
+		switch key, value := directive[:pos], directive[pos+1:]; key {
+		case "zone":
+			zoneName = value
+		case "host":
+			hostName = value
+		default:
+			return nil, errors.Errorf("unknown placement directive: %v", placement)
+		}
+	}
+	if zoneName == "" {
+		return nil, errors.Errorf("placement directive %q does not specify a zone", placement)
+	}
+
+	zone, err := env.availZone(ctx, zoneName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &vmwarePlacement{zone: zone}
+	if hostName == "" {
+		return result, nil
 	}
 
-	switch key, value := placement[:pos], placement[pos+1:]; key {
-	case "zone":
-		zone, err := env.availZone(ctx, value)
-		if err != nil {
-			return nil, errors.Trace(err)
+	hosts, err := env.client.HostSystems(env.ctx, &zone.r)
+	if err != nil {
+		HandleCredentialError(err, env, ctx)
+		return nil, errors.Trace(err)
+	}
+	for i, host := range hosts {
+		if host.Name == hostName {
+			result.host = &hosts[i]
+			return result, nil
 		}
-		return zone, nil
 	}
-	return nil, errors.Errorf("unknown placement directive: %v", placement)
+	return nil, errors.NotFoundf("host %q in availability zone %q", hostName, zoneName)
 }
 
 func (env *sessionEnviron) modelFolderName() string {
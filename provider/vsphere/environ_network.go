@@ -5,9 +5,17 @@ package vsphere
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 )
 
+var _ environs.NetworkingEnviron = &environ{}
+
 // OpenPorts is part of the environs.Firewaller interface.
 func (*environ) OpenPorts(rules firewall.IngressRules) error {
 	return errors.Trace(errors.NotSupportedf("ClosePorts"))
@@ -22,3 +30,83 @@ func (*environ) ClosePorts(rules firewall.IngressRules) error {
 func (*environ) IngressRules() (firewall.IngressRules, error) {
 	return nil, errors.Trace(errors.NotSupportedf("Ports"))
 }
+
+// SupportsSpaces implements environs.NetworkingEnviron. Spaces are
+// mapped to vSphere networks by the model's space-networks config.
+func (*environ) SupportsSpaces(context.ProviderCallContext) (bool, error) {
+	return true, nil
+}
+
+// Subnets implements environs.NetworkingEnviron.
+func (*environ) Subnets(context.ProviderCallContext, instance.Id, []corenetwork.Id) ([]corenetwork.SubnetInfo, error) {
+	return nil, errors.NotSupportedf("subnets")
+}
+
+// SuperSubnets implements environs.NetworkingEnviron.
+func (*environ) SuperSubnets(context.ProviderCallContext) ([]string, error) {
+	return nil, errors.NotSupportedf("super subnets")
+}
+
+// SupportsSpaceDiscovery implements environs.NetworkingEnviron.
+func (*environ) SupportsSpaceDiscovery(context.ProviderCallContext) (bool, error) {
+	return false, nil
+}
+
+// Spaces implements environs.NetworkingEnviron.
+func (*environ) Spaces(context.ProviderCallContext) ([]corenetwork.SpaceInfo, error) {
+	return nil, errors.NotSupportedf("spaces")
+}
+
+// SupportsContainerAddresses implements environs.NetworkingEnviron.
+func (*environ) SupportsContainerAddresses(context.ProviderCallContext) (bool, error) {
+	return false, nil
+}
+
+// AllocateContainerAddresses implements environs.NetworkingEnviron.
+func (*environ) AllocateContainerAddresses(
+	context.ProviderCallContext, instance.Id, names.MachineTag, corenetwork.InterfaceInfos,
+) (corenetwork.InterfaceInfos, error) {
+	return nil, errors.NotSupportedf("container addresses")
+}
+
+// ReleaseContainerAddresses implements environs.NetworkingEnviron.
+func (*environ) ReleaseContainerAddresses(context.ProviderCallContext, []corenetwork.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container addresses")
+}
+
+// ProviderSpaceInfo implements environs.NetworkingEnviron.
+func (*environ) ProviderSpaceInfo(
+	context.ProviderCallContext, *corenetwork.SpaceInfo,
+) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable implements environs.NetworkingEnviron.
+func (*environ) AreSpacesRoutable(_ context.ProviderCallContext, _, _ *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// SSHAddresses implements environs.NetworkingEnviron.
+func (*environ) SSHAddresses(
+	_ context.ProviderCallContext, addresses corenetwork.SpaceAddresses,
+) (corenetwork.SpaceAddresses, error) {
+	return addresses, nil
+}
+
+// NetworkInterfaces implements environs.NetworkingEnviron. The interfaces
+// of each instance are those reported by VMware Tools running in its guest.
+func (env *environ) NetworkInterfaces(
+	ctx context.ProviderCallContext, ids []instance.Id,
+) ([]corenetwork.InterfaceInfos, error) {
+	insts, err := env.Instances(ctx, ids)
+	if err != nil && errors.Cause(err) != environs.ErrPartialInstances {
+		return nil, err
+	}
+	infos := make([]corenetwork.InterfaceInfos, len(ids))
+	for i, inst := range insts {
+		if inst != nil {
+			infos[i] = inst.(*environInstance).networkInterfaces()
+		}
+	}
+	return infos, err
+}
@@ -17,6 +17,9 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 	if args.Placement == "" && args.Constraints.String() == "" {
 		return nil
 	}
+	if _, err := requiredSpaces(args.Constraints, nil, env.ecfg.spaceNetworks()); err != nil {
+		return errors.Trace(err)
+	}
 	return env.withSession(ctx, func(env *sessionEnviron) error {
 		return env.PrecheckInstance(ctx, args)
 	})
@@ -4,6 +4,7 @@
 package vsphere_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/mo"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/provider/vsphere/internal/vsphereclient"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type environPolSuite struct {
@@ -82,6 +84,55 @@ func (s *environPolSuite) TestPrecheckInstanceChecksPlacementZone(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environPolSuite) TestPrecheckInstanceChecksPlacementHost(c *gc.C) {
+	s.client.folders = makeFolders("/DC/host")
+	s.client.computeResources = []vsphereclient.ComputeResource{
+		{Resource: newComputeResource("z1"), Path: "/DC/host/z1"},
+		{Resource: newComputeResource("z2"), Path: "/DC/host/z2"},
+	}
+	s.client.resourcePools = map[string][]*object.ResourcePool{
+		"/DC/host/z1/...": {makeResourcePool("pool-1", "/DC/host/z1/Resources")},
+		"/DC/host/z2/...": {makeResourcePool("pool-2", "/DC/host/z2/Resources")},
+	}
+	s.client.hostSystems = map[string][]mo.HostSystem{
+		"z1": {newHostSystem("esxi-1", "host-1"), newHostSystem("esxi-2", "host-2")},
+		"z2": {newHostSystem("esxi-3", "host-3")},
+	}
+
+	err := s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Placement: "zone=z1,host=esxi-2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceChecksPlacementHostNotInZone(c *gc.C) {
+	s.client.folders = makeFolders("/DC/host")
+	s.client.computeResources = []vsphereclient.ComputeResource{
+		{Resource: newComputeResource("z1"), Path: "/DC/host/z1"},
+		{Resource: newComputeResource("z2"), Path: "/DC/host/z2"},
+	}
+	s.client.resourcePools = map[string][]*object.ResourcePool{
+		"/DC/host/z1/...": {makeResourcePool("pool-1", "/DC/host/z1/Resources")},
+		"/DC/host/z2/...": {makeResourcePool("pool-2", "/DC/host/z2/Resources")},
+	}
+	s.client.hostSystems = map[string][]mo.HostSystem{
+		"z1": {newHostSystem("esxi-1", "host-1")},
+		"z2": {newHostSystem("esxi-3", "host-3")},
+	}
+
+	err := s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Placement: "zone=z1,host=esxi-3",
+	})
+	c.Assert(err, gc.ErrorMatches, `host "esxi-3" in availability zone "z1" not found`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceChecksPlacementHostWithoutZone(c *gc.C) {
+	err := s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Placement: "host=esxi-1",
+	})
+	c.Assert(err, gc.ErrorMatches, `placement directive "host=esxi-1" does not specify a zone`)
+}
+
 func (s *environPolSuite) TestPrecheckInstanceChecksConstraintZones(c *gc.C) {
 	s.client.folders = makeFolders("/DC/host")
 	s.client.computeResources = []vsphereclient.ComputeResource{
@@ -139,3 +190,23 @@ func (s *environPolSuite) TestPrecheckInstanceChecksConstraintDatastore(c *gc.C)
 	})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *environPolSuite) TestPrecheckInstanceChecksConstraintSpaces(c *gc.C) {
+	err := s.env.SetConfig(fakeConfig(c, coretesting.Attrs{
+		"image-metadata-url": s.imageServer.URL,
+		"space-networks":     []interface{}{"db=db-pg"},
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Constraints: constraints.MustParse("spaces=db,web"),
+	})
+	c.Assert(err, gc.ErrorMatches, "networks for spaces web in space-networks config not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.client.CheckNoCalls(c)
+
+	err = s.env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Constraints: constraints.MustParse("spaces=db,alpha,^web"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -123,18 +123,156 @@ func (s *environSuite) TestDestroyController(c *gc.C) {
 }
 
 func (s *environSuite) TestAdoptResources(c *gc.C) {
+	s.client.hasPrivilege = true
+	s.client.SetErrors(nil, nil, nil, errors.NotFoundf("folder"))
+
+	// The model's VMs are spread across resource pools,
+	// but all live within the model's folder.
+	rp1 := &types.ManagedObjectReference{Type: "ResourcePool", Value: "rp-1"}
+	rp2 := &types.ManagedObjectReference{Type: "ResourcePool", Value: "rp-2"}
+	vm1 := buildVM("vm-1").resourcePool(rp1).extraConfig("juju-controller-uuid", "old").vm()
+	vm2 := buildVM("vm-2").resourcePool(rp2).extraConfig("juju-controller-uuid", "old").vm()
+	vm3 := buildVM("vm-3").resourcePool(rp2).extraConfig("juju-controller-uuid", "foo").vm()
+	s.client.virtualMachines = []*mo.VirtualMachine{vm1, vm2, vm3}
+
 	err := s.env.AdoptResources(s.callCtx, "foo", version.Number{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.dialStub.CheckCallNames(c, "Dial")
-	s.client.CheckCallNames(c, "MoveVMFolderInto", "Close")
-	moveVMFolderIntoCall := s.client.Calls()[0]
+	s.client.CheckCallNames(c,
+		"UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege",
+		"FindFolder", "EnsureVMFolder", "MoveVMFolderInto", "VirtualMachines",
+		"UpdateVirtualMachineExtraConfig", "UpdateVirtualMachineExtraConfig",
+		"Close",
+	)
+	calls := s.client.Calls()
+	c.Assert(calls[0].Args[1], gc.Equals, "Folder.Create")
+	c.Assert(calls[1].Args[1], gc.Equals, "Folder.Move")
+	c.Assert(calls[2].Args[1], gc.Equals, "VirtualMachine.Config.AdvancedConfig")
+
+	c.Assert(calls[3].Args[1], gc.Equals,
+		`Juju Controller (foo)/Model "testmodel" (2d02eeac-9dbb-11e4-89d3-123b93f75cba)`,
+	)
+	c.Assert(calls[4].Args[1:], jc.DeepEquals, []interface{}{"", `Juju Controller (foo)`})
+
+	moveVMFolderIntoCall := calls[5]
 	c.Assert(moveVMFolderIntoCall.Args, gc.HasLen, 3)
 	c.Assert(moveVMFolderIntoCall.Args[0], gc.Implements, new(context.Context))
 	c.Assert(moveVMFolderIntoCall.Args[1], gc.Equals, `Juju Controller (foo)`)
 	c.Assert(moveVMFolderIntoCall.Args[2], gc.Equals,
 		`Juju Controller (*)/Model "testmodel" (2d02eeac-9dbb-11e4-89d3-123b93f75cba)`,
 	)
+
+	c.Assert(calls[6].Args[1], gc.Equals,
+		`Juju Controller (foo)/Model "testmodel" (2d02eeac-9dbb-11e4-89d3-123b93f75cba)/*`,
+	)
+
+	// VMs already tagged with the new controller are not updated.
+	c.Assert(calls[7].Args[1], gc.Equals, vm1)
+	c.Assert(calls[7].Args[2], jc.DeepEquals, map[string]string{"juju-controller-uuid": "foo"})
+	c.Assert(calls[8].Args[1], gc.Equals, vm2)
+	c.Assert(calls[8].Args[2], jc.DeepEquals, map[string]string{"juju-controller-uuid": "foo"})
+}
+
+func (s *environSuite) TestAdoptResourcesUpdatesControllerTag(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: fakeCloudSpec(),
+		Config: fakeConfig(c, testing.Attrs{
+			"vm-tags": []interface{}{"juju-controller-uuid", "juju-model-uuid"},
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.hasPrivilege = true
+	vm1 := buildVM("vm-1").extraConfig("juju-controller-uuid", "old").vm()
+	vm2 := buildVM("vm-2").extraConfig("juju-controller-uuid", "foo").vm()
+	s.client.virtualMachines = []*mo.VirtualMachine{vm1, vm2}
+
+	err = env.AdoptResources(s.callCtx, "foo", version.Number{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c,
+		"UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege",
+		"FindFolder", "VirtualMachines", "TagVirtualMachine", "UpdateVirtualMachineExtraConfig",
+		"Close",
+	)
+	calls := s.client.Calls()
+	c.Assert(calls[5].Args[1], gc.Equals, vm1)
+	c.Assert(calls[5].Args[2], jc.DeepEquals, map[string]string{"juju-controller-uuid": "foo"})
+	c.Assert(calls[6].Args[1], gc.Equals, vm1)
+}
+
+func (s *environSuite) TestAdoptResourcesTagFailureIsRetried(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: fakeCloudSpec(),
+		Config: fakeConfig(c, testing.Attrs{
+			"vm-tags": []interface{}{"juju-controller-uuid"},
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.hasPrivilege = true
+	vm1 := buildVM("vm-1").extraConfig("juju-controller-uuid", "old").vm()
+	s.client.virtualMachines = []*mo.VirtualMachine{vm1}
+	s.client.SetErrors(nil, nil, nil, nil, nil, errors.New("no tagging privilege"))
+
+	err = env.AdoptResources(s.callCtx, "foo", version.Number{})
+	c.Assert(err, gc.ErrorMatches, "tagging VM vm-1: no tagging privilege")
+
+	// The VM's ExtraConfig is left alone, so
+	// the VM is tagged again when retried.
+	s.client.CheckCallNames(c,
+		"UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege",
+		"FindFolder", "VirtualMachines", "TagVirtualMachine", "Close",
+	)
+}
+
+func (s *environSuite) TestAdoptResourcesAlreadyAdopted(c *gc.C) {
+	s.client.hasPrivilege = true
+	s.client.virtualMachines = []*mo.VirtualMachine{
+		buildVM("vm-1").extraConfig("juju-controller-uuid", "foo").vm(),
+	}
+
+	err := s.env.AdoptResources(s.callCtx, "foo", version.Number{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c,
+		"UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege",
+		"FindFolder", "VirtualMachines", "Close",
+	)
+}
+
+func (s *environSuite) TestAdoptResourcesRetriesPartialUpdate(c *gc.C) {
+	s.client.hasPrivilege = true
+	vm1 := buildVM("vm-1").extraConfig("juju-controller-uuid", "old").vm()
+	vm2 := buildVM("vm-2").extraConfig("juju-controller-uuid", "old").vm()
+	s.client.virtualMachines = []*mo.VirtualMachine{vm1, vm2}
+	s.client.SetErrors(nil, nil, nil, nil, nil, nil, errors.New("boom"))
+
+	err := s.env.AdoptResources(s.callCtx, "foo", version.Number{})
+	c.Assert(err, gc.ErrorMatches, "updating VM vm-2: boom")
+
+	// Simulate the first VM having been updated.
+	vm1.Config.ExtraConfig = []types.BaseOptionValue{
+		&types.OptionValue{Key: "juju-controller-uuid", Value: "foo"},
+	}
+	s.client.ResetCalls()
+
+	err = s.env.AdoptResources(s.callCtx, "foo", version.Number{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c,
+		"UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege", "UserHasRootLevelPrivilege",
+		"FindFolder", "VirtualMachines", "UpdateVirtualMachineExtraConfig", "Close",
+	)
+	c.Assert(s.client.Calls()[5].Args[1], gc.Equals, vm2)
+}
+
+func (s *environSuite) TestAdoptResourcesMissingPrivilege(c *gc.C) {
+	err := s.env.AdoptResources(s.callCtx, "foo", version.Number{})
+	c.Assert(err, gc.ErrorMatches, `adopting resources requires the "Folder.Create" privilege`)
+
+	// Nothing is changed if the user lacks the required privileges.
+	s.client.CheckCallNames(c, "UserHasRootLevelPrivilege", "Close")
 }
 
 func (s *environSuite) TestPrepareForBootstrap(c *gc.C) {
@@ -144,7 +282,8 @@ func (s *environSuite) TestPrepareForBootstrap(c *gc.C) {
 
 func (s *environSuite) TestSupportsNetworking(c *gc.C) {
 	_, ok := environs.SupportsNetworking(s.env)
-	c.Assert(ok, jc.IsFalse)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(environs.SupportsSpaces(s.callCtx, s.env), jc.IsTrue)
 }
 
 func (s *environSuite) TestAdoptResourcesPermissionError(c *gc.C) {
@@ -63,6 +63,35 @@ func (inst *environInstance) Addresses(ctx context.ProviderCallContext) (corenet
 	return res, nil
 }
 
+// networkInterfaces returns the network interfaces of the instance,
+// as reported by VMware Tools. Addresses are placed in the spaces mapped
+// to the interfaces' networks by the model's space-networks config.
+func (inst *environInstance) networkInterfaces() corenetwork.InterfaceInfos {
+	if inst.base.Guest == nil {
+		return nil
+	}
+	spaces := make(map[string]string)
+	for space, network := range inst.env.ecfg.spaceNetworks() {
+		spaces[network] = space
+	}
+	interfaces := make(corenetwork.InterfaceInfos, len(inst.base.Guest.Net))
+	for i, nic := range inst.base.Guest.Net {
+		interfaces[i] = corenetwork.InterfaceInfo{
+			DeviceIndex:       i,
+			MACAddress:        nic.MacAddress,
+			ProviderNetworkId: corenetwork.Id(nic.Network),
+			InterfaceType:     corenetwork.EthernetInterface,
+			ConfigType:        corenetwork.ConfigDHCP,
+			Origin:            corenetwork.OriginProvider,
+		}
+		for _, ip := range nic.IpAddress {
+			interfaces[i].Addresses = append(interfaces[i].Addresses,
+				corenetwork.NewProviderAddressInSpace(spaces[nic.Network], ip))
+		}
+	}
+	return interfaces
+}
+
 // firewall stuff
 
 // OpenPorts opens the given ports on the instance, which
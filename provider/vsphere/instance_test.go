@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/instances"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type InstanceSuite struct {
@@ -97,6 +98,48 @@ func (s *InstanceSuite) TestInstanceAddresses(c *gc.C) {
 	c.Assert(addrs, gc.HasLen, 0)
 }
 
+func (s *InstanceSuite) TestNetworkInterfaces(c *gc.C) {
+	err := s.env.SetConfig(fakeConfig(c, coretesting.Attrs{
+		"image-metadata-url": s.imageServer.URL,
+		"space-networks":     []interface{}{"db=db-pg"},
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	nic0 := newNic("10.1.1.1")
+	nic0.Network = "VM Network"
+	nic0.MacAddress = "00:50:56:00:00:01"
+	nic1 := newNic("10.2.2.1", "10.2.2.2")
+	nic1.Network = "db-pg"
+	nic1.MacAddress = "00:50:56:00:00:02"
+	s.client.virtualMachines = []*mo.VirtualMachine{
+		buildVM("inst-0").nic(nic0, nic1).vm(),
+	}
+
+	netEnv, ok := environs.SupportsNetworking(s.env)
+	c.Assert(ok, jc.IsTrue)
+	infos, err := netEnv.NetworkInterfaces(s.callCtx, []instance.Id{"inst-0", "inst-1"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(infos, gc.HasLen, 2)
+	c.Assert(infos[1], gc.IsNil)
+	c.Assert(infos[0], jc.DeepEquals, network.InterfaceInfos{{
+		DeviceIndex:       0,
+		MACAddress:        "00:50:56:00:00:01",
+		ProviderNetworkId: "VM Network",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		Origin:            network.OriginProvider,
+		Addresses:         network.NewProviderAddresses("10.1.1.1"),
+	}, {
+		DeviceIndex:       1,
+		MACAddress:        "00:50:56:00:00:02",
+		ProviderNetworkId: "db-pg",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		Origin:            network.OriginProvider,
+		Addresses:         network.NewProviderAddressesInSpace("db", "10.2.2.1", "10.2.2.2"),
+	}})
+}
+
 func (s *InstanceSuite) TestControllerInstances(c *gc.C) {
 	s.client.virtualMachines = []*mo.VirtualMachine{
 		buildVM("inst-0").vm(),
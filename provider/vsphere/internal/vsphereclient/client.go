@@ -54,6 +54,7 @@ type ComputeResource struct {
 // functionality that we require in the Juju provider.
 type Client struct {
 	client       *govmomi.Client
+	user         *url.Userinfo
 	datacenter   string
 	logger       loggo.Logger
 	clock        clock.Clock
@@ -76,6 +77,7 @@ func Dial(
 	}
 	return &Client{
 		client:       client,
+		user:         u.User,
 		datacenter:   datacenter,
 		logger:       logger,
 		clock:        clock.WallClock,
@@ -242,6 +244,52 @@ func (c *Client) VirtualMachines(ctx context.Context, path string) ([]*mo.Virtua
 	return vms, nil
 }
 
+// ResourceUsage records the number of virtual machines in a folder,
+// and the total resources allocated to them.
+type ResourceUsage struct {
+	VirtualMachines int
+	CPUs            int
+	MemoryMB        int64
+}
+
+// ResourceUsage returns the number of virtual machines matching the
+// input path, along with the CPUs and memory allocated to them. The
+// configuration of all of the virtual machines is retrieved with a
+// single property collector query.
+func (c *Client) ResourceUsage(ctx context.Context, path string) (ResourceUsage, error) {
+	c.logger.Tracef("ResourceUsage() path=%q", path)
+	finder, _, err := c.finder(ctx)
+	if err != nil {
+		return ResourceUsage{}, errors.Trace(err)
+	}
+	items, err := finder.VirtualMachineList(ctx, path)
+	if err != nil {
+		if _, ok := err.(*find.NotFoundError); ok {
+			return ResourceUsage{}, nil
+		}
+		return ResourceUsage{}, errors.Annotate(err, "listing VMs")
+	}
+	if len(items) == 0 {
+		return ResourceUsage{}, nil
+	}
+
+	refs := make([]types.ManagedObjectReference, len(items))
+	for i, item := range items {
+		refs[i] = item.Reference()
+	}
+	var vms []mo.VirtualMachine
+	if err := c.client.Retrieve(ctx, refs, []string{"summary.config"}, &vms); err != nil {
+		return ResourceUsage{}, errors.Annotate(err, "retrieving VM configuration")
+	}
+
+	usage := ResourceUsage{VirtualMachines: len(vms)}
+	for _, vm := range vms {
+		usage.CPUs += int(vm.Summary.Config.NumCpu)
+		usage.MemoryMB += int64(vm.Summary.Config.MemorySizeMB)
+	}
+	return usage, nil
+}
+
 // ComputeResources returns a slice of all compute resources in the datacenter,
 // along with a slice of each compute resource's full path.
 func (c *Client) ComputeResources(ctx context.Context) ([]ComputeResource, error) {
@@ -296,6 +344,19 @@ func (c *Client) computeResourcesFromRef(ctx context.Context, ref types.ManagedO
 	return crs, nil
 }
 
+// HostSystems returns the hosts that make up the given compute resource.
+func (c *Client) HostSystems(ctx context.Context, cr *mo.ComputeResource) ([]mo.HostSystem, error) {
+	c.logger.Tracef("HostSystems() cr=%q", cr.Name)
+	if len(cr.Host) == 0 {
+		return nil, nil
+	}
+	var hosts []mo.HostSystem
+	if err := c.client.Retrieve(ctx, cr.Host, []string{"name"}, &hosts); err != nil {
+		return nil, errors.Annotate(err, "retrieving host details")
+	}
+	return hosts, nil
+}
+
 // Folders returns the datacenter's folders object.
 func (c *Client) Folders(ctx context.Context) (*object.DatacenterFolders, error) {
 	c.logger.Tracef("Folders()")
@@ -560,6 +621,7 @@ func (c *Client) cloneVM(
 		Config: vmConfigSpec,
 		Location: types.VirtualMachineRelocateSpec{
 			Pool:      &args.ResourcePool,
+			Host:      args.HostSystem,
 			Datastore: &datastoreRef,
 		},
 	})
@@ -46,6 +46,13 @@ type NetworkDevice struct {
 // That's a default network that's defined in OVF.
 const defaultNetwork = "VM Network"
 
+// These are the number of CPUs and the memory defined in the OVF, which
+// a new VM has unless they are overridden by its constraints.
+const (
+	DefaultCPUs     = 2
+	DefaultMemoryMB = 1024
+)
+
 // CreateVirtualMachineParams contains the parameters required for creating
 // a new virtual machine.
 type CreateVirtualMachineParams struct {
@@ -88,6 +95,10 @@ type CreateVirtualMachineParams struct {
 	// created in.
 	ResourcePool types.ManagedObjectReference
 
+	// HostSystem, if non-nil, is a reference to the host within the
+	// compute resource on which the VM should be created.
+	HostSystem *types.ManagedObjectReference
+
 	// Metadata are metadata key/value pairs to apply to the VM as
 	// "extra config".
 	Metadata map[string]string
@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package vsphereclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/vmware/govmomi/vapi/rest"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/mo"
+)
+
+// vmTagAssociableType is the managed object type
+// that Juju tag categories may be associated with.
+const vmTagAssociableType = "VirtualMachine"
+
+// TagVirtualMachine attaches vSphere tags to the specified virtual
+// machine. Each key in the input map names a tag category, and each
+// value names the tag in that category to attach. Categories and tags
+// that do not already exist are created. Juju's tag categories allow a
+// single tag per object, so any other tag already attached to the VM in
+// the same category is detached first.
+//
+// Tagging uses the vSphere Automation API rather than the SOAP API used
+// elsewhere by the client, so it requires a separate session. Failures
+// to attach individual tags do not prevent the remaining tags from being
+// attached; they are combined in the returned error.
+func (c *Client) TagVirtualMachine(
+	ctx context.Context,
+	vmInfo *mo.VirtualMachine,
+	vmTags map[string]string,
+) error {
+	c.logger.Tracef("TagVirtualMachine() vmInfo.Name=%q, tags=%v", vmInfo.Name, vmTags)
+	if len(vmTags) == 0 {
+		return nil
+	}
+
+	restClient := rest.NewClient(c.client.Client)
+	if err := restClient.Login(ctx, c.user); err != nil {
+		return errors.Annotate(err, "logging in to vSphere automation API")
+	}
+	defer func() {
+		if err := restClient.Logout(ctx); err != nil {
+			c.logger.Debugf("logging out of vSphere automation API: %v", err)
+		}
+	}()
+	manager := tags.NewManager(restClient)
+
+	categories := make([]string, 0, len(vmTags))
+	for category := range vmTags {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	var failures []string
+	for _, category := range categories {
+		if err := c.attachTag(ctx, manager, vmInfo, category, vmTags[category]); err != nil {
+			failures = append(failures, fmt.Sprintf("%s=%s: %v", category, vmTags[category], err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("tagging VM %q: %s", vmInfo.Name, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// attachTag attaches the tag with the input name in the input category
+// to the virtual machine, creating the category and tag if necessary,
+// and replacing any other tag attached to the VM in that category.
+func (c *Client) attachTag(
+	ctx context.Context,
+	manager *tags.Manager,
+	vmInfo *mo.VirtualMachine,
+	categoryName, tagName string,
+) error {
+	categoryID, err := c.ensureTagCategory(ctx, manager, categoryName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tagID, err := c.ensureTag(ctx, manager, categoryID, tagName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	attached, err := manager.GetAttachedTags(ctx, vmInfo.Reference())
+	if err != nil {
+		return errors.Annotate(err, "listing attached tags")
+	}
+	for _, tag := range attached {
+		if tag.CategoryID != categoryID {
+			continue
+		}
+		if tag.ID == tagID {
+			// Already attached.
+			return nil
+		}
+		if err := manager.DetachTag(ctx, tag.ID, vmInfo.Reference()); err != nil {
+			return errors.Annotatef(err, "detaching tag %q", tag.Name)
+		}
+	}
+	return errors.Annotate(manager.AttachTag(ctx, tagID, vmInfo.Reference()), "attaching tag")
+}
+
+// ensureTagCategory returns the ID of the tag category
+// with the input name, creating it if it does not exist.
+func (c *Client) ensureTagCategory(ctx context.Context, manager *tags.Manager, name string) (string, error) {
+	if category, err := manager.GetCategory(ctx, name); err == nil {
+		return category.ID, nil
+	}
+	id, err := manager.CreateCategory(ctx, &tags.Category{
+		Name:            name,
+		Description:     "Created by Juju",
+		Cardinality:     "SINGLE",
+		AssociableTypes: []string{vmTagAssociableType},
+	})
+	return id, errors.Annotatef(err, "creating tag category %q", name)
+}
+
+// ensureTag returns the ID of the tag with the input name in
+// the input category, creating it if it does not exist.
+func (c *Client) ensureTag(ctx context.Context, manager *tags.Manager, categoryID, name string) (string, error) {
+	if tag, err := manager.GetTagForCategory(ctx, name, categoryID); err == nil {
+		return tag.ID, nil
+	}
+	id, err := manager.CreateTag(ctx, &tags.Tag{
+		Name:        name,
+		Description: "Created by Juju",
+		CategoryID:  categoryID,
+	})
+	return id, errors.Annotatef(err, "creating tag %q", name)
+}
@@ -35,8 +35,10 @@ type mockClient struct {
 
 	computeResources      []vsphereclient.ComputeResource
 	resourcePools         map[string][]*object.ResourcePool
+	hostSystems           map[string][]mo.HostSystem
 	createdVirtualMachine *mo.VirtualMachine
 	virtualMachines       []*mo.VirtualMachine
+	resourceUsage         vsphereclient.ResourceUsage
 	folders               *object.DatacenterFolders
 	datastores            []mo.Datastore
 	vmFolder              *object.Folder
@@ -113,6 +115,13 @@ func (c *mockClient) EnsureVMFolder(ctx context.Context, credAttrFolder string,
 	return c.vmFolder, c.NextErr()
 }
 
+func (c *mockClient) HostSystems(ctx context.Context, cr *mo.ComputeResource) ([]mo.HostSystem, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "HostSystems", ctx, cr)
+	return c.hostSystems[cr.Name], c.NextErr()
+}
+
 func (c *mockClient) MoveVMFolderInto(ctx context.Context, parent string, child string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -134,6 +143,20 @@ func (c *mockClient) RemoveVirtualMachines(ctx context.Context, path string) err
 	return c.NextErr()
 }
 
+func (c *mockClient) ResourceUsage(ctx context.Context, path string) (vsphereclient.ResourceUsage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "ResourceUsage", ctx, path)
+	return c.resourceUsage, c.NextErr()
+}
+
+func (c *mockClient) TagVirtualMachine(ctx context.Context, vm *mo.VirtualMachine, tags map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "TagVirtualMachine", ctx, vm, tags)
+	return c.NextErr()
+}
+
 func (c *mockClient) UpdateVirtualMachineExtraConfig(ctx context.Context, vm *mo.VirtualMachine, attrs map[string]string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -220,6 +243,13 @@ func newComputeResource(name string) *mo.ComputeResource {
 	return cr
 }
 
+func newHostSystem(name, ref string) mo.HostSystem {
+	var host mo.HostSystem
+	host.Name = name
+	host.Self = types.ManagedObjectReference{Type: "HostSystem", Value: ref}
+	return host
+}
+
 type mockSummary struct {
 	types.ComputeResourceSummary
 }
@@ -169,6 +169,21 @@ func (mr *MockClientMockRecorder) Folders(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Folders", reflect.TypeOf((*MockClient)(nil).Folders), arg0)
 }
 
+// HostSystems mocks base method
+func (m *MockClient) HostSystems(arg0 context.Context, arg1 *mo.ComputeResource) ([]mo.HostSystem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HostSystems", arg0, arg1)
+	ret0, _ := ret[0].([]mo.HostSystem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HostSystems indicates an expected call of HostSystems
+func (mr *MockClientMockRecorder) HostSystems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HostSystems", reflect.TypeOf((*MockClient)(nil).HostSystems), arg0, arg1)
+}
+
 // MoveVMFolderInto mocks base method
 func (m *MockClient) MoveVMFolderInto(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -231,6 +246,35 @@ func (mr *MockClientMockRecorder) ResourcePools(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourcePools", reflect.TypeOf((*MockClient)(nil).ResourcePools), arg0, arg1)
 }
 
+// ResourceUsage mocks base method
+func (m *MockClient) ResourceUsage(arg0 context.Context, arg1 string) (vsphereclient.ResourceUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceUsage", arg0, arg1)
+	ret0, _ := ret[0].(vsphereclient.ResourceUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResourceUsage indicates an expected call of ResourceUsage
+func (mr *MockClientMockRecorder) ResourceUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceUsage", reflect.TypeOf((*MockClient)(nil).ResourceUsage), arg0, arg1)
+}
+
+// TagVirtualMachine mocks base method
+func (m *MockClient) TagVirtualMachine(arg0 context.Context, arg1 *mo.VirtualMachine, arg2 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagVirtualMachine", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagVirtualMachine indicates an expected call of TagVirtualMachine
+func (mr *MockClientMockRecorder) TagVirtualMachine(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagVirtualMachine", reflect.TypeOf((*MockClient)(nil).TagVirtualMachine), arg0, arg1, arg2)
+}
+
 // UpdateVirtualMachineExtraConfig mocks base method
 func (m *MockClient) UpdateVirtualMachineExtraConfig(arg0 context.Context, arg1 *mo.VirtualMachine, arg2 map[string]string) error {
 	m.ctrl.T.Helper()
@@ -49,6 +49,31 @@ type DetailsRequest struct {
 	LocalOnly bool   `yaml:"local-only"`
 }
 
+// BoundAddressesTopic is the topic name for the published message
+// whenever an API server starts listening, or the addresses it is
+// bound to or the names it serves certificates for change.
+// data: `BoundAddresses`
+const BoundAddressesTopic = "apiserver.bound-addresses"
+
+// BoundAddresses describes the addresses an API server is listening on,
+// allowing HA peers to discover each other without polling state.
+type BoundAddresses struct {
+	// MachineTag is the tag of the controller machine
+	// running the API server.
+	MachineTag string `yaml:"machine-tag"`
+
+	// Addresses contains the addresses the API server is bound to,
+	// in the form addr:port.
+	Addresses []string `yaml:"addresses"`
+
+	// Ports contains the ports the API server is listening on.
+	Ports []int `yaml:"ports"`
+
+	// SNINames contains the server names that the API server
+	// holds certificates for.
+	SNINames []string `yaml:"sni-names,omitempty"`
+}
+
 // ConnectTopic is the topic name for the published message
 // whenever an agent conntects to the API server.
 // data: `APIConnection`
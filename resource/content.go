@@ -7,11 +7,10 @@ package resource
 
 import (
 	"io"
-	"os"
+	"io/ioutil"
 
 	charmresource "github.com/juju/charm/v9/resource"
 	"github.com/juju/errors"
-	"github.com/juju/utils/v2"
 )
 
 // Content holds a reader for the content of a resource along
@@ -29,21 +28,18 @@ type Content struct {
 
 // GenerateContent returns a new Content for the given data stream.
 func GenerateContent(reader io.ReadSeeker) (Content, error) {
-	var sizer utils.SizeTracker
-	sizingReader := io.TeeReader(reader, &sizer)
-	fp, err := charmresource.GenerateFingerprint(sizingReader)
-	if err != nil {
+	fpReader := NewFingerprintingReader(reader)
+	if _, err := io.Copy(ioutil.Discard, fpReader); err != nil {
 		return Content{}, errors.Trace(err)
 	}
-	if _, err := reader.Seek(0, os.SEEK_SET); err != nil {
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
 		return Content{}, errors.Trace(err)
 	}
-	size := sizer.Size()
 
 	content := Content{
 		Data:        reader,
-		Size:        size,
-		Fingerprint: fp,
+		Size:        fpReader.Size(),
+		Fingerprint: fpReader.Fingerprint(),
 	}
 	return content, nil
 }
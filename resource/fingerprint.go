@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"io"
+
+	charmresource "github.com/juju/charm/v9/resource"
+)
+
+// FingerprintingReader wraps a reader, computing the fingerprint and
+// size of the data as it is read. This allows resource content to be
+// fingerprinted in the same pass as it is streamed elsewhere, without
+// buffering the whole of it.
+type FingerprintingReader struct {
+	reader io.Reader
+	hash   *charmresource.FingerprintHash
+	size   int64
+}
+
+// NewFingerprintingReader returns a new FingerprintingReader
+// for the given data stream.
+func NewFingerprintingReader(reader io.Reader) *FingerprintingReader {
+	return &FingerprintingReader{
+		reader: reader,
+		hash:   charmresource.NewFingerprintHash(),
+	}
+}
+
+// Read implements io.Reader. The data read passes through unchanged.
+func (r *FingerprintingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		// Writing to a hash never returns an error.
+		_, _ = r.hash.Write(p[:n])
+		r.size += int64(n)
+	}
+	return n, err
+}
+
+// Fingerprint returns the fingerprint of the data read so far.
+func (r *FingerprintingReader) Fingerprint() charmresource.Fingerprint {
+	return r.hash.Fingerprint()
+}
+
+// Size returns the number of bytes read so far.
+func (r *FingerprintingReader) Size() int64 {
+	return r.size
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing/iotest"
+
+	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/resource"
+)
+
+type FingerprintingReaderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FingerprintingReaderSuite{})
+
+func (FingerprintingReaderSuite) TestMultiChunkRead(c *gc.C) {
+	data := strings.Repeat("some resource content\n", 1000)
+	expected, err := charmresource.GenerateFingerprint(strings.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Reading a byte at a time forces many small reads.
+	reader := resource.NewFingerprintingReader(iotest.OneByteReader(strings.NewReader(data)))
+	read, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(string(read), gc.Equals, data)
+	c.Check(reader.Size(), gc.Equals, int64(len(data)))
+	c.Check(reader.Fingerprint(), jc.DeepEquals, expected)
+}
+
+func (FingerprintingReaderSuite) TestPartialRead(c *gc.C) {
+	data := []byte("spamspamspam")
+	expected, err := charmresource.GenerateFingerprint(bytes.NewReader(data[:4]))
+	c.Assert(err, jc.ErrorIsNil)
+
+	reader := resource.NewFingerprintingReader(bytes.NewReader(data))
+	buf := make([]byte, 4)
+	n, err := reader.Read(buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 4)
+
+	c.Check(buf, jc.DeepEquals, data[:4])
+	c.Check(reader.Size(), gc.Equals, int64(4))
+	c.Check(reader.Fingerprint(), jc.DeepEquals, expected)
+}
+
+func (FingerprintingReaderSuite) TestReadError(c *gc.C) {
+	reader := resource.NewFingerprintingReader(iotest.TimeoutReader(strings.NewReader("spam")))
+	_, err := ioutil.ReadAll(reader)
+	c.Check(err, gc.Equals, iotest.ErrTimeout)
+	c.Check(reader.Size(), gc.Equals, int64(4))
+}
@@ -25,11 +25,11 @@ type DeployResourcesFunc func(
 	resources map[string]charmresource.Meta,
 	conn base.APICallCloser,
 	filesystem modelcmd.Filesystem,
-) (ids map[string]string, err error)
+) ([]resourcecmd.DeployedResource, error)
 
 // DeployResources uploads the bytes for the given files to the server and
 // creates pending resource metadata for the all resource mentioned in the
-// metadata. It returns a map of resource name to pending resource IDs.
+// metadata. It returns the resources that were added, ordered by name.
 func DeployResources(
 	applicationID string,
 	chID client.CharmID,
@@ -38,7 +38,7 @@ func DeployResources(
 	resources map[string]charmresource.Meta,
 	conn base.APICallCloser,
 	filesystem modelcmd.Filesystem,
-) (ids map[string]string, err error) {
+) ([]resourcecmd.DeployedResource, error) {
 
 	if len(filesAndRevisions)+len(resources) == 0 {
 		// Nothing to upload.
@@ -61,7 +61,7 @@ func DeployResources(
 		}
 	}
 
-	ids, err = resourcecmd.DeployResources(resourcecmd.DeployResourcesArgs{
+	deployed, err := resourcecmd.DeployResources(resourcecmd.DeployResourcesArgs{
 		ApplicationID:      applicationID,
 		CharmID:            chID,
 		CharmStoreMacaroon: csMac,
@@ -74,7 +74,7 @@ func DeployResources(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return ids, nil
+	return deployed, nil
 }
 
 type deployClient struct {
@@ -110,6 +110,9 @@ func makeAllWatcherCollectionInfo(collNames []string) map[string]allWatcherState
 		case podSpecsC:
 			collection.docType = reflect.TypeOf(backingPodSpec{})
 			collection.subsidiary = true
+		case cloudContainersC:
+			collection.docType = reflect.TypeOf(backingCloudContainer{})
+			collection.subsidiary = true
 		default:
 			allWatcherLogger.Criticalf("programming error: unknown collection %q", collName)
 		}
@@ -557,6 +560,9 @@ func (u *backingUnit) updated(ctx *allWatcherContext) error {
 			if err == nil {
 				info.ContainerStatus = containerStatus
 			}
+			if container, err := ctx.getCloudContainer(globalCloudContainerKey(u.Name)); err == nil {
+				container.updateUnitInfo(info)
+			}
 		}
 	} else {
 		// The entry already exists, so preserve the current status and ports.
@@ -567,6 +573,10 @@ func (u *backingUnit) updated(ctx *allWatcherContext) error {
 		info.WorkloadStatus = oldInfo.WorkloadStatus
 		info.ContainerStatus = oldInfo.ContainerStatus
 		info.OpenPortRangesByEndpoint = oldInfo.OpenPortRangesByEndpoint
+		// Cloud container details.
+		info.ProviderID = oldInfo.ProviderID
+		info.ContainerAddress = oldInfo.ContainerAddress
+		info.ContainerPorts = oldInfo.ContainerPorts
 	}
 
 	u.updateAgentVersion(info)
@@ -788,6 +798,66 @@ func (ps *backingPodSpec) mongoID() string {
 	return ""
 }
 
+type backingCloudContainer cloudContainerDoc
+
+func (cc *backingCloudContainer) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`cloud container "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	return cc.updateUnit(ctx, cc.updateUnitInfo)
+}
+
+func (cc *backingCloudContainer) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`cloud container "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	return cc.updateUnit(ctx, func(info *multiwatcher.UnitInfo) {
+		info.ProviderID = ""
+		info.ContainerAddress = ""
+		info.ContainerPorts = nil
+	})
+}
+
+// updateUnit applies the input update to the info for the unit hosted
+// by the cloud container. The id of the cloud container is derived from
+// the unit global key.
+func (cc *backingCloudContainer) updateUnit(ctx *allWatcherContext, update func(*multiwatcher.UnitInfo)) error {
+	parentID, _, ok := ctx.entityIDForGlobalKey(ctx.id)
+	if !ok {
+		return nil
+	}
+	info0 := ctx.store.Get(parentID)
+	switch info := info0.(type) {
+	case nil:
+		// The parent info doesn't exist. Ignore until it does,
+		// at which point the cloud container is read with it.
+		return nil
+	case *multiwatcher.UnitInfo:
+		newInfo := *info
+		update(&newInfo)
+		info0 = &newInfo
+	default:
+		allWatcherLogger.Warningf("unexpected cloud container parent type: %T", info)
+		return nil
+	}
+	ctx.store.Update(info0)
+	return nil
+}
+
+// updateUnitInfo sets the cloud container details on the input unit info.
+func (cc *backingCloudContainer) updateUnitInfo(info *multiwatcher.UnitInfo) {
+	info.ProviderID = cc.ProviderId
+	info.ContainerAddress = ""
+	if cc.Address != nil {
+		info.ContainerAddress = cc.Address.Value
+	}
+	info.ContainerPorts = nil
+	if len(cc.Ports) > 0 {
+		info.ContainerPorts = append([]string(nil), cc.Ports...)
+	}
+}
+
+func (cc *backingCloudContainer) mongoID() string {
+	allWatcherLogger.Criticalf("programming error: attempting to get mongoID from cloud container document")
+	return ""
+}
+
 type backingCharm charmDoc
 
 func (ch *backingCharm) updated(ctx *allWatcherContext) error {
@@ -1025,6 +1095,7 @@ func (r *backingRelation) updated(ctx *allWatcherContext) error {
 		ModelUUID: r.ModelUUID,
 		Key:       r.Key,
 		ID:        r.Id,
+		Life:      life.Value(r.Life.String()),
 		Endpoints: eps,
 	}
 	ctx.store.Update(info)
@@ -1645,6 +1716,7 @@ func NewAllWatcherBacking(pool *StatePool) AllWatcherBacking {
 		settingsC,
 		// And for CAAS we need to watch these...
 		podSpecsC,
+		cloudContainersC,
 	}
 	collectionMap := makeAllWatcherCollectionInfo(collectionNames)
 	controllerState := pool.SystemState()
@@ -1865,6 +1937,7 @@ type allWatcherContext struct {
 	constraints map[string]constraints.Value
 	statuses    map[string]status.StatusInfo
 	instances   map[string]instanceData
+	containers  map[string]cloudContainerDoc
 	// A map of the existing MachinePortRanges where the keys are machine IDs.
 	openPortRanges map[string]MachinePortRanges
 	userAccess     map[string]map[string]permission.Access
@@ -1886,6 +1959,9 @@ func (ctx *allWatcherContext) loadSubsidiaryCollections() error {
 	if err := ctx.loadInstanceData(); err != nil {
 		return errors.Annotatef(err, "cache instance data")
 	}
+	if err := ctx.loadCloudContainers(); err != nil {
+		return errors.Annotatef(err, "cache cloud containers")
+	}
 	if err := ctx.loadOpenedPortRanges(); err != nil {
 		return errors.Annotatef(err, "cache opened ports")
 	}
@@ -1966,6 +2042,23 @@ func (ctx *allWatcherContext) loadInstanceData() error {
 	return nil
 }
 
+func (ctx *allWatcherContext) loadCloudContainers() error {
+	col, closer := ctx.state.db().GetCollection(cloudContainersC)
+	defer closer()
+
+	var docs []cloudContainerDoc
+	if err := col.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot read all cloud containers")
+	}
+
+	ctx.containers = make(map[string]cloudContainerDoc)
+	for _, doc := range docs {
+		ctx.containers[doc.Id] = doc
+	}
+
+	return nil
+}
+
 func (ctx *allWatcherContext) loadOpenedPortRanges() error {
 	openedMachineRanges, err := getOpenedPortRangesForAllMachines(ctx.state)
 	if err != nil {
@@ -2123,6 +2216,28 @@ func (ctx *allWatcherContext) getInstanceData(id string) (instanceData, error) {
 	return getInstanceData(ctx.state, id)
 }
 
+func (ctx *allWatcherContext) getCloudContainer(key string) (*backingCloudContainer, error) {
+	var doc cloudContainerDoc
+	if ctx.containers != nil {
+		gKey := ensureModelUUID(ctx.modelUUID, key)
+		cached, found := ctx.containers[gKey]
+		if !found {
+			return nil, errors.NotFoundf("cloud container %v", key)
+		}
+		doc = cached
+	} else {
+		col, closer := ctx.state.db().GetCollection(cloudContainersC)
+		defer closer()
+		if err := col.FindId(key).One(&doc); err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("cloud container %v", key)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	container := backingCloudContainer(doc)
+	return &container, nil
+}
+
 func (ctx *allWatcherContext) permissionsForModel(uuid string) (map[string]permission.Access, error) {
 	if ctx.userAccess != nil {
 		return ctx.userAccess[uuid], nil
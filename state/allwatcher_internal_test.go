@@ -254,6 +254,7 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		ModelUUID: modelUUID,
 		Key:       "logging:logging-directory wordpress:logging-dir",
 		ID:        rel.Id(),
+		Life:      life.Alive,
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}},
 			{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
@@ -428,6 +429,7 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		ModelUUID: modelUUID,
 		Key:       rel.Tag().Id(),
 		ID:        rel.Id(),
+		Life:      life.Alive,
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress2", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
@@ -440,6 +442,7 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		ModelUUID: modelUUID,
 		Key:       rel2.Tag().Id(),
 		ID:        rel2.Id(),
+		Life:      life.Alive,
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
@@ -888,6 +891,86 @@ func (s *allWatcherStateSuite) TestChangeCAASUnits(c *gc.C) {
 				},
 			}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			caasSt := s.newCAASState(c)
+			ch := AddTestingCharmForSeries(c, caasSt, "kubernetes", "mysql")
+			mysql := AddTestingApplication(c, caasSt, "mysql", ch)
+			unit, err := mysql.AddUnit(AddUnitParams{})
+			c.Assert(err, jc.ErrorIsNil)
+
+			updateUnits := UpdateUnitsOperation{
+				Updates: []*UpdateUnitOperation{
+					unit.UpdateOperation(UnitUpdateProperties{
+						ProviderId: strPtr("mysql-pod"),
+						Address:    strPtr("10.0.0.1"),
+						Ports:      &[]string{"3306/TCP"},
+					}),
+				},
+			}
+			err = mysql.UpdateUnits(&updateUnits)
+			c.Assert(err, jc.ErrorIsNil)
+
+			return changeTestCase{
+				about: "cloud container details update existing unit",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.UnitInfo{
+						ModelUUID:   caasSt.ModelUUID(),
+						Name:        "mysql/0",
+						Application: "mysql",
+						Series:      "kubernetes",
+					},
+				},
+				change: watcher.Change{
+					C:  "cloudcontainers",
+					Id: caasSt.docID(unit.globalCloudContainerKey()),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.UnitInfo{
+						ModelUUID:        caasSt.ModelUUID(),
+						Name:             "mysql/0",
+						Application:      "mysql",
+						Series:           "kubernetes",
+						ProviderID:       "mysql-pod",
+						ContainerAddress: "10.0.0.1",
+						ContainerPorts:   []string{"3306/TCP"},
+					},
+				},
+			}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			caasSt := s.newCAASState(c)
+			ch := AddTestingCharmForSeries(c, caasSt, "kubernetes", "mysql")
+			mysql := AddTestingApplication(c, caasSt, "mysql", ch)
+			unit, err := mysql.AddUnit(AddUnitParams{})
+			c.Assert(err, jc.ErrorIsNil)
+
+			return changeTestCase{
+				about: "cloud container removal clears details on existing unit",
+				initialContents: []multiwatcher.EntityInfo{
+					&multiwatcher.UnitInfo{
+						ModelUUID:        caasSt.ModelUUID(),
+						Name:             "mysql/0",
+						Application:      "mysql",
+						Series:           "kubernetes",
+						ProviderID:       "mysql-pod",
+						ContainerAddress: "10.0.0.1",
+						ContainerPorts:   []string{"3306/TCP"},
+					},
+				},
+				change: watcher.Change{
+					C:  "cloudcontainers",
+					Id: caasSt.docID(unit.globalCloudContainerKey()),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.UnitInfo{
+						ModelUUID:   caasSt.ModelUUID(),
+						Name:        "mysql/0",
+						Application: "mysql",
+						Series:      "kubernetes",
+					},
+				},
+			}
+		},
 	}
 	s.performChangeTestCases(c, changeTestFuncs)
 }
@@ -2066,6 +2149,7 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 					&multiwatcher.RelationInfo{
 						ModelUUID: st.ModelUUID(),
 						Key:       "logging:logging-directory wordpress:logging-dir",
+						Life:      life.Alive,
 						Endpoints: []multiwatcher.Endpoint{
 							{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}},
 							{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
@@ -2105,6 +2105,14 @@ func (a *Application) addUnitOps(
 	// we verify the application is alive
 	asserts = append(isAliveDoc, asserts...)
 	ops = append(ops, a.incUnitCountOp(asserts))
+
+	// If a branch is tracking the whole application,
+	// the new unit tracks it too.
+	branchOps, err := trackingBranchUnitOps(a.st, a.doc.Name, uNames)
+	if err != nil {
+		return uNames, nil, errors.Trace(err)
+	}
+	ops = append(ops, branchOps...)
 	return uNames, ops, nil
 }
 
@@ -3162,6 +3170,33 @@ func (a *Application) UnitStatuses() (map[string]status.StatusInfo, error) {
 	return result, nil
 }
 
+// DerivedStatus returns the status of the application if it is set.
+// If not, the status is derived from the workload statuses of its units,
+// as it is for applications in the model cache.
+func (a *Application) DerivedStatus() (status.StatusInfo, error) {
+	info, err := a.Status()
+	if err != nil {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	if info.Status != status.Unset {
+		return info, nil
+	}
+
+	unitStatuses, err := a.UnitStatuses()
+	if err != nil {
+		return status.StatusInfo{}, errors.Trace(err)
+	}
+	statuses := make([]status.StatusInfo, 0, len(unitStatuses))
+	for _, unitStatus := range unitStatuses {
+		statuses = append(statuses, unitStatus)
+	}
+	derived := status.DeriveStatus(statuses)
+	if derived.Since == nil {
+		derived.Since = info.Since
+	}
+	return derived, nil
+}
+
 type addApplicationOpsArgs struct {
 	applicationDoc    *applicationDoc
 	statusDoc         statusDoc
@@ -3467,6 +3502,26 @@ func (a *Application) UpdateCloudService(providerId string, addresses []network.
 	return errors.Trace(err)
 }
 
+// SetCloudServiceAddresses replaces the addresses of the application's
+// cloud service. Passing no addresses clears those recorded, as when the
+// cloud removes a load balancer. This is only used for CAAS models.
+func (a *Application) SetCloudServiceAddresses(addresses []network.SpaceAddress) error {
+	ops := []txn.Op{{
+		C:      cloudServicesC,
+		Id:     applicationGlobalKey(a.Name()),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"addresses", fromNetworkAddresses(addresses, network.OriginProvider)},
+		}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("cloud service for application %q", a.Name())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set cloud service addresses for application %q", a.Name())
+	}
+	return nil
+}
+
 // ServiceInfo returns information about this application's cloud service.
 // This is only used for CAAS models.
 func (a *Application) ServiceInfo() (CloudServicer, error) {
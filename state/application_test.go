@@ -4168,6 +4168,30 @@ func (s *ApplicationSuite) TestUnitStatusesWithUnits(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestDerivedStatus(c *gc.C) {
+	u1, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.SetStatus(status.StatusInfo{Status: status.Maintenance})
+	c.Assert(err, jc.ErrorIsNil)
+	u2, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.SetStatus(status.StatusInfo{Status: status.Blocked, Message: "waiting"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// While the application status is unset,
+	// it is derived from the unit statuses.
+	info, err := s.mysql.DerivedStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Status, gc.Equals, status.Blocked)
+	c.Check(info.Message, gc.Equals, "waiting")
+
+	err = s.mysql.SetStatus(status.StatusInfo{Status: status.Active})
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = s.mysql.DerivedStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Status, gc.Equals, status.Active)
+}
+
 func sampleApplicationConfigSchema() environschema.Fields {
 	schema := environschema.Fields{
 		"title":       environschema.Attr{Type: environschema.Tstring},
@@ -4493,6 +4517,32 @@ func (s *CAASApplicationSuite) TestServiceInfoEmptyProviderId(c *gc.C) {
 	}
 }
 
+func (s *CAASApplicationSuite) TestSetCloudServiceAddresses(c *gc.C) {
+	err := s.app.UpdateCloudService("id", network.NewSpaceAddresses("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	addrs := network.NewSpaceAddresses("10.0.0.1", "203.0.113.1")
+	err = s.app.SetCloudServiceAddresses(addrs)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.app.ServiceInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ProviderId(), gc.Equals, "id")
+	c.Assert(info.Addresses(), jc.DeepEquals, addrs)
+
+	// No addresses clears those recorded.
+	err = s.app.SetCloudServiceAddresses(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err = s.app.ServiceInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.ProviderId(), gc.Equals, "id")
+	c.Assert(info.Addresses(), gc.HasLen, 0)
+}
+
+func (s *CAASApplicationSuite) TestSetCloudServiceAddressesNoService(c *gc.C) {
+	err := s.app.SetCloudServiceAddresses(network.NewSpaceAddresses("10.0.0.1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *CAASApplicationSuite) TestRemoveApplicationDeletesServiceInfo(c *gc.C) {
 	addrs := network.NewSpaceAddresses("10.0.0.1")
 
@@ -60,6 +60,17 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.MaxCharmStateSize,
 		controller.MaxAgentStateSize,
 		controller.NonSyncedWritesToRaftLog,
+		controller.LogSinkIngestionRate,
+		controller.LogSinkIngestionBurst,
+		controller.ModelLogsDailyQuota,
+		controller.MaxCharmUploadSize,
+		controller.MaxResourceUploadSize,
+		controller.MaxAgentBinaryUploadSize,
+		controller.MaxBackupUploadSize,
+		controller.AgentChurnDisconnects,
+		controller.AgentChurnWindow,
+		controller.ModelCacheEvictionRetention,
+		controller.ModelCacheMaxResidents,
 	)
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
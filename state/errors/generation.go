@@ -0,0 +1,67 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/settings"
+)
+
+type branchConflictError struct {
+	branchName string
+	conflicts  map[string][]settings.Conflict
+}
+
+// NewBranchConflictError returns an error indicating that the input branch
+// can not be committed without overwriting the input charm config conflicts,
+// keyed by application name.
+func NewBranchConflictError(branchName string, conflicts map[string][]settings.Conflict) error {
+	return &branchConflictError{
+		branchName: branchName,
+		conflicts:  conflicts,
+	}
+}
+
+// Error is part of the error interface.
+func (e *branchConflictError) Error() string {
+	appNames := make([]string, 0, len(e.conflicts))
+	for appName := range e.conflicts {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	var details []string
+	for _, appName := range appNames {
+		keys := make([]string, len(e.conflicts[appName]))
+		for i, conflict := range e.conflicts[appName] {
+			keys[i] = conflict.Key
+		}
+		details = append(details, fmt.Sprintf("%s (%s)", appName, strings.Join(keys, ", ")))
+	}
+	return fmt.Sprintf("branch %q config changed on master since it was changed under the branch: %s",
+		e.branchName, strings.Join(details, ", "))
+}
+
+// IsBranchConflictError reports whether or not the given error was caused
+// by an attempt to commit a branch with config changes that would
+// overwrite newer master values.
+func IsBranchConflictError(err error) bool {
+	_, ok := errors.Cause(err).(*branchConflictError)
+	return ok
+}
+
+// BranchConflicts returns the conflicts, keyed by application
+// name, carried by a branch conflict error. It returns nil for
+// any other error.
+func BranchConflicts(err error) map[string][]settings.Conflict {
+	if e, ok := errors.Cause(err).(*branchConflictError); ok {
+		return e.conflicts
+	}
+	return nil
+}
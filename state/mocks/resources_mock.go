@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	resource "github.com/juju/charm/v9/resource"
 	resource0 "github.com/juju/juju/resource"
+	state "github.com/juju/juju/state"
 	txn "gopkg.in/mgo.v2/txn"
 	io "io"
 	reflect "reflect"
@@ -52,6 +53,51 @@ func (mr *MockResourcesMockRecorder) AddPendingResource(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPendingResource", reflect.TypeOf((*MockResources)(nil).AddPendingResource), arg0, arg1, arg2)
 }
 
+// CharmResourceChanges mocks base method
+func (m *MockResources) CharmResourceChanges(arg0 string, arg1 map[string]resource.Meta) ([]state.CharmResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CharmResourceChanges", arg0, arg1)
+	ret0, _ := ret[0].([]state.CharmResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CharmResourceChanges indicates an expected call of CharmResourceChanges
+func (mr *MockResourcesMockRecorder) CharmResourceChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmResourceChanges", reflect.TypeOf((*MockResources)(nil).CharmResourceChanges), arg0, arg1)
+}
+
+// CheckResourceConsistency mocks base method
+func (m *MockResources) CheckResourceConsistency(arg0 string) ([]state.ResourceDiscrepancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckResourceConsistency", arg0)
+	ret0, _ := ret[0].([]state.ResourceDiscrepancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckResourceConsistency indicates an expected call of CheckResourceConsistency
+func (mr *MockResourcesMockRecorder) CheckResourceConsistency(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckResourceConsistency", reflect.TypeOf((*MockResources)(nil).CheckResourceConsistency), arg0)
+}
+
+// ExportResources mocks base method
+func (m *MockResources) ExportResources(arg0 string) ([]state.ExportedResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportResources", arg0)
+	ret0, _ := ret[0].([]state.ExportedResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportResources indicates an expected call of ExportResources
+func (mr *MockResourcesMockRecorder) ExportResources(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportResources", reflect.TypeOf((*MockResources)(nil).ExportResources), arg0)
+}
+
 // GetPendingResource mocks base method
 func (m *MockResources) GetPendingResource(arg0, arg1, arg2 string) (resource0.Resource, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +128,21 @@ func (mr *MockResourcesMockRecorder) GetResource(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResource", reflect.TypeOf((*MockResources)(nil).GetResource), arg0, arg1)
 }
 
+// ImportResource mocks base method
+func (m *MockResources) ImportResource(arg0 resource0.Resource, arg1 map[string]resource0.Resource, arg2 io.Reader) (resource0.Resource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportResource", arg0, arg1, arg2)
+	ret0, _ := ret[0].(resource0.Resource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportResource indicates an expected call of ImportResource
+func (mr *MockResourcesMockRecorder) ImportResource(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportResource", reflect.TypeOf((*MockResources)(nil).ImportResource), arg0, arg1, arg2)
+}
+
 // ListPendingResources mocks base method
 func (m *MockResources) ListPendingResources(arg0 string) ([]resource0.Resource, error) {
 	m.ctrl.T.Helper()
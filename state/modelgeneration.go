@@ -13,11 +13,13 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	"github.com/juju/naturalsort"
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/mongo/utils"
 	stateerrors "github.com/juju/juju/state/errors"
@@ -68,6 +70,11 @@ type generationDoc struct {
 	// generation, but no units currently set to be in it.
 	AssignedUnits map[string][]string `bson:"assigned-units"`
 
+	// TrackingPolicies records, keyed by application name, how units of
+	// applications tracked in this generation come to track it.
+	// See model.BranchTrackingPolicy for the recognised values.
+	TrackingPolicies map[string]string `bson:"tracking-policies,omitempty"`
+
 	// Config is all changes made to charm configuration under this branch.
 	Config map[string][]itemChange `bson:"charm-config"`
 
@@ -85,6 +92,10 @@ type generationDoc struct {
 
 	// CompletedBy is the user who committed this generation to the model.
 	CompletedBy string `bson:"completed-by"`
+
+	// Comment is an optional note supplied by the user who
+	// committed this generation, explaining why it was committed.
+	Comment string `bson:"comment,omitempty"`
 }
 
 // Generation represents the state of a model generation.
@@ -114,6 +125,16 @@ func (g *Generation) AssignedUnits() map[string][]string {
 	return g.doc.AssignedUnits
 }
 
+// TrackingPolicy returns the policy recorded for the input application.
+// Applications without a recorded policy only have explicitly assigned
+// units tracking the branch.
+func (g *Generation) TrackingPolicy(appName string) model.BranchTrackingPolicy {
+	if policy, ok := g.doc.TrackingPolicies[appName]; ok {
+		return model.BranchTrackingPolicy(policy)
+	}
+	return model.BranchTrackExplicitUnits
+}
+
 // Config returns all changed charm configuration for the generation.
 // The persisted objects are converted to core changes.
 func (g *Generation) Config() map[string]settings.ItemChanges {
@@ -155,6 +176,11 @@ func (g *Generation) CompletedBy() string {
 	return g.doc.CompletedBy
 }
 
+// Comment returns the note recorded when the generation was committed.
+func (g *Generation) Comment() string {
+	return g.doc.Comment
+}
+
 // AssignApplication indicates that the application with the input name has had
 // changes in this generation.
 func (g *Generation) AssignApplication(appName string) error {
@@ -203,6 +229,10 @@ func (g *Generation) AssignAllUnits(appName string) error {
 	return g.AssignUnits(appName, 0)
 }
 
+// AssignUnits designates numUnits units of the input application that are
+// not yet tracking the branch as tracking it, lowest unit numbers first.
+// If numUnits is zero, all of the application's units are assigned.
+// An error is returned if numUnits exceeds the number of untracked units.
 func (g *Generation) AssignUnits(appName string, numUnits int) error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
@@ -232,33 +262,80 @@ func (g *Generation) AssignUnits(appName string, numUnits int) error {
 			},
 		}
 		// Ensure we sort the unitNames so that when we ask for the numUnits
-		// to track, they're going to be predictable results.
-		sort.Strings(unitNames)
+		// to track, they're going to be predictable results. The names are
+		// sorted naturally so that lower unit numbers are assigned first.
+		naturalsort.Sort(unitNames)
 
-		var assigned int
+		var untracked []string
 		assignedUnits := set.NewStrings(g.doc.AssignedUnits[appName]...)
 		for _, name := range unitNames {
 			if !assignedUnits.Contains(name) {
-				if numUnits > 0 && numUnits == assigned {
-					break
-				}
-				unit, err := g.st.Unit(name)
-				if err != nil {
-					return nil, errors.Trace(err)
-				}
-				ops = append(ops, assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)...)
-				assigned++
+				untracked = append(untracked, name)
 			}
 		}
+		if numUnits > len(untracked) {
+			return nil, errors.Errorf(
+				"cannot track %d units of application %q: only %d units are not tracking branch %q",
+				numUnits, appName, len(untracked), g.BranchName())
+		}
+		if numUnits > 0 {
+			untracked = untracked[:numUnits]
+		}
 		// If there are no units to add to the generation, quit here.
-		if assigned == 0 {
+		if len(untracked) == 0 {
 			return nil, jujutxn.ErrNoOperations
 		}
+		for _, name := range untracked {
+			unit, err := g.st.Unit(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)...)
+		}
 		return ops, nil
 	}
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
 
+// SetTrackingPolicy records the policy determining whether units of the
+// input application added while this branch is in-flight will track it.
+func (g *Generation) SetTrackingPolicy(appName string, policy model.BranchTrackingPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current, ok := g.doc.TrackingPolicies[appName]; ok && current == string(policy) {
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		// Ensure that the application is recorded against the branch even
+		// if it has no units yet, so that units added later are assigned.
+		var ops []txn.Op
+		if _, ok := g.doc.AssignedUnits[appName]; !ok {
+			ops = assignGenerationAppTxnOps(g.doc.DocId, appName)
+		}
+		return append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"completed", 0}},
+			Update: bson.D{
+				{"$set", bson.D{{"tracking-policies." + appName, string(policy)}}},
+			},
+		}), nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
 // AssignUnit indicates that the unit with the input name is tracking this
 // branch, by adding the name to the generation.
 func (g *Generation) AssignUnit(unitName string) error {
@@ -314,6 +391,107 @@ func assignGenerationUnitTxnOps(id, appName string, unit *Unit) []txn.Op {
 	}
 }
 
+// assignNewUnitOps returns operations that add the input unit name to the
+// generation. Unlike assignGenerationUnitTxnOps, it does not assert on the
+// unit document, so it can be used in the same transaction that adds the unit.
+func (g *Generation) assignNewUnitOps(appName, unitName string) []txn.Op {
+	appField := "assigned-units." + appName
+
+	return []txn.Op{
+		{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{{"$and", []bson.D{
+				{{"completed", 0}},
+				{{appField, bson.D{{"$exists", true}}}},
+			}}},
+			Update: bson.D{
+				{"$push", bson.D{{appField, unitName}}},
+			},
+		},
+	}
+}
+
+// UnassignUnit indicates that the unit with the input name is no longer
+// tracking this branch, by removing the name from the generation.
+func (g *Generation) UnassignUnit(unitName string) error {
+	appName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !set.NewStrings(g.doc.AssignedUnits[appName]...).Contains(unitName) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return g.unassignUnitOps(unitName, appName), nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// UnassignAllUnits indicates that no unit of the input application is
+// tracking this branch. The application's tracking policy is removed, so
+// units added to it later do not track the branch either. Config changes
+// made for the application under the branch are retained.
+func (g *Generation) UnassignAllUnits(appName string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		assigned, isAssigned := g.doc.AssignedUnits[appName]
+		_, hasPolicy := g.doc.TrackingPolicies[appName]
+		if len(assigned) == 0 && !hasPolicy {
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		// An application with config changes stays in the assigned
+		// units without any units. Otherwise it is removed altogether.
+		appField := "assigned-units." + appName
+		var set, unset bson.D
+		if g.HasChangesFor(appName) {
+			set = append(set, bson.DocElem{Name: appField, Value: []string{}})
+		} else if isAssigned {
+			unset = append(unset, bson.DocElem{Name: appField, Value: 1})
+		}
+		if hasPolicy {
+			unset = append(unset, bson.DocElem{Name: "tracking-policies." + appName, Value: 1})
+		}
+		var update bson.D
+		if len(set) > 0 {
+			update = append(update, bson.DocElem{Name: "$set", Value: set})
+		}
+		if len(unset) > 0 {
+			update = append(update, bson.DocElem{Name: "$unset", Value: unset})
+		}
+
+		// As a proxy for checking that the generation has not changed,
+		// Assert that the txn rev-no has not changed since we materialised
+		// this generation object.
+		return []txn.Op{{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: update,
+		}}, nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
 // UpdateCharmConfig applies the input changes to the input application's
 // charm configuration under this branch.
 // the incoming charm settings are assumed to have been validated.
@@ -372,9 +550,48 @@ func (g *Generation) UpdateCharmConfig(appName string, master *Settings, validCh
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
 
+// ConfigConflicts returns the charm config changes made under this branch,
+// keyed by application name, that conflict with changes made on master
+// since each setting was first changed under the branch.
+// The master value recorded as the old value of each change is the
+// baseline against which the current master value is compared.
+// Applications without conflicts are not included.
+func (g *Generation) ConfigConflicts() (map[string][]settings.Conflict, error) {
+	conflicts := make(map[string][]settings.Conflict)
+	for appName, delta := range g.Config() {
+		if len(delta) == 0 {
+			continue
+		}
+		cfg, err := g.masterCharmConfig(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if appConflicts := delta.Conflicts(cfg.Map()); len(appConflicts) > 0 {
+			conflicts[appName] = appConflicts
+		}
+	}
+	return conflicts, nil
+}
+
+// masterCharmConfig returns the master charm config
+// settings for the application with the input name.
+func (g *Generation) masterCharmConfig(appName string) (*Settings, error) {
+	app, err := g.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := readSettings(g.st.db(), settingsC, app.charmConfigKey())
+	return cfg, errors.Trace(err)
+}
+
 // Commit marks the generation as completed and assigns it the next value from
 // the generation sequence. The new generation ID is returned.
-func (g *Generation) Commit(userName string) (int, error) {
+// If master charm config has been changed since any of the settings changed
+// under the branch were first changed, an error satisfying
+// IsBranchConflictError is returned, unless force is true, in which case
+// the branch values overwrite those on master.
+// The input comment, which may be empty, is recorded with the commit.
+func (g *Generation) Commit(userName string, force bool, comment string) (int, error) {
 	var newGenId int
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -399,7 +616,7 @@ func (g *Generation) Commit(userName string) (int, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		ops, err := g.commitConfigTxnOps()
+		ops, err := g.commitConfigTxnOps(force)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -428,6 +645,7 @@ func (g *Generation) Commit(userName string) (int, error) {
 					{"assigned-units", assigned},
 					{"completed", now.Unix()},
 					{"completed-by", userName},
+					{"comment", comment},
 					{"generation-id", newGenId},
 				}},
 			},
@@ -460,22 +678,27 @@ func (g *Generation) assignedWithAllUnits() (map[string][]string, error) {
 // deltas, determines their effective new settings, then gathers the
 // operations representing the changes so that they can all be applied in a
 // single transaction.
-func (g *Generation) commitConfigTxnOps() ([]txn.Op, error) {
+// Unless force is true, an error is returned if any of the deltas
+// conflict with changes made on master.
+func (g *Generation) commitConfigTxnOps(force bool) ([]txn.Op, error) {
 	var ops []txn.Op
+	conflicts := make(map[string][]settings.Conflict)
 	for appName, delta := range g.Config() {
 		if len(delta) == 0 {
 			continue
 		}
-		app, err := g.st.Application(appName)
+		cfg, err := g.masterCharmConfig(appName)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if !force {
+			if appConflicts := delta.Conflicts(cfg.Map()); len(appConflicts) > 0 {
+				conflicts[appName] = appConflicts
+				continue
+			}
+		}
 
 		// Apply the branch delta to the application's charm config settings.
-		cfg, err := readSettings(g.st.db(), settingsC, app.charmConfigKey())
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
 		cfg.applyChanges(delta)
 
 		_, updates := cfg.settingsUpdateOps()
@@ -486,6 +709,9 @@ func (g *Generation) commitConfigTxnOps() ([]txn.Op, error) {
 			ops = append(ops, updates...)
 		}
 	}
+	if len(conflicts) > 0 {
+		return nil, stateerrors.NewBranchConflictError(g.BranchName(), conflicts)
+	}
 	return ops, nil
 }
 
@@ -618,6 +844,16 @@ func (g *Generation) unassignAppOps(appName string) []txn.Op {
 			{"$set", bson.D{{"assigned-units", assigned}}},
 		},
 	}}
+	if _, ok := g.doc.TrackingPolicies[appName]; ok {
+		ops = append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{
+				{"$unset", bson.D{{"tracking-policies." + appName, 1}}},
+			},
+		})
+	}
 	currentCfg := g.doc.Config
 	if _, ok := currentCfg[appName]; ok {
 		newCfg := map[string][]itemChange{}
@@ -852,6 +1088,30 @@ func (m *Model) unitBranch(unitName string) (*Generation, error) {
 	return nil, nil
 }
 
+// trackingBranchUnitOps returns operations assigning the input unit, which is
+// being added to the application, to an in-flight branch that tracks all of
+// the application's units.
+// As with unitBranch, a unit only ever tracks a single branch.
+func trackingBranchUnitOps(st *State, appName, unitName string) ([]txn.Op, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	branches, err := m.applicationBranches(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, b := range branches {
+		if _, ok := b.doc.AssignedUnits[appName]; !ok {
+			continue
+		}
+		if b.TrackingPolicy(appName) == model.BranchTrackAll {
+			return b.assignNewUnitOps(appName, unitName), nil
+		}
+	}
+	return nil, nil
+}
+
 func newGeneration(st *State, doc *generationDoc) *Generation {
 	return &Generation{
 		st:  st,
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/state"
+	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/testing"
 )
 
@@ -56,7 +57,7 @@ func (s *generationSuite) TestAssignApplicationCompletedError(c *gc.C) {
 	gen := s.addBranch(c)
 
 	// Absence of changes will result in an aborted generation.
-	_, err := gen.Commit(branchCommitter)
+	_, err := gen.Commit(branchCommitter, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
@@ -81,7 +82,7 @@ func (s *generationSuite) TestAssignUnitBranchAbortedError(c *gc.C) {
 	gen := s.addBranch(c)
 
 	// Absence of changes will result in an aborted generation.
-	_, err := gen.Commit(branchCommitter)
+	_, err := gen.Commit(branchCommitter, false, "")
 
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -102,7 +103,7 @@ func (s *generationSuite) TestAssignUnitBranchCommittedError(c *gc.C) {
 	// Make a change so that commit is a real commit with a generation ID.
 	c.Assert(gen.AssignApplication("riak"), jc.ErrorIsNil)
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
-	_, err := gen.Commit(branchCommitter)
+	_, err := gen.Commit(branchCommitter, false, "")
 
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -194,17 +195,46 @@ func (s *generationSuite) TestAssignNumUnitsSuccessRemaining(c *gc.C) {
 func (s *generationSuite) TestAssignUnitsNoOperations(c *gc.C) {
 	gen := s.setupAssignUnits(c)
 
-	c.Assert(gen.AssignUnits("riak", 1), jc.ErrorIsNil)
+	c.Assert(gen.AssignAllUnits("riak"), jc.ErrorIsNil)
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
 	c.Check(gen.AssignedUnits(), gc.HasLen, 0)
 }
 
+func (s *generationSuite) TestAssignNumUnitsTooMany(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignUnits("riak", 3), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	err := gen.AssignUnits("riak", 2)
+	c.Assert(err, gc.ErrorMatches,
+		`cannot track 2 units of application "riak": only 1 units are not tracking branch "new-branch"`)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1", "riak/2"})
+}
+
+func (s *generationSuite) TestAssignNumUnitsLowestFirst(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 8; i++ {
+		_, err := riak.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Unit numbers are ordered numerically, so riak/10
+	// and riak/11 are assigned after riak/2.
+	c.Assert(gen.AssignUnits("riak", 3), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1", "riak/2"})
+}
+
 func (s *generationSuite) TestAssignNumUnitsSelectAll(c *gc.C) {
 	gen := s.setupAssignAllUnits(c)
 
 	expected := []string{"riak/0", "riak/1", "riak/2", "riak/3"}
 
-	c.Assert(gen.AssignUnits("riak", 100), jc.ErrorIsNil)
+	c.Assert(gen.AssignUnits("riak", 4), jc.ErrorIsNil)
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
 	c.Check(gen.AssignedUnits(), gc.HasLen, 1)
 	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, expected)
@@ -221,7 +251,7 @@ func (s *generationSuite) TestAssignAllUnitsCompletedError(c *gc.C) {
 	gen := s.setupAssignAllUnits(c)
 
 	// Absence of changes will result in an aborted generation.
-	_, err := gen.Commit(branchCommitter)
+	_, err := gen.Commit(branchCommitter, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
@@ -235,26 +265,180 @@ func (s *generationSuite) TestCommitAssignsRemainingUnits(c *gc.C) {
 	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
 
-	genId, err := gen.Commit(branchCommitter)
+	genId, err := gen.Commit(branchCommitter, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(genId, gc.Not(gc.Equals), 0)
 
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
 	c.Check(gen.IsCompleted(), jc.IsTrue)
 	c.Check(gen.CompletedBy(), gc.Equals, branchCommitter)
+	c.Check(gen.Comment(), gc.Equals, "")
 	c.Check(gen.AssignedUnits(), gc.HasLen, 1)
 	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1", "riak/2", "riak/3"})
 
 	// Idempotent.
-	_, err = gen.Commit(branchCommitter)
+	_, err = gen.Commit(branchCommitter, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *generationSuite) TestCommitWithComment(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	_, err := gen.Commit(branchCommitter, false, "scale out the ring")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsTrue)
+	c.Check(gen.Comment(), gc.Equals, "scale out the ring")
+}
+
+func (s *generationSuite) TestSetTrackingPolicy(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	c.Check(gen.TrackingPolicy("riak"), gc.Equals, model.BranchTrackExplicitUnits)
+
+	c.Assert(gen.SetTrackingPolicy("riak", model.BranchTrackAll), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.TrackingPolicy("riak"), gc.Equals, model.BranchTrackAll)
+	c.Check(gen.AssignedUnits(), gc.DeepEquals, map[string][]string{"riak": {}})
+
+	// Idempotent.
+	c.Assert(gen.SetTrackingPolicy("riak", model.BranchTrackAll), jc.ErrorIsNil)
+
+	err := gen.SetTrackingPolicy("riak", "some-units")
+	c.Assert(err, gc.ErrorMatches, `branch tracking policy "some-units" not valid`)
+}
+
+func (s *generationSuite) TestAddUnitTracksBranchWithTrackAllPolicy(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignAllUnits("riak"), jc.ErrorIsNil)
+	c.Assert(gen.SetTrackingPolicy("riak", model.BranchTrackAll), jc.ErrorIsNil)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = riak.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents,
+		[]string{"riak/0", "riak/1", "riak/2", "riak/3", "riak/4"})
+}
+
+func (s *generationSuite) TestAddUnitDoesNotTrackBranchWithExplicitUnitsPolicy(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignUnits("riak", 2), jc.ErrorIsNil)
+	c.Assert(gen.SetTrackingPolicy("riak", model.BranchTrackExplicitUnits), jc.ErrorIsNil)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = riak.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1"})
+}
+
+func (s *generationSuite) TestUnassignUnitSuccess(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignUnits("riak", 2), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.UnassignUnit("riak/0"), jc.ErrorIsNil)
+
+	expected := map[string][]string{"riak": {"riak/1"}}
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits(), gc.DeepEquals, expected)
+
+	// Idempotent.
+	c.Assert(gen.UnassignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits(), gc.DeepEquals, expected)
+}
+
+func (s *generationSuite) TestUnassignUnitBranchCommittedError(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	_, err := gen.Commit(branchCommitter, false, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.UnassignUnit("riak/0"), gc.ErrorMatches, "branch was already committed")
+}
+
+func (s *generationSuite) TestUnassignAllUnits(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignAllUnits("riak"), jc.ErrorIsNil)
+	c.Assert(gen.SetTrackingPolicy("riak", model.BranchTrackAll), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.UnassignAllUnits("riak"), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits(), gc.HasLen, 0)
+	c.Check(gen.TrackingPolicy("riak"), gc.Equals, model.BranchTrackExplicitUnits)
+
+	// Units added later do not track the branch.
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = riak.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits(), gc.HasLen, 0)
+
+	// Idempotent.
+	c.Assert(gen.UnassignAllUnits("riak"), jc.ErrorIsNil)
+}
+
+func (s *generationSuite) TestUnassignAllUnitsRetainsConfigChanges(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCfg := map[string]interface{}{"http_port": int64(9999)}
+	c.Assert(app.UpdateCharmConfig(newBranchName, newCfg), jc.ErrorIsNil)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	c.Assert(gen.UnassignAllUnits("riak"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits(), gc.DeepEquals, map[string][]string{"riak": {}})
+	c.Check(gen.HasChangesFor("riak"), jc.IsTrue)
+}
+
+func (s *generationSuite) TestCommitWithAutoTrackedUnits(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.AssignAllUnits("riak"), jc.ErrorIsNil)
+	c.Assert(gen.SetTrackingPolicy("riak", model.BranchTrackAll), jc.ErrorIsNil)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = riak.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	genId, err := gen.Commit(branchCommitter, false, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(genId, gc.Not(gc.Equals), 0)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsTrue)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents,
+		[]string{"riak/0", "riak/1", "riak/2", "riak/3", "riak/4"})
+}
+
 func (s *generationSuite) TestCommitNoChangesEffectivelyAborted(c *gc.C) {
 	s.setupTestingClock(c)
 	gen := s.addBranch(c)
 
-	genId, err := gen.Commit(branchCommitter)
+	genId, err := gen.Commit(branchCommitter, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(genId, gc.Equals, 0)
 
@@ -274,7 +458,7 @@ func (s *generationSuite) TestCommitAppliesConfigDeltas(c *gc.C) {
 	c.Assert(app.UpdateCharmConfig(newBranchName, newCfg), jc.ErrorIsNil)
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
 
-	_, err = gen.Commit(branchCommitter)
+	_, err = gen.Commit(branchCommitter, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(gen.Refresh(), jc.ErrorIsNil)
 
@@ -284,6 +468,74 @@ func (s *generationSuite) TestCommitAppliesConfigDeltas(c *gc.C) {
 	c.Check(cfg, gc.DeepEquals, charm.Settings(newCfg))
 }
 
+func (s *generationSuite) TestCommitConfigConflict(c *gc.C) {
+	s.setupTestingClock(c)
+	gen, app := s.setupConfigConflict(c)
+
+	conflicts, err := gen.ConfigConflicts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(conflicts, gc.DeepEquals, map[string][]settings.Conflict{"riak": {{
+		Key:      "http_port",
+		Baseline: int64(9999),
+		Master:   int64(7777),
+		Branch:   int64(8888),
+	}}})
+
+	_, err = gen.Commit(branchCommitter, false, "")
+	c.Assert(err, jc.Satisfies, stateerrors.IsBranchConflictError)
+	c.Check(err, gc.ErrorMatches, `branch "new-branch" config changed on master .*: riak \(http_port\)`)
+	c.Check(stateerrors.BranchConflicts(err), gc.DeepEquals, conflicts)
+
+	// Neither the branch nor master config are changed.
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsFalse)
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["http_port"], gc.Equals, int64(7777))
+}
+
+func (s *generationSuite) TestCommitConfigConflictForce(c *gc.C) {
+	s.setupTestingClock(c)
+	gen, app := s.setupConfigConflict(c)
+
+	_, err := gen.Commit(branchCommitter, true, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsTrue)
+
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["http_port"], gc.Equals, int64(8888))
+}
+
+func (s *generationSuite) TestConfigConflictsMasterUnchanged(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(newBranchName, charm.Settings{"http_port": int64(8888)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	conflicts, err := gen.ConfigConflicts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(conflicts, gc.HasLen, 0)
+}
+
+// setupConfigConflict stages a change to http_port under the branch,
+// then changes it on master.
+func (s *generationSuite) setupConfigConflict(c *gc.C) (*state.Generation, *state.Application) {
+	gen := s.setupAssignAllUnits(c)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"http_port": int64(9999)}), jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(newBranchName, charm.Settings{"http_port": int64(8888)}), jc.ErrorIsNil)
+	c.Assert(app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"http_port": int64(7777)}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	return gen, app
+}
+
 func (s *generationSuite) TestAbortSuccess(c *gc.C) {
 	s.setupTestingClock(c)
 
@@ -334,7 +586,7 @@ func (s *generationSuite) TestAbortCommittedBranch(c *gc.C) {
 	err = gen.Refresh()
 	c.Assert(err, jc.ErrorIsNil)
 
-	_, err = gen.Commit(branchCommitter)
+	_, err = gen.Commit(branchCommitter, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 	err = gen.Refresh()
 	c.Assert(err, jc.ErrorIsNil)
@@ -416,7 +668,7 @@ func (s *generationSuite) TestBranches(c *gc.C) {
 	// Commit the newly added branch. Branches call should not return it.
 	branch, err := s.Model.Branch(otherBranchName)
 	c.Assert(err, jc.ErrorIsNil)
-	_, err = branch.Commit(newBranchCreator)
+	_, err = branch.Commit(newBranchCreator, false, "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	branches, err = s.State.Branches()
@@ -63,6 +63,69 @@ type Resources interface {
 	// NewResolvePendingResourcesOps generates mongo transaction operations
 	// to set the identified resources as active.
 	NewResolvePendingResourcesOps(applicationID string, pendingIDs map[string]string) ([]txn.Op, error)
+
+	// CheckResourceConsistency returns the discrepancies between the
+	// metadata of the application's resources and their stored content.
+	CheckResourceConsistency(applicationID string) ([]ResourceDiscrepancy, error)
+
+	// CharmResourceChanges compares the application's stored resources
+	// with the resources declared by a new charm, returning a change
+	// for each stored resource that the charm drops or redefines.
+	CharmResourceChanges(applicationID string, newMeta map[string]charmresource.Meta) ([]CharmResourceChange, error)
+
+	// ExportResources returns the application's resources, along
+	// with the units using them, for migration to another controller.
+	// Pending resources are skipped.
+	ExportResources(applicationID string) ([]ExportedResource, error)
+
+	// ImportResource stores a resource migrated from another
+	// controller, along with the revision of it used by each of the
+	// input units. The reader must be nil for placeholder resources.
+	ImportResource(res resource.Resource, units map[string]resource.Resource, r io.Reader) (resource.Resource, error)
+}
+
+// ExportedResource is an application resource
+// to be migrated to another controller.
+type ExportedResource struct {
+	// Resource is the application's revision of the resource.
+	Resource resource.Resource
+
+	// Units holds the revision of the resource
+	// used by each unit, keyed by unit name.
+	Units map[string]resource.Resource
+
+	// Open returns a reader for the content of the resource,
+	// which must be closed by the caller. It is nil for
+	// placeholder resources, which have no content.
+	Open func() (io.ReadCloser, error)
+}
+
+// ResourceDiscrepancy describes a resource whose
+// metadata does not match its stored content.
+type ResourceDiscrepancy struct {
+	// Name is the name of the resource.
+	Name string
+
+	// Problem describes the inconsistency.
+	Problem string
+}
+
+// CharmResourceChange describes a stored resource which is
+// no longer consistent with the resources declared by a charm.
+type CharmResourceChange struct {
+	// Name is the name of the stored resource.
+	Name string
+
+	// Removed is true if the charm no longer declares the resource,
+	// so the stored resource should be removed.
+	Removed bool
+
+	// OldType is the type of the stored resource.
+	OldType charmresource.Type
+
+	// NewType is the type the charm now declares for the resource.
+	// It is only set if the resource has not been removed.
+	NewType charmresource.Type
 }
 
 // Resources returns the resources functionality for the current state.
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"sort"
 	"time"
 
 	charmresource "github.com/juju/charm/v9/resource"
@@ -320,6 +321,63 @@ func (p ResourcePersistence) setUnitResource(unitID string, res resource.Resourc
 	return nil
 }
 
+// ImportResource stores the info for a resource migrated from another
+// controller, along with the revision of the resource used by each of
+// the input units, keyed by unit name. All of the documents are written
+// in a single transaction, so either all or none of them are stored.
+func (p ResourcePersistence) ImportResource(res resource.Resource, storagePath string, units map[string]resource.Resource) error {
+	rpLogger.Tracef("import resource %q for %q", res.Name, res.ApplicationID)
+	if res.PendingID != "" {
+		return errors.Errorf("pending resources not allowed")
+	}
+	if err := res.Validate(); err != nil {
+		return errors.Annotate(err, "bad resource")
+	}
+	stored := storedResource{
+		Resource:    res,
+		storagePath: storagePath,
+	}
+
+	unitIDs := make([]string, 0, len(units))
+	unitStored := make(map[string]storedResource)
+	for unitID, unitRes := range units {
+		unitRes.ID = res.ID
+		unitRes.ApplicationID = res.ApplicationID
+		if err := unitRes.Validate(); err != nil {
+			return errors.Annotatef(err, "bad resource for unit %q", unitID)
+		}
+		unitIDs = append(unitIDs, unitID)
+		unitStored[unitID] = storedResource{
+			Resource:    unitRes,
+			storagePath: storagePath,
+		}
+	}
+	sort.Strings(unitIDs)
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		// The application's resource is "upserted", as a placeholder
+		// for it may have been added when the model was imported.
+		var ops []txn.Op
+		switch attempt {
+		case 0:
+			ops = newInsertResourceOps(stored)
+		case 1:
+			ops = newUpdateResourceOps(stored)
+		default:
+			return nil, errors.New("importing the resource failed")
+		}
+		for _, unitID := range unitIDs {
+			ops = append(ops, newInsertUnitResourceOps(unitID, unitStored[unitID], nil)...)
+		}
+		ops = append(ops, p.base.ApplicationExistsOps(res.ApplicationID)...)
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 func (p ResourcePersistence) getStored(res resource.Resource) (storedResource, error) {
 	doc, err := p.getOne(res.ID)
 	if errors.IsNotFound(err) {
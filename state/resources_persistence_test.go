@@ -470,6 +470,48 @@ func (s *ResourcePersistenceSuite) TestSetUnitResourceProgress(c *gc.C) {
 	}})
 }
 
+func (s *ResourcePersistenceSuite) TestImportResourceOkay(c *gc.C) {
+	applicationname := "a-application"
+	unitname := "a-application/0"
+	res, doc := newPersistenceResource(c, applicationname, "spam")
+	unitRes, unitDoc := newPersistenceUnitResource(c, applicationname, unitname, "spam")
+	p := NewResourcePersistence(s.base)
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, ignoredErr)
+
+	err := p.ImportResource(res.Resource, res.storagePath, map[string]resource.Resource{
+		unitname: unitRes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "RunTransaction")
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-application/spam",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#unit-a-application/0",
+		Assert: txn.DocMissing,
+		Insert: &unitDoc,
+	}, {
+		C:      "application",
+		Id:     "a-application",
+		Assert: txn.DocExists,
+	}})
+}
+
+func (s *ResourcePersistenceSuite) TestImportResourcePending(c *gc.C) {
+	res, _ := newPersistenceResource(c, "a-application", "spam")
+	res.PendingID = "<a pending ID>"
+	p := NewResourcePersistence(s.base)
+
+	err := p.ImportResource(res.Resource, res.storagePath, nil)
+	c.Check(err, gc.ErrorMatches, "pending resources not allowed")
+	s.stub.CheckNoCalls(c)
+}
+
 func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc.C) {
 	pendingID := "some-unique-ID-001"
 	stored, expected := newPersistenceResource(c, "a-application", "spam")
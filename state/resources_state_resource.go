@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"sort"
 	"time"
 
 	charmresource "github.com/juju/charm/v9/resource"
@@ -56,6 +57,10 @@ type resourcePersistence interface {
 	// progressfor a unit.
 	SetUnitResourceProgress(unitID string, args resource.Resource, progress int64) error
 
+	// ImportResource stores the info for a resource migrated from
+	// another controller, along with that of the units using it.
+	ImportResource(res resource.Resource, storagePath string, units map[string]resource.Resource) error
+
 	// NewResolvePendingResourceOps generates mongo transaction operations
 	// to set the identified resource as active.
 	NewResolvePendingResourceOps(resID, pendingID string) ([]txn.Op, error)
@@ -368,6 +373,101 @@ func (st resourceState) OpenResource(applicationID, name string) (resource.Resou
 	return resourceInfo, resourceReader, nil
 }
 
+// CheckResourceConsistency cross-references the metadata of each of the
+// application's resources against the content held in storage. A
+// discrepancy is returned for each resource whose content is missing,
+// or whose stored size differs from that recorded in its metadata.
+// Placeholder resources have no content and are not checked.
+func (st resourceState) CheckResourceConsistency(applicationID string) ([]ResourceDiscrepancy, error) {
+	resources, err := st.persist.ListResources(applicationID)
+	if err != nil {
+		if err := st.raw.VerifyApplication(applicationID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Trace(err)
+	}
+
+	var discrepancies []ResourceDiscrepancy
+	for _, res := range resources.Resources {
+		if res.IsPlaceholder() {
+			continue
+		}
+
+		var (
+			reader io.ReadCloser
+			size   int64
+		)
+		switch res.Type {
+		case charmresource.TypeContainerImage:
+			reader, size, err = st.dockerMetadataStorage.Get(res.ID)
+		case charmresource.TypeFile:
+			var storagePath string
+			if _, storagePath, err = st.persist.GetResource(res.ID); err != nil {
+				return nil, errors.Trace(err)
+			}
+			reader, size, err = st.storage.Get(storagePath)
+		default:
+			continue
+		}
+		if errors.IsNotFound(err) {
+			discrepancies = append(discrepancies, ResourceDiscrepancy{
+				Name:    res.Name,
+				Problem: "metadata exists but content is missing from storage",
+			})
+			continue
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving content for resource %q", res.Name)
+		}
+		_ = reader.Close()
+
+		// The size of container image resources is only recorded
+		// once they have been opened, so it is not compared here.
+		if res.Type == charmresource.TypeFile && size != res.Size {
+			discrepancies = append(discrepancies, ResourceDiscrepancy{
+				Name:    res.Name,
+				Problem: fmt.Sprintf("storage holds %d bytes but metadata records %d", size, res.Size),
+			})
+		}
+	}
+	return discrepancies, nil
+}
+
+// CharmResourceChanges compares the application's stored resources with
+// the resource metadata of a new charm. A change is returned, ordered by
+// name, for each stored resource that the charm no longer declares, and
+// for each whose declared type differs from that stored. A renamed
+// resource is reported as removed under its old name.
+func (st resourceState) CharmResourceChanges(applicationID string, newMeta map[string]charmresource.Meta) ([]CharmResourceChange, error) {
+	resources, err := st.ListResources(applicationID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var changes []CharmResourceChange
+	for _, res := range resources.Resources {
+		meta, ok := newMeta[res.Name]
+		switch {
+		case !ok:
+			changes = append(changes, CharmResourceChange{
+				Name:    res.Name,
+				Removed: true,
+				OldType: res.Type,
+			})
+		case meta.Type != res.Type:
+			changes = append(changes, CharmResourceChange{
+				Name:    res.Name,
+				OldType: res.Type,
+				NewType: meta.Type,
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
 // OpenResourceForUniter returns metadata about the resource and
 // a reader for the resource. The resource is associated with
 // the unit once the reader is completely exhausted.
@@ -405,6 +505,177 @@ func (st resourceState) OpenResourceForUniter(unit resource.Unit, name string) (
 	return resourceInfo, resourceReader, nil
 }
 
+// ExportResources returns the application's resources, along with the
+// units using them, for migration to another controller. Pending
+// resources have not been resolved for use by the application, so
+// they are skipped.
+func (st resourceState) ExportResources(applicationID string) ([]ExportedResource, error) {
+	rLogger.Tracef("export resources of %q", applicationID)
+	pending, err := st.persist.ListPendingResources(applicationID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, res := range pending {
+		rLogger.Infof("skipping pending resource %q (application %q) for migration", res.Name, applicationID)
+	}
+
+	resources, err := st.persist.ListResources(applicationID)
+	if err != nil {
+		if err := st.raw.VerifyApplication(applicationID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Trace(err)
+	}
+	var exported []ExportedResource
+	for _, res := range resources.Resources {
+		exRes := ExportedResource{Resource: res}
+		for _, unitResources := range resources.UnitResources {
+			for _, unitRes := range unitResources.Resources {
+				if unitRes.Name != res.Name {
+					continue
+				}
+				if exRes.Units == nil {
+					exRes.Units = make(map[string]resource.Resource)
+				}
+				exRes.Units[unitResources.Tag.Id()] = unitRes
+			}
+		}
+		if !res.IsPlaceholder() {
+			exRes.Open = st.contentOpener(res)
+		}
+		exported = append(exported, exRes)
+	}
+	return exported, nil
+}
+
+// contentOpener returns a function which opens the stored
+// content of the resource when it is called, so that the content
+// of many resources isn't read at the same time.
+func (st resourceState) contentOpener(res resource.Resource) func() (io.ReadCloser, error) {
+	return func() (io.ReadCloser, error) {
+		_, storagePath, err := st.persist.GetResource(res.ID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var reader io.ReadCloser
+		switch res.Type {
+		case charmresource.TypeContainerImage:
+			reader, _, err = st.dockerMetadataStorage.Get(res.ID)
+		case charmresource.TypeFile:
+			reader, _, err = st.storage.Get(storagePath)
+		default:
+			return nil, errors.New("unknown resource type")
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "opening resource %q", res.Name)
+		}
+		return reader, nil
+	}
+}
+
+// ImportResource stores a resource migrated from another controller,
+// with the revision of it used by each of the input units, keyed by
+// unit name. The metadata recorded by the source controller is kept,
+// and the content is checked against the source fingerprint. If the
+// import fails then none of the resource's records are left behind,
+// and neither is any content stored by this call.
+func (st resourceState) ImportResource(res resource.Resource, units map[string]resource.Resource, r io.Reader) (resource.Resource, error) {
+	rLogger.Tracef("import resource %q of %q", res.Name, res.ApplicationID)
+	res.ID = newResourceID(res.ApplicationID, res.Name)
+	res.PendingID = ""
+	if err := res.Validate(); err != nil {
+		return res, errors.Annotate(err, "bad resource metadata")
+	}
+	if r == nil && !res.IsPlaceholder() {
+		return res, errors.NotValidf("missing content for resource %q", res.Name)
+	}
+	if r != nil && res.IsPlaceholder() {
+		return res, errors.NotValidf("content for placeholder resource %q", res.Name)
+	}
+
+	storagePath := storagePath(res.Name, res.ApplicationID, "")
+	var stored bool
+	if r != nil {
+		// Content already at the storage path, as from an earlier
+		// attempt, is replaced but not removed if the import fails.
+		existed, err := st.contentExists(res, storagePath)
+		if err != nil {
+			return res, errors.Trace(err)
+		}
+		if err := st.importContent(res, storagePath, r); err != nil {
+			return res, errors.Trace(err)
+		}
+		stored = !existed
+	}
+	if err := st.persist.ImportResource(res, storagePath, units); err != nil {
+		if stored {
+			st.removeContent(res, storagePath)
+		}
+		return res, errors.Trace(err)
+	}
+	return res, nil
+}
+
+// contentExists returns whether there is content
+// stored for the resource at the storage path.
+func (st resourceState) contentExists(res resource.Resource, storagePath string) (bool, error) {
+	var (
+		reader io.ReadCloser
+		err    error
+	)
+	switch res.Type {
+	case charmresource.TypeFile:
+		reader, _, err = st.storage.Get(storagePath)
+	case charmresource.TypeContainerImage:
+		reader, _, err = st.dockerMetadataStorage.Get(res.ID)
+	default:
+		return false, errors.New("unknown resource type")
+	}
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	_ = reader.Close()
+	return true, nil
+}
+
+// importContent stores the content of a migrated resource.
+func (st resourceState) importContent(res resource.Resource, storagePath string, r io.Reader) error {
+	switch res.Type {
+	case charmresource.TypeFile:
+		err := st.storage.PutAndCheckHash(storagePath, r, res.Size, res.Fingerprint.String())
+		return errors.Trace(err)
+	case charmresource.TypeContainerImage:
+		respBuf := new(bytes.Buffer)
+		if _, err := respBuf.ReadFrom(r); err != nil {
+			return errors.Trace(err)
+		}
+		dockerDetails, err := resources.UnmarshalDockerResource(respBuf.Bytes())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(st.dockerMetadataStorage.Save(res.ID, dockerDetails))
+	default:
+		return errors.New("unknown resource type")
+	}
+}
+
+// removeContent removes the newly stored content
+// of a resource whose records could not be stored.
+func (st resourceState) removeContent(res resource.Resource, storagePath string) {
+	var err error
+	switch res.Type {
+	case charmresource.TypeFile:
+		err = st.storage.Remove(storagePath)
+	case charmresource.TypeContainerImage:
+		err = st.dockerMetadataStorage.Remove(res.ID)
+	}
+	if err != nil {
+		rLogger.Errorf("could not remove resource %q (application %q) from storage: %v", res.Name, res.ApplicationID, err)
+	}
+}
+
 // SetCharmStoreResources sets the "polled" resources for the
 // application to the provided values.
 func (st resourceState) SetCharmStoreResources(applicationID string, info []charmresource.Resource, lastPolled time.Time) error {
@@ -5,15 +5,19 @@ package state_test
 
 import (
 	"bytes"
+	"io/ioutil"
 	"time" // Only using time func.
 
 	charmresource "github.com/juju/charm/v9/resource"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/component/all"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testing"
 )
 
@@ -66,6 +70,191 @@ func (s *ResourcesSuite) TestFunctional(c *gc.C) {
 	// TODO(ericsnow) Add more as state.Resources grows more functionality.
 }
 
+func (s *ResourcesSuite) TestCheckResourceConsistency(c *gc.C) {
+	ch := s.ConnSuite.AddTestingCharm(c, "wordpress")
+	s.ConnSuite.AddTestingApplication(c, "a-application", ch)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, name := range []string{"spam", "eggs"} {
+		data := name + name + name
+		res := newResource(c, name, data)
+		_, err = st.SetResource("a-application", res.Username, res.Resource, bytes.NewBufferString(data))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	discrepancies, err := st.CheckResourceConsistency("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(discrepancies, gc.HasLen, 0)
+
+	// Remove the blob for one of the resources, leaving its metadata.
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	err = stor.Remove("application-a-application/resources/spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	discrepancies, err = st.CheckResourceConsistency("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(discrepancies, jc.DeepEquals, []state.ResourceDiscrepancy{{
+		Name:    "spam",
+		Problem: "metadata exists but content is missing from storage",
+	}})
+}
+
+func (s *ResourcesSuite) TestCharmResourceChanges(c *gc.C) {
+	ch := s.ConnSuite.AddTestingCharm(c, "wordpress")
+	s.ConnSuite.AddTestingApplication(c, "a-application", ch)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta := make(map[string]charmresource.Meta)
+	for _, name := range []string{"spam", "eggs"} {
+		data := name + name + name
+		res := newResource(c, name, data)
+		_, err = st.SetResource("a-application", res.Username, res.Resource, bytes.NewBufferString(data))
+		c.Assert(err, jc.ErrorIsNil)
+		meta[name] = res.Meta
+	}
+
+	changes, err := st.CharmResourceChanges("a-application", meta)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, gc.HasLen, 0)
+
+	// The new charm drops one resource and changes the type of the other.
+	eggs := meta["eggs"]
+	eggs.Type = charmresource.TypeContainerImage
+	changes, err = st.CharmResourceChanges("a-application", map[string]charmresource.Meta{
+		"eggs": eggs,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, []state.CharmResourceChange{{
+		Name:    "eggs",
+		OldType: charmresource.TypeFile,
+		NewType: charmresource.TypeContainerImage,
+	}, {
+		Name:    "spam",
+		Removed: true,
+		OldType: charmresource.TypeFile,
+	}})
+}
+
+func (s *ResourcesSuite) TestExportImportResources(c *gc.C) {
+	ch := s.ConnSuite.AddTestingCharm(c, "wordpress")
+	app := s.ConnSuite.AddTestingApplication(c, "a-application", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.SetResource("a-application", res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.SetUnitResource(unit.Name(), res.Username, res.Resource)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Pending resources are not exported.
+	eggs := newResource(c, "eggs", "eggseggseggs")
+	_, err = st.AddPendingResource("a-application", eggs.Username, eggs.Resource)
+	c.Assert(err, jc.ErrorIsNil)
+
+	exported, err := st.ExportResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exported, gc.HasLen, 1)
+	exRes := exported[0]
+	c.Check(exRes.Resource.Name, gc.Equals, "spam")
+	c.Check(exRes.Units, gc.HasLen, 1)
+	c.Assert(exRes.Open, gc.NotNil)
+	reader, err := exRes.Open()
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+
+	// Import the resource for another application.
+	s.ConnSuite.AddTestingApplication(c, "b-application", ch)
+	imported := exRes.Resource
+	imported.ApplicationID = "b-application"
+	_, err = st.ImportResource(imported, map[string]resource.Resource{
+		"b-application/0": exRes.Units[unit.Name()],
+	}, reader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The metadata recorded by the source controller is kept,
+	// and the unit records are rebuilt.
+	resources, err := st.ListResources("b-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources.Resources, gc.HasLen, 1)
+	c.Check(resources.Resources[0].Timestamp.Equal(res.Timestamp), jc.IsTrue)
+	c.Check(resources.Resources[0].Username, gc.Equals, res.Username)
+	c.Assert(resources.UnitResources, gc.HasLen, 1)
+	c.Check(resources.UnitResources[0].Tag.Id(), gc.Equals, "b-application/0")
+	c.Assert(resources.UnitResources[0].Resources, gc.HasLen, 1)
+	c.Check(resources.UnitResources[0].Resources[0].Name, gc.Equals, "spam")
+
+	_, content, err := st.OpenResource("b-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer content.Close()
+	readData, err := ioutil.ReadAll(content)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(readData), gc.Equals, data)
+}
+
+func (s *ResourcesSuite) TestImportResourceFingerprintMismatch(c *gc.C) {
+	ch := s.ConnSuite.AddTestingCharm(c, "wordpress")
+	s.ConnSuite.AddTestingApplication(c, "a-application", ch)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	res := newResource(c, "spam", "spamspamspam")
+	_, err = st.ImportResource(res, nil, bytes.NewBufferString("eggseggseggs"))
+	c.Assert(err, gc.NotNil)
+
+	resources, err := st.ListResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resources.Resources, gc.HasLen, 0)
+}
+
+func (s *ResourcesSuite) TestImportResourceFailureRemovesContent(c *gc.C) {
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The application doesn't exist, so the records can't be written.
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	_, err = st.ImportResource(res, map[string]resource.Resource{
+		"a-application/0": res,
+	}, bytes.NewBufferString(data))
+	c.Assert(err, gc.NotNil)
+
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	_, _, err = stor.Get("application-a-application/resources/spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ResourcesSuite) TestImportResourceFailureKeepsExistingContent(c *gc.C) {
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Content is already stored for the resource, as from an earlier attempt.
+	data := "spamspamspam"
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	path := "application-a-application/resources/spam"
+	err = stor.Put(path, bytes.NewBufferString(data), int64(len(data)))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The application doesn't exist, so the records can't be written.
+	res := newResource(c, "spam", data)
+	_, err = st.ImportResource(res, nil, bytes.NewBufferString(data))
+	c.Assert(err, gc.NotNil)
+
+	// Content this call didn't store is left alone.
+	reader, _, err := stor.Get(path)
+	c.Assert(err, jc.ErrorIsNil)
+	_ = reader.Close()
+}
+
 func newResource(c *gc.C, name, data string) resource.Resource {
 	opened := resourcetesting.NewResource(c, nil, name, "a-application", data)
 	res := opened.Resource
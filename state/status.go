@@ -91,6 +91,18 @@ func (m *ModelStatus) MachineModification(machineID string) (status.StatusInfo,
 	return m.getStatus(machineGlobalModificationKey(machineID), "modification")
 }
 
+// Application returns the status of the application,
+// as set by its leader. It is unset if never set.
+func (m *ModelStatus) Application(appName string) (status.StatusInfo, error) {
+	return m.getStatus(applicationGlobalKey(appName), "application")
+}
+
+// ApplicationOperator returns the status of the application's
+// operator. Only applications in CAAS models have one.
+func (m *ModelStatus) ApplicationOperator(appName string) (status.StatusInfo, error) {
+	return m.getStatus(applicationGlobalOperatorKey(appName), "operator")
+}
+
 // FullUnitWorkloadVersion returns the full status info for the workload
 // version of a unit. This is used for selecting the workload version for
 // an application.
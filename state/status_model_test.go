@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -185,6 +186,23 @@ func (s *ModelStatusSuite) TestMachineStatus(c *gc.C) {
 	c.Assert(msInstance, jc.DeepEquals, mInstance)
 }
 
+func (s *ModelStatusSuite) TestApplicationStatus(c *gc.C) {
+	app := s.factory.MakeApplication(c, nil)
+	c.Assert(app.SetStatus(status.StatusInfo{Status: status.Active}), jc.ErrorIsNil)
+
+	ms, err := s.model.LoadModelStatus()
+	c.Assert(err, jc.ErrorIsNil)
+
+	msApp, err := ms.Application(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ms.ApplicationOperator(app.Name())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	aStatus, err := app.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(msApp, jc.DeepEquals, aStatus)
+}
+
 func (s *ModelStatusSuite) TestUnitStatus(c *gc.C) {
 	unit := s.factory.MakeUnit(c, nil)
 
@@ -159,12 +159,18 @@ func (u *Unit) Application() (*Application, error) {
 // value for the associated option, and may thus be nil when no default is
 // specified.
 func (u *Unit) ConfigSettings() (charm.Settings, error) {
+	// TODO (manadart 2019-02-21) Factor the current generation into this call.
+	return u.BranchConfigSettings(model.GenerationMaster)
+}
+
+// BranchConfigSettings is the same as ConfigSettings, but with the
+// config changes made in the input branch applied to the settings.
+func (u *Unit) BranchConfigSettings(branchName string) (charm.Settings, error) {
 	if u.doc.CharmURL == nil {
 		return nil, fmt.Errorf("unit's charm URL must be set before retrieving config")
 	}
 
-	// TODO (manadart 2019-02-21) Factor the current generation into this call.
-	s, err := charmSettingsWithDefaults(u.st, u.doc.CharmURL, u.doc.Application, model.GenerationMaster)
+	s, err := charmSettingsWithDefaults(u.st, u.doc.CharmURL, u.doc.Application, branchName)
 	if err != nil {
 		return nil, errors.Annotatef(err, "charm config for unit %q", u.Name())
 	}
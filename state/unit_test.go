@@ -544,6 +544,22 @@ func (s *UnitSuite) TestConfigSettingsReflectApplication(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, charm.Settings{"blog-title": "ironic title"})
 }
 
+func (s *UnitSuite) TestBranchConfigSettings(c *gc.C) {
+	c.Assert(s.State.AddBranch("new-branch", "branch-user"), jc.ErrorIsNil)
+	err := s.application.UpdateCharmConfig("new-branch", charm.Settings{"blog-title": "branch title"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := s.unit.BranchConfigSettings("new-branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"blog-title": "branch title"})
+
+	settings, err = s.unit.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.DeepEquals, charm.Settings{"blog-title": "My Title"})
+}
+
 func (s *UnitSuite) TestConfigSettingsReflectCharm(c *gc.C) {
 	err := s.unit.SetCharmURL(s.charm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -12,15 +12,27 @@ import (
 	"github.com/juju/juju/storage/plans/local"
 )
 
-var registry = map[storage.DeviceType]common.Plan{
-	storage.DeviceTypeLocal: local.NewLocalPlan(),
-	storage.DeviceTypeISCSI: iscsi.NewiSCSIPlan(),
-}
+// Registry holds the plans used to perform the host-side steps
+// of volume attachments, keyed by the type of device attached.
+type Registry map[storage.DeviceType]common.Plan
 
-func PlanByType(name storage.DeviceType) (common.Plan, error) {
-	plan, ok := registry[name]
+// PlanByType returns the plan for the named device type.
+func (r Registry) PlanByType(name storage.DeviceType) (common.Plan, error) {
+	plan, ok := r[name]
 	if !ok {
 		return nil, errors.NotFoundf("plan type %s not found", name)
 	}
 	return plan, nil
 }
+
+// DefaultRegistry holds the plans for all of the device types known to juju.
+var DefaultRegistry = Registry{
+	storage.DeviceTypeLocal: local.NewLocalPlan(),
+	storage.DeviceTypeISCSI: iscsi.NewiSCSIPlan(),
+}
+
+// PlanByType returns the plan for the named device type
+// from the DefaultRegistry.
+func PlanByType(name storage.DeviceType) (common.Plan, error) {
+	return DefaultRegistry.PlanByType(name)
+}
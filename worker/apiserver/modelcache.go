@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/juju/juju/core/cache"
+)
+
+// modelCacheReportPath is the path, under "/introspection", at which
+// the report for the model cache is served.
+const modelCacheReportPath = "/modelcache"
+
+// NewModelCacheReportHandler returns an http.Handler that serves the
+// report of the input model cache controller, serialized as JSON.
+func NewModelCacheReportHandler(controller *cache.Controller) http.Handler {
+	return modelCacheReportHandler{controller: controller}
+}
+
+type modelCacheReportHandler struct {
+	controller *cache.Controller
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h modelCacheReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := json.Marshal(h.controller.Report())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// withModelCacheReport returns an introspection handler registration
+// function that registers the model cache report handler in addition
+// to those registered by the input function.
+func withModelCacheReport(
+	register func(func(path string, _ http.Handler)), controller *cache.Controller,
+) func(func(path string, _ http.Handler)) {
+	return func(handle func(path string, _ http.Handler)) {
+		register(handle)
+		handle(modelCacheReportPath, NewModelCacheReportHandler(controller))
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/life"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/apiserver"
+)
+
+type ModelCacheReportSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ModelCacheReportSuite{})
+
+func (s *ModelCacheReportSuite) TestReport(c *gc.C) {
+	changes := make(chan interface{})
+	processed := make(chan interface{})
+	controller, err := cache.NewController(cache.ControllerConfig{
+		Changes: changes,
+		Notify:  func(change interface{}) { processed <- change },
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, controller)
+
+	for _, change := range []interface{}{
+		cache.ModelChange{
+			ModelUUID: "model-uuid",
+			Name:      "test",
+			Owner:     "admin",
+			Life:      life.Alive,
+		},
+		cache.ApplicationChange{
+			ModelUUID: "model-uuid",
+			Name:      "redis",
+		},
+		cache.UnitChange{
+			ModelUUID:   "model-uuid",
+			Name:        "redis/0",
+			Application: "redis",
+		},
+	} {
+		select {
+		case changes <- change:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("change not read by controller")
+		}
+		select {
+		case <-processed:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("change not processed by controller")
+		}
+	}
+
+	handler := apiserver.NewModelCacheReportHandler(controller)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/introspection/modelcache", nil))
+
+	c.Assert(recorder.Code, gc.Equals, http.StatusOK)
+	c.Assert(recorder.Header().Get("Content-Type"), gc.Equals, "application/json")
+
+	var report map[string]interface{}
+	err = json.Unmarshal(recorder.Body.Bytes(), &report)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, map[string]interface{}{
+		"model-uuid": map[string]interface{}{
+			"name":              "admin/test",
+			"life":              "alive",
+			"application-count": float64(1),
+			"charm-count":       float64(0),
+			"machine-count":     float64(0),
+			"unit-count":        float64(1),
+			"relation-count":    float64(0),
+			"branch-count":      float64(0),
+		},
+	})
+}
+
+func (s *ModelCacheReportSuite) TestMethodNotAllowed(c *gc.C) {
+	controller, err := cache.NewController(cache.ControllerConfig{
+		Changes: make(chan interface{}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, controller)
+
+	handler := apiserver.NewModelCacheReportHandler(controller)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/introspection/modelcache", nil))
+	c.Assert(recorder.Code, gc.Equals, http.StatusMethodNotAllowed)
+}
@@ -137,7 +137,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		PublicDNSName:                 controllerConfig.AutocertDNSName(),
 		AllowModelAccess:              controllerConfig.AllowModelAccess(),
 		NewObserver:                   observerFactory,
-		RegisterIntrospectionHandlers: config.RegisterIntrospectionHTTPHandlers,
+		RegisterIntrospectionHandlers: withModelCacheReport(config.RegisterIntrospectionHTTPHandlers, config.Controller),
 		MetricsCollector:              config.MetricsCollector,
 		LogSinkConfig:                 &logSinkConfig,
 		GetAuditConfig:                config.GetAuditConfig,
@@ -4,6 +4,7 @@
 package auditconfigupdater
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
@@ -78,7 +79,8 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	st := statePool.SystemState()
 
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		sink := auditlog.NewSink(cfg, logDir, clock.WallClock)
+		return auditlog.NewQueuedLog(sink, auditlog.DefaultQueueSize)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -125,6 +127,9 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
+		MaxAgeDays:     cfg.AuditLogMaxAgeDays(),
+		Sink:           auditlog.SinkType(cfg.AuditLogSink()),
+		SyslogAddress:  cfg.AuditLogSyslogAddress(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
 	}
 	return result, nil
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/catacomb"
 
@@ -15,6 +16,8 @@ import (
 	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
 // ConfigSource lets us get notifications of changes to controller
 // configuration, and then get the changed config. (Primary
 // implementation is State.)
@@ -27,12 +30,19 @@ type ConfigSource interface {
 // config.
 type AuditLogFactory func(auditlog.Config) auditlog.AuditLog
 
+// droppedCounter is implemented by audit logs that drop records
+// rather than block the caller, such as auditlog.QueuedLog.
+type droppedCounter interface {
+	Dropped() uint64
+}
+
 // New returns a worker that will keep an up-to-date audit log config.
 func New(source ConfigSource, initial auditlog.Config, logFactory AuditLogFactory) (worker.Worker, error) {
 	u := &updater{
-		source:     source,
-		current:    initial,
-		logFactory: logFactory,
+		source:       source,
+		current:      initial,
+		targetConfig: initial,
+		logFactory:   logFactory,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -50,6 +60,14 @@ type updater struct {
 	source     ConfigSource
 	current    auditlog.Config
 	logFactory AuditLogFactory
+
+	// targetConfig is the config the current target was created
+	// with. It is only accessed by the loop goroutine.
+	targetConfig auditlog.Config
+
+	// dropped is the number of records dropped
+	// by targets that have since been closed.
+	dropped uint64
 }
 
 // Kill is part of the worker.Worker interface.
@@ -63,6 +81,8 @@ func (u *updater) Wait() error {
 }
 
 func (u *updater) loop() error {
+	defer u.closeTarget()
+
 	watcher := u.source.WatchControllerConfig()
 	if err := u.catacomb.Add(watcher); err != nil {
 		return errors.Trace(err)
@@ -94,10 +114,14 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		CaptureAPIArgs: cfg.AuditLogCaptureArgs(),
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
+		MaxAgeDays:     cfg.AuditLogMaxAgeDays(),
+		Sink:           auditlog.SinkType(cfg.AuditLogSink()),
+		SyslogAddress:  cfg.AuditLogSyslogAddress(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
 	}
-	if result.Enabled && u.current.Target == nil {
+	if result.Enabled && (u.current.Target == nil || u.targetConfig.SinkChanged(result)) {
 		result.Target = u.logFactory(result)
+		u.targetConfig = result
 	} else {
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
@@ -109,8 +133,54 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 
 func (u *updater) update(newConfig auditlog.Config) {
 	u.mu.Lock()
-	defer u.mu.Unlock()
+	old := u.current.Target
 	u.current = newConfig
+	u.mu.Unlock()
+
+	// Connections may still be holding the old target; closing it
+	// flushes anything already written, and anything written
+	// afterwards is discarded rather than blocking the caller.
+	if old != nil && old != newConfig.Target {
+		if err := old.Close(); err != nil {
+			logger.Warningf("closing previous audit log target: %v", err)
+		}
+		if counter, ok := old.(droppedCounter); ok {
+			u.mu.Lock()
+			u.dropped += counter.Dropped()
+			u.mu.Unlock()
+		}
+	}
+}
+
+// closeTarget closes the current target when the worker stops.
+func (u *updater) closeTarget() {
+	u.mu.Lock()
+	target := u.current.Target
+	u.mu.Unlock()
+	if target == nil {
+		return
+	}
+	if err := target.Close(); err != nil {
+		logger.Warningf("closing audit log target: %v", err)
+	}
+}
+
+// Report provides information for the engine report.
+func (u *updater) Report() map[string]interface{} {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	dropped := u.dropped
+	if counter, ok := u.current.Target.(droppedCounter); ok {
+		dropped += counter.Dropped()
+	}
+	result := map[string]interface{}{
+		"enabled":         u.current.Enabled,
+		"dropped-records": dropped,
+	}
+	if u.current.Sink != "" {
+		result["sink"] = string(u.current.Sink)
+	}
+	return result
 }
 
 // CurrentConfig returns the updater's up-to-date audit config.
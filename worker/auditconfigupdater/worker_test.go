@@ -79,10 +79,10 @@ func waitForConfig(c *gc.C, w worker.Worker, predicate func(auditlog.Config) boo
 
 func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withDefaultRotation(auditlog.Config{
 		Enabled: true,
 		Target:  &apitesting.FakeAuditLog{},
-	}
+	})
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false),
@@ -107,10 +107,10 @@ func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 
 func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withDefaultRotation(auditlog.Config{
 		Enabled: false,
 		Target:  &apitesting.FakeAuditLog{},
-	}
+	})
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(false, false),
@@ -135,11 +135,11 @@ func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 
 func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withDefaultRotation(auditlog.Config{
 		Enabled:        true,
 		ExcludeMethods: set.NewStrings("Pink.Floyd"),
 		Target:         &apitesting.FakeAuditLog{},
-	}
+	})
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false, "Pink.Floyd"),
@@ -166,11 +166,11 @@ func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
 
 func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	configChanged := make(chan struct{}, 1)
-	initial := auditlog.Config{
+	initial := withDefaultRotation(auditlog.Config{
 		Enabled:        true,
 		CaptureAPIArgs: false,
 		Target:         &apitesting.FakeAuditLog{},
-	}
+	})
 	source := configSource{
 		watcher: watchertest.NewNotifyWatcher(configChanged),
 		cfg:     makeControllerConfig(true, false, "Pink.Floyd"),
@@ -188,6 +188,165 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingSinkClosesOldTarget(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-sink"] = "syslog"
+	cfg["audit-log-syslog-address"] = "10.0.0.1:514"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Sink == auditlog.SyslogSink
+	})
+	c.Assert(newConfig.SyslogAddress, gc.Equals, "10.0.0.1:514")
+	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(newTarget))
+	c.Assert(calls, gc.HasLen, 1)
+	waitForClose(c, oldTarget)
+
+	// The new target is closed when the worker stops.
+	workertest.CleanKill(c, w)
+	newTarget.CheckCallNames(c, "Close")
+}
+
+func (s *updaterSuite) TestChangingRotationCreatesNewTarget(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := withDefaultRotation(auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	})
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-max-backups"] = 3
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.MaxBackups == 3
+	})
+	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(newTarget))
+	c.Assert(calls, gc.HasLen, 1)
+	waitForClose(c, oldTarget)
+}
+
+func (s *updaterSuite) TestReportDroppedRecords(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := &droppingAuditLog{dropped: 3}
+	initial := withDefaultRotation(auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	})
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &droppingAuditLog{dropped: 2}
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	reporter, ok := w.(worker.Reporter)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(reporter.Report(), jc.DeepEquals, map[string]interface{}{
+		"enabled":         true,
+		"dropped-records": uint64(3),
+	})
+
+	// Records dropped by a replaced target are still counted.
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-sink"] = "syslog"
+	cfg["audit-log-syslog-address"] = "10.0.0.1:514"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Sink == auditlog.SyslogSink
+	})
+	waitForClose(c, &oldTarget.FakeAuditLog)
+	for a := jujutesting.LongAttempt.Start(); a.Next(); {
+		if reporter.Report()["dropped-records"] == uint64(5) {
+			break
+		}
+	}
+	c.Check(reporter.Report(), jc.DeepEquals, map[string]interface{}{
+		"enabled":         true,
+		"sink":            "syslog",
+		"dropped-records": uint64(5),
+	})
+}
+
+// droppingAuditLog is an audit log that reports dropping records.
+type droppingAuditLog struct {
+	apitesting.FakeAuditLog
+	dropped uint64
+}
+
+func (l *droppingAuditLog) Dropped() uint64 {
+	return l.dropped
+}
+
+// withDefaultRotation returns the input config with the log file
+// rotation settings that makeControllerConfig leaves as the defaults,
+// so that the worker does not need to create a new target.
+func withDefaultRotation(cfg auditlog.Config) auditlog.Config {
+	cfg.MaxSizeMB = controller.DefaultAuditLogMaxSizeMB
+	cfg.MaxBackups = controller.DefaultAuditLogMaxBackups
+	cfg.MaxAgeDays = controller.DefaultAuditLogMaxAge
+	return cfg
+}
+
+func waitForClose(c *gc.C, target *apitesting.FakeAuditLog) {
+	for a := jujutesting.LongAttempt.Start(); a.Next(); {
+		if len(target.Calls()) > 0 {
+			target.CheckCallNames(c, "Close")
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for target to be closed")
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
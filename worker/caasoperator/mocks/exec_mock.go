@@ -62,6 +62,21 @@ func (mr *MockExecutorMockRecorder) Exec(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockExecutor)(nil).Exec), arg0, arg1)
 }
 
+// ExecAcrossApplication mocks base method
+func (m *MockExecutor) ExecAcrossApplication(arg0 exec.ApplicationExecParams, arg1 <-chan struct{}) (map[string]exec.PodExecResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecAcrossApplication", arg0, arg1)
+	ret0, _ := ret[0].(map[string]exec.PodExecResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecAcrossApplication indicates an expected call of ExecAcrossApplication
+func (mr *MockExecutorMockRecorder) ExecAcrossApplication(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecAcrossApplication", reflect.TypeOf((*MockExecutor)(nil).ExecAcrossApplication), arg0, arg1)
+}
+
 // NameSpace mocks base method
 func (m *MockExecutor) NameSpace() string {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -29,8 +30,10 @@ type applicationWorker struct {
 	provisioningInfoGetter   ProvisioningInfoGetter
 	applicationGetter        ApplicationGetter
 	applicationUpdater       ApplicationUpdater
+	lifeGetter               LifeGetter
 	unitUpdater              UnitUpdater
 
+	clock  clock.Clock
 	logger Logger
 }
 
@@ -43,7 +46,9 @@ func newApplicationWorker(
 	provisioningInfoGetter ProvisioningInfoGetter,
 	applicationGetter ApplicationGetter,
 	applicationUpdater ApplicationUpdater,
+	lifeGetter LifeGetter,
 	unitUpdater UnitUpdater,
+	clock clock.Clock,
 	logger Logger,
 ) (*applicationWorker, error) {
 	w := &applicationWorker{
@@ -55,7 +60,9 @@ func newApplicationWorker(
 		provisioningInfoGetter:   provisioningInfoGetter,
 		applicationGetter:        applicationGetter,
 		applicationUpdater:       applicationUpdater,
+		lifeGetter:               lifeGetter,
 		unitUpdater:              unitUpdater,
+		clock:                    clock,
 		logger:                   logger,
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
@@ -86,6 +93,9 @@ func (aw *applicationWorker) loop() error {
 			aw.provisioningInfoGetter,
 			aw.applicationGetter,
 			aw.applicationUpdater,
+			aw.lifeGetter,
+			aw.unitUpdater,
+			aw.clock,
 			aw.logger,
 		)
 		if err != nil {
@@ -124,6 +134,10 @@ func (aw *applicationWorker) loop() error {
 	lastReportedStatus := make(map[string]status.StatusInfo)
 	lastReportedScale := -1
 	initialOperatorEvent := true
+	// Addresses may have been recorded for the service
+	// before this worker started, so the first event
+	// without any clears them.
+	addressesRecorded := true
 	logger := aw.logger
 	for {
 		var err error
@@ -208,6 +222,9 @@ func (aw *applicationWorker) loop() error {
 				} else if err != nil {
 					return errors.Trace(err)
 				}
+				if err := aw.clearRemovedAddresses(service, &addressesRecorded); err != nil {
+					return errors.Trace(err)
+				}
 				lastStatus, ok := lastReportedStatus[service.Id]
 				lastReportedStatus[service.Id] = service.Status
 				if ok {
@@ -259,6 +276,29 @@ func (aw *applicationWorker) loop() error {
 	}
 }
 
+// clearRemovedAddresses clears the recorded addresses of the application's
+// service once the cloud no longer reports any, as when a load balancer
+// is deleted. Addresses reported by the cloud are recorded along with the
+// rest of the service details, so recorded is set when there are any.
+func (aw *applicationWorker) clearRemovedAddresses(service *caas.Service, recorded *bool) error {
+	if len(service.Addresses) > 0 {
+		*recorded = true
+		return nil
+	}
+	if !*recorded {
+		return nil
+	}
+	aw.logger.Debugf("clearing service addresses for %q", aw.application)
+	err := aw.applicationUpdater.SetApplicationServiceAddresses(aw.application, nil)
+	if errors.IsNotSupported(err) {
+		aw.logger.Debugf("controller cannot clear service addresses for %q", aw.application)
+	} else if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "clearing service addresses for %q", aw.application)
+	}
+	*recorded = false
+	return nil
+}
+
 func (aw *applicationWorker) clusterChanged(
 	service *caas.Service,
 	lastReportedStatus map[string]status.StatusInfo,
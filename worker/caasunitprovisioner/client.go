@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -35,6 +36,7 @@ type ApplicationGetter interface {
 	DeploymentMode(string) (caas.DeploymentMode, error)
 	WatchApplicationScale(string) (watcher.NotifyWatcher, error)
 	ApplicationScale(string) (int, error)
+	ApplicationServiceHash(string) (string, error)
 }
 
 // ApplicationUpdater provides an interface for updating
@@ -42,6 +44,8 @@ type ApplicationGetter interface {
 type ApplicationUpdater interface {
 	UpdateApplicationService(arg params.UpdateApplicationServiceArg) error
 	ClearApplicationResources(appName string) error
+	SetApplicationServiceHash(appName, hash string) error
+	SetApplicationServiceAddresses(appName string, addresses network.ProviderAddresses) error
 }
 
 // ProvisioningInfoGetter provides an interface for
@@ -62,6 +66,7 @@ type LifeGetter interface {
 // Juju units from changes in the cloud.
 type UnitUpdater interface {
 	UpdateUnits(arg params.UpdateApplicationUnits) (*params.UpdateApplicationUnitsInfo, error)
+	StopUnits(appName string, scale int) ([]string, error)
 }
 
 // ProvisioningStatusSetter provides an interface for
@@ -4,8 +4,13 @@
 package caasunitprovisioner
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"reflect"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -16,10 +21,23 @@ import (
 	"github.com/juju/juju/caas"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
 
+const (
+	// defaultStopTimeout is how long the worker waits for the agents of
+	// surplus units to stop before scaling the application down, when
+	// the application has no termination grace period configured.
+	defaultStopTimeout = 30 * time.Second
+
+	// stopPollInterval is how often the worker checks whether the
+	// stopping units have acknowledged the stop.
+	stopPollInterval = time.Second
+)
+
 // deploymentWorker informs the CAAS broker of how many pods to run and their spec, and
 // lets the broker figure out how to make that all happen.
 type deploymentWorker struct {
@@ -30,7 +48,19 @@ type deploymentWorker struct {
 	applicationGetter        ApplicationGetter
 	applicationUpdater       ApplicationUpdater
 	provisioningInfoGetter   ProvisioningInfoGetter
+	lifeGetter               LifeGetter
+	unitUpdater              UnitUpdater
+	clock                    clock.Clock
 	logger                   Logger
+
+	// appliedHash is the hash of the service definition last
+	// successfully applied to the cloud, as recorded against
+	// the application so that it survives worker restarts.
+	// Controllers that cannot record the hash are not asked to,
+	// and the service is ensured on every change.
+	appliedHash          string
+	haveAppliedHash      bool
+	serviceHashSupported bool
 }
 
 func newDeploymentWorker(
@@ -40,6 +70,9 @@ func newDeploymentWorker(
 	provisioningInfoGetter ProvisioningInfoGetter,
 	applicationGetter ApplicationGetter,
 	applicationUpdater ApplicationUpdater,
+	lifeGetter LifeGetter,
+	unitUpdater UnitUpdater,
+	clock clock.Clock,
 	logger Logger,
 ) (worker.Worker, error) {
 	w := &deploymentWorker{
@@ -49,6 +82,9 @@ func newDeploymentWorker(
 		provisioningInfoGetter:   provisioningInfoGetter,
 		applicationGetter:        applicationGetter,
 		applicationUpdater:       applicationUpdater,
+		lifeGetter:               lifeGetter,
+		unitUpdater:              unitUpdater,
+		clock:                    clock,
 		logger:                   logger,
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
@@ -136,7 +172,10 @@ func (w *deploymentWorker) loop() error {
 				provisionChan = nil
 			}
 			logger.Debugf("no units for %v", w.application)
-			err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, &caas.ServiceParams{}, 0, nil)
+			if err := w.stopUnits(0); err != nil {
+				return errors.Trace(err)
+			}
+			err = w.ensureService(&caas.ServiceParams{}, 0, nil)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -174,6 +213,11 @@ func (w *deploymentWorker) loop() error {
 			}
 		}
 
+		if desiredScale < currentScale {
+			if err := w.stopUnits(desiredScale); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		currentScale = desiredScale
 		currentInfo = info
 
@@ -186,7 +230,7 @@ func (w *deploymentWorker) loop() error {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, serviceParams, desiredScale, appConfig)
+		err = w.ensureService(serviceParams, desiredScale, appConfig)
 		if err != nil {
 			// Some errors we don't want to exit the worker.
 			if k8sprovider.MaskError(err) {
@@ -214,6 +258,117 @@ func (w *deploymentWorker) loop() error {
 	}
 }
 
+// stopUnits tells the agents of the units surplus to the given scale
+// to stop, and waits for them to do so before the cloud is asked to
+// remove their pods. The wait is limited to the application's
+// termination grace period, after which the pods are removed anyway.
+func (w *deploymentWorker) stopUnits(scale int) error {
+	units, err := w.unitUpdater.StopUnits(w.application, scale)
+	if errors.IsNotSupported(err) {
+		w.logger.Debugf("controller cannot stop units, not waiting for the units of %v to stop", w.application)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return nil
+	}
+
+	appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	timeout := defaultStopTimeout
+	if gracePeriod := appConfig.GetInt(k8sprovider.TerminationGracePeriodKey, 0); gracePeriod > 0 {
+		timeout = time.Duration(gracePeriod) * time.Second
+	}
+	w.logger.Debugf("waiting up to %v for units %v to stop", timeout, units)
+
+	deadline := w.clock.After(timeout)
+	for {
+		var stopping []string
+		for _, unit := range units {
+			unitLife, err := w.lifeGetter.Life(unit)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if unitLife != life.Dead {
+				stopping = append(stopping, unit)
+			}
+		}
+		if len(stopping) == 0 {
+			return nil
+		}
+		units = stopping
+
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-deadline:
+			w.logger.Warningf("units %v did not stop within %v, removing their pods", units, timeout)
+			return nil
+		case <-w.clock.After(stopPollInterval):
+		}
+	}
+}
+
+// ensureService calls EnsureService on the broker, unless the desired
+// service definition is identical to the one last successfully applied.
+// Errors from the broker are returned unchanged so they can be masked.
+func (w *deploymentWorker) ensureService(
+	serviceParams *caas.ServiceParams, numUnits int, config application.ConfigAttributes,
+) error {
+	hash, err := serviceHash(serviceParams, numUnits, config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !w.haveAppliedHash {
+		w.appliedHash, err = w.applicationGetter.ApplicationServiceHash(w.application)
+		switch {
+		case err == nil:
+			w.serviceHashSupported = true
+		case errors.IsNotSupported(err):
+			w.logger.Debugf("controller cannot record service hashes, always ensuring service for %v", w.application)
+		default:
+			return errors.Trace(err)
+		}
+		w.haveAppliedHash = true
+	}
+	if w.serviceHashSupported && hash == w.appliedHash {
+		w.logger.Debugf("service definition for %v unchanged, not ensuring service", w.application)
+		return nil
+	}
+
+	err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, serviceParams, numUnits, config)
+	if err != nil {
+		return err
+	}
+	if !w.serviceHashSupported {
+		return nil
+	}
+	if err := w.applicationUpdater.SetApplicationServiceHash(w.application, hash); err != nil {
+		return errors.Trace(err)
+	}
+	w.appliedHash = hash
+	return nil
+}
+
+// serviceHash returns a hash of the full desired service definition.
+func serviceHash(serviceParams *caas.ServiceParams, numUnits int, config application.ConfigAttributes) (string, error) {
+	data, err := json.Marshal(struct {
+		Params   *caas.ServiceParams
+		NumUnits int
+		Config   application.ConfigAttributes
+	}{serviceParams, numUnits, config})
+	if err != nil {
+		return "", errors.Annotate(err, "serialising service definition")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 func provisionInfoToServiceParams(info *apicaasunitprovisioner.ProvisioningInfo) (serviceParams *caas.ServiceParams, err error) {
 	if len(info.PodSpec) > 0 && len(info.RawK8sSpec) > 0 {
 		// This should never happen.
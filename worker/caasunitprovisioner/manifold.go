@@ -4,6 +4,7 @@
 package caasunitprovisioner
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
@@ -26,6 +27,7 @@ type ManifoldConfig struct {
 
 	NewClient func(base.APICaller) Client
 	NewWorker func(Config) (worker.Worker, error)
+	Clock     clock.Clock
 	Logger    Logger
 }
 
@@ -43,6 +45,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	if config.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
@@ -77,6 +82,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		LifeGetter:               client,
 		UnitUpdater:              client,
 
+		Clock:  config.Clock,
 		Logger: config.Logger,
 	})
 	if err != nil {
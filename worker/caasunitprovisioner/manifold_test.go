@@ -4,6 +4,7 @@
 package caasunitprovisioner_test
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
@@ -45,6 +46,7 @@ func (s *ManifoldSuite) validConfig() caasunitprovisioner.ManifoldConfig {
 		BrokerName:    "broker",
 		NewClient:     s.newClient,
 		NewWorker:     s.newWorker,
+		Clock:         clock.WallClock,
 		Logger:        loggo.GetLogger("test"),
 	}
 }
@@ -93,6 +95,12 @@ func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
 	s.checkConfigInvalid(c, config, "nil NewWorker not valid")
 }
 
+func (s *ManifoldSuite) TestMissingClock(c *gc.C) {
+	config := s.validConfig()
+	config.Clock = nil
+	s.checkConfigInvalid(c, config, "nil Clock not valid")
+}
+
 func (s *ManifoldSuite) TestMissingLogger(c *gc.C) {
 	config := s.validConfig()
 	config.Logger = nil
@@ -143,6 +151,7 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		ProvisioningStatusSetter: &s.client,
 		LifeGetter:               &s.client,
 		UnitUpdater:              &s.client,
+		Clock:                    clock.WallClock,
 		Logger:                   loggo.GetLogger("test"),
 	})
 }
@@ -49,6 +49,7 @@ type mockServiceBroker struct {
 	deleted        chan<- struct{}
 	serviceStatus  status.StatusInfo
 	serviceWatcher *watchertest.MockNotifyWatcher
+	noAddresses    bool
 }
 
 func (m *mockServiceBroker) Provider() caas.ContainerEnvironProvider {
@@ -65,8 +66,12 @@ func (m *mockServiceBroker) EnsureService(appName string, statusCallback caas.St
 func (m *mockServiceBroker) GetService(appName string, mode caas.DeploymentMode, includeClusterIP bool) (*caas.Service, error) {
 	m.MethodCall(m, "GetService", appName, mode)
 	scale := 4
+	addresses := network.NewProviderAddresses("10.0.0.1")
+	if m.noAddresses {
+		addresses = nil
+	}
 	return &caas.Service{
-		Id: "id", Scale: &scale, Addresses: network.NewProviderAddresses("10.0.0.1"), Status: m.serviceStatus,
+		Id: "id", Scale: &scale, Addresses: addresses, Status: m.serviceStatus,
 	}, m.NextErr()
 }
 
@@ -151,6 +156,8 @@ type mockApplicationGetter struct {
 	scaleWatcher   *watchertest.MockNotifyWatcher
 	deploymentMode caas.DeploymentMode
 	scale          int
+	serviceHash    string
+	serviceHashErr error
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -189,6 +196,11 @@ func (a *mockApplicationGetter) ApplicationScale(application string) (int, error
 	return a.scale, nil
 }
 
+func (a *mockApplicationGetter) ApplicationServiceHash(application string) (string, error) {
+	a.MethodCall(a, "ApplicationServiceHash", application)
+	return a.serviceHash, a.serviceHashErr
+}
+
 type mockApplicationUpdater struct {
 	testing.Stub
 	updated chan<- struct{}
@@ -201,6 +213,16 @@ func (m *mockApplicationUpdater) UpdateApplicationService(arg params.UpdateAppli
 	return m.NextErr()
 }
 
+func (m *mockApplicationUpdater) SetApplicationServiceHash(appName, hash string) error {
+	m.MethodCall(m, "SetApplicationServiceHash", appName, hash)
+	return nil
+}
+
+func (m *mockApplicationUpdater) SetApplicationServiceAddresses(appName string, addresses network.ProviderAddresses) error {
+	m.MethodCall(m, "SetApplicationServiceAddresses", appName, addresses)
+	return m.NextErr()
+}
+
 func (m *mockApplicationUpdater) ClearApplicationResources(appName string) error {
 	m.MethodCall(m, "ClearApplicationResources", appName)
 	m.cleared <- struct{}{}
@@ -288,6 +310,7 @@ func (m *mockLifeGetter) Life(entityName string) (life.Value, error) {
 type mockUnitUpdater struct {
 	testing.Stub
 	unitsInfo *params.UpdateApplicationUnitsInfo
+	stopping  []string
 }
 
 func (m *mockUnitUpdater) UpdateUnits(arg params.UpdateApplicationUnits) (*params.UpdateApplicationUnitsInfo, error) {
@@ -297,3 +320,11 @@ func (m *mockUnitUpdater) UpdateUnits(arg params.UpdateApplicationUnits) (*param
 	}
 	return m.unitsInfo, nil
 }
+
+func (m *mockUnitUpdater) StopUnits(appName string, scale int) ([]string, error) {
+	m.MethodCall(m, "StopUnits", appName, scale)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.stopping, nil
+}
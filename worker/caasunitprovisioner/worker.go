@@ -6,6 +6,7 @@ package caasunitprovisioner
 import (
 	"sync"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/catacomb"
@@ -29,6 +30,7 @@ type Config struct {
 	LifeGetter               LifeGetter
 	UnitUpdater              UnitUpdater
 
+	Clock  clock.Clock
 	Logger Logger
 }
 
@@ -58,6 +60,9 @@ func (config Config) Validate() error {
 	if config.ProvisioningStatusSetter == nil {
 		return errors.NotValidf("missing ProvisioningStatusSetter")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("missing Clock")
+	}
 	if config.Logger == nil {
 		return errors.NotValidf("missing Logger")
 	}
@@ -207,7 +212,9 @@ func (p *provisioner) loop() error {
 					p.config.ProvisioningInfoGetter,
 					p.config.ApplicationGetter,
 					p.config.ApplicationUpdater,
+					p.config.LifeGetter,
 					p.config.UnitUpdater,
+					p.config.Clock,
 					logger,
 				)
 				if err != nil {
@@ -223,6 +223,10 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 		config.ProvisioningStatusSetter = nil
 	}, `missing ProvisioningStatusSetter not valid`)
 
+	s.testValidateConfig(c, func(config *caasunitprovisioner.Config) {
+		config.Clock = nil
+	}, `missing Clock not valid`)
+
 	s.testValidateConfig(c, func(config *caasunitprovisioner.Config) {
 		config.Logger = nil
 	}, `missing Logger not valid`)
@@ -301,7 +305,7 @@ func (s *WorkerSuite) TestScaleChangedInJuju(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
 
-	s.applicationGetter.CheckCallNames(c, "WatchApplications", "DeploymentMode", "WatchApplicationScale", "ApplicationScale", "ApplicationConfig")
+	s.applicationGetter.CheckCallNames(c, "WatchApplications", "DeploymentMode", "WatchApplicationScale", "ApplicationScale", "ApplicationConfig", "ApplicationServiceHash")
 	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "ProvisioningInfo", "ProvisioningInfo")
 	s.podSpecGetter.CheckCall(c, 0, "WatchPodSpec", "gitlab")
 	s.podSpecGetter.CheckCall(c, 1, "ProvisioningInfo", "gitlab") // not found
@@ -353,6 +357,186 @@ func (s *WorkerSuite) TestScaleChangedInJuju(c *gc.C) {
 		"gitlab", newExpectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestEnsureServiceSkippedAfterRestart(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	s.serviceBroker.CheckCallNames(c, "WatchService", "EnsureService", "GetService")
+	s.applicationUpdater.CheckCallNames(c, "SetApplicationServiceHash", "UpdateApplicationService")
+	appliedHash := s.applicationUpdater.Calls()[0].Args[1].(string)
+	c.Assert(appliedHash, gc.Not(gc.Equals), "")
+	workertest.CleanKill(c, w)
+
+	// Restart the worker with the applied hash recorded against the
+	// application; the unchanged service definition is not re-applied.
+	s.serviceBroker.ResetCalls()
+	s.applicationUpdater.ResetCalls()
+	s.applicationGetter.serviceHash = appliedHash
+
+	// The old worker stopped the watchers, so start afresh.
+	s.applicationGetter.watcher = watchertest.NewMockStringsWatcher(s.applicationChanges)
+	s.applicationGetter.scaleWatcher = watchertest.NewMockNotifyWatcher(s.applicationScaleChanges)
+	s.podSpecGetter.watcher = watchertest.NewMockNotifyWatcher(s.containerSpecChanges)
+	s.serviceBroker.serviceWatcher = watchertest.NewMockNotifyWatcher(s.caasServiceChanges)
+	s.containerBroker.unitsWatcher = watchertest.NewMockNotifyWatcher(s.caasUnitsChanges)
+	s.containerBroker.operatorWatcher = watchertest.NewMockNotifyWatcher(s.caasOperatorChanges)
+
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	select {
+	case <-s.serviceUpdated:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be updated")
+	}
+
+	// Further no-op events do not ensure the service either.
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+	select {
+	case <-s.serviceEnsured:
+		c.Fatal("service ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	s.serviceBroker.CheckCallNames(c, "WatchService", "GetService")
+	s.applicationUpdater.CheckCallNames(c, "UpdateApplicationService")
+}
+
+func (s *WorkerSuite) TestEnsureServiceRecordsHash(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.applicationUpdater.CheckCallNames(c, "SetApplicationServiceHash", "UpdateApplicationService")
+	firstHash := s.applicationUpdater.Calls()[0].Args[1].(string)
+	s.applicationUpdater.ResetCalls()
+
+	// Scaling changes the service definition, so a new hash is recorded.
+	s.applicationGetter.scale = 2
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.applicationUpdater.Calls()) > 0 {
+			break
+		}
+	}
+	s.applicationUpdater.CheckCallNames(c, "SetApplicationServiceHash")
+	secondHash := s.applicationUpdater.Calls()[0].Args[1].(string)
+	c.Assert(secondHash, gc.Not(gc.Equals), firstHash)
+}
+
+func (s *WorkerSuite) TestEnsureServiceWithoutServiceHashSupport(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.applicationGetter.serviceHashErr = errors.NotSupportedf("service hashes")
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	// No hash is recorded against the application.
+	s.applicationUpdater.CheckCallNames(c, "UpdateApplicationService")
+	s.serviceBroker.ResetCalls()
+
+	// Without a recorded hash, the service is ensured on every change.
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.applicationUpdater.CheckCallNames(c, "UpdateApplicationService")
+}
+
+func (s *WorkerSuite) TestServiceAddressesRemoved(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+	s.applicationUpdater.ResetCalls()
+
+	// The load balancer is deleted, so the service has no addresses.
+	s.serviceBroker.noAddresses = true
+	s.sendServiceChange(c)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.applicationUpdater.Calls()) > 1 {
+			break
+		}
+	}
+	s.applicationUpdater.CheckCallNames(c, "UpdateApplicationService", "SetApplicationServiceAddresses")
+	s.applicationUpdater.CheckCall(c, 1, "SetApplicationServiceAddresses", "gitlab", network.ProviderAddresses(nil))
+
+	// The addresses are only cleared once.
+	s.applicationUpdater.ResetCalls()
+	s.sendServiceChange(c)
+	time.Sleep(coretesting.ShortWait)
+	s.applicationUpdater.CheckCallNames(c, "UpdateApplicationService")
+}
+
+func (s *WorkerSuite) TestServiceAddressesRemovedNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+	s.applicationUpdater.ResetCalls()
+
+	// An older controller cannot clear the addresses; the worker carries on.
+	s.applicationUpdater.SetErrors(nil, errors.NotSupportedf("setting service addresses"))
+	s.serviceBroker.noAddresses = true
+	s.sendServiceChange(c)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.applicationUpdater.Calls()) > 1 {
+			break
+		}
+	}
+	s.applicationUpdater.CheckCallNames(c, "UpdateApplicationService", "SetApplicationServiceAddresses")
+
+	// Clearing is not retried on later events.
+	s.applicationUpdater.ResetCalls()
+	s.sendServiceChange(c)
+	time.Sleep(coretesting.ShortWait)
+	s.applicationUpdater.CheckCallNames(c, "UpdateApplicationService")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) sendServiceChange(c *gc.C) {
+	select {
+	case s.caasServiceChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending service change")
+	}
+	select {
+	case <-s.serviceUpdated:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be updated")
+	}
+}
+
 func intPtr(i int) *int {
 	return &i
 }
@@ -615,6 +799,90 @@ func (s *WorkerSuite) TestScaleZero(c *gc.C) {
 		"gitlab", &caas.ServiceParams{}, 0, application.ConfigAttributes(nil))
 }
 
+func (s *WorkerSuite) scaleUpToTwo(c *gc.C) {
+	s.applicationGetter.scale = 2
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.serviceBroker.ResetCalls()
+	s.unitUpdater.ResetCalls()
+	s.lifeGetter.ResetCalls()
+}
+
+func (s *WorkerSuite) TestScaleDownWaitsForUnitsToStop(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+	s.scaleUpToTwo(c)
+
+	// The surplus unit's agent has already run its stop hook.
+	s.unitUpdater.stopping = []string{"gitlab/1"}
+	s.lifeGetter.setLife(life.Dead)
+	s.applicationGetter.scale = 1
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.unitUpdater.CheckCallNames(c, "StopUnits")
+	s.unitUpdater.CheckCall(c, 0, "StopUnits", "gitlab", 1)
+	s.lifeGetter.CheckCallNames(c, "Life")
+	s.lifeGetter.CheckCall(c, 0, "Life", "gitlab/1")
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", getExpectedServiceParams(), 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
+func (s *WorkerSuite) TestScaleDownStopTimesOut(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+	s.scaleUpToTwo(c)
+
+	// The surplus unit's agent never acknowledges the stop.
+	s.unitUpdater.stopping = []string{"gitlab/1"}
+	s.applicationGetter.scale = 1
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+
+	select {
+	case <-s.serviceEnsured:
+		c.Fatal("service ensured before the unit stopped")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	// Wait for the deadline and the first poll.
+	err := s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.unitUpdater.CheckCallNames(c, "StopUnits")
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", getExpectedServiceParams(), 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
 func (s *WorkerSuite) TestApplicationDeadRemovesService(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -1034,6 +1302,7 @@ func (s *WorkerSuite) setupMocks(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
 	s.statusSetter = caasunitprovisioner.NewMockProvisioningStatusSetter(ctrl)
+	s.clock = testclock.NewClock(time.Time{})
 
 	s.config = caasunitprovisioner.Config{
 		ApplicationGetter:        &s.applicationGetter,
@@ -1044,6 +1313,7 @@ func (s *WorkerSuite) setupMocks(c *gc.C) *gomock.Controller {
 		LifeGetter:               &s.lifeGetter,
 		UnitUpdater:              &s.unitUpdater,
 		ProvisioningStatusSetter: s.statusSetter,
+		Clock:                    s.clock,
 		Logger:                   loggo.GetLogger("test"),
 	}
 
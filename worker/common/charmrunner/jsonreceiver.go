@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrunner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+)
+
+// JSONRecord is the structured form of a single line
+// of hook output written by a JSONReceiver.
+type JSONRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	Stream    string    `json:"stream"`
+	Hook      string    `json:"hook"`
+	Line      string    `json:"line"`
+
+	// Partial is true if the line was too long to be read in one go,
+	// and the remainder follows in subsequent records.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// JSONReceiver implements MessageReceiver by writing
+// each message to a writer as a line of JSON.
+type JSONReceiver struct {
+	clock  clock.Clock
+	hook   string
+	stream string
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONReceiver returns a JSONReceiver writing records to the input
+// writer, for output from the input stream (such as "stdout") of the
+// named hook.
+func NewJSONReceiver(w io.Writer, clock clock.Clock, hookName, stream string) *JSONReceiver {
+	return &JSONReceiver{
+		clock:  clock,
+		hook:   hookName,
+		stream: stream,
+		enc:    json.NewEncoder(w),
+	}
+}
+
+// Messagef implements MessageReceiver.
+func (r *JSONReceiver) Messagef(isPrefix bool, message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	record := JSONRecord{
+		Timestamp: r.clock.Now().UTC(),
+		Stream:    r.stream,
+		Hook:      r.hook,
+		Line:      message,
+		Partial:   isPrefix,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(record); err != nil {
+		logger.Errorf("cannot write %s output for hook %q: %v", r.stream, r.hook, err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrunner_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/common/charmrunner"
+)
+
+type JSONReceiverSuite struct{}
+
+var _ = gc.Suite(&JSONReceiverSuite{})
+
+func (s *JSONReceiverSuite) TestHookOutputRecords(c *gc.C) {
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	var buf bytes.Buffer
+	receiver := charmrunner.NewJSONReceiver(&buf, testclock.NewClock(now), "config-changed", "stdout")
+
+	out := ioutil.NopCloser(strings.NewReader("first line\nsecond \"quoted\" line\n"))
+	hookLogger := charmrunner.NewHookLogger(out, receiver)
+	hookLogger.Run()
+
+	var records []charmrunner.JSONRecord
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record charmrunner.JSONRecord
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		c.Assert(err, jc.ErrorIsNil, gc.Commentf("line %q", scanner.Text()))
+		records = append(records, record)
+	}
+	c.Assert(scanner.Err(), jc.ErrorIsNil)
+
+	c.Check(records, jc.DeepEquals, []charmrunner.JSONRecord{{
+		Timestamp: now,
+		Stream:    "stdout",
+		Hook:      "config-changed",
+		Line:      "first line",
+	}, {
+		Timestamp: now,
+		Stream:    "stdout",
+		Hook:      "config-changed",
+		Line:      `second "quoted" line`,
+	}})
+}
+
+func (s *JSONReceiverSuite) TestRecordFields(c *gc.C) {
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	var buf bytes.Buffer
+	receiver := charmrunner.NewJSONReceiver(&buf, testclock.NewClock(now), "install", "stderr")
+	receiver.Messagef(true, "%s", "partial")
+
+	var fields map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &fields)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fields, jc.DeepEquals, map[string]interface{}{
+		"timestamp": "2021-03-04T05:06:07Z",
+		"stream":    "stderr",
+		"hook":      "install",
+		"line":      "partial",
+		"partial":   true,
+	})
+}
@@ -5,6 +5,7 @@ package charmrunner
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"sync"
 	"time"
@@ -14,6 +15,14 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.common.runner")
 
+// TruncatedLineMarker is appended to lines of hook output
+// that have been truncated to the maximum line length.
+const TruncatedLineMarker = "...[truncated]"
+
+// receiverDrainTimeout is the longest Run waits, once the hook output
+// has been read, for buffered receivers to process their lines.
+const receiverDrainTimeout = time.Second
+
 // MessageReceiver instances are fed messages written to stdout/stderr
 // when running hooks/actions.
 type MessageReceiver interface {
@@ -22,27 +31,121 @@ type MessageReceiver interface {
 
 // NewHookLogger creates a new hook logger.
 func NewHookLogger(outReader io.ReadCloser, receivers ...MessageReceiver) *HookLogger {
-	return &HookLogger{
-		r:         outReader,
-		done:      make(chan struct{}),
-		receivers: receivers,
+	l := &HookLogger{
+		r:    outReader,
+		done: make(chan struct{}),
+	}
+	for _, r := range receivers {
+		l.receivers = append(l.receivers, &hookReceiver{receiver: r})
 	}
+	return l
 }
 
 // HookLogger streams the output from a hook to message receivers.
 type HookLogger struct {
-	r         io.ReadCloser
-	done      chan struct{}
-	mu        sync.Mutex
-	stopped   bool
-	receivers []MessageReceiver
+	r             io.ReadCloser
+	done          chan struct{}
+	mu            sync.Mutex
+	stopped       bool
+	receivers     []*hookReceiver
+	maxLineLength int
+	bufferSize    int
+
+	// receiversClosed is set once the buffered
+	// receivers will be sent no more lines.
+	receiversClosed bool
 }
 
-// Run starts the hook logger.
+// hookReceiver isolates the logger from a MessageReceiver that panics
+// and, when buffered, from one that is slow to process lines.
+type hookReceiver struct {
+	receiver MessageReceiver
+
+	// failed is set when a receiver fed synchronously panics.
+	failed bool
+
+	// lines is the buffer of lines fed to the receiver by
+	// its own goroutine, which closes done once finished.
+	lines   chan hookLine
+	done    chan struct{}
+	dropped int
+}
+
+// hookLine is a line of output queued for a buffered receiver.
+type hookLine struct {
+	isPrefix bool
+	line     string
+}
+
+// SetMaxLineLength causes lines of output that are too long to be read
+// in one go to be reassembled before being passed to receivers. Lines
+// longer than the input length are truncated, with TruncatedLineMarker
+// appended. A length of zero (the default) passes long lines to
+// receivers in fragments. It must be called before Run.
+func (l *HookLogger) SetMaxLineLength(max int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxLineLength = max
+}
+
+// SetReceiverBufferSize causes each receiver to be fed lines by its
+// own goroutine, through a buffer of the input size, so that a slow
+// receiver cannot hold up the others. Lines for a receiver whose buffer
+// is full are dropped. A size of zero (the default) feeds receivers in
+// turn as lines are read. It must be called before Run.
+func (l *HookLogger) SetReceiverBufferSize(size int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bufferSize = size
+}
+
+// Run starts the hook logger. It returns once the
+// output has been read to EOF or the logger is stopped.
 func (l *HookLogger) Run() {
+	l.RunContext(context.Background())
+}
+
+// RunContext starts the hook logger, as for Run, but also returns
+// promptly when the input context is cancelled, even if a read of the
+// hook output is blocked. Once cancelled, no further output is passed
+// to receivers. The reader is closed, which unblocks pending reads
+// from a pipe; a read that cannot be interrupted is left to complete
+// in the background.
+func (l *HookLogger) RunContext(ctx context.Context) {
 	defer close(l.done)
+	if ctx.Done() == nil {
+		l.run()
+		l.closeReceivers(true)
+		return
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		l.run()
+	}()
+	select {
+	case <-finished:
+		l.closeReceivers(true)
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.stopped = true
+		l.mu.Unlock()
+		_ = l.r.Close()
+		l.closeReceivers(false)
+	}
+}
+
+// run reads the hook output, passing it to the
+// receivers until EOF or the logger is stopped.
+func (l *HookLogger) run() {
 	defer l.r.Close()
 	br := bufio.NewReaderSize(l.r, 4096)
+
+	// pending accumulates the fragments of a long line
+	// when lines are being reassembled.
+	var pending []byte
+	var truncated bool
 	for {
 		line, isPrefix, err := br.ReadLine()
 		if err != nil {
@@ -51,23 +154,122 @@ func (l *HookLogger) Run() {
 			}
 			break
 		}
-		l.mu.Lock()
-		if l.stopped {
-			l.mu.Unlock()
+		if l.maxLineLength > 0 {
+			if !truncated {
+				pending = append(pending, line...)
+				if len(pending) > l.maxLineLength {
+					pending = append(pending[:l.maxLineLength], TruncatedLineMarker...)
+					truncated = true
+				}
+			}
+			if isPrefix {
+				continue
+			}
+			line, isPrefix = pending, false
+			pending, truncated = nil, false
+		}
+		if !l.send(isPrefix, line) {
 			return
 		}
-		for _, r := range l.receivers {
-			r.Messagef(isPrefix, "%s", line)
+	}
+	if len(pending) > 0 {
+		l.send(false, pending)
+	}
+}
+
+// send passes the input line to all receivers, returning
+// false if the logger has been stopped.
+func (l *HookLogger) send(isPrefix bool, line []byte) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stopped || l.receiversClosed {
+		return false
+	}
+	for _, r := range l.receivers {
+		if l.bufferSize == 0 {
+			if !r.failed {
+				r.failed = !deliver(r.receiver, isPrefix, string(line))
+			}
+			continue
+		}
+		if r.lines == nil {
+			r.lines = make(chan hookLine, l.bufferSize)
+			r.done = make(chan struct{})
+			go l.runReceiver(r)
 		}
+		select {
+		case r.lines <- hookLine{isPrefix: isPrefix, line: string(line)}:
+		default:
+			r.dropped++
+		}
+	}
+	return true
+}
+
+// runReceiver feeds the lines buffered for the input receiver to it,
+// until the buffer is closed, the receiver panics, or the logger
+// is stopped.
+func (l *HookLogger) runReceiver(r *hookReceiver) {
+	defer close(r.done)
+	for line := range r.lines {
+		l.mu.Lock()
+		stopped := l.stopped
 		l.mu.Unlock()
+		if stopped || !deliver(r.receiver, line.isPrefix, line.line) {
+			return
+		}
+	}
+}
+
+// closeReceivers closes the buffers of the buffered receivers, and if
+// wait is true, waits a limited time for the receivers to process the
+// lines already buffered.
+func (l *HookLogger) closeReceivers(wait bool) {
+	l.mu.Lock()
+	l.receiversClosed = true
+	var buffered []*hookReceiver
+	for _, r := range l.receivers {
+		if r.lines != nil {
+			close(r.lines)
+			buffered = append(buffered, r)
+		}
+	}
+	l.mu.Unlock()
+	if !wait {
+		return
+	}
+
+	deadline := time.Now().Add(receiverDrainTimeout)
+	for _, r := range buffered {
+		select {
+		case <-r.done:
+		case <-time.After(time.Until(deadline)):
+			logger.Warningf("timed out waiting for hook output receiver %T", r.receiver)
+		}
+		if r.dropped > 0 {
+			logger.Warningf("hook output receiver %T dropped %d lines", r.receiver, r.dropped)
+		}
 	}
 }
 
+// deliver passes the input line to the receiver,
+// returning false if the receiver panicked.
+func deliver(receiver MessageReceiver, isPrefix bool, line string) (ok bool) {
+	defer func() {
+		if p := recover(); p != nil {
+			logger.Errorf("hook output receiver %T panicked: %v", receiver, p)
+			ok = false
+		}
+	}()
+	receiver.Messagef(isPrefix, "%s", line)
+	return true
+}
+
 // AddReceiver adds an additional receiver to get messages
 func (l *HookLogger) AddReceiver(receiver MessageReceiver) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	l.receivers = append(l.receivers, receiver)
+	l.receivers = append(l.receivers, &hookReceiver{receiver: receiver})
 }
 
 // Stopper instances can be stopped.
@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrunner_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/common/charmrunner"
+)
+
+type HookLoggerSuite struct{}
+
+var _ = gc.Suite(&HookLoggerSuite{})
+
+func (s *HookLoggerSuite) TestLongLinesFragmented(c *gc.C) {
+	long := strings.Repeat("x", 10000)
+	var receiver recordingReceiver
+	hookLogger := charmrunner.NewHookLogger(ioutil.NopCloser(strings.NewReader(long+"\nshort\n")), &receiver)
+	hookLogger.Run()
+
+	c.Assert(len(receiver.messages), jc.GreaterThan, 2)
+	for _, m := range receiver.messages[:len(receiver.messages)-2] {
+		c.Check(m.isPrefix, jc.IsTrue)
+	}
+	c.Check(receiver.messages[len(receiver.messages)-1], gc.Equals, message{line: "short"})
+}
+
+func (s *HookLoggerSuite) TestLongLinesReassembledAndTruncated(c *gc.C) {
+	long := strings.Repeat("x", 6000) + strings.Repeat("y", 4000)
+	var receiver recordingReceiver
+	hookLogger := charmrunner.NewHookLogger(ioutil.NopCloser(strings.NewReader(long+"\nshort\n")), &receiver)
+	hookLogger.SetMaxLineLength(6000)
+	hookLogger.Run()
+
+	c.Check(receiver.messages, jc.DeepEquals, []message{
+		{line: strings.Repeat("x", 6000) + charmrunner.TruncatedLineMarker},
+		{line: "short"},
+	})
+}
+
+func (s *HookLoggerSuite) TestLongLinesReassembledWithinLimit(c *gc.C) {
+	long := strings.Repeat("x", 10000)
+	var receiver recordingReceiver
+	hookLogger := charmrunner.NewHookLogger(ioutil.NopCloser(strings.NewReader(long)), &receiver)
+	hookLogger.SetMaxLineLength(20000)
+	hookLogger.Run()
+
+	c.Check(receiver.messages, jc.DeepEquals, []message{{line: long}})
+}
+
+func (s *HookLoggerSuite) TestRunContextCancelled(c *gc.C) {
+	reader := newBlockingReader()
+	defer reader.unblock()
+	var receiver recordingReceiver
+	hookLogger := charmrunner.NewHookLogger(reader, &receiver)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hookLogger.RunContext(ctx)
+	}()
+
+	select {
+	case <-done:
+		c.Fatalf("hook logger stopped before cancellation")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	// The reader never reaches EOF, and ignores being closed,
+	// but cancelling the context still terminates Run.
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("hook logger did not stop after cancellation")
+	}
+	c.Check(reader.closed(), jc.IsTrue)
+
+	// Output read after cancellation is discarded.
+	reader.unblock()
+	hookLogger.Stop()
+	c.Check(receiver.messages, gc.HasLen, 0)
+}
+
+func (s *HookLoggerSuite) TestBlockingReceiverIsolated(c *gc.C) {
+	var lines []string
+	for i := 0; i < 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+
+	// The blocking receiver is held up until
+	// the other has received every line.
+	release := make(chan struct{})
+	var blockedCount int
+	blocking := funcReceiver(func(isPrefix bool, line string) {
+		<-release
+		blockedCount++
+	})
+	var received []string
+	other := funcReceiver(func(isPrefix bool, line string) {
+		received = append(received, line)
+		if len(received) == len(lines) {
+			close(release)
+		}
+	})
+
+	reader := ioutil.NopCloser(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+	hookLogger := charmrunner.NewHookLogger(reader, blocking, other)
+	hookLogger.SetReceiverBufferSize(10)
+	hookLogger.Run()
+
+	c.Check(received, jc.DeepEquals, lines)
+	// Lines that did not fit in the blocked receiver's buffer were dropped.
+	c.Check(blockedCount, jc.GreaterThan, 9)
+	c.Check(blockedCount, jc.LessThan, 12)
+}
+
+func (s *HookLoggerSuite) TestPanickingReceiverIsolated(c *gc.C) {
+	var panics int
+	panicking := funcReceiver(func(isPrefix bool, line string) {
+		panics++
+		panic("boom")
+	})
+	var receiver recordingReceiver
+	reader := ioutil.NopCloser(strings.NewReader("one\ntwo\n"))
+	hookLogger := charmrunner.NewHookLogger(reader, panicking, &receiver)
+	hookLogger.Run()
+
+	c.Check(receiver.messages, jc.DeepEquals, []message{{line: "one"}, {line: "two"}})
+	// A receiver that panics is not passed further lines.
+	c.Check(panics, gc.Equals, 1)
+}
+
+type message struct {
+	isPrefix bool
+	line     string
+}
+
+// recordingReceiver is a charmrunner.MessageReceiver
+// that records the messages it receives.
+type recordingReceiver struct {
+	messages []message
+}
+
+func (r *recordingReceiver) Messagef(isPrefix bool, msg string, args ...interface{}) {
+	r.messages = append(r.messages, message{isPrefix: isPrefix, line: fmt.Sprintf(msg, args...)})
+}
+
+// funcReceiver is a charmrunner.MessageReceiver
+// that calls itself with each formatted message.
+type funcReceiver func(isPrefix bool, line string)
+
+func (f funcReceiver) Messagef(isPrefix bool, msg string, args ...interface{}) {
+	f(isPrefix, fmt.Sprintf(msg, args...))
+}
+
+// blockingReader is an io.ReadCloser whose reads block
+// until it is unblocked, regardless of it being closed.
+type blockingReader struct {
+	release  chan struct{}
+	once     sync.Once
+	mu       sync.Mutex
+	isClosed bool
+}
+
+func newBlockingReader() *blockingReader {
+	return &blockingReader{release: make(chan struct{})}
+}
+
+func (r *blockingReader) Read(p []byte) (int, error) {
+	<-r.release
+	return copy(p, "late output\n"), nil
+}
+
+func (r *blockingReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.isClosed = true
+	return nil
+}
+
+func (r *blockingReader) closed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.isClosed
+}
+
+func (r *blockingReader) unblock() {
+	r.once.Do(func() { close(r.release) })
+}
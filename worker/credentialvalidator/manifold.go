@@ -81,7 +81,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 func filterErrors(err error) error {
 	cause := errors.Cause(err)
 	if cause == ErrValidityChanged ||
-		cause == ErrModelCredentialChanged {
+		cause == ErrModelCredentialChanged ||
+		cause == ErrModelCredentialDeleted {
 		return dependency.ErrBounce
 	}
 	return err
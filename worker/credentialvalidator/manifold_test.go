@@ -54,6 +54,12 @@ func (*ManifoldSuite) TestFilterErrModelCredentialChanged(c *gc.C) {
 	c.Check(err, gc.Equals, dependency.ErrBounce)
 }
 
+func (*ManifoldSuite) TestFilterErrModelCredentialDeleted(c *gc.C) {
+	manifold := credentialvalidator.Manifold(credentialvalidator.ManifoldConfig{})
+	err := manifold.Filter(credentialvalidator.ErrModelCredentialDeleted)
+	c.Check(err, gc.Equals, dependency.ErrBounce)
+}
+
 func (*ManifoldSuite) TestFilterOther(c *gc.C) {
 	manifold := credentialvalidator.Manifold(credentialvalidator.ManifoldConfig{})
 	expect := errors.New("whatever")
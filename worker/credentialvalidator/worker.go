@@ -25,6 +25,11 @@ var ErrValidityChanged = errors.New("cloud credential validity has changed")
 // model's cloud credential has changed.
 var ErrModelCredentialChanged = errors.New("model cloud credential has changed")
 
+// ErrModelCredentialDeleted indicates that a Worker has bounced because
+// its model's cloud credential has been removed from the controller,
+// while still being referenced by the model.
+var ErrModelCredentialDeleted = errors.New("model cloud credential has been deleted")
+
 // Facade exposes functionality required by a Worker to access and watch
 // a cloud credential that a model uses.
 type Facade interface {
@@ -69,6 +74,12 @@ func NewWorker(config Config) (worker.Worker, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if mc.Missing {
+		// The credential will remain invalid until it is recreated
+		// or the model is updated to use another credential, both
+		// of which are observed by the watchers below.
+		config.Logger.Infof("model cloud credential %q is no longer on the controller", mc.CloudCredential)
+	}
 
 	// This worker needs to monitor both the changes to the credential content that
 	// this model uses as well as what credential the model uses.
@@ -138,6 +149,8 @@ func (v *validator) Wait() error {
 }
 
 // Check is part of the util.Flag interface.
+// A credential that has been removed from the
+// controller is never considered to be valid.
 func (v *validator) Check() bool {
 	return v.credential.Valid
 }
@@ -171,9 +184,15 @@ func (v *validator) loop() error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			if updatedCredential.Missing && !v.credential.Missing {
+				return ErrModelCredentialDeleted
+			}
 			if v.credential.Valid != updatedCredential.Valid {
 				return ErrValidityChanged
 			}
+			// A recreated credential that is still
+			// invalid does not require a restart.
+			v.credential.Missing = updatedCredential.Missing
 		}
 	}
 }
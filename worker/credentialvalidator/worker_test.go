@@ -15,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/watcher/watchertest"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/credentialvalidator"
@@ -37,7 +38,7 @@ var _ = gc.Suite(&WorkerSuite{})
 func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 
-	s.credential = &base.StoredCredential{credentialTag, true}
+	s.credential = &base.StoredCredential{CloudCredential: credentialTag, Valid: true}
 	s.credentialChanges = make(chan struct{})
 	s.exists = true
 	s.modelCredentialChanges = make(chan struct{})
@@ -187,6 +188,68 @@ func (s *WorkerSuite) TestModelCredentialChanged(c *gc.C) {
 	s.facade.CheckCallNames(c, "ModelCredential", "WatchModelCredential", "WatchCredential", "ModelCredential")
 }
 
+func (s *WorkerSuite) TestCredentialDeleted(c *gc.C) {
+	worker, err := testWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.facade.credential.Valid = false
+	s.facade.credential.Missing = true
+	s.sendChange(c)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.Equals, credentialvalidator.ErrModelCredentialDeleted)
+	s.facade.CheckCallNames(c, "ModelCredential", "WatchModelCredential", "WatchCredential", "ModelCredential")
+}
+
+func (s *WorkerSuite) TestStartWithDeletedCredential(c *gc.C) {
+	s.facade.credential.Valid = false
+	s.facade.credential.Missing = true
+	worker, err := testWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.(engine.Flag).Check(), jc.IsFalse)
+
+	// Further changes while the credential is missing do not
+	// cause the worker to restart.
+	s.sendChange(c)
+	s.sendChange(c)
+
+	workertest.CheckAlive(c, worker)
+	c.Check(worker.(engine.Flag).Check(), jc.IsFalse)
+	workertest.CleanKill(c, worker)
+	s.facade.CheckCallNames(c, "ModelCredential", "WatchModelCredential", "WatchCredential", "ModelCredential", "ModelCredential")
+}
+
+func (s *WorkerSuite) TestDeletedCredentialRecreated(c *gc.C) {
+	s.facade.credential.Valid = false
+	s.facade.credential.Missing = true
+	worker, err := testWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.facade.credential.Valid = true
+	s.facade.credential.Missing = false
+	s.sendChange(c)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.Equals, credentialvalidator.ErrValidityChanged)
+}
+
+func (s *WorkerSuite) TestDeletedCredentialRecreatedInvalid(c *gc.C) {
+	s.facade.credential.Valid = false
+	s.facade.credential.Missing = true
+	worker, err := testWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.facade.credential.Missing = false
+	s.sendChange(c)
+
+	// Deleting the recreated credential restarts the worker.
+	s.facade.credential.Missing = true
+	s.sendChange(c)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.Equals, credentialvalidator.ErrModelCredentialDeleted)
+}
+
 func (s *WorkerSuite) sendModelChange(c *gc.C) {
 	select {
 	case s.modelCredentialChanges <- struct{}{}:
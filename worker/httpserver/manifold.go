@@ -24,6 +24,10 @@ import (
 	workerstate "github.com/juju/juju/worker/state"
 )
 
+// boundAddressesCheckInterval is how often the worker checks whether
+// its bound addresses or SNI names have changed.
+const boundAddressesCheckInterval = time.Minute
+
 type Logger interface {
 	Debugf(string, ...interface{})
 	Errorf(string, ...interface{})
@@ -196,9 +200,28 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 		APIPort:              controllerConfig.APIPort(),
 		APIPortOpenDelay:     controllerConfig.APIPortOpenDelay(),
 		ControllerAPIPort:    controllerConfig.ControllerAPIPort(),
+		SNINames:             authoritySNINames(authority, controllerConfig.AutocertDNSName()),
+		AddressCheckInterval: boundAddressesCheckInterval,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
 }
+
+// authoritySNINames returns a function reporting the DNS names of
+// all the authority's leaf certificates, along with the autocert
+// DNS name if one is configured.
+func authoritySNINames(authority pki.Authority, autocertDNSName string) func() []string {
+	return func() []string {
+		var names []string
+		authority.LeafRange(func(leaf pki.Leaf) bool {
+			names = append(names, leaf.Certificate().DNSNames...)
+			return true
+		})
+		if autocertDNSName != "" {
+			names = append(names, autocertDNSName)
+		}
+		return names
+	}
+}
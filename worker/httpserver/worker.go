@@ -13,7 +13,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime/pprof"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -43,6 +45,17 @@ type Config struct {
 	APIPort              int
 	APIPortOpenDelay     time.Duration
 	ControllerAPIPort    int
+
+	// SNINames, if non-nil, returns the server names that the
+	// API server holds certificates for. They are published on
+	// the hub along with the addresses the server is bound to.
+	SNINames func() []string
+
+	// AddressCheckInterval is how often the bound addresses and
+	// SNI names are checked for changes, which are then published
+	// on the hub. If zero, changes are only published when the
+	// worker opens a new port.
+	AddressCheckInterval time.Duration
 }
 
 // Validate validates the API server configuration.
@@ -76,6 +89,8 @@ func NewWorker(config Config) (*Worker, error) {
 		logger: config.Logger,
 		url:    make(chan string),
 		status: "starting",
+
+		addressesChanged: make(chan struct{}, 1),
 	}
 	var err error
 	var listener listener
@@ -110,9 +125,15 @@ type Worker struct {
 	holdable *heldListener
 	logger   Logger
 
-	// mu controls access to both status and reporter.
+	// addressesChanged is signalled when the listener opens a new port.
+	addressesChanged chan struct{}
+
+	// mu controls access to status, reporter and boundAddresses.
 	mu     sync.Mutex
 	status string
+
+	// boundAddresses holds the details last published on the hub.
+	boundAddresses apiserver.BoundAddresses
 }
 
 // Kill implements worker.Kill.
@@ -133,6 +154,9 @@ func (w *Worker) Report() map[string]interface{} {
 		"status":   w.status,
 		"ports":    w.holdable.report(),
 	}
+	if addresses := w.boundAddressDetails().Addresses; len(addresses) > 0 {
+		result["bound-addresses"] = addresses
+	}
 	if w.config.ControllerAPIPort != 0 {
 		result["api-port-open-delay"] = w.config.APIPortOpenDelay
 		result["controller-api-port"] = w.config.ControllerAPIPort
@@ -181,6 +205,12 @@ func (w *Worker) loop() error {
 	w.status = "running"
 	w.mu.Unlock()
 
+	w.publishBoundAddresses()
+	var recheck <-chan time.Time
+	if w.config.AddressCheckInterval > 0 {
+		recheck = w.config.Clock.After(w.config.AddressCheckInterval)
+	}
+
 	for {
 		select {
 		case <-w.catacomb.Dying():
@@ -193,10 +223,58 @@ func (w *Worker) loop() error {
 			w.holdable.hold()
 			return w.shutdown()
 		case w.url <- w.holdable.URL():
+		case <-w.addressesChanged:
+			w.publishBoundAddresses()
+		case <-recheck:
+			w.publishBoundAddresses()
+			recheck = w.config.Clock.After(w.config.AddressCheckInterval)
 		}
 	}
 }
 
+// boundAddressDetails returns the addresses and ports that the
+// server is currently listening on, along with its SNI names.
+func (w *Worker) boundAddressDetails() apiserver.BoundAddresses {
+	details := apiserver.BoundAddresses{
+		MachineTag: w.config.AgentName,
+	}
+	for _, addr := range w.holdable.addrs() {
+		details.Addresses = append(details.Addresses, addr.String())
+		if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+			details.Ports = append(details.Ports, tcpAddr.Port)
+		}
+	}
+	if w.config.SNINames != nil {
+		seen := make(map[string]bool)
+		for _, name := range w.config.SNINames() {
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			details.SNINames = append(details.SNINames, name)
+		}
+		sort.Strings(details.SNINames)
+	}
+	return details
+}
+
+// publishBoundAddresses publishes the bound address details on
+// the hub if they have changed since they were last published.
+func (w *Worker) publishBoundAddresses() {
+	details := w.boundAddressDetails()
+	w.mu.Lock()
+	unchanged := reflect.DeepEqual(details, w.boundAddresses)
+	w.boundAddresses = details
+	w.mu.Unlock()
+	if unchanged || w.config.Hub == nil {
+		return
+	}
+	w.logger.Debugf("publishing bound addresses %v", details.Addresses)
+	if _, err := w.config.Hub.Publish(apiserver.BoundAddressesTopic, details); err != nil {
+		w.logger.Warningf("unable to publish bound addresses: %v", err)
+	}
+}
+
 func (w *Worker) shutdown() error {
 	muxDone := make(chan struct{})
 	go func() {
@@ -286,6 +364,9 @@ type listener interface {
 	net.Listener
 	reporter
 	URL() string
+
+	// addrs returns the addresses of all the open listeners.
+	addrs() []net.Addr
 }
 
 func (w *Worker) newSimpleListener() (listener, error) {
@@ -306,6 +387,10 @@ func (s *simpleListener) URL() string {
 	return fmt.Sprintf("https://%s", s.Addr())
 }
 
+func (s *simpleListener) addrs() []net.Addr {
+	return []net.Addr{s.Addr()}
+}
+
 func (s *simpleListener) report() map[string]interface{} {
 	return map[string]interface{}{
 		"listening": s.Addr().String(),
@@ -337,6 +422,7 @@ func (w *Worker) newDualPortListener() (listener, error) {
 		done:               make(chan struct{}),
 		errors:             make(chan error),
 		connections:        make(chan net.Conn),
+		changed:            w.addressesChanged,
 		logger:             w.logger,
 	}
 	go dual.accept(listener)
@@ -367,6 +453,9 @@ type dualListener struct {
 	errors      chan error
 	connections chan net.Conn
 
+	// changed is signalled when the api port is opened.
+	changed chan<- struct{}
+
 	logger Logger
 
 	unsub func()
@@ -387,6 +476,16 @@ func (d *dualListener) report() map[string]interface{} {
 	return result
 }
 
+func (d *dualListener) addrs() []net.Addr {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	result := []net.Addr{d.controllerListener.Addr()}
+	if d.apiListener != nil {
+		result = append(result, d.apiListener.Addr())
+	}
+	return result
+}
+
 func (d *dualListener) accept(listener net.Listener) {
 	for {
 		conn, err := listener.Accept()
@@ -527,4 +626,10 @@ func (d *dualListener) openAPIPort(topic string, conn apiserver.APIConnection, e
 	d.apiListener = listener
 	go d.accept(listener)
 	d.status = ""
+
+	select {
+	case d.changed <- struct{}{}:
+	default:
+		// A change is already pending.
+	}
 }
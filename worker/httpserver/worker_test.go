@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -389,6 +390,7 @@ func (s *WorkerControllerPortSuite) TestDualPortListenerWithDelay(c *gc.C) {
 		"controller-api-port": s.config.ControllerAPIPort,
 		"status":              "running",
 		"ports":               reportPorts,
+		"bound-addresses":     []string{fmt.Sprintf("[::]:%d", s.config.ControllerAPIPort)},
 	}
 	c.Check(worker.Report(), jc.DeepEquals, report)
 
@@ -446,6 +448,10 @@ func (s *WorkerControllerPortSuite) TestDualPortListenerWithDelay(c *gc.C) {
 
 	delete(reportPorts, "status")
 	reportPorts["agent"] = fmt.Sprintf("[::]:%d", s.config.APIPort)
+	report["bound-addresses"] = []string{
+		fmt.Sprintf("[::]:%d", s.config.ControllerAPIPort),
+		fmt.Sprintf("[::]:%d", s.config.APIPort),
+	}
 	c.Check(worker.Report(), jc.DeepEquals, report)
 }
 
@@ -491,3 +497,121 @@ func (s *WorkerControllerPortSuite) TestDualPortListenerWithDelayShutdown(c *gc.
 	// We exit cleanly even if we never tick the clock forward
 	workertest.CleanKill(c, worker)
 }
+
+type WorkerBoundAddressesSuite struct {
+	workerFixture
+	published chan apiserver.BoundAddresses
+}
+
+var _ = gc.Suite(&WorkerBoundAddressesSuite{})
+
+func (s *WorkerBoundAddressesSuite) SetUpTest(c *gc.C) {
+	s.workerFixture.SetUpTest(c)
+	s.published = make(chan apiserver.BoundAddresses, 10)
+	unsubscribe, err := s.hub.Subscribe(apiserver.BoundAddressesTopic, func(topic string, data apiserver.BoundAddresses, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		s.published <- data
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { unsubscribe() })
+}
+
+func (s *WorkerBoundAddressesSuite) newWorker(c *gc.C) *httpserver.Worker {
+	worker, err := httpserver.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		workertest.DirtyKill(c, worker)
+	})
+	return worker
+}
+
+func (s *WorkerBoundAddressesSuite) nextPublished(c *gc.C) apiserver.BoundAddresses {
+	select {
+	case details := <-s.published:
+		return details
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for bound addresses")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerBoundAddressesSuite) TestPublishedOnStart(c *gc.C) {
+	port := testing.FindTCPPort()
+	s.config.APIPort = port
+	s.config.SNINames = func() []string {
+		return []string{"juju-apiserver", "anything", "juju-apiserver"}
+	}
+	worker := s.newWorker(c)
+
+	address := fmt.Sprintf("[::]:%d", port)
+	c.Check(s.nextPublished(c), jc.DeepEquals, apiserver.BoundAddresses{
+		MachineTag: s.agentName,
+		Addresses:  []string{address},
+		Ports:      []int{port},
+		SNINames:   []string{"anything", "juju-apiserver"},
+	})
+	c.Check(worker.Report()["bound-addresses"], jc.DeepEquals, []string{address})
+
+	workertest.CleanKill(c, worker)
+}
+
+func (s *WorkerBoundAddressesSuite) TestPublishedWhenAgentPortOpens(c *gc.C) {
+	port := testing.FindTCPPort()
+	controllerPort := testing.FindTCPPort()
+	s.config.APIPort = port
+	s.config.ControllerAPIPort = controllerPort
+	worker := s.newWorker(c)
+
+	details := s.nextPublished(c)
+	c.Check(details.Ports, jc.DeepEquals, []int{controllerPort})
+
+	_, err := s.hub.Publish(apiserver.ConnectTopic, apiserver.APIConnection{
+		AgentTag: s.agentName,
+		Origin:   s.agentName,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	details = s.nextPublished(c)
+	c.Check(details.Addresses, jc.DeepEquals, []string{
+		fmt.Sprintf("[::]:%d", controllerPort),
+		fmt.Sprintf("[::]:%d", port),
+	})
+	c.Check(details.Ports, jc.DeepEquals, []int{controllerPort, port})
+
+	workertest.CleanKill(c, worker)
+}
+
+func (s *WorkerBoundAddressesSuite) TestPublishedWhenSNINamesChange(c *gc.C) {
+	var mu sync.Mutex
+	names := []string{"juju-apiserver"}
+	s.config.SNINames = func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return names
+	}
+	s.config.AddressCheckInterval = time.Minute
+	worker := s.newWorker(c)
+
+	details := s.nextPublished(c)
+	c.Check(details.SNINames, jc.DeepEquals, []string{"juju-apiserver"})
+
+	// Nothing is published if nothing has changed.
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case details := <-s.published:
+		c.Fatalf("unexpected publication %#v", details)
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	mu.Lock()
+	names = []string{"juju-apiserver", "controller.example.com"}
+	mu.Unlock()
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	details = s.nextPublished(c)
+	c.Check(details.SNINames, jc.DeepEquals, []string{"controller.example.com", "juju-apiserver"})
+
+	workertest.CleanKill(c, worker)
+}
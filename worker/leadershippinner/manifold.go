@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinner
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/upgradeseries"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+)
+
+// ManifoldConfig holds the information necessary for the dependency engine
+// to run a leadership pinner worker.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	Clock     clock.Clock
+	Logger    Logger
+	Interval  time.Duration
+	NewFacade func(base.APICaller, names.Tag) Facade
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade function")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker function")
+	}
+	return nil
+}
+
+// Manifold returns a dependency manifold that runs a leadership pinner
+// worker, using the resource names defined in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.newWorker)
+}
+
+// newWorker wraps NewWorker for use in a engine.AgentAPIManifold.
+func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Leadership pinning is only applicable to machine agents.
+	agentTag := a.CurrentConfig().Tag()
+	tag, ok := agentTag.(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected a machine tag, got %v", agentTag)
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	w, err := config.NewWorker(Config{
+		Facade:   config.NewFacade(apiCaller, tag),
+		Clock:    config.Clock,
+		Logger:   config.Logger,
+		Interval: interval,
+	})
+	return w, errors.Annotate(err, "starting leadership pinner")
+}
+
+// NewFacade creates a new upgrade-series client and returns its
+// reference as the facade indirection above.
+func NewFacade(apiCaller base.APICaller, tag names.Tag) Facade {
+	return upgradeseries.NewClient(apiCaller, tag)
+}
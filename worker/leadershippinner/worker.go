@@ -0,0 +1,269 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/watcher"
+)
+
+// DefaultInterval is the period after which pins are re-asserted
+// while an upgrade-series lock is held for the machine.
+const DefaultInterval = time.Minute
+
+// Logger represents the methods required to emit log messages.
+type Logger interface {
+	Debugf(message string, args ...interface{})
+	Infof(message string, args ...interface{})
+	Warningf(message string, args ...interface{})
+	Errorf(message string, args ...interface{})
+}
+
+// Facade exposes the upgrade-series state and leadership pinning
+// operations required by the worker.
+type Facade interface {
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	MachineStatus() (model.UpgradeSeriesStatus, error)
+	PinMachineApplications() (map[string]error, error)
+	UnpinMachineApplications() (map[string]error, error)
+}
+
+// Config is the configuration needed to construct a leadership pinner.
+type Config struct {
+	// Facade is used to access the machine's upgrade-series lock and
+	// to pin and unpin leadership for its applications.
+	Facade Facade
+
+	// Clock is used to schedule the re-assertion of pins.
+	Clock clock.Clock
+
+	// Logger is the logger for this worker.
+	Logger Logger
+
+	// Interval is the period after which pins are re-asserted.
+	Interval time.Duration
+}
+
+// Validate validates the leadership pinner configuration.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// pinner ensures that leadership for applications with units on the
+// machine remains pinned for as long as the machine holds an
+// upgrade-series lock. Pins are re-asserted periodically, so that pins
+// dropped by the lease layer (for instance due to an agent restart part
+// way through an upgrade) are re-established. Once the upgrade completes,
+// the pins are released.
+type pinner struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	mu            sync.Mutex
+	machineStatus model.UpgradeSeriesStatus
+	pinned        bool
+
+	// checked is false until the upgrade-series lock has been inspected
+	// for the first time. Until then we do not know whether a previous
+	// incarnation of this worker left leadership pinned.
+	checked bool
+}
+
+// NewWorker creates, starts and returns a new leadership pinner based on
+// the input configuration.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &pinner{
+		config:        config,
+		machineStatus: model.UpgradeSeriesNotStarted,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *pinner) loop() error {
+	uw, err := w.config.Facade.WatchUpgradeSeriesNotifications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(uw); err != nil {
+		return errors.Trace(err)
+	}
+
+	var repin <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-uw.Changes():
+			if !ok {
+				return errors.New("upgrade-series watcher closed")
+			}
+			active, err := w.handleUpgradeSeriesChange()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			repin = nil
+			if active {
+				repin = w.config.Clock.After(w.config.Interval)
+			}
+		case <-repin:
+			active, err := w.pinLeaders()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			repin = nil
+			if active {
+				repin = w.config.Clock.After(w.config.Interval)
+			}
+		}
+	}
+}
+
+// handleUpgradeSeriesChange pins or unpins leadership depending on the
+// current state of the machine's upgrade-series lock.
+// The returned boolean indicates whether pins should be re-asserted.
+func (w *pinner) handleUpgradeSeriesChange() (bool, error) {
+	status, err := w.config.Facade.MachineStatus()
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+
+	w.mu.Lock()
+	firstCheck := !w.checked
+	w.checked = true
+	if errors.IsNotFound(err) {
+		w.machineStatus = model.UpgradeSeriesNotStarted
+	} else {
+		w.machineStatus = status
+	}
+	pinned := w.pinned
+	w.mu.Unlock()
+
+	if errors.IsNotFound(err) {
+		w.config.Logger.Debugf("no series upgrade lock present")
+		status = model.UpgradeSeriesNotStarted
+	}
+
+	switch status {
+	case model.UpgradeSeriesNotStarted, model.UpgradeSeriesCompleted:
+		// If we have just started, a previous incarnation of this worker
+		// may have pinned leadership before the agent was restarted.
+		// Unpinning is idempotent, so release the pins to be sure.
+		if pinned || firstCheck {
+			return false, errors.Trace(w.unpinLeaders())
+		}
+		return false, nil
+	}
+	return w.pinLeaders()
+}
+
+// pinLeaders pins leadership for applications represented by units
+// running on this machine.
+// The returned boolean indicates whether pins should be re-asserted.
+func (w *pinner) pinLeaders() (bool, error) {
+	results, err := w.config.Facade.PinMachineApplications()
+	if err != nil {
+		// The legacy lease store does not support pinning, in which case
+		// there is nothing for this worker to do.
+		if params.IsCodeNotImplemented(err) {
+			w.config.Logger.Infof("leadership pinning is not implemented with the legacy lease manager")
+			return false, nil
+		}
+		return false, errors.Trace(err)
+	}
+
+	for app, err := range results {
+		if err == nil {
+			w.config.Logger.Debugf("pinned leader for application %q", app)
+			continue
+		}
+		// Failures will be retried when pins are next re-asserted.
+		w.config.Logger.Warningf("failed to pin leader for application %q: %s", app, err.Error())
+	}
+
+	w.mu.Lock()
+	w.pinned = true
+	w.mu.Unlock()
+	return true, nil
+}
+
+// unpinLeaders unpins leadership for applications represented by units
+// running on this machine.
+func (w *pinner) unpinLeaders() error {
+	results, err := w.config.Facade.UnpinMachineApplications()
+	if err != nil {
+		if params.IsCodeNotImplemented(err) {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	var lastErr error
+	for app, err := range results {
+		if err == nil {
+			w.config.Logger.Infof("unpinned leader for application %q", app)
+			continue
+		}
+		w.config.Logger.Errorf("failed to unpin leader for application %q: %s", app, err.Error())
+		lastErr = err
+	}
+	if lastErr != nil {
+		return errors.Trace(lastErr)
+	}
+
+	w.mu.Lock()
+	w.pinned = false
+	w.mu.Unlock()
+	return nil
+}
+
+// Report (worker.Reporter) generates a report for the Juju engine.
+func (w *pinner) Report() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return map[string]interface{}{
+		"machine status": w.machineStatus,
+		"pinned":         w.pinned,
+	}
+}
+
+// Kill implements worker.Worker.Kill.
+func (w *pinner) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.Wait.
+func (w *pinner) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,316 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinner_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/leadershippinner"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.facade = newMockFacade()
+}
+
+func (s *WorkerSuite) config() leadershippinner.Config {
+	return leadershippinner.Config{
+		Facade:   s.facade,
+		Clock:    s.clock,
+		Logger:   loggo.GetLogger("test"),
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.Facade = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	cfg = s.config()
+	cfg.Clock = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Clock not valid")
+
+	cfg = s.config()
+	cfg.Logger = nil
+	c.Check(cfg.Validate(), gc.ErrorMatches, "nil Logger not valid")
+
+	cfg = s.config()
+	cfg.Interval = 0
+	c.Check(cfg.Validate(), gc.ErrorMatches, "non-positive Interval not valid")
+}
+
+func (s *WorkerSuite) TestNoLockUnpinsOnStart(c *gc.C) {
+	s.facade.setStatus("", errors.NotFoundf("upgrade lock"))
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// A previous incarnation of the worker may have pinned leadership
+	// before the lock was removed, so the pins are released.
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "UnpinMachineApplications")
+
+	// Later changes without a lock do not pin or unpin again.
+	s.facade.notify(c)
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestUnpinsWhenLockRemoved(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesPrepareStarted, nil)
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	s.facade.setStatus("", errors.NotFoundf("upgrade lock"))
+	s.facade.notify(c)
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "UnpinMachineApplications")
+}
+
+func (s *WorkerSuite) TestUnpinsWhenReturnedToNotStarted(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesPrepareStarted, nil)
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	s.facade.setStatus(model.UpgradeSeriesNotStarted, nil)
+	s.facade.notify(c)
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "UnpinMachineApplications")
+
+	// Pins are no longer re-asserted.
+	s.clock.Advance(time.Minute)
+	s.facade.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestPinsAndReassertsWhileLocked(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesPrepareStarted, nil)
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.facade.waitCall(c, "PinMachineApplications")
+}
+
+func (s *WorkerSuite) TestUnpinsOnCompletion(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesPrepareStarted, nil)
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	s.facade.setStatus(model.UpgradeSeriesCompleted, nil)
+	s.facade.notify(c)
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "UnpinMachineApplications")
+
+	// Pins are no longer re-asserted.
+	s.clock.Advance(time.Minute)
+	s.facade.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestRestartWhilePinned(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesCompleteStarted, nil)
+
+	w := s.startWorker(c)
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	// Simulate the agent restarting mid-upgrade.
+	workertest.CleanKill(c, w)
+
+	w = s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	// The new worker re-establishes the pins straight away,
+	// and keeps re-asserting them. The timer started by the first
+	// worker is still pending, hence waiting for two timers.
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "PinMachineApplications")
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 2), jc.ErrorIsNil)
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	s.facade.setStatus(model.UpgradeSeriesCompleted, nil)
+	s.facade.notify(c)
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "UnpinMachineApplications")
+}
+
+func (s *WorkerSuite) TestRestartAfterCompletionUnpins(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesCompleted, nil)
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "UnpinMachineApplications")
+}
+
+func (s *WorkerSuite) TestPinNotImplemented(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesPrepareStarted, nil)
+	s.facade.SetErrors(&params.Error{Code: params.CodeNotImplemented})
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	s.clock.Advance(time.Minute)
+	s.facade.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestPinError(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesPrepareStarted, nil)
+	s.facade.SetErrors(errors.New("boom"))
+
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *WorkerSuite) TestReport(c *gc.C) {
+	s.facade.setStatus(model.UpgradeSeriesPrepareStarted, nil)
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.waitCall(c, "MachineStatus")
+	s.facade.waitCall(c, "PinMachineApplications")
+
+	reporter, ok := w.(worker.Reporter)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(reporter.Report(), jc.DeepEquals, map[string]interface{}{
+		"machine status": model.UpgradeSeriesPrepareStarted,
+		"pinned":         true,
+	})
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := leadershippinner.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.notify(c)
+	return w
+}
+
+type mockFacade struct {
+	testing.Stub
+
+	mu        sync.Mutex
+	status    model.UpgradeSeriesStatus
+	statusErr error
+
+	changes chan struct{}
+	calls   chan string
+}
+
+func newMockFacade() *mockFacade {
+	return &mockFacade{
+		changes: make(chan struct{}),
+		calls:   make(chan string, 10),
+	}
+}
+
+func (f *mockFacade) setStatus(status model.UpgradeSeriesStatus, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = status
+	f.statusErr = err
+}
+
+func (f *mockFacade) notify(c *gc.C) {
+	select {
+	case f.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (f *mockFacade) waitCall(c *gc.C, name string) {
+	select {
+	case call := <-f.calls:
+		c.Assert(call, gc.Equals, name)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s call", name)
+	}
+}
+
+func (f *mockFacade) assertNoCall(c *gc.C) {
+	select {
+	case call := <-f.calls:
+		c.Fatalf("unexpected %s call", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (f *mockFacade) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	f.AddCall("WatchUpgradeSeriesNotifications")
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *mockFacade) MachineStatus() (model.UpgradeSeriesStatus, error) {
+	f.AddCall("MachineStatus")
+	f.mu.Lock()
+	status, err := f.status, f.statusErr
+	f.mu.Unlock()
+	f.calls <- "MachineStatus"
+	return status, err
+}
+
+func (f *mockFacade) PinMachineApplications() (map[string]error, error) {
+	f.MethodCall(f, "PinMachineApplications")
+	f.calls <- "PinMachineApplications"
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return map[string]error{"mysql": nil}, nil
+}
+
+func (f *mockFacade) UnpinMachineApplications() (map[string]error, error) {
+	f.MethodCall(f, "UnpinMachineApplications")
+	f.calls <- "UnpinMachineApplications"
+	return map[string]error{"mysql": nil}, nil
+}
@@ -20,6 +20,7 @@ type Logger interface {
 	Tracef(string, ...interface{})
 	Debugf(string, ...interface{})
 	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
 	Errorf(string, ...interface{})
 }
 
@@ -26,8 +26,13 @@ type Facade interface {
 	AllMachineRemovals() ([]names.MachineTag, error)
 	GetProviderInterfaceInfo(names.MachineTag) ([]network.ProviderInterfaceInfo, error)
 	CompleteRemoval(names.MachineTag) error
+	SetRemovalProgress(pending, addressesReleased, failures int) error
 }
 
+// progressBatchSize is the number of machines processed
+// between each report of the removal progress.
+const progressBatchSize = 10
+
 // AddressReleaser defines the interface we need from the environment
 // networking.
 type AddressReleaser interface {
@@ -79,41 +84,68 @@ func (u *Undertaker) Handle(<-chan struct{}) error {
 	u.Logger.Debugf("handling removals: %v", removals)
 	// TODO(babbageclunk): shuffle the removals so if there's a
 	// problem with one others can still get past?
-	for _, machine := range removals {
-		err := u.MaybeReleaseAddresses(machine)
+	var removed, released, failures int
+	u.reportProgress(len(removals), released, failures)
+	for i, machine := range removals {
+		if i > 0 && i%progressBatchSize == 0 {
+			u.reportProgress(len(removals)-removed, released, failures)
+		}
+		didRelease, err := u.releaseAddresses(machine)
 		if err != nil {
 			u.Logger.Errorf("couldn't release addresses for %s: %s", machine, err)
+			failures++
 			continue
 		}
+		if didRelease {
+			released++
+		}
 		err = u.API.CompleteRemoval(machine)
 		if err != nil {
 			u.Logger.Errorf("couldn't complete removal for %s: %s", machine, err)
+			failures++
 		} else {
 			u.Logger.Debugf("completed removal: %s", machine)
+			removed++
 		}
 	}
+	u.reportProgress(len(removals)-removed, released, failures)
 	return nil
 }
 
+// reportProgress records the progress made in removing machines.
+// Failure to do so is logged, but does not hold up the removals.
+func (u *Undertaker) reportProgress(pending, released, failures int) {
+	if err := u.API.SetRemovalProgress(pending, released, failures); err != nil {
+		u.Logger.Warningf("couldn't record machine removal progress: %s", err)
+	}
+}
+
 // MaybeReleaseAddresses releases any addresses that have been
 // allocated to this machine by the provider (if the provider supports
 // that).
 func (u *Undertaker) MaybeReleaseAddresses(machine names.MachineTag) error {
+	_, err := u.releaseAddresses(machine)
+	return errors.Trace(err)
+}
+
+// releaseAddresses releases any provider-allocated addresses for the
+// machine, returning true if there were addresses that were released.
+func (u *Undertaker) releaseAddresses(machine names.MachineTag) (bool, error) {
 	if u.Releaser == nil {
 		// This environ doesn't support releasing addresses.
-		return nil
+		return false, nil
 	}
 	if !names.IsContainerMachine(machine.Id()) {
 		// At the moment, only containers need their addresses releasing.
-		return nil
+		return false, nil
 	}
 	interfaceInfos, err := u.API.GetProviderInterfaceInfo(machine)
 	if err != nil {
-		return errors.Trace(err)
+		return false, errors.Trace(err)
 	}
 	if len(interfaceInfos) == 0 {
 		u.Logger.Debugf("%s has no addresses to release", machine)
-		return nil
+		return false, nil
 	}
 	err = u.Releaser.ReleaseContainerAddresses(u.CallContext, interfaceInfos)
 	// Some providers say they support networking but don't
@@ -121,10 +153,11 @@ func (u *Undertaker) MaybeReleaseAddresses(machine names.MachineTag) error {
 	// about those.
 	if errors.IsNotSupported(err) {
 		u.Logger.Debugf("%s has addresses but provider doesn't support releasing them", machine)
+		return false, nil
 	} else if err != nil {
-		return errors.Trace(err)
+		return false, errors.Trace(err)
 	}
-	return nil
+	return true, nil
 }
 
 // TearDown (part of watcher.NotifyHandler) is an opportunity to stop
@@ -4,6 +4,8 @@
 package machineundertaker_test
 
 import (
+	"strconv"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -241,6 +243,62 @@ func (*undertakerSuite) TestHandle_ErrorOnRemoval(c *gc.C) {
 	checkRemovalsMatch(c, api.Stub, "3", "4/lxd/4")
 }
 
+func (*undertakerSuite) TestHandle_ReportsProgress(c *gc.C) {
+	api := fakeAPI{
+		Stub:     &testing.Stub{},
+		removals: []string{"3", "4/lxd/4", "5"},
+		interfaces: map[string][]network.ProviderInterfaceInfo{
+			"4/lxd/4": {
+				{InterfaceName: "chloe"},
+			},
+		},
+	}
+	api.SetErrors(nil, nil, nil, nil, errors.New("couldn't remove machine 5"))
+	releaser := fakeReleaser{Stub: &testing.Stub{}}
+	u := machineundertaker.Undertaker{
+		API:      &api,
+		Releaser: &releaser,
+		Logger:   loggo.GetLogger("test"),
+	}
+	err := u.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	checkRemovalsMatch(c, api.Stub, "3", "4/lxd/4", "5")
+	c.Check(api.progress, gc.DeepEquals, [][3]int{
+		{3, 0, 0},
+		{1, 1, 1},
+	})
+}
+
+func (*undertakerSuite) TestHandle_ReportsProgressInBatches(c *gc.C) {
+	api := fakeAPI{Stub: &testing.Stub{}}
+	for i := 0; i < 25; i++ {
+		api.removals = append(api.removals, strconv.Itoa(i))
+	}
+	u := machineundertaker.Undertaker{API: &api, Logger: loggo.GetLogger("test")}
+	err := u.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(api.progress, gc.DeepEquals, [][3]int{
+		{25, 0, 0},
+		{15, 0, 0},
+		{5, 0, 0},
+		{0, 0, 0},
+	})
+}
+
+func (*undertakerSuite) TestHandle_ProgressErrorIgnored(c *gc.C) {
+	api := fakeAPI{
+		Stub:        &testing.Stub{},
+		removals:    []string{"3"},
+		progressErr: errors.New("model gone away"),
+	}
+	u := machineundertaker.Undertaker{API: &api, Logger: loggo.GetLogger("test")}
+	err := u.Handle(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	checkRemovalsMatch(c, api.Stub, "3")
+}
+
 func checkRemovalsMatch(c *gc.C, stub *testing.Stub, expected ...string) {
 	var completedRemovals []string
 	for _, call := range stub.Calls() {
@@ -299,6 +357,11 @@ type fakeAPI struct {
 	watcher    *mockNotifyWatcher
 	removals   []string
 	interfaces map[string][]network.ProviderInterfaceInfo
+
+	// progress records the removal progress reported,
+	// and progressErr is returned when it is reported.
+	progress    [][3]int
+	progressErr error
 }
 
 func (a *fakeAPI) WatchMachineRemovals() (watcher.NotifyWatcher, error) {
@@ -325,6 +388,11 @@ func (a *fakeAPI) CompleteRemoval(machine names.MachineTag) error {
 	return a.Stub.NextErr()
 }
 
+func (a *fakeAPI) SetRemovalProgress(pending, addressesReleased, failures int) error {
+	a.progress = append(a.progress, [3]int{pending, addressesReleased, failures})
+	return a.progressErr
+}
+
 type mockNotifyWatcher struct {
 	watcher.NotifyWatcher
 
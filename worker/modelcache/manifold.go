@@ -10,8 +10,10 @@ import (
 	"github.com/juju/worker/v2/dependency"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/multiwatcher"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/gate"
 	workerstate "github.com/juju/juju/worker/state"
 )
@@ -35,6 +37,10 @@ type ManifoldConfig struct {
 
 	PrometheusRegisterer prometheus.Registerer
 
+	// GetControllerConfig returns the controller config, from which
+	// the cache's eviction retention is read.
+	GetControllerConfig func(*state.State) (controller.Config, error)
+
 	NewWorker func(Config) (worker.Worker, error)
 }
 
@@ -58,6 +64,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.PrometheusRegisterer == nil {
 		return errors.NotValidf("missing PrometheusRegisterer")
 	}
+	if config.GetControllerConfig == nil {
+		return errors.NotValidf("missing GetControllerConfig func")
+	}
 	if config.NewWorker == nil {
 		return errors.NotValidf("missing NewWorker func")
 	}
@@ -110,6 +119,12 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
+	controllerConfig, err := config.GetControllerConfig(pool.SystemState())
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Annotate(err, "unable to get controller config")
+	}
+
 	w, err := config.NewWorker(Config{
 		StatePool:            pool,
 		Hub:                  hub,
@@ -118,6 +133,8 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		WatcherFactory:       factory.WatchController,
 		PrometheusRegisterer: config.PrometheusRegisterer,
 		Cleanup:              func() { _ = stTracker.Done() },
+		EvictionRetention:    controllerConfig.ModelCacheEvictionRetention(),
+		MaxResidents:         controllerConfig.ModelCacheMaxResidents(),
 	}.WithDefaultRestartStrategy())
 	if err != nil {
 		_ = stTracker.Done()
@@ -126,6 +143,11 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	return w, nil
 }
 
+// GetControllerConfig gets the controller config from the input state.
+func GetControllerConfig(st *state.State) (controller.Config, error) {
+	return st.ControllerConfig()
+}
+
 // ExtractCacheController extracts a *cache.Controller from a *cacheWorker.
 func ExtractCacheController(in worker.Worker, out interface{}) error {
 	inWorker, _ := in.(*cacheWorker)
@@ -4,6 +4,8 @@
 package modelcache_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/pubsub"
@@ -14,6 +16,7 @@ import (
 	dt "github.com/juju/worker/v2/dependency/testing"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/gate"
@@ -37,6 +40,12 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		InitializedGateName:  "initialized-gate",
 		Logger:               loggo.GetLogger("test"),
 		PrometheusRegisterer: noopRegisterer{},
+		GetControllerConfig: func(*state.State) (controller.Config, error) {
+			return controller.Config{
+				controller.ModelCacheEvictionRetention: time.Hour,
+				controller.ModelCacheMaxResidents:      5000,
+			}, nil
+		},
 		NewWorker: func(modelcache.Config) (worker.Worker, error) {
 			return nil, errors.New("boom")
 		},
@@ -99,6 +108,13 @@ func (s *ManifoldSuite) TestConfigValidationMissingLogger(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "missing Logger not valid")
 }
 
+func (s *ManifoldSuite) TestConfigValidationMissingGetControllerConfig(c *gc.C) {
+	s.config.GetControllerConfig = nil
+	err := s.config.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "missing GetControllerConfig func not valid")
+}
+
 func (s *ManifoldSuite) TestConfigValidationMissingNewWorker(c *gc.C) {
 	s.config.NewWorker = nil
 	err := s.config.Validate()
@@ -191,12 +207,32 @@ func (s *ManifoldSuite) TestNewWorkerArgs(c *gc.C) {
 	c.Check(config.WatcherFactory, gc.NotNil)
 	c.Check(config.Logger, gc.Equals, s.config.Logger)
 	c.Check(config.PrometheusRegisterer, gc.Equals, s.config.PrometheusRegisterer)
+	c.Check(config.EvictionRetention, gc.Equals, time.Hour)
+	c.Check(config.MaxResidents, gc.Equals, 5000)
 
 	c.Check(tracker.released, jc.IsFalse)
 	config.Cleanup()
 	c.Check(tracker.released, jc.IsTrue)
 }
 
+func (s *ManifoldSuite) TestControllerConfigErrorReleasesState(c *gc.C) {
+	s.config.GetControllerConfig = func(*state.State) (controller.Config, error) {
+		return nil, errors.New("boom")
+	}
+	tracker := &fakeStateTracker{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"state":            tracker,
+		"central-hub":      pubsub.NewStructuredHub(nil),
+		"multiwatcher":     &fakeMultwatcherFactory{},
+		"initialized-gate": gate.NewLock(),
+	})
+
+	worker, err := s.manifold().Start(context)
+	c.Check(err, gc.ErrorMatches, "unable to get controller config: boom")
+	c.Check(worker, gc.IsNil)
+	c.Check(tracker.released, jc.IsTrue)
+}
+
 func (s *ManifoldSuite) TestNewWorkerErrorReleasesState(c *gc.C) {
 	tracker := &fakeStateTracker{}
 	context := dt.StubContext(nil, map[string]interface{}{
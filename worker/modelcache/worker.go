@@ -33,6 +33,9 @@ type Clock interface {
 	// After waits for the duration to elapse and then sends the
 	// current time on the returned channel.
 	After(time.Duration) <-chan time.Time
+
+	// Now returns the current clock time.
+	Now() time.Time
 }
 
 // Hub defines the methods of the apiserver centralhub that the peer
@@ -73,7 +76,17 @@ type Config struct {
 	// error.
 	WatcherRestartDelayMax time.Duration
 
-	// Clock is used to enforce watcher restart delays.
+	// EvictionRetention is how long the cache retains dead machines and
+	// completed branches before evicting them. Zero disables eviction.
+	EvictionRetention time.Duration
+
+	// MaxResidents is a soft limit on the number of entities in the
+	// cache, above which the least recently accessed are evicted.
+	// Zero disables the limit.
+	MaxResidents int
+
+	// Clock is used to enforce watcher restart delays,
+	// and to schedule the eviction of finished cache entities.
 	Clock Clock
 }
 
@@ -144,8 +157,12 @@ func NewWorker(config Config) (worker.Worker, error) {
 	}
 	controller, err := cache.NewController(
 		cache.ControllerConfig{
-			Changes: w.changes,
-			Notify:  config.Notify,
+			Changes:              w.changes,
+			Notify:               config.Notify,
+			EvictionRetention:    config.EvictionRetention,
+			MaxResidents:         config.MaxResidents,
+			Clock:                config.Clock,
+			PrometheusRegisterer: config.PrometheusRegisterer,
 		})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -352,7 +369,9 @@ func (c *cacheWorker) handleWatcherErr(err error) {
 	}
 
 	// For any other errors close the watcher, which will cause us
-	// to create a new one after the restart delay.
+	// to create a new one after the restart delay. Until the cache
+	// is refreshed by the new watcher, it may be stale.
+	c.controller.SetDegraded(err)
 	select {
 	case <-c.catacomb.Dying():
 		return
@@ -542,6 +561,10 @@ func (c *cacheWorker) translateUnit(d multiwatcher.Delta) interface{} {
 		WorkloadStatus:  coreStatus(value.WorkloadStatus),
 		AgentStatus:     coreStatus(value.AgentStatus),
 		ContainerStatus: coreStatus(value.ContainerStatus),
+
+		ProviderId:       value.ProviderID,
+		ContainerAddress: value.ContainerAddress,
+		ContainerPorts:   value.ContainerPorts,
 	}
 }
 
@@ -578,6 +601,8 @@ func (c *cacheWorker) translateRelation(d multiwatcher.Delta) interface{} {
 	return cache.RelationChange{
 		ModelUUID: value.ModelUUID,
 		Key:       value.Key,
+		Id:        value.ID,
+		Life:      value.Life,
 		Endpoints: endpoints,
 	}
 }
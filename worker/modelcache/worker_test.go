@@ -570,6 +570,53 @@ func (s *WorkerSuite) TestAddUnit(c *gc.C) {
 	c.Check(cachedApp, gc.NotNil)
 }
 
+func (s *WorkerSuite) TestUnitCloudContainer(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.UnitEvents)
+	w := s.start(c)
+
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer func() { _ = st.Close() }()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "gitlab", Charm: ch})
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	providerId, address := "gitlab-0", "10.0.0.1"
+	err = app.UpdateUnits(&state.UpdateUnitsOperation{
+		Updates: []*state.UpdateUnitOperation{unit.UpdateOperation(state.UnitUpdateProperties{
+			ProviderId: &providerId,
+			Address:    &address,
+			Ports:      &[]string{"80/TCP"},
+		})},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+
+	// The cloud container details reach the
+	// cache via the allwatcher's unit info.
+	for {
+		change := s.nextChange(c, changes)
+		obtained, ok := change.(cache.UnitChange)
+		if !ok || obtained.Name != unit.Name() || obtained.ProviderId == "" {
+			continue
+		}
+		c.Check(obtained.ProviderId, gc.Equals, "gitlab-0")
+		c.Check(obtained.ContainerAddress, gc.Equals, "10.0.0.1")
+		c.Check(obtained.ContainerPorts, jc.DeepEquals, []string{"80/TCP"})
+		break
+	}
+
+	mod, err := s.getController(c, w).Model(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	cachedUnit, err := mod.Unit(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	container := cachedUnit.CloudContainer()
+	c.Check(container.ProviderId, gc.Equals, "gitlab-0")
+	c.Check(container.Address, gc.Equals, "10.0.0.1")
+	c.Check(container.Ports, jc.DeepEquals, []string{"80/TCP"})
+}
+
 func (s *WorkerSuite) TestRemoveUnit(c *gc.C) {
 	changes := s.captureEvents(c, cachetest.UnitEvents)
 	w := s.start(c)
@@ -599,6 +646,63 @@ func (s *WorkerSuite) TestRemoveUnit(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestAddRelation(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.RelationEvents)
+	w := s.start(c)
+
+	relation := s.Factory.MakeRelation(c, nil)
+	s.State.StartSync()
+
+	change := s.nextChange(c, changes)
+	obtained, ok := change.(cache.RelationChange)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obtained.Key, gc.Equals, relation.String())
+	c.Check(obtained.Id, gc.Equals, relation.Id())
+	c.Check(obtained.Life, gc.Equals, life.Alive)
+	c.Check(obtained.Endpoints, gc.HasLen, 2)
+
+	controller := s.getController(c, w)
+	modUUIDs := controller.ModelUUIDs()
+	c.Check(modUUIDs, gc.HasLen, 1)
+
+	mod, err := controller.Model(modUUIDs[0])
+	c.Assert(err, jc.ErrorIsNil)
+
+	cachedRelation, err := mod.Relation(relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cachedRelation.Id(), gc.Equals, relation.Id())
+}
+
+func (s *WorkerSuite) TestRemoveRelation(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.RelationEvents)
+	w := s.start(c)
+
+	relation := s.Factory.MakeRelation(c, nil)
+	s.State.StartSync()
+	_ = s.nextChange(c, changes)
+
+	controller := s.getController(c, w)
+	modUUID := controller.ModelUUIDs()[0]
+
+	// A relation without units is removed when destroyed.
+	c.Assert(relation.Destroy(), jc.ErrorIsNil)
+	s.State.StartSync()
+
+	// We will either get our relation event,
+	// or time-out after processing all the changes.
+	for {
+		change := s.nextChange(c, changes)
+		if _, ok := change.(cache.RemoveRelation); ok {
+			mod, err := controller.Model(modUUID)
+			c.Assert(err, jc.ErrorIsNil)
+
+			_, err = mod.Relation(relation.String())
+			c.Check(errors.IsNotFound(err), jc.IsTrue)
+			return
+		}
+	}
+}
+
 func (s *WorkerSuite) TestAddBranch(c *gc.C) {
 	changes := s.captureEvents(c, cachetest.BranchEvents)
 	w := s.start(c)
@@ -641,7 +745,7 @@ func (s *WorkerSuite) TestRemoveBranch(c *gc.C) {
 	// Generation docs are not deleted from the DB in any current workflow.
 	// Committing the branch so that it is no longer active should cause
 	// a removal message to be emitted.
-	_, err = branch.Commit("test-user")
+	_, err = branch.Commit("test-user", false, "")
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.State.StartSync()
@@ -788,6 +892,55 @@ func (s *WorkerSuite) TestWatcherErrorRestartBackoff(c *gc.C) {
 	_ = s.nextChange(c, changes)
 }
 
+func (s *WorkerSuite) TestWatcherErrorDegradesCache(c *gc.C) {
+	clk := testclock.NewClock(time.Now())
+	s.config.WatcherRestartDelayMin = time.Second
+	s.config.WatcherRestartDelayMax = time.Second
+	s.config.Clock = clk
+
+	errorSent := false
+	s.config.WatcherFactory = func() multiwatcher.Watcher {
+		return testingMultiwatcher{
+			Watcher: s.mwFactory.WatchController(),
+			manipulate: func(deltas []multiwatcher.Delta) ([]multiwatcher.Delta, error) {
+				if !errorSent {
+					errorSent = true
+					return nil, errors.New("boom")
+				}
+				return deltas, nil
+			},
+		}
+	}
+
+	changes := s.captureEvents(c, cachetest.ModelEvents)
+	w := s.start(c)
+	s.State.StartSync()
+	controller := s.getController(c, w)
+
+	// The cache is degraded while the watcher restart is pending.
+	select {
+	case <-clk.Alarms():
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for watcher restart")
+	}
+	c.Check(controller.Health(), gc.Equals, cache.Health{
+		Status:    cache.HealthDegraded,
+		LastError: "boom",
+	})
+
+	// It recovers once the new watcher's changes are swept.
+	c.Assert(clk.WaitAdvance(time.Second, testing.LongWait, 1), jc.ErrorIsNil)
+	_ = s.nextChange(c, changes)
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		if controller.Health().Status == cache.HealthSynced {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("cache not synced: %+v", controller.Health())
+		}
+	}
+}
+
 func (s *WorkerSuite) TestWatcherErrorStoppedKillsWorker(c *gc.C) {
 	mw := s.mwFactory.WatchController()
 	s.config.WatcherFactory = func() multiwatcher.Watcher { return mw }
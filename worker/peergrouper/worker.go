@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/clock"
@@ -135,6 +136,11 @@ type pgWorker struct {
 	// It is used to detect changes since the last publish.
 	serverDetails apiserver.Details
 
+	// boundAddresses holds the addresses that each API server has
+	// reported it is bound to, keyed by machine tag.
+	boundAddressesMu sync.Mutex
+	boundAddresses   map[string]apiserver.BoundAddresses
+
 	metrics *Collector
 
 	idleFunc func()
@@ -207,6 +213,7 @@ func New(config Config) (worker.Worker, error) {
 		controllerChanges:  make(chan struct{}),
 		controllerTrackers: make(map[string]*controllerTracker),
 		detailsRequests:    make(chan string),
+		boundAddresses:     make(map[string]apiserver.BoundAddresses),
 		idleFunc:           IdleFunc,
 		metrics:            NewMetricsCollector(),
 	}
@@ -232,7 +239,17 @@ func (w *pgWorker) Wait() error {
 
 // Report is shown in the engine report.
 func (w *pgWorker) Report() map[string]interface{} {
-	return w.metrics.report()
+	result := w.metrics.report()
+	w.boundAddressesMu.Lock()
+	defer w.boundAddressesMu.Unlock()
+	if len(w.boundAddresses) > 0 {
+		bound := make(map[string]interface{})
+		for tag, details := range w.boundAddresses {
+			bound[tag] = details.Addresses
+		}
+		result["bound-addresses"] = bound
+	}
+	return result
 }
 
 func (w *pgWorker) loop() error {
@@ -255,6 +272,12 @@ func (w *pgWorker) loop() error {
 	}
 	defer unsubscribe()
 
+	unsubscribeBound, err := w.config.Hub.Subscribe(apiserver.BoundAddressesTopic, w.apiserverBoundAddresses)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer unsubscribeBound()
+
 	var updateChan <-chan time.Time
 	retryInterval := initialRetryInterval
 
@@ -515,6 +538,20 @@ func (w *pgWorker) apiserverDetailsRequested(topic string, request apiserver.Det
 	}
 }
 
+// apiserverBoundAddresses records the addresses that an
+// API server has published it is listening on.
+func (w *pgWorker) apiserverBoundAddresses(topic string, details apiserver.BoundAddresses, err error) {
+	if err != nil {
+		// This shouldn't happen (barring programmer error ;) - treat it as fatal.
+		w.catacomb.Kill(errors.Annotate(err, "apiserver bound addresses callback failed"))
+		return
+	}
+	logger.Debugf("API server %q bound to %v", details.MachineTag, details.Addresses)
+	w.boundAddressesMu.Lock()
+	w.boundAddresses[details.MachineTag] = details
+	w.boundAddressesMu.Unlock()
+}
+
 func inStrings(t string, ss []string) bool {
 	for _, s := range ss {
 		if s == t {
@@ -554,6 +554,36 @@ func (s *workerSuite) TestControllersArePublishedOverHub(c *gc.C) {
 	}
 }
 
+func (s *workerSuite) TestBoundAddressesAreReported(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+
+	hub := pubsub.NewStructuredHub(nil)
+	s.hub = hub
+
+	w := s.newWorker(c, st, st.session, nopAPIHostPortsSetter{}, true)
+	defer workertest.CleanKill(c, w)
+	reporter, ok := w.(interface{ Report() map[string]interface{} })
+	c.Assert(ok, jc.IsTrue)
+
+	// Publish until the worker has subscribed and recorded the details.
+	expected := map[string]interface{}{
+		"machine-10": []string{"[::]:5678"},
+	}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		_, err := hub.Publish(apiserver.BoundAddressesTopic, apiserver.BoundAddresses{
+			MachineTag: "machine-10",
+			Addresses:  []string{"[::]:5678"},
+			Ports:      []int{5678},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		if _, ok := reporter.Report()["bound-addresses"]; ok {
+			break
+		}
+	}
+	c.Check(reporter.Report()["bound-addresses"], jc.DeepEquals, expected)
+}
+
 func (s *workerSuite) TestControllersPublishedWithControllerAPIPort(c *gc.C) {
 	st := NewFakeState()
 	InitState(c, st, 3, testIPv4)
@@ -1096,9 +1096,10 @@ func (task *provisionerTask) startMachine(
 		if err == nil {
 			result = attemptResult
 			break
-		} else if attemptsLeft <= 0 {
+		} else if attemptsLeft <= 0 || environs.IsQuotaExceeded(err) {
 			// Set the state to error, so the machine will be skipped
-			// next time until the error is resolved.
+			// next time until the error is resolved. Retrying is
+			// pointless if the model's resource quota is exhausted.
 			task.removeMachineFromAZMap(machine)
 			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
 		} else {
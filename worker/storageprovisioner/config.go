@@ -30,6 +30,7 @@ type Config struct {
 	Filesystems      FilesystemAccessor
 	Life             LifecycleManager
 	Registry         storage.ProviderRegistry
+	Plans            PlanRegistry
 	Machines         MachineAccessor
 	Status           StatusSetter
 	Clock            clock.Clock
@@ -53,6 +54,9 @@ func (config Config) Validate() error {
 		if config.Machines == nil {
 			return errors.NotValidf("nil Machines")
 		}
+		if config.Plans == nil {
+			return errors.NotValidf("machine Scope with nil Plans")
+		}
 	case names.ApplicationTag:
 		if config.StorageDir != "" {
 			return errors.NotValidf("application Scope with StorageDir")
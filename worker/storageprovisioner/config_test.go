@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/storageprovisioner"
 )
@@ -92,6 +93,12 @@ func (s *ConfigSuite) TestNilMachines(c *gc.C) {
 	s.checkNotValid(c, "nil Machines not valid")
 }
 
+func (s *ConfigSuite) TestMachineScopeNilPlans(c *gc.C) {
+	s.config = validMachineConfig()
+	s.config.Plans = nil
+	s.checkNotValid(c, "machine Scope with nil Plans not valid")
+}
+
 func (s *ConfigSuite) TestNilStatus(c *gc.C) {
 	s.config.Status = nil
 	s.checkNotValid(c, "nil Status not valid")
@@ -123,6 +130,7 @@ func validMachineConfig() storageprovisioner.Config {
 	config := almostValidConfig()
 	config.Scope = names.NewMachineTag("123/lxd/7")
 	config.StorageDir = "storage-dir"
+	config.Plans = plans.Registry{}
 	return config
 }
 
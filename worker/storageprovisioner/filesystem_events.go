@@ -145,7 +145,7 @@ func updatePendingFilesystem(ctx *context, params storage.FilesystemParams) {
 
 func removePendingFilesystem(ctx *context, tag names.FilesystemTag) {
 	delete(ctx.incompleteFilesystemParams, tag)
-	ctx.schedule.Remove(tag)
+	unscheduleOperation(ctx, tag)
 }
 
 // updatePendingFilesystemAttachment adds the given filesystem attachment params to
@@ -193,7 +193,7 @@ func updatePendingFilesystemAttachment(
 // there.
 func removePendingFilesystemAttachment(ctx *context, id params.MachineStorageId) {
 	delete(ctx.incompleteFilesystemAttachmentParams, id)
-	ctx.schedule.Remove(id)
+	unscheduleOperation(ctx, id)
 }
 
 // processDeadFilesystems processes the FilesystemResults for Dead filesystems,
@@ -26,6 +26,7 @@ type MachineManifoldConfig struct {
 	APICallerName                string
 	Clock                        clock.Clock
 	Logger                       Logger
+	Plans                        PlanRegistry
 	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
 }
 
@@ -36,6 +37,9 @@ func (config MachineManifoldConfig) newWorker(a agent.Agent, apiCaller base.APIC
 	if config.Logger == nil {
 		return nil, errors.NotValidf("missing Logger")
 	}
+	if config.Plans == nil {
+		return nil, errors.NotValidf("missing Plans")
+	}
 	cfg := a.CurrentConfig()
 	api, err := storageprovisioner.NewState(apiCaller)
 	if err != nil {
@@ -60,6 +64,7 @@ func (config MachineManifoldConfig) newWorker(a agent.Agent, apiCaller base.APIC
 		Filesystems:      api,
 		Life:             api,
 		Registry:         provider.CommonStorageProviders(),
+		Plans:            config.Plans,
 		Machines:         api,
 		Status:           api,
 		Clock:            config.Clock,
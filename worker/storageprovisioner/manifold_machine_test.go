@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/jujud/agent/engine/enginetest"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/storage/plans"
 	"github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/storageprovisioner"
 )
@@ -49,6 +50,7 @@ func (s *MachineManifoldSuite) SetUpTest(c *gc.C) {
 		APICallerName:                config.APICallerName,
 		Clock:                        testclock.NewClock(defaultClockStart),
 		Logger:                       loggo.GetLogger("test"),
+		Plans:                        plans.Registry{},
 		NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 	}
 }
@@ -84,6 +86,17 @@ func (s *MachineManifoldSuite) TestMissingLogger(c *gc.C) {
 	c.Assert(s.newCalled, jc.IsFalse)
 }
 
+func (s *MachineManifoldSuite) TestMissingPlans(c *gc.C) {
+	s.config.Plans = nil
+	_, err := enginetest.RunAgentAPIManifold(
+		storageprovisioner.MachineManifold(s.config),
+		&fakeAgent{tag: names.NewMachineTag("42")},
+		&fakeAPIConn{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err.Error(), gc.Equals, "missing Plans not valid")
+	c.Assert(s.newCalled, jc.IsFalse)
+}
+
 func (s *MachineManifoldSuite) TestNonAgent(c *gc.C) {
 	_, err := enginetest.RunAgentAPIManifold(
 		storageprovisioner.MachineManifold(s.config),
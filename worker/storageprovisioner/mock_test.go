@@ -145,9 +145,11 @@ type mockVolumeAccessor struct {
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
 
-	setVolumeInfo               func([]params.Volume) ([]params.ErrorResult, error)
-	setVolumeAttachmentInfo     func([]params.VolumeAttachment) ([]params.ErrorResult, error)
-	createVolumeAttachmentPlans func([]params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
+	setVolumeInfo                    func([]params.Volume) ([]params.ErrorResult, error)
+	setVolumeAttachmentInfo          func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	createVolumeAttachmentPlans      func([]params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
+	setVolumeAttachmentPlanBlockInfo func([]params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
+	volumeAttachmentPlans            func([]params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -310,10 +312,16 @@ func (v *mockVolumeAccessor) RemoveVolumeAttachmentPlan(machineIds []params.Mach
 }
 
 func (v *mockVolumeAccessor) SetVolumeAttachmentPlanBlockInfo(volumeAttachmentPlans []params.VolumeAttachmentPlan) ([]params.ErrorResult, error) {
+	if v.setVolumeAttachmentPlanBlockInfo != nil {
+		return v.setVolumeAttachmentPlanBlockInfo(volumeAttachmentPlans)
+	}
 	return make([]params.ErrorResult, len(volumeAttachmentPlans)), nil
 }
 
-func (v *mockVolumeAccessor) VolumeAttachmentPlans([]params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error) {
+func (v *mockVolumeAccessor) VolumeAttachmentPlans(ids []params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error) {
+	if v.volumeAttachmentPlans != nil {
+		return v.volumeAttachmentPlans(ids)
+	}
 	return []params.VolumeAttachmentPlanResult{}, nil
 }
 
@@ -915,3 +923,15 @@ func (m *mockStatusSetter) SetStatus(args []params.EntityStatusArgs) error {
 	m.args = append(m.args, args...)
 	return nil
 }
+
+type mockPlan struct {
+	attachVolume func(map[string]string) (storage.BlockDevice, error)
+}
+
+func (p *mockPlan) AttachVolume(volumeInfo map[string]string) (storage.BlockDevice, error) {
+	return p.attachVolume(volumeInfo)
+}
+
+func (p *mockPlan) DetachVolume(map[string]string) error {
+	return nil
+}
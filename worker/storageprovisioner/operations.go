@@ -0,0 +1,162 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Operation describes a storage operation that is
+// queued or in progress in a storage provisioner.
+type Operation struct {
+	// Kind is the kind of operation, e.g. "create-volume".
+	Kind string
+
+	// Target is the tag of the volume or filesystem the operation
+	// acts on, or for attachments, the tag of the attached volume or
+	// filesystem and the tag of the machine or unit it is attached to,
+	// separated by a colon.
+	Target string
+
+	// Age is the time since the operation was first scheduled.
+	// Retries of a failed operation do not reset its age.
+	Age time.Duration
+
+	// InProgress is true if the operation is being executed,
+	// and false if it is queued.
+	InProgress bool
+}
+
+// operationTracker records the operations that are queued in the
+// schedule or being executed, so that they can be reported while
+// the worker's loop is running.
+type operationTracker struct {
+	mu  sync.Mutex
+	ops map[interface{}]*trackedOperation
+}
+
+type trackedOperation struct {
+	kind       string
+	target     string
+	scheduled  time.Time
+	inProgress bool
+}
+
+func newOperationTracker() *operationTracker {
+	return &operationTracker{
+		ops: make(map[interface{}]*trackedOperation),
+	}
+}
+
+// queued records that the operation was added to the schedule.
+// An operation rescheduled while in progress keeps its age.
+func (t *operationTracker) queued(op scheduleOp, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tracked, ok := t.ops[op.key()]; ok {
+		tracked.inProgress = false
+		return
+	}
+	kind, target := describeOperation(op)
+	t.ops[op.key()] = &trackedOperation{
+		kind:      kind,
+		target:    target,
+		scheduled: now,
+	}
+}
+
+// removed records that the operation with the
+// input key was removed from the schedule.
+func (t *operationTracker) removed(key interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.ops, key)
+}
+
+// started records that the input operations,
+// taken from the schedule, are being executed.
+func (t *operationTracker) started(ops []interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, op := range ops {
+		if tracked, ok := t.ops[op.(scheduleOp).key()]; ok {
+			tracked.inProgress = true
+		}
+	}
+}
+
+// finished records that the input operations have been executed.
+// Those that were rescheduled while executing remain queued.
+func (t *operationTracker) finished(ops []interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, op := range ops {
+		key := op.(scheduleOp).key()
+		if tracked, ok := t.ops[key]; ok && tracked.inProgress {
+			delete(t.ops, key)
+		}
+	}
+}
+
+// operations returns the tracked operations,
+// ordered by target and then kind.
+func (t *operationTracker) operations(now time.Time) []Operation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]Operation, 0, len(t.ops))
+	for _, tracked := range t.ops {
+		result = append(result, Operation{
+			Kind:       tracked.kind,
+			Target:     tracked.target,
+			Age:        now.Sub(tracked.scheduled),
+			InProgress: tracked.inProgress,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Target != result[j].Target {
+			return result[i].Target < result[j].Target
+		}
+		return result[i].Kind < result[j].Kind
+	})
+	return result
+}
+
+// describeOperation returns the kind and target of the input operation.
+func describeOperation(op scheduleOp) (kind, target string) {
+	switch op.(type) {
+	case *createVolumeOp:
+		kind = "create-volume"
+	case *removeVolumeOp:
+		kind = "remove-volume"
+	case *attachVolumeOp:
+		kind = "attach-volume"
+	case *attachVolumePlanOp:
+		kind = "attach-volume-plan"
+	case *detachVolumeOp:
+		kind = "detach-volume"
+	case *createFilesystemOp:
+		kind = "create-filesystem"
+	case *removeFilesystemOp:
+		kind = "remove-filesystem"
+	case *attachFilesystemOp:
+		kind = "attach-filesystem"
+	case *detachFilesystemOp:
+		kind = "detach-filesystem"
+	}
+	switch key := op.key().(type) {
+	case names.Tag:
+		target = key.String()
+	case params.MachineStorageId:
+		target = key.AttachmentTag + ":" + key.MachineTag
+	case volumeAttachmentPlanId:
+		target = key.AttachmentTag + ":" + key.MachineTag
+	}
+	return kind, target
+}
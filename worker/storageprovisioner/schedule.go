@@ -30,9 +30,17 @@ func scheduleOperations(ctx *context, ops ...scheduleOp) {
 		k := op.key()
 		d := op.delay()
 		ctx.schedule.Add(k, op, now.Add(d))
+		ctx.operations.queued(op, now)
 	}
 }
 
+// unscheduleOperation removes the operation
+// with the given key from the schedule.
+func unscheduleOperation(ctx *context, key interface{}) {
+	ctx.schedule.Remove(key)
+	ctx.operations.removed(key)
+}
+
 // scheduleOp is an interface implemented by schedule
 // operations.
 type scheduleOp interface {
@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans/common"
 	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/worker/storageprovisioner/internal/schedule"
 )
@@ -174,6 +175,15 @@ type StatusSetter interface {
 	SetStatus([]params.EntityStatusArgs) error
 }
 
+// PlanRegistry defines an interface used to obtain the plans that perform
+// the host-side steps of volume attachments, such as logging into an iSCSI
+// target, for each type of attached device.
+type PlanRegistry interface {
+	// PlanByType returns the plan for the specified device type,
+	// or a NotFound error if there is none.
+	PlanByType(storage.DeviceType) (common.Plan, error)
+}
+
 // NewStorageProvisioner returns a Worker which manages
 // provisioning (deprovisioning), and attachment (detachment)
 // of first-class volumes and filesystems.
@@ -187,7 +197,8 @@ var NewStorageProvisioner = func(config Config) (worker.Worker, error) {
 		return nil, errors.Trace(err)
 	}
 	w := &storageProvisioner{
-		config: config,
+		config:     config,
+		operations: newOperationTracker(),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -200,8 +211,33 @@ var NewStorageProvisioner = func(config Config) (worker.Worker, error) {
 }
 
 type storageProvisioner struct {
-	catacomb catacomb.Catacomb
-	config   Config
+	catacomb   catacomb.Catacomb
+	config     Config
+	operations *operationTracker
+}
+
+// Operations returns the storage operations that are queued or in
+// progress, ordered by target and then kind. It is intended to help
+// diagnose provisioning that is not progressing.
+func (w *storageProvisioner) Operations() []Operation {
+	return w.operations.operations(w.config.Clock.Now())
+}
+
+// Report is part of the dependency.Reporter interface.
+func (w *storageProvisioner) Report() map[string]interface{} {
+	ops := w.Operations()
+	reported := make([]map[string]interface{}, len(ops))
+	for i, op := range ops {
+		reported[i] = map[string]interface{}{
+			"kind":        op.Kind,
+			"target":      op.Target,
+			"age":         op.Age.String(),
+			"in-progress": op.InProgress,
+		}
+	}
+	return map[string]interface{}{
+		"operations": reported,
+	}
 }
 
 // Kill implements Worker.Kill().
@@ -261,6 +297,7 @@ func (w *storageProvisioner) loop() error {
 		machines:                             make(map[names.MachineTag]*machineWatcher),
 		machineChanges:                       machineChanges,
 		schedule:                             schedule.NewSchedule(w.config.Clock),
+		operations:                           w.operations,
 		incompleteVolumeParams:               make(map[names.VolumeTag]storage.VolumeParams),
 		incompleteVolumeAttachmentParams:     make(map[params.MachineStorageId]storage.VolumeAttachmentParams),
 		incompleteFilesystemParams:           make(map[names.FilesystemTag]storage.FilesystemParams),
@@ -383,9 +420,12 @@ func (w *storageProvisioner) loop() error {
 // processSchedule executes scheduled operations.
 func processSchedule(ctx *context) error {
 	ready := ctx.schedule.Ready(ctx.config.Clock.Now())
+	ctx.operations.started(ready)
+	defer ctx.operations.finished(ready)
 	createVolumeOps := make(map[names.VolumeTag]*createVolumeOp)
 	removeVolumeOps := make(map[names.VolumeTag]*removeVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
+	var attachVolumePlanOps []*attachVolumePlanOp
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	removeFilesystemOps := make(map[names.FilesystemTag]*removeFilesystemOp)
@@ -401,6 +441,8 @@ func processSchedule(ctx *context) error {
 			removeVolumeOps[key.(names.VolumeTag)] = op
 		case *attachVolumeOp:
 			attachVolumeOps[key.(params.MachineStorageId)] = op
+		case *attachVolumePlanOp:
+			attachVolumePlanOps = append(attachVolumePlanOps, op)
 		case *detachVolumeOp:
 			detachVolumeOps[key.(params.MachineStorageId)] = op
 		case *createFilesystemOp:
@@ -433,6 +475,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(attachVolumePlanOps) > 0 {
+		if err := attachVolumePlans(ctx, attachVolumePlanOps); err != nil {
+			return errors.Annotate(err, "processing volume attachment plans")
+		}
+	}
 	if len(removeFilesystemOps) > 0 {
 		if err := removeFilesystems(ctx, removeFilesystemOps); err != nil {
 			return errors.Annotate(err, "removing filesystems")
@@ -488,6 +535,10 @@ type context struct {
 	// schedule is the schedule of storage operations.
 	schedule *schedule.Schedule
 
+	// operations records the operations that are
+	// scheduled or in progress, for introspection.
+	operations *operationTracker
+
 	// incompleteVolumeParams contains incomplete parameters for volumes.
 	//
 	// Volume parameters are incomplete when they lack information about
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/storageprovisioner"
 )
@@ -283,6 +285,61 @@ func (s *storageProvisionerSuite) TestCreateVolumeRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestOperations(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+
+	started := make(chan interface{})
+	release := make(chan struct{})
+	s.provider.createVolumesFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		close(started)
+		<-release
+		return []storage.CreateVolumesResult{{Error: errors.New("badness")}}, nil
+	}
+
+	clock := testclock.NewClock(time.Time{})
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	w := newStorageProvisioner(c, args)
+	defer func() { c.Assert(w.Wait(), gc.IsNil) }()
+	defer w.Kill()
+	reporter, ok := w.(interface {
+		Operations() []storageprovisioner.Operation
+	})
+	c.Assert(ok, jc.IsTrue)
+	c.Check(reporter.Operations(), gc.HasLen, 0)
+
+	volumeAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "volume-1",
+	}}
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+
+	// The volume creation is in progress until the provider returns.
+	waitChannel(c, started, "waiting for volume creation")
+	clock.Advance(10 * time.Second)
+	c.Check(reporter.Operations(), jc.DeepEquals, []storageprovisioner.Operation{{
+		Kind:       "create-volume",
+		Target:     "volume-1",
+		Age:        10 * time.Second,
+		InProgress: true,
+	}})
+
+	// The failed creation is queued for retry, keeping its age.
+	close(release)
+	expected := []storageprovisioner.Operation{{
+		Kind:   "create-volume",
+		Target: "volume-1",
+		Age:    10 * time.Second,
+	}}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		ops := reporter.Operations()
+		if len(ops) == 1 && !ops[0].InProgress {
+			c.Check(ops, jc.DeepEquals, expected)
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for volume creation to be queued")
+}
+
 func (s *storageProvisionerSuite) TestCreateFilesystemRetry(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
@@ -434,6 +491,72 @@ func (s *storageProvisionerSuite) TestAttachVolumeRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestAttachVolumePlanRetry(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeAttachmentPlans = func(ids []params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error) {
+		c.Assert(ids, jc.DeepEquals, []params.MachineStorageId{{
+			MachineTag: "machine-1", AttachmentTag: "volume-1",
+		}})
+		return []params.VolumeAttachmentPlanResult{{
+			Result: params.VolumeAttachmentPlan{
+				MachineTag: "machine-1",
+				VolumeTag:  "volume-1",
+				Life:       life.Alive,
+				PlanInfo: params.VolumeAttachmentPlanInfo{
+					DeviceType:       storage.DeviceTypeISCSI,
+					DeviceAttributes: map[string]string{"iqn": "iqn.2021-01.com.example:target"},
+				},
+			},
+		}}, nil
+	}
+	blockInfoSet := make(chan interface{})
+	volumeAccessor.setVolumeAttachmentPlanBlockInfo = func(volumeAttachmentPlans []params.VolumeAttachmentPlan) ([]params.ErrorResult, error) {
+		defer close(blockInfoSet)
+		c.Assert(volumeAttachmentPlans, gc.HasLen, 1)
+		c.Assert(volumeAttachmentPlans[0].BlockDevice, jc.DeepEquals, storage.BlockDevice{DeviceName: "sdb"})
+		return make([]params.ErrorResult, len(volumeAttachmentPlans)), nil
+	}
+
+	// mockFunc's After will progress the current time by the specified
+	// duration and signal the channel immediately.
+	clock := &mockClock{}
+	var attachTimes []time.Time
+	plan := &mockPlan{
+		attachVolume: func(volumeInfo map[string]string) (storage.BlockDevice, error) {
+			c.Assert(volumeInfo, jc.DeepEquals, map[string]string{"iqn": "iqn.2021-01.com.example:target"})
+			attachTimes = append(attachTimes, clock.Now())
+			if len(attachTimes) < 3 {
+				return storage.BlockDevice{}, errors.New("iscsi login failed")
+			}
+			return storage.BlockDevice{DeviceName: "sdb"}, nil
+		},
+	}
+
+	args := &workerArgs{
+		scope:    names.NewMachineTag("1"),
+		volumes:  volumeAccessor,
+		clock:    clock,
+		registry: s.registry,
+		plans:    plans.Registry{storage.DeviceTypeISCSI: plan},
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentPlansWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "volume-1",
+	}}
+	waitChannel(c, blockInfoSet, "waiting for volume attachment plan block info to be set")
+
+	c.Assert(attachTimes, gc.HasLen, 3)
+	c.Assert(attachTimes[1].Sub(attachTimes[0]), gc.Equals, 30*time.Second)
+	c.Assert(attachTimes[2].Sub(attachTimes[1]), gc.Equals, time.Minute)
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "attaching", Info: "iscsi login failed"},
+		{Tag: "volume-1", Status: "attaching", Info: "iscsi login failed"},
+	})
+}
+
 func (s *storageProvisionerSuite) TestAttachFilesystemRetry(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
@@ -2088,6 +2211,9 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args.statusSetter == nil {
 		args.statusSetter = &mockStatusSetter{}
 	}
+	if args.plans == nil {
+		args.plans = plans.Registry{}
+	}
 	worker, err := storageprovisioner.NewStorageProvisioner(storageprovisioner.Config{
 		Scope:            args.scope,
 		StorageDir:       storageDir,
@@ -2095,6 +2221,7 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 		Filesystems:      args.filesystems,
 		Life:             args.life,
 		Registry:         args.registry,
+		Plans:            args.plans,
 		Machines:         args.machines,
 		Status:           args.statusSetter,
 		Clock:            args.clock,
@@ -2111,6 +2238,7 @@ type workerArgs struct {
 	filesystems  *mockFilesystemAccessor
 	life         *mockLifecycleManager
 	registry     storage.ProviderRegistry
+	plans        storageprovisioner.PlanRegistry
 	machines     *mockMachineAccessor
 	clock        clock.Clock
 	statusSetter *mockStatusSetter
@@ -12,7 +12,6 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/storage"
-	"github.com/juju/juju/storage/plans"
 	"github.com/juju/juju/wrench"
 )
 
@@ -86,9 +85,7 @@ func volumeAttachmentPlansChanged(ctx *context, watcherIds []watcher.MachineStor
 	}
 	ctx.config.Logger.Debugf("volume attachment plans alive: %v, dying: %v, dead: %v", alive, dying, dead)
 
-	if err := processAliveVolumePlans(ctx, alive); err != nil {
-		return err
-	}
+	processAliveVolumePlans(ctx, alive)
 
 	if err := processDyingVolumePlans(ctx, dying); err != nil {
 		return err
@@ -96,50 +93,23 @@ func volumeAttachmentPlansChanged(ctx *context, watcherIds []watcher.MachineStor
 	return nil
 }
 
-func processAliveVolumePlans(ctx *context, volumePlans []params.VolumeAttachmentPlanResult) error {
-	volumeAttachmentPlans := make([]params.VolumeAttachmentPlan, len(volumePlans))
-	volumeTags := make([]names.VolumeTag, len(volumePlans))
+func processAliveVolumePlans(ctx *context, volumePlans []params.VolumeAttachmentPlanResult) {
+	ops := make([]scheduleOp, len(volumePlans))
 	for i, val := range volumePlans {
-		volumeAttachmentPlans[i] = val.Result
-		tag, err := names.ParseVolumeTag(val.Result.VolumeTag)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		volumeTags[i] = tag
-	}
-
-	for idx, val := range volumeAttachmentPlans {
-		volPlan, err := plans.PlanByType(val.PlanInfo.DeviceType)
-		if err != nil {
-			if !errors.IsNotFound(err) {
-				return errors.Trace(err)
-			}
-			continue
-		}
-		if blockDeviceInfo, err := volPlan.AttachVolume(val.PlanInfo.DeviceAttributes); err != nil {
-			return errors.Trace(err)
-		} else {
-			volumeAttachmentPlans[idx].BlockDevice = blockDeviceInfo
-		}
-	}
-
-	results, err := ctx.config.Volumes.SetVolumeAttachmentPlanBlockInfo(volumeAttachmentPlans)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	for _, result := range results {
-		if result.Error != nil {
-			return errors.Errorf("failed to publish block info to state: %s", result.Error)
-		}
+		op := &attachVolumePlanOp{plan: val.Result}
+		// Replace any retry scheduled for an earlier version of the plan.
+		unscheduleOperation(ctx, op.key())
+		ops[i] = op
 	}
-	_, err = refreshVolumeBlockDevices(ctx, volumeTags)
-	return err
+	scheduleOperations(ctx, ops...)
 }
 
 func processDyingVolumePlans(ctx *context, volumePlans []params.VolumeAttachmentPlanResult) error {
 	ids := volumePlansToMachineIds(volumePlans)
 	for _, val := range volumePlans {
-		volPlan, err := plans.PlanByType(val.Result.PlanInfo.DeviceType)
+		// Stop retrying the host-side steps of the attachment.
+		unscheduleOperation(ctx, (&attachVolumePlanOp{plan: val.Result}).key())
+		volPlan, err := ctx.config.Plans.PlanByType(val.Result.PlanInfo.DeviceType)
 		if err != nil {
 			if !errors.IsNotFound(err) {
 				return errors.Trace(err)
@@ -269,7 +239,7 @@ func updatePendingVolume(ctx *context, params storage.VolumeParams) {
 // incomplete set and/or the schedule if it exists there.
 func removePendingVolume(ctx *context, tag names.VolumeTag) {
 	delete(ctx.incompleteVolumeParams, tag)
-	ctx.schedule.Remove(tag)
+	unscheduleOperation(ctx, tag)
 }
 
 // updatePendingVolumeAttachment adds the given volume attachment params to
@@ -296,7 +266,7 @@ func updatePendingVolumeAttachment(
 // there.
 func removePendingVolumeAttachment(ctx *context, id params.MachineStorageId) {
 	delete(ctx.incompleteVolumeAttachmentParams, id)
-	ctx.schedule.Remove(id)
+	unscheduleOperation(ctx, id)
 }
 
 // processDeadVolumes processes the VolumeResults for Dead volumes,
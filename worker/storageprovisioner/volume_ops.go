@@ -249,6 +249,69 @@ func volumeAttachmentPlanFromAttachment(attachment storage.VolumeAttachment) par
 	}
 }
 
+// attachVolumePlans performs the host-side steps of the volume attachment
+// plans, such as logging into an iSCSI target, and records the resulting
+// block device details. If the steps fail for a plan, the volume's status
+// records the failure and the plan is rescheduled.
+func attachVolumePlans(ctx *context, ops []*attachVolumePlanOp) error {
+	var (
+		volumeAttachmentPlans []params.VolumeAttachmentPlan
+		volumeTags            []names.VolumeTag
+		statuses              []params.EntityStatusArgs
+		reschedule            []scheduleOp
+	)
+	for _, op := range ops {
+		plan := op.plan
+		tag, err := names.ParseVolumeTag(plan.VolumeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volPlan, err := ctx.config.Plans.PlanByType(plan.PlanInfo.DeviceType)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if err == nil {
+			blockDeviceInfo, err := volPlan.AttachVolume(plan.PlanInfo.DeviceAttributes)
+			if err != nil {
+				reschedule = append(reschedule, op)
+
+				// Note: we keep the status as "attaching" to
+				// indicate that we will retry.
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    plan.VolumeTag,
+					Status: status.Attaching.String(),
+					Info:   err.Error(),
+				})
+				ctx.config.Logger.Debugf(
+					"failed to attach %s to %s on host: %v",
+					names.ReadableString(tag), plan.MachineTag, err,
+				)
+				continue
+			}
+			plan.BlockDevice = blockDeviceInfo
+		}
+		volumeAttachmentPlans = append(volumeAttachmentPlans, plan)
+		volumeTags = append(volumeTags, tag)
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(volumeAttachmentPlans) == 0 {
+		return nil
+	}
+
+	results, err := ctx.config.Volumes.SetVolumeAttachmentPlanBlockInfo(volumeAttachmentPlans)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return errors.Errorf("failed to publish block info to state: %s", result.Error)
+		}
+	}
+	_, err = refreshVolumeBlockDevices(ctx, volumeTags)
+	return err
+}
+
 // removeVolumes destroys or releases volumes with the specified parameters.
 func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error {
 	tags := make([]names.VolumeTag, 0, len(ops))
@@ -572,6 +635,23 @@ func (op *attachVolumeOp) key() interface{} {
 	}
 }
 
+type attachVolumePlanOp struct {
+	exponentialBackoff
+	plan params.VolumeAttachmentPlan
+}
+
+// volumeAttachmentPlanId identifies a volume attachment plan in the
+// schedule. It is distinct from params.MachineStorageId, so that the
+// plan's host-side steps may be scheduled alongside the attachment.
+type volumeAttachmentPlanId params.MachineStorageId
+
+func (op *attachVolumePlanOp) key() interface{} {
+	return volumeAttachmentPlanId{
+		MachineTag:    op.plan.MachineTag,
+		AttachmentTag: op.plan.VolumeTag,
+	}
+}
+
 type detachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams